@@ -0,0 +1,48 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"kubevirt.io/client-go/log"
+	exportserver "kubevirt.io/kubevirt/pkg/virt-exportserver"
+)
+
+func main() {
+	server := &exportserver.ExportServer{}
+
+	pflag.StringVar(&server.ListenAddr, "listen", ":8443", "Address to serve the export on")
+	pflag.StringVar(&server.CertFile, "cert-file", "", "TLS certificate of the export server")
+	pflag.StringVar(&server.KeyFile, "key-file", "", "TLS key of the export server")
+	pflag.StringVar(&server.TokenFile, "token-file", "", "File holding the token clients have to present")
+	pflag.StringVar(&server.ManifestFile, "manifest-file", "", "File holding the VirtualMachine manifest to serve")
+	pflag.StringToStringVar(&server.Volumes, "volume", map[string]string{}, "Volume to serve as name=path, can be repeated")
+	pflag.Parse()
+
+	log.InitializeLogging("virt-exportserver")
+
+	if err := server.Run(); err != nil {
+		log.Log.Reason(err).Error("Export server failed")
+		os.Exit(1)
+	}
+}
@@ -60,6 +60,7 @@ bazel run \
     libvirt-daemon-driver-storage-core-${LIBVIRT_VERSION} \
     qemu-kvm-${QEMU_VERSION} \
     seabios-${SEABIOS_VERSION} \
+    swtpm-tools \
     genisoimage \
     selinux-policy selinux-policy-targeted \
     nftables \
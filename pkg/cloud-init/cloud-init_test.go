@@ -554,4 +554,62 @@ var _ = Describe("CloudInit", func() {
 		})
 
 	})
+	Describe("GenerateStaticNetworkData", func() {
+		It("should render static and DHCP interfaces", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				*v1.DefaultBridgeNetworkInterface(),
+				{
+					Name:                   "blue",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+					IPAM: &v1.InterfaceIPAM{
+						Addresses:   []string{"192.168.100.10/24", "fd10:100::10/64"},
+						Gateway:     "192.168.100.1",
+						GatewayIPv6: "fd10:100::1",
+						Routes:      []v1.InterfaceRoute{{Destination: "10.10.0.0/16", Gateway: "192.168.100.254"}},
+						DNS:         &v1.InterfaceDNS{Nameservers: []string{"192.168.100.2"}, Search: []string{"example.com"}},
+					},
+				},
+				{
+					Name:                   "sriov",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}},
+				},
+			}
+			Expect(HasStaticIPAM(vmi)).To(BeTrue())
+
+			networkData, err := GenerateStaticNetworkData(vmi, map[string]string{
+				"default": "02:00:00:00:00:01",
+				"blue":    "02:00:00:00:00:02",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networkData).To(Equal(`ethernets:
+  blue:
+    addresses:
+    - 192.168.100.10/24
+    - fd10:100::10/64
+    gateway4: 192.168.100.1
+    gateway6: fd10:100::1
+    match:
+      macaddress: "02:00:00:00:00:02"
+    nameservers:
+      addresses:
+      - 192.168.100.2
+      search:
+      - example.com
+    routes:
+    - to: 10.10.0.0/16
+      via: 192.168.100.254
+  default:
+    dhcp4: true
+    match:
+      macaddress: "02:00:00:00:00:01"
+version: 2
+`))
+		})
+		It("should not detect static IPAM without configured interfaces", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			Expect(HasStaticIPAM(vmi)).To(BeFalse())
+		})
+	})
 })
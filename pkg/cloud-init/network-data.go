@@ -0,0 +1,111 @@
+/*
+ * This file is part of the kubevirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package cloudinit
+
+import (
+	"sigs.k8s.io/yaml"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// networkConfig is the version 2 (netplan) format of the NoCloud network-config
+type networkConfig struct {
+	Version   int                              `json:"version"`
+	Ethernets map[string]networkConfigEthernet `json:"ethernets"`
+}
+
+type networkConfigEthernet struct {
+	Match       networkConfigMatch        `json:"match"`
+	DHCP4       bool                      `json:"dhcp4,omitempty"`
+	Addresses   []string                  `json:"addresses,omitempty"`
+	Gateway4    string                    `json:"gateway4,omitempty"`
+	Gateway6    string                    `json:"gateway6,omitempty"`
+	Routes      []networkConfigRoute      `json:"routes,omitempty"`
+	Nameservers *networkConfigNameservers `json:"nameservers,omitempty"`
+}
+
+type networkConfigMatch struct {
+	MACAddress string `json:"macaddress"`
+}
+
+type networkConfigRoute struct {
+	To  string `json:"to"`
+	Via string `json:"via"`
+}
+
+type networkConfigNameservers struct {
+	Addresses []string `json:"addresses,omitempty"`
+	Search    []string `json:"search,omitempty"`
+}
+
+// HasStaticIPAM returns true if at least one interface of the VMI has a static IPAM configuration
+func HasStaticIPAM(vmi *v1.VirtualMachineInstance) bool {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.IPAM != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateStaticNetworkData renders the network-config for the interfaces of the VMI.
+// Interfaces with a static IPAM configuration get their addresses, all other interfaces
+// with a known MAC address use DHCPv4, as they would without a network-config.
+// The MAC addresses are indexed by interface name.
+func GenerateStaticNetworkData(vmi *v1.VirtualMachineInstance, macs map[string]string) (string, error) {
+	config := networkConfig{
+		Version:   2,
+		Ethernets: map[string]networkConfigEthernet{},
+	}
+
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		mac, exists := macs[iface.Name]
+		if !exists || mac == "" {
+			continue
+		}
+
+		ethernet := networkConfigEthernet{Match: networkConfigMatch{MACAddress: mac}}
+		if iface.IPAM == nil {
+			ethernet.DHCP4 = true
+			config.Ethernets[iface.Name] = ethernet
+			continue
+		}
+
+		ethernet.Addresses = iface.IPAM.Addresses
+		ethernet.Gateway4 = iface.IPAM.Gateway
+		ethernet.Gateway6 = iface.IPAM.GatewayIPv6
+		for _, route := range iface.IPAM.Routes {
+			ethernet.Routes = append(ethernet.Routes, networkConfigRoute{To: route.Destination, Via: route.Gateway})
+		}
+		if iface.IPAM.DNS != nil {
+			ethernet.Nameservers = &networkConfigNameservers{
+				Addresses: iface.IPAM.DNS.Nameservers,
+				Search:    iface.IPAM.DNS.Search,
+			}
+		}
+		config.Ethernets[iface.Name] = ethernet
+	}
+
+	networkData, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(networkData), nil
+}
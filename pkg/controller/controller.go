@@ -228,6 +228,62 @@ func ApplyVolumeRequestOnVMISpec(vmiSpec *v1.VirtualMachineInstanceSpec, request
 	return vmiSpec
 }
 
+func ApplyInterfaceRequestOnVMISpec(vmiSpec *v1.VirtualMachineInstanceSpec, request *v1.VirtualMachineInterfaceRequest) *v1.VirtualMachineInstanceSpec {
+	if request.AddInterfaceOptions != nil {
+		name := request.AddInterfaceOptions.Name
+		alreadyAdded := false
+		for _, network := range vmiSpec.Networks {
+			if network.Name == name {
+				alreadyAdded = true
+				break
+			}
+		}
+
+		if !alreadyAdded {
+			vmiSpec.Networks = append(vmiSpec.Networks, v1.Network{
+				Name: name,
+				NetworkSource: v1.NetworkSource{
+					Multus: &v1.MultusNetwork{
+						NetworkName: request.AddInterfaceOptions.NetworkAttachmentDefinitionName,
+					},
+				},
+			})
+			vmiSpec.Domain.Devices.Interfaces = append(vmiSpec.Domain.Devices.Interfaces, v1.Interface{
+				Name: name,
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Bridge: &v1.InterfaceBridge{},
+				},
+			})
+		}
+
+	} else if request.RemoveInterfaceOptions != nil {
+
+		newNetworksList := []v1.Network{}
+		newInterfacesList := []v1.Interface{}
+
+		for _, network := range vmiSpec.Networks {
+			if network.Name != request.RemoveInterfaceOptions.Name {
+				newNetworksList = append(newNetworksList, network)
+			}
+		}
+
+		for _, iface := range vmiSpec.Domain.Devices.Interfaces {
+			if iface.Name != request.RemoveInterfaceOptions.Name {
+				newInterfacesList = append(newInterfacesList, iface)
+			}
+		}
+
+		if len(newNetworksList) != len(vmiSpec.Networks) {
+			vmiSpec.Networks = newNetworksList
+		}
+		if len(newInterfacesList) != len(vmiSpec.Domain.Devices.Interfaces) {
+			vmiSpec.Domain.Devices.Interfaces = newInterfacesList
+		}
+	}
+
+	return vmiSpec
+}
+
 func CurrentVMIPod(vmi *v1.VirtualMachineInstance, podInformer cache.SharedIndexInformer) (*k8sv1.Pod, error) {
 
 	// current pod is the most recent pod created on the current VMI node
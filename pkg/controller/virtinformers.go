@@ -50,11 +50,15 @@ import (
 	aggregatorclient "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 
 	kubev1 "kubevirt.io/client-go/api/v1"
+	backupv1alpha1 "kubevirt.io/client-go/apis/backup/v1alpha1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 )
 
 const (
@@ -107,6 +111,15 @@ type KubeInformerFactory interface {
 	// Watches VirtualMachineRestore objects
 	VirtualMachineRestore() cache.SharedIndexInformer
 
+	// Watches VirtualMachineExport objects
+	VirtualMachineExport() cache.SharedIndexInformer
+
+	// Watches VirtualMachineClone objects
+	VirtualMachineClone() cache.SharedIndexInformer
+
+	// Watches VirtualMachineBackup objects
+	VirtualMachineBackup() cache.SharedIndexInformer
+
 	// Watches for k8s extensions api configmap
 	ApiAuthConfigMap() cache.SharedIndexInformer
 
@@ -306,6 +319,7 @@ func (f *kubeInformerFactory) VMI() cache.SharedIndexInformer {
 			"node": func(obj interface{}) (strings []string, e error) {
 				return []string{obj.(*kubev1.VirtualMachineInstance).Status.NodeName}, nil
 			},
+			macpool.MacAddressIndex: macpool.IndexByMacAddress,
 		})
 	})
 }
@@ -388,7 +402,9 @@ func (f *kubeInformerFactory) KubeVirtNode() cache.SharedIndexInformer {
 func (f *kubeInformerFactory) VirtualMachine() cache.SharedIndexInformer {
 	return f.getInformer("vmInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.restClient, "virtualmachines", k8sv1.NamespaceAll, fields.Everything())
-		return cache.NewSharedIndexInformer(lw, &kubev1.VirtualMachine{}, f.defaultResync, cache.Indexers{})
+		return cache.NewSharedIndexInformer(lw, &kubev1.VirtualMachine{}, f.defaultResync, cache.Indexers{
+			macpool.MacAddressIndex: macpool.IndexByMacAddress,
+		})
 	})
 }
 
@@ -471,6 +487,59 @@ func (f *kubeInformerFactory) VirtualMachineRestore() cache.SharedIndexInformer
 	})
 }
 
+func (f *kubeInformerFactory) VirtualMachineExport() cache.SharedIndexInformer {
+	return f.getInformer("vmExportInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().ExportV1alpha1().RESTClient(), "virtualmachineexports", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &exportv1alpha1.VirtualMachineExport{}, f.defaultResync, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+			"vm": func(obj interface{}) ([]string, error) {
+				vmExport, ok := obj.(*exportv1alpha1.VirtualMachineExport)
+				if !ok {
+					return nil, unexpectedObjectError
+				}
+
+				source := vmExport.Spec.Source
+				if source.APIGroup != nil && *source.APIGroup == kubev1.GroupName && source.Kind == "VirtualMachine" {
+					return []string{fmt.Sprintf("%s/%s", vmExport.Namespace, source.Name)}, nil
+				}
+
+				return nil, nil
+			},
+			"pvc": func(obj interface{}) ([]string, error) {
+				vmExport, ok := obj.(*exportv1alpha1.VirtualMachineExport)
+				if !ok {
+					return nil, unexpectedObjectError
+				}
+
+				source := vmExport.Spec.Source
+				if (source.APIGroup == nil || *source.APIGroup == "") && source.Kind == "PersistentVolumeClaim" {
+					return []string{fmt.Sprintf("%s/%s", vmExport.Namespace, source.Name)}, nil
+				}
+
+				return nil, nil
+			},
+		})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineClone() cache.SharedIndexInformer {
+	return f.getInformer("vmCloneInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().CloneV1alpha1().RESTClient(), "virtualmachineclones", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &clonev1alpha1.VirtualMachineClone{}, f.defaultResync, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineBackup() cache.SharedIndexInformer {
+	return f.getInformer("vmBackupInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().BackupV1alpha1().RESTClient(), "virtualmachinebackups", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &backupv1alpha1.VirtualMachineBackup{}, f.defaultResync, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		})
+	})
+}
+
 func (f *kubeInformerFactory) DataVolume() cache.SharedIndexInformer {
 	return f.getInformer("dataVolumeInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.clientSet.CdiClient().CdiV1alpha1().RESTClient(), "datavolumes", k8sv1.NamespaceAll, fields.Everything())
@@ -20,6 +20,8 @@ It has these top-level messages:
 	GuestInfoResponse
 	GuestUserListResponse
 	GuestFilesystemsResponse
+	FreezeRequest
+	BackupRequest
 */
 package v1
 
@@ -111,9 +113,11 @@ func (m *SMBios) GetFamily() string {
 }
 
 type VirtualMachineOptions struct {
-	VirtualMachineSMBios  *SMBios  `protobuf:"bytes,1,opt,name=VirtualMachineSMBios" json:"VirtualMachineSMBios,omitempty"`
-	MemBalloonStatsPeriod uint32   `protobuf:"varint,2,opt,name=MemBalloonStatsPeriod" json:"MemBalloonStatsPeriod,omitempty"`
-	PreallocatedVolumes   []string `protobuf:"bytes,3,rep,name=PreallocatedVolumes" json:"PreallocatedVolumes,omitempty"`
+	VirtualMachineSMBios     *SMBios  `protobuf:"bytes,1,opt,name=VirtualMachineSMBios" json:"VirtualMachineSMBios,omitempty"`
+	MemBalloonStatsPeriod    uint32   `protobuf:"varint,2,opt,name=MemBalloonStatsPeriod" json:"MemBalloonStatsPeriod,omitempty"`
+	PreallocatedVolumes      []string `protobuf:"bytes,3,rep,name=PreallocatedVolumes" json:"PreallocatedVolumes,omitempty"`
+	ExpandDisksEnabled       bool     `protobuf:"varint,4,opt,name=ExpandDisksEnabled" json:"ExpandDisksEnabled,omitempty"`
+	IncrementalBackupEnabled bool     `protobuf:"varint,5,opt,name=IncrementalBackupEnabled" json:"IncrementalBackupEnabled,omitempty"`
 }
 
 func (m *VirtualMachineOptions) Reset()                    { *m = VirtualMachineOptions{} }
@@ -142,6 +146,20 @@ func (m *VirtualMachineOptions) GetPreallocatedVolumes() []string {
 	return nil
 }
 
+func (m *VirtualMachineOptions) GetExpandDisksEnabled() bool {
+	if m != nil {
+		return m.ExpandDisksEnabled
+	}
+	return false
+}
+
+func (m *VirtualMachineOptions) GetIncrementalBackupEnabled() bool {
+	if m != nil {
+		return m.IncrementalBackupEnabled
+	}
+	return false
+}
+
 type VMIRequest struct {
 	Vmi     *VMI                   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
 	Options *VirtualMachineOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
@@ -342,6 +360,54 @@ func (m *GuestFilesystemsResponse) GetGuestFilesystemsResponse() string {
 	return ""
 }
 
+type FreezeRequest struct {
+	Vmi                    *VMI  `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	UnfreezeTimeoutSeconds int32 `protobuf:"varint,2,opt,name=unfreezeTimeoutSeconds" json:"unfreezeTimeoutSeconds,omitempty"`
+}
+
+func (m *FreezeRequest) Reset()                    { *m = FreezeRequest{} }
+func (m *FreezeRequest) String() string            { return proto.CompactTextString(m) }
+func (*FreezeRequest) ProtoMessage()               {}
+func (*FreezeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *FreezeRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *FreezeRequest) GetUnfreezeTimeoutSeconds() int32 {
+	if m != nil {
+		return m.UnfreezeTimeoutSeconds
+	}
+	return 0
+}
+
+type BackupRequest struct {
+	Vmi     *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Options []byte `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *BackupRequest) Reset()                    { *m = BackupRequest{} }
+func (m *BackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()               {}
+func (*BackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *BackupRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *BackupRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
 	proto.RegisterType((*SMBios)(nil), "kubevirt.cmd.v1.SMBios")
@@ -355,6 +421,8 @@ func init() {
 	proto.RegisterType((*GuestInfoResponse)(nil), "kubevirt.cmd.v1.GuestInfoResponse")
 	proto.RegisterType((*GuestUserListResponse)(nil), "kubevirt.cmd.v1.GuestUserListResponse")
 	proto.RegisterType((*GuestFilesystemsResponse)(nil), "kubevirt.cmd.v1.GuestFilesystemsResponse")
+	proto.RegisterType((*FreezeRequest)(nil), "kubevirt.cmd.v1.FreezeRequest")
+	proto.RegisterType((*BackupRequest)(nil), "kubevirt.cmd.v1.BackupRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -371,6 +439,10 @@ type CmdClient interface {
 	SyncVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	PauseVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	UnpauseVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	FreezeVirtualMachine(ctx context.Context, in *FreezeRequest, opts ...grpc.CallOption) (*Response, error)
+	UnfreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	BackupVirtualMachine(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error)
+	EndBackupVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	ShutdownVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	KillVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	DeleteVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
@@ -421,6 +493,42 @@ func (c *cmdClient) UnpauseVirtualMachine(ctx context.Context, in *VMIRequest, o
 	return out, nil
 }
 
+func (c *cmdClient) FreezeVirtualMachine(ctx context.Context, in *FreezeRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/FreezeVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) UnfreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/UnfreezeVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) BackupVirtualMachine(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/BackupVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) EndBackupVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/EndBackupVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cmdClient) ShutdownVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/ShutdownVirtualMachine", in, out, c.cc, opts...)
@@ -544,6 +652,10 @@ type CmdServer interface {
 	SyncVirtualMachine(context.Context, *VMIRequest) (*Response, error)
 	PauseVirtualMachine(context.Context, *VMIRequest) (*Response, error)
 	UnpauseVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	FreezeVirtualMachine(context.Context, *FreezeRequest) (*Response, error)
+	UnfreezeVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	BackupVirtualMachine(context.Context, *BackupRequest) (*Response, error)
+	EndBackupVirtualMachine(context.Context, *VMIRequest) (*Response, error)
 	ShutdownVirtualMachine(context.Context, *VMIRequest) (*Response, error)
 	KillVirtualMachine(context.Context, *VMIRequest) (*Response, error)
 	DeleteVirtualMachine(context.Context, *VMIRequest) (*Response, error)
@@ -617,6 +729,78 @@ func _Cmd_UnpauseVirtualMachine_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_FreezeVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).FreezeVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/FreezeVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).FreezeVirtualMachine(ctx, req.(*FreezeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_UnfreezeVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).UnfreezeVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/UnfreezeVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).UnfreezeVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_BackupVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).BackupVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/BackupVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).BackupVirtualMachine(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_EndBackupVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).EndBackupVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/EndBackupVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).EndBackupVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Cmd_ShutdownVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(VMIRequest)
 	if err := dec(in); err != nil {
@@ -867,6 +1051,22 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UnpauseVirtualMachine",
 			Handler:    _Cmd_UnpauseVirtualMachine_Handler,
 		},
+		{
+			MethodName: "FreezeVirtualMachine",
+			Handler:    _Cmd_FreezeVirtualMachine_Handler,
+		},
+		{
+			MethodName: "UnfreezeVirtualMachine",
+			Handler:    _Cmd_UnfreezeVirtualMachine_Handler,
+		},
+		{
+			MethodName: "BackupVirtualMachine",
+			Handler:    _Cmd_BackupVirtualMachine_Handler,
+		},
+		{
+			MethodName: "EndBackupVirtualMachine",
+			Handler:    _Cmd_EndBackupVirtualMachine_Handler,
+		},
 		{
 			MethodName: "ShutdownVirtualMachine",
 			Handler:    _Cmd_ShutdownVirtualMachine_Handler,
@@ -927,53 +1127,61 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 764 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x96, 0x5d, 0x4f, 0xdb, 0x48,
-	0x14, 0x86, 0x13, 0xc2, 0x42, 0x38, 0x64, 0x59, 0x18, 0x08, 0xeb, 0x65, 0x85, 0x60, 0x47, 0x2b,
-	0x04, 0xd2, 0x12, 0x16, 0x4a, 0x6f, 0x7a, 0x51, 0x55, 0x81, 0x12, 0x51, 0x1a, 0x48, 0x1d, 0x48,
-	0xd5, 0x0f, 0xa9, 0x1a, 0xec, 0xc1, 0x19, 0x61, 0xcf, 0xa4, 0x9e, 0x71, 0xda, 0xf4, 0xba, 0x57,
-	0x95, 0xfa, 0xb3, 0x7a, 0xd7, 0x1f, 0x55, 0x79, 0xec, 0x04, 0x12, 0x3b, 0x8d, 0xaa, 0xe4, 0x2a,
-	0x3e, 0x73, 0xce, 0x3c, 0xe7, 0x9d, 0x0f, 0xbf, 0x31, 0xec, 0xb4, 0x6e, 0x9d, 0xbd, 0x26, 0xe1,
-	0xb6, 0x4b, 0xfd, 0x5d, 0x97, 0x04, 0xdc, 0x6a, 0x52, 0x7f, 0xd7, 0x12, 0xde, 0x9e, 0xe5, 0xd9,
-	0x7b, 0xed, 0xfd, 0xf0, 0xa7, 0xd4, 0xf2, 0x85, 0x12, 0xe8, 0x8f, 0xdb, 0xe0, 0x9a, 0xb6, 0x99,
-	0xaf, 0x4a, 0xe1, 0x58, 0x7b, 0x1f, 0x6f, 0x40, 0xae, 0x51, 0x3d, 0x45, 0x06, 0xcc, 0xb6, 0x3d,
-	0xf6, 0x4c, 0x0a, 0x6e, 0x64, 0x37, 0xb3, 0xdb, 0x05, 0xb3, 0x1b, 0xe2, 0x2f, 0x59, 0x98, 0xa9,
-	0x57, 0xcb, 0x4c, 0x48, 0x84, 0xa1, 0xe0, 0x11, 0x1e, 0xdc, 0x10, 0x4b, 0x05, 0x3e, 0xf5, 0x75,
-	0xe5, 0x9c, 0xd9, 0x37, 0x16, 0x82, 0x5a, 0xbe, 0xb0, 0x03, 0x4b, 0x19, 0x53, 0x3a, 0xdd, 0x0d,
-	0x75, 0x0b, 0xea, 0x4b, 0x26, 0xb8, 0x91, 0x8b, 0x32, 0x71, 0x88, 0x16, 0x21, 0x27, 0x6f, 0x03,
-	0x63, 0x5a, 0x8f, 0x86, 0x8f, 0x68, 0x15, 0x66, 0x6e, 0x88, 0xc7, 0xdc, 0x8e, 0xf1, 0x9b, 0x1e,
-	0x8c, 0x23, 0xfc, 0x3d, 0x0b, 0xc5, 0x06, 0xf3, 0x55, 0x40, 0xdc, 0x2a, 0xb1, 0x9a, 0x8c, 0xd3,
-	0x8b, 0x96, 0x62, 0x82, 0x4b, 0x74, 0x06, 0x2b, 0xfd, 0x89, 0x48, 0xb3, 0xd6, 0x38, 0x7f, 0xf0,
-	0x67, 0x69, 0x60, 0xdd, 0xa5, 0x28, 0x6d, 0xa6, 0x4e, 0x42, 0x87, 0x50, 0xac, 0x52, 0xaf, 0x4c,
-	0x5c, 0x57, 0x08, 0x5e, 0x57, 0x44, 0xc9, 0x1a, 0xf5, 0x99, 0xb0, 0xf5, 0x92, 0x7e, 0x37, 0xd3,
-	0x93, 0xe8, 0x7f, 0x58, 0xae, 0xf9, 0x34, 0x1c, 0xb7, 0x88, 0xa2, 0x76, 0x43, 0xb8, 0x81, 0x47,
-	0xa5, 0x91, 0xdb, 0xcc, 0x6d, 0xcf, 0x99, 0x69, 0x29, 0xdc, 0x06, 0x68, 0x54, 0x4f, 0x4d, 0xfa,
-	0x3e, 0xa0, 0x52, 0xa1, 0x2d, 0xc8, 0xb5, 0x3d, 0x16, 0x2b, 0x5e, 0x49, 0x28, 0x0e, 0x2b, 0xc3,
-	0x02, 0xf4, 0x04, 0x66, 0x45, 0xb4, 0x6a, 0xad, 0x67, 0xfe, 0x60, 0x2b, 0x59, 0x9b, 0xb6, 0x47,
-	0x66, 0x77, 0x1a, 0xbe, 0x84, 0xc5, 0x2a, 0x73, 0x7c, 0x12, 0x46, 0xbf, 0xda, 0xdd, 0xe8, 0xef,
-	0x5e, 0xb8, 0xa3, 0x2e, 0x40, 0xe1, 0xa9, 0xd7, 0x52, 0x9d, 0x98, 0x88, 0x1f, 0x43, 0xde, 0xa4,
-	0xb2, 0x25, 0xb8, 0xa4, 0xe1, 0x2c, 0x19, 0x58, 0x16, 0x95, 0xd1, 0x89, 0xe4, 0xcd, 0x6e, 0x18,
-	0x66, 0x3c, 0x2a, 0x25, 0x71, 0x68, 0xf7, 0xc2, 0xc4, 0x21, 0x7e, 0x07, 0x0b, 0xc7, 0xc2, 0x23,
-	0x8c, 0xf7, 0x28, 0x0f, 0x21, 0xef, 0xc7, 0xcf, 0xb1, 0xd0, 0xbf, 0x12, 0x42, 0xbb, 0xc5, 0x66,
-	0xaf, 0x34, 0xbc, 0x4d, 0xb6, 0x06, 0xc5, 0x1d, 0xe2, 0x08, 0x73, 0x58, 0x8e, 0x1a, 0xe8, 0x53,
-	0x1c, 0xb7, 0xcb, 0x26, 0xcc, 0xdb, 0x77, 0xb4, 0xb8, 0xd5, 0xfd, 0x21, 0xfc, 0x11, 0x96, 0x2a,
-	0xe1, 0xce, 0x9c, 0xf2, 0x1b, 0x31, 0x6e, 0xb7, 0xff, 0x60, 0xc9, 0x19, 0x64, 0xc5, 0x3d, 0x93,
-	0x09, 0xfc, 0x39, 0x0b, 0x45, 0xdd, 0xfa, 0x4a, 0x52, 0xff, 0x39, 0x93, 0x6a, 0xdc, 0xf6, 0x87,
-	0x50, 0x74, 0xd2, 0x78, 0xb1, 0x84, 0xf4, 0x24, 0xfe, 0x9a, 0x05, 0x43, 0xcb, 0x38, 0x61, 0x2e,
-	0x95, 0x1d, 0xa9, 0xa8, 0x37, 0xf6, 0xb6, 0x3f, 0x02, 0xc3, 0x19, 0x82, 0x8c, 0xc5, 0x0c, 0xcd,
-	0x1f, 0x7c, 0x03, 0xc8, 0x1d, 0x79, 0x36, 0x3a, 0x07, 0x54, 0xef, 0x70, 0xab, 0xff, 0xad, 0x41,
-	0x7f, 0xa7, 0xbe, 0x04, 0xd1, 0xe5, 0x5e, 0x1b, 0xae, 0x0d, 0x67, 0xd0, 0x05, 0x2c, 0xd7, 0x48,
-	0x20, 0xe9, 0xc4, 0x80, 0x2f, 0xa0, 0x78, 0xc5, 0x5b, 0x13, 0x45, 0x9a, 0xb0, 0x5a, 0x6f, 0x06,
-	0xca, 0x16, 0x1f, 0xf8, 0xc4, 0x98, 0xe7, 0x80, 0xce, 0x98, 0xeb, 0x4e, 0x8c, 0x57, 0x83, 0x95,
-	0x63, 0xea, 0x52, 0x35, 0xb9, 0x55, 0xbf, 0x84, 0x62, 0xe4, 0x7c, 0x83, 0xc8, 0x7f, 0x12, 0xb3,
-	0x06, 0x1d, 0x72, 0xe4, 0x91, 0x87, 0x57, 0xa8, 0x37, 0xe9, 0x92, 0xf8, 0x0e, 0x55, 0x63, 0x28,
-	0x7d, 0x05, 0xeb, 0x47, 0x84, 0x5b, 0x74, 0x60, 0x37, 0x7b, 0x0d, 0xc6, 0x40, 0xbf, 0x81, 0x8d,
-	0x13, 0xc6, 0x89, 0xcb, 0x3e, 0xd1, 0xc9, 0xc3, 0xab, 0x30, 0x57, 0xa1, 0x2a, 0xf2, 0x55, 0xb4,
-	0x9e, 0xa8, 0xbc, 0xff, 0x0f, 0xb1, 0xb6, 0x91, 0x48, 0xf7, 0x1b, 0xbe, 0x3e, 0xb0, 0x85, 0x1e,
-	0x4e, 0xbb, 0xe8, 0x28, 0xe6, 0xbf, 0x43, 0x98, 0x7d, 0x1e, 0x8f, 0x33, 0xa8, 0x0e, 0x85, 0x0a,
-	0x55, 0x3d, 0x3f, 0x1e, 0x85, 0xc5, 0x89, 0x74, 0xc2, 0xca, 0x35, 0x34, 0x5f, 0xa1, 0xda, 0xf7,
-	0x46, 0xea, 0xdc, 0x4a, 0x07, 0x26, 0x3c, 0x33, 0x83, 0xde, 0xea, 0x2d, 0xb8, 0xe7, 0x5f, 0xa3,
-	0xd0, 0x3b, 0xe9, 0xe8, 0x14, 0x07, 0xc4, 0x19, 0x54, 0x86, 0xe9, 0x1a, 0xe3, 0xce, 0x28, 0xe6,
-	0xcf, 0xce, 0xbc, 0x3c, 0xfd, 0x7a, 0xaa, 0xbd, 0x7f, 0x3d, 0xa3, 0x3f, 0x31, 0x1f, 0xfc, 0x08,
-	0x00, 0x00, 0xff, 0xff, 0x29, 0xfa, 0xff, 0x33, 0x8f, 0x0a, 0x00, 0x00,
+	// 890 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x97, 0xdf, 0x6f, 0xdb, 0x36,
+	0x10, 0xc7, 0xe3, 0x38, 0x49, 0x9d, 0x8b, 0x93, 0xb5, 0x4c, 0x9c, 0x6a, 0x1d, 0xba, 0x64, 0xc4,
+	0x10, 0xb4, 0xc0, 0xea, 0x2c, 0x59, 0xb7, 0x87, 0x3e, 0x0c, 0x83, 0x9b, 0x1f, 0xc8, 0x3a, 0xb7,
+	0xae, 0x9c, 0x64, 0xd8, 0x0f, 0x60, 0x60, 0xa4, 0xb3, 0x43, 0x58, 0x22, 0x35, 0x92, 0xf2, 0x9a,
+	0x3e, 0xef, 0x69, 0xc0, 0xde, 0xf7, 0xa7, 0xec, 0xcf, 0x1b, 0x44, 0xc9, 0x6e, 0x6c, 0xc9, 0x35,
+	0x3a, 0xfb, 0x29, 0x3a, 0x7e, 0x79, 0x9f, 0x3b, 0xdd, 0x91, 0x39, 0x19, 0x1e, 0x47, 0xbd, 0xee,
+	0xfe, 0x35, 0x13, 0x7e, 0x80, 0xea, 0x49, 0xc0, 0x62, 0xe1, 0x5d, 0xa3, 0x7a, 0xe2, 0xc9, 0x70,
+	0xdf, 0x0b, 0xfd, 0xfd, 0xfe, 0x41, 0xf2, 0xa7, 0x1e, 0x29, 0x69, 0x24, 0xf9, 0xa8, 0x17, 0x5f,
+	0x61, 0x9f, 0x2b, 0x53, 0x4f, 0xd6, 0xfa, 0x07, 0x74, 0x07, 0xca, 0x97, 0xcd, 0x33, 0xe2, 0xc0,
+	0x9d, 0x7e, 0xc8, 0xbf, 0xd7, 0x52, 0x38, 0xa5, 0xdd, 0xd2, 0xa3, 0xaa, 0x3b, 0x30, 0xe9, 0x5f,
+	0x25, 0x58, 0x69, 0x37, 0x1b, 0x5c, 0x6a, 0x42, 0xa1, 0x1a, 0x32, 0x11, 0x77, 0x98, 0x67, 0x62,
+	0x85, 0xca, 0xee, 0x5c, 0x75, 0x47, 0xd6, 0x12, 0x50, 0xa4, 0xa4, 0x1f, 0x7b, 0xc6, 0x59, 0xb4,
+	0xf2, 0xc0, 0xb4, 0x21, 0x50, 0x69, 0x2e, 0x85, 0x53, 0x4e, 0x95, 0xcc, 0x24, 0x77, 0xa1, 0xac,
+	0x7b, 0xb1, 0xb3, 0x64, 0x57, 0x93, 0x47, 0xb2, 0x0d, 0x2b, 0x1d, 0x16, 0xf2, 0xe0, 0xc6, 0x59,
+	0xb6, 0x8b, 0x99, 0x45, 0xff, 0x5d, 0x84, 0xda, 0x25, 0x57, 0x26, 0x66, 0x41, 0x93, 0x79, 0xd7,
+	0x5c, 0xe0, 0xab, 0xc8, 0x70, 0x29, 0x34, 0x79, 0x01, 0x5b, 0xa3, 0x42, 0x9a, 0xb3, 0xcd, 0x71,
+	0xed, 0xf0, 0x7e, 0x7d, 0xec, 0xbd, 0xeb, 0xa9, 0xec, 0x16, 0x3a, 0x91, 0xa7, 0x50, 0x6b, 0x62,
+	0xd8, 0x60, 0x41, 0x20, 0xa5, 0x68, 0x1b, 0x66, 0x74, 0x0b, 0x15, 0x97, 0xbe, 0x7d, 0xa5, 0x75,
+	0xb7, 0x58, 0x24, 0x5f, 0xc2, 0x66, 0x4b, 0x61, 0xb2, 0xee, 0x31, 0x83, 0xfe, 0xa5, 0x0c, 0xe2,
+	0x10, 0xb5, 0x53, 0xde, 0x2d, 0x3f, 0x5a, 0x75, 0x8b, 0x24, 0x52, 0x07, 0x72, 0xfc, 0x26, 0x62,
+	0xc2, 0x3f, 0xe2, 0xba, 0xa7, 0x8f, 0x05, 0xbb, 0x0a, 0xd0, 0xb7, 0x75, 0xa8, 0xb8, 0x05, 0x0a,
+	0x79, 0x06, 0xce, 0x99, 0xf0, 0x14, 0x86, 0x28, 0x0c, 0x0b, 0x1a, 0xcc, 0xeb, 0xc5, 0xd1, 0xc0,
+	0x6b, 0xd9, 0x7a, 0x4d, 0xd4, 0x69, 0x1f, 0xe0, 0xb2, 0x79, 0xe6, 0xe2, 0xef, 0x31, 0x6a, 0x43,
+	0xf6, 0xa0, 0xdc, 0x0f, 0x79, 0x56, 0x9d, 0xad, 0x5c, 0x75, 0x92, 0x9d, 0xc9, 0x06, 0xf2, 0x1d,
+	0xdc, 0x91, 0x69, 0x85, 0xed, 0xbb, 0xaf, 0x1d, 0xee, 0xe5, 0xf7, 0x16, 0xf5, 0xc3, 0x1d, 0xb8,
+	0xd1, 0x73, 0xb8, 0xdb, 0xe4, 0x5d, 0xc5, 0x12, 0xeb, 0x43, 0xa3, 0x3b, 0xa3, 0xd1, 0xab, 0xef,
+	0xa8, 0x1b, 0x50, 0x3d, 0x0e, 0x23, 0x73, 0x93, 0x11, 0xe9, 0xb7, 0x50, 0x71, 0x51, 0x47, 0x52,
+	0x68, 0x4c, 0xbc, 0x74, 0xec, 0x79, 0xa8, 0xd3, 0xee, 0x57, 0xdc, 0x81, 0x99, 0x28, 0x21, 0x6a,
+	0xcd, 0xba, 0x38, 0x38, 0x9c, 0x99, 0x49, 0x7f, 0x83, 0x8d, 0x23, 0x19, 0x32, 0x2e, 0x86, 0x94,
+	0xaf, 0xa1, 0xa2, 0xb2, 0xe7, 0x2c, 0xd1, 0x8f, 0x73, 0x89, 0x0e, 0x36, 0xbb, 0xc3, 0xad, 0xc9,
+	0xc9, 0xf5, 0x2d, 0x28, 0x8b, 0x90, 0x59, 0x54, 0xc0, 0x66, 0x1a, 0xc0, 0x9e, 0x98, 0x59, 0xa3,
+	0xec, 0xc2, 0x9a, 0xff, 0x8e, 0x96, 0x85, 0xba, 0xbd, 0x44, 0xdf, 0xc0, 0xbd, 0xd3, 0xa4, 0x32,
+	0x67, 0xa2, 0x23, 0x67, 0x8d, 0xf6, 0x05, 0xdc, 0xeb, 0x8e, 0xb3, 0xb2, 0x98, 0x79, 0x81, 0xfe,
+	0x59, 0x82, 0x9a, 0x0d, 0x7d, 0xa1, 0x51, 0xfd, 0xc0, 0xb5, 0x99, 0x35, 0xfc, 0x53, 0xa8, 0x75,
+	0x8b, 0x78, 0x59, 0x0a, 0xc5, 0x22, 0xfd, 0xbb, 0x04, 0x8e, 0x4d, 0xe3, 0x84, 0x07, 0xa8, 0x6f,
+	0xb4, 0xc1, 0x70, 0xe6, 0xb2, 0x3f, 0x03, 0xa7, 0x3b, 0x01, 0x99, 0x25, 0x33, 0x51, 0xa7, 0x12,
+	0xd6, 0x4f, 0x14, 0xe2, 0x5b, 0xfc, 0xd0, 0x4b, 0xf0, 0x0d, 0x6c, 0xc7, 0xa2, 0x63, 0x5d, 0xcf,
+	0x79, 0x88, 0x32, 0x36, 0x6d, 0xf4, 0xa4, 0xf0, 0xd3, 0xb6, 0x2f, 0xbb, 0x13, 0x54, 0xfa, 0x1a,
+	0xd6, 0xd3, 0xff, 0x00, 0x73, 0xbb, 0x75, 0x87, 0xff, 0xac, 0x43, 0xf9, 0x79, 0xe8, 0x93, 0x97,
+	0x40, 0xda, 0x37, 0xc2, 0x1b, 0xbd, 0xf9, 0xe4, 0x93, 0x42, 0x64, 0x1a, 0xfc, 0xc1, 0xe4, 0xfa,
+	0xd2, 0x05, 0xf2, 0x0a, 0x36, 0x5b, 0x2c, 0xd6, 0x38, 0x37, 0xe0, 0x6b, 0xa8, 0x5d, 0x88, 0x68,
+	0xae, 0xc8, 0x36, 0x6c, 0xa5, 0xfd, 0x1b, 0x23, 0x7e, 0x9a, 0x73, 0x1a, 0x69, 0xf3, 0xfb, 0xa1,
+	0x2e, 0x6c, 0x5f, 0x88, 0x4e, 0x11, 0x76, 0xa6, 0x44, 0xd3, 0xbe, 0x4f, 0x4d, 0x74, 0xe4, 0x78,
+	0x4c, 0x83, 0xde, 0x3f, 0x16, 0x7e, 0x21, 0xf7, 0xff, 0x67, 0xea, 0xc2, 0x76, 0xfb, 0x3a, 0x36,
+	0xbe, 0xfc, 0x43, 0xcc, 0x8d, 0xf9, 0x12, 0xc8, 0x0b, 0x1e, 0x04, 0x73, 0xe3, 0xb5, 0x60, 0xeb,
+	0x08, 0x03, 0x34, 0xf3, 0xeb, 0xcf, 0x8f, 0x50, 0x4b, 0x07, 0xe2, 0x38, 0xf2, 0xb3, 0x9c, 0xd7,
+	0xf8, 0xe0, 0x9c, 0x7a, 0x8b, 0x92, 0x5b, 0x39, 0x74, 0x3a, 0x67, 0xaa, 0x8b, 0x66, 0x86, 0x4c,
+	0x7f, 0x82, 0x87, 0xcf, 0x99, 0xf0, 0x70, 0xac, 0x9a, 0xc3, 0x00, 0x33, 0xa0, 0x7f, 0x81, 0x9d,
+	0x13, 0x2e, 0x58, 0xc0, 0xdf, 0xe2, 0xfc, 0xe1, 0x4d, 0x58, 0x3d, 0x45, 0x93, 0x8e, 0x5b, 0xf2,
+	0x30, 0xb7, 0xf3, 0xf6, 0x87, 0xc3, 0x83, 0x9d, 0x9c, 0x3c, 0xfa, 0x1d, 0x60, 0x1b, 0xb6, 0x31,
+	0xc4, 0xd9, 0xe1, 0x3a, 0x8d, 0xf9, 0xf9, 0x04, 0xe6, 0xc8, 0xe8, 0xb7, 0x97, 0xaa, 0x7a, 0x8a,
+	0x66, 0x38, 0xa6, 0xa7, 0x61, 0x69, 0x4e, 0xce, 0x4d, 0x78, 0x0b, 0xad, 0x9c, 0xa2, 0x1d, 0x87,
+	0x53, 0xf3, 0xdc, 0x2b, 0x06, 0xe6, 0x46, 0xe9, 0x02, 0xf9, 0xd5, 0x96, 0xe0, 0xd6, 0x58, 0x9b,
+	0x86, 0x7e, 0x5c, 0x8c, 0x2e, 0x1a, 0x8c, 0x0b, 0xa4, 0x01, 0x4b, 0x2d, 0x2e, 0xba, 0xd3, 0x98,
+	0xef, 0xeb, 0x79, 0x63, 0xe9, 0xe7, 0xc5, 0xfe, 0xc1, 0xd5, 0x8a, 0xfd, 0x95, 0xf3, 0xd5, 0x7f,
+	0x03, 0x00, 0xf8, 0x7d, 0x69, 0x41, 0x12, 0x0d, 0x00, 0x00,
 }
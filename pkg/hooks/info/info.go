@@ -21,3 +21,5 @@ package kubevirt_hooks_info
 
 const OnDefineDomainHookPointName = "OnDefineDomain"
 const PreCloudInitIsoHookPointName = "PreCloudInitIso"
+const PlugPhase1HookPointName = "PlugPhase1"
+const PlugPhase2HookPointName = "PlugPhase2"
@@ -25,6 +25,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"sort"
 	"strings"
 	"sync"
@@ -36,6 +37,7 @@ import (
 	hooksInfo "kubevirt.io/kubevirt/pkg/hooks/info"
 	hooksV1alpha1 "kubevirt.io/kubevirt/pkg/hooks/v1alpha1"
 	hooksV1alpha2 "kubevirt.io/kubevirt/pkg/hooks/v1alpha2"
+	hooksV1alpha3 "kubevirt.io/kubevirt/pkg/hooks/v1alpha3"
 	grpcutil "kubevirt.io/kubevirt/pkg/util/net/grpc"
 	virtwrapApi "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
@@ -43,6 +45,7 @@ import (
 type callBackClient struct {
 	SocketPath           string
 	Version              string
+	Name                 string
 	subscribedHookPoints []*hooksInfo.HookPoint
 }
 
@@ -149,22 +152,31 @@ func processSideCarSocket(socketPath string) (*callBackClient, bool, error) {
 		versionsSet[version] = true
 	}
 
-	if _, found := versionsSet[hooksV1alpha2.Version]; found {
+	if _, found := versionsSet[hooksV1alpha3.Version]; found {
+		return &callBackClient{
+			SocketPath:           socketPath,
+			Version:              hooksV1alpha3.Version,
+			Name:                 info.GetName(),
+			subscribedHookPoints: info.GetHookPoints(),
+		}, false, nil
+	} else if _, found := versionsSet[hooksV1alpha2.Version]; found {
 		return &callBackClient{
 			SocketPath:           socketPath,
 			Version:              hooksV1alpha2.Version,
+			Name:                 info.GetName(),
 			subscribedHookPoints: info.GetHookPoints(),
 		}, false, nil
 	} else if _, found := versionsSet[hooksV1alpha1.Version]; found {
 		return &callBackClient{
 			SocketPath:           socketPath,
 			Version:              hooksV1alpha1.Version,
+			Name:                 info.GetName(),
 			subscribedHookPoints: info.GetHookPoints(),
 		}, false, nil
 	} else {
 		return nil, false,
 			fmt.Errorf("Hook sidecar does not expose a supported version. Exposed versions: %v, supported versions: %v",
-				info.GetVersions(), []string{hooksV1alpha1.Version, hooksV1alpha2.Version})
+				info.GetVersions(), []string{hooksV1alpha1.Version, hooksV1alpha2.Version, hooksV1alpha3.Version})
 	}
 }
 
@@ -189,7 +201,7 @@ func (m *Manager) OnDefineDomain(domainSpec *virtwrapApi.DomainSpec, vmi *v1.Vir
 	}
 	if callbacks, found := m.CallbacksPerHookPoint[hooksInfo.OnDefineDomainHookPointName]; found {
 		for _, callback := range callbacks {
-			if callback.Version == hooksV1alpha1.Version || callback.Version == hooksV1alpha2.Version {
+			if callback.Version == hooksV1alpha1.Version || callback.Version == hooksV1alpha2.Version || callback.Version == hooksV1alpha3.Version {
 				vmiJSON, err := json.Marshal(vmi)
 				if err != nil {
 					return "", fmt.Errorf("Failed to marshal VMI spec: %v", vmi)
@@ -226,6 +238,16 @@ func (m *Manager) OnDefineDomain(domainSpec *virtwrapApi.DomainSpec, vmi *v1.Vir
 						return "", err
 					}
 					domainSpecXML = result.GetDomainXML()
+				case hooksV1alpha3.Version:
+					client := hooksV1alpha3.NewCallbacksClient(conn)
+					result, err := client.OnDefineDomain(ctx, &hooksV1alpha3.OnDefineDomainParams{
+						DomainXML: domainSpecXML,
+						Vmi:       vmiJSON,
+					})
+					if err != nil {
+						return "", err
+					}
+					domainSpecXML = result.GetDomainXML()
 				default:
 					panic("Should never happen, version compatibility check is done during Info call")
 				}
@@ -238,7 +260,7 @@ func (m *Manager) OnDefineDomain(domainSpec *virtwrapApi.DomainSpec, vmi *v1.Vir
 func (m *Manager) PreCloudInitIso(vmi *v1.VirtualMachineInstance, cloudInitData *cloudinit.CloudInitData) (*cloudinit.CloudInitData, error) {
 	if callbacks, found := m.CallbacksPerHookPoint[hooksInfo.PreCloudInitIsoHookPointName]; found {
 		for _, callback := range callbacks {
-			if callback.Version == hooksV1alpha2.Version {
+			if callback.Version == hooksV1alpha2.Version || callback.Version == hooksV1alpha3.Version {
 				var resultData *cloudinit.CloudInitData
 				vmiJSON, err := json.Marshal(vmi)
 				if err != nil {
@@ -268,19 +290,35 @@ func (m *Manager) PreCloudInitIso(vmi *v1.VirtualMachineInstance, cloudInitData
 				}
 				defer conn.Close()
 
-				client := hooksV1alpha2.NewCallbacksClient(conn)
 				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 				defer cancel()
-				result, err := client.PreCloudInitIso(ctx, &hooksV1alpha2.PreCloudInitIsoParams{
-					CloudInitData:          cloudInitDataJSON,
-					CloudInitNoCloudSource: cloudInitNoCloudSourceJSON,
-					Vmi:                    vmiJSON,
-				})
-				if err != nil {
-					return cloudInitData, err
+
+				var resultCloudInitData, resultCloudInitNoCloudSource []byte
+				if callback.Version == hooksV1alpha3.Version {
+					client := hooksV1alpha3.NewCallbacksClient(conn)
+					result, err := client.PreCloudInitIso(ctx, &hooksV1alpha3.PreCloudInitIsoParams{
+						CloudInitData:          cloudInitDataJSON,
+						CloudInitNoCloudSource: cloudInitNoCloudSourceJSON,
+						Vmi:                    vmiJSON,
+					})
+					if err != nil {
+						return cloudInitData, err
+					}
+					resultCloudInitData, resultCloudInitNoCloudSource = result.GetCloudInitData(), result.GetCloudInitNoCloudSource()
+				} else {
+					client := hooksV1alpha2.NewCallbacksClient(conn)
+					result, err := client.PreCloudInitIso(ctx, &hooksV1alpha2.PreCloudInitIsoParams{
+						CloudInitData:          cloudInitDataJSON,
+						CloudInitNoCloudSource: cloudInitNoCloudSourceJSON,
+						Vmi:                    vmiJSON,
+					})
+					if err != nil {
+						return cloudInitData, err
+					}
+					resultCloudInitData, resultCloudInitNoCloudSource = result.GetCloudInitData(), result.GetCloudInitNoCloudSource()
 				}
 
-				err = json.Unmarshal(result.GetCloudInitData(), &resultData)
+				err = json.Unmarshal(resultCloudInitData, &resultData)
 				if err != nil {
 					log.Log.Reason(err).Infof("Failed to unmarshal CloudInitData result")
 					return cloudInitData, err
@@ -288,7 +326,7 @@ func (m *Manager) PreCloudInitIso(vmi *v1.VirtualMachineInstance, cloudInitData
 				if !cloudinit.IsValidCloudInitData(resultData) {
 					// Be backwards compatible for hook sidecars still working on CloudInitNoCloudSource objects instead of CloudInitData
 					var resultNoCloudSourceData *v1.CloudInitNoCloudSource
-					err = json.Unmarshal(result.GetCloudInitNoCloudSource(), &resultNoCloudSourceData)
+					err = json.Unmarshal(resultCloudInitNoCloudSource, &resultNoCloudSourceData)
 					if err != nil {
 						log.Log.Reason(err).Infof("Failed to unmarshal CloudInitNoCloudSource result")
 						return cloudInitData, err
@@ -307,3 +345,83 @@ func (m *Manager) PreCloudInitIso(vmi *v1.VirtualMachineInstance, cloudInitData
 	}
 	return cloudInitData, nil
 }
+
+// NewManagerForPID returns a manager of the hook sidecars which the virt-launcher with the given pid already collected.
+// It lets virt-handler reach the sidecars through the root of the virt-launcher process.
+func NewManagerForPID(pid int) (*Manager, error) {
+	m := NewManager(fmt.Sprintf("/proc/%d/root%s", pid, HookSocketsSharedDirectory))
+	sockets, err := ioutil.ReadDir(m.SocketsDir)
+	if os.IsNotExist(err) {
+		// the pod has no hook sidecars
+		return m, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := m.Collect(uint(len(sockets)), time.Minute); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PlugPhase1 delegates phase1 of the network configuration of an interface to the sidecar of its network binding plugin
+func (m *Manager) PlugPhase1(vmi *v1.VirtualMachineInstance, pluginName string, interfaceName string, podInterfaceName string, tapDevice string) error {
+	return m.onPlugPhase(hooksInfo.PlugPhase1HookPointName, pluginName, vmi, func(ctx context.Context, client hooksV1alpha3.CallbacksClient, vmiJSON []byte) error {
+		_, err := client.PlugPhase1(ctx, &hooksV1alpha3.PlugPhase1Params{
+			Vmi:              vmiJSON,
+			InterfaceName:    interfaceName,
+			PodInterfaceName: podInterfaceName,
+			TapDevice:        tapDevice,
+		})
+		return err
+	})
+}
+
+// PlugPhase2 delegates phase2 of the network configuration of an interface to the sidecar of its network binding plugin
+func (m *Manager) PlugPhase2(vmi *v1.VirtualMachineInstance, pluginName string, interfaceName string, podInterfaceName string, tapDevice string) error {
+	return m.onPlugPhase(hooksInfo.PlugPhase2HookPointName, pluginName, vmi, func(ctx context.Context, client hooksV1alpha3.CallbacksClient, vmiJSON []byte) error {
+		_, err := client.PlugPhase2(ctx, &hooksV1alpha3.PlugPhase2Params{
+			Vmi:              vmiJSON,
+			InterfaceName:    interfaceName,
+			PodInterfaceName: podInterfaceName,
+			TapDevice:        tapDevice,
+		})
+		return err
+	})
+}
+
+// onPlugPhase only calls the sidecars whose hook name is the name of the network binding plugin
+func (m *Manager) onPlugPhase(hookPointName string, pluginName string, vmi *v1.VirtualMachineInstance, plug func(context.Context, hooksV1alpha3.CallbacksClient, []byte) error) error {
+	callbacks, found := m.CallbacksPerHookPoint[hookPointName]
+	if !found {
+		return nil
+	}
+	vmiJSON, err := json.Marshal(vmi)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal VMI spec: %v", vmi)
+	}
+	for _, callback := range callbacks {
+		if callback.Name != pluginName {
+			continue
+		}
+		if callback.Version != hooksV1alpha3.Version {
+			return fmt.Errorf("Hook sidecar %s does not support the %s hook point", callback.SocketPath, hookPointName)
+		}
+		if err := callPlugPhase(callback.SocketPath, vmiJSON, plug); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func callPlugPhase(socketPath string, vmiJSON []byte, plug func(context.Context, hooksV1alpha3.CallbacksClient, []byte) error) error {
+	conn, err := grpcutil.DialSocketWithTimeout(socketPath, 1)
+	if err != nil {
+		log.Log.Reason(err).Infof("Failed to Dial hook socket: %s", socketPath)
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return plug(ctx, hooksV1alpha3.NewCallbacksClient(conn), vmiJSON)
+}
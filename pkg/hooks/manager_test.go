@@ -33,9 +33,11 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	hooksInfo "kubevirt.io/kubevirt/pkg/hooks/info"
 	hooksV1alpha1 "kubevirt.io/kubevirt/pkg/hooks/v1alpha1"
+	hooksV1alpha3 "kubevirt.io/kubevirt/pkg/hooks/v1alpha3"
 )
 
 type dynamicInfoServer struct {
@@ -80,6 +82,61 @@ func hookListenAndServe(socketPath string, hookName string, hookPointName string
 	return socket, nil
 }
 
+type plugInfoServer struct {
+	pluginName string
+}
+
+func (s plugInfoServer) Info(ctx context.Context, params *hooksInfo.InfoParams) (*hooksInfo.InfoResult, error) {
+	return &hooksInfo.InfoResult{
+		Name:     s.pluginName,
+		Versions: []string{hooksV1alpha3.Version},
+		HookPoints: []*hooksInfo.HookPoint{
+			{Name: hooksInfo.PlugPhase1HookPointName},
+			{Name: hooksInfo.PlugPhase2HookPointName},
+		},
+	}, nil
+}
+
+type plugCallbacksServer struct {
+	phase1 chan *hooksV1alpha3.PlugPhase1Params
+	phase2 chan *hooksV1alpha3.PlugPhase2Params
+}
+
+func (s plugCallbacksServer) OnDefineDomain(ctx context.Context, params *hooksV1alpha3.OnDefineDomainParams) (*hooksV1alpha3.OnDefineDomainResult, error) {
+	return &hooksV1alpha3.OnDefineDomainResult{DomainXML: params.GetDomainXML()}, nil
+}
+
+func (s plugCallbacksServer) PreCloudInitIso(ctx context.Context, params *hooksV1alpha3.PreCloudInitIsoParams) (*hooksV1alpha3.PreCloudInitIsoResult, error) {
+	return &hooksV1alpha3.PreCloudInitIsoResult{CloudInitData: params.GetCloudInitData()}, nil
+}
+
+func (s plugCallbacksServer) PlugPhase1(ctx context.Context, params *hooksV1alpha3.PlugPhase1Params) (*hooksV1alpha3.PlugPhase1Result, error) {
+	s.phase1 <- params
+	return &hooksV1alpha3.PlugPhase1Result{}, nil
+}
+
+func (s plugCallbacksServer) PlugPhase2(ctx context.Context, params *hooksV1alpha3.PlugPhase2Params) (*hooksV1alpha3.PlugPhase2Result, error) {
+	s.phase2 <- params
+	return &hooksV1alpha3.PlugPhase2Result{}, nil
+}
+
+func plugListenAndServe(socketPath string, pluginName string) (*grpc.Server, plugCallbacksServer, error) {
+	socket, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, plugCallbacksServer{}, err
+	}
+
+	callbacks := plugCallbacksServer{
+		phase1: make(chan *hooksV1alpha3.PlugPhase1Params, 1),
+		phase2: make(chan *hooksV1alpha3.PlugPhase2Params, 1),
+	}
+	server := grpc.NewServer()
+	hooksInfo.RegisterInfoServer(server, plugInfoServer{pluginName: pluginName})
+	hooksV1alpha3.RegisterCallbacksServer(server, callbacks)
+	go server.Serve(socket)
+	return server, callbacks, nil
+}
+
 var _ = Describe("HooksManager", func() {
 	Context("With existing sockets", func() {
 		var socketDir string
@@ -156,6 +213,47 @@ var _ = Describe("HooksManager", func() {
 			}
 		})
 
+		It("Should delegate the plug phases to the sidecar of the network binding plugin only", func() {
+			socketPath := filepath.Join(socketDir, "binding-plugin.sock")
+			server, callbacks, err := plugListenAndServe(socketPath, "binding-plugin")
+			Expect(err).ToNot(HaveOccurred())
+			defer server.Stop()
+			defer os.Remove(socketPath)
+
+			otherSocketPath := filepath.Join(socketDir, "other-plugin.sock")
+			otherServer, otherCallbacks, err := plugListenAndServe(otherSocketPath, "other-plugin")
+			Expect(err).ToNot(HaveOccurred())
+			defer otherServer.Stop()
+			defer os.Remove(otherSocketPath)
+
+			manager := hooks.NewManager(socketDir)
+			Expect(manager.Collect(2, 10*time.Second)).To(Succeed())
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			Expect(manager.PlugPhase1(vmi, "binding-plugin", "default", "eth0", "tap0")).To(Succeed())
+			var phase1 *hooksV1alpha3.PlugPhase1Params
+			Eventually(callbacks.phase1).Should(Receive(&phase1))
+			Expect(phase1.GetInterfaceName()).To(Equal("default"))
+			Expect(phase1.GetPodInterfaceName()).To(Equal("eth0"))
+			Expect(phase1.GetTapDevice()).To(Equal("tap0"))
+			Expect(phase1.GetVmi()).To(ContainSubstring("testvmi"))
+
+			Expect(manager.PlugPhase2(vmi, "binding-plugin", "default", "eth0", "tap0")).To(Succeed())
+			var phase2 *hooksV1alpha3.PlugPhase2Params
+			Eventually(callbacks.phase2).Should(Receive(&phase2))
+			Expect(phase2.GetInterfaceName()).To(Equal("default"))
+			Expect(phase2.GetTapDevice()).To(Equal("tap0"))
+
+			Consistently(otherCallbacks.phase1).ShouldNot(Receive())
+			Consistently(otherCallbacks.phase2).ShouldNot(Receive())
+		})
+
+		It("Should not fail the plug phases without network binding plugin sidecars", func() {
+			manager := hooks.NewManager(socketDir)
+			Expect(manager.PlugPhase1(v1.NewMinimalVMI("testvmi"), "binding-plugin", "default", "eth0", "")).To(Succeed())
+			Expect(manager.PlugPhase2(v1.NewMinimalVMI("testvmi"), "binding-plugin", "default", "eth0", "")).To(Succeed())
+		})
+
 		AfterEach(func() {
 			os.RemoveAll(socketDir)
 		})
@@ -0,0 +1,426 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package instancetype
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+const (
+	SingularResourceName = "virtualmachineinstancetype"
+	PluralResourceName   = SingularResourceName + "s"
+
+	ClusterSingularResourceName = "virtualmachineclusterinstancetype"
+	ClusterPluralResourceName   = ClusterSingularResourceName + "s"
+
+	SingularPreferenceResourceName = "virtualmachinepreference"
+	PluralPreferenceResourceName   = SingularPreferenceResourceName + "s"
+
+	ClusterSingularPreferenceResourceName = "virtualmachineclusterpreference"
+	ClusterPluralPreferenceResourceName   = ClusterSingularPreferenceResourceName + "s"
+)
+
+// Methods finds the instancetype and preference referenced by a VirtualMachine
+// and expands them into a VirtualMachineInstanceSpec.
+type Methods interface {
+	FindInstancetypeSpec(vm *virtv1.VirtualMachine) (*instancetypev1alpha1.VirtualMachineInstancetypeSpec, error)
+	FindPreferenceSpec(vm *virtv1.VirtualMachine) (*instancetypev1alpha1.VirtualMachinePreferenceSpec, error)
+	ApplyToVmi(field *k8sfield.Path, instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) Conflicts
+}
+
+// Conflicts lists the fields of a VirtualMachineInstanceSpec which are
+// provided by both the user and the referenced instancetype.
+type Conflicts []*k8sfield.Path
+
+func (c Conflicts) String() string {
+	pathStrings := make([]string, 0, len(c))
+	for _, path := range c {
+		pathStrings = append(pathStrings, path.String())
+	}
+	return strings.Join(pathStrings, ", ")
+}
+
+type methods struct {
+	clientset kubecli.KubevirtClient
+}
+
+var _ Methods = &methods{}
+
+func NewMethods(clientset kubecli.KubevirtClient) Methods {
+	return &methods{
+		clientset: clientset,
+	}
+}
+
+func (m *methods) FindInstancetypeSpec(vm *virtv1.VirtualMachine) (*instancetypev1alpha1.VirtualMachineInstancetypeSpec, error) {
+	if vm.Spec.Instancetype == nil {
+		return nil, nil
+	}
+
+	matcher := vm.Spec.Instancetype
+	switch strings.ToLower(matcher.Kind) {
+	case SingularResourceName, PluralResourceName:
+		instancetype, err := m.clientset.VirtualMachineInstancetype(vm.Namespace).Get(context.Background(), matcher.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &instancetype.Spec, nil
+
+	case ClusterSingularResourceName, ClusterPluralResourceName, "":
+		clusterInstancetype, err := m.clientset.VirtualMachineClusterInstancetype().Get(context.Background(), matcher.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &clusterInstancetype.Spec, nil
+
+	default:
+		return nil, fmt.Errorf("got unexpected kind in InstancetypeMatcher: %s", matcher.Kind)
+	}
+}
+
+func (m *methods) FindPreferenceSpec(vm *virtv1.VirtualMachine) (*instancetypev1alpha1.VirtualMachinePreferenceSpec, error) {
+	if vm.Spec.Preference == nil {
+		return nil, nil
+	}
+
+	matcher := vm.Spec.Preference
+	switch strings.ToLower(matcher.Kind) {
+	case SingularPreferenceResourceName, PluralPreferenceResourceName:
+		preference, err := m.clientset.VirtualMachinePreference(vm.Namespace).Get(context.Background(), matcher.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &preference.Spec, nil
+
+	case ClusterSingularPreferenceResourceName, ClusterPluralPreferenceResourceName, "":
+		clusterPreference, err := m.clientset.VirtualMachineClusterPreference().Get(context.Background(), matcher.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &clusterPreference.Spec, nil
+
+	default:
+		return nil, fmt.Errorf("got unexpected kind in PreferenceMatcher: %s", matcher.Kind)
+	}
+}
+
+// ApplyToVmi expands the instancetype and preference into the given VirtualMachineInstanceSpec.
+// Values of the instancetype must not be provided by the VirtualMachineInstanceSpec already, any
+// such field is returned as a conflict and the spec is left untouched. Preferences are only
+// applied to fields which are not set yet.
+func (m *methods) ApplyToVmi(field *k8sfield.Path, instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) Conflicts {
+	if instancetypeSpec != nil {
+		conflicts := checkInstancetypeConflicts(field, instancetypeSpec, vmiSpec)
+		if len(conflicts) > 0 {
+			return conflicts
+		}
+		applyCPU(instancetypeSpec, preferenceSpec, vmiSpec)
+		applyMemory(instancetypeSpec, vmiSpec)
+		applyIOThreadPolicy(instancetypeSpec, vmiSpec)
+		applyGPUs(instancetypeSpec, vmiSpec)
+		applyHostDevices(instancetypeSpec, vmiSpec)
+	}
+
+	if preferenceSpec != nil {
+		applyDevicePreferences(preferenceSpec, vmiSpec)
+		applyFeaturePreferences(preferenceSpec, vmiSpec)
+		applyFirmwarePreferences(preferenceSpec, vmiSpec)
+		applyMachinePreferences(preferenceSpec, vmiSpec)
+	}
+
+	return nil
+}
+
+func checkInstancetypeConflicts(field *k8sfield.Path, instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) Conflicts {
+	var conflicts Conflicts
+	domainField := field.Child("domain")
+
+	if cpu := vmiSpec.Domain.CPU; cpu != nil {
+		if cpu.Sockets != 0 {
+			conflicts = append(conflicts, domainField.Child("cpu", "sockets"))
+		}
+		if cpu.Cores != 0 {
+			conflicts = append(conflicts, domainField.Child("cpu", "cores"))
+		}
+		if cpu.Threads != 0 {
+			conflicts = append(conflicts, domainField.Child("cpu", "threads"))
+		}
+		if cpu.Model != "" && instancetypeSpec.CPU.Model != "" {
+			conflicts = append(conflicts, domainField.Child("cpu", "model"))
+		}
+		if cpu.DedicatedCPUPlacement && instancetypeSpec.CPU.DedicatedCPUPlacement {
+			conflicts = append(conflicts, domainField.Child("cpu", "dedicatedCpuPlacement"))
+		}
+		if cpu.IsolateEmulatorThread && instancetypeSpec.CPU.IsolateEmulatorThread {
+			conflicts = append(conflicts, domainField.Child("cpu", "isolateEmulatorThread"))
+		}
+	}
+
+	for _, resourceName := range []k8sv1.ResourceName{k8sv1.ResourceCPU, k8sv1.ResourceMemory} {
+		if _, exists := vmiSpec.Domain.Resources.Requests[resourceName]; exists {
+			conflicts = append(conflicts, domainField.Child("resources", "requests", string(resourceName)))
+		}
+		if _, exists := vmiSpec.Domain.Resources.Limits[resourceName]; exists {
+			conflicts = append(conflicts, domainField.Child("resources", "limits", string(resourceName)))
+		}
+	}
+
+	if memory := vmiSpec.Domain.Memory; memory != nil {
+		if memory.Guest != nil {
+			conflicts = append(conflicts, domainField.Child("memory", "guest"))
+		}
+		if memory.Hugepages != nil && instancetypeSpec.Memory.Hugepages != nil {
+			conflicts = append(conflicts, domainField.Child("memory", "hugepages"))
+		}
+	}
+
+	if vmiSpec.Domain.IOThreadsPolicy != nil && instancetypeSpec.IOThreadsPolicy != nil {
+		conflicts = append(conflicts, domainField.Child("ioThreadsPolicy"))
+	}
+
+	if len(vmiSpec.Domain.Devices.GPUs) > 0 && len(instancetypeSpec.GPUs) > 0 {
+		conflicts = append(conflicts, domainField.Child("devices", "gpus"))
+	}
+
+	if len(vmiSpec.Domain.Devices.HostDevices) > 0 && len(instancetypeSpec.HostDevices) > 0 {
+		conflicts = append(conflicts, domainField.Child("devices", "hostDevices"))
+	}
+
+	return conflicts
+}
+
+func applyCPU(instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if vmiSpec.Domain.CPU == nil {
+		vmiSpec.Domain.CPU = &virtv1.CPU{}
+	}
+
+	// Default to PreferSockets when a PreferredCPUTopology isn't provided
+	preferredTopology := instancetypev1alpha1.PreferSockets
+	if preferenceSpec != nil && preferenceSpec.CPU != nil && preferenceSpec.CPU.PreferredCPUTopology != "" {
+		preferredTopology = preferenceSpec.CPU.PreferredCPUTopology
+	}
+
+	vmiSpec.Domain.CPU.Sockets = 1
+	vmiSpec.Domain.CPU.Cores = 1
+	vmiSpec.Domain.CPU.Threads = 1
+	switch preferredTopology {
+	case instancetypev1alpha1.PreferCores:
+		vmiSpec.Domain.CPU.Cores = instancetypeSpec.CPU.Guest
+	case instancetypev1alpha1.PreferThreads:
+		vmiSpec.Domain.CPU.Threads = instancetypeSpec.CPU.Guest
+	default:
+		vmiSpec.Domain.CPU.Sockets = instancetypeSpec.CPU.Guest
+	}
+
+	if instancetypeSpec.CPU.Model != "" {
+		vmiSpec.Domain.CPU.Model = instancetypeSpec.CPU.Model
+	}
+	if instancetypeSpec.CPU.DedicatedCPUPlacement {
+		vmiSpec.Domain.CPU.DedicatedCPUPlacement = true
+	}
+	if instancetypeSpec.CPU.IsolateEmulatorThread {
+		vmiSpec.Domain.CPU.IsolateEmulatorThread = true
+	}
+}
+
+func applyMemory(instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if vmiSpec.Domain.Memory == nil {
+		vmiSpec.Domain.Memory = &virtv1.Memory{}
+	}
+
+	instancetypeMemoryGuest := instancetypeSpec.Memory.Guest.DeepCopy()
+	vmiSpec.Domain.Memory.Guest = &instancetypeMemoryGuest
+
+	if instancetypeSpec.Memory.Hugepages != nil {
+		vmiSpec.Domain.Memory.Hugepages = instancetypeSpec.Memory.Hugepages.DeepCopy()
+	}
+}
+
+func applyIOThreadPolicy(instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if instancetypeSpec.IOThreadsPolicy == nil {
+		return
+	}
+	instancetypeIOThreadPolicy := *instancetypeSpec.IOThreadsPolicy
+	vmiSpec.Domain.IOThreadsPolicy = &instancetypeIOThreadPolicy
+}
+
+func applyGPUs(instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if len(instancetypeSpec.GPUs) == 0 {
+		return
+	}
+	vmiSpec.Domain.Devices.GPUs = make([]virtv1.GPU, len(instancetypeSpec.GPUs))
+	copy(vmiSpec.Domain.Devices.GPUs, instancetypeSpec.GPUs)
+}
+
+func applyHostDevices(instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if len(instancetypeSpec.HostDevices) == 0 {
+		return
+	}
+	vmiSpec.Domain.Devices.HostDevices = make([]virtv1.HostDevice, len(instancetypeSpec.HostDevices))
+	copy(vmiSpec.Domain.Devices.HostDevices, instancetypeSpec.HostDevices)
+}
+
+func applyDevicePreferences(preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if preferenceSpec.Devices == nil {
+		return
+	}
+	preferences := preferenceSpec.Devices
+	devices := &vmiSpec.Domain.Devices
+
+	applyBoolPreference(preferences.PreferredAutoattachGraphicsDevice, &devices.AutoattachGraphicsDevice)
+	applyBoolPreference(preferences.PreferredAutoattachMemBalloon, &devices.AutoattachMemBalloon)
+	applyBoolPreference(preferences.PreferredAutoattachPodInterface, &devices.AutoattachPodInterface)
+	applyBoolPreference(preferences.PreferredAutoattachSerialConsole, &devices.AutoattachSerialConsole)
+	applyBoolPreference(preferences.PreferredUseVirtioTransitional, &devices.UseVirtioTransitional)
+	applyBoolPreference(preferences.PreferredBlockMultiQueue, &devices.BlockMultiQueue)
+	applyBoolPreference(preferences.PreferredNetworkInterfaceMultiQueue, &devices.NetworkInterfaceMultiQueue)
+
+	for i := range devices.Disks {
+		disk := &devices.Disks[i]
+		switch {
+		case disk.Disk != nil:
+			if disk.Disk.Bus == "" {
+				disk.Disk.Bus = preferences.PreferredDiskBus
+			}
+		case disk.LUN != nil:
+			if disk.LUN.Bus == "" {
+				disk.LUN.Bus = preferences.PreferredLunBus
+			}
+		case disk.CDRom != nil:
+			if disk.CDRom.Bus == "" {
+				disk.CDRom.Bus = preferences.PreferredCdromBus
+			}
+		case disk.Floppy == nil && preferences.PreferredDiskBus != "":
+			// Disks without any target are defaulted to a Disk target later on
+			disk.Disk = &virtv1.DiskTarget{
+				Bus: preferences.PreferredDiskBus,
+			}
+		}
+	}
+
+	for i := range devices.Interfaces {
+		if devices.Interfaces[i].Model == "" {
+			devices.Interfaces[i].Model = preferences.PreferredInterfaceModel
+		}
+	}
+
+	for i := range devices.Inputs {
+		if devices.Inputs[i].Bus == "" {
+			devices.Inputs[i].Bus = preferences.PreferredInputBus
+		}
+		if devices.Inputs[i].Type == "" {
+			devices.Inputs[i].Type = preferences.PreferredInputType
+		}
+	}
+}
+
+func applyFeaturePreferences(preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if preferenceSpec.Features == nil {
+		return
+	}
+	preferences := preferenceSpec.Features
+
+	if vmiSpec.Domain.Features == nil {
+		vmiSpec.Domain.Features = &virtv1.Features{}
+	}
+	features := vmiSpec.Domain.Features
+
+	// ACPI is not a pointer and is enabled by default, only apply the preference when it is not explicitly set
+	if preferences.PreferredAcpi != nil && features.ACPI.Enabled == nil {
+		features.ACPI = *preferences.PreferredAcpi.DeepCopy()
+	}
+	if preferences.PreferredApic != nil && features.APIC == nil {
+		features.APIC = preferences.PreferredApic.DeepCopy()
+	}
+	if preferences.PreferredHyperv != nil && features.Hyperv == nil {
+		features.Hyperv = preferences.PreferredHyperv.DeepCopy()
+	}
+	if preferences.PreferredKvm != nil && features.KVM == nil {
+		features.KVM = preferences.PreferredKvm.DeepCopy()
+	}
+	if preferences.PreferredSmm != nil && features.SMM == nil {
+		features.SMM = preferences.PreferredSmm.DeepCopy()
+	}
+}
+
+func applyFirmwarePreferences(preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if preferenceSpec.Firmware == nil {
+		return
+	}
+	preferences := preferenceSpec.Firmware
+
+	if vmiSpec.Domain.Firmware == nil {
+		vmiSpec.Domain.Firmware = &virtv1.Firmware{}
+	}
+	firmware := vmiSpec.Domain.Firmware
+
+	// Only apply a preferred bootloader if the user has not already selected one
+	if firmware.Bootloader != nil {
+		return
+	}
+
+	if preferences.PreferredUseEfi != nil && *preferences.PreferredUseEfi {
+		firmware.Bootloader = &virtv1.Bootloader{
+			EFI: &virtv1.EFI{},
+		}
+		if preferences.PreferredUseSecureBoot != nil {
+			secureBoot := *preferences.PreferredUseSecureBoot
+			firmware.Bootloader.EFI.SecureBoot = &secureBoot
+		}
+		return
+	}
+
+	if preferences.PreferredUseBios != nil && *preferences.PreferredUseBios {
+		firmware.Bootloader = &virtv1.Bootloader{
+			BIOS: &virtv1.BIOS{},
+		}
+		if preferences.PreferredUseBiosSerial != nil {
+			useSerial := *preferences.PreferredUseBiosSerial
+			firmware.Bootloader.BIOS.UseSerial = &useSerial
+		}
+	}
+}
+
+func applyMachinePreferences(preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if preferenceSpec.Machine == nil {
+		return
+	}
+	if vmiSpec.Domain.Machine.Type == "" {
+		vmiSpec.Domain.Machine.Type = preferenceSpec.Machine.PreferredMachineType
+	}
+}
+
+func applyBoolPreference(preference *bool, target **bool) {
+	if preference == nil || *target != nil {
+		return
+	}
+	value := *preference
+	*target = &value
+}
@@ -0,0 +1,446 @@
+package instancetype_test
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/instancetype"
+)
+
+var _ = Describe("Instancetype and Preferences", func() {
+	var (
+		ctrl                *gomock.Controller
+		virtClient          *kubecli.MockKubevirtClient
+		kubevirtClient      *kubevirtfake.Clientset
+		instancetypeMethods instancetype.Methods
+		vm                  *v1.VirtualMachine
+		vmi                 *v1.VirtualMachineInstance
+		field               = k8sfield.NewPath("spec", "template", "spec")
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+
+		virtClient.EXPECT().VirtualMachineInstancetype(gomock.Any()).DoAndReturn(func(namespace string) interface{} {
+			return kubevirtClient.InstancetypeV1alpha1().VirtualMachineInstancetypes(namespace)
+		}).AnyTimes()
+		virtClient.EXPECT().VirtualMachineClusterInstancetype().Return(
+			kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterInstancetypes()).AnyTimes()
+		virtClient.EXPECT().VirtualMachinePreference(gomock.Any()).DoAndReturn(func(namespace string) interface{} {
+			return kubevirtClient.InstancetypeV1alpha1().VirtualMachinePreferences(namespace)
+		}).AnyTimes()
+		virtClient.EXPECT().VirtualMachineClusterPreference().Return(
+			kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterPreferences()).AnyTimes()
+
+		instancetypeMethods = instancetype.NewMethods(virtClient)
+
+		vm = &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "testvm",
+				Namespace: k8sv1.NamespaceDefault,
+			},
+		}
+		vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{
+			Spec: v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{},
+			},
+		}
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Resources = v1.ResourceRequirements{}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("Find Instancetype Spec", func() {
+
+		It("returns nil when no instancetype is specified", func() {
+			spec, err := instancetypeMethods.FindInstancetypeSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec).To(BeNil())
+		})
+
+		It("returns error when invalid Instancetype Kind is specified", func() {
+			vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+				Name: "foo",
+				Kind: "bar",
+			}
+			_, err := instancetypeMethods.FindInstancetypeSpec(vm)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("got unexpected kind in InstancetypeMatcher"))
+		})
+
+		table.DescribeTable("finds the VirtualMachineClusterInstancetype", func(kind string) {
+			clusterInstancetype := &instancetypev1alpha1.VirtualMachineClusterInstancetype{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster-instancetype",
+				},
+				Spec: instancetypev1alpha1.VirtualMachineInstancetypeSpec{
+					CPU: instancetypev1alpha1.CPUInstancetype{
+						Guest: uint32(2),
+					},
+				},
+			}
+			_, err := kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterInstancetypes().Create(nil, clusterInstancetype, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+				Name: clusterInstancetype.Name,
+				Kind: kind,
+			}
+			spec, err := instancetypeMethods.FindInstancetypeSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*spec).To(Equal(clusterInstancetype.Spec))
+		},
+			table.Entry("with the default kind", ""),
+			table.Entry("with the cluster kind", "VirtualMachineClusterInstancetype"),
+			table.Entry("with the cluster resource name", instancetype.ClusterPluralResourceName),
+		)
+
+		It("finds the namespaced VirtualMachineInstancetype", func() {
+			namespacedInstancetype := &instancetypev1alpha1.VirtualMachineInstancetype{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-instancetype",
+					Namespace: vm.Namespace,
+				},
+				Spec: instancetypev1alpha1.VirtualMachineInstancetypeSpec{
+					CPU: instancetypev1alpha1.CPUInstancetype{
+						Guest: uint32(4),
+					},
+				},
+			}
+			_, err := kubevirtClient.InstancetypeV1alpha1().VirtualMachineInstancetypes(vm.Namespace).Create(nil, namespacedInstancetype, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+				Name: namespacedInstancetype.Name,
+				Kind: "VirtualMachineInstancetype",
+			}
+			spec, err := instancetypeMethods.FindInstancetypeSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*spec).To(Equal(namespacedInstancetype.Spec))
+		})
+
+		It("returns an error when the instancetype does not exist", func() {
+			vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+				Name: "missing",
+			}
+			_, err := instancetypeMethods.FindInstancetypeSpec(vm)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Find Preference Spec", func() {
+
+		It("returns nil when no preference is specified", func() {
+			spec, err := instancetypeMethods.FindPreferenceSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec).To(BeNil())
+		})
+
+		It("returns error when invalid Preference Kind is specified", func() {
+			vm.Spec.Preference = &v1.PreferenceMatcher{
+				Name: "foo",
+				Kind: "bar",
+			}
+			_, err := instancetypeMethods.FindPreferenceSpec(vm)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("got unexpected kind in PreferenceMatcher"))
+		})
+
+		It("finds the VirtualMachineClusterPreference by default", func() {
+			clusterPreference := &instancetypev1alpha1.VirtualMachineClusterPreference{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster-preference",
+				},
+				Spec: instancetypev1alpha1.VirtualMachinePreferenceSpec{
+					CPU: &instancetypev1alpha1.CPUPreferences{
+						PreferredCPUTopology: instancetypev1alpha1.PreferCores,
+					},
+				},
+			}
+			_, err := kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterPreferences().Create(nil, clusterPreference, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			vm.Spec.Preference = &v1.PreferenceMatcher{
+				Name: clusterPreference.Name,
+			}
+			spec, err := instancetypeMethods.FindPreferenceSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*spec).To(Equal(clusterPreference.Spec))
+		})
+
+		It("finds the namespaced VirtualMachinePreference", func() {
+			preference := &instancetypev1alpha1.VirtualMachinePreference{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-preference",
+					Namespace: vm.Namespace,
+				},
+				Spec: instancetypev1alpha1.VirtualMachinePreferenceSpec{
+					CPU: &instancetypev1alpha1.CPUPreferences{
+						PreferredCPUTopology: instancetypev1alpha1.PreferThreads,
+					},
+				},
+			}
+			_, err := kubevirtClient.InstancetypeV1alpha1().VirtualMachinePreferences(vm.Namespace).Create(nil, preference, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			vm.Spec.Preference = &v1.PreferenceMatcher{
+				Name: preference.Name,
+				Kind: "VirtualMachinePreference",
+			}
+			spec, err := instancetypeMethods.FindPreferenceSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*spec).To(Equal(preference.Spec))
+		})
+	})
+
+	Context("Apply", func() {
+		var instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec
+		var preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec
+
+		BeforeEach(func() {
+			instancetypeSpec = &instancetypev1alpha1.VirtualMachineInstancetypeSpec{
+				CPU: instancetypev1alpha1.CPUInstancetype{
+					Guest:                 uint32(2),
+					Model:                 "host-passthrough",
+					DedicatedCPUPlacement: true,
+					IsolateEmulatorThread: true,
+				},
+				Memory: instancetypev1alpha1.MemoryInstancetype{
+					Guest: resource.MustParse("512M"),
+					Hugepages: &v1.Hugepages{
+						PageSize: "1Gi",
+					},
+				},
+			}
+			preferenceSpec = nil
+		})
+
+		It("should apply nothing without an instancetype or preference", func() {
+			expectedSpec := vmi.Spec.DeepCopy()
+			Expect(instancetypeMethods.ApplyToVmi(field, nil, nil, &vmi.Spec)).To(BeEmpty())
+			Expect(vmi.Spec).To(Equal(*expectedSpec))
+		})
+
+		It("should apply CPU and Memory to VMI", func() {
+			Expect(instancetypeMethods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, &vmi.Spec)).To(BeEmpty())
+
+			Expect(vmi.Spec.Domain.CPU.Sockets).To(Equal(instancetypeSpec.CPU.Guest))
+			Expect(vmi.Spec.Domain.CPU.Cores).To(Equal(uint32(1)))
+			Expect(vmi.Spec.Domain.CPU.Threads).To(Equal(uint32(1)))
+			Expect(vmi.Spec.Domain.CPU.Model).To(Equal(instancetypeSpec.CPU.Model))
+			Expect(vmi.Spec.Domain.CPU.DedicatedCPUPlacement).To(BeTrue())
+			Expect(vmi.Spec.Domain.CPU.IsolateEmulatorThread).To(BeTrue())
+			Expect(*vmi.Spec.Domain.Memory.Guest).To(Equal(instancetypeSpec.Memory.Guest))
+			Expect(*vmi.Spec.Domain.Memory.Hugepages).To(Equal(*instancetypeSpec.Memory.Hugepages))
+		})
+
+		table.DescribeTable("should apply the preferred CPU topology", func(topology instancetypev1alpha1.PreferredCPUTopology, sockets, cores, threads uint32) {
+			preferenceSpec = &instancetypev1alpha1.VirtualMachinePreferenceSpec{
+				CPU: &instancetypev1alpha1.CPUPreferences{
+					PreferredCPUTopology: topology,
+				},
+			}
+
+			Expect(instancetypeMethods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, &vmi.Spec)).To(BeEmpty())
+			Expect(vmi.Spec.Domain.CPU.Sockets).To(Equal(sockets))
+			Expect(vmi.Spec.Domain.CPU.Cores).To(Equal(cores))
+			Expect(vmi.Spec.Domain.CPU.Threads).To(Equal(threads))
+		},
+			table.Entry("PreferSockets", instancetypev1alpha1.PreferSockets, uint32(2), uint32(1), uint32(1)),
+			table.Entry("PreferCores", instancetypev1alpha1.PreferCores, uint32(1), uint32(2), uint32(1)),
+			table.Entry("PreferThreads", instancetypev1alpha1.PreferThreads, uint32(1), uint32(1), uint32(2)),
+		)
+
+		table.DescribeTable("should detect conflicts with the instancetype", func(updateSpec func(*v1.VirtualMachineInstanceSpec), expectedConflicts ...string) {
+			updateSpec(&vmi.Spec)
+			expectedSpec := vmi.Spec.DeepCopy()
+
+			conflicts := instancetypeMethods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, &vmi.Spec)
+			Expect(conflicts).To(HaveLen(len(expectedConflicts)))
+			for i, expected := range expectedConflicts {
+				Expect(conflicts[i].String()).To(Equal(expected))
+			}
+			// The spec must not be touched when conflicts are found
+			Expect(vmi.Spec).To(Equal(*expectedSpec))
+		},
+			table.Entry("on CPU topology", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.CPU = &v1.CPU{Sockets: 1, Cores: 2, Threads: 1}
+			}, "spec.template.spec.domain.cpu.sockets", "spec.template.spec.domain.cpu.cores", "spec.template.spec.domain.cpu.threads"),
+			table.Entry("on CPU model and placement", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.CPU = &v1.CPU{Model: "host-model", DedicatedCPUPlacement: true, IsolateEmulatorThread: true}
+			}, "spec.template.spec.domain.cpu.model", "spec.template.spec.domain.cpu.dedicatedCpuPlacement", "spec.template.spec.domain.cpu.isolateEmulatorThread"),
+			table.Entry("on resources", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.Resources.Requests = k8sv1.ResourceList{
+					k8sv1.ResourceCPU:    resource.MustParse("1"),
+					k8sv1.ResourceMemory: resource.MustParse("128Mi"),
+				}
+				spec.Domain.Resources.Limits = k8sv1.ResourceList{
+					k8sv1.ResourceMemory: resource.MustParse("128Mi"),
+				}
+			}, "spec.template.spec.domain.resources.requests.cpu", "spec.template.spec.domain.resources.requests.memory", "spec.template.spec.domain.resources.limits.memory"),
+			table.Entry("on memory", func(spec *v1.VirtualMachineInstanceSpec) {
+				guest := resource.MustParse("128Mi")
+				spec.Domain.Memory = &v1.Memory{
+					Guest:     &guest,
+					Hugepages: &v1.Hugepages{PageSize: "2Mi"},
+				}
+			}, "spec.template.spec.domain.memory.guest", "spec.template.spec.domain.memory.hugepages"),
+		)
+
+		It("should apply GPUs, HostDevices and IOThreadsPolicy", func() {
+			policy := v1.IOThreadsPolicyShared
+			instancetypeSpec.IOThreadsPolicy = &policy
+			instancetypeSpec.GPUs = []v1.GPU{{Name: "gpu1", DeviceName: "vendor.com/gpu_name"}}
+			instancetypeSpec.HostDevices = []v1.HostDevice{{Name: "hostdev1", DeviceName: "vendor.com/hostdev_name"}}
+
+			Expect(instancetypeMethods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, &vmi.Spec)).To(BeEmpty())
+			Expect(*vmi.Spec.Domain.IOThreadsPolicy).To(Equal(policy))
+			Expect(vmi.Spec.Domain.Devices.GPUs).To(Equal(instancetypeSpec.GPUs))
+			Expect(vmi.Spec.Domain.Devices.HostDevices).To(Equal(instancetypeSpec.HostDevices))
+		})
+
+		It("should detect conflicting GPUs, HostDevices and IOThreadsPolicy", func() {
+			policy := v1.IOThreadsPolicyShared
+			instancetypeSpec.IOThreadsPolicy = &policy
+			instancetypeSpec.GPUs = []v1.GPU{{Name: "gpu1", DeviceName: "vendor.com/gpu_name"}}
+			instancetypeSpec.HostDevices = []v1.HostDevice{{Name: "hostdev1", DeviceName: "vendor.com/hostdev_name"}}
+			vmi.Spec.Domain.IOThreadsPolicy = &policy
+			vmi.Spec.Domain.Devices.GPUs = []v1.GPU{{Name: "gpu2", DeviceName: "vendor.com/gpu_name"}}
+			vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{{Name: "hostdev2", DeviceName: "vendor.com/hostdev_name"}}
+
+			conflicts := instancetypeMethods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, &vmi.Spec)
+			Expect(conflicts.String()).To(Equal("spec.template.spec.domain.ioThreadsPolicy, spec.template.spec.domain.devices.gpus, spec.template.spec.domain.devices.hostDevices"))
+		})
+
+		Context("Preferences", func() {
+			var (
+				userDefinedBus = "sata"
+				preferredTrue  = true
+				preferredFalse = false
+			)
+
+			BeforeEach(func() {
+				preferenceSpec = &instancetypev1alpha1.VirtualMachinePreferenceSpec{
+					Devices: &instancetypev1alpha1.DevicePreferences{
+						PreferredAutoattachGraphicsDevice: &preferredTrue,
+						PreferredAutoattachMemBalloon:     &preferredTrue,
+						PreferredAutoattachSerialConsole:  &preferredFalse,
+						PreferredDiskBus:                  "virtio",
+						PreferredCdromBus:                 "sata",
+						PreferredLunBus:                   "scsi",
+						PreferredInterfaceModel:           "virtio",
+						PreferredInputBus:                 "usb",
+						PreferredInputType:                "tablet",
+					},
+					Features: &instancetypev1alpha1.FeaturePreferences{
+						PreferredAcpi: &v1.FeatureState{Enabled: &preferredTrue},
+						PreferredSmm:  &v1.FeatureState{Enabled: &preferredTrue},
+					},
+					Firmware: &instancetypev1alpha1.FirmwarePreferences{
+						PreferredUseEfi:        &preferredTrue,
+						PreferredUseSecureBoot: &preferredTrue,
+					},
+					Machine: &instancetypev1alpha1.MachinePreferences{
+						PreferredMachineType: "q35-rhel-8.0",
+					},
+				}
+				vmi.Spec.Domain.Devices.AutoattachSerialConsole = &preferredTrue
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+					{
+						Name: "disk",
+						DiskDevice: v1.DiskDevice{
+							Disk: &v1.DiskTarget{},
+						},
+					},
+					{
+						Name: "userdisk",
+						DiskDevice: v1.DiskDevice{
+							Disk: &v1.DiskTarget{Bus: userDefinedBus},
+						},
+					},
+					{
+						Name: "cdrom",
+						DiskDevice: v1.DiskDevice{
+							CDRom: &v1.CDRomTarget{},
+						},
+					},
+					{
+						Name: "lun",
+						DiskDevice: v1.DiskDevice{
+							LUN: &v1.LunTarget{},
+						},
+					},
+					{
+						Name: "defaultdisk",
+					},
+				}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					{Name: "default"},
+				}
+				vmi.Spec.Domain.Devices.Inputs = []v1.Input{
+					{Name: "tablet"},
+				}
+			})
+
+			It("should apply device preferences only to unset values", func() {
+				Expect(instancetypeMethods.ApplyToVmi(field, nil, preferenceSpec, &vmi.Spec)).To(BeEmpty())
+
+				devices := vmi.Spec.Domain.Devices
+				Expect(*devices.AutoattachGraphicsDevice).To(BeTrue())
+				Expect(*devices.AutoattachMemBalloon).To(BeTrue())
+				Expect(*devices.AutoattachSerialConsole).To(BeTrue())
+				Expect(devices.AutoattachPodInterface).To(BeNil())
+				Expect(devices.Disks[0].Disk.Bus).To(Equal("virtio"))
+				Expect(devices.Disks[1].Disk.Bus).To(Equal(userDefinedBus))
+				Expect(devices.Disks[2].CDRom.Bus).To(Equal("sata"))
+				Expect(devices.Disks[3].LUN.Bus).To(Equal("scsi"))
+				Expect(devices.Disks[4].Disk.Bus).To(Equal("virtio"))
+				Expect(devices.Interfaces[0].Model).To(Equal("virtio"))
+				Expect(devices.Inputs[0].Bus).To(Equal("usb"))
+				Expect(devices.Inputs[0].Type).To(Equal("tablet"))
+			})
+
+			It("should apply feature, firmware and machine preferences", func() {
+				Expect(instancetypeMethods.ApplyToVmi(field, nil, preferenceSpec, &vmi.Spec)).To(BeEmpty())
+
+				Expect(*vmi.Spec.Domain.Features.ACPI.Enabled).To(BeTrue())
+				Expect(*vmi.Spec.Domain.Features.SMM.Enabled).To(BeTrue())
+				Expect(vmi.Spec.Domain.Firmware.Bootloader.BIOS).To(BeNil())
+				Expect(*vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot).To(BeTrue())
+				Expect(vmi.Spec.Domain.Machine.Type).To(Equal("q35-rhel-8.0"))
+			})
+
+			It("should not override user provided features, firmware and machine", func() {
+				vmi.Spec.Domain.Features = &v1.Features{
+					SMM: &v1.FeatureState{Enabled: &preferredFalse},
+				}
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					Bootloader: &v1.Bootloader{BIOS: &v1.BIOS{}},
+				}
+				vmi.Spec.Domain.Machine.Type = "pc-q35-rhel8.2.0"
+
+				Expect(instancetypeMethods.ApplyToVmi(field, nil, preferenceSpec, &vmi.Spec)).To(BeEmpty())
+
+				Expect(*vmi.Spec.Domain.Features.SMM.Enabled).To(BeFalse())
+				Expect(vmi.Spec.Domain.Firmware.Bootloader.EFI).To(BeNil())
+				Expect(vmi.Spec.Domain.Firmware.Bootloader.BIOS).ToNot(BeNil())
+				Expect(vmi.Spec.Domain.Machine.Type).To(Equal("pc-q35-rhel8.2.0"))
+			})
+		})
+	})
+})
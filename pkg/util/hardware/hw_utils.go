@@ -84,6 +84,17 @@ func GetNumberOfVCPUs(cpuSpec *v1.CPU) int64 {
 	return int64(vCPUs)
 }
 
+//GetMaxNumberOfVCPUs returns the number of vCPUs the VMI can be scaled up to
+//by hotplugging sockets. It counts maxSockets*cores*threads
+func GetMaxNumberOfVCPUs(cpuSpec *v1.CPU) int64 {
+	if cpuSpec.MaxSockets <= cpuSpec.Sockets {
+		return GetNumberOfVCPUs(cpuSpec)
+	}
+	maxCPUSpec := cpuSpec.DeepCopy()
+	maxCPUSpec.Sockets = maxCPUSpec.MaxSockets
+	return GetNumberOfVCPUs(maxCPUSpec)
+}
+
 // ParsePciAddress returns an array of PCI DBSF fields (domain, bus, slot, function)
 func ParsePciAddress(pciAddress string) ([]string, error) {
 	pciAddrRegx, err := regexp.Compile(PCI_ADDRESS_PATTERN)
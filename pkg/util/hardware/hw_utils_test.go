@@ -81,6 +81,21 @@ var _ = Describe("Hardware utils test", func() {
 			})
 			Expect(vCPUs).To(Equal(int64(4)), "Expect vCPUs")
 		})
+
+		It("should count the vCPUs up to maxSockets", func() {
+			vCPUs := GetMaxNumberOfVCPUs(&v1.CPU{
+				Sockets:    2,
+				Cores:      2,
+				MaxSockets: 4,
+			})
+			Expect(vCPUs).To(Equal(int64(8)), "Expect vCPUs")
+
+			vCPUs = GetMaxNumberOfVCPUs(&v1.CPU{
+				Sockets: 2,
+				Cores:   2,
+			})
+			Expect(vCPUs).To(Equal(int64(4)), "Expect vCPUs")
+		})
 	})
 
 	Context("parse PCI address", func() {
@@ -0,0 +1,457 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package macpool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+const (
+	// MacAddressIndex is the name of the informer index of VirtualMachines and
+	// VirtualMachineInstances by the MAC addresses of their interfaces
+	MacAddressIndex = "macAddress"
+
+	// ReservationsConfigMapName is the name of the ConfigMap in the KubeVirt namespace which holds
+	// the MAC addresses reserved by all instances of the pool
+	ReservationsConfigMapName = "kubevirt-mac-pool-reservations"
+
+	// reservationTimeout bounds how long an allocated address is held for its owner,
+	// until the owner shows up in the informers
+	reservationTimeout = 5 * time.Minute
+
+	macAddressBits = 48
+	multicastBit   = 1 << 40
+)
+
+// IndexByMacAddress indexes VirtualMachines and VirtualMachineInstances by the MAC addresses of their interfaces
+func IndexByMacAddress(obj interface{}) ([]string, error) {
+	var interfaces []v1.Interface
+	switch o := obj.(type) {
+	case *v1.VirtualMachine:
+		if o.Spec.Template == nil {
+			return nil, nil
+		}
+		interfaces = o.Spec.Template.Spec.Domain.Devices.Interfaces
+	case *v1.VirtualMachineInstance:
+		interfaces = o.Spec.Domain.Devices.Interfaces
+	}
+
+	var macs []string
+	for _, iface := range interfaces {
+		if mac, err := net.ParseMAC(iface.MacAddress); err == nil {
+			macs = append(macs, mac.String())
+		}
+	}
+	return macs, nil
+}
+
+// OwnerKey identifies the owner of MAC addresses. The VirtualMachineInstance of a
+// VirtualMachine shares the addresses of the VirtualMachine.
+func OwnerKey(obj metav1.Object) string {
+	if ref := metav1.GetControllerOf(obj); ref != nil && ref.Kind == v1.VirtualMachineGroupVersionKind.Kind {
+		return obj.GetNamespace() + "/" + ref.Name
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+type reservation struct {
+	Owner   string      `json:"owner"`
+	Expires metav1.Time `json:"expires"`
+}
+
+// reservations are the MAC addresses reserved in the reservations ConfigMap by their owner
+type reservations struct {
+	configMap *k8sv1.ConfigMap
+	exists    bool
+	byMac     map[string]reservation
+	changed   bool
+}
+
+// MacPool allocates MAC addresses from the configured ranges. The allocations are the MAC
+// addresses persisted in the VirtualMachines and VirtualMachineInstances of the cluster, they
+// are looked up in the informers and survive restarts of the pool. Addresses which are allocated
+// but not yet visible in the informers are reserved in a ConfigMap shared by all instances of
+// the pool, e.g. the replicas of virt-api and virt-controller.
+type MacPool struct {
+	client      kubecli.KubevirtClient
+	namespace   string
+	vmInformer  cache.SharedIndexInformer
+	vmiInformer cache.SharedIndexInformer
+	lock        sync.Mutex
+	clock       clock.Clock
+}
+
+// NewMacPool creates a MAC pool on informers indexed by MacAddressIndex, which keeps its
+// reservations in the KubeVirt namespace
+func NewMacPool(client kubecli.KubevirtClient, namespace string, vmInformer cache.SharedIndexInformer, vmiInformer cache.SharedIndexInformer) *MacPool {
+	return &MacPool{
+		client:      client,
+		namespace:   namespace,
+		vmInformer:  vmInformer,
+		vmiInformer: vmiInformer,
+		clock:       clock.RealClock{},
+	}
+}
+
+type macRange struct {
+	start uint64
+	end   uint64
+}
+
+func (r macRange) size() uint64 {
+	return r.end - r.start + 1
+}
+
+func parseRanges(config *v1.MacPoolConfiguration) ([]macRange, error) {
+	var ranges []macRange
+	for _, poolRange := range config.Ranges {
+		start, err := parseMac(poolRange.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseMac(poolRange.End)
+		if err != nil {
+			return nil, err
+		}
+		if start > end {
+			return nil, fmt.Errorf("invalid MAC pool range %s-%s, the start is after the end", poolRange.Start, poolRange.End)
+		}
+		ranges = append(ranges, macRange{start: start, end: end})
+	}
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("the MAC pool has no ranges")
+	}
+	return ranges, nil
+}
+
+func parseMac(mac string) (uint64, error) {
+	hwAddr, err := net.ParseMAC(mac)
+	if err != nil || len(hwAddr) != macAddressBits/8 {
+		return 0, fmt.Errorf("invalid MAC address %s in the MAC pool", mac)
+	}
+	var value uint64
+	for _, b := range hwAddr {
+		value = value<<8 | uint64(b)
+	}
+	return value, nil
+}
+
+func formatMac(value uint64) string {
+	hwAddr := make(net.HardwareAddr, macAddressBits/8)
+	for i := len(hwAddr) - 1; i >= 0; i-- {
+		hwAddr[i] = byte(value)
+		value >>= 8
+	}
+	return hwAddr.String()
+}
+
+// AssignMacAddresses checks the MAC addresses of the interfaces against the addresses in use by other
+// owners and allocates addresses to the interfaces on Multus networks which have none. The allocation
+// starts at an offset derived from the owner and the interface name, so that an owner gets the same
+// addresses again as long as they are free. Addresses in previousSpec were checked before and are
+// accepted as they are. The new addresses are reserved for the owner in the reservations ConfigMap,
+// the allocation is retried if another instance of the pool updated the ConfigMap concurrently.
+// The ConfigMap is not touched if there is nothing to allocate or check.
+func (p *MacPool) AssignMacAddresses(config *v1.MacPoolConfiguration, owner string, spec *v1.VirtualMachineInstanceSpec, previousSpec *v1.VirtualMachineInstanceSpec) error {
+	if _, err := specMacAddresses(spec); err != nil {
+		return err
+	}
+	if !needsAssignment(spec, previousSpec) {
+		return nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var assigned *v1.VirtualMachineInstanceSpec
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		r, err := p.loadReservations()
+		if err != nil {
+			return err
+		}
+		assigned = spec.DeepCopy()
+		if err := p.assign(r, config, owner, assigned, previousSpec); err != nil {
+			return err
+		}
+		return p.storeReservations(r)
+	})
+	if err != nil {
+		return err
+	}
+	for i := range spec.Domain.Devices.Interfaces {
+		spec.Domain.Devices.Interfaces[i].MacAddress = assigned.Domain.Devices.Interfaces[i].MacAddress
+	}
+	return nil
+}
+
+// specMacAddresses returns the valid MAC addresses of the interfaces of the spec by the name of
+// their interface. A MAC address used by more than one interface is rejected.
+func specMacAddresses(spec *v1.VirtualMachineInstanceSpec) (map[string]string, error) {
+	macs := map[string]string{}
+	for _, iface := range spec.Domain.Devices.Interfaces {
+		mac, err := net.ParseMAC(iface.MacAddress)
+		if err != nil {
+			// the validating webhook rejects it
+			continue
+		}
+		if other, exists := macs[mac.String()]; exists {
+			return nil, fmt.Errorf("MAC address %s of interface %s is already used by interface %s", iface.MacAddress, iface.Name, other)
+		}
+		macs[mac.String()] = iface.Name
+	}
+	return macs, nil
+}
+
+func previousMacAddresses(previousSpec *v1.VirtualMachineInstanceSpec) map[string]bool {
+	accepted := map[string]bool{}
+	if previousSpec != nil {
+		for _, iface := range previousSpec.Domain.Devices.Interfaces {
+			if mac, err := net.ParseMAC(iface.MacAddress); err == nil {
+				accepted[mac.String()] = true
+			}
+		}
+	}
+	return accepted
+}
+
+func multusNetworks(spec *v1.VirtualMachineInstanceSpec) map[string]bool {
+	networks := map[string]bool{}
+	for _, network := range spec.Networks {
+		if network.Multus != nil {
+			networks[network.Name] = true
+		}
+	}
+	return networks
+}
+
+// needsAssignment reports whether an interface on a Multus network has no MAC address or an
+// interface has a MAC address which was not checked before
+func needsAssignment(spec *v1.VirtualMachineInstanceSpec, previousSpec *v1.VirtualMachineInstanceSpec) bool {
+	accepted := previousMacAddresses(previousSpec)
+	networks := multusNetworks(spec)
+	for _, iface := range spec.Domain.Devices.Interfaces {
+		if iface.MacAddress == "" {
+			if networks[iface.Name] {
+				return true
+			}
+			continue
+		}
+		if mac, err := net.ParseMAC(iface.MacAddress); err == nil && !accepted[mac.String()] {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *MacPool) assign(r *reservations, config *v1.MacPoolConfiguration, owner string, spec *v1.VirtualMachineInstanceSpec, previousSpec *v1.VirtualMachineInstanceSpec) error {
+	used, err := specMacAddresses(spec)
+	if err != nil {
+		return err
+	}
+	accepted := previousMacAddresses(previousSpec)
+	networks := multusNetworks(spec)
+
+	var ranges []macRange
+	for i, iface := range spec.Domain.Devices.Interfaces {
+		if iface.MacAddress != "" {
+			mac, err := net.ParseMAC(iface.MacAddress)
+			if err != nil {
+				// the validating webhook rejects it
+				continue
+			}
+			if accepted[mac.String()] {
+				continue
+			}
+			if p.isInUse(r, mac.String(), owner) {
+				return fmt.Errorf("MAC address %s of interface %s is already in use", iface.MacAddress, iface.Name)
+			}
+			p.reserve(r, mac.String(), owner)
+			continue
+		}
+		if !networks[iface.Name] {
+			continue
+		}
+
+		if ranges == nil {
+			var err error
+			if ranges, err = parseRanges(config); err != nil {
+				return err
+			}
+		}
+		mac, err := p.allocate(r, ranges, owner, iface.Name, used)
+		if err != nil {
+			return err
+		}
+		used[mac] = iface.Name
+		spec.Domain.Devices.Interfaces[i].MacAddress = mac
+	}
+	return nil
+}
+
+// allocate finds a free MAC address for the interface, skipping the addresses used by the other
+// interfaces of the spec
+func (p *MacPool) allocate(r *reservations, ranges []macRange, owner string, ifaceName string, used map[string]string) (string, error) {
+	var total uint64
+	for _, r := range ranges {
+		total += r.size()
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(owner + "/" + ifaceName))
+	offset := hash.Sum64() % total
+
+	for i := uint64(0); i < total; i++ {
+		value := addressAt(ranges, (offset+i)%total)
+		if value&multicastBit != 0 {
+			continue
+		}
+		mac := formatMac(value)
+		if _, exists := used[mac]; exists {
+			continue
+		}
+		if !p.isInUse(r, mac, owner) {
+			p.reserve(r, mac, owner)
+			return mac, nil
+		}
+	}
+	return "", fmt.Errorf("the MAC pool is exhausted")
+}
+
+func addressAt(ranges []macRange, index uint64) uint64 {
+	for _, r := range ranges {
+		if index < r.size() {
+			return r.start + index
+		}
+		index -= r.size()
+	}
+	return 0
+}
+
+func (p *MacPool) isInUse(r *reservations, mac string, owner string) bool {
+	if reserved, exists := r.byMac[mac]; exists && reserved.Owner != owner {
+		return true
+	}
+	for _, informer := range []cache.SharedIndexInformer{p.vmInformer, p.vmiInformer} {
+		objs, err := informer.GetIndexer().ByIndex(MacAddressIndex, mac)
+		if err != nil {
+			// be conservative if the informer is not indexed
+			return true
+		}
+		for _, obj := range objs {
+			if metaObj, ok := obj.(metav1.Object); ok && OwnerKey(metaObj) != owner {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *MacPool) reserve(r *reservations, mac string, owner string) {
+	if reserved, exists := r.byMac[mac]; exists && reserved.Owner == owner {
+		return
+	}
+	r.byMac[mac] = reservation{Owner: owner, Expires: metav1.NewTime(p.clock.Now().Add(reservationTimeout))}
+	r.changed = true
+}
+
+// loadReservations reads the reservations from the API server, the cached ConfigMap may be
+// behind the updates of other instances of the pool. Expired reservations are dropped.
+func (p *MacPool) loadReservations() (*reservations, error) {
+	configMap, err := p.client.CoreV1().ConfigMaps(p.namespace).Get(context.Background(), ReservationsConfigMapName, metav1.GetOptions{})
+	exists := err == nil
+	if errors.IsNotFound(err) {
+		configMap = &k8sv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ReservationsConfigMapName,
+				Namespace: p.namespace,
+				Labels: map[string]string{
+					v1.AppLabel: "",
+				},
+			},
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read the MAC pool reservations: %v", err)
+	}
+
+	r := &reservations{
+		configMap: configMap,
+		exists:    exists,
+		byMac:     map[string]reservation{},
+	}
+	now := p.clock.Now()
+	for key, value := range configMap.Data {
+		reserved := reservation{}
+		if err := json.Unmarshal([]byte(value), &reserved); err != nil || now.After(reserved.Expires.Time) {
+			r.changed = true
+			continue
+		}
+		r.byMac[strings.Replace(key, "-", ":", -1)] = reserved
+	}
+	return r, nil
+}
+
+// storeReservations writes changed reservations back. A conflict is returned if another instance
+// of the pool updated or created the ConfigMap since it was read.
+func (p *MacPool) storeReservations(r *reservations) error {
+	if !r.changed {
+		return nil
+	}
+	configMap := r.configMap.DeepCopy()
+	configMap.Data = map[string]string{}
+	for mac, reserved := range r.byMac {
+		value, err := json.Marshal(reserved)
+		if err != nil {
+			return err
+		}
+		// ConfigMap keys must not contain colons
+		configMap.Data[strings.Replace(mac, ":", "-", -1)] = string(value)
+	}
+
+	var err error
+	if !r.exists {
+		_, err = p.client.CoreV1().ConfigMaps(p.namespace).Create(context.Background(), configMap, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			err = errors.NewConflict(schema.GroupResource{Resource: "configmaps"}, configMap.Name, err)
+		}
+	} else {
+		_, err = p.client.CoreV1().ConfigMaps(p.namespace).Update(context.Background(), configMap, metav1.UpdateOptions{})
+	}
+	if err != nil && !errors.IsConflict(err) {
+		return fmt.Errorf("failed to store the MAC pool reservations: %v", err)
+	}
+	return err
+}
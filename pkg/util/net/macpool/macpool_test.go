@@ -0,0 +1,253 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package macpool
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("MacPool", func() {
+	var vmInformer cache.SharedIndexInformer
+	var vmiInformer cache.SharedIndexInformer
+	var fakeClock *clock.FakeClock
+	var virtClient *kubecli.MockKubevirtClient
+	var kubeClient *fake.Clientset
+	var pool *MacPool
+	var config *v1.MacPoolConfiguration
+
+	newSpec := func(macAddress string) *v1.VirtualMachineInstanceSpec {
+		return &v1.VirtualMachineInstanceSpec{
+			Domain: v1.DomainSpec{
+				Devices: v1.Devices{
+					Interfaces: []v1.Interface{
+						{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}},
+						{Name: "secondary", MacAddress: macAddress, InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+					},
+				},
+			},
+			Networks: []v1.Network{
+				*v1.DefaultPodNetwork(),
+				{Name: "secondary", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "net"}}},
+			},
+		}
+	}
+
+	newVMI := func(namespace, name, macAddress string) *v1.VirtualMachineInstance {
+		return &v1.VirtualMachineInstance{
+			ObjectMeta: k8smetav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       *newSpec(macAddress),
+		}
+	}
+
+	BeforeEach(func() {
+		indexers := cache.Indexers{MacAddressIndex: IndexByMacAddress}
+		vmInformer, _ = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachine{}, indexers)
+		vmiInformer, _ = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachineInstance{}, indexers)
+		fakeClock = clock.NewFakeClock(time.Now())
+		virtClient = kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		pool = NewMacPool(virtClient, "kubevirt", vmInformer, vmiInformer)
+		pool.clock = fakeClock
+		config = &v1.MacPoolConfiguration{
+			Ranges: []v1.MacPoolRange{{Start: "02:00:00:00:00:00", End: "02:00:00:00:00:0f"}},
+		}
+	})
+
+	It("should allocate a MAC address of the range to interfaces on Multus networks", func() {
+		spec := newSpec("")
+		Expect(pool.AssignMacAddresses(config, "default/testvmi", spec, nil)).To(Succeed())
+		Expect(spec.Domain.Devices.Interfaces[0].MacAddress).To(BeEmpty())
+		Expect(spec.Domain.Devices.Interfaces[1].MacAddress).To(HavePrefix("02:00:00:00:00:0"))
+	})
+
+	It("should allocate the same MAC address to the same owner", func() {
+		first := newSpec("")
+		Expect(pool.AssignMacAddresses(config, "default/testvmi", first, nil)).To(Succeed())
+		second := newSpec("")
+		Expect(pool.AssignMacAddresses(config, "default/testvmi", second, nil)).To(Succeed())
+		Expect(second.Domain.Devices.Interfaces[1].MacAddress).To(Equal(first.Domain.Devices.Interfaces[1].MacAddress))
+	})
+
+	It("should skip MAC addresses in use by other owners", func() {
+		config.Ranges[0].End = "02:00:00:00:00:01"
+		vmiInformer.GetIndexer().Add(newVMI("default", "other", "02:00:00:00:00:00"))
+		vmInformer.GetIndexer().Add(&v1.VirtualMachine{
+			ObjectMeta: k8smetav1.ObjectMeta{Namespace: "default", Name: "othervm"},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{Spec: *newSpec("02:00:00:00:00:01")},
+			},
+		})
+
+		spec := newSpec("")
+		Expect(pool.AssignMacAddresses(config, "default/testvmi", spec, nil)).To(MatchError("the MAC pool is exhausted"))
+	})
+
+	It("should not allocate multicast MAC addresses", func() {
+		config.Ranges[0] = v1.MacPoolRange{Start: "01:ff:ff:ff:ff:ff", End: "02:00:00:00:00:00"}
+
+		spec := newSpec("")
+		Expect(pool.AssignMacAddresses(config, "default/testvmi", spec, nil)).To(Succeed())
+		Expect(spec.Domain.Devices.Interfaces[1].MacAddress).To(Equal("02:00:00:00:00:00"))
+	})
+
+	It("should reserve allocated MAC addresses until they expire", func() {
+		config.Ranges[0].End = "02:00:00:00:00:00"
+		Expect(pool.AssignMacAddresses(config, "default/first", newSpec(""), nil)).To(Succeed())
+		Expect(pool.AssignMacAddresses(config, "default/second", newSpec(""), nil)).To(HaveOccurred())
+
+		fakeClock.Step(reservationTimeout + time.Second)
+		Expect(pool.AssignMacAddresses(config, "default/second", newSpec(""), nil)).To(Succeed())
+	})
+
+	It("should share the reservations with the other instances of the pool", func() {
+		config.Ranges[0].End = "02:00:00:00:00:00"
+		Expect(pool.AssignMacAddresses(config, "default/first", newSpec(""), nil)).To(Succeed())
+
+		configMap, err := kubeClient.CoreV1().ConfigMaps("kubevirt").Get(context.Background(), ReservationsConfigMapName, k8smetav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap.Data).To(HaveKey("02-00-00-00-00-00"))
+
+		otherPool := NewMacPool(virtClient, "kubevirt", vmInformer, vmiInformer)
+		otherPool.clock = fakeClock
+		Expect(otherPool.AssignMacAddresses(config, "default/second", newSpec(""), nil)).To(MatchError("the MAC pool is exhausted"))
+		Expect(otherPool.AssignMacAddresses(config, "default/third", newSpec("02:00:00:00:00:00"), nil)).To(
+			MatchError("MAC address 02:00:00:00:00:00 of interface secondary is already in use"))
+	})
+
+	It("should retry the allocation if the reservations were updated concurrently", func() {
+		config.Ranges[0].End = "02:00:00:00:00:01"
+		// another instance of the pool reserves the same address between the read and the write of the reservations
+		var contended string
+		kubeClient.Fake.PrependReactor("create", "configmaps", func(action testing.Action) (bool, runtime.Object, error) {
+			if contended != "" {
+				return false, nil, nil
+			}
+			configMap := action.(testing.CreateAction).GetObject().(*k8sv1.ConfigMap).DeepCopy()
+			for key := range configMap.Data {
+				contended = key
+				value, err := json.Marshal(reservation{Owner: "default/other", Expires: k8smetav1.NewTime(fakeClock.Now().Add(reservationTimeout))})
+				Expect(err).ToNot(HaveOccurred())
+				configMap.Data[key] = string(value)
+			}
+			Expect(kubeClient.Tracker().Add(configMap)).To(Succeed())
+			return true, nil, errors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, ReservationsConfigMapName)
+		})
+
+		spec := newSpec("")
+		Expect(pool.AssignMacAddresses(config, "default/testvmi", spec, nil)).To(Succeed())
+		Expect(contended).ToNot(BeEmpty())
+		Expect(strings.Replace(spec.Domain.Devices.Interfaces[1].MacAddress, ":", "-", -1)).ToNot(Equal(contended))
+
+		configMap, err := kubeClient.CoreV1().ConfigMaps("kubevirt").Get(context.Background(), ReservationsConfigMapName, k8smetav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap.Data).To(HaveLen(2))
+	})
+
+	It("should drop expired reservations from the ConfigMap", func() {
+		Expect(pool.AssignMacAddresses(config, "default/first", newSpec(""), nil)).To(Succeed())
+		fakeClock.Step(reservationTimeout + time.Second)
+		spec := newSpec("")
+		Expect(pool.AssignMacAddresses(config, "default/second", spec, nil)).To(Succeed())
+
+		configMap, err := kubeClient.CoreV1().ConfigMaps("kubevirt").Get(context.Background(), ReservationsConfigMapName, k8smetav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap.Data).To(HaveLen(1))
+		Expect(configMap.Data).To(HaveKey(strings.Replace(spec.Domain.Devices.Interfaces[1].MacAddress, ":", "-", -1)))
+	})
+
+	It("should reject a manual MAC address in use by another owner", func() {
+		vmiInformer.GetIndexer().Add(newVMI("default", "other", "02:00:00:00:00:05"))
+		err := pool.AssignMacAddresses(config, "default/testvmi", newSpec("02:00:00:00:00:05"), nil)
+		Expect(err).To(MatchError("MAC address 02:00:00:00:00:05 of interface secondary is already in use"))
+	})
+
+	It("should accept a manual MAC address in use by the VirtualMachine of the instance", func() {
+		vmInformer.GetIndexer().Add(&v1.VirtualMachine{
+			ObjectMeta: k8smetav1.ObjectMeta{Namespace: "default", Name: "testvm"},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{Spec: *newSpec("02:00:00:00:00:05")},
+			},
+		})
+		vmi := newVMI("default", "testvm", "02:00:00:00:00:05")
+		vmi.OwnerReferences = []k8smetav1.OwnerReference{
+			*k8smetav1.NewControllerRef(&v1.VirtualMachine{ObjectMeta: k8smetav1.ObjectMeta{Name: "testvm"}}, v1.VirtualMachineGroupVersionKind),
+		}
+		Expect(pool.AssignMacAddresses(config, OwnerKey(vmi), &vmi.Spec, nil)).To(Succeed())
+	})
+
+	It("should accept MAC addresses of the previous spec", func() {
+		vmiInformer.GetIndexer().Add(newVMI("default", "other", "02:00:00:00:00:05"))
+		spec := newSpec("02:00:00:00:00:05")
+		Expect(pool.AssignMacAddresses(config, "default/testvm", spec, spec.DeepCopy())).To(Succeed())
+	})
+
+	It("should reject a MAC address used by two interfaces of the spec", func() {
+		spec := newSpec("02:00:00:00:00:05")
+		spec.Domain.Devices.Interfaces[0].MacAddress = "02:00:00:00:00:05"
+		err := pool.AssignMacAddresses(config, "default/testvmi", spec, spec.DeepCopy())
+		Expect(err).To(MatchError("MAC address 02:00:00:00:00:05 of interface secondary is already used by interface default"))
+	})
+
+	It("should not allocate a MAC address used by another interface of the spec", func() {
+		config.Ranges[0].End = "02:00:00:00:00:00"
+		spec := newSpec("")
+		spec.Domain.Devices.Interfaces[0].MacAddress = "02:00:00:00:00:00"
+		Expect(pool.AssignMacAddresses(config, "default/testvmi", spec, nil)).To(MatchError("the MAC pool is exhausted"))
+	})
+
+	It("should not access the reservations if there is nothing to assign", func() {
+		spec := newSpec("")
+		spec.Networks = spec.Networks[:1]
+		Expect(pool.AssignMacAddresses(config, "default/testvmi", spec, nil)).To(Succeed())
+
+		spec = newSpec("02:00:00:00:00:05")
+		Expect(pool.AssignMacAddresses(config, "default/testvmi", spec, spec.DeepCopy())).To(Succeed())
+		Expect(kubeClient.Actions()).To(BeEmpty())
+	})
+
+	It("should reject invalid ranges", func() {
+		config.Ranges[0] = v1.MacPoolRange{Start: "02:00:00:00:00:0f", End: "02:00:00:00:00:00"}
+		Expect(pool.AssignMacAddresses(config, "default/testvmi", newSpec(""), nil)).To(HaveOccurred())
+
+		config.Ranges[0] = v1.MacPoolRange{Start: "invalid", End: "02:00:00:00:00:00"}
+		Expect(pool.AssignMacAddresses(config, "default/testvmi", newSpec(""), nil)).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,50 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package namescheme
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+)
+
+const hotplugPodInterfacePrefix = "hp"
+
+// hotplugHashLength keeps the derived bridge names, e.g. k6t-hp1a2b3-nic,
+// within the 15 character limit of Linux interface names.
+const hotplugHashLength = 5
+
+var hotplugPodInterfaceNameRegex = regexp.MustCompile(fmt.Sprintf("^%s[0-9a-f]{%d}$", hotplugPodInterfacePrefix, hotplugHashLength))
+
+// HotplugPodInterfaceName returns the name of the pod interface Multus creates
+// for a network hotplugged into a running VMI. Pod interfaces of networks
+// present when the pod was created are named by their ordinal (net1, net2, ...),
+// which would shift on unplug, so hotplugged networks use a name derived
+// from the network name instead.
+func HotplugPodInterfaceName(networkName string) string {
+	hash := sha256.Sum256([]byte(networkName))
+	return fmt.Sprintf("%s%x", hotplugPodInterfacePrefix, hash)[:len(hotplugPodInterfacePrefix)+hotplugHashLength]
+}
+
+// IsHotplugPodInterfaceName reports whether a pod interface name was generated
+// by HotplugPodInterfaceName.
+func IsHotplugPodInterfaceName(name string) bool {
+	return hotplugPodInterfaceNameRegex.MatchString(name)
+}
@@ -0,0 +1,50 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package namescheme
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Name scheme", func() {
+	It("should generate a stable, short pod interface name for hotplugged networks", func() {
+		name := HotplugPodInterfaceName("blue")
+		Expect(name).To(HavePrefix("hp"))
+		Expect(name).To(HaveLen(7))
+		Expect(HotplugPodInterfaceName("blue")).To(Equal(name))
+		Expect(HotplugPodInterfaceName("red")).ToNot(Equal(name))
+		Expect(IsHotplugPodInterfaceName(name)).To(BeTrue())
+	})
+
+	table.DescribeTable("should not consider as hotplugged the pod interface", func(name string) {
+		Expect(IsHotplugPodInterfaceName(name)).To(BeFalse())
+	},
+		table.Entry("of an ordinal network", "net1"),
+		table.Entry("of the pod network", "eth0"),
+		table.Entry("with a short hash", "hp1a2b"),
+		table.Entry("with a long hash", "hp1a2b3c"),
+		table.Entry("with a non hexadecimal hash", "hpxyz12"),
+		table.Entry("with an upper case hash", "hp1A2B3"),
+		table.Entry("with another prefix", "xp1a2b3"),
+		table.Entry("of a derived bridge", "k6t-hp1a2b3"),
+	)
+})
@@ -0,0 +1,94 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package portforward
+
+import (
+	"fmt"
+	"net"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	// MinPort and MaxPort bound the guest ports which can be forwarded
+	MinPort = 1
+	MaxPort = 65535
+
+	// guestVMAddressOffset is the offset of the guest address in the masquerade network,
+	// after the network address and the gateway
+	guestVMAddressOffset = 2
+)
+
+// GuestAddress returns the address which is dialed from the network namespace of the
+// virt-launcher pod to reach a port of the guest.
+//
+// A masquerade guest is reached directly on its address in the VM network, so that all
+// of its ports can be forwarded. Slirp forwards the declared ports of the interface
+// from the pod, they are reached on the loopback address. Other bindings hand the pod
+// address over to the guest and leave nothing to dial from within the pod.
+func GuestAddress(vmi *v1.VirtualMachineInstance) (string, error) {
+	var podNetwork *v1.Network
+	for i, network := range vmi.Spec.Networks {
+		if network.Pod != nil {
+			podNetwork = &vmi.Spec.Networks[i]
+			break
+		}
+	}
+	if podNetwork == nil {
+		return "", fmt.Errorf("VirtualMachineInstance %s has no pod network", vmi.Name)
+	}
+
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Name != podNetwork.Name {
+			continue
+		}
+		switch {
+		case iface.Masquerade != nil:
+			return masqueradeGuestAddress(podNetwork.Pod.VMNetworkCIDR)
+		case iface.Slirp != nil:
+			return "127.0.0.1", nil
+		default:
+			return "", fmt.Errorf("port forwarding is only supported for masquerade and slirp interfaces on the pod network")
+		}
+	}
+	return "", fmt.Errorf("VirtualMachineInstance %s has no interface connected to the pod network", vmi.Name)
+}
+
+func masqueradeGuestAddress(vmNetworkCIDR string) (string, error) {
+	if vmNetworkCIDR == "" {
+		vmNetworkCIDR = api.DefaultVMCIDR
+	}
+	_, ipNet, err := net.ParseCIDR(vmNetworkCIDR)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse VM network CIDR %s: %v", vmNetworkCIDR, err)
+	}
+
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		return "", fmt.Errorf("VM network CIDR %s is not an IPv4 network", vmNetworkCIDR)
+	}
+	guestIP := make(net.IP, len(ip))
+	copy(guestIP, ip)
+	guestIP[len(guestIP)-1] += guestVMAddressOffset
+	if !ipNet.Contains(guestIP) {
+		return "", fmt.Errorf("VM network CIDR %s is too small", vmNetworkCIDR)
+	}
+	return guestIP.String(), nil
+}
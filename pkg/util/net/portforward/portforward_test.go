@@ -0,0 +1,70 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package portforward
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Port forward guest address", func() {
+	newVMI := func(iface v1.Interface, network v1.Network) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+		vmi.Spec.Networks = []v1.Network{network}
+		return vmi
+	}
+
+	table.DescribeTable("should resolve the address of the guest", func(iface v1.Interface, network v1.Network, expectedAddress string) {
+		address, err := GuestAddress(newVMI(iface, network))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(address).To(Equal(expectedAddress))
+	},
+		table.Entry("with masquerade on the default VM network",
+			v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}},
+			*v1.DefaultPodNetwork(), "10.0.2.2"),
+		table.Entry("with masquerade on a custom VM network",
+			v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}},
+			v1.Network{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{VMNetworkCIDR: "192.168.100.0/24"}}}, "192.168.100.2"),
+		table.Entry("with slirp",
+			v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}},
+			*v1.DefaultPodNetwork(), "127.0.0.1"),
+	)
+
+	table.DescribeTable("should fail", func(iface v1.Interface, network v1.Network) {
+		_, err := GuestAddress(newVMI(iface, network))
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("with bridge on the pod network",
+			v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			*v1.DefaultPodNetwork()),
+		table.Entry("without pod network",
+			v1.Interface{Name: "red", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			v1.Network{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red"}}}),
+		table.Entry("without interface on the pod network",
+			v1.Interface{Name: "red", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}},
+			*v1.DefaultPodNetwork()),
+		table.Entry("with a VM network too small for the guest",
+			v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}},
+			v1.Network{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{VMNetworkCIDR: "10.0.2.0/31"}}}),
+	)
+})
@@ -0,0 +1,124 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package vhostuser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// NetworkStatusAnnotation is set by Multus on the pod and describes the attached networks
+	NetworkStatusAnnotation = "k8s.v1.cni.cncf.io/network-status"
+	// NetworkStatusVolumeName is the name of the downward API volume exposing NetworkStatusAnnotation
+	NetworkStatusVolumeName = "network-status"
+	// NetworkStatusDir is where the compute container finds NetworkStatusFile
+	NetworkStatusDir = "/var/run/kubevirt-network-status"
+	// NetworkStatusFile holds the value of NetworkStatusAnnotation
+	NetworkStatusFile = "network-status"
+	// SocketsVolumeName is the name of the pod volume holding the vhost-user sockets of the VMI.
+	// The userspace CNI creates the sockets in the host directory of the pod volume with this name.
+	SocketsVolumeName = "shared-dir"
+
+	deviceInfoTypeVhostUser = "vhost-user"
+
+	modeClient = "client"
+	modeServer = "server"
+)
+
+// Socket describes the vhost-user socket a pod interface is connected to
+type Socket struct {
+	Path string
+	// Mode is the vhost-user role QEMU takes on the socket, client or server
+	Mode string
+}
+
+// networkStatus follows the network-status entries of the Network Plumbing Working Group,
+// only the fields needed to find the vhost-user sockets are kept
+type networkStatus struct {
+	Name       string      `json:"name"`
+	Interface  string      `json:"interface,omitempty"`
+	DeviceInfo *deviceInfo `json:"device-info,omitempty"`
+}
+
+type deviceInfo struct {
+	Type      string           `json:"type"`
+	VhostUser *vhostUserDevice `json:"vhost-user,omitempty"`
+}
+
+type vhostUserDevice struct {
+	Mode string `json:"mode"`
+	Path string `json:"path"`
+}
+
+// NetworkStatusPath returns the path of NetworkStatusFile in the compute container
+func NetworkStatusPath() string {
+	return filepath.Join(NetworkStatusDir, NetworkStatusFile)
+}
+
+// LookupSocket reads the network status stored at path and returns the vhost-user socket
+// reported for the pod interface
+func LookupSocket(path string, podInterfaceName string) (*Socket, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the network status: %v", err)
+	}
+	var statuses []networkStatus
+	if err := json.Unmarshal(content, &statuses); err != nil {
+		return nil, fmt.Errorf("failed to parse the network status: %v", err)
+	}
+	for _, status := range statuses {
+		if status.Interface != podInterfaceName {
+			continue
+		}
+		if status.DeviceInfo == nil || status.DeviceInfo.Type != deviceInfoTypeVhostUser || status.DeviceInfo.VhostUser == nil {
+			return nil, fmt.Errorf("network %s attached to pod interface %s is not a vhost-user network", status.Name, podInterfaceName)
+		}
+		if status.DeviceInfo.VhostUser.Path == "" {
+			return nil, fmt.Errorf("network %s does not report a vhost-user socket path", status.Name)
+		}
+		mode, err := qemuMode(status.DeviceInfo.VhostUser.Mode)
+		if err != nil {
+			return nil, fmt.Errorf("network %s: %v", status.Name, err)
+		}
+		return &Socket{Path: status.DeviceInfo.VhostUser.Path, Mode: mode}, nil
+	}
+	return nil, fmt.Errorf("pod interface %s is not part of the network status", podInterfaceName)
+}
+
+// SocketDir returns the host directory holding the vhost-user sockets of a VMI
+func SocketDir(baseDir string, vmiUID types.UID) string {
+	return filepath.Join(baseDir, string(vmiUID))
+}
+
+// qemuMode returns the vhost-user role of QEMU for a socket. The device-info reports
+// the role of the switch and QEMU has to take the other end of the socket.
+func qemuMode(switchMode string) (string, error) {
+	switch switchMode {
+	case modeServer:
+		return modeClient, nil
+	case modeClient:
+		return modeServer, nil
+	}
+	return "", fmt.Errorf("unknown vhost-user socket mode %q", switchMode)
+}
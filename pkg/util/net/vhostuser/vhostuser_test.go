@@ -0,0 +1,92 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package vhostuser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("vhost-user network status", func() {
+	var tmpDir string
+	var path string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "network-status")
+		Expect(err).ToNot(HaveOccurred())
+		path = filepath.Join(tmpDir, NetworkStatusFile)
+		Expect(ioutil.WriteFile(path, []byte(`[
+  {"name": "kindnet", "interface": "eth0", "ips": ["10.244.0.7"], "default": true},
+  {"name": "default/ovs-dpdk", "interface": "net1", "device-info": {"type": "vhost-user", "version": "1.0.0",
+    "vhost-user": {"mode": "server", "path": "/var/lib/cni/usrspcni/3a4b5c-net1"}}},
+  {"name": "default/sriov", "interface": "net2", "device-info": {"type": "pci", "version": "1.0.0",
+    "pci": {"pci-address": "0000:18:02.5"}}}
+]`), 0644)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should return the socket of a vhost-user pod interface", func() {
+		socket, err := LookupSocket(path, "net1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(socket).To(Equal(&Socket{Path: "/var/lib/cni/usrspcni/3a4b5c-net1", Mode: "client"}))
+	})
+
+	table.DescribeTable("should connect QEMU to the other end of the socket", func(switchMode, qemuMode string) {
+		Expect(ioutil.WriteFile(path, []byte(fmt.Sprintf(`[{"name": "default/ovs-dpdk", "interface": "net1", "device-info": {"type": "vhost-user",
+  "version": "1.0.0", "vhost-user": {"mode": "%s", "path": "/var/lib/cni/usrspcni/3a4b5c-net1"}}}]`, switchMode)), 0644)).To(Succeed())
+		socket, err := LookupSocket(path, "net1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(socket.Mode).To(Equal(qemuMode))
+	},
+		table.Entry("as client if the switch is the server", "server", "client"),
+		table.Entry("as server if the switch is the client", "client", "server"),
+	)
+
+	It("should fail for an unknown socket mode", func() {
+		Expect(ioutil.WriteFile(path, []byte(`[{"name": "default/ovs-dpdk", "interface": "net1", "device-info": {"type": "vhost-user",
+  "version": "1.0.0", "vhost-user": {"mode": "", "path": "/var/lib/cni/usrspcni/3a4b5c-net1"}}}]`), 0644)).To(Succeed())
+		_, err := LookupSocket(path, "net1")
+		Expect(err).To(MatchError(`network default/ovs-dpdk: unknown vhost-user socket mode ""`))
+	})
+
+	It("should fail for a pod interface which is not attached to a vhost-user network", func() {
+		_, err := LookupSocket(path, "net2")
+		Expect(err).To(MatchError("network default/sriov attached to pod interface net2 is not a vhost-user network"))
+	})
+
+	It("should fail for an unknown pod interface", func() {
+		_, err := LookupSocket(path, "net3")
+		Expect(err).To(MatchError("pod interface net3 is not part of the network status"))
+	})
+
+	It("should fail if the network status is not available", func() {
+		_, err := LookupSocket(filepath.Join(tmpDir, "missing"), "net1")
+		Expect(err).To(HaveOccurred())
+	})
+})
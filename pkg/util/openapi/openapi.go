@@ -17,6 +17,10 @@ import (
 	"k8s.io/kube-openapi/pkg/common"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	backupv1alpha1 "kubevirt.io/client-go/apis/backup/v1alpha1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 )
 
@@ -108,6 +112,30 @@ func createConfig() *common.Config {
 					m[k] = v
 				}
 			}
+			m3 := instancetypev1alpha1.GetOpenAPIDefinitions(ref)
+			for k, v := range m3 {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
+			m4 := exportv1alpha1.GetOpenAPIDefinitions(ref)
+			for k, v := range m4 {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
+			m5 := clonev1alpha1.GetOpenAPIDefinitions(ref)
+			for k, v := range m5 {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
+			m6 := backupv1alpha1.GetOpenAPIDefinitions(ref)
+			for k, v := range m6 {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
 			return m
 		},
 
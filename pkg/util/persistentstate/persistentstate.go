@@ -0,0 +1,65 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package persistentstate
+
+import (
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	// VolumeName is the name of the pod volume holding the persistent state
+	VolumeName = "vm-state"
+	// PVCSize is the size of the PVC created for the persistent state
+	PVCSize = "10Mi"
+
+	// NVRAMSubPath is the directory on the persistent state volume holding the EFI NVRAM
+	NVRAMSubPath = "nvram"
+	// NVRAMPath is where virt-launcher stores the EFI NVRAM of persistent domains
+	NVRAMPath = "/var/lib/libvirt/qemu/nvram"
+	// SWTPMSubPath is the directory on the persistent state volume holding the TPM state
+	SWTPMSubPath = "swtpm"
+	// SWTPMPath is where libvirt keeps the state of emulated TPM devices
+	SWTPMPath = "/var/lib/libvirt/swtpm"
+
+	pvcNamePrefix = "persistent-state-for-"
+)
+
+// PVCName returns the name of the PVC holding the persistent state of a VirtualMachine
+func PVCName(vmName string) string {
+	return pvcNamePrefix + vmName
+}
+
+// HasPersistentEFI returns true if the EFI NVRAM of the VMI is meant to be persisted
+func HasPersistentEFI(spec *v1.VirtualMachineInstanceSpec) bool {
+	firmware := spec.Domain.Firmware
+	return firmware != nil && firmware.Bootloader != nil && firmware.Bootloader.EFI != nil &&
+		firmware.Bootloader.EFI.Persistent != nil && *firmware.Bootloader.EFI.Persistent
+}
+
+// HasPersistentTPM returns true if the TPM state of the VMI is meant to be persisted
+func HasPersistentTPM(spec *v1.VirtualMachineInstanceSpec) bool {
+	tpm := spec.Domain.Devices.TPM
+	return tpm != nil && tpm.Persistent != nil && *tpm.Persistent
+}
+
+// HasPersistentState returns true if the VMI requires the persistent state volume
+func HasPersistentState(spec *v1.VirtualMachineInstanceSpec) bool {
+	return HasPersistentEFI(spec) || HasPersistentTPM(spec)
+}
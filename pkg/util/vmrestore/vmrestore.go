@@ -0,0 +1,140 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vmrestore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+// patchPaths are the only parts of the restored VirtualMachine the patches of a restore may change
+var patchPaths = []string{
+	"/metadata/labels",
+	"/metadata/annotations",
+	"/spec/template/",
+}
+
+// CloneRestoreName returns the name of the VirtualMachineRestore created by a VirtualMachineClone
+func CloneRestoreName(vmClone *clonev1alpha1.VirtualMachineClone) string {
+	return fmt.Sprintf("tmp-restore-%s", vmClone.UID)
+}
+
+// IsCloneRestore returns true for restores created by a VirtualMachineClone. Since anyone creating a
+// restore can set its owner references, the clone is looked up to confirm that it names the restore.
+func IsCloneRestore(client kubecli.KubevirtClient, vmRestore *snapshotv1.VirtualMachineRestore) (bool, error) {
+	owner := metav1.GetControllerOf(vmRestore)
+	if owner == nil || owner.APIVersion != clonev1alpha1.SchemeGroupVersion.String() || owner.Kind != "VirtualMachineClone" {
+		return false, nil
+	}
+
+	vmClone, err := client.VirtualMachineClone(vmRestore.Namespace).Get(context.Background(), owner.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return vmClone.UID == owner.UID && vmRestore.Name == CloneRestoreName(vmClone), nil
+}
+
+// ValidatePatches checks that the patches, each holding a single JSON patch operation, only
+// add, replace or remove labels, annotations or parts of the template of the restored VirtualMachine
+func ValidatePatches(patches []string) error {
+	_, err := decodePatches(patches)
+	return err
+}
+
+// ApplyPatches applies the validated patches to the VirtualMachine
+func ApplyPatches(vm *v1.VirtualMachine, patches []string) (*v1.VirtualMachine, error) {
+	if len(patches) == 0 {
+		return vm, nil
+	}
+
+	patch, err := decodePatches(patches)
+	if err != nil {
+		return nil, err
+	}
+
+	vmBytes, err := json.Marshal(vm)
+	if err != nil {
+		return nil, err
+	}
+
+	patchedBytes, err := patch.Apply(vmBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	patchedVM := &v1.VirtualMachine{}
+	if err = json.Unmarshal(patchedBytes, patchedVM); err != nil {
+		return nil, err
+	}
+
+	return patchedVM, nil
+}
+
+func decodePatches(patches []string) (jsonpatch.Patch, error) {
+	patch, err := jsonpatch.DecodePatch([]byte(fmt.Sprintf("[%s]", strings.Join(patches, ","))))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range patch {
+		switch op.Kind() {
+		case "add", "replace", "remove":
+		default:
+			return nil, fmt.Errorf("patch operation %q is not allowed", op.Kind())
+		}
+
+		path, err := op.Path()
+		if err != nil {
+			return nil, err
+		}
+		if !allowedPatchPath(path) {
+			return nil, fmt.Errorf("patch path %q is not allowed", path)
+		}
+	}
+
+	return patch, nil
+}
+
+func allowedPatchPath(path string) bool {
+	for _, allowed := range patchPaths {
+		if strings.HasSuffix(allowed, "/") {
+			if strings.HasPrefix(path, allowed) {
+				return true
+			}
+		} else if path == allowed || strings.HasPrefix(path, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
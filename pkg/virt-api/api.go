@@ -50,6 +50,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/rest/filter"
 	"kubevirt.io/kubevirt/pkg/service"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	"kubevirt.io/kubevirt/pkg/util/openapi"
 	webhooksutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/rest"
@@ -96,6 +97,7 @@ type virtAPIApp struct {
 	authorizor       rest.VirtApiAuthorizor
 	certsDirectory   string
 	clusterConfig    *virtconfig.ClusterConfig
+	macPool          *macpool.MacPool
 
 	namespace               string
 	host                    string
@@ -254,6 +256,44 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("freeze")).
+			To(subresourceApp.FreezeVMIRequestHandler).
+			Reads(v1.FreezeUnfreezeTimeout{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"Freeze").
+			Doc("Freeze a VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("unfreeze")).
+			To(subresourceApp.UnfreezeVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"Unfreeze").
+			Doc("Unfreeze a VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("backup")).
+			To(subresourceApp.BackupVMIRequestHandler).
+			Reads(v1.BackupOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"Backup").
+			Doc("Start a backup of a VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("endbackup")).
+			To(subresourceApp.EndBackupVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"EndBackup").
+			Doc("End the backup of a VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("console")).
 			To(subresourceApp.ConsoleRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -266,6 +306,18 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version + "VNC").
 			Doc("Open a websocket connection to connect to VNC on the specified VirtualMachineInstance."))
 
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("portforward") + rest.PortPath).
+			To(subresourceApp.PortForwardRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).Param(rest.PortForwardPortParam(subws)).
+			Operation(version.Version + "PortForward").
+			Doc("Open a websocket connection forwarding a TCP port of the specified VirtualMachineInstance."))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("backupnbd")).
+			To(subresourceApp.BackupNBDRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version + "BackupNBD").
+			Doc("Open a websocket connection to the NBD server of the backup in progress on the specified VirtualMachineInstance."))
+
 		// An empty handler function would respond with HTTP OK by default
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("test")).
 			To(func(request *restful.Request, response *restful.Response) {}).
@@ -355,6 +407,78 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusOK, "OK", "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("addinterface")).
+			To(subresourceApp.VMIAddInterfaceRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.AddInterfaceOptions{}).
+			Operation(version.Version+"vmi-addinterface").
+			Doc("Add a network interface to a running Virtual Machine Instance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("removeinterface")).
+			To(subresourceApp.VMIRemoveInterfaceRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.RemoveInterfaceOptions{}).
+			Operation(version.Version+"vmi-removeinterface").
+			Doc("Removes a network interface from a running Virtual Machine Instance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("setlinkstate")).
+			To(subresourceApp.VMISetLinkStateRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.SetLinkStateOptions{}).
+			Operation(version.Version+"vmi-setlinkstate").
+			Doc("Sets the link state of a network interface of a running Virtual Machine Instance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("setiotune")).
+			To(subresourceApp.VMISetIOTuneRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.SetIOTuneOptions{}).
+			Operation(version.Version+"vmi-setiotune").
+			Doc("Sets the I/O limits of a disk of a running Virtual Machine Instance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("injectmedia")).
+			To(subresourceApp.VMIInjectMediaRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.InjectMediaOptions{}).
+			Operation(version.Version+"vmi-injectmedia").
+			Doc("Inserts media into an empty CD-ROM of a running Virtual Machine Instance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("ejectmedia")).
+			To(subresourceApp.VMIEjectMediaRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.EjectMediaOptions{}).
+			Operation(version.Version+"vmi-ejectmedia").
+			Doc("Removes the media from a CD-ROM of a running Virtual Machine Instance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("addinterface")).
+			To(subresourceApp.VMAddInterfaceRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.AddInterfaceOptions{}).
+			Operation(version.Version+"vm-addinterface").
+			Doc("Add a network interface to a running Virtual Machine.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("removeinterface")).
+			To(subresourceApp.VMRemoveInterfaceRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.RemoveInterfaceOptions{}).
+			Operation(version.Version+"vm-removeinterface").
+			Doc("Removes a network interface from a running Virtual Machine.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		// Return empty api resource list.
 		// K8s expects to be able to retrieve a resource list for each aggregated
 		// app in order to discover what resources it provides. Without returning
@@ -377,6 +501,10 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/console",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/portforward",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/pause",
 						Namespaced: true,
@@ -385,6 +513,26 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/unpause",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/freeze",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/unfreeze",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/backup",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/endbackup",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/backupnbd",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachines/start",
 						Namespaced: true,
@@ -425,6 +573,38 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/removevolume",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/addinterface",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/removeinterface",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/setlinkstate",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/setiotune",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/injectmedia",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/ejectmedia",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/addinterface",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/removeinterface",
+						Namespaced: true,
+					},
 				}
 
 				response.WriteAsJson(list)
@@ -631,6 +811,15 @@ func (app *virtAPIApp) registerValidatingWebhooks() {
 	http.HandleFunc(components.VMRestoreValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMRestores(w, r, app.clusterConfig, app.virtCli)
 	})
+	http.HandleFunc(components.VMExportValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMExports(w, r, app.clusterConfig)
+	})
+	http.HandleFunc(components.VMCloneValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMClones(w, r, app.clusterConfig)
+	})
+	http.HandleFunc(components.VMBackupValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMBackups(w, r, app.clusterConfig)
+	})
 	http.HandleFunc(components.StatusValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeStatusValidation(w, r, app.clusterConfig, app.virtCli)
 	})
@@ -642,10 +831,10 @@ func (app *virtAPIApp) registerValidatingWebhooks() {
 func (app *virtAPIApp) registerMutatingWebhook() {
 
 	http.HandleFunc(components.VMMutatePath, func(w http.ResponseWriter, r *http.Request) {
-		mutating_webhook.ServeVMs(w, r, app.clusterConfig)
+		mutating_webhook.ServeVMs(w, r, app.clusterConfig, app.macPool)
 	})
 	http.HandleFunc(components.VMIMutatePath, func(w http.ResponseWriter, r *http.Request) {
-		mutating_webhook.ServeVMIs(w, r, app.clusterConfig)
+		mutating_webhook.ServeVMIs(w, r, app.clusterConfig, app.macPool)
 	})
 	http.HandleFunc(components.MigrationMutatePath, func(w http.ResponseWriter, r *http.Request) {
 		mutating_webhook.ServeMigrationCreate(w, r)
@@ -735,6 +924,7 @@ func (app *virtAPIApp) Run() {
 	stopChan := make(chan struct{}, 1)
 	defer close(stopChan)
 	go webhookInformers.VMIInformer.Run(stopChan)
+	go webhookInformers.VMInformer.Run(stopChan)
 	go webhookInformers.VMIPresetInformer.Run(stopChan)
 	go webhookInformers.NamespaceLimitsInformer.Run(stopChan)
 	go webhookInformers.VMRestoreInformer.Run(stopChan)
@@ -749,12 +939,15 @@ func (app *virtAPIApp) Run() {
 		kubevirtCAConfigInformer.HasSynced,
 		kubeVirtInformer.HasSynced,
 		webhookInformers.VMIInformer.HasSynced,
+		webhookInformers.VMInformer.HasSynced,
 		webhookInformers.VMIPresetInformer.HasSynced,
 		webhookInformers.NamespaceLimitsInformer.HasSynced,
 		configMapInformer.HasSynced)
 
 	app.clusterConfig = virtconfig.NewClusterConfig(configMapInformer, crdInformer, kubeVirtInformer, app.namespace)
 	app.clusterConfig.SetConfigModifiedCallback(app.shouldChangeLogVerbosity)
+	// the allocated MAC addresses are rebuilt from the synced VMs and VMIs
+	app.macPool = macpool.NewMacPool(app.virtCli, app.namespace, webhookInformers.VMInformer, webhookInformers.VMIInformer)
 
 	go app.certmanager.Start()
 	go app.handlerCertManager.Start()
@@ -31,6 +31,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	backupv1alpha1 "kubevirt.io/client-go/apis/backup/v1alpha1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	mime "kubevirt.io/kubevirt/pkg/rest"
 )
@@ -48,6 +52,17 @@ func ComposeAPIDefinitions() []*restful.WebService {
 	vmscGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinesnapshotcontents")
 	vmrGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinerestores")
 
+	instancetypeGVR := instancetypev1alpha1.SchemeGroupVersion.WithResource("virtualmachineinstancetypes")
+	clusterInstancetypeGVR := instancetypev1alpha1.SchemeGroupVersion.WithResource("virtualmachineclusterinstancetypes")
+	preferenceGVR := instancetypev1alpha1.SchemeGroupVersion.WithResource("virtualmachinepreferences")
+	clusterPreferenceGVR := instancetypev1alpha1.SchemeGroupVersion.WithResource("virtualmachineclusterpreferences")
+
+	vmExportGVR := exportv1alpha1.SchemeGroupVersion.WithResource("virtualmachineexports")
+
+	vmCloneGVR := clonev1alpha1.SchemeGroupVersion.WithResource("virtualmachineclones")
+
+	vmBackupGVR := backupv1alpha1.SchemeGroupVersion.WithResource("virtualmachinebackups")
+
 	ws, err := GroupVersionProxyBase(v1.GroupVersion)
 	if err != nil {
 		panic(err)
@@ -112,7 +127,82 @@ func ComposeAPIDefinitions() []*restful.WebService {
 		panic(err)
 	}
 
-	return []*restful.WebService{ws, ws1, ws2, ws3}
+	ws4, err := GroupVersionProxyBase(instancetypev1alpha1.SchemeGroupVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	ws4, err = GenericResourceProxy(ws4, instancetypeGVR, &instancetypev1alpha1.VirtualMachineInstancetype{}, "VirtualMachineInstancetype", &instancetypev1alpha1.VirtualMachineInstancetypeList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws4, err = GenericClusterResourceProxy(ws4, clusterInstancetypeGVR, &instancetypev1alpha1.VirtualMachineClusterInstancetype{}, "VirtualMachineClusterInstancetype", &instancetypev1alpha1.VirtualMachineClusterInstancetypeList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws4, err = GenericResourceProxy(ws4, preferenceGVR, &instancetypev1alpha1.VirtualMachinePreference{}, "VirtualMachinePreference", &instancetypev1alpha1.VirtualMachinePreferenceList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws4, err = GenericClusterResourceProxy(ws4, clusterPreferenceGVR, &instancetypev1alpha1.VirtualMachineClusterPreference{}, "VirtualMachineClusterPreference", &instancetypev1alpha1.VirtualMachineClusterPreferenceList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws5, err := ResourceProxyAutodiscovery(instancetypeGVR)
+	if err != nil {
+		panic(err)
+	}
+
+	ws6, err := GroupVersionProxyBase(exportv1alpha1.SchemeGroupVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	ws6, err = GenericResourceProxy(ws6, vmExportGVR, &exportv1alpha1.VirtualMachineExport{}, "VirtualMachineExport", &exportv1alpha1.VirtualMachineExportList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws7, err := ResourceProxyAutodiscovery(vmExportGVR)
+	if err != nil {
+		panic(err)
+	}
+
+	ws8, err := GroupVersionProxyBase(clonev1alpha1.SchemeGroupVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	ws8, err = GenericResourceProxy(ws8, vmCloneGVR, &clonev1alpha1.VirtualMachineClone{}, "VirtualMachineClone", &clonev1alpha1.VirtualMachineCloneList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws9, err := ResourceProxyAutodiscovery(vmCloneGVR)
+	if err != nil {
+		panic(err)
+	}
+
+	ws10, err := GroupVersionProxyBase(backupv1alpha1.SchemeGroupVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	ws10, err = GenericResourceProxy(ws10, vmBackupGVR, &backupv1alpha1.VirtualMachineBackup{}, "VirtualMachineBackup", &backupv1alpha1.VirtualMachineBackupList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws11, err := ResourceProxyAutodiscovery(vmBackupGVR)
+	if err != nil {
+		panic(err)
+	}
+
+	return []*restful.WebService{ws, ws1, ws2, ws3, ws4, ws5, ws6, ws7, ws8, ws9, ws10, ws11}
 }
 
 func GroupVersionProxyBase(gv schema.GroupVersion) (*restful.WebService, error) {
@@ -251,6 +341,114 @@ func GenericResourceProxy(ws *restful.WebService, gvr schema.GroupVersionResourc
 	return ws, nil
 }
 
+// GenericClusterResourceProxy documents the routes of a cluster scoped resource
+func GenericClusterResourceProxy(ws *restful.WebService, gvr schema.GroupVersionResource, objPointer runtime.Object, objKind string, objListPointer runtime.Object) (*restful.WebService, error) {
+
+	objExample := reflect.ValueOf(objPointer).Elem().Interface()
+	listExample := reflect.ValueOf(objListPointer).Elem().Interface()
+
+	ws.Route(
+		ws.POST(ClusterResourceBasePath(gvr)).
+			Produces(mime.MIME_JSON, mime.MIME_YAML).
+			Consumes(mime.MIME_JSON, mime.MIME_YAML).
+			Operation("create"+objKind).
+			To(Noop).Reads(objExample).Writes(objExample).
+			Doc("Create a "+objKind+" object.").
+			Returns(http.StatusOK, "OK", objExample).
+			Returns(http.StatusCreated, "Created", objExample).
+			Returns(http.StatusAccepted, "Accepted", objExample).
+			Returns(http.StatusUnauthorized, "Unauthorized", ""),
+	)
+
+	ws.Route(
+		ws.PUT(ClusterResourcePath(gvr)).
+			Produces(mime.MIME_JSON, mime.MIME_YAML).
+			Consumes(mime.MIME_JSON, mime.MIME_YAML).
+			Operation("replace"+objKind).
+			To(Noop).Reads(objExample).Writes(objExample).
+			Param(NameParam(ws)).
+			Doc("Update a "+objKind+" object.").
+			Returns(http.StatusOK, "OK", objExample).
+			Returns(http.StatusCreated, "Create", objExample).
+			Returns(http.StatusUnauthorized, "Unauthorized", ""),
+	)
+
+	ws.Route(
+		ws.DELETE(ClusterResourcePath(gvr)).
+			Produces(mime.MIME_JSON, mime.MIME_YAML).
+			Consumes(mime.MIME_JSON, mime.MIME_YAML).
+			Operation("delete"+objKind).
+			To(Noop).
+			Reads(metav1.DeleteOptions{}).Writes(metav1.Status{}).
+			Param(NameParam(ws)).
+			Param(gracePeriodSecondsParam(ws)).
+			Param(orphanDependentsParam(ws)).
+			Param(propagationPolicyParam(ws)).
+			Doc("Delete a "+objKind+" object.").
+			Returns(http.StatusOK, "OK", metav1.Status{}).
+			Returns(http.StatusUnauthorized, "Unauthorized", ""),
+	)
+
+	ws.Route(
+		ws.GET(ClusterResourcePath(gvr)).
+			Produces(mime.MIME_JSON, mime.MIME_YAML, mime.MIME_JSON_STREAM).
+			Operation("read"+objKind).
+			To(Noop).Writes(objExample).
+			Param(NameParam(ws)).
+			Param(exactParam(ws)).
+			Param(exportParam(ws)).
+			Doc("Get a "+objKind+" object.").
+			Returns(http.StatusOK, "OK", objExample).
+			Returns(http.StatusUnauthorized, "Unauthorized", ""),
+	)
+
+	ws.Route(addCollectionParams(
+		ws.GET(ClusterResourceBasePath(gvr)).
+			Produces(mime.MIME_JSON, mime.MIME_YAML, mime.MIME_JSON_STREAM).
+			Operation("list"+objKind).
+			To(Noop).Writes(listExample).
+			Doc("Get a list of "+objKind+" objects.").
+			Returns(http.StatusOK, "OK", listExample).
+			Returns(http.StatusUnauthorized, "Unauthorized", ""), ws,
+	))
+
+	ws.Route(
+		ws.PATCH(ClusterResourcePath(gvr)).
+			Consumes(mime.MIME_JSON_PATCH, mime.MIME_MERGE_PATCH).
+			Produces(mime.MIME_JSON).
+			Operation("patch"+objKind).
+			To(Noop).
+			Writes(objExample).Reads(metav1.Patch{}).
+			Param(NameParam(ws)).
+			Doc("Patch a "+objKind+" object.").
+			Returns(http.StatusOK, "OK", objExample).
+			Returns(http.StatusUnauthorized, "Unauthorized", ""),
+	)
+
+	// TODO, implement watch. For now it is here to provide swagger doc only
+	ws.Route(addWatchGetListParams(
+		ws.GET("/watch"+ClusterResourceBasePath(gvr)).
+			Produces(mime.MIME_JSON).
+			Operation("watch"+objKind+"List").
+			To(Noop).Writes(metav1.WatchEvent{}).
+			Doc("Watch a "+objKind+"List object.").
+			Returns(http.StatusOK, "OK", metav1.WatchEvent{}).
+			Returns(http.StatusUnauthorized, "Unauthorized", ""), ws,
+	))
+
+	ws.Route(addDeleteListParams(
+		ws.DELETE(ClusterResourceBasePath(gvr)).
+			Operation("deleteCollection"+objKind).
+			Produces(mime.MIME_JSON, mime.MIME_YAML).
+			To(Noop).Writes(metav1.Status{}).
+			Doc("Delete a collection of "+objKind+" objects.").
+			Returns(http.StatusOK, "OK", metav1.Status{}).
+			Returns(http.StatusUnauthorized, "Unauthorized", ""), ws,
+	))
+
+	return ws, nil
+}
+
 func ResourceProxyAutodiscovery(gvr schema.GroupVersionResource) (*restful.WebService, error) {
 	ws := new(restful.WebService)
 	ws.Path(GroupBasePath(gvr.GroupVersion()))
@@ -329,6 +527,10 @@ func NamespaceParam(ws *restful.WebService) *restful.Parameter {
 	return ws.PathParameter("namespace", "Object name and auth scope, such as for teams and projects").Required(true)
 }
 
+func PortForwardPortParam(ws *restful.WebService) *restful.Parameter {
+	return ws.PathParameter("port", "The guest port to forward").Required(true)
+}
+
 func labelSelectorParam(ws *restful.WebService) *restful.Parameter {
 	return ws.QueryParameter("labelSelector", "A selector to restrict the list of returned objects by their labels. Defaults to everything")
 }
@@ -393,10 +595,21 @@ func ResourceBasePath(gvr schema.GroupVersionResource) string {
 	return fmt.Sprintf("/namespaces/{namespace:[a-z0-9][a-z0-9\\-]*}/%s", gvr.Resource)
 }
 
+func ClusterResourceBasePath(gvr schema.GroupVersionResource) string {
+	return fmt.Sprintf("/%s", gvr.Resource)
+}
+
+func ClusterResourcePath(gvr schema.GroupVersionResource) string {
+	return fmt.Sprintf("/%s/{name:[a-z0-9][a-z0-9\\-]*}", gvr.Resource)
+}
+
 func ResourcePath(gvr schema.GroupVersionResource) string {
 	return fmt.Sprintf("/namespaces/{namespace:[a-z0-9][a-z0-9\\-]*}/%s/{name:[a-z0-9][a-z0-9\\-]*}", gvr.Resource)
 }
 
+// PortPath is the path parameter of the guest port of a port forward
+const PortPath = "/{port:[0-9]+}"
+
 func SubResourcePath(subResource string) string {
 	if !strings.HasPrefix(subResource, "/") {
 		return "/" + subResource
@@ -20,14 +20,17 @@
 package rest
 
 import (
+	"bytes"
 	"context"
 
 	"crypto/tls"
 	goerror "errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -41,6 +44,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
+	"kubevirt.io/kubevirt/pkg/util/net/portforward"
 	"kubevirt.io/kubevirt/pkg/util/status"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -158,7 +162,7 @@ func (app *SubresourceAPIApp) putRequestHandler(request *restful.Request, respon
 		return
 	}
 
-	err := conn.Put(url, app.handlerTLSConfiguration)
+	err := conn.Put(url, app.handlerTLSConfiguration, request.Request.Body)
 	if err != nil {
 		writeError(errors.NewInternalError(err), response)
 		return
@@ -185,6 +189,30 @@ func (app *SubresourceAPIApp) VNCRequestHandler(request *restful.Request, respon
 	app.streamRequestHandler(request, response, validate, getConsoleURL)
 }
 
+func (app *SubresourceAPIApp) PortForwardRequestHandler(request *restful.Request, response *restful.Response) {
+	port, err := strconv.Atoi(request.PathParameter("port"))
+	if err != nil || port < portforward.MinPort || port > portforward.MaxPort {
+		writeError(errors.NewBadRequest(fmt.Sprintf("port must be a number between %d and %d", portforward.MinPort, portforward.MaxPort)), response)
+		return
+	}
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if _, err := portforward.GuestAddress(vmi); err != nil {
+			log.Log.Object(vmi).Reason(err).Error("Can't establish a port forward connection.")
+			return errors.NewBadRequest(err.Error())
+		}
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if condManager.HasCondition(vmi, v1.VirtualMachineInstancePaused) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is paused"))
+		}
+		return nil
+	}
+	getPortForwardURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.PortForwardURI(vmi, port)
+	}
+	app.streamRequestHandler(request, response, validate, getPortForwardURL)
+}
+
 func (app *SubresourceAPIApp) getVirtHandlerConnForVMI(vmi *v1.VirtualMachineInstance) (kubecli.VirtHandlerConn, error) {
 	if !vmi.IsRunning() {
 		return nil, goerror.New(fmt.Sprintf("Unable to connect to VirtualMachineInstance because phase is %s instead of %s", vmi.Status.Phase, v1.Running))
@@ -324,6 +352,7 @@ func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request,
 	// RunStrategyManual         -> send restart request
 	// RunStrategyAlways         -> send restart request
 	// RunStrategyRerunOnFailure -> send restart request
+	// RunStrategyOnce           -> doesn't make sense
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
@@ -367,8 +396,8 @@ func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request,
 		writeError(errors.NewInternalError(err), response)
 		return
 	}
-	if runStrategy == v1.RunStrategyHalted {
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual restart requests", v1.RunStrategyHalted)), response)
+	if runStrategy == v1.RunStrategyHalted || runStrategy == v1.RunStrategyOnce {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual restart requests", runStrategy)), response)
 		return
 	}
 
@@ -591,6 +620,7 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 	// RunStrategyManual         -> send start request
 	// RunStrategyAlways         -> doesn't make sense
 	// RunStrategyRerunOnFailure -> doesn't make sense
+	// RunStrategyOnce           -> doesn't make sense
 	switch runStrategy {
 	case v1.RunStrategyHalted:
 		bodyString := getRunningJson(vm, true)
@@ -623,8 +653,8 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 		}
 		log.Log.Object(vm).V(4).Infof("Patching VM status: %s", bodyString)
 		patchErr = app.statusUpdater.PatchStatus(vm, patchType, []byte(bodyString))
-	case v1.RunStrategyAlways:
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual start requests", v1.RunStrategyAlways)), response)
+	case v1.RunStrategyAlways, v1.RunStrategyOnce:
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual start requests", runStrategy)), response)
 		return
 	}
 
@@ -645,6 +675,7 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 	// RunStrategyManual         -> send stop request
 	// RunStrategyAlways         -> spec.running = false
 	// RunStrategyRerunOnFailure -> spec.running = false
+	// RunStrategyOnce           -> spec.running = false
 
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
@@ -693,7 +724,7 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 		}
 		log.Log.Object(vm).V(4).Infof("Patching VM status: %s", bodyString)
 		patchErr = app.statusUpdater.PatchStatus(vm, patchType, []byte(bodyString))
-	case v1.RunStrategyRerunOnFailure, v1.RunStrategyAlways:
+	case v1.RunStrategyRerunOnFailure, v1.RunStrategyAlways, v1.RunStrategyOnce:
 		bodyString := getRunningJson(vm, false)
 		log.Log.Object(vm).V(4).Infof("Patching VM: %s", bodyString)
 		_, patchErr = app.virtCli.VirtualMachine(namespace).Patch(vm.GetName(), patchType, []byte(bodyString))
@@ -753,6 +784,154 @@ func (app *SubresourceAPIApp) UnpauseVMIRequestHandler(request *restful.Request,
 
 }
 
+func (app *SubresourceAPIApp) FreezeVMIRequestHandler(request *restful.Request, response *restful.Response) {
+
+	unfreezeTimeout := &v1.FreezeUnfreezeTimeout{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, unfreeze timeout is expected as the request body"), response)
+		return
+	}
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(unfreezeTimeout)
+	request.Request.Body.Close()
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+	if unfreezeTimeout.UnfreezeTimeout == nil {
+		writeError(errors.NewBadRequest("UnfreezeTimeout must be provided"), response)
+		return
+	}
+	if unfreezeTimeout.UnfreezeTimeout.Duration < 0 {
+		writeError(errors.NewBadRequest("UnfreezeTimeout must be a non-negative duration"), response)
+		return
+	}
+	body, err := json.Marshal(unfreezeTimeout)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	request.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.FreezeURI(vmi)
+	}
+	app.putRequestHandler(request, response, validate, getURL)
+}
+
+func (app *SubresourceAPIApp) UnfreezeVMIRequestHandler(request *restful.Request, response *restful.Response) {
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.UnfreezeURI(vmi)
+	}
+	app.putRequestHandler(request, response, validate, getURL)
+}
+
+func backupInProgress(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Status.Backup != nil && !vmi.Status.Backup.Completed && !vmi.Status.Backup.Failed
+}
+
+func (app *SubresourceAPIApp) BackupVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	if !app.clusterConfig.IncrementalBackupEnabled() {
+		writeError(errors.NewBadRequest("Unable to start a backup because IncrementalBackup feature gate is not enabled."), response)
+		return
+	}
+
+	backupOptions := &v1.BackupOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, backup options are expected as the request body"), response)
+		return
+	}
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(backupOptions)
+	request.Request.Body.Close()
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+	if backupOptions.Name == "" {
+		writeError(errors.NewBadRequest("Backup name must be provided"), response)
+		return
+	}
+	if backupOptions.Checkpoint == "" {
+		writeError(errors.NewBadRequest("Checkpoint name must be provided"), response)
+		return
+	}
+	if backupOptions.Incremental != nil && *backupOptions.Incremental == backupOptions.Checkpoint {
+		writeError(errors.NewBadRequest("An incremental backup can not be based on the checkpoint it creates"), response)
+		return
+	}
+	body, err := json.Marshal(backupOptions)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	request.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		if backupInProgress(vmi) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("backup %s is still in progress", vmi.Status.Backup.Name))
+		}
+		if vmi.Status.MigrationState != nil && !vmi.Status.MigrationState.Completed {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is migrating"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.BackupURI(vmi)
+	}
+	app.putRequestHandler(request, response, validate, getURL)
+}
+
+func (app *SubresourceAPIApp) EndBackupVMIRequestHandler(request *restful.Request, response *restful.Response) {
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		if vmi.Status.Backup == nil {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI has no backup"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.EndBackupURI(vmi)
+	}
+	app.putRequestHandler(request, response, validate, getURL)
+}
+
+func (app *SubresourceAPIApp) BackupNBDRequestHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if !backupInProgress(vmi) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI has no backup in progress"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.BackupNBDURI(vmi)
+	}
+	app.streamRequestHandler(request, response, validate, getURL)
+}
+
 func (app *SubresourceAPIApp) fetchVirtualMachine(name string, namespace string) (*v1.VirtualMachine, *errors.StatusError) {
 
 	vm, err := app.virtCli.VirtualMachine(namespace).Get(name, &k8smetav1.GetOptions{})
@@ -1247,3 +1426,605 @@ func (app *SubresourceAPIApp) VMIAddVolumeRequestHandler(request *restful.Reques
 func (app *SubresourceAPIApp) VMIRemoveVolumeRequestHandler(request *restful.Request, response *restful.Response) {
 	app.removeVolumeRequestHandler(request, response, true)
 }
+
+func generateVMInterfaceRequestPatch(vm *v1.VirtualMachine, interfaceRequest *v1.VirtualMachineInterfaceRequest) (string, error) {
+	verb := "add"
+	if len(vm.Status.InterfaceRequests) > 0 {
+		verb = "replace"
+	}
+	vmCopy := vm.DeepCopy()
+
+	// As with volume requests, only validate against other items in the list here.
+	// The VM validation webhook validates the list against the VMI template spec.
+	var requests []v1.VirtualMachineInterfaceRequest
+	if interfaceRequest.AddInterfaceOptions != nil {
+		name := interfaceRequest.AddInterfaceOptions.Name
+		for _, request := range vm.Status.InterfaceRequests {
+			if request.AddInterfaceOptions != nil && request.AddInterfaceOptions.Name == name {
+				return "", fmt.Errorf("add interface request for interface [%s] already exists", name)
+			}
+			if request.RemoveInterfaceOptions != nil && request.RemoveInterfaceOptions.Name == name {
+				return "", fmt.Errorf("unable to add interface since a remove interface request for interface [%s] already exists and is still being processed", name)
+			}
+			requests = append(requests, request)
+		}
+	} else if interfaceRequest.RemoveInterfaceOptions != nil {
+		name := interfaceRequest.RemoveInterfaceOptions.Name
+		for _, request := range vm.Status.InterfaceRequests {
+			if request.AddInterfaceOptions != nil && request.AddInterfaceOptions.Name == name {
+				// Filter matching AddInterface requests from the new list.
+				continue
+			}
+			if request.RemoveInterfaceOptions != nil && request.RemoveInterfaceOptions.Name == name {
+				return "", fmt.Errorf("a remove interface request for interface [%s] already exists and is still being processed", name)
+			}
+			requests = append(requests, request)
+		}
+	}
+	vmCopy.Status.InterfaceRequests = append(requests, *interfaceRequest)
+
+	oldJson, err := json.Marshal(vm.Status.InterfaceRequests)
+	if err != nil {
+		return "", err
+	}
+	newJson, err := json.Marshal(vmCopy.Status.InterfaceRequests)
+	if err != nil {
+		return "", err
+	}
+
+	test := fmt.Sprintf(`{ "op": "test", "path": "/status/interfaceRequests", "value": %s}`, string(oldJson))
+	update := fmt.Sprintf(`{ "op": "%s", "path": "/status/interfaceRequests", "value": %s}`, verb, string(newJson))
+	patch := fmt.Sprintf("[%s, %s]", test, update)
+
+	return patch, nil
+}
+
+func generateVMIInterfaceRequestPatch(vmi *v1.VirtualMachineInstance, interfaceRequest *v1.VirtualMachineInterfaceRequest) (string, error) {
+
+	networkVerb := "add"
+	interfaceVerb := "add"
+
+	if len(vmi.Spec.Networks) > 0 {
+		networkVerb = "replace"
+	}
+
+	if len(vmi.Spec.Domain.Devices.Interfaces) > 0 {
+		interfaceVerb = "replace"
+	}
+
+	var removeNetwork *v1.Network
+	for i, network := range vmi.Spec.Networks {
+		if interfaceRequest.AddInterfaceOptions != nil && network.Name == interfaceRequest.AddInterfaceOptions.Name {
+			return "", fmt.Errorf("Unable to add interface [%s] because it already exists", network.Name)
+		} else if interfaceRequest.RemoveInterfaceOptions != nil && network.Name == interfaceRequest.RemoveInterfaceOptions.Name {
+			removeNetwork = &vmi.Spec.Networks[i]
+		}
+	}
+
+	if interfaceRequest.RemoveInterfaceOptions != nil {
+		if removeNetwork == nil {
+			return "", fmt.Errorf("Unable to remove interface [%s] because it does not exist", interfaceRequest.RemoveInterfaceOptions.Name)
+		}
+		if removeNetwork.Multus == nil || removeNetwork.Multus.Default {
+			return "", fmt.Errorf("Unable to remove interface [%s] because only secondary multus interfaces can be unplugged", removeNetwork.Name)
+		}
+	}
+
+	vmiCopy := vmi.DeepCopy()
+	vmiCopy.Spec = *controller.ApplyInterfaceRequestOnVMISpec(&vmiCopy.Spec, interfaceRequest)
+
+	oldNetworksJson, err := json.Marshal(vmi.Spec.Networks)
+	if err != nil {
+		return "", err
+	}
+
+	newNetworksJson, err := json.Marshal(vmiCopy.Spec.Networks)
+	if err != nil {
+		return "", err
+	}
+
+	oldInterfacesJson, err := json.Marshal(vmi.Spec.Domain.Devices.Interfaces)
+	if err != nil {
+		return "", err
+	}
+
+	newInterfacesJson, err := json.Marshal(vmiCopy.Spec.Domain.Devices.Interfaces)
+	if err != nil {
+		return "", err
+	}
+
+	testNetworks := fmt.Sprintf(`{ "op": "test", "path": "/spec/networks", "value": %s}`, string(oldNetworksJson))
+	updateNetworks := fmt.Sprintf(`{ "op": "%s", "path": "/spec/networks", "value": %s}`, networkVerb, string(newNetworksJson))
+
+	testInterfaces := fmt.Sprintf(`{ "op": "test", "path": "/spec/domain/devices/interfaces", "value": %s}`, string(oldInterfacesJson))
+	updateInterfaces := fmt.Sprintf(`{ "op": "%s", "path": "/spec/domain/devices/interfaces", "value": %s}`, interfaceVerb, string(newInterfacesJson))
+
+	patch := fmt.Sprintf("[%s, %s, %s, %s]", testNetworks, testInterfaces, updateNetworks, updateInterfaces)
+
+	return patch, nil
+}
+
+func (app *SubresourceAPIApp) patchInterfaceRequest(name, namespace string, interfaceRequest *v1.VirtualMachineInterfaceRequest, ephemeral bool) *errors.StatusError {
+	// inject into VMI if ephemeral, else set as a request on the VM to both make permanent and hotplug.
+	if ephemeral {
+		vmi, statErr := app.fetchVirtualMachineInstance(name, namespace)
+		if statErr != nil {
+			return statErr
+		}
+
+		if !vmi.IsRunning() {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running"))
+		}
+
+		patch, err := generateVMIInterfaceRequestPatch(vmi, interfaceRequest)
+		if err != nil {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err)
+		}
+
+		log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+		_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+		if err != nil {
+			return errors.NewInternalError(fmt.Errorf("unable to patch vmi during interface hotplug: %v", err))
+		}
+		return nil
+	}
+
+	vm, statErr := app.fetchVirtualMachine(name, namespace)
+	if statErr != nil {
+		return statErr
+	}
+
+	patch, err := generateVMInterfaceRequestPatch(vm, interfaceRequest)
+	if err != nil {
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, err)
+	}
+
+	err = app.statusUpdater.PatchStatus(vm, types.JSONPatchType, []byte(patch))
+	if err != nil {
+		return errors.NewInternalError(fmt.Errorf("unable to patch vm status during interface hotplug: %v", err))
+	}
+	return nil
+}
+
+func (app *SubresourceAPIApp) addInterfaceRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if !app.clusterConfig.HotplugNetworkInterfacesEnabled() {
+		writeError(errors.NewBadRequest("Unable to Add Interface because HotplugNICs feature gate is not enabled."), response)
+		return
+	}
+
+	opts := &v1.AddInterfaceOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, AddInterfaceOptions are expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("AddInterfaceOptions requires name to be set"), response)
+		return
+	} else if opts.NetworkAttachmentDefinitionName == "" {
+		writeError(errors.NewBadRequest("AddInterfaceOptions requires networkAttachmentDefinitionName to be set"), response)
+		return
+	}
+
+	interfaceRequest := v1.VirtualMachineInterfaceRequest{
+		AddInterfaceOptions: opts,
+	}
+	if statErr := app.patchInterfaceRequest(name, namespace, &interfaceRequest, ephemeral); statErr != nil {
+		writeError(statErr, response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (app *SubresourceAPIApp) removeInterfaceRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if !app.clusterConfig.HotplugNetworkInterfacesEnabled() {
+		writeError(errors.NewBadRequest("Unable to Remove Interface because HotplugNICs feature gate is not enabled."), response)
+		return
+	}
+
+	opts := &v1.RemoveInterfaceOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, RemoveInterfaceOptions are expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("RemoveInterfaceOptions requires name to be set"), response)
+		return
+	}
+
+	interfaceRequest := v1.VirtualMachineInterfaceRequest{
+		RemoveInterfaceOptions: opts,
+	}
+	if statErr := app.patchInterfaceRequest(name, namespace, &interfaceRequest, ephemeral); statErr != nil {
+		writeError(statErr, response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// VMAddInterfaceRequestHandler handles the subresource for hot plugging a network interface.
+func (app *SubresourceAPIApp) VMAddInterfaceRequestHandler(request *restful.Request, response *restful.Response) {
+	app.addInterfaceRequestHandler(request, response, false)
+}
+
+// VMRemoveInterfaceRequestHandler handles the subresource for hot unplugging a network interface.
+func (app *SubresourceAPIApp) VMRemoveInterfaceRequestHandler(request *restful.Request, response *restful.Response) {
+	app.removeInterfaceRequestHandler(request, response, false)
+}
+
+// VMIAddInterfaceRequestHandler handles the subresource for hot plugging a network interface.
+func (app *SubresourceAPIApp) VMIAddInterfaceRequestHandler(request *restful.Request, response *restful.Response) {
+	app.addInterfaceRequestHandler(request, response, true)
+}
+
+// VMIRemoveInterfaceRequestHandler handles the subresource for hot unplugging a network interface.
+func (app *SubresourceAPIApp) VMIRemoveInterfaceRequestHandler(request *restful.Request, response *restful.Response) {
+	app.removeInterfaceRequestHandler(request, response, true)
+}
+
+func generateVMISetLinkStatePatch(vmi *v1.VirtualMachineInstance, opts *v1.SetLinkStateOptions) (string, error) {
+	interfaces := make([]v1.Interface, len(vmi.Spec.Domain.Devices.Interfaces))
+	copy(interfaces, vmi.Spec.Domain.Devices.Interfaces)
+
+	found := false
+	for i := range interfaces {
+		if interfaces[i].Name != opts.Name {
+			continue
+		}
+		if interfaces[i].SRIOV != nil || interfaces[i].Slirp != nil {
+			return "", fmt.Errorf("the link state of SR-IOV and slirp interfaces can not be changed")
+		}
+		interfaces[i].LinkState = opts.LinkState
+		found = true
+		break
+	}
+	if !found {
+		return "", fmt.Errorf("network interface %s not found", opts.Name)
+	}
+
+	oldInterfacesJson, err := json.Marshal(vmi.Spec.Domain.Devices.Interfaces)
+	if err != nil {
+		return "", err
+	}
+
+	newInterfacesJson, err := json.Marshal(interfaces)
+	if err != nil {
+		return "", err
+	}
+
+	test := fmt.Sprintf(`{ "op": "test", "path": "/spec/domain/devices/interfaces", "value": %s}`, string(oldInterfacesJson))
+	update := fmt.Sprintf(`{ "op": "replace", "path": "/spec/domain/devices/interfaces", "value": %s}`, string(newInterfacesJson))
+
+	return fmt.Sprintf("[%s, %s]", test, update), nil
+}
+
+// VMISetLinkStateRequestHandler handles the subresource for changing the link state of a network interface.
+func (app *SubresourceAPIApp) VMISetLinkStateRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	opts := &v1.SetLinkStateOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, SetLinkStateOptions are expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("SetLinkStateOptions requires name to be set"), response)
+		return
+	} else if opts.LinkState != v1.InterfaceLinkStateUp && opts.LinkState != v1.InterfaceLinkStateDown {
+		writeError(errors.NewBadRequest(fmt.Sprintf("SetLinkStateOptions requires linkState to be %s or %s", v1.InterfaceLinkStateUp, v1.InterfaceLinkStateDown)), response)
+		return
+	}
+
+	vmi, statErr := app.fetchVirtualMachineInstance(name, namespace)
+	if statErr != nil {
+		writeError(statErr, response)
+		return
+	}
+
+	if !vmi.IsRunning() {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running")), response)
+		return
+	}
+
+	patch, err := generateVMISetLinkStatePatch(vmi, opts)
+	if err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+
+	log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+	_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+	if err != nil {
+		writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi while setting the link state: %v", err)), response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func generateVMISetIOTunePatch(vmi *v1.VirtualMachineInstance, opts *v1.SetIOTuneOptions) (string, error) {
+	disks := make([]v1.Disk, len(vmi.Spec.Domain.Devices.Disks))
+	copy(disks, vmi.Spec.Domain.Devices.Disks)
+
+	found := false
+	for i := range disks {
+		if disks[i].Name != opts.Name {
+			continue
+		}
+		if disks[i].CDRom != nil || disks[i].Floppy != nil {
+			return "", fmt.Errorf("the I/O limits of CD-ROM and floppy disks can not be changed")
+		}
+		disks[i].IOTune = opts.IOTune
+		found = true
+		break
+	}
+	if !found {
+		return "", fmt.Errorf("disk %s not found", opts.Name)
+	}
+
+	oldDisksJson, err := json.Marshal(vmi.Spec.Domain.Devices.Disks)
+	if err != nil {
+		return "", err
+	}
+
+	newDisksJson, err := json.Marshal(disks)
+	if err != nil {
+		return "", err
+	}
+
+	test := fmt.Sprintf(`{ "op": "test", "path": "/spec/domain/devices/disks", "value": %s}`, string(oldDisksJson))
+	update := fmt.Sprintf(`{ "op": "replace", "path": "/spec/domain/devices/disks", "value": %s}`, string(newDisksJson))
+
+	return fmt.Sprintf("[%s, %s]", test, update), nil
+}
+
+// VMISetIOTuneRequestHandler handles the subresource for changing the I/O limits of a disk.
+func (app *SubresourceAPIApp) VMISetIOTuneRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	opts := &v1.SetIOTuneOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, SetIOTuneOptions are expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("SetIOTuneOptions requires name to be set"), response)
+		return
+	}
+
+	vmi, statErr := app.fetchVirtualMachineInstance(name, namespace)
+	if statErr != nil {
+		writeError(statErr, response)
+		return
+	}
+
+	if !vmi.IsRunning() {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running")), response)
+		return
+	}
+
+	patch, err := generateVMISetIOTunePatch(vmi, opts)
+	if err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+
+	log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+	_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+	if err != nil {
+		writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi while setting the I/O limits: %v", err)), response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// generateVMIMediaPatch replaces the volume of the CD-ROM with the given name,
+// a nil volume ejects the media of the CD-ROM.
+func generateVMIMediaPatch(vmi *v1.VirtualMachineInstance, name string, volume *v1.Volume) (string, error) {
+	found := false
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Name != name {
+			continue
+		}
+		if disk.CDRom == nil {
+			return "", fmt.Errorf("disk %s is not a CD-ROM", name)
+		}
+		found = true
+		break
+	}
+	if !found {
+		return "", fmt.Errorf("CD-ROM %s not found", name)
+	}
+
+	volumes := []v1.Volume{}
+	hasMedia := false
+	for _, v := range vmi.Spec.Volumes {
+		if v.Name == name {
+			hasMedia = true
+			continue
+		}
+		volumes = append(volumes, v)
+	}
+
+	if volume != nil {
+		if hasMedia {
+			return "", fmt.Errorf("CD-ROM %s already contains media, it has to be ejected first", name)
+		}
+		for _, status := range vmi.Status.VolumeStatus {
+			if status.Name == name {
+				return "", fmt.Errorf("the media of CD-ROM %s is still being ejected", name)
+			}
+		}
+		volumes = append(volumes, *volume)
+	} else if !hasMedia {
+		return "", fmt.Errorf("CD-ROM %s is empty", name)
+	}
+
+	verb := "add"
+	if len(vmi.Spec.Volumes) > 0 {
+		verb = "replace"
+	}
+
+	oldVolumesJson, err := json.Marshal(vmi.Spec.Volumes)
+	if err != nil {
+		return "", err
+	}
+
+	newVolumesJson, err := json.Marshal(volumes)
+	if err != nil {
+		return "", err
+	}
+
+	test := fmt.Sprintf(`{ "op": "test", "path": "/spec/volumes", "value": %s}`, string(oldVolumesJson))
+	update := fmt.Sprintf(`{ "op": "%s", "path": "/spec/volumes", "value": %s}`, verb, string(newVolumesJson))
+
+	return fmt.Sprintf("[%s, %s]", test, update), nil
+}
+
+func (app *SubresourceAPIApp) patchVMIMedia(request *restful.Request, response *restful.Response, name string, volume *v1.Volume) {
+	vmi, statErr := app.fetchVirtualMachineInstance(request.PathParameter("name"), request.PathParameter("namespace"))
+	if statErr != nil {
+		writeError(statErr, response)
+		return
+	}
+
+	if !vmi.IsRunning() {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running")), response)
+		return
+	}
+
+	patch, err := generateVMIMediaPatch(vmi, name, volume)
+	if err != nil {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, err), response)
+		return
+	}
+
+	log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+	_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+	if err != nil {
+		writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi while changing the media of a CD-ROM: %v", err)), response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// VMIInjectMediaRequestHandler handles the subresource for inserting media into an empty CD-ROM.
+func (app *SubresourceAPIApp) VMIInjectMediaRequestHandler(request *restful.Request, response *restful.Response) {
+	if !app.clusterConfig.HotplugVolumesEnabled() {
+		writeError(errors.NewBadRequest("Unable to inject media because HotplugVolumes feature gate is not enabled."), response)
+		return
+	}
+
+	opts := &v1.InjectMediaOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, InjectMediaOptions are expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("InjectMediaOptions requires name to be set"), response)
+		return
+	} else if opts.VolumeSource == nil || (opts.VolumeSource.PersistentVolumeClaim == nil && opts.VolumeSource.DataVolume == nil) {
+		writeError(errors.NewBadRequest("InjectMediaOptions requires a PersistentVolumeClaim or DataVolume source"), response)
+		return
+	}
+
+	volume := &v1.Volume{
+		Name: opts.Name,
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: opts.VolumeSource.PersistentVolumeClaim,
+			DataVolume:            opts.VolumeSource.DataVolume,
+		},
+	}
+	app.patchVMIMedia(request, response, opts.Name, volume)
+}
+
+// VMIEjectMediaRequestHandler handles the subresource for removing the media from a CD-ROM.
+func (app *SubresourceAPIApp) VMIEjectMediaRequestHandler(request *restful.Request, response *restful.Response) {
+	if !app.clusterConfig.HotplugVolumesEnabled() {
+		writeError(errors.NewBadRequest("Unable to eject media because HotplugVolumes feature gate is not enabled."), response)
+		return
+	}
+
+	opts := &v1.EjectMediaOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, EjectMediaOptions are expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("EjectMediaOptions requires name to be set"), response)
+		return
+	}
+
+	app.patchVMIMedia(request, response, opts.Name, nil)
+}
@@ -32,12 +32,14 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/emicklei/go-restful"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
+	"github.com/onsi/gomega/types"
 
 	"kubevirt.io/kubevirt/pkg/util/status"
 
@@ -354,6 +356,52 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			close(done)
 		}, 5)
 
+		It("should fail to forward an invalid port", func(done Done) {
+
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+			request.PathParameters()["port"] = "65536"
+
+			app.PortForwardRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			close(done)
+		}, 5)
+
+		table.DescribeTable("should fail to forward a port", func(bridge bool, paused bool, expectedCode int) {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+			request.PathParameters()["port"] = "22"
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+			if bridge {
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			}
+			if paused {
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+					{
+						Type:   v1.VirtualMachineInstancePaused,
+						Status: k8sv1.ConditionTrue,
+					},
+				}
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.PortForwardRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, expectedCode)
+		},
+			table.Entry("with a bridge interface on the pod network", true, false, http.StatusBadRequest),
+			table.Entry("if the VMI is paused", false, true, http.StatusConflict),
+		)
+
 		It("should fail if VirtualMachine not exists", func(done Done) {
 			request.PathParameters()["name"] = "testvm"
 			request.PathParameters()["namespace"] = "default"
@@ -920,6 +968,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("Manual", v1.RunStrategyManual, "VM is not running"),
 			table.Entry("RerunOnFailure", v1.RunStrategyRerunOnFailure, "VM is not running"),
 			table.Entry("Halted", v1.RunStrategyHalted, "Halted does not support manual restart requests"),
+			table.Entry("Once", v1.RunStrategyOnce, "Once does not support manual restart requests"),
 		)
 
 		It("should fail on a VM that is scheduled to be renamed", func() {
@@ -1242,6 +1291,381 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		)
 	})
 
+	Context("Add/Remove Interface Subresource api", func() {
+
+		newInterfaceOptionsBody := func(opts interface{}) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
+
+		newRunningVMIWithSecondaryInterface := func(name string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI(name)
+			vmi.Namespace = "default"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Networks = []v1.Network{
+				*v1.DefaultPodNetwork(),
+				{
+					Name:          "existingnic",
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "existing-net"}},
+				},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				*v1.DefaultBridgeNetworkInterface(),
+				{
+					Name:                   "existingnic",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				},
+			}
+			return vmi
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		table.DescribeTable("Should handle an add interface request", func(addOpts *v1.AddInterfaceOptions, removeOpts *v1.RemoveInterfaceOptions, isVM bool, code int, enableGate bool) {
+			if enableGate {
+				enableFeatureGate(virtconfig.HotplugNICsGate)
+			}
+			if addOpts != nil {
+				request.Request.Body = newInterfaceOptionsBody(addOpts)
+			} else {
+				request.Request.Body = newInterfaceOptionsBody(removeOpts)
+			}
+
+			if isVM {
+				vm := newMinimalVM(request.PathParameter("name"))
+				vm.Namespace = "default"
+
+				patchedVM := vm.DeepCopy()
+				patchedVM.Status.InterfaceRequests = append(patchedVM.Status.InterfaceRequests, v1.VirtualMachineInterfaceRequest{AddInterfaceOptions: addOpts, RemoveInterfaceOptions: removeOpts})
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm/status"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, patchedVM),
+					),
+				)
+				if addOpts != nil {
+					app.VMAddInterfaceRequestHandler(request, response)
+				} else {
+					app.VMRemoveInterfaceRequestHandler(request, response)
+				}
+			} else {
+				vmi := newRunningVMIWithSecondaryInterface(request.PathParameter("name"))
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
+				if addOpts != nil {
+					app.VMIAddInterfaceRequestHandler(request, response)
+				} else {
+					app.VMIRemoveInterfaceRequestHandler(request, response)
+				}
+			}
+
+			Expect(response.StatusCode()).To(Equal(code))
+		},
+			table.Entry("VM with a valid add interface request", &v1.AddInterfaceOptions{
+				Name:                            "newnic",
+				NetworkAttachmentDefinitionName: "new-net",
+			}, nil, true, http.StatusAccepted, true),
+			table.Entry("VMI with a valid add interface request", &v1.AddInterfaceOptions{
+				Name:                            "newnic",
+				NetworkAttachmentDefinitionName: "new-net",
+			}, nil, false, http.StatusAccepted, true),
+			table.Entry("VMI with an add interface request for an existing interface", &v1.AddInterfaceOptions{
+				Name:                            "existingnic",
+				NetworkAttachmentDefinitionName: "existing-net",
+			}, nil, false, http.StatusConflict, true),
+			table.Entry("VMI with an invalid add interface request that's missing a name", &v1.AddInterfaceOptions{
+				NetworkAttachmentDefinitionName: "new-net",
+			}, nil, false, http.StatusBadRequest, true),
+			table.Entry("VMI with an invalid add interface request that's missing a network", &v1.AddInterfaceOptions{
+				Name: "newnic",
+			}, nil, false, http.StatusBadRequest, true),
+			table.Entry("VM with a valid remove interface request", nil, &v1.RemoveInterfaceOptions{
+				Name: "existingnic",
+			}, true, http.StatusAccepted, true),
+			table.Entry("VMI with a valid remove interface request", nil, &v1.RemoveInterfaceOptions{
+				Name: "existingnic",
+			}, false, http.StatusAccepted, true),
+			table.Entry("VMI with a remove interface request for the pod network", nil, &v1.RemoveInterfaceOptions{
+				Name: "default",
+			}, false, http.StatusConflict, true),
+			table.Entry("VMI with a valid remove interface request but no feature gate", nil, &v1.RemoveInterfaceOptions{
+				Name: "existingnic",
+			}, false, http.StatusBadRequest, false),
+			table.Entry("VM with a valid add interface request but no feature gate", &v1.AddInterfaceOptions{
+				Name:                            "newnic",
+				NetworkAttachmentDefinitionName: "new-net",
+			}, nil, true, http.StatusBadRequest, false),
+		)
+	})
+
+	Context("Set link state Subresource api", func() {
+		newRunningVMI := func(name string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI(name)
+			vmi.Namespace = "default"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			return vmi
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should patch the link state of the interface", func() {
+			optsJson, _ := json.Marshal(&v1.SetLinkStateOptions{Name: "default", LinkState: v1.InterfaceLinkStateDown})
+			request.Request.Body = &readCloserWrapper{bytes.NewReader(optsJson)}
+
+			vmi := newRunningVMI("testvmi")
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.VMISetLinkStateRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		table.DescribeTable("should reject", func(opts *v1.SetLinkStateOptions, phase v1.VirtualMachineInstancePhase, fetchVMI bool, code int) {
+			optsJson, _ := json.Marshal(opts)
+			request.Request.Body = &readCloserWrapper{bytes.NewReader(optsJson)}
+
+			if fetchVMI {
+				vmi := newRunningVMI("testvmi")
+				vmi.Status.Phase = phase
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
+			}
+
+			app.VMISetLinkStateRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(code))
+		},
+			table.Entry("a request without a name", &v1.SetLinkStateOptions{LinkState: v1.InterfaceLinkStateDown}, v1.Running, false, http.StatusBadRequest),
+			table.Entry("an unknown link state", &v1.SetLinkStateOptions{Name: "default", LinkState: "sideways"}, v1.Running, false, http.StatusBadRequest),
+			table.Entry("an unknown interface", &v1.SetLinkStateOptions{Name: "blue", LinkState: v1.InterfaceLinkStateDown}, v1.Running, true, http.StatusBadRequest),
+			table.Entry("a VMI which is not running", &v1.SetLinkStateOptions{Name: "default", LinkState: v1.InterfaceLinkStateDown}, v1.Scheduled, true, http.StatusConflict),
+		)
+	})
+
+	Context("Set I/O limits Subresource api", func() {
+		newRunningVMI := func(name string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI(name)
+			vmi.Namespace = "default"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "rootdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+				{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}},
+			}
+			return vmi
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should patch the I/O limits of the disk", func() {
+			optsJson, _ := json.Marshal(&v1.SetIOTuneOptions{Name: "rootdisk", IOTune: &v1.DiskIOTune{TotalIOPSSec: 100}})
+			request.Request.Body = &readCloserWrapper{bytes.NewReader(optsJson)}
+
+			vmi := newRunningVMI("testvmi")
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring(`"ioTune":{"totalIopsSec":100}`))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.VMISetIOTuneRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		table.DescribeTable("should reject", func(opts *v1.SetIOTuneOptions, phase v1.VirtualMachineInstancePhase, fetchVMI bool, code int) {
+			optsJson, _ := json.Marshal(opts)
+			request.Request.Body = &readCloserWrapper{bytes.NewReader(optsJson)}
+
+			if fetchVMI {
+				vmi := newRunningVMI("testvmi")
+				vmi.Status.Phase = phase
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
+			}
+
+			app.VMISetIOTuneRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(code))
+		},
+			table.Entry("a request without a name", &v1.SetIOTuneOptions{}, v1.Running, false, http.StatusBadRequest),
+			table.Entry("an unknown disk", &v1.SetIOTuneOptions{Name: "datadisk"}, v1.Running, true, http.StatusBadRequest),
+			table.Entry("a CD-ROM", &v1.SetIOTuneOptions{Name: "cdrom", IOTune: &v1.DiskIOTune{TotalIOPSSec: 100}}, v1.Running, true, http.StatusBadRequest),
+			table.Entry("a VMI which is not running", &v1.SetIOTuneOptions{Name: "rootdisk"}, v1.Scheduled, true, http.StatusConflict),
+		)
+	})
+
+	Context("CD-ROM media Subresource api", func() {
+		newRunningVMI := func(name string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI(name)
+			vmi.Namespace = "default"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "rootdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+				{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}},
+				{Name: "installer", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "rootdisk", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fedora"}}},
+				{Name: "installer", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "windows"}}},
+			}
+			return vmi
+		}
+
+		virtioWin := &v1.HotplugVolumeSource{
+			PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "virtio-win"},
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+		})
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		expectPatch := func(vmi *v1.VirtualMachineInstance, matcher types.GomegaMatcher) {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(body)).To(matcher)
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+		}
+
+		It("should add a volume for the media injected into an empty CD-ROM", func() {
+			optsJson, _ := json.Marshal(&v1.InjectMediaOptions{Name: "cdrom", VolumeSource: virtioWin})
+			request.Request.Body = &readCloserWrapper{bytes.NewReader(optsJson)}
+
+			expectPatch(newRunningVMI("testvmi"), ContainSubstring(`{"name":"cdrom","persistentVolumeClaim":{"claimName":"virtio-win"}}]`))
+
+			app.VMIInjectMediaRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		It("should remove the volume of the ejected media", func() {
+			optsJson, _ := json.Marshal(&v1.EjectMediaOptions{Name: "installer"})
+			request.Request.Body = &readCloserWrapper{bytes.NewReader(optsJson)}
+
+			expectPatch(newRunningVMI("testvmi"), And(
+				ContainSubstring(`"op": "test"`),
+				HaveSuffix(`"value": [{"name":"rootdisk","containerDisk":{"image":"fedora"}}]}]`),
+			))
+
+			app.VMIEjectMediaRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		table.DescribeTable("should reject", func(handler func(*SubresourceAPIApp, *restful.Request, *restful.Response), opts interface{}, changeVMI func(*v1.VirtualMachineInstance), code int) {
+			optsJson, _ := json.Marshal(opts)
+			request.Request.Body = &readCloserWrapper{bytes.NewReader(optsJson)}
+
+			if changeVMI != nil {
+				vmi := newRunningVMI("testvmi")
+				changeVMI(vmi)
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
+			}
+
+			handler(&app, request, response)
+			Expect(response.StatusCode()).To(Equal(code))
+		},
+			table.Entry("injecting without a name", (*SubresourceAPIApp).VMIInjectMediaRequestHandler,
+				&v1.InjectMediaOptions{VolumeSource: virtioWin}, nil, http.StatusBadRequest),
+			table.Entry("injecting without a volume source", (*SubresourceAPIApp).VMIInjectMediaRequestHandler,
+				&v1.InjectMediaOptions{Name: "cdrom", VolumeSource: &v1.HotplugVolumeSource{}}, nil, http.StatusBadRequest),
+			table.Entry("injecting into a disk", (*SubresourceAPIApp).VMIInjectMediaRequestHandler,
+				&v1.InjectMediaOptions{Name: "rootdisk", VolumeSource: virtioWin}, func(*v1.VirtualMachineInstance) {}, http.StatusConflict),
+			table.Entry("injecting into an unknown CD-ROM", (*SubresourceAPIApp).VMIInjectMediaRequestHandler,
+				&v1.InjectMediaOptions{Name: "dvd", VolumeSource: virtioWin}, func(*v1.VirtualMachineInstance) {}, http.StatusConflict),
+			table.Entry("injecting into a CD-ROM with media", (*SubresourceAPIApp).VMIInjectMediaRequestHandler,
+				&v1.InjectMediaOptions{Name: "installer", VolumeSource: virtioWin}, func(*v1.VirtualMachineInstance) {}, http.StatusConflict),
+			table.Entry("injecting while the previous media is being ejected", (*SubresourceAPIApp).VMIInjectMediaRequestHandler,
+				&v1.InjectMediaOptions{Name: "cdrom", VolumeSource: virtioWin}, func(vmi *v1.VirtualMachineInstance) {
+					vmi.Status.VolumeStatus = []v1.VolumeStatus{{Name: "cdrom", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+				}, http.StatusConflict),
+			table.Entry("injecting into a VMI which is not running", (*SubresourceAPIApp).VMIInjectMediaRequestHandler,
+				&v1.InjectMediaOptions{Name: "cdrom", VolumeSource: virtioWin}, func(vmi *v1.VirtualMachineInstance) {
+					vmi.Status.Phase = v1.Scheduled
+				}, http.StatusConflict),
+			table.Entry("ejecting without a name", (*SubresourceAPIApp).VMIEjectMediaRequestHandler,
+				&v1.EjectMediaOptions{}, nil, http.StatusBadRequest),
+			table.Entry("ejecting from an empty CD-ROM", (*SubresourceAPIApp).VMIEjectMediaRequestHandler,
+				&v1.EjectMediaOptions{Name: "cdrom"}, func(*v1.VirtualMachineInstance) {}, http.StatusConflict),
+			table.Entry("ejecting from a disk", (*SubresourceAPIApp).VMIEjectMediaRequestHandler,
+				&v1.EjectMediaOptions{Name: "rootdisk"}, func(*v1.VirtualMachineInstance) {}, http.StatusConflict),
+		)
+
+		It("should reject changing media without the HotplugVolumes feature gate", func() {
+			disableFeatureGates()
+			optsJson, _ := json.Marshal(&v1.EjectMediaOptions{Name: "installer"})
+			request.Request.Body = &readCloserWrapper{bytes.NewReader(optsJson)}
+
+			app.VMIEjectMediaRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		})
+	})
+
 	Context("Subresource api - error handling for StartVMRequestHandler", func() {
 		BeforeEach(func() {
 			request.PathParameters()["name"] = "testvm"
@@ -1279,6 +1703,9 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("Always without VMI", v1.RunStrategyAlways, v1.VmPhaseUnset, http.StatusNotFound, "Always does not support manual start requests"),
 			table.Entry("Always with VMI in phase Running", v1.RunStrategyAlways, v1.Running, http.StatusOK, "VM is already running"),
 			table.Entry("RerunOnFailure with VMI in phase Failed", v1.RunStrategyRerunOnFailure, v1.Failed, http.StatusOK, "RerunOnFailure does not support starting VM from failed state"),
+			table.Entry("Once without VMI", v1.RunStrategyOnce, v1.VmPhaseUnset, http.StatusNotFound, "Once does not support manual start requests"),
+			table.Entry("Once with VMI in phase Succeeded", v1.RunStrategyOnce, v1.Succeeded, http.StatusOK, "Once does not support manual start requests"),
+			table.Entry("Once with VMI in phase Failed", v1.RunStrategyOnce, v1.Failed, http.StatusOK, "Once does not support manual start requests"),
 		)
 
 		table.DescribeTable("should not fail on VM with RunStrategy ",
@@ -1377,6 +1804,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("RunStrategyManual", v1.RunStrategyManual, "VM is not running"),
 			table.Entry("RunStrategyRerunOnFailure", v1.RunStrategyRerunOnFailure, "VM is not running"),
 			table.Entry("RunStrategyHalted", v1.RunStrategyHalted, "VM is not running"),
+			table.Entry("RunStrategyOnce", v1.RunStrategyOnce, "VM is not running"),
 		)
 
 		It("should fail on VM with RunStrategyHalted", func() {
@@ -1445,6 +1873,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("Always", v1.RunStrategyAlways),
 			table.Entry("RerunOnFailure", v1.RunStrategyRerunOnFailure),
 			table.Entry("Manual", v1.RunStrategyManual),
+			table.Entry("Once", v1.RunStrategyOnce),
 		)
 	})
 
@@ -1846,6 +2275,193 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		})
 	})
 
+	Context("Freezing", func() {
+		It("Should freeze a running VMI", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/freeze"),
+					ghttp.VerifyBody([]byte(`{"unfreezeTimeout":"5m0s"}`)),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMI(true, false)
+
+			body := map[string]interface{}{
+				"unfreezeTimeout": "5m",
+			}
+			bytesRepresentation, _ := json.Marshal(body)
+			request.Request.Body = ioutil.NopCloser(bytes.NewReader(bytesRepresentation))
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should fail freezing a not running VMI", func() {
+			expectVMI(false, false)
+
+			bytesRepresentation, _ := json.Marshal(&v1.FreezeUnfreezeTimeout{
+				UnfreezeTimeout: &k8smetav1.Duration{Duration: 5 * time.Minute},
+			})
+			request.Request.Body = ioutil.NopCloser(bytes.NewReader(bytesRepresentation))
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		table.DescribeTable("Should fail freezing with", func(body string) {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+			request.Request.Body = ioutil.NopCloser(strings.NewReader(body))
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		},
+			table.Entry("no unfreeze timeout", "{}"),
+			table.Entry("a negative unfreeze timeout", `{"unfreezeTimeout": "-1m"}`),
+			table.Entry("a malformed body", "{"),
+		)
+
+		It("Should unfreeze a running VMI", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/unfreeze"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMI(true, false)
+
+			app.UnfreezeVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should fail unfreezing a not running VMI", func() {
+			expectVMI(false, false)
+
+			app.UnfreezeVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+	})
+
+	Context("Backup", func() {
+		expectVMIWithBackup := func(backup *v1.VirtualMachineInstanceBackupState, withHandler bool) {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+				},
+				Status: v1.VirtualMachineInstanceStatus{
+					Phase:  v1.Running,
+					Backup: backup,
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+			if withHandler {
+				expectHandlerPod()
+			}
+		}
+
+		setBackupBody := func(options *v1.BackupOptions) {
+			bytesRepresentation, _ := json.Marshal(options)
+			request.Request.Body = ioutil.NopCloser(bytes.NewReader(bytesRepresentation))
+		}
+
+		It("Should start a backup of a running VMI", func() {
+			enableFeatureGate(virtconfig.IncrementalBackupGate)
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/backup"),
+					ghttp.VerifyBody([]byte(`{"name":"backup","checkpoint":"cp-2","incremental":"cp-1"}`)),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMIWithBackup(nil, true)
+			incremental := "cp-1"
+			setBackupBody(&v1.BackupOptions{Name: "backup", Checkpoint: "cp-2", Incremental: &incremental})
+
+			app.BackupVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should fail starting a backup while another one is in progress", func() {
+			enableFeatureGate(virtconfig.IncrementalBackupGate)
+			expectVMIWithBackup(&v1.VirtualMachineInstanceBackupState{Name: "other"}, false)
+			setBackupBody(&v1.BackupOptions{Name: "backup", Checkpoint: "cp-1"})
+
+			app.BackupVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should fail starting a backup when the feature gate is disabled", func() {
+			setBackupBody(&v1.BackupOptions{Name: "backup", Checkpoint: "cp-1"})
+
+			app.BackupVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		table.DescribeTable("Should fail starting a backup with", func(body string) {
+			enableFeatureGate(virtconfig.IncrementalBackupGate)
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+			request.Request.Body = ioutil.NopCloser(strings.NewReader(body))
+
+			app.BackupVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		},
+			table.Entry("no backup name", `{"checkpoint": "cp-1"}`),
+			table.Entry("no checkpoint", `{"name": "backup"}`),
+			table.Entry("an incremental base equal to the new checkpoint", `{"name": "backup", "checkpoint": "cp-1", "incremental": "cp-1"}`),
+			table.Entry("a malformed body", "{"),
+		)
+
+		It("Should end the backup of a running VMI", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/endbackup"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMIWithBackup(&v1.VirtualMachineInstanceBackupState{Name: "backup"}, true)
+
+			app.EndBackupVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should fail ending a backup of a VMI without backup", func() {
+			expectVMIWithBackup(nil, false)
+
+			app.EndBackupVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should refuse NBD connections without a backup in progress", func() {
+			expectVMIWithBackup(&v1.VirtualMachineInstanceBackupState{Name: "backup", Completed: true}, false)
+
+			app.BackupNBDRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+	})
+
 	AfterEach(func() {
 		server.Close()
 		backend.Close()
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/mutating-webhook/mutators"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -73,12 +74,12 @@ func serve(resp http.ResponseWriter, req *http.Request, m mutator) {
 	}
 }
 
-func ServeVMs(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
-	serve(resp, req, &mutators.VMsMutator{ClusterConfig: clusterConfig})
+func ServeVMs(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig, macPool *macpool.MacPool) {
+	serve(resp, req, &mutators.VMsMutator{ClusterConfig: clusterConfig, MacPool: macPool})
 }
 
-func ServeVMIs(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
-	serve(resp, req, &mutators.VMIsMutator{ClusterConfig: clusterConfig})
+func ServeVMIs(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig, macPool *macpool.MacPool) {
+	serve(resp, req, &mutators.VMIsMutator{ClusterConfig: clusterConfig, MacPool: macPool})
 }
 
 func ServeMigrationCreate(resp http.ResponseWriter, req *http.Request) {
@@ -0,0 +1,60 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package mutators
+
+import (
+	"net/http"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// assignMacAddresses allocates MAC addresses of the MAC pool to the interfaces of the spec,
+// if a MAC pool is configured. The denial of the request is returned if the allocation fails.
+func assignMacAddresses(clusterConfig *virtconfig.ClusterConfig, pool *macpool.MacPool, ar *v1beta1.AdmissionReview, objectMeta *metav1.ObjectMeta, spec *v1.VirtualMachineInstanceSpec, previousSpec *v1.VirtualMachineInstanceSpec) *v1beta1.AdmissionResponse {
+	config := clusterConfig.GetMacPool()
+	if config == nil || pool == nil {
+		return nil
+	}
+
+	meta := objectMeta.DeepCopy()
+	if meta.Namespace == "" {
+		meta.Namespace = ar.Request.Namespace
+	}
+	owner := macpool.OwnerKey(meta)
+	if meta.Name == "" {
+		// the name is generated after the admission, the request identifies the object until then
+		owner = meta.Namespace + "/" + meta.GenerateName + string(ar.Request.UID)
+	}
+
+	if err := pool.AssignMacAddresses(config, owner, spec, previousSpec); err != nil {
+		return &v1beta1.AdmissionResponse{
+			Result: &metav1.Status{
+				Message: err.Error(),
+				Code:    http.StatusUnprocessableEntity,
+			},
+		}
+	}
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -32,6 +33,7 @@ import (
 
 type VMsMutator struct {
 	ClusterConfig *virtconfig.ClusterConfig
+	MacPool       *macpool.MacPool
 }
 
 // until the minimum supported version is kubernetes 1.15 (see https://github.com/kubernetes/kubernetes/commit/c2fcdc818be1441dd788cae22648c04b1650d3af#diff-e057ec5b2ec27b4ba1e1a3915f715262)
@@ -66,6 +68,19 @@ func (mutator *VMsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissio
 	log.Log.Object(&vm).V(4).Info("Apply defaults")
 	mutator.setDefaultMachineType(&vm)
 
+	if vm.Spec.Template != nil {
+		var previousSpec *v1.VirtualMachineInstanceSpec
+		if ar.Request.Operation == v1beta1.Update {
+			oldVM := v1.VirtualMachine{}
+			if err := json.Unmarshal(ar.Request.OldObject.Raw, &oldVM); err == nil && oldVM.Spec.Template != nil {
+				previousSpec = &oldVM.Spec.Template.Spec
+			}
+		}
+		if resp := assignMacAddresses(mutator.ClusterConfig, mutator.MacPool, ar, &vm.ObjectMeta, &vm.Spec.Template.Spec, previousSpec); resp != nil {
+			return resp
+		}
+	}
+
 	var patch []patchOperation
 	var value interface{}
 	value = vm.Spec
@@ -101,6 +116,10 @@ func (mutator *VMsMutator) setDefaultMachineType(vm *v1.VirtualMachine) {
 		// nothing to do, let's the validating webhook fail later
 		return
 	}
+	if vm.Spec.Preference != nil {
+		// the preference may provide a machine type, the VMI mutator defaults it otherwise
+		return
+	}
 	if vm.Spec.Template.Spec.Domain.Machine.Type == "" {
 		vm.Spec.Template.Spec.Domain.Machine.Type = mutator.ClusterConfig.GetMachineType()
 	}
@@ -22,16 +22,20 @@ import (
 	"encoding/json"
 	rt "runtime"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"k8s.io/api/admission/v1beta1"
 	k8sv1 "k8s.io/api/core/v1"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
@@ -115,4 +119,77 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		vmSpec, _ := getVMSpecMetaFromResponse()
 		Expect(vmSpec.Template.Spec.Domain.Machine.Type).To(Equal(vm.Spec.Template.Spec.Domain.Machine.Type))
 	})
+
+	Context("with a MAC pool", func() {
+		var vmiInformer cache.SharedIndexInformer
+
+		mutateVMUpdate := func(oldVM *v1.VirtualMachine) *v1beta1.AdmissionResponse {
+			vmBytes, err := json.Marshal(vm)
+			Expect(err).ToNot(HaveOccurred())
+			oldVMBytes, err := json.Marshal(oldVM)
+			Expect(err).ToNot(HaveOccurred())
+			return mutator.Mutate(&v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Operation: v1beta1.Update,
+					Resource:  k8smetav1.GroupVersionResource{Group: v1.VirtualMachineGroupVersionKind.Group, Version: v1.VirtualMachineGroupVersionKind.Version, Resource: "virtualmachines"},
+					Object: runtime.RawExtension{
+						Raw: vmBytes,
+					},
+					OldObject: runtime.RawExtension{
+						Raw: oldVMBytes,
+					},
+				},
+			})
+		}
+
+		BeforeEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+				Data: map[string]string{
+					virtconfig.MacPoolKey: "ranges:\n- start: 02:00:00:00:00:00\n  end: 02:00:00:00:00:00\n",
+				},
+			})
+			indexers := cache.Indexers{macpool.MacAddressIndex: macpool.IndexByMacAddress}
+			vmInformer, _ := testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachine{}, indexers)
+			vmiInformer, _ = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachineInstance{}, indexers)
+			virtClient := kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+			virtClient.EXPECT().CoreV1().Return(k8sfake.NewSimpleClientset().CoreV1()).AnyTimes()
+			mutator.MacPool = macpool.NewMacPool(virtClient, "kubevirt", vmInformer, vmiInformer)
+
+			vm.Namespace = "default"
+			vm.Name = "testvm"
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "secondary", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+			vm.Spec.Template.Spec.Networks = []v1.Network{
+				{Name: "secondary", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "net"}}},
+			}
+		})
+
+		It("should allocate MAC addresses on VM create", func() {
+			vmSpec, _ := getVMSpecMetaFromResponse()
+			Expect(vmSpec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:00"))
+		})
+
+		It("should reject MAC addresses in use on VM update", func() {
+			vmiInformer.GetIndexer().Add(&v1.VirtualMachineInstance{
+				ObjectMeta: k8smetav1.ObjectMeta{Namespace: "default", Name: "other"},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						Devices: v1.Devices{
+							Interfaces: []v1.Interface{{Name: "secondary", MacAddress: "02:00:00:00:00:00"}},
+						},
+					},
+				},
+			})
+			oldVM := vm.DeepCopy()
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress = "02:00:00:00:00:00"
+
+			resp := mutateVMUpdate(oldVM)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("already in use"))
+
+			By("accepting the MAC address once it is part of the VM")
+			Expect(mutateVMUpdate(vm.DeepCopy()).Allowed).To(BeTrue())
+		})
+	})
 })
@@ -33,6 +33,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -40,6 +41,7 @@ import (
 
 type VMIsMutator struct {
 	ClusterConfig *virtconfig.ClusterConfig
+	MacPool       *macpool.MacPool
 }
 
 func (mutator *VMIsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
@@ -88,6 +90,9 @@ func (mutator *VMIsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 		if err != nil {
 			return webhookutils.ToAdmissionResponseError(err)
 		}
+		if resp := assignMacAddresses(mutator.ClusterConfig, mutator.MacPool, ar, &newVMI.ObjectMeta, &newVMI.Spec, nil); resp != nil {
+			return resp
+		}
 		v1.SetObjectDefaults_VirtualMachineInstance(newVMI)
 
 		// In a future, yet undecided, release either libvirt or QEMU are going to check the hyperv dependencies, so we can get rid of this code.
@@ -25,6 +25,7 @@ import (
 	"reflect"
 	rt "runtime"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -34,10 +35,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/rbac"
@@ -737,6 +741,60 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		}
 		Expect(ok).To(BeTrue())
 	})
+	Context("with a MAC pool", func() {
+		var vmiInformer cache.SharedIndexInformer
+
+		BeforeEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+				Data: map[string]string{
+					virtconfig.MacPoolKey: "ranges:\n- start: 02:00:00:00:00:00\n  end: 02:00:00:00:00:00\n",
+				},
+			})
+			indexers := cache.Indexers{macpool.MacAddressIndex: macpool.IndexByMacAddress}
+			vmInformer, _ := testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachine{}, indexers)
+			vmiInformer, _ = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachineInstance{}, indexers)
+			virtClient := kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+			virtClient.EXPECT().CoreV1().Return(k8sfake.NewSimpleClientset().CoreV1()).AnyTimes()
+			mutator.MacPool = macpool.NewMacPool(virtClient, "kubevirt", vmInformer, vmiInformer)
+
+			vmi.Namespace = "default"
+			vmi.Name = "testvmi"
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "secondary", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "secondary", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "net"}}},
+			}
+		})
+
+		It("should allocate MAC addresses on VMI create", func() {
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:00"))
+		})
+
+		It("should reject MAC addresses in use on VMI create", func() {
+			other := vmi.DeepCopy()
+			other.Name = "other"
+			other.Spec.Domain.Devices.Interfaces[0].MacAddress = "02:00:00:00:00:00"
+			vmiInformer.GetIndexer().Add(other)
+			vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = "02:00:00:00:00:00"
+
+			vmiBytes, err := json.Marshal(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			resp := mutator.Mutate(&v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Operation: v1beta1.Create,
+					Resource:  k8smetav1.GroupVersionResource{Group: v1.VirtualMachineInstanceGroupVersionKind.Group, Version: v1.VirtualMachineInstanceGroupVersionKind.Version, Resource: "virtualmachineinstances"},
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			})
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("already in use"))
+		})
+	})
+
 	table.DescribeTable("modify the VMI status", func(user string, shouldChange bool) {
 		oldVMI := &v1.VirtualMachineInstance{}
 		oldVMI.Status = v1.VirtualMachineInstanceStatus{
@@ -82,6 +82,7 @@ type Informers struct {
 	VMIPresetInformer       cache.SharedIndexInformer
 	NamespaceLimitsInformer cache.SharedIndexInformer
 	VMIInformer             cache.SharedIndexInformer
+	VMInformer              cache.SharedIndexInformer
 	VMRestoreInformer       cache.SharedIndexInformer
 }
 
@@ -116,6 +117,7 @@ func newInformers() *Informers {
 	kubeInformerFactory := controller.NewKubeInformerFactory(kubeClient.RestClient(), kubeClient, nil, namespace)
 	return &Informers{
 		VMIInformer:             kubeInformerFactory.VMI(),
+		VMInformer:              kubeInformerFactory.VirtualMachine(),
 		VMIPresetInformer:       kubeInformerFactory.VirtualMachinePreset(),
 		NamespaceLimitsInformer: kubeInformerFactory.LimitRanges(),
 		VMRestoreInformer:       kubeInformerFactory.VirtualMachineRestore(),
@@ -0,0 +1,176 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	backupv1alpha1 "kubevirt.io/client-go/apis/backup/v1alpha1"
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// VMBackupAdmitter validates VirtualMachineBackups
+type VMBackupAdmitter struct {
+	Config *virtconfig.ClusterConfig
+}
+
+// NewVMBackupAdmitter creates a VMBackupAdmitter
+func NewVMBackupAdmitter(config *virtconfig.ClusterConfig) *VMBackupAdmitter {
+	return &VMBackupAdmitter{
+		Config: config,
+	}
+}
+
+// Admit validates an AdmissionReview
+func (admitter *VMBackupAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	if ar.Request.Resource.Group != backupv1alpha1.SchemeGroupVersion.Group ||
+		ar.Request.Resource.Resource != "virtualmachinebackups" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	if ar.Request.Operation == v1beta1.Create && !admitter.Config.IncrementalBackupEnabled() {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("IncrementalBackup feature gate not enabled"))
+	}
+
+	vmBackup := &backupv1alpha1.VirtualMachineBackup{}
+	err := json.Unmarshal(ar.Request.Object.Raw, vmBackup)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	var causes []metav1.StatusCause
+
+	switch ar.Request.Operation {
+	case v1beta1.Create:
+		causes = validateVMBackupSpec(k8sfield.NewPath("spec"), &vmBackup.Spec)
+
+	case v1beta1.Update:
+		prevObj := &backupv1alpha1.VirtualMachineBackup{}
+		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+
+		if !reflect.DeepEqual(prevObj.Spec, vmBackup.Spec) {
+			causes = []metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "spec in immutable after creation",
+					Field:   k8sfield.NewPath("spec").String(),
+				},
+			}
+		}
+	default:
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected operation %s", ar.Request.Operation))
+	}
+
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+	return &reviewResponse
+}
+
+func validateVMBackupSpec(field *k8sfield.Path, spec *backupv1alpha1.VirtualMachineBackupSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	sourceField := field.Child("source")
+
+	if spec.Source.APIGroup == nil || *spec.Source.APIGroup != v1.GroupName {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "invalid apiGroup",
+			Field:   sourceField.Child("apiGroup").String(),
+		})
+	}
+
+	if spec.Source.Kind != virtualMachineKind {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "invalid kind",
+			Field:   sourceField.Child("kind").String(),
+		})
+	}
+
+	if spec.Source.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "missing name",
+			Field:   sourceField.Child("name").String(),
+		})
+	}
+
+	if spec.Incremental != nil && *spec.Incremental == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "incremental must name a checkpoint",
+			Field:   field.Child("incremental").String(),
+		})
+	}
+
+	mode := backupv1alpha1.BackupModePull
+	if spec.Mode != nil {
+		mode = *spec.Mode
+	}
+
+	switch mode {
+	case backupv1alpha1.BackupModePull:
+		if spec.Target != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "pull mode backups have no target",
+				Field:   field.Child("target").String(),
+			})
+		}
+	case backupv1alpha1.BackupModePush:
+		if spec.Target == nil || spec.Target.PersistentVolumeClaimName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "push mode backups require a target claim",
+				Field:   field.Child("target", "persistentVolumeClaimName").String(),
+			})
+		}
+		// the changed blocks are only reported through the NBD server
+		if spec.Incremental != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "push mode backups cannot be incremental",
+				Field:   field.Child("incremental").String(),
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("invalid mode %s", mode),
+			Field:   field.Child("mode").String(),
+		})
+	}
+
+	return causes
+}
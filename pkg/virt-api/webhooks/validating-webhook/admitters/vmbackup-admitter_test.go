@@ -0,0 +1,197 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	backupv1alpha1 "kubevirt.io/client-go/apis/backup/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VirtualMachineBackup Admitter", func() {
+	kubevirtGroup := "kubevirt.io"
+	invalidGroup := "invalid.kubevirt.io"
+	pull := backupv1alpha1.BackupModePull
+	push := backupv1alpha1.BackupModePush
+	invalidMode := backupv1alpha1.BackupMode("Invalid")
+	checkpoint := "checkpoint"
+	empty := ""
+
+	config, configMapInformer, _, _ := testutils.NewFakeClusterConfig(&corev1.ConfigMap{})
+
+	newVMBackup := func(apiGroup *string, kind, name string) *backupv1alpha1.VirtualMachineBackup {
+		return &backupv1alpha1.VirtualMachineBackup{
+			Spec: backupv1alpha1.VirtualMachineBackupSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: apiGroup,
+					Kind:     kind,
+					Name:     name,
+				},
+			},
+		}
+	}
+
+	Context("Without feature gate enabled", func() {
+		It("should reject anything", func() {
+			ar := createBackupAdmissionReview(newVMBackup(&kubevirtGroup, "VirtualMachine", "vm"))
+			resp := NewVMBackupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(Equal("IncrementalBackup feature gate not enabled"))
+		})
+	})
+
+	Context("With feature gate enabled", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.IncrementalBackupGate},
+			})
+		})
+
+		AfterEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{})
+		})
+
+		It("should reject invalid request resource", func() {
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+				},
+			}
+
+			resp := NewVMBackupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+		})
+
+		table.DescribeTable("should accept a valid backup", func(mode *backupv1alpha1.BackupMode, incremental *string, target *backupv1alpha1.BackupTarget) {
+			vmBackup := newVMBackup(&kubevirtGroup, "VirtualMachine", "vm")
+			vmBackup.Spec.Mode = mode
+			vmBackup.Spec.Incremental = incremental
+			vmBackup.Spec.Target = target
+
+			resp := NewVMBackupAdmitter(config).Admit(createBackupAdmissionReview(vmBackup))
+			Expect(resp.Allowed).To(BeTrue())
+		},
+			table.Entry("full pull mode backup", nil, nil, nil),
+			table.Entry("incremental pull mode backup", &pull, &checkpoint, nil),
+			table.Entry("full push mode backup", &push, nil, &backupv1alpha1.BackupTarget{PersistentVolumeClaimName: "target"}),
+		)
+
+		table.DescribeTable("should reject an invalid source", func(apiGroup *string, kind, name, field string) {
+			resp := NewVMBackupAdmitter(config).Admit(createBackupAdmissionReview(newVMBackup(apiGroup, kind, name)))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			table.Entry("with invalid apiGroup", &invalidGroup, "VirtualMachine", "vm", "spec.source.apiGroup"),
+			table.Entry("without apiGroup", nil, "VirtualMachine", "vm", "spec.source.apiGroup"),
+			table.Entry("with invalid kind", &kubevirtGroup, "VirtualMachineInstance", "vm", "spec.source.kind"),
+			table.Entry("without name", &kubevirtGroup, "VirtualMachine", "", "spec.source.name"),
+		)
+
+		table.DescribeTable("should reject an invalid backup", func(mode *backupv1alpha1.BackupMode, incremental *string, target *backupv1alpha1.BackupTarget, field string) {
+			vmBackup := newVMBackup(&kubevirtGroup, "VirtualMachine", "vm")
+			vmBackup.Spec.Mode = mode
+			vmBackup.Spec.Incremental = incremental
+			vmBackup.Spec.Target = target
+
+			resp := NewVMBackupAdmitter(config).Admit(createBackupAdmissionReview(vmBackup))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			table.Entry("with an invalid mode", &invalidMode, nil, nil, "spec.mode"),
+			table.Entry("with an empty checkpoint", nil, &empty, nil, "spec.incremental"),
+			table.Entry("with a pull mode target", &pull, nil, &backupv1alpha1.BackupTarget{PersistentVolumeClaimName: "target"}, "spec.target"),
+			table.Entry("without a push mode target", &push, nil, nil, "spec.target.persistentVolumeClaimName"),
+			table.Entry("with an incremental push mode backup", &push, &checkpoint, &backupv1alpha1.BackupTarget{PersistentVolumeClaimName: "target"}, "spec.incremental"),
+		)
+
+		It("should reject spec update", func() {
+			oldVMBackup := newVMBackup(&kubevirtGroup, "VirtualMachine", "vm")
+			vmBackup := newVMBackup(&kubevirtGroup, "VirtualMachine", "other-vm")
+
+			resp := NewVMBackupAdmitter(config).Admit(createBackupUpdateAdmissionReview(oldVMBackup, vmBackup))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+		})
+
+		It("should accept metadata update", func() {
+			oldVMBackup := newVMBackup(&kubevirtGroup, "VirtualMachine", "vm")
+			vmBackup := newVMBackup(&kubevirtGroup, "VirtualMachine", "vm")
+			vmBackup.Finalizers = []string{"backup.kubevirt.io/vmbackup-protection"}
+
+			resp := NewVMBackupAdmitter(config).Admit(createBackupUpdateAdmissionReview(oldVMBackup, vmBackup))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+})
+
+func createBackupAdmissionReview(vmBackup *backupv1alpha1.VirtualMachineBackup) *v1beta1.AdmissionReview {
+	bytes, _ := json.Marshal(vmBackup)
+
+	return &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Operation: v1beta1.Create,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "backup.kubevirt.io",
+				Resource: "virtualmachinebackups",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+}
+
+func createBackupUpdateAdmissionReview(old, current *backupv1alpha1.VirtualMachineBackup) *v1beta1.AdmissionReview {
+	oldBytes, _ := json.Marshal(old)
+	currentBytes, _ := json.Marshal(current)
+
+	return &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Operation: v1beta1.Update,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "backup.kubevirt.io",
+				Resource: "virtualmachinebackups",
+			},
+			Object: runtime.RawExtension{
+				Raw: currentBytes,
+			},
+			OldObject: runtime.RawExtension{
+				Raw: oldBytes,
+			},
+		},
+	}
+}
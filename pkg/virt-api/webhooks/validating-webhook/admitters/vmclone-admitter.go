@@ -0,0 +1,200 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const virtualMachineSnapshotKind = "VirtualMachineSnapshot"
+
+// VMCloneAdmitter validates VirtualMachineClones
+type VMCloneAdmitter struct {
+	Config *virtconfig.ClusterConfig
+}
+
+// NewVMCloneAdmitter creates a VMCloneAdmitter
+func NewVMCloneAdmitter(config *virtconfig.ClusterConfig) *VMCloneAdmitter {
+	return &VMCloneAdmitter{
+		Config: config,
+	}
+}
+
+// Admit validates an AdmissionReview
+func (admitter *VMCloneAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	if ar.Request.Resource.Group != clonev1alpha1.SchemeGroupVersion.Group ||
+		ar.Request.Resource.Resource != "virtualmachineclones" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	if ar.Request.Operation == v1beta1.Create {
+		if !admitter.Config.VMCloneEnabled() {
+			return webhookutils.ToAdmissionResponseError(fmt.Errorf("vm clone feature gate not enabled"))
+		}
+		// clones are taken with snapshots and restores
+		if !admitter.Config.SnapshotEnabled() {
+			return webhookutils.ToAdmissionResponseError(fmt.Errorf("Snapshot/Restore feature gate not enabled"))
+		}
+	}
+
+	vmClone := &clonev1alpha1.VirtualMachineClone{}
+	err := json.Unmarshal(ar.Request.Object.Raw, vmClone)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	var causes []metav1.StatusCause
+
+	switch ar.Request.Operation {
+	case v1beta1.Create:
+		causes = validateVMCloneSpec(k8sfield.NewPath("spec"), &vmClone.Spec)
+
+	case v1beta1.Update:
+		prevObj := &clonev1alpha1.VirtualMachineClone{}
+		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+
+		if !reflect.DeepEqual(prevObj.Spec, vmClone.Spec) {
+			causes = []metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "spec in immutable after creation",
+					Field:   k8sfield.NewPath("spec").String(),
+				},
+			}
+		}
+	default:
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected operation %s", ar.Request.Operation))
+	}
+
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+	return &reviewResponse
+}
+
+func validateVMCloneSpec(field *k8sfield.Path, spec *clonev1alpha1.VirtualMachineCloneSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	sourceField := field.Child("source")
+
+	if spec.Source == nil {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "missing source",
+				Field:   sourceField.String(),
+			},
+		}
+	}
+
+	if spec.Source.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "missing name",
+			Field:   sourceField.Child("name").String(),
+		})
+	}
+
+	apiGroup := ""
+	if spec.Source.APIGroup != nil {
+		apiGroup = *spec.Source.APIGroup
+	}
+
+	switch apiGroup {
+	case v1.GroupName:
+		if spec.Source.Kind != virtualMachineKind {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "invalid kind",
+				Field:   sourceField.Child("kind").String(),
+			})
+		}
+	case snapshotv1.SchemeGroupVersion.Group:
+		if spec.Source.Kind != virtualMachineSnapshotKind {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "invalid kind",
+				Field:   sourceField.Child("kind").String(),
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "invalid apiGroup",
+			Field:   sourceField.Child("apiGroup").String(),
+		})
+	}
+
+	if spec.Target != nil {
+		targetField := field.Child("target")
+		if spec.Target.APIGroup == nil || *spec.Target.APIGroup != v1.GroupName {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "invalid apiGroup",
+				Field:   targetField.Child("apiGroup").String(),
+			})
+		}
+		if spec.Target.Kind != virtualMachineKind {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "invalid kind",
+				Field:   targetField.Child("kind").String(),
+			})
+		}
+		if spec.Target.Name != "" && spec.Source.Kind == virtualMachineKind && spec.Target.Name == spec.Source.Name {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "target name must differ from the source name",
+				Field:   targetField.Child("name").String(),
+			})
+		}
+	}
+
+	for name, mac := range spec.NewMacAddresses {
+		if _, err := net.ParseMAC(mac); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("invalid MAC address %q", mac),
+				Field:   field.Child("newMacAddresses").Key(name).String(),
+			})
+		}
+	}
+
+	return causes
+}
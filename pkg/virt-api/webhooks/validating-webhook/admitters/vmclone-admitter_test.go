@@ -0,0 +1,213 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VirtualMachineClone Admitter", func() {
+	kubevirtGroup := "kubevirt.io"
+	snapshotGroup := "snapshot.kubevirt.io"
+	invalidGroup := "invalid.kubevirt.io"
+
+	config, configMapInformer, _, _ := testutils.NewFakeClusterConfig(&corev1.ConfigMap{})
+
+	newVMClone := func(apiGroup *string, kind, name string) *clonev1alpha1.VirtualMachineClone {
+		return &clonev1alpha1.VirtualMachineClone{
+			Spec: clonev1alpha1.VirtualMachineCloneSpec{
+				Source: &corev1.TypedLocalObjectReference{
+					APIGroup: apiGroup,
+					Kind:     kind,
+					Name:     name,
+				},
+			},
+		}
+	}
+
+	Context("Without feature gate enabled", func() {
+		It("should reject anything", func() {
+			vmClone := newVMClone(&kubevirtGroup, "VirtualMachine", "vm")
+
+			ar := createCloneAdmissionReview(vmClone)
+			resp := NewVMCloneAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(Equal("vm clone feature gate not enabled"))
+		})
+
+		It("should reject without snapshot feature gate", func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.VMCloneGate},
+			})
+			defer testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{})
+
+			ar := createCloneAdmissionReview(newVMClone(&kubevirtGroup, "VirtualMachine", "vm"))
+			resp := NewVMCloneAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(Equal("Snapshot/Restore feature gate not enabled"))
+		})
+	})
+
+	Context("With feature gate enabled", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.VMCloneGate + "," + virtconfig.SnapshotGate},
+			})
+		})
+
+		AfterEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{})
+		})
+
+		It("should reject invalid request resource", func() {
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+				},
+			}
+
+			resp := NewVMCloneAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+		})
+
+		table.DescribeTable("should accept a valid source", func(apiGroup *string, kind string) {
+			ar := createCloneAdmissionReview(newVMClone(apiGroup, kind, "source"))
+			resp := NewVMCloneAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		},
+			table.Entry("VirtualMachine", &kubevirtGroup, "VirtualMachine"),
+			table.Entry("VirtualMachineSnapshot", &snapshotGroup, "VirtualMachineSnapshot"),
+		)
+
+		table.DescribeTable("should reject an invalid source", func(apiGroup *string, kind, name, field string) {
+			ar := createCloneAdmissionReview(newVMClone(apiGroup, kind, name))
+			resp := NewVMCloneAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			table.Entry("with invalid apiGroup", &invalidGroup, "VirtualMachine", "vm", "spec.source.apiGroup"),
+			table.Entry("without apiGroup", nil, "VirtualMachine", "vm", "spec.source.apiGroup"),
+			table.Entry("with invalid kubevirt kind", &kubevirtGroup, "VirtualMachineInstance", "vm", "spec.source.kind"),
+			table.Entry("with invalid snapshot kind", &snapshotGroup, "VirtualMachineRestore", "vm", "spec.source.kind"),
+			table.Entry("without name", &kubevirtGroup, "VirtualMachine", "", "spec.source.name"),
+		)
+
+		It("should reject missing source", func() {
+			ar := createCloneAdmissionReview(&clonev1alpha1.VirtualMachineClone{})
+			resp := NewVMCloneAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.source"))
+		})
+
+		It("should reject target with the name of the source", func() {
+			vmClone := newVMClone(&kubevirtGroup, "VirtualMachine", "vm")
+			vmClone.Spec.Target = &corev1.TypedLocalObjectReference{
+				APIGroup: &kubevirtGroup,
+				Kind:     "VirtualMachine",
+				Name:     "vm",
+			}
+
+			ar := createCloneAdmissionReview(vmClone)
+			resp := NewVMCloneAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.target.name"))
+		})
+
+		It("should reject invalid MAC addresses", func() {
+			vmClone := newVMClone(&kubevirtGroup, "VirtualMachine", "vm")
+			vmClone.Spec.NewMacAddresses = map[string]string{"default": "not-a-mac"}
+
+			ar := createCloneAdmissionReview(vmClone)
+			resp := NewVMCloneAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.newMacAddresses[default]"))
+		})
+
+		It("should reject spec update", func() {
+			oldVMClone := newVMClone(&kubevirtGroup, "VirtualMachine", "vm")
+			vmClone := newVMClone(&kubevirtGroup, "VirtualMachine", "other-vm")
+
+			ar := createCloneUpdateAdmissionReview(oldVMClone, vmClone)
+			resp := NewVMCloneAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+		})
+	})
+})
+
+func createCloneAdmissionReview(vmClone *clonev1alpha1.VirtualMachineClone) *v1beta1.AdmissionReview {
+	bytes, _ := json.Marshal(vmClone)
+
+	return &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Operation: v1beta1.Create,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "clone.kubevirt.io",
+				Resource: "virtualmachineclones",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+}
+
+func createCloneUpdateAdmissionReview(old, current *clonev1alpha1.VirtualMachineClone) *v1beta1.AdmissionReview {
+	oldBytes, _ := json.Marshal(old)
+	currentBytes, _ := json.Marshal(current)
+
+	return &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Operation: v1beta1.Update,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "clone.kubevirt.io",
+				Resource: "virtualmachineclones",
+			},
+			Object: runtime.RawExtension{
+				Raw: currentBytes,
+			},
+			OldObject: runtime.RawExtension{
+				Raw: oldBytes,
+			},
+		},
+	}
+}
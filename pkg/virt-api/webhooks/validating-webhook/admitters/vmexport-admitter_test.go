@@ -0,0 +1,187 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VirtualMachineExport Admitter", func() {
+	kubevirtGroup := "kubevirt.io"
+	invalidGroup := "invalid.kubevirt.io"
+
+	config, configMapInformer, _, _ := testutils.NewFakeClusterConfig(&corev1.ConfigMap{})
+
+	newVMExport := func(apiGroup *string, kind, name string) *exportv1alpha1.VirtualMachineExport {
+		return &exportv1alpha1.VirtualMachineExport{
+			Spec: exportv1alpha1.VirtualMachineExportSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: apiGroup,
+					Kind:     kind,
+					Name:     name,
+				},
+				TokenSecretRef: "token",
+			},
+		}
+	}
+
+	Context("Without feature gate enabled", func() {
+		It("should reject anything", func() {
+			vmExport := newVMExport(&kubevirtGroup, "VirtualMachine", "vm")
+
+			ar := createExportAdmissionReview(vmExport)
+			resp := NewVMExportAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(Equal("vm export feature gate not enabled"))
+		})
+	})
+
+	Context("With feature gate enabled", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.VMExportGate},
+			})
+		})
+
+		AfterEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{})
+		})
+
+		It("should reject invalid request resource", func() {
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+				},
+			}
+
+			resp := NewVMExportAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+		})
+
+		table.DescribeTable("should accept a valid source", func(apiGroup *string, kind string) {
+			ar := createExportAdmissionReview(newVMExport(apiGroup, kind, "source"))
+			resp := NewVMExportAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		},
+			table.Entry("VirtualMachine", &kubevirtGroup, "VirtualMachine"),
+			table.Entry("PersistentVolumeClaim", nil, "PersistentVolumeClaim"),
+		)
+
+		table.DescribeTable("should reject an invalid source", func(apiGroup *string, kind, name, field string) {
+			ar := createExportAdmissionReview(newVMExport(apiGroup, kind, name))
+			resp := NewVMExportAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			table.Entry("with invalid apiGroup", &invalidGroup, "VirtualMachine", "vm", "spec.source.apiGroup"),
+			table.Entry("with invalid kubevirt kind", &kubevirtGroup, "VirtualMachineInstance", "vm", "spec.source.kind"),
+			table.Entry("with invalid core kind", nil, "Pod", "pod", "spec.source.kind"),
+			table.Entry("without name", &kubevirtGroup, "VirtualMachine", "", "spec.source.name"),
+		)
+
+		It("should reject missing tokenSecretRef", func() {
+			vmExport := newVMExport(&kubevirtGroup, "VirtualMachine", "vm")
+			vmExport.Spec.TokenSecretRef = ""
+
+			ar := createExportAdmissionReview(vmExport)
+			resp := NewVMExportAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.tokenSecretRef"))
+		})
+
+		It("should reject spec update", func() {
+			oldVMExport := newVMExport(&kubevirtGroup, "VirtualMachine", "vm")
+			vmExport := newVMExport(&kubevirtGroup, "VirtualMachine", "other-vm")
+
+			ar := createExportUpdateAdmissionReview(oldVMExport, vmExport)
+			resp := NewVMExportAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+		})
+
+		It("should allow metadata update", func() {
+			oldVMExport := newVMExport(&kubevirtGroup, "VirtualMachine", "vm")
+			vmExport := newVMExport(&kubevirtGroup, "VirtualMachine", "vm")
+			vmExport.Finalizers = []string{"finalizer"}
+
+			ar := createExportUpdateAdmissionReview(oldVMExport, vmExport)
+			resp := NewVMExportAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+})
+
+func createExportAdmissionReview(vmExport *exportv1alpha1.VirtualMachineExport) *v1beta1.AdmissionReview {
+	bytes, _ := json.Marshal(vmExport)
+
+	return &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Operation: v1beta1.Create,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "export.kubevirt.io",
+				Resource: "virtualmachineexports",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+}
+
+func createExportUpdateAdmissionReview(old, current *exportv1alpha1.VirtualMachineExport) *v1beta1.AdmissionReview {
+	oldBytes, _ := json.Marshal(old)
+	currentBytes, _ := json.Marshal(current)
+
+	return &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Operation: v1beta1.Update,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "export.kubevirt.io",
+				Resource: "virtualmachineexports",
+			},
+			Object: runtime.RawExtension{
+				Raw: currentBytes,
+			},
+			OldObject: runtime.RawExtension{
+				Raw: oldBytes,
+			},
+		},
+	}
+}
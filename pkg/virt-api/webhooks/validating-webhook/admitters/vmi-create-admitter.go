@@ -36,6 +36,7 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	hwutil "kubevirt.io/kubevirt/pkg/util/hardware"
+	"kubevirt.io/kubevirt/pkg/util/persistentstate"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -97,6 +98,7 @@ func (admitter *VMICreateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 	causes = append(causes, ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), &vmi.ObjectMeta, admitter.ClusterConfig, accountName)...)
 	// In a future, yet undecided, release either libvirt or QEMU are going to check the hyperv dependencies, so we can get rid of this code.
 	causes = append(causes, webhooks.ValidateVirtualMachineInstanceHypervFeatureDependencies(k8sfield.NewPath("spec"), &vmi.Spec)...)
+	causes = append(causes, validatePersistentStateOwner(k8sfield.NewPath("spec"), vmi)...)
 
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
@@ -128,6 +130,13 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateMemoryLimitsNegativeOrNull(field, spec)...)
 	causes = append(causes, validateHugepagesMemoryRequests(field, spec)...)
 	causes = append(causes, validateGuestMemoryLimit(field, spec)...)
+	causes = append(causes, validateTPM(field, spec, config)...)
+	causes = append(causes, validatePersistentState(field, spec, config)...)
+	causes = append(causes, validateNUMA(field, spec, config)...)
+	causes = append(causes, validateVhostUserInterfaces(field, spec)...)
+	causes = append(causes, validateInterfaceBandwidthAndLinkState(field, spec)...)
+	causes = append(causes, validateInterfaceIPAM(field, spec)...)
+	causes = append(causes, validateInterfaceFirewall(field, spec)...)
 	causes = append(causes, validateEmulatedMachine(field, spec, config)...)
 	causes = append(causes, validateFirmwareSerial(field, spec)...)
 	causes = append(causes, validateCPURequestNotNegative(field, spec)...)
@@ -136,6 +145,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateCpuPinning(field, spec)...)
 	causes = append(causes, validateCPUIsolatorThread(field, spec)...)
 	causes = append(causes, validateCPUFeaturePolicies(field, spec)...)
+	causes = append(causes, validateCPUHotplug(field, spec, config)...)
 
 	maxNumberOfInterfacesExceeded := len(spec.Domain.Devices.Interfaces) > arrayLenMax
 	if maxNumberOfInterfacesExceeded {
@@ -260,6 +270,38 @@ func validateInterfaceNetworkBasics(field *k8sfield.Path, networkExists bool, id
 		causes = appendStatusCauseForMacvtapFeatureGateNotEnabled(field, causes, idx)
 	} else if iface.InterfaceBindingMethod.Macvtap != nil && networkData.NetworkSource.Multus == nil {
 		causes = appendStatusCauseForMacvtapOnlyAllowedWithMultus(field, causes, idx)
+	} else if iface.InterfaceBindingMethod.VhostUser != nil && !config.VhostUserEnabled() {
+		causes = appendStatusCauseForVhostUserFeatureGateNotEnabled(field, causes, idx)
+	} else if iface.InterfaceBindingMethod.VhostUser != nil && networkData.NetworkSource.Multus == nil {
+		causes = appendStatusCauseForVhostUserOnlyAllowedWithMultus(field, causes, idx)
+	} else if iface.Binding != nil {
+		causes = append(causes, validateInterfaceBindingPlugin(field, iface, idx, config)...)
+	}
+	return causes
+}
+
+func validateInterfaceBindingPlugin(field *k8sfield.Path, iface v1.Interface, idx int, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	bindingField := field.Child("domain", "devices", "interfaces").Index(idx).Child("binding")
+	if !config.NetworkBindingPluginsEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.NetworkBindingPluginsGate),
+			Field:   bindingField.String(),
+		})
+	}
+	if iface.InterfaceBindingMethod != (v1.InterfaceBindingMethod{}) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s can not be combined with another binding method", bindingField.String()),
+			Field:   bindingField.String(),
+		})
+	}
+	if _, exists := config.GetNetworkBindings()[iface.Binding.Name]; !exists {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s refers to the network binding plugin %s which is not registered in the KubeVirt configuration", bindingField.String(), iface.Binding.Name),
+			Field:   bindingField.Child("name").String(),
+		})
 	}
 	return causes
 }
@@ -480,6 +522,22 @@ func appendStatusCauseForMacvtapFeatureGateNotEnabled(field *k8sfield.Path, caus
 	return causes
 }
 
+func appendStatusCauseForVhostUserFeatureGateNotEnabled(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	return append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "VhostUser feature gate is not enabled",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+}
+
+func appendStatusCauseForVhostUserOnlyAllowedWithMultus(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	return append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "Vhostuser interface only implemented with Multus network",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+}
+
 func appendStatusCauseForBridgeNotEnabled(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
 	causes = append(causes, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueInvalid,
@@ -854,6 +912,10 @@ func validateNetworkHasOnlyOneType(field *k8sfield.Path, cniTypesCount int, caus
 	return causes
 }
 
+func isCDRomOnly(disk v1.Disk) bool {
+	return disk.CDRom != nil && disk.Disk == nil && disk.LUN == nil && disk.Floppy == nil
+}
+
 func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, volumeNameMap map[string]*v1.Volume) (bootOrderMap map[uint]bool, causes []metav1.StatusCause) {
 	// used to validate uniqueness of boot orders among disks and interfaces
 	bootOrderMap = make(map[uint]bool)
@@ -868,7 +930,8 @@ func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec
 
 		matchingVolume, volumeExists := volumeNameMap[disk.Name]
 
-		if !volumeExists {
+		// A CD-ROM without a volume is an empty drive
+		if !volumeExists && !isCDRomOnly(disk) {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf(nameOfTypeNotFoundMessagePattern, field.Child("domain", "devices", "disks").Index(idx).Child("Name").String(), disk.Name),
@@ -951,6 +1014,36 @@ func validateCPUIsolatorThread(field *k8sfield.Path, spec *v1.VirtualMachineInst
 	return causes
 }
 
+func validateCPUHotplug(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.CPU == nil || spec.Domain.CPU.MaxSockets == 0 {
+		return causes
+	}
+	cpu := spec.Domain.CPU
+	if !config.CPUHotplugEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "CPUHotplug feature gate is not enabled in kubevirt-config",
+			Field:   field.Child("domain", "cpu", "maxSockets").String(),
+		})
+		return causes
+	}
+	if cpu.Sockets > cpu.MaxSockets {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must not be greater than %s", field.Child("domain", "cpu", "sockets").String(), field.Child("domain", "cpu", "maxSockets").String()),
+			Field:   field.Child("domain", "cpu", "sockets").String(),
+		})
+	}
+	if cpu.DedicatedCPUPlacement {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s can not be set in combination with DedicatedCPUPlacement", field.Child("domain", "cpu", "maxSockets").String()),
+			Field:   field.Child("domain", "cpu", "maxSockets").String(),
+		})
+	}
+	return causes
+}
+
 func validateCpuPinning(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
 	if spec.Domain.CPU != nil && spec.Domain.CPU.DedicatedCPUPlacement {
 		causes = append(causes, validateMemoryLimitAndRequestProvided(field, spec)...)
@@ -1169,6 +1262,351 @@ func validateGuestMemoryLimit(field *k8sfield.Path, spec *v1.VirtualMachineInsta
 	return causes
 }
 
+func validateTPM(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.Devices.TPM != nil && !config.TPMEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.TPMGate),
+			Field:   field.Child("domain", "devices", "tpm").String(),
+		})
+	}
+	return causes
+}
+
+func validatePersistentState(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if config.VMPersistentStateEnabled() {
+		return causes
+	}
+	if persistentstate.HasPersistentEFI(spec) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.VMPersistentStateGate),
+			Field:   field.Child("domain", "firmware", "bootloader", "efi", "persistent").String(),
+		})
+	}
+	if persistentstate.HasPersistentTPM(spec) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.VMPersistentStateGate),
+			Field:   field.Child("domain", "devices", "tpm", "persistent").String(),
+		})
+	}
+	return causes
+}
+
+func validateNUMA(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.CPU == nil || spec.Domain.CPU.NUMA == nil || spec.Domain.CPU.NUMA.GuestMappingPassthrough == nil {
+		return causes
+	}
+	passthroughField := field.Child("domain", "cpu", "numa", "guestMappingPassthrough")
+	if !config.NUMAEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.NUMAGate),
+			Field:   passthroughField.String(),
+		})
+		return causes
+	}
+	if !spec.Domain.CPU.DedicatedCPUPlacement {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be set in combination with %s", passthroughField.String(), field.Child("domain", "cpu", "dedicatedCpuPlacement").String()),
+			Field:   passthroughField.String(),
+		})
+	}
+	if spec.Domain.Memory == nil || spec.Domain.Memory.Hugepages == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be set in combination with %s", passthroughField.String(), field.Child("domain", "memory", "hugepages").String()),
+			Field:   passthroughField.String(),
+		})
+	}
+	return causes
+}
+
+// validateVhostUserInterfaces ensures that the guest memory can be shared with the userspace switch
+// and that vhostuser interfaces use a virtio model.
+func validateVhostUserInterfaces(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if iface.VhostUser == nil {
+			continue
+		}
+		ifaceField := field.Child("domain", "devices", "interfaces").Index(idx)
+		if spec.Domain.Memory == nil || spec.Domain.Memory.Hugepages == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be set in combination with %s", ifaceField.Child("vhostuser").String(), field.Child("domain", "memory", "hugepages").String()),
+				Field:   ifaceField.Child("vhostuser").String(),
+			})
+		}
+		if iface.Model != "" && iface.Model != "virtio" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be virtio for vhostuser interfaces", ifaceField.Child("model").String()),
+				Field:   ifaceField.Child("model").String(),
+			})
+		}
+	}
+	return causes
+}
+
+// validateInterfaceBandwidthAndLinkState ensures that bandwidth limits and link states are only requested
+// for interfaces which are backed by a libvirt network device that supports them.
+func validateInterfaceBandwidthAndLinkState(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		ifaceField := field.Child("domain", "devices", "interfaces").Index(idx)
+
+		switch iface.LinkState {
+		case "", v1.InterfaceLinkStateUp, v1.InterfaceLinkStateDown:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s must be one of %s or %s", ifaceField.Child("linkState").String(), v1.InterfaceLinkStateUp, v1.InterfaceLinkStateDown),
+				Field:   ifaceField.Child("linkState").String(),
+			})
+		}
+		if iface.LinkState != "" && (iface.SRIOV != nil || iface.Slirp != nil) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s is not supported for SR-IOV and slirp interfaces", ifaceField.Child("linkState").String()),
+				Field:   ifaceField.Child("linkState").String(),
+			})
+		}
+
+		if iface.Bandwidth == nil {
+			continue
+		}
+		// libvirt shapes the traffic on the tap device of the interface
+		if iface.SRIOV != nil || iface.Slirp != nil || iface.VhostUser != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s is not supported for SR-IOV, slirp and vhostuser interfaces", ifaceField.Child("bandwidth").String()),
+				Field:   ifaceField.Child("bandwidth").String(),
+			})
+		}
+		causes = append(causes, validateBandwidthLimits(ifaceField.Child("bandwidth", "inbound"), iface.Bandwidth.Inbound)...)
+		causes = append(causes, validateBandwidthLimits(ifaceField.Child("bandwidth", "outbound"), iface.Bandwidth.Outbound)...)
+	}
+	return causes
+}
+
+// validateInterfaceIPAM ensures that static IPAM is only requested where no address is discovered
+// on the pod interface, and that the configuration can be parsed.
+func validateInterfaceIPAM(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	networks := map[string]v1.Network{}
+	for _, network := range spec.Networks {
+		networks[network.Name] = network
+	}
+
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if iface.IPAM == nil {
+			continue
+		}
+		ipamField := field.Child("domain", "devices", "interfaces").Index(idx).Child("ipam")
+
+		network, exists := networks[iface.Name]
+		if iface.Bridge == nil || !exists || network.Multus == nil || network.Multus.Default {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s is only supported for bridge interfaces on secondary Multus networks", ipamField.String()),
+				Field:   ipamField.String(),
+			})
+			continue
+		}
+
+		var hasIPv4, hasIPv6 bool
+		if len(iface.IPAM.Addresses) == 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must contain at least one address", ipamField.Child("addresses").String()),
+				Field:   ipamField.Child("addresses").String(),
+			})
+		}
+		for addrIdx, address := range iface.IPAM.Addresses {
+			addrField := ipamField.Child("addresses").Index(addrIdx)
+			ip, _, err := net.ParseCIDR(address)
+			if err != nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be an address in CIDR notation", addrField.String()),
+					Field:   addrField.String(),
+				})
+				continue
+			}
+			isIPv4 := ip.To4() != nil
+			if (isIPv4 && hasIPv4) || (!isIPv4 && hasIPv6) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s can only contain one IPv4 and one IPv6 address", ipamField.Child("addresses").String()),
+					Field:   addrField.String(),
+				})
+			}
+			hasIPv4 = hasIPv4 || isIPv4
+			hasIPv6 = hasIPv6 || !isIPv4
+		}
+
+		if iface.IPAM.Gateway != "" {
+			if ip := net.ParseIP(iface.IPAM.Gateway); ip == nil || ip.To4() == nil || !hasIPv4 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be an IPv4 address and requires an IPv4 address on the interface", ipamField.Child("gateway").String()),
+					Field:   ipamField.Child("gateway").String(),
+				})
+			}
+		}
+		if iface.IPAM.GatewayIPv6 != "" {
+			if ip := net.ParseIP(iface.IPAM.GatewayIPv6); ip == nil || ip.To4() != nil || !hasIPv6 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be an IPv6 address and requires an IPv6 address on the interface", ipamField.Child("gatewayIPv6").String()),
+					Field:   ipamField.Child("gatewayIPv6").String(),
+				})
+			}
+		}
+
+		for routeIdx, route := range iface.IPAM.Routes {
+			routeField := ipamField.Child("routes").Index(routeIdx)
+			_, dst, err := net.ParseCIDR(route.Destination)
+			gw := net.ParseIP(route.Gateway)
+			if err != nil || gw == nil || (dst.IP.To4() == nil) != (gw.To4() == nil) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must have a destination in CIDR notation and a gateway of the same IP family", routeField.String()),
+					Field:   routeField.String(),
+				})
+			}
+		}
+
+		if iface.IPAM.DNS != nil {
+			for nsIdx, nameserver := range iface.IPAM.DNS.Nameservers {
+				if net.ParseIP(nameserver) == nil {
+					nsField := ipamField.Child("dns", "nameservers").Index(nsIdx)
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("%s must be an IP address", nsField.String()),
+						Field:   nsField.String(),
+					})
+				}
+			}
+		}
+	}
+	return causes
+}
+
+// validateInterfaceFirewall ensures that firewall rules are only requested for interfaces whose
+// traffic passes the virt-launcher pod, and that they can be translated into nftables rules.
+func validateInterfaceFirewall(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if iface.Firewall == nil {
+			continue
+		}
+		firewallField := field.Child("domain", "devices", "interfaces").Index(idx).Child("firewall")
+
+		if iface.Bridge == nil && iface.Masquerade == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s is only supported for bridge and masquerade interfaces", firewallField.String()),
+				Field:   firewallField.String(),
+			})
+			continue
+		}
+		causes = append(causes, validateFirewallPolicy(firewallField.Child("ingress"), iface.Firewall.Ingress)...)
+		causes = append(causes, validateFirewallPolicy(firewallField.Child("egress"), iface.Firewall.Egress)...)
+	}
+	return causes
+}
+
+func validateFirewallPolicy(field *k8sfield.Path, policy *v1.FirewallPolicy) (causes []metav1.StatusCause) {
+	if policy == nil {
+		return causes
+	}
+
+	validateAction := func(field *k8sfield.Path, action v1.FirewallAction, optional bool) {
+		if action == v1.FirewallActionAllow || action == v1.FirewallActionDeny || (optional && action == "") {
+			return
+		}
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be one of %s or %s", field.String(), v1.FirewallActionAllow, v1.FirewallActionDeny),
+			Field:   field.String(),
+		})
+	}
+
+	validateAction(field.Child("defaultAction"), policy.DefaultAction, true)
+	for ruleIdx, rule := range policy.Rules {
+		ruleField := field.Child("rules").Index(ruleIdx)
+		validateAction(ruleField.Child("action"), rule.Action, false)
+
+		if rule.CIDR != "" {
+			if _, _, err := net.ParseCIDR(rule.CIDR); err != nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be a network in CIDR notation", ruleField.Child("cidr").String()),
+					Field:   ruleField.Child("cidr").String(),
+				})
+			}
+		}
+
+		switch rule.Protocol {
+		case "", "TCP", "UDP", "ICMP":
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s must be one of TCP, UDP or ICMP", ruleField.Child("protocol").String()),
+				Field:   ruleField.Child("protocol").String(),
+			})
+		}
+
+		if rule.Port == 0 {
+			continue
+		}
+		if rule.Port < 0 || rule.Port > 65535 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be between 1 and 65535", ruleField.Child("port").String()),
+				Field:   ruleField.Child("port").String(),
+			})
+		}
+		if rule.Protocol != "TCP" && rule.Protocol != "UDP" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s requires the TCP or UDP protocol", ruleField.Child("port").String()),
+				Field:   ruleField.Child("port").String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateBandwidthLimits(field *k8sfield.Path, limits *v1.BandwidthLimits) (causes []metav1.StatusCause) {
+	if limits != nil && limits.Average == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be greater than 0", field.Child("average").String()),
+			Field:   field.Child("average").String(),
+		})
+	}
+	return causes
+}
+
+// validatePersistentStateOwner ensures that only VMIs of a VirtualMachine ask for persistent state,
+// since the state is kept in a PVC which belongs to the VirtualMachine.
+func validatePersistentStateOwner(field *k8sfield.Path, vmi *v1.VirtualMachineInstance) (causes []metav1.StatusCause) {
+	if !persistentstate.HasPersistentState(&vmi.Spec) {
+		return causes
+	}
+	owner := metav1.GetControllerOf(vmi)
+	if owner != nil && owner.Kind == v1.VirtualMachineGroupVersionKind.Kind {
+		return causes
+	}
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueNotSupported,
+		Message: fmt.Sprintf("%s requests persistent state, which is only supported for VirtualMachineInstances owned by a VirtualMachine", field.String()),
+		Field:   field.String(),
+	})
+	return causes
+}
+
 func validateHugepagesMemoryRequests(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
 	if spec.Domain.Memory != nil && spec.Domain.Memory.Hugepages != nil {
 		hugepagesSize, err := resource.ParseQuantity(spec.Domain.Memory.Hugepages.PageSize)
@@ -2117,6 +2555,17 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 			})
 		}
 
+		if disk.IOTune != nil {
+			if disk.CDRom != nil || disk.Floppy != nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s is only supported for disk and lun devices", field.Index(idx).Child("ioTune").String()),
+					Field:   field.Index(idx).Child("ioTune").String(),
+				})
+			}
+			causes = append(causes, ValidateDiskIOTune(field.Index(idx).Child("ioTune"), disk.IOTune)...)
+		}
+
 		// Verify disk and volume name can be a valid container name since disk
 		// name can become a container name which will fail to schedule if invalid
 		errs := validation.IsDNS1123Label(disk.Name)
@@ -2132,3 +2581,76 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 
 	return causes
 }
+
+// ValidateDiskIOTune ensures that the I/O limits of a disk are accepted by libvirt: total limits
+// can not be combined with read or write limits, and a burst limit needs a lower limit of its kind.
+func ValidateDiskIOTune(field *k8sfield.Path, ioTune *v1.DiskIOTune) (causes []metav1.StatusCause) {
+	if ioTune == nil {
+		return nil
+	}
+	burst := ioTune.Burst
+	if burst == nil {
+		burst = &v1.DiskIOTuneBurst{}
+	}
+
+	limits := []struct {
+		name         string
+		limit, burst uint64
+	}{
+		{"totalBytesSec", ioTune.TotalBytesSec, burst.TotalBytesSec},
+		{"readBytesSec", ioTune.ReadBytesSec, burst.ReadBytesSec},
+		{"writeBytesSec", ioTune.WriteBytesSec, burst.WriteBytesSec},
+		{"totalIopsSec", ioTune.TotalIOPSSec, burst.TotalIOPSSec},
+		{"readIopsSec", ioTune.ReadIOPSSec, burst.ReadIOPSSec},
+		{"writeIopsSec", ioTune.WriteIOPSSec, burst.WriteIOPSSec},
+	}
+
+	hasBurst := false
+	for i, limit := range limits {
+		// the total limit of a kind is followed by its read and write limits
+		if i%3 == 0 {
+			read, write := limits[i+1], limits[i+2]
+			if limit.limit != 0 && (read.limit != 0 || write.limit != 0) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s can not be combined with %s or %s", field.Child(limit.name).String(), read.name, write.name),
+					Field:   field.Child(limit.name).String(),
+				})
+			}
+			if limit.burst != 0 && (read.burst != 0 || write.burst != 0) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s can not be combined with %s or %s", field.Child("burst", limit.name).String(), read.name, write.name),
+					Field:   field.Child("burst", limit.name).String(),
+				})
+			}
+		}
+
+		if limit.burst == 0 {
+			continue
+		}
+		hasBurst = true
+		if limit.limit == 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s requires %s to be set", field.Child("burst", limit.name).String(), field.Child(limit.name).String()),
+				Field:   field.Child("burst", limit.name).String(),
+			})
+		} else if limit.burst <= limit.limit {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be greater than %s", field.Child("burst", limit.name).String(), field.Child(limit.name).String()),
+				Field:   field.Child("burst", limit.name).String(),
+			})
+		}
+	}
+
+	if !hasBurst && burst.LengthSeconds != 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires a burst limit", field.Child("burst", "lengthSeconds").String()),
+			Field:   field.Child("burst", "lengthSeconds").String(),
+		})
+	}
+	return causes
+}
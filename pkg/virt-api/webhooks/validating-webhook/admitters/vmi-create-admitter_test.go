@@ -68,9 +68,9 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 	vmiCreateAdmitter := &VMICreateAdmitter{ClusterConfig: config}
 
 	dnsConfigTestOption := "test"
-	enableFeatureGate := func(featureGate string) {
+	enableFeatureGate := func(featureGates ...string) {
 		kvConfig := kv.DeepCopy()
-		kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{featureGate}
+		kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = featureGates
 		testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
 	}
 	disableFeatureGates := func() {
@@ -568,6 +568,19 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].name"))
 		})
+		It("should accept a CD-ROM without a volume", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testcdrom",
+				DiskDevice: v1.DiskDevice{
+					CDRom: &v1.CDRomTarget{Bus: "sata"},
+				},
+			})
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
 		It("should reject multiple disks referencing same volume", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
@@ -1589,6 +1602,200 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
 			Expect(causes).To(HaveLen(0))
 		})
+		Context("with vhostuser interface", func() {
+			var vm *v1.VirtualMachineInstance
+			BeforeEach(func() {
+				vm = v1.NewMinimalVMI("testvm")
+				vm.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+				vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+					Name:                   "dpdk",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+				}}
+				vm.Spec.Networks = []v1.Network{{
+					Name:          "dpdk",
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "ovs-dpdk"}},
+				}}
+			})
+
+			It("should reject the interface when the feature is inactive", func() {
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].name"))
+				Expect(causes[0].Message).To(Equal("VhostUser feature gate is not enabled"))
+			})
+			It("should accept the interface on a multus network when the feature is active", func() {
+				enableFeatureGate(virtconfig.VhostUserGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+			It("should reject the interface on the pod network", func() {
+				enableFeatureGate(virtconfig.VhostUserGate)
+				vm.Spec.Networks = []v1.Network{{Name: "dpdk", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}}}
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].name"))
+				Expect(causes[0].Message).To(Equal("Vhostuser interface only implemented with Multus network"))
+			})
+			It("should reject the interface without hugepages", func() {
+				enableFeatureGate(virtconfig.VhostUserGate)
+				vm.Spec.Domain.Memory = nil
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].vhostuser"))
+			})
+			It("should reject the interface with a model other than virtio", func() {
+				enableFeatureGate(virtconfig.VhostUserGate)
+				vm.Spec.Domain.Devices.Interfaces[0].Model = "e1000"
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].model"))
+			})
+		})
+		Context("with interface bandwidth and link state", func() {
+			var vm *v1.VirtualMachineInstance
+			BeforeEach(func() {
+				vm = v1.NewMinimalVMI("testvm")
+				vm.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+				vm.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			})
+
+			It("should accept bandwidth limits and link state on a bridge interface", func() {
+				vm.Spec.Domain.Devices.Interfaces[0].Bandwidth = &v1.InterfaceBandwidth{
+					Inbound:  &v1.BandwidthLimits{Average: 1024},
+					Outbound: &v1.BandwidthLimits{Average: 512},
+				}
+				vm.Spec.Domain.Devices.Interfaces[0].LinkState = v1.InterfaceLinkStateDown
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+			It("should reject an unknown link state", func() {
+				vm.Spec.Domain.Devices.Interfaces[0].LinkState = "sideways"
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].linkState"))
+			})
+			It("should reject a zero average rate", func() {
+				vm.Spec.Domain.Devices.Interfaces[0].Bandwidth = &v1.InterfaceBandwidth{
+					Outbound: &v1.BandwidthLimits{},
+				}
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].bandwidth.outbound.average"))
+			})
+			It("should reject bandwidth limits and link state on a slirp interface", func() {
+				enableSlirpInterface()
+				vm.Spec.Domain.Devices.Interfaces[0] = *v1.DefaultSlirpNetworkInterface()
+				vm.Spec.Domain.Devices.Interfaces[0].Bandwidth = &v1.InterfaceBandwidth{
+					Inbound: &v1.BandwidthLimits{Average: 1024},
+				}
+				vm.Spec.Domain.Devices.Interfaces[0].LinkState = v1.InterfaceLinkStateDown
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(2))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].linkState"))
+				Expect(causes[1].Field).To(Equal("fake.domain.devices.interfaces[0].bandwidth"))
+			})
+		})
+		Context("with static IPAM", func() {
+			var vm *v1.VirtualMachineInstance
+			BeforeEach(func() {
+				vm = v1.NewMinimalVMI("testvm")
+				vm.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					*v1.DefaultBridgeNetworkInterface(),
+					{
+						Name:                   "blue",
+						InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+						IPAM: &v1.InterfaceIPAM{
+							Addresses:   []string{"192.168.100.10/24", "fd10:100::10/64"},
+							Gateway:     "192.168.100.1",
+							GatewayIPv6: "fd10:100::1",
+							Routes:      []v1.InterfaceRoute{{Destination: "10.10.0.0/16", Gateway: "192.168.100.254"}},
+							DNS:         &v1.InterfaceDNS{Nameservers: []string{"192.168.100.2"}},
+						},
+					},
+				}
+				vm.Spec.Networks = []v1.Network{
+					*v1.DefaultPodNetwork(),
+					{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+				}
+			})
+
+			It("should accept a bridge interface on a secondary multus network", func() {
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+			It("should reject static IPAM on the pod network", func() {
+				vm.Spec.Domain.Devices.Interfaces[0].IPAM = vm.Spec.Domain.Devices.Interfaces[1].IPAM
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].ipam"))
+			})
+			table.DescribeTable("should reject", func(ipam v1.InterfaceIPAM, field string) {
+				vm.Spec.Domain.Devices.Interfaces[1].IPAM = &ipam
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(field))
+			},
+				table.Entry("missing addresses", v1.InterfaceIPAM{}, "fake.domain.devices.interfaces[1].ipam.addresses"),
+				table.Entry("an address without prefix", v1.InterfaceIPAM{Addresses: []string{"192.168.100.10"}}, "fake.domain.devices.interfaces[1].ipam.addresses[0]"),
+				table.Entry("two IPv4 addresses", v1.InterfaceIPAM{Addresses: []string{"192.168.100.10/24", "192.168.100.11/24"}}, "fake.domain.devices.interfaces[1].ipam.addresses[1]"),
+				table.Entry("an IPv6 gateway without IPv6 address", v1.InterfaceIPAM{Addresses: []string{"192.168.100.10/24"}, GatewayIPv6: "fd10:100::1"}, "fake.domain.devices.interfaces[1].ipam.gatewayIPv6"),
+				table.Entry("an IPv6 address as IPv4 gateway", v1.InterfaceIPAM{Addresses: []string{"192.168.100.10/24"}, Gateway: "fd10:100::1"}, "fake.domain.devices.interfaces[1].ipam.gateway"),
+				table.Entry("a route with mixed IP families", v1.InterfaceIPAM{Addresses: []string{"192.168.100.10/24"}, Routes: []v1.InterfaceRoute{{Destination: "10.10.0.0/16", Gateway: "fd10:100::1"}}}, "fake.domain.devices.interfaces[1].ipam.routes[0]"),
+				table.Entry("a malformed nameserver", v1.InterfaceIPAM{Addresses: []string{"192.168.100.10/24"}, DNS: &v1.InterfaceDNS{Nameservers: []string{"dns.example.com"}}}, "fake.domain.devices.interfaces[1].ipam.dns.nameservers[0]"),
+			)
+		})
+		Context("with an interface firewall", func() {
+			var vm *v1.VirtualMachineInstance
+			BeforeEach(func() {
+				vm = v1.NewMinimalVMI("testvm")
+				vm.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+				vm.Spec.Domain.Devices.Interfaces[0].Firewall = &v1.InterfaceFirewall{
+					Ingress: &v1.FirewallPolicy{
+						Rules: []v1.FirewallRule{
+							{Action: v1.FirewallActionAllow, CIDR: "10.10.0.0/16", Protocol: "TCP", Port: 22},
+							{Action: v1.FirewallActionAllow, Protocol: "ICMP"},
+						},
+						DefaultAction: v1.FirewallActionDeny,
+					},
+					Egress: &v1.FirewallPolicy{
+						Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, CIDR: "fd10::/64"}},
+					},
+				}
+				vm.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			})
+
+			It("should accept a firewall on a masquerade interface", func() {
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+			It("should reject a firewall on a slirp interface", func() {
+				enableSlirpInterface()
+				firewall := vm.Spec.Domain.Devices.Interfaces[0].Firewall
+				vm.Spec.Domain.Devices.Interfaces[0] = *v1.DefaultSlirpNetworkInterface()
+				vm.Spec.Domain.Devices.Interfaces[0].Firewall = firewall
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].firewall"))
+			})
+			table.DescribeTable("should reject", func(rule v1.FirewallRule, field string) {
+				vm.Spec.Domain.Devices.Interfaces[0].Firewall.Egress.Rules = []v1.FirewallRule{rule}
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(field))
+			},
+				table.Entry("a missing action", v1.FirewallRule{CIDR: "10.10.0.0/16"}, "fake.domain.devices.interfaces[0].firewall.egress.rules[0].action"),
+				table.Entry("an address without prefix", v1.FirewallRule{Action: v1.FirewallActionDeny, CIDR: "10.10.0.1"}, "fake.domain.devices.interfaces[0].firewall.egress.rules[0].cidr"),
+				table.Entry("an unknown protocol", v1.FirewallRule{Action: v1.FirewallActionDeny, Protocol: "SCTP"}, "fake.domain.devices.interfaces[0].firewall.egress.rules[0].protocol"),
+				table.Entry("a port without protocol", v1.FirewallRule{Action: v1.FirewallActionDeny, Port: 53}, "fake.domain.devices.interfaces[0].firewall.egress.rules[0].port"),
+				table.Entry("a port out of range", v1.FirewallRule{Action: v1.FirewallActionDeny, Protocol: "UDP", Port: 65536}, "fake.domain.devices.interfaces[0].firewall.egress.rules[0].port"),
+			)
+			It("should reject an unknown default action", func() {
+				vm.Spec.Domain.Devices.Interfaces[0].Firewall.Egress.DefaultAction = "reject"
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].firewall.egress.defaultAction"))
+			})
+		})
 		It("should reject port out of range", func() {
 			enableSlirpInterface()
 			vm := v1.NewMinimalVMI("testvm")
@@ -2219,6 +2426,219 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		})
 	})
 
+	Context("with cpu hotplug", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 2, MaxSockets: 4}
+		})
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+		It("should accept maxSockets when the CPUHotplug feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.CPUHotplugGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject maxSockets when the CPUHotplug feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.maxSockets"))
+		})
+		It("should reject sockets exceeding maxSockets", func() {
+			enableFeatureGate(virtconfig.CPUHotplugGate)
+			vmi.Spec.Domain.CPU.Sockets = 5
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.sockets"))
+		})
+		It("should reject maxSockets in combination with DedicatedCPUPlacement", func() {
+			enableFeatureGate(virtconfig.CPUHotplugGate)
+			vmi.Spec.Domain.CPU.DedicatedCPUPlacement = true
+			vmi.Spec.Domain.Resources.Limits = k8sv1.ResourceList{
+				k8sv1.ResourceCPU:    resource.MustParse("2"),
+				k8sv1.ResourceMemory: resource.MustParse("64M"),
+			}
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceCPU:    resource.MustParse("2"),
+				k8sv1.ResourceMemory: resource.MustParse("64M"),
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			var fields []string
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(ContainElement("fake.domain.cpu.maxSockets"))
+		})
+	})
+
+	Context("with persistent state", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			persistent := true
+			secureBoot := false
+			vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{Persistent: &persistent}
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{EFI: &v1.EFI{SecureBoot: &secureBoot, Persistent: &persistent}},
+			}
+		})
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		admit := func(vmi *v1.VirtualMachineInstance) *v1beta1.AdmissionResponse {
+			vmiBytes, _ := json.Marshal(vmi)
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+			return vmiCreateAdmitter.Admit(ar)
+		}
+
+		It("should accept persistent state when the VMPersistentState feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.VMPersistentStateGate, virtconfig.TPMGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should accept a non persistent TPM when the VMPersistentState feature gate is disabled", func() {
+			enableFeatureGate(virtconfig.TPMGate)
+			vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{}
+			vmi.Spec.Domain.Firmware = nil
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject a TPM when the TPM feature gate is disabled", func() {
+			enableFeatureGate(virtconfig.VMPersistentStateGate)
+			vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{}
+			vmi.Spec.Domain.Firmware = nil
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.tpm"))
+		})
+		It("should reject persistent state when the VMPersistentState feature gate is disabled", func() {
+			enableFeatureGate(virtconfig.TPMGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			var fields []string
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(ConsistOf("fake.domain.firmware.bootloader.efi.persistent", "fake.domain.devices.tpm.persistent"))
+		})
+		It("should reject persistent state on a VMI not owned by a VirtualMachine", func() {
+			enableFeatureGate(virtconfig.VMPersistentStateGate, virtconfig.TPMGate)
+			resp := admit(vmi)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+		})
+		It("should accept persistent state on a VMI owned by a VirtualMachine", func() {
+			enableFeatureGate(virtconfig.VMPersistentStateGate, virtconfig.TPMGate)
+			controller := true
+			vmi.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: v1.VirtualMachineGroupVersionKind.GroupVersion().String(),
+				Kind:       v1.VirtualMachineGroupVersionKind.Kind,
+				Name:       "testvmi",
+				UID:        "1234",
+				Controller: &controller,
+			}}
+			resp := admit(vmi)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
+	Context("with network binding plugins", func() {
+		var vmi *v1.VirtualMachineInstance
+		enableBindingPlugins := func() {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.NetworkBindingPluginsGate}
+			kvConfig.Spec.Configuration.NetworkConfiguration = &v1.NetworkConfiguration{
+				Binding: map[string]v1.InterfaceBindingPlugin{
+					"custom": {SidecarImage: "registry:5000/custom-binding:latest", DomainAttachmentType: v1.Tap},
+				},
+			}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+		}
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:    "default",
+				Binding: &v1.PluginBinding{Name: "custom"},
+			}}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		})
+		It("should accept an interface bound by a registered plugin", func() {
+			enableBindingPlugins()
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject a plugin binding when the NetworkBindingPlugins feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].binding"))
+		})
+		It("should reject a plugin which is not registered", func() {
+			enableBindingPlugins()
+			vmi.Spec.Domain.Devices.Interfaces[0].Binding.Name = "unknown"
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].binding.name"))
+		})
+		It("should reject a plugin binding combined with another binding method", func() {
+			enableBindingPlugins()
+			vmi.Spec.Domain.Devices.Interfaces[0].Masquerade = &v1.InterfaceMasquerade{}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].binding"))
+		})
+	})
+
+	Context("with guest NUMA passthrough", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.CPU = &v1.CPU{
+				Cores:                 2,
+				DedicatedCPUPlacement: true,
+				NUMA:                  &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}},
+			}
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+		})
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+		It("should accept the passthrough when the NUMA feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.NUMAGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject the passthrough when the NUMA feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.numa.guestMappingPassthrough"))
+		})
+		It("should reject the passthrough without dedicated CPUs", func() {
+			enableFeatureGate(virtconfig.NUMAGate)
+			vmi.Spec.Domain.CPU.DedicatedCPUPlacement = false
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.numa.guestMappingPassthrough"))
+			Expect(causes[0].Message).To(ContainSubstring("dedicatedCpuPlacement"))
+		})
+		It("should reject the passthrough without hugepages", func() {
+			enableFeatureGate(virtconfig.NUMAGate)
+			vmi.Spec.Domain.Memory = nil
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.numa.guestMappingPassthrough"))
+			Expect(causes[0].Message).To(ContainSubstring("hugepages"))
+		})
+	})
+
 	Context("with AccessCredentials", func() {
 		It("should accept a valid ssh access credential with configdrive propagation", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -2487,6 +2907,45 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			),
 		)
 
+		table.DescribeTable("should validate the I/O limits of disks",
+			func(disk v1.Disk, expectedFields ...string) {
+				causes := validateDisks(k8sfield.NewPath("fake"), []v1.Disk{disk})
+				Expect(causes).To(HaveLen(len(expectedFields)))
+				for i, field := range expectedFields {
+					Expect(causes[i].Field).To(Equal(field))
+				}
+			},
+			table.Entry("accepting read and write limits with bursts",
+				v1.Disk{Name: "testdisk", IOTune: &v1.DiskIOTune{ReadBytesSec: 1024, WriteBytesSec: 1024, TotalIOPSSec: 100,
+					Burst: &v1.DiskIOTuneBurst{ReadBytesSec: 2048, TotalIOPSSec: 200, LengthSeconds: 10}}},
+			),
+			table.Entry("rejecting a total limit combined with a read limit",
+				v1.Disk{Name: "testdisk", IOTune: &v1.DiskIOTune{TotalBytesSec: 1024, ReadBytesSec: 1024}},
+				"fake[0].ioTune.totalBytesSec",
+			),
+			table.Entry("rejecting a total burst limit combined with a write burst limit",
+				v1.Disk{Name: "testdisk", IOTune: &v1.DiskIOTune{TotalIOPSSec: 100, WriteIOPSSec: 100,
+					Burst: &v1.DiskIOTuneBurst{TotalIOPSSec: 200, WriteIOPSSec: 200}}},
+				"fake[0].ioTune.totalIopsSec", "fake[0].ioTune.burst.totalIopsSec",
+			),
+			table.Entry("rejecting a burst limit without a limit",
+				v1.Disk{Name: "testdisk", IOTune: &v1.DiskIOTune{Burst: &v1.DiskIOTuneBurst{WriteBytesSec: 2048}}},
+				"fake[0].ioTune.burst.writeBytesSec",
+			),
+			table.Entry("rejecting a burst limit not greater than the limit",
+				v1.Disk{Name: "testdisk", IOTune: &v1.DiskIOTune{ReadIOPSSec: 100, Burst: &v1.DiskIOTuneBurst{ReadIOPSSec: 100}}},
+				"fake[0].ioTune.burst.readIopsSec",
+			),
+			table.Entry("rejecting a burst length without burst limits",
+				v1.Disk{Name: "testdisk", IOTune: &v1.DiskIOTune{ReadIOPSSec: 100, Burst: &v1.DiskIOTuneBurst{LengthSeconds: 10}}},
+				"fake[0].ioTune.burst.lengthSeconds",
+			),
+			table.Entry("rejecting limits on a CD-ROM",
+				v1.Disk{Name: "testdisk", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}, IOTune: &v1.DiskIOTune{ReadIOPSSec: 100}},
+				"fake[0].ioTune",
+			),
+		)
+
 		It("should reject floppy disks", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
@@ -53,10 +53,19 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 	if !reflect.DeepEqual(newVMI.Spec, oldVMI.Spec) {
 		// Only allow the KubeVirt SA to modify the VMI spec, since that means it went through the sub resource.
 		if webhooks.IsKubeVirtServiceAccount(ar.Request.UserInfo.Username) {
-			hotplugResponse := admitHotplug(newVMI.Spec.Volumes, oldVMI.Spec.Volumes, newVMI.Spec.Domain.Devices.Disks, oldVMI.Spec.Domain.Devices.Disks, oldVMI.Status.VolumeStatus, newVMI, admitter.ClusterConfig)
+			if volumeMigrationResponse := admitVolumeMigration(newVMI, oldVMI, admitter.ClusterConfig); volumeMigrationResponse != nil {
+				return volumeMigrationResponse
+			}
+			hotplugResponse := admitHotplug(volumesWithoutMigratedClaims(newVMI, oldVMI), oldVMI.Spec.Volumes, newVMI.Spec.Domain.Devices.Disks, oldVMI.Spec.Domain.Devices.Disks, oldVMI.Status.VolumeStatus, newVMI, admitter.ClusterConfig)
 			if hotplugResponse != nil {
 				return hotplugResponse
 			}
+			if interfaceResponse := admitInterfaceHotplug(newVMI, oldVMI, admitter.ClusterConfig); interfaceResponse != nil {
+				return interfaceResponse
+			}
+			if cpuResponse := admitCPUHotplug(newVMI, oldVMI, admitter.ClusterConfig); cpuResponse != nil {
+				return cpuResponse
+			}
 		} else {
 			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 				{
@@ -78,7 +87,7 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 
 // admitHotplug compares the old and new volumes and disks, and ensures that they match and are valid.
 func admitHotplug(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Disk, volumeStatuses []v1.VolumeStatus, newVMI *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) *v1beta1.AdmissionResponse {
-	if len(newVolumes) != len(newDisks) {
+	if len(newVolumes) != len(newDisks)-len(getEmptyCDRoms(newVolumes, newDisks)) {
 		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 			{
 				Type:    metav1.CauseTypeFieldValueInvalid,
@@ -91,8 +100,14 @@ func admitHotplug(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Di
 	oldHotplugVolumeMap := getHotplugVolumes(oldVolumes, volumeStatuses)
 	oldPermanentVolumeMap := getPermanentVolumes(oldVolumes, volumeStatuses)
 
-	newDiskMap := getDiskMap(newDisks)
-	oldDiskMap := getDiskMap(oldDisks)
+	// the I/O limits of a disk can be changed on a running VMI, they are validated with the spec
+	newDiskMap := getDiskMap(disksWithoutIOTune(newDisks))
+	oldDiskMap := getDiskMap(disksWithoutIOTune(oldDisks))
+
+	// the media of a CD-ROM can be ejected, the drive stays in place
+	for name := range getEmptyCDRoms(newVolumes, newDisks) {
+		delete(oldPermanentVolumeMap, name)
+	}
 
 	permanentAr := verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap, newDiskMap, oldDiskMap)
 	if permanentAr != nil {
@@ -112,6 +127,216 @@ func admitHotplug(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Di
 	return nil
 }
 
+// admitVolumeMigration ensures that a volume migration only starts when the VolumeMigration feature gate is enabled.
+func admitVolumeMigration(newVMI, oldVMI *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) *v1beta1.AdmissionResponse {
+	if newVMI.Status.VolumeMigration == nil || oldVMI.Status.VolumeMigration != nil || config.VolumeMigrationEnabled() {
+		return nil
+	}
+	return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+		{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("volumes can only be migrated to new claims when the %s feature gate is enabled", virtconfig.VolumeMigrationGate),
+		},
+	})
+}
+
+// volumesWithoutMigratedClaims returns the volumes of the new VMI, with the claims which
+// were switched by a starting, or back by a failed, volume migration set to the old ones.
+func volumesWithoutMigratedClaims(newVMI, oldVMI *v1.VirtualMachineInstance) []v1.Volume {
+	var migratedVolumes []v1.MigratedVolume
+	for _, state := range []*v1.VolumeMigrationState{newVMI.Status.VolumeMigration, oldVMI.Status.VolumeMigration} {
+		if state != nil {
+			migratedVolumes = append(migratedVolumes, state.MigratedVolumes...)
+		}
+	}
+	if len(migratedVolumes) == 0 {
+		return newVMI.Spec.Volumes
+	}
+
+	oldClaims := make(map[string]string)
+	for _, volume := range oldVMI.Spec.Volumes {
+		if claim := getVolumeClaimName(volume); claim != "" {
+			oldClaims[volume.Name] = claim
+		}
+	}
+
+	volumes := make([]v1.Volume, 0, len(newVMI.Spec.Volumes))
+	for _, volume := range newVMI.Spec.Volumes {
+		volume = *volume.DeepCopy()
+		newClaim, oldClaim := getVolumeClaimName(volume), oldClaims[volume.Name]
+		for _, migratedVolume := range migratedVolumes {
+			if migratedVolume.VolumeName != volume.Name || newClaim == "" || oldClaim == "" {
+				continue
+			}
+			if (migratedVolume.SourceClaim == oldClaim && migratedVolume.DestinationClaim == newClaim) ||
+				(migratedVolume.SourceClaim == newClaim && migratedVolume.DestinationClaim == oldClaim) {
+				if volume.PersistentVolumeClaim != nil {
+					volume.PersistentVolumeClaim.ClaimName = oldClaim
+				} else {
+					volume.DataVolume.Name = oldClaim
+				}
+				break
+			}
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes
+}
+
+func getVolumeClaimName(volume v1.Volume) string {
+	if volume.PersistentVolumeClaim != nil {
+		return volume.PersistentVolumeClaim.ClaimName
+	} else if volume.DataVolume != nil {
+		return volume.DataVolume.Name
+	}
+	return ""
+}
+
+// admitCPUHotplug ensures that only the number of sockets of a VMI changes, within
+// the configured maximum, and only when the CPUHotplug feature gate is enabled.
+func admitCPUHotplug(newVMI, oldVMI *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) *v1beta1.AdmissionResponse {
+	if reflect.DeepEqual(newVMI.Spec.Domain.CPU, oldVMI.Spec.Domain.CPU) {
+		return nil
+	}
+
+	if !config.CPUHotplugEnabled() {
+		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("the CPU topology can only be changed when the %s feature gate is enabled", virtconfig.CPUHotplugGate),
+			},
+		})
+	}
+
+	newCPU := newVMI.Spec.Domain.CPU
+	oldCPU := oldVMI.Spec.Domain.CPU
+	if newCPU == nil || oldCPU == nil || oldCPU.MaxSockets == 0 {
+		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "the CPU topology can only be changed if maxSockets is set",
+				Field:   k8sfield.NewPath("spec", "domain", "cpu", "maxSockets").String(),
+			},
+		})
+	}
+
+	newCPUWithoutSockets := newCPU.DeepCopy()
+	newCPUWithoutSockets.Sockets = oldCPU.Sockets
+	if !reflect.DeepEqual(newCPUWithoutSockets, oldCPU) {
+		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "only the number of sockets can be changed on a running VMI",
+				Field:   k8sfield.NewPath("spec", "domain", "cpu").String(),
+			},
+		})
+	}
+
+	if newCPU.Sockets < 1 || newCPU.Sockets > newCPU.MaxSockets {
+		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("the number of sockets must be between 1 and %d", newCPU.MaxSockets),
+				Field:   k8sfield.NewPath("spec", "domain", "cpu", "sockets").String(),
+			},
+		})
+	}
+
+	return nil
+}
+
+// admitInterfaceHotplug ensures that network interfaces only change through hotplug of
+// secondary Multus networks, and only when the HotplugNICs feature gate is enabled.
+func admitInterfaceHotplug(newVMI, oldVMI *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) *v1beta1.AdmissionResponse {
+	// the link state of an interface can be changed on a running VMI without hotplug support
+	if reflect.DeepEqual(newVMI.Spec.Networks, oldVMI.Spec.Networks) &&
+		reflect.DeepEqual(interfacesWithoutLinkState(newVMI.Spec.Domain.Devices.Interfaces), interfacesWithoutLinkState(oldVMI.Spec.Domain.Devices.Interfaces)) {
+		return nil
+	}
+
+	if !config.HotplugNetworkInterfacesEnabled() {
+		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("network interfaces can only be changed when the %s feature gate is enabled", virtconfig.HotplugNICsGate),
+			},
+		})
+	}
+
+	newNetworks := getNetworkMap(newVMI.Spec.Networks)
+	oldNetworks := getNetworkMap(oldVMI.Spec.Networks)
+	newInterfaces := getInterfaceMap(newVMI.Spec.Domain.Devices.Interfaces)
+	oldInterfaces := getInterfaceMap(oldVMI.Spec.Domain.Devices.Interfaces)
+
+	for name, oldNetwork := range oldNetworks {
+		newNetwork, exists := newNetworks[name]
+		if exists {
+			newInterface, oldInterface := newInterfaces[name], oldInterfaces[name]
+			newInterface.LinkState, oldInterface.LinkState = "", ""
+			if !reflect.DeepEqual(newNetwork, oldNetwork) || !reflect.DeepEqual(newInterface, oldInterface) {
+				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+					{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("network interface %s cannot be modified", name),
+					},
+				})
+			}
+		} else if !isHotpluggableInterface(oldNetwork, oldInterfaces[name]) {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("network interface %s cannot be unplugged, only secondary multus bridge interfaces are supported", name),
+				},
+			})
+		}
+	}
+
+	for name, newNetwork := range newNetworks {
+		if _, exists := oldNetworks[name]; exists {
+			continue
+		}
+		if !isHotpluggableInterface(newNetwork, newInterfaces[name]) {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("network interface %s cannot be hotplugged, only secondary multus bridge interfaces are supported", name),
+				},
+			})
+		}
+	}
+
+	return nil
+}
+
+func interfacesWithoutLinkState(interfaces []v1.Interface) []v1.Interface {
+	var result []v1.Interface
+	for _, iface := range interfaces {
+		iface.LinkState = ""
+		result = append(result, iface)
+	}
+	return result
+}
+
+func isHotpluggableInterface(network v1.Network, iface v1.Interface) bool {
+	return network.Multus != nil && !network.Multus.Default && iface.Bridge != nil
+}
+
+func getNetworkMap(networks []v1.Network) map[string]v1.Network {
+	networkMap := make(map[string]v1.Network)
+	for _, network := range networks {
+		networkMap[network.Name] = network
+	}
+	return networkMap
+}
+
+func getInterfaceMap(interfaces []v1.Interface) map[string]v1.Interface {
+	interfaceMap := make(map[string]v1.Interface)
+	for _, iface := range interfaces {
+		interfaceMap[iface.Name] = iface
+	}
+	return interfaceMap
+}
+
 func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1.Volume, newDisks, oldDisks map[string]v1.Disk) *v1beta1.AdmissionResponse {
 	for k, v := range newHotplugVolumeMap {
 		if _, ok := oldHotplugVolumeMap[k]; ok {
@@ -160,6 +385,18 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 				})
 			}
 			disk := newDisks[k]
+			if isCDRomOnly(disk) {
+				// media injected into a CD-ROM
+				if oldDisk, ok := oldDisks[k]; !ok || !reflect.DeepEqual(disk, oldDisk) {
+					return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+						{
+							Type:    metav1.CauseTypeFieldValueInvalid,
+							Message: fmt.Sprintf("media can only be injected into the existing CD-ROM %s", k),
+						},
+					})
+				}
+				continue
+			}
 			if disk.Disk == nil || disk.Disk.Bus != "scsi" {
 				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 					{
@@ -216,6 +453,30 @@ func verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap map[str
 	return nil
 }
 
+func disksWithoutIOTune(disks []v1.Disk) []v1.Disk {
+	var result []v1.Disk
+	for _, disk := range disks {
+		disk.IOTune = nil
+		result = append(result, disk)
+	}
+	return result
+}
+
+// getEmptyCDRoms returns the names of the CD-ROMs without a volume.
+func getEmptyCDRoms(volumes []v1.Volume, disks []v1.Disk) map[string]bool {
+	volumeNames := make(map[string]bool)
+	for _, volume := range volumes {
+		volumeNames[volume.Name] = true
+	}
+	emptyCDRoms := make(map[string]bool)
+	for _, disk := range disks {
+		if isCDRomOnly(disk) && !volumeNames[disk.Name] {
+			emptyCDRoms[disk.Name] = true
+		}
+	}
+	return emptyCDRoms
+}
+
 func getDiskMap(disks []v1.Disk) map[string]v1.Disk {
 	newDiskMap := make(map[string]v1.Disk, 0)
 	for _, disk := range disks {
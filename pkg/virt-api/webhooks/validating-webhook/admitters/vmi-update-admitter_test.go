@@ -30,6 +30,7 @@ import (
 	"github.com/onsi/gomega/types"
 	"k8s.io/api/admission/v1beta1"
 	authv1 "k8s.io/api/authentication/v1"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -37,6 +38,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/testutils"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/rbac"
 )
 
@@ -55,9 +57,18 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 			Phase: v1.KubeVirtPhaseDeploying,
 		},
 	}
-	config, _, _, _ := testutils.NewFakeClusterConfigUsingKV(kv)
+	config, _, _, kvInformer := testutils.NewFakeClusterConfigUsingKV(kv)
 	vmiUpdateAdmitter := &VMIUpdateAdmitter{config}
 
+	enableFeatureGate := func(featureGate string) {
+		kvConfig := kv.DeepCopy()
+		kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{featureGate}
+		testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+	}
+	disableFeatureGates := func() {
+		testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kv)
+	}
+
 	table.DescribeTable("should reject documents containing unknown or missing fields for", func(data string, validationResult string, gvr metav1.GroupVersionResource, review func(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse) {
 		input := map[string]interface{}{}
 		json.Unmarshal([]byte(data), &input)
@@ -348,6 +359,23 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		return res
 	}
 
+	makeDisksWithIOTune := func(ioTune *v1.DiskIOTune, indexes ...int) []v1.Disk {
+		res := makeDisks(indexes...)
+		res[0].IOTune = ioTune
+		return res
+	}
+
+	makeDisksWithCDRom := func(cdRomIndex int, indexes ...int) []v1.Disk {
+		return append(makeDisks(indexes...), v1.Disk{
+			Name: fmt.Sprintf("volume-name-%d", cdRomIndex),
+			DiskDevice: v1.DiskDevice{
+				CDRom: &v1.CDRomTarget{
+					Bus: "sata",
+				},
+			},
+		})
+	}
+
 	makeDisksNoVolume := func(indexes ...int) []v1.Disk {
 		res := make([]v1.Disk, 0)
 		for _, index := range indexes {
@@ -484,6 +512,48 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 			makeDisks(0),
 			makeStatus(1, 0),
 			makeExpected("spec.domain.devices.disks[1] must have a boot order > 0, if supplied", "spec.domain.devices.disks[1].bootOrder")),
+		table.Entry("Should accept if we change the I/O limits of a permanent disk",
+			makeVolumes(0, 1),
+			makeVolumes(0, 1),
+			makeDisksWithIOTune(&v1.DiskIOTune{ReadBytesSec: 1024}, 0, 1),
+			makeDisksWithIOTune(&v1.DiskIOTune{WriteIOPSSec: 100}, 0, 1),
+			makeStatus(2, 0),
+			nil),
+		table.Entry("Should reject if we change the I/O limits of a disk to invalid ones",
+			makeVolumes(0),
+			makeVolumes(0),
+			makeDisksWithIOTune(&v1.DiskIOTune{TotalBytesSec: 1024, ReadBytesSec: 1024}, 0),
+			makeDisks(0),
+			makeStatus(1, 0),
+			makeExpected("spec.domain.devices.disks[0].ioTune.totalBytesSec can not be combined with readBytesSec or writeBytesSec", "spec.domain.devices.disks[0].ioTune.totalBytesSec")),
+		table.Entry("Should accept if we eject the media of a permanent CD-ROM",
+			makeVolumes(0),
+			makeVolumes(0, 1),
+			makeDisksWithCDRom(1, 0),
+			makeDisksWithCDRom(1, 0),
+			makeStatus(2, 0),
+			nil),
+		table.Entry("Should accept if we inject media into an empty CD-ROM",
+			makeVolumes(0, 1),
+			makeVolumes(0),
+			makeDisksWithCDRom(1, 0),
+			makeDisksWithCDRom(1, 0),
+			makeStatus(1, 0),
+			nil),
+		table.Entry("Should reject if we inject media into a new CD-ROM",
+			makeVolumes(0, 1),
+			makeVolumes(0),
+			makeDisksWithCDRom(1, 0),
+			makeDisks(0),
+			makeStatus(1, 0),
+			makeExpected("media can only be injected into the existing CD-ROM volume-name-1", "")),
+		table.Entry("Should reject if we remove the volume of a permanent disk",
+			makeVolumes(0),
+			makeVolumes(0, 1),
+			makeDisks(0, 1),
+			makeDisks(0, 1),
+			makeStatus(2, 0),
+			makeExpected("number of disks does not equal the number of volumes", "")),
 	)
 
 	table.DescribeTable("Admit or deny based on user", func(user string, expected types.GomegaMatcher) {
@@ -517,4 +587,179 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		table.Entry("Should admit internal sa", "system:serviceaccount:kubevirt:"+rbac.ApiServiceAccountName, BeTrue()),
 		table.Entry("Should reject regular user", "system:serviceaccount:someNamespace:someUser", BeFalse()),
 	)
+
+	Context("with network interface hotplug", func() {
+		newVMIWithInterfaces := func(names ...string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			for _, name := range names {
+				vmi.Spec.Networks = append(vmi.Spec.Networks, v1.Network{
+					Name:          name,
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: name + "-net"}},
+				})
+				vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces, v1.Interface{
+					Name:                   name,
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				})
+			}
+			return vmi
+		}
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		It("should reject interface changes if the feature gate is disabled", func() {
+			result := admitInterfaceHotplug(newVMIWithInterfaces("blue"), newVMIWithInterfaces(), vmiUpdateAdmitter.ClusterConfig)
+			Expect(result).ToNot(BeNil())
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.Result.Details.Causes[0].Message).To(ContainSubstring(virtconfig.HotplugNICsGate))
+		})
+
+		It("should accept unchanged interfaces if the feature gate is disabled", func() {
+			Expect(admitInterfaceHotplug(newVMIWithInterfaces("blue"), newVMIWithInterfaces("blue"), vmiUpdateAdmitter.ClusterConfig)).To(BeNil())
+		})
+
+		It("should accept link state changes if the feature gate is disabled", func() {
+			newVMI := newVMIWithInterfaces("blue")
+			newVMI.Spec.Domain.Devices.Interfaces[1].LinkState = v1.InterfaceLinkStateDown
+			Expect(admitInterfaceHotplug(newVMI, newVMIWithInterfaces("blue"), vmiUpdateAdmitter.ClusterConfig)).To(BeNil())
+		})
+
+		table.DescribeTable("with the feature gate enabled", func(newVMI, oldVMI *v1.VirtualMachineInstance, expectedMessage string) {
+			enableFeatureGate(virtconfig.HotplugNICsGate)
+			result := admitInterfaceHotplug(newVMI, oldVMI, vmiUpdateAdmitter.ClusterConfig)
+			if expectedMessage == "" {
+				Expect(result).To(BeNil())
+				return
+			}
+			Expect(result).ToNot(BeNil())
+			Expect(result.Result.Details.Causes[0].Message).To(Equal(expectedMessage))
+		},
+			table.Entry("should accept a new secondary bridge interface", newVMIWithInterfaces("blue", "red"), newVMIWithInterfaces("blue"), ""),
+			table.Entry("should accept removing a secondary bridge interface", newVMIWithInterfaces(), newVMIWithInterfaces("blue"), ""),
+			table.Entry("should reject removing the pod network interface",
+				func() *v1.VirtualMachineInstance {
+					vmi := newVMIWithInterfaces("blue")
+					vmi.Spec.Networks = vmi.Spec.Networks[1:]
+					vmi.Spec.Domain.Devices.Interfaces = vmi.Spec.Domain.Devices.Interfaces[1:]
+					return vmi
+				}(),
+				newVMIWithInterfaces("blue"),
+				"network interface default cannot be unplugged, only secondary multus bridge interfaces are supported"),
+			table.Entry("should reject modifying an existing interface",
+				func() *v1.VirtualMachineInstance {
+					vmi := newVMIWithInterfaces("blue")
+					vmi.Spec.Networks[1].Multus.NetworkName = "other-net"
+					return vmi
+				}(),
+				newVMIWithInterfaces("blue"),
+				"network interface blue cannot be modified"),
+			table.Entry("should accept a new interface together with a link state change",
+				func() *v1.VirtualMachineInstance {
+					vmi := newVMIWithInterfaces("blue", "red")
+					vmi.Spec.Domain.Devices.Interfaces[1].LinkState = v1.InterfaceLinkStateDown
+					return vmi
+				}(),
+				newVMIWithInterfaces("blue"),
+				""),
+			table.Entry("should reject hotplugging a masquerade interface",
+				func() *v1.VirtualMachineInstance {
+					vmi := newVMIWithInterfaces("blue")
+					vmi.Spec.Domain.Devices.Interfaces[1].InterfaceBindingMethod = v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}
+					return vmi
+				}(),
+				newVMIWithInterfaces(),
+				"network interface blue cannot be hotplugged, only secondary multus bridge interfaces are supported"),
+		)
+	})
+
+	Context("with CPU hotplug", func() {
+		newVMIWithSockets := func(sockets, maxSockets uint32) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: sockets, Cores: 1, Threads: 1, MaxSockets: maxSockets}
+			return vmi
+		}
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		It("should reject socket changes if the feature gate is disabled", func() {
+			result := admitCPUHotplug(newVMIWithSockets(3, 4), newVMIWithSockets(2, 4), vmiUpdateAdmitter.ClusterConfig)
+			Expect(result).ToNot(BeNil())
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.Result.Details.Causes[0].Message).To(ContainSubstring(virtconfig.CPUHotplugGate))
+		})
+
+		It("should accept an unchanged CPU if the feature gate is disabled", func() {
+			Expect(admitCPUHotplug(newVMIWithSockets(2, 4), newVMIWithSockets(2, 4), vmiUpdateAdmitter.ClusterConfig)).To(BeNil())
+		})
+
+		table.DescribeTable("with the feature gate enabled", func(newVMI, oldVMI *v1.VirtualMachineInstance, expectedMessage string) {
+			enableFeatureGate(virtconfig.CPUHotplugGate)
+			result := admitCPUHotplug(newVMI, oldVMI, vmiUpdateAdmitter.ClusterConfig)
+			if expectedMessage == "" {
+				Expect(result).To(BeNil())
+				return
+			}
+			Expect(result).ToNot(BeNil())
+			Expect(result.Result.Details.Causes[0].Message).To(Equal(expectedMessage))
+		},
+			table.Entry("should accept adding sockets", newVMIWithSockets(3, 4), newVMIWithSockets(2, 4), ""),
+			table.Entry("should accept removing sockets", newVMIWithSockets(1, 4), newVMIWithSockets(2, 4), ""),
+			table.Entry("should reject exceeding maxSockets", newVMIWithSockets(5, 4), newVMIWithSockets(2, 4),
+				"the number of sockets must be between 1 and 4"),
+			table.Entry("should reject removing all sockets", newVMIWithSockets(0, 4), newVMIWithSockets(2, 4),
+				"the number of sockets must be between 1 and 4"),
+			table.Entry("should reject changing maxSockets", newVMIWithSockets(2, 8), newVMIWithSockets(2, 4),
+				"only the number of sockets can be changed on a running VMI"),
+			table.Entry("should reject socket changes without maxSockets", newVMIWithSockets(3, 0), newVMIWithSockets(2, 0),
+				"the CPU topology can only be changed if maxSockets is set"),
+		)
+	})
+
+	Context("with a volume migration", func() {
+		newVMIWithClaim := func(claim string, state *v1.VolumeMigrationState) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "disk0",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+				},
+			}}
+			vmi.Status.VolumeMigration = state
+			return vmi
+		}
+		state := &v1.VolumeMigrationState{
+			MigrationName:   "volume-migration",
+			MigratedVolumes: []v1.MigratedVolume{{VolumeName: "disk0", SourceClaim: "src", DestinationClaim: "dst"}},
+		}
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		It("should reject starting a volume migration if the feature gate is disabled", func() {
+			result := admitVolumeMigration(newVMIWithClaim("dst", state), newVMIWithClaim("src", nil), vmiUpdateAdmitter.ClusterConfig)
+			Expect(result).ToNot(BeNil())
+			Expect(result.Result.Details.Causes[0].Message).To(ContainSubstring(virtconfig.VolumeMigrationGate))
+		})
+
+		It("should accept finishing a volume migration if the feature gate is disabled", func() {
+			Expect(admitVolumeMigration(newVMIWithClaim("src", nil), newVMIWithClaim("dst", state), vmiUpdateAdmitter.ClusterConfig)).To(BeNil())
+		})
+
+		table.DescribeTable("should compare the claims", func(newVMI, oldVMI *v1.VirtualMachineInstance, expectedClaim string) {
+			volumes := volumesWithoutMigratedClaims(newVMI, oldVMI)
+			Expect(volumes).To(HaveLen(1))
+			Expect(volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(expectedClaim))
+		},
+			table.Entry("before the switch to the destination claim", newVMIWithClaim("dst", state), newVMIWithClaim("src", nil), "src"),
+			table.Entry("before the switch back to the source claim", newVMIWithClaim("src", nil), newVMIWithClaim("dst", state), "dst"),
+			table.Entry("but not switched to another claim", newVMIWithClaim("other", state), newVMIWithClaim("src", nil), "other"),
+			table.Entry("but not switched without a volume migration", newVMIWithClaim("dst", nil), newVMIWithClaim("src", nil), "dst"),
+		)
+	})
 })
@@ -35,6 +35,7 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/util/vmrestore"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -79,6 +80,14 @@ func (admitter *VMRestoreAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 		var targetUID *types.UID
 		targetField := k8sfield.NewPath("spec", "target")
 
+		if vmRestore.Namespace == "" {
+			vmRestore.Namespace = ar.Request.Namespace
+		}
+		cloneRestore, err := vmrestore.IsCloneRestore(admitter.Client, vmRestore)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+
 		if vmRestore.Spec.Target.APIGroup == nil {
 			causes = []metav1.StatusCause{
 				{
@@ -92,7 +101,7 @@ func (admitter *VMRestoreAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 			case v1.GroupName:
 				switch vmRestore.Spec.Target.Kind {
 				case "VirtualMachine":
-					causes, targetUID, err = admitter.validateCreateVM(targetField.Child("name"), ar.Request.Namespace, vmRestore.Spec.Target.Name)
+					causes, targetUID, err = admitter.validateCreateVM(targetField.Child("name"), ar.Request.Namespace, vmRestore.Spec.Target.Name, cloneRestore)
 					if err != nil {
 						return webhookutils.ToAdmissionResponseError(err)
 					}
@@ -147,6 +156,23 @@ func (admitter *VMRestoreAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 
 		causes = append(causes, snapshotCauses...)
 
+		if len(vmRestore.Spec.Patches) > 0 {
+			patchesField := k8sfield.NewPath("spec", "patches")
+			if !cloneRestore {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: "patches are only allowed for the restore of a VirtualMachineClone",
+					Field:   patchesField.String(),
+				})
+			} else if err := vmrestore.ValidatePatches(vmRestore.Spec.Patches); err != nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("invalid patches: %v", err),
+					Field:   patchesField.String(),
+				})
+			}
+		}
+
 	case v1beta1.Update:
 		prevObj := &snapshotv1.VirtualMachineRestore{}
 		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
@@ -177,9 +203,13 @@ func (admitter *VMRestoreAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 	return &reviewResponse
 }
 
-func (admitter *VMRestoreAdmitter) validateCreateVM(field *k8sfield.Path, namespace, name string) ([]metav1.StatusCause, *types.UID, error) {
+func (admitter *VMRestoreAdmitter) validateCreateVM(field *k8sfield.Path, namespace, name string, allowMissing bool) ([]metav1.StatusCause, *types.UID, error) {
 	vm, err := admitter.Client.VirtualMachine(namespace).Get(name, &metav1.GetOptions{})
 	if errors.IsNotFound(err) {
+		if allowMissing {
+			return nil, nil, nil
+		}
+
 		return []metav1.StatusCause{
 			{
 				Type:    metav1.CauseTypeFieldValueInvalid,
@@ -24,6 +24,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
 	"k8s.io/api/admission/v1beta1"
@@ -35,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
 	"kubevirt.io/client-go/kubecli"
@@ -66,6 +68,41 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 		},
 	}
 
+	vmClone := &clonev1alpha1.VirtualMachineClone{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "clone",
+			Namespace: "default",
+			UID:       "clone-uid",
+		},
+	}
+
+	createCloneRestore := func(patches []string) *snapshotv1.VirtualMachineRestore {
+		return &snapshotv1.VirtualMachineRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tmp-restore-clone-uid",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "clone.kubevirt.io/v1alpha1",
+						Kind:       "VirtualMachineClone",
+						Name:       "clone",
+						UID:        "clone-uid",
+						Controller: &t,
+					},
+				},
+			},
+			Spec: snapshotv1.VirtualMachineRestoreSpec{
+				Target: corev1.TypedLocalObjectReference{
+					APIGroup: &apiGroup,
+					Kind:     "VirtualMachine",
+					Name:     vmName,
+				},
+				VirtualMachineSnapshotName: vmSnapshotName,
+				Patches:                    patches,
+			},
+		}
+	}
+
 	config, configMapInformer, _, _ := testutils.NewFakeClusterConfig(&corev1.ConfigMap{})
 
 	Context("Without feature gate enabled", func() {
@@ -161,6 +198,76 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.target.name"))
 		})
 
+		It("should accept when VM does not exist and restore is owned by a VirtualMachineClone", func() {
+			restore := createCloneRestore(nil)
+
+			ar := createRestoreAdmissionReview(restore)
+			resp := createTestVMRestoreAdmitter(config, nil, snapshot, vmClone).Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject when VM does not exist and the VirtualMachineClone owning the restore does not exist", func() {
+			restore := createCloneRestore(nil)
+
+			ar := createRestoreAdmissionReview(restore)
+			resp := createTestVMRestoreAdmitter(config, nil, snapshot).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(len(resp.Result.Details.Causes)).To(Equal(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.target.name"))
+		})
+
+		It("should reject when VM does not exist and the VirtualMachineClone does not name the restore", func() {
+			restore := createCloneRestore(nil)
+			restore.Name = "restore"
+
+			ar := createRestoreAdmissionReview(restore)
+			resp := createTestVMRestoreAdmitter(config, nil, snapshot, vmClone).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(len(resp.Result.Details.Causes)).To(Equal(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.target.name"))
+		})
+
+		It("should accept patches of the labels, annotations and template for the restore of a VirtualMachineClone", func() {
+			restore := createCloneRestore([]string{
+				`{"op": "add", "path": "/metadata/labels", "value": {"clone": "true"}}`,
+				`{"op": "remove", "path": "/metadata/annotations/foo"}`,
+				`{"op": "replace", "path": "/spec/template/spec/domain/firmware/uuid", "value": "uuid"}`,
+			})
+
+			ar := createRestoreAdmissionReview(restore)
+			resp := createTestVMRestoreAdmitter(config, nil, snapshot, vmClone).Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		table.DescribeTable("should reject patches", func(patch string, withClone bool) {
+			restore := createCloneRestore([]string{patch})
+			objs := []runtime.Object{snapshot}
+			if withClone {
+				objs = append(objs, vmClone)
+			} else {
+				restore.OwnerReferences = nil
+			}
+			vm := &v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: vmName,
+					UID:  vmUID,
+				},
+			}
+
+			ar := createRestoreAdmissionReview(restore)
+			resp := createTestVMRestoreAdmitter(config, vm, objs...).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(len(resp.Result.Details.Causes)).To(Equal(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.patches"))
+		},
+			table.Entry("that are invalid", `{"op": "remove"`, true),
+			table.Entry("of the namespace", `{"op": "replace", "path": "/metadata/namespace", "value": "kube-system"}`, true),
+			table.Entry("of the owner references", `{"op": "add", "path": "/metadata/ownerReferences", "value": []}`, true),
+			table.Entry("of the run strategy", `{"op": "add", "path": "/spec/runStrategy", "value": "Always"}`, true),
+			table.Entry("with a disallowed operation", `{"op": "move", "from": "/metadata/labels", "path": "/spec/template/metadata/labels"}`, true),
+			table.Entry("when the restore is not owned by a VirtualMachineClone", `{"op": "add", "path": "/metadata/labels", "value": {}}`, false),
+		)
+
 		It("should reject when VM and snapshot do not exist", func() {
 			restore := &snapshotv1.VirtualMachineRestore{
 				ObjectMeta: metav1.ObjectMeta{
@@ -544,6 +651,8 @@ func createTestVMRestoreAdmitter(
 	virtClient.EXPECT().VirtualMachineSnapshot("default").
 		Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots("default"))
 	virtClient.EXPECT().VirtualMachine(gomock.Any()).Return(vmInterface).AnyTimes()
+	virtClient.EXPECT().VirtualMachineClone("default").
+		Return(kubevirtClient.CloneV1alpha1().VirtualMachineClones("default")).AnyTimes()
 
 	restoreInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
 	webhooks.GetInformers().VMRestoreInformer = restoreInformer
@@ -38,19 +38,21 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/instancetype"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
-var validRunStrategies = []v1.VirtualMachineRunStrategy{v1.RunStrategyHalted, v1.RunStrategyManual, v1.RunStrategyAlways, v1.RunStrategyRerunOnFailure}
+var validRunStrategies = []v1.VirtualMachineRunStrategy{v1.RunStrategyHalted, v1.RunStrategyManual, v1.RunStrategyAlways, v1.RunStrategyRerunOnFailure, v1.RunStrategyOnce}
 
 type CloneAuthFunc func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error)
 
 type VMsAdmitter struct {
-	ClusterConfig *virtconfig.ClusterConfig
-	cloneAuthFunc CloneAuthFunc
-	virtClient    kubecli.KubevirtClient
+	ClusterConfig       *virtconfig.ClusterConfig
+	InstancetypeMethods instancetype.Methods
+	cloneAuthFunc       CloneAuthFunc
+	virtClient          kubecli.KubevirtClient
 }
 
 type sarProxy struct {
@@ -65,8 +67,9 @@ func NewVMsAdmitter(clusterConfig *virtconfig.ClusterConfig, client kubecli.Kube
 	proxy := &sarProxy{client: client}
 
 	return &VMsAdmitter{
-		ClusterConfig: clusterConfig,
-		virtClient:    client,
+		ClusterConfig:       clusterConfig,
+		InstancetypeMethods: instancetype.NewMethods(client),
+		virtClient:          client,
 		cloneAuthFunc: func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 			return cdiclone.CanServiceAccountClonePVC(proxy, pvcNamespace, pvcName, saNamespace, saName)
 		},
@@ -92,7 +95,14 @@ func (admitter *VMsAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 		return webhookutils.ToAdmissionResponseError(err)
 	}
 
-	causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, admitter.ClusterConfig, accountName)
+	// The template of a VirtualMachine referencing an instancetype is not complete
+	// on its own, so expand the instancetype and preference before validating it.
+	causes := admitter.applyInstancetypeToVm(&vm)
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	causes = ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, admitter.ClusterConfig, accountName)
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
 	}
@@ -117,6 +127,11 @@ func (admitter *VMsAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 		return webhookutils.ToAdmissionResponse(causes)
 	}
 
+	causes = admitter.validateInterfaceRequests(&vm)
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
 	causes = validateSnapshotStatus(ar.Request, &vm)
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
@@ -145,6 +160,11 @@ func (admitter *VMsAdmitter) AdmitStatus(ar *v1beta1.AdmissionReview) *v1beta1.A
 		return webhookutils.ToAdmissionResponse(causes)
 	}
 
+	causes = admitter.validateInterfaceRequests(vm)
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
 	causes = validateSnapshotStatus(ar.Request, vm)
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
@@ -155,6 +175,59 @@ func (admitter *VMsAdmitter) AdmitStatus(ar *v1beta1.AdmissionReview) *v1beta1.A
 	return &reviewResponse
 }
 
+func (admitter *VMsAdmitter) applyInstancetypeToVm(vm *v1.VirtualMachine) []metav1.StatusCause {
+	if vm.Spec.Instancetype == nil && vm.Spec.Preference == nil {
+		return nil
+	}
+
+	if !admitter.ClusterConfig.InstancetypeEnabled() {
+		field := k8sfield.NewPath("spec", "instancetype")
+		if vm.Spec.Instancetype == nil {
+			field = k8sfield.NewPath("spec", "preference")
+		}
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.InstancetypeGate),
+			Field:   field.String(),
+		}}
+	}
+
+	instancetypeSpec, err := admitter.InstancetypeMethods.FindInstancetypeSpec(vm)
+	if err != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueNotFound,
+			Message: fmt.Sprintf("Failure to find instancetype: %v", err),
+			Field:   k8sfield.NewPath("spec", "instancetype").String(),
+		}}
+	}
+
+	preferenceSpec, err := admitter.InstancetypeMethods.FindPreferenceSpec(vm)
+	if err != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueNotFound,
+			Message: fmt.Sprintf("Failure to find preference: %v", err),
+			Field:   k8sfield.NewPath("spec", "preference").String(),
+		}}
+	}
+
+	if vm.Spec.Template == nil {
+		// nothing to expand, the missing template is reported by ValidateVirtualMachineSpec
+		return nil
+	}
+
+	conflicts := admitter.InstancetypeMethods.ApplyToVmi(k8sfield.NewPath("spec", "template", "spec"), instancetypeSpec, preferenceSpec, &vm.Spec.Template.Spec)
+
+	var causes []metav1.StatusCause
+	for _, conflict := range conflicts {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "VM field conflicts with selected Instancetype",
+			Field:   conflict.String(),
+		})
+	}
+	return causes
+}
+
 func (admitter *VMsAdmitter) authorizeVirtualMachineSpec(ar *v1beta1.AdmissionRequest, vm *v1.VirtualMachine) ([]metav1.StatusCause, error) {
 	var causes []metav1.StatusCause
 
@@ -289,6 +362,26 @@ func ValidateVirtualMachineSpec(field *k8sfield.Path, spec *v1.VirtualMachineSpe
 		}
 	}
 
+	if spec.UpdateVolumesStrategy != nil {
+		switch *spec.UpdateVolumesStrategy {
+		case v1.UpdateVolumesStrategyReplacement:
+		case v1.UpdateVolumesStrategyMigration:
+			if !config.VolumeMigrationEnabled() {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.VolumeMigrationGate),
+					Field:   field.Child("updateVolumesStrategy").String(),
+				})
+			}
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Invalid UpdateVolumesStrategy (%s)", *spec.UpdateVolumesStrategy),
+				Field:   field.Child("updateVolumesStrategy").String(),
+			})
+		}
+	}
+
 	return causes
 }
 
@@ -445,6 +538,75 @@ func (admitter *VMsAdmitter) validateVolumeRequests(vm *v1.VirtualMachine) ([]me
 
 }
 
+func (admitter *VMsAdmitter) validateInterfaceRequests(vm *v1.VirtualMachine) []metav1.StatusCause {
+	if len(vm.Status.InterfaceRequests) == 0 {
+		return nil
+	}
+
+	field := k8sfield.NewPath("Status", "interfaceRequests").String()
+	if !admitter.ClusterConfig.HotplugNetworkInterfacesEnabled() {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("InterfaceRequests require the %s feature gate to be enabled", virtconfig.HotplugNICsGate),
+			Field:   field,
+		}}
+	}
+
+	addRequests := make(map[string]bool)
+	removeRequests := make(map[string]bool)
+	newSpec := vm.Spec.Template.Spec.DeepCopy()
+	for _, interfaceRequest := range vm.Status.InterfaceRequests {
+		interfaceRequest := interfaceRequest
+		if interfaceRequest.AddInterfaceOptions != nil && interfaceRequest.RemoveInterfaceOptions != nil {
+			return []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "InterfaceRequests require either addInterfaceOptions or removeInterfaceOptions to be set, not both",
+				Field:   field,
+			}}
+		} else if interfaceRequest.AddInterfaceOptions != nil {
+			name := interfaceRequest.AddInterfaceOptions.Name
+			if addRequests[name] {
+				return []metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("AddInterface request for [%s] already exists", name),
+					Field:   field,
+				}}
+			}
+			for _, network := range newSpec.Networks {
+				if network.Name == name && (network.Multus == nil ||
+					network.Multus.NetworkName != interfaceRequest.AddInterfaceOptions.NetworkAttachmentDefinitionName) {
+					return []metav1.StatusCause{{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("AddInterface request for [%s] conflicts with an existing network of the same name on the vmi template.", name),
+						Field:   field,
+					}}
+				}
+			}
+			addRequests[name] = true
+		} else if interfaceRequest.RemoveInterfaceOptions != nil {
+			name := interfaceRequest.RemoveInterfaceOptions.Name
+			if removeRequests[name] {
+				return []metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("RemoveInterface request for [%s] already exists", name),
+					Field:   field,
+				}}
+			}
+			removeRequests[name] = true
+		} else {
+			return []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "InterfaceRequests require one of either addInterfaceOptions or removeInterfaceOptions to be set",
+				Field:   field,
+			}}
+		}
+		newSpec = controller.ApplyInterfaceRequestOnVMISpec(newSpec, &interfaceRequest)
+	}
+
+	// this simulates injecting the changes into the VMI template and validates it will work.
+	return ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec", "template", "spec"), newSpec, admitter.ClusterConfig)
+}
+
 func validateStateChangeRequests(ar *v1beta1.AdmissionRequest, vm *v1.VirtualMachine) []metav1.StatusCause {
 	// Only rename request is validated
 	renameRequest := getRenameRequest(vm)
@@ -42,8 +42,11 @@ import (
 	authv1 "k8s.io/api/authentication/v1"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/instancetype"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -73,7 +76,8 @@ var _ = Describe("Validating VM Admitter", func() {
 		virtClient = kubecli.NewMockKubevirtClient(ctrl)
 		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
 		vmsAdmitter = &VMsAdmitter{
-			ClusterConfig: config,
+			ClusterConfig:       config,
+			InstancetypeMethods: instancetype.NewMethods(virtClient),
 			cloneAuthFunc: func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 				return true, "", nil
 			},
@@ -579,6 +583,75 @@ var _ = Describe("Validating VM Admitter", func() {
 			false),
 	)
 
+	Context("with InterfaceRequests", func() {
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		admitInterfaceRequests := func(requests []v1.VirtualMachineInterfaceRequest) *v1beta1.AdmissionResponse {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vm := &v1.VirtualMachine{
+				Spec: v1.VirtualMachineSpec{
+					Running: &notRunning,
+					Template: &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: vmi.Spec,
+					},
+				},
+				Status: v1.VirtualMachineStatus{
+					InterfaceRequests: requests,
+				},
+			}
+			vmBytes, _ := json.Marshal(&vm)
+
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmBytes,
+					},
+				},
+			}
+			return vmsAdmitter.Admit(ar)
+		}
+
+		It("should reject interface requests if the feature gate is disabled", func() {
+			resp := admitInterfaceRequests([]v1.VirtualMachineInterfaceRequest{
+				{AddInterfaceOptions: &v1.AddInterfaceOptions{Name: "blue", NetworkAttachmentDefinitionName: "blue-net"}},
+			})
+			Expect(resp.Allowed).To(BeFalse())
+		})
+
+		table.DescribeTable("should validate InterfaceRequests", func(requests []v1.VirtualMachineInterfaceRequest, isValid bool) {
+			enableFeatureGate(virtconfig.HotplugNICsGate)
+			Expect(admitInterfaceRequests(requests).Allowed).To(Equal(isValid))
+		},
+			table.Entry("with valid request to add an interface", []v1.VirtualMachineInterfaceRequest{
+				{AddInterfaceOptions: &v1.AddInterfaceOptions{Name: "blue", NetworkAttachmentDefinitionName: "blue-net"}},
+			}, true),
+			table.Entry("with valid request to remove an interface", []v1.VirtualMachineInterfaceRequest{
+				{RemoveInterfaceOptions: &v1.RemoveInterfaceOptions{Name: "blue"}},
+			}, true),
+			table.Entry("with invalid request containing both add and remove options", []v1.VirtualMachineInterfaceRequest{
+				{
+					AddInterfaceOptions:    &v1.AddInterfaceOptions{Name: "blue", NetworkAttachmentDefinitionName: "blue-net"},
+					RemoveInterfaceOptions: &v1.RemoveInterfaceOptions{Name: "blue"},
+				},
+			}, false),
+			table.Entry("with invalid request containing neither add nor remove options", []v1.VirtualMachineInterfaceRequest{
+				{},
+			}, false),
+			table.Entry("with invalid duplicate add requests", []v1.VirtualMachineInterfaceRequest{
+				{AddInterfaceOptions: &v1.AddInterfaceOptions{Name: "blue", NetworkAttachmentDefinitionName: "blue-net"}},
+				{AddInterfaceOptions: &v1.AddInterfaceOptions{Name: "blue", NetworkAttachmentDefinitionName: "blue-net"}},
+			}, false),
+			table.Entry("with invalid request conflicting with the pod network", []v1.VirtualMachineInterfaceRequest{
+				{AddInterfaceOptions: &v1.AddInterfaceOptions{Name: "default", NetworkAttachmentDefinitionName: "blue-net"}},
+			}, false),
+		)
+	})
+
 	It("should accept valid DataVolumeTemplate", func() {
 		vmi := v1.NewMinimalVMI("testvmi")
 		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
@@ -1525,6 +1598,178 @@ var _ = Describe("Validating VM Admitter", func() {
 			return true
 		}),
 	)
+
+	Context("with Instancetype", func() {
+		var vm *v1.VirtualMachine
+		var kubevirtClient *kubevirtfake.Clientset
+
+		admitVM := func() *v1beta1.AdmissionResponse {
+			vmBytes, _ := json.Marshal(vm)
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Operation: v1beta1.Create,
+					Resource:  webhooks.VirtualMachineGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmBytes,
+					},
+				},
+			}
+			return vmsAdmitter.Admit(ar)
+		}
+
+		BeforeEach(func() {
+			clusterInstancetype := &instancetypev1alpha1.VirtualMachineClusterInstancetype{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "small",
+				},
+				Spec: instancetypev1alpha1.VirtualMachineInstancetypeSpec{
+					CPU: instancetypev1alpha1.CPUInstancetype{
+						Guest: 2,
+					},
+					Memory: instancetypev1alpha1.MemoryInstancetype{
+						Guest: resource.MustParse("128Mi"),
+					},
+				},
+			}
+			preference := &instancetypev1alpha1.VirtualMachinePreference{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "virtio",
+					Namespace: k8sv1.NamespaceDefault,
+				},
+				Spec: instancetypev1alpha1.VirtualMachinePreferenceSpec{
+					Devices: &instancetypev1alpha1.DevicePreferences{
+						PreferredDiskBus: "virtio",
+					},
+				},
+			}
+			kubevirtClient = kubevirtfake.NewSimpleClientset(clusterInstancetype, preference)
+			virtClient.EXPECT().VirtualMachineClusterInstancetype().Return(
+				kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterInstancetypes()).AnyTimes()
+			virtClient.EXPECT().VirtualMachinePreference(k8sv1.NamespaceDefault).Return(
+				kubevirtClient.InstancetypeV1alpha1().VirtualMachinePreferences(k8sv1.NamespaceDefault)).AnyTimes()
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Resources = v1.ResourceRequirements{}
+			vm = &v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testvm",
+					Namespace: k8sv1.NamespaceDefault,
+				},
+				Spec: v1.VirtualMachineSpec{
+					Running: &notRunning,
+					Instancetype: &v1.InstancetypeMatcher{
+						Name: "small",
+					},
+					Template: &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: vmi.Spec,
+					},
+				},
+			}
+
+			enableFeatureGate(virtconfig.InstancetypeGate)
+		})
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		It("should reject the VM if the feature gate is not enabled", func() {
+			disableFeatureGates()
+
+			resp := admitVM()
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.instancetype"))
+		})
+
+		It("should accept a VM without inline memory when the instancetype provides it", func() {
+			resp := admitVM()
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should accept a VM referencing a namespaced preference", func() {
+			vm.Spec.Preference = &v1.PreferenceMatcher{
+				Name: "virtio",
+				Kind: "VirtualMachinePreference",
+			}
+
+			resp := admitVM()
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject a VM referencing a missing instancetype", func() {
+			vm.Spec.Instancetype.Name = "missing"
+
+			resp := admitVM()
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Type).To(Equal(metav1.CauseTypeFieldValueNotFound))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.instancetype"))
+		})
+
+		It("should reject a VM referencing an unknown instancetype kind", func() {
+			vm.Spec.Instancetype.Kind = "VirtualMachineInstanceFlavor"
+
+			resp := admitVM()
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.instancetype"))
+		})
+
+		table.DescribeTable("should reject a VM with values conflicting with the instancetype", func(updateTemplate func(*v1.VirtualMachineInstanceSpec), expectedFields ...string) {
+			updateTemplate(&vm.Spec.Template.Spec)
+
+			resp := admitVM()
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(resp.Result.Details.Causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("with CPU topology", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.CPU = &v1.CPU{Sockets: 2, Cores: 1}
+			}, "spec.template.spec.domain.cpu.sockets", "spec.template.spec.domain.cpu.cores"),
+			table.Entry("with guest memory", func(spec *v1.VirtualMachineInstanceSpec) {
+				guest := resource.MustParse("64Mi")
+				spec.Domain.Memory = &v1.Memory{Guest: &guest}
+			}, "spec.template.spec.domain.memory.guest"),
+			table.Entry("with resource requests", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.Resources.Requests = k8sv1.ResourceList{
+					k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+				}
+			}, "spec.template.spec.domain.resources.requests.memory"),
+		)
+	})
+
+	Context("with an update volumes strategy", func() {
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		table.DescribeTable("should validate the strategy", func(strategy v1.UpdateVolumesStrategy, featureGate string, expectedMessage string) {
+			if featureGate != "" {
+				enableFeatureGate(featureGate)
+			}
+			vmi := v1.NewMinimalVMI("testvmi")
+			spec := &v1.VirtualMachineSpec{
+				Running:               &notRunning,
+				UpdateVolumesStrategy: &strategy,
+				Template:              &v1.VirtualMachineInstanceTemplateSpec{Spec: vmi.Spec},
+			}
+			causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), spec, config, "fake-account")
+			if expectedMessage == "" {
+				Expect(causes).To(BeEmpty())
+				return
+			}
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("spec.updateVolumesStrategy"))
+			Expect(causes[0].Message).To(Equal(expectedMessage))
+		},
+			table.Entry("accepting Replacement", v1.UpdateVolumesStrategyReplacement, "", ""),
+			table.Entry("accepting Migration with the feature gate", v1.UpdateVolumesStrategyMigration, virtconfig.VolumeMigrationGate, ""),
+			table.Entry("rejecting Migration without the feature gate", v1.UpdateVolumesStrategyMigration, "", "VolumeMigration feature gate is not enabled"),
+			table.Entry("rejecting an unknown strategy", v1.UpdateVolumesStrategy("Copy"), "", "Invalid UpdateVolumesStrategy (Copy)"),
+		)
+	})
 })
 
 func makeCloneAdmitFunc(expectedSourceNamespace, expectedPVCName, expectedTargetNamespace, expectedServiceAccount string) CloneAuthFunc {
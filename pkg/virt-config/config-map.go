@@ -61,6 +61,10 @@ const (
 	MemBalloonStatsPeriod             = "memBalloonStatsPeriod"
 	CPUAllocationRatio                = "cpu-allocation-ratio"
 	PermittedHostDevicesKey           = "permittedHostDevices"
+	VMStateStorageClassKey            = "vmStateStorageClass"
+	NetworkBindingPluginsKey          = "networkBindingPlugins"
+	MacPoolKey                        = "macPool"
+	VhostUserSocketDirKey             = "vhostUserSocketDir"
 )
 
 type ConfigModifiedFn func()
@@ -402,6 +406,32 @@ func setConfigFromConfigMap(config *v1.KubeVirtConfiguration, configMap *k8sv1.C
 		return fmt.Errorf("invalid value for permitBridgeInterfaceOnPodNetwork in config: %v", permitBridge)
 	}
 
+	// set network binding plugins
+	rawConfig = strings.TrimSpace(configMap.Data[NetworkBindingPluginsKey])
+	if rawConfig != "" {
+		bindings := map[string]v1.InterfaceBindingPlugin{}
+		err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(rawConfig), 1024).Decode(&bindings)
+		if err != nil {
+			return fmt.Errorf("failed to parse network binding plugins config: %v", err)
+		}
+		config.NetworkConfiguration.Binding = bindings
+	}
+
+	// set the MAC pool
+	rawConfig = strings.TrimSpace(configMap.Data[MacPoolKey])
+	if rawConfig != "" {
+		macPool := &v1.MacPoolConfiguration{}
+		err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(rawConfig), 1024).Decode(macPool)
+		if err != nil {
+			return fmt.Errorf("failed to parse MAC pool config: %v", err)
+		}
+		config.NetworkConfiguration.MacPool = macPool
+	}
+
+	if vhostUserSocketDir := strings.TrimSpace(configMap.Data[VhostUserSocketDirKey]); vhostUserSocketDir != "" {
+		config.NetworkConfiguration.VhostUserSocketDir = vhostUserSocketDir
+	}
+
 	// set default network interface
 	iface := strings.TrimSpace(configMap.Data[NetworkInterfaceKey])
 	switch iface {
@@ -429,6 +459,10 @@ func setConfigFromConfigMap(config *v1.KubeVirtConfiguration, configMap *k8sv1.C
 		config.OVMFPath = ovmfPath
 	}
 
+	if vmStateStorageClass := strings.TrimSpace(configMap.Data[VMStateStorageClassKey]); vmStateStorageClass != "" {
+		config.VMStateStorageClass = vmStateStorageClass
+	}
+
 	if memBalloonStatsPeriod := strings.TrimSpace(configMap.Data[MemBalloonStatsPeriod]); memBalloonStatsPeriod != "" {
 		i, err := strconv.Atoi(memBalloonStatsPeriod)
 		if err != nil {
@@ -105,6 +105,17 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("when invalid, GetDefaultNetworkInterface should return the default", "invalid", "bridge"),
 	)
 
+	table.DescribeTable(" when macPool", func(value string, result *v1.MacPoolConfiguration) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.MacPoolKey: value},
+		})
+		Expect(clusterConfig.GetMacPool()).To(Equal(result))
+	},
+		table.Entry("is set, GetMacPool should return the ranges", "ranges:\n- start: 02:00:00:00:00:00\n  end: 02:00:00:00:ff:ff\n",
+			&v1.MacPoolConfiguration{Ranges: []v1.MacPoolRange{{Start: "02:00:00:00:00:00", End: "02:00:00:00:ff:ff"}}}),
+		table.Entry("is unset, GetMacPool should return nil", "", nil),
+	)
+
 	nodeSelectorsStr := "kubernetes.io/hostname=node02\nnode-role.kubernetes.io/compute=true\n"
 	nodeSelectors := map[string]string{
 		"kubernetes.io/hostname":          "node02",
@@ -315,6 +326,26 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("when unset, GetSELinuxLauncherType should return the default", virtconfig.DefaultSELinuxLauncherType, virtconfig.DefaultSELinuxLauncherType),
 	)
 
+	table.DescribeTable(" when VMStateStorageClass", func(value string, result string) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.VMStateStorageClassKey: value},
+		})
+		Expect(clusterConfig.GetVMStateStorageClass()).To(Equal(result))
+	},
+		table.Entry("when set, GetVMStateStorageClass should return the value", "rwx-storage", "rwx-storage"),
+		table.Entry("when unset, GetVMStateStorageClass should return an empty value", "", ""),
+	)
+
+	table.DescribeTable(" when VhostUserSocketDir", func(value string, result string) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.VhostUserSocketDirKey: value},
+		})
+		Expect(clusterConfig.GetVhostUserSocketDir()).To(Equal(result))
+	},
+		table.Entry("when set, GetVhostUserSocketDir should return the value", "/var/run/vhost", "/var/run/vhost"),
+		table.Entry("when unset, GetVhostUserSocketDir should return the default", "", virtconfig.DefaultVhostUserSocketDir),
+	)
+
 	table.DescribeTable(" when OVMFPath", func(value string, result string) {
 		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
 			Data: map[string]string{virtconfig.OVMFPathKey: value},
@@ -497,4 +528,14 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("LiveMigration is open, SRIOVLiveMigration should be close",
 			virtconfig.LiveMigrationGate, true, false),
 	)
+
+	It("should not enable LiveMigration when only the BridgeLiveMigration feature-gate is open", func() {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.BridgeLiveMigrationGate},
+		})
+
+		Expect(clusterConfig.LiveMigrationEnabled()).To(BeFalse())
+		Expect(clusterConfig.BridgeLiveMigrationEnabled()).To(BeTrue())
+		Expect(clusterConfig.SRIOVLiveMigrationEnabled()).To(BeFalse())
+	})
 })
@@ -29,16 +29,31 @@ const (
 	LiveMigrationGate = "LiveMigration"
 	// SRIOVLiveMigrationGate enable's Live Migration for VM's with SRIOV interfaces.
 	SRIOVLiveMigrationGate = "SRIOVLiveMigration"
-	CPUNodeDiscoveryGate   = "CPUNodeDiscovery"
-	HypervStrictCheckGate  = "HypervStrictCheck"
-	SidecarGate            = "Sidecar"
-	GPUGate                = "GPU"
-	HostDevicesGate        = "HostDevices"
-	SnapshotGate           = "Snapshot"
-	HotplugVolumesGate     = "HotplugVolumes"
-	HostDiskGate           = "HostDisk"
-	VirtIOFSGate           = "ExperimentalVirtiofsSupport"
-	MacvtapGate            = "Macvtap"
+	// BridgeLiveMigrationGate enables Live Migration for VMs with a bridge binding on the pod network.
+	BridgeLiveMigrationGate   = "BridgeLiveMigration"
+	CPUNodeDiscoveryGate      = "CPUNodeDiscovery"
+	HypervStrictCheckGate     = "HypervStrictCheck"
+	SidecarGate               = "Sidecar"
+	GPUGate                   = "GPU"
+	HostDevicesGate           = "HostDevices"
+	SnapshotGate              = "Snapshot"
+	HotplugVolumesGate        = "HotplugVolumes"
+	HotplugNICsGate           = "HotplugNICs"
+	CPUHotplugGate            = "CPUHotplug"
+	VMPersistentStateGate     = "VMPersistentState"
+	TPMGate                   = "TPM"
+	NUMAGate                  = "NUMA"
+	NetworkBindingPluginsGate = "NetworkBindingPlugins"
+	VhostUserGate             = "VhostUser"
+	HostDiskGate              = "HostDisk"
+	VirtIOFSGate              = "ExperimentalVirtiofsSupport"
+	MacvtapGate               = "Macvtap"
+	InstancetypeGate          = "Instancetype"
+	VMExportGate              = "VMExport"
+	VMCloneGate               = "VMClone"
+	VolumeMigrationGate       = "VolumeMigration"
+	ExpandDisksGate           = "ExpandDisks"
+	IncrementalBackupGate     = "IncrementalBackup"
 )
 
 func (c *ClusterConfig) isFeatureGateEnabled(featureGate string) bool {
@@ -67,6 +82,10 @@ func (config *ClusterConfig) SRIOVLiveMigrationEnabled() bool {
 	return config.isFeatureGateEnabled(SRIOVLiveMigrationGate)
 }
 
+func (config *ClusterConfig) BridgeLiveMigrationEnabled() bool {
+	return config.isFeatureGateEnabled(BridgeLiveMigrationGate)
+}
+
 func (config *ClusterConfig) HypervStrictCheckEnabled() bool {
 	return config.isFeatureGateEnabled(HypervStrictCheckGate)
 }
@@ -91,6 +110,30 @@ func (config *ClusterConfig) HotplugVolumesEnabled() bool {
 	return config.isFeatureGateEnabled(HotplugVolumesGate)
 }
 
+func (config *ClusterConfig) HotplugNetworkInterfacesEnabled() bool {
+	return config.isFeatureGateEnabled(HotplugNICsGate)
+}
+
+func (config *ClusterConfig) CPUHotplugEnabled() bool {
+	return config.isFeatureGateEnabled(CPUHotplugGate)
+}
+
+func (config *ClusterConfig) VMPersistentStateEnabled() bool {
+	return config.isFeatureGateEnabled(VMPersistentStateGate)
+}
+
+func (config *ClusterConfig) TPMEnabled() bool {
+	return config.isFeatureGateEnabled(TPMGate)
+}
+
+func (config *ClusterConfig) NUMAEnabled() bool {
+	return config.isFeatureGateEnabled(NUMAGate)
+}
+
+func (config *ClusterConfig) NetworkBindingPluginsEnabled() bool {
+	return config.isFeatureGateEnabled(NetworkBindingPluginsGate)
+}
+
 func (config *ClusterConfig) HostDiskEnabled() bool {
 	return config.isFeatureGateEnabled(HostDiskGate)
 }
@@ -103,6 +146,34 @@ func (config *ClusterConfig) MacvtapEnabled() bool {
 	return config.isFeatureGateEnabled(MacvtapGate)
 }
 
+func (config *ClusterConfig) VhostUserEnabled() bool {
+	return config.isFeatureGateEnabled(VhostUserGate)
+}
+
 func (config *ClusterConfig) HostDevicesPassthroughEnabled() bool {
 	return config.isFeatureGateEnabled(HostDevicesGate)
 }
+
+func (config *ClusterConfig) InstancetypeEnabled() bool {
+	return config.isFeatureGateEnabled(InstancetypeGate)
+}
+
+func (config *ClusterConfig) VMExportEnabled() bool {
+	return config.isFeatureGateEnabled(VMExportGate)
+}
+
+func (config *ClusterConfig) VMCloneEnabled() bool {
+	return config.isFeatureGateEnabled(VMCloneGate)
+}
+
+func (config *ClusterConfig) VolumeMigrationEnabled() bool {
+	return config.isFeatureGateEnabled(VolumeMigrationGate)
+}
+
+func (config *ClusterConfig) ExpandDisksEnabled() bool {
+	return config.isFeatureGateEnabled(ExpandDisksGate)
+}
+
+func (config *ClusterConfig) IncrementalBackupEnabled() bool {
+	return config.isFeatureGateEnabled(IncrementalBackupGate)
+}
@@ -60,6 +60,7 @@ const (
 	DefaultSELinuxLauncherType                      = "virt_launcher.process"
 	SupportedGuestAgentVersions                     = "2.*,3.*,4.*"
 	DefaultOVMFPath                                 = "/usr/share/OVMF"
+	DefaultVhostUserSocketDir                       = "/var/lib/cni/usrspcni"
 	DefaultMemBalloonStatsPeriod             uint32 = 10
 	DefaultCPUAllocationRatio                       = 10
 	DefaultVirtAPILogVerbosity                      = 2
@@ -137,6 +138,24 @@ func (c *ClusterConfig) IsSlirpInterfaceEnabled() bool {
 	return *c.GetConfig().NetworkConfiguration.PermitSlirpInterface
 }
 
+// GetNetworkBindings returns the registered network binding plugins by name
+func (c *ClusterConfig) GetNetworkBindings() map[string]v1.InterfaceBindingPlugin {
+	return c.GetConfig().NetworkConfiguration.Binding
+}
+
+// GetVhostUserSocketDir returns the host directory holding the vhost-user socket directories of the VMIs
+func (c *ClusterConfig) GetVhostUserSocketDir() string {
+	if dir := c.GetConfig().NetworkConfiguration.VhostUserSocketDir; dir != "" {
+		return dir
+	}
+	return DefaultVhostUserSocketDir
+}
+
+// GetMacPool returns the MAC pool configuration, nil if no MAC pool is configured
+func (c *ClusterConfig) GetMacPool() *v1.MacPoolConfiguration {
+	return c.GetConfig().NetworkConfiguration.MacPool
+}
+
 func (c *ClusterConfig) GetSMBIOS() *v1.SMBiosConfiguration {
 	return c.GetConfig().SMBIOSConfig
 }
@@ -161,6 +180,12 @@ func (c *ClusterConfig) GetOVMFPath() string {
 	return c.GetConfig().OVMFPath
 }
 
+// GetVMStateStorageClass returns the storage class used for the persistent state of VirtualMachines,
+// an empty value selects the default storage class of the cluster
+func (c *ClusterConfig) GetVMStateStorageClass() string {
+	return c.GetConfig().VMStateStorageClass
+}
+
 func (c *ClusterConfig) GetCPUAllocationRatio() int {
 	return c.GetConfig().DeveloperConfiguration.CPUAllocationRatio
 }
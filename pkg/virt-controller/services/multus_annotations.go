@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util/net/namescheme"
 )
 
 type multusNetworkAnnotation struct {
@@ -68,6 +69,57 @@ func generateMultusCNIAnnotation(vmi *v1.VirtualMachineInstance) (string, error)
 	return "", nil
 }
 
+// GenerateHotplugMultusCNIAnnotation computes the multus networks annotation
+// of a running VMI's pod after networks were hotplugged into or unplugged from
+// the VMI. Entries of networks which remain attached keep their pod interface
+// names, entries of unplugged networks are dropped and hotplugged networks are
+// appended using their hotplug pod interface name.
+func GenerateHotplugMultusCNIAnnotation(vmi *v1.VirtualMachineInstance, currentAnnotation string) (string, error) {
+	var current []multusNetworkAnnotation
+	if currentAnnotation != "" {
+		if err := json.Unmarshal([]byte(currentAnnotation), &current); err != nil {
+			return "", fmt.Errorf("failed to parse multus networks annotation %q: %v", currentAnnotation, err)
+		}
+	}
+
+	multusNetworkAnnotationPool := multusNetworkAnnotationPool{}
+	used := make([]bool, len(current))
+	for _, network := range filterMultusNonDefaultNetworks(vmi.Spec.Networks) {
+		hotplugName := namescheme.HotplugPodInterfaceName(network.Name)
+		annotation := newMultusAnnotationData(vmi, network, hotplugName)
+
+		match := -1
+		for i, entry := range current {
+			if !used[i] && entry.InterfaceName == hotplugName {
+				match = i
+				break
+			}
+		}
+		if match < 0 {
+			for i, entry := range current {
+				// networks present at pod creation are matched by their attachment definition
+				if !used[i] && !namescheme.IsHotplugPodInterfaceName(entry.InterfaceName) &&
+					entry.Namespace == annotation.Namespace && entry.NetworkName == annotation.NetworkName {
+					match = i
+					break
+				}
+			}
+		}
+
+		if match >= 0 {
+			used[match] = true
+			multusNetworkAnnotationPool.add(current[match])
+		} else {
+			multusNetworkAnnotationPool.add(annotation)
+		}
+	}
+
+	if !multusNetworkAnnotationPool.isEmpty() {
+		return multusNetworkAnnotationPool.toString()
+	}
+	return "", nil
+}
+
 func filterMultusNonDefaultNetworks(networks []v1.Network) []v1.Network {
 	var multusNetworks []v1.Network
 	for _, network := range networks {
@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util/net/namescheme"
 )
 
 var _ = Describe("Multus annotations", func() {
@@ -84,4 +85,42 @@ var _ = Describe("Multus annotations", func() {
 			Expect(multusAnnotationPool.toString()).To(BeIdenticalTo(expectedString))
 		})
 	})
+
+	Context("generating the annotation of a pod after network hotplug", func() {
+		multusNetwork := func(name, networkName string) v1.Network {
+			return v1.Network{
+				Name:          name,
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: networkName}},
+			}
+		}
+		currentAnnotation := `[{"interface":"net1","name":"red-net","namespace":"namespace1"},{"interface":"net2","name":"green-net","namespace":"namespace1"}]`
+
+		BeforeEach(func() {
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork(), multusNetwork("red", "red-net"), multusNetwork("green", "green-net")}
+		})
+
+		It("keeps the annotation unchanged if the networks did not change", func() {
+			Expect(GenerateHotplugMultusCNIAnnotation(&vmi, currentAnnotation)).To(Equal(currentAnnotation))
+		})
+
+		It("appends hotplugged networks using their hotplug pod interface name", func() {
+			vmi.Spec.Networks = append(vmi.Spec.Networks, multusNetwork("blue", "blue-net"))
+			expected := `[{"interface":"net1","name":"red-net","namespace":"namespace1"},{"interface":"net2","name":"green-net","namespace":"namespace1"},` +
+				`{"interface":"` + namescheme.HotplugPodInterfaceName("blue") + `","name":"blue-net","namespace":"namespace1"}]`
+			Expect(GenerateHotplugMultusCNIAnnotation(&vmi, currentAnnotation)).To(Equal(expected))
+		})
+
+		It("drops unplugged networks without renaming the remaining pod interfaces", func() {
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork(), multusNetwork("green", "green-net")}
+			expected := `[{"interface":"net2","name":"green-net","namespace":"namespace1"}]`
+			Expect(GenerateHotplugMultusCNIAnnotation(&vmi, currentAnnotation)).To(Equal(expected))
+		})
+
+		It("adds a new pod interface for a hotplugged network sharing an attachment definition", func() {
+			vmi.Spec.Networks = append(vmi.Spec.Networks, multusNetwork("blue", "red-net"))
+			expected := `[{"interface":"net1","name":"red-net","namespace":"namespace1"},{"interface":"net2","name":"green-net","namespace":"namespace1"},` +
+				`{"interface":"` + namescheme.HotplugPodInterfaceName("blue") + `","name":"red-net","namespace":"namespace1"}]`
+			Expect(GenerateHotplugMultusCNIAnnotation(&vmi, currentAnnotation)).To(Equal(expected))
+		})
+	})
 })
@@ -47,6 +47,8 @@ import (
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
+	"kubevirt.io/kubevirt/pkg/util/net/vhostuser"
+	"kubevirt.io/kubevirt/pkg/util/persistentstate"
 	"kubevirt.io/kubevirt/pkg/util/types"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
@@ -723,6 +725,66 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		})
 	}
 
+	if util.IsVhostUserVmi(vmi) {
+		// The socket paths reported by Multus point into the socket directory of the VMI on
+		// the host, which is therefore mounted at the same path
+		socketDir := vhostuser.SocketDir(t.clusterConfig.GetVhostUserSocketDir(), vmi.UID)
+		hostPathType := k8sv1.HostPathDirectoryOrCreate
+		volumes = append(volumes, k8sv1.Volume{
+			Name: vhostuser.SocketsVolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				HostPath: &k8sv1.HostPathVolumeSource{
+					Path: socketDir,
+					Type: &hostPathType,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      vhostuser.SocketsVolumeName,
+			MountPath: socketDir,
+		})
+		volumes = append(volumes, k8sv1.Volume{
+			Name: vhostuser.NetworkStatusVolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				DownwardAPI: &k8sv1.DownwardAPIVolumeSource{
+					Items: []k8sv1.DownwardAPIVolumeFile{{
+						Path: vhostuser.NetworkStatusFile,
+						FieldRef: &k8sv1.ObjectFieldSelector{
+							FieldPath: fmt.Sprintf("metadata.annotations['%s']", vhostuser.NetworkStatusAnnotation),
+						},
+					}},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      vhostuser.NetworkStatusVolumeName,
+			MountPath: vhostuser.NetworkStatusDir,
+			ReadOnly:  true,
+		})
+	}
+
+	if persistentstate.HasPersistentState(&vmi.Spec) {
+		// The PVC is created by the VM controller and shared by the EFI NVRAM and the TPM state
+		volumes = append(volumes, k8sv1.Volume{
+			Name: persistentstate.VolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: persistentstate.PVCName(vmi.Name),
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      persistentstate.VolumeName,
+			MountPath: persistentstate.NVRAMPath,
+			SubPath:   persistentstate.NVRAMSubPath,
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      persistentstate.VolumeName,
+			MountPath: persistentstate.SWTPMPath,
+			SubPath:   persistentstate.SWTPMSubPath,
+		})
+	}
+
 	if t.imagePullSecret != "" {
 		imagePullSecrets = appendUniqueImagePullSecret(imagePullSecrets, k8sv1.LocalObjectReference{
 			Name: t.imagePullSecret,
@@ -750,7 +812,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	if !vmi.IsCPUDedicated() {
 		vcpus := int64(1)
 		if vmi.Spec.Domain.CPU != nil {
-			vcpus = hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU)
+			vcpus = hardware.GetMaxNumberOfVCPUs(vmi.Spec.Domain.CPU)
 		}
 		cpuAllocationRatio := t.clusterConfig.GetCPUAllocationRatio()
 		if vcpus != 0 && cpuAllocationRatio > 0 {
@@ -773,6 +835,16 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		resources.Limits[key] = value
 	}
 
+	// Leave room for the sockets which can be hotplugged later on
+	if vmi.Spec.Domain.CPU != nil {
+		vcpus := hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU)
+		maxVCPUs := hardware.GetMaxNumberOfVCPUs(vmi.Spec.Domain.CPU)
+		if vcpus > 0 && maxVCPUs > vcpus {
+			scaleCPU(resources.Requests, vmiResources.Requests, vcpus, maxVCPUs)
+			scaleCPU(resources.Limits, vmiResources.Limits, vcpus, maxVCPUs)
+		}
+	}
+
 	// Add ephemeral storage request to container to be used by Kubevirt. This amount of ephemeral storage
 	// should be added to the user's request.
 	ephemeralStorageOverhead := resource.MustParse(ephemeralStorageOverheadSize)
@@ -859,6 +931,12 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		return nil, err
 	}
 
+	bindingPlugins, err := getNetworkBindingPlugins(vmi, t.clusterConfig.GetNetworkBindings())
+	if err != nil {
+		return nil, err
+	}
+	requestedHookSidecarList = append(requestedHookSidecarList, networkBindingPluginSidecars(vmi, bindingPlugins, t.clusterConfig.GetImagePullPolicy())...)
+
 	if len(requestedHookSidecarList) != 0 {
 		volumes = append(volumes, k8sv1.Volume{
 			Name: "hook-sidecar-sockets",
@@ -1012,6 +1090,13 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		compute.Env = append(compute.Env, k8sv1.EnvVar{Name: varName, Value: resourceName})
 	}
 
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if plugin, exists := bindingPlugins[iface.Name]; exists && plugin.DomainAttachmentType != "" {
+			varName := fmt.Sprintf("KUBEVIRT_DOMAIN_ATTACHMENT_%s", iface.Name)
+			compute.Env = append(compute.Env, k8sv1.EnvVar{Name: varName, Value: string(plugin.DomainAttachmentType)})
+		}
+	}
+
 	virtLauncherLogVerbosity := t.clusterConfig.GetVirtLauncherVerbosity()
 
 	if verbosity, isSet := vmi.Labels[logVerbosity]; isSet || virtLauncherLogVerbosity != virtconfig.DefaultVirtLauncherLogVerbosity {
@@ -1411,6 +1496,14 @@ func getRequiredCapabilities(vmi *v1.VirtualMachineInstance, config *virtconfig.
 	return capabilities
 }
 
+// scaleCPU scales the CPU of the VMI resources by the ratio of the vCPUs the
+// VMI can be scaled up to and the vCPUs it starts with
+func scaleCPU(resources k8sv1.ResourceList, vmiResources k8sv1.ResourceList, vcpus int64, maxVCPUs int64) {
+	if cpu, ok := vmiResources[k8sv1.ResourceCPU]; ok {
+		resources[k8sv1.ResourceCPU] = *resource.NewMilliQuantity(cpu.MilliValue()*maxVCPUs/vcpus, cpu.Format)
+	}
+}
+
 func getRequiredResources(vmi *v1.VirtualMachineInstance, useEmulation bool) k8sv1.ResourceList {
 	res := k8sv1.ResourceList{}
 	if (len(vmi.Spec.Domain.Devices.Interfaces) > 0) ||
@@ -1439,6 +1532,40 @@ func appendUniqueImagePullSecret(secrets []k8sv1.LocalObjectReference, newsecret
 	return append(secrets, newsecret)
 }
 
+// getNetworkBindingPlugins returns the network binding plugins used by the VMI interfaces, indexed by interface name
+func getNetworkBindingPlugins(vmi *v1.VirtualMachineInstance, registeredPlugins map[string]v1.InterfaceBindingPlugin) (map[string]v1.InterfaceBindingPlugin, error) {
+	plugins := map[string]v1.InterfaceBindingPlugin{}
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Binding == nil {
+			continue
+		}
+		plugin, exists := registeredPlugins[iface.Binding.Name]
+		if !exists {
+			return nil, fmt.Errorf("network binding plugin %s of interface %s is not registered", iface.Binding.Name, iface.Name)
+		}
+		plugins[iface.Name] = plugin
+	}
+	return plugins, nil
+}
+
+// networkBindingPluginSidecars returns a hook sidecar for every distinct sidecar image of the used network binding plugins
+func networkBindingPluginSidecars(vmi *v1.VirtualMachineInstance, plugins map[string]v1.InterfaceBindingPlugin, pullPolicy k8sv1.PullPolicy) hooks.HookSidecarList {
+	var sidecars hooks.HookSidecarList
+	images := map[string]struct{}{}
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		plugin, exists := plugins[iface.Name]
+		if !exists || plugin.SidecarImage == "" {
+			continue
+		}
+		if _, exists := images[plugin.SidecarImage]; exists {
+			continue
+		}
+		images[plugin.SidecarImage] = struct{}{}
+		sidecars = append(sidecars, hooks.HookSidecar{Image: plugin.SidecarImage, ImagePullPolicy: pullPolicy})
+	}
+	return sidecars
+}
+
 // getMemoryOverhead computes the estimation of total
 // memory needed for the domain to operate properly.
 // This includes the memory needed for the guest and memory
@@ -1468,7 +1595,7 @@ func getMemoryOverhead(vmi *v1.VirtualMachineInstance) *resource.Quantity {
 	coresMemory := resource.MustParse("8Mi")
 	var vcpus int64
 	if domain.CPU != nil {
-		vcpus = hardware.GetNumberOfVCPUs(domain.CPU)
+		vcpus = hardware.GetMaxNumberOfVCPUs(domain.CPU)
 	} else {
 		// Currently, a default guest CPU topology is set by the API webhook mutator, if not set by a user.
 		// However, this wasn't always the case.
@@ -468,6 +468,68 @@ var _ = Describe("Template", func() {
 				}
 				Expect(volumeMountFound).To(BeTrue(), "could not find ssh key secret volume mount")
 			})
+			It("should mount the persistent state PVC if the TPM state is persistent", func() {
+				persistent := true
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								TPM: &v1.TPMDevice{Persistent: &persistent},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				var claimName string
+				for _, volume := range pod.Spec.Volumes {
+					if volume.Name == "vm-state" && volume.PersistentVolumeClaim != nil {
+						claimName = volume.PersistentVolumeClaim.ClaimName
+					}
+				}
+				Expect(claimName).To(Equal("persistent-state-for-testvmi"))
+
+				mounts := map[string]string{}
+				for _, volumeMount := range pod.Spec.Containers[0].VolumeMounts {
+					if volumeMount.Name == "vm-state" {
+						mounts[volumeMount.MountPath] = volumeMount.SubPath
+					}
+				}
+				Expect(mounts).To(Equal(map[string]string{
+					"/var/lib/libvirt/qemu/nvram": "nvram",
+					"/var/lib/libvirt/swtpm":      "swtpm",
+				}))
+			})
+			It("should not mount the persistent state PVC if the TPM state is not persistent", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								TPM: &v1.TPMDevice{},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, volume := range pod.Spec.Volumes {
+					Expect(volume.Name).ToNot(Equal("vm-state"))
+				}
+			})
 			It("should add volume with secret referenced by qemu agent access cred", func() {
 				vmi := v1.VirtualMachineInstance{
 					ObjectMeta: metav1.ObjectMeta{
@@ -860,6 +922,122 @@ var _ = Describe("Template", func() {
 				Expect(value).To(Equal("k6t-eth0"))
 			})
 		})
+		Context("with vhostuser interface", func() {
+			It("should mount the socket directory of the VMI and the network status", func() {
+				testutils.UpdateFakeClusterConfig(configMapInformer, &kubev1.ConfigMap{
+					Data: map[string]string{virtconfig.VhostUserSocketDirKey: "/var/run/vhost"},
+				})
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+								Interfaces: []v1.Interface{{
+									Name:                   "dpdk",
+									InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+								}},
+							},
+						},
+						Networks: []v1.Network{{
+							Name:          "dpdk",
+							NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test1"}},
+						}},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				hostPathType := kubev1.HostPathDirectoryOrCreate
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "shared-dir",
+					VolumeSource: kubev1.VolumeSource{
+						HostPath: &kubev1.HostPathVolumeSource{Path: "/var/run/vhost/1234", Type: &hostPathType},
+					},
+				}))
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "network-status",
+					VolumeSource: kubev1.VolumeSource{
+						DownwardAPI: &kubev1.DownwardAPIVolumeSource{
+							Items: []kubev1.DownwardAPIVolumeFile{{
+								Path:     "network-status",
+								FieldRef: &kubev1.ObjectFieldSelector{FieldPath: "metadata.annotations['k8s.v1.cni.cncf.io/network-status']"},
+							}},
+						},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{Name: "shared-dir", MountPath: "/var/run/vhost/1234"}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{Name: "network-status", MountPath: "/var/run/kubevirt-network-status", ReadOnly: true}))
+			})
+		})
+		Context("with network binding plugins", func() {
+			var vmi v1.VirtualMachineInstance
+			BeforeEach(func() {
+				testutils.UpdateFakeClusterConfig(configMapInformer, &kubev1.ConfigMap{
+					Data: map[string]string{
+						virtconfig.FeatureGatesKey: virtconfig.NetworkBindingPluginsGate,
+						virtconfig.NetworkBindingPluginsKey: `
+custom:
+  sidecarImage: registry:5000/custom-binding:latest
+  domainAttachmentType: tap
+hookonly:
+  sidecarImage: registry:5000/custom-binding:latest
+`,
+					},
+				})
+				vmi = v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+								Interfaces: []v1.Interface{
+									{Name: "default", Binding: &v1.PluginBinding{Name: "custom"}},
+									{Name: "blue", Binding: &v1.PluginBinding{Name: "hookonly"}},
+								},
+							},
+						},
+						Networks: []v1.Network{
+							{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+							{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test1"}}},
+						},
+					},
+				}
+			})
+
+			It("should add a single hook sidecar per plugin image", func() {
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers).To(HaveLen(2))
+				Expect(pod.Spec.Containers[1].Name).To(Equal("hook-sidecar-0"))
+				Expect(pod.Spec.Containers[1].Image).To(Equal("registry:5000/custom-binding:latest"))
+				Expect(pod.Spec.Containers[0].Command).To(ContainElement("--hook-sidecars"))
+				Expect(pod.Spec.Containers[0].Command).To(ContainElement("1"))
+			})
+
+			It("should pass the domain attachment type to virt-launcher", func() {
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].Env).To(ContainElement(kubev1.EnvVar{Name: "KUBEVIRT_DOMAIN_ATTACHMENT_default", Value: "tap"}))
+				for _, env := range pod.Spec.Containers[0].Env {
+					Expect(env.Name).ToNot(Equal("KUBEVIRT_DOMAIN_ATTACHMENT_blue"))
+				}
+			})
+
+			It("should fail if the plugin is not registered", func() {
+				vmi.Spec.Domain.Devices.Interfaces[0].Binding.Name = "unknown"
+				_, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).To(HaveOccurred())
+			})
+		})
 		Context("with node selectors", func() {
 			It("should add node selectors to template", func() {
 
@@ -1404,6 +1582,53 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.Containers[0].Resources.Requests.Memory().String()).To(Equal("1180211045"))
 				Expect(pod.Spec.Containers[0].Resources.Limits.Memory().String()).To(Equal("2180211045"))
 			})
+			It("should size the default cpu request for the hotpluggable sockets", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							CPU: &v1.CPU{Sockets: 2, MaxSockets: 4},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal("400m"))
+			})
+			It("should scale cpu constraints for the hotpluggable sockets", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							CPU: &v1.CPU{Sockets: 2, MaxSockets: 4},
+							Resources: v1.ResourceRequirements{
+								Requests: kubev1.ResourceList{
+									kubev1.ResourceCPU: resource.MustParse("500m"),
+								},
+								Limits: kubev1.ResourceList{
+									kubev1.ResourceCPU: resource.MustParse("2"),
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal("1"))
+				Expect(pod.Spec.Containers[0].Resources.Limits.Cpu().String()).To(Equal("4"))
+			})
 			It("should overcommit guest overhead if selected, by only adding the overhead to memory limits", func() {
 
 				vmi := v1.VirtualMachineInstance{
@@ -43,6 +43,9 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/healthz"
 
+	backupv1alpha1 "kubevirt.io/client-go/apis/backup/v1alpha1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
@@ -50,14 +53,19 @@ import (
 	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/instancetype"
 	"kubevirt.io/kubevirt/pkg/service"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	"kubevirt.io/kubevirt/pkg/util/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/leaderelectionconfig"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/backup"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/clone"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/disruptionbudget"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/evacuation"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/export"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/snapshot"
 	workloadupdater "kubevirt.io/kubevirt/pkg/virt-controller/watch/workload-updater"
 )
@@ -149,6 +157,15 @@ type VirtControllerApp struct {
 	storageClassInformer      cache.SharedIndexInformer
 	allPodInformer            cache.SharedIndexInformer
 
+	exportController *export.VMExportController
+	vmExportInformer cache.SharedIndexInformer
+
+	cloneController *clone.VMCloneController
+	vmCloneInformer cache.SharedIndexInformer
+
+	backupController *backup.VMBackupController
+	vmBackupInformer cache.SharedIndexInformer
+
 	crdInformer cache.SharedIndexInformer
 
 	LeaderElection leaderelectionconfig.Configuration
@@ -184,6 +201,9 @@ type VirtControllerApp struct {
 	launcherSubGid                    int64
 	snapshotControllerThreads         int
 	restoreControllerThreads          int
+	exportControllerThreads           int
+	cloneControllerThreads            int
+	backupControllerThreads           int
 	snapshotControllerResyncPeriod    time.Duration
 
 	caConfigMapName  string
@@ -196,6 +216,9 @@ var _ service.Service = &VirtControllerApp{}
 func init() {
 	vsv1beta1.AddToScheme(scheme.Scheme)
 	snapshotv1.AddToScheme(scheme.Scheme)
+	exportv1alpha1.AddToScheme(scheme.Scheme)
+	clonev1alpha1.AddToScheme(scheme.Scheme)
+	backupv1alpha1.AddToScheme(scheme.Scheme)
 
 	prometheus.MustRegister(leaderGauge)
 	prometheus.MustRegister(readyGauge)
@@ -281,6 +304,9 @@ func Execute() {
 	app.vmRestoreInformer = app.informerFactory.VirtualMachineRestore()
 	app.storageClassInformer = app.informerFactory.StorageClass()
 	app.allPodInformer = app.informerFactory.Pod()
+	app.vmExportInformer = app.informerFactory.VirtualMachineExport()
+	app.vmCloneInformer = app.informerFactory.VirtualMachineClone()
+	app.vmBackupInformer = app.informerFactory.VirtualMachineBackup()
 
 	if app.hasCDI {
 		app.dataVolumeInformer = app.informerFactory.DataVolume()
@@ -300,6 +326,9 @@ func Execute() {
 	app.initEvacuationController()
 	app.initSnapshotController()
 	app.initRestoreController()
+	app.initExportController()
+	app.initCloneController()
+	app.initBackupController()
 	app.initWorkloadUpdaterController()
 	go app.Run()
 
@@ -409,6 +438,9 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 		go vca.migrationController.Run(vca.migrationControllerThreads, stop)
 		go vca.snapshotController.Run(vca.snapshotControllerThreads, stop)
 		go vca.restoreController.Run(vca.restoreControllerThreads, stop)
+		go vca.exportController.Run(vca.exportControllerThreads, stop)
+		go vca.cloneController.Run(vca.cloneControllerThreads, stop)
+		go vca.backupController.Run(vca.backupControllerThreads, stop)
 		go vca.workloadUpdateController.Run(stop)
 		cache.WaitForCacheSync(stop, vca.persistentVolumeClaimInformer.HasSynced)
 		close(vca.readyChan)
@@ -444,7 +476,7 @@ func (vca *VirtControllerApp) initCommon() {
 		vca.launcherSubGid,
 	)
 
-	vca.vmiController = NewVMIController(vca.templateService, vca.vmiInformer, vca.kvPodInformer, vca.persistentVolumeClaimInformer, vca.vmiRecorder, vca.clientSet, vca.dataVolumeInformer)
+	vca.vmiController = NewVMIController(vca.templateService, vca.vmiInformer, vca.kvPodInformer, vca.persistentVolumeClaimInformer, vca.vmiRecorder, vca.clientSet, vca.dataVolumeInformer, vca.clusterConfig)
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "node-controller")
 	vca.nodeController = NewNodeController(vca.clientSet, vca.nodeInformer, vca.vmiInformer, recorder)
 	vca.migrationController = NewMigrationController(vca.templateService, vca.vmiInformer, vca.kvPodInformer, vca.migrationInformer, vca.vmiRecorder, vca.clientSet, vca.clusterConfig)
@@ -463,8 +495,13 @@ func (vca *VirtControllerApp) initVirtualMachines() {
 		vca.vmInformer,
 		vca.dataVolumeInformer,
 		vca.persistentVolumeClaimInformer,
+		vca.migrationInformer,
+		instancetype.NewMethods(vca.clientSet),
 		recorder,
-		vca.clientSet)
+		vca.clientSet,
+		vca.clusterConfig,
+		// the allocated MAC addresses are rebuilt from the synced VMs and VMIs
+		macpool.NewMacPool(vca.clientSet, vca.kubevirtNamespace, vca.vmInformer, vca.vmiInformer))
 }
 
 func (vca *VirtControllerApp) initDisruptionBudgetController() {
@@ -539,6 +576,50 @@ func (vca *VirtControllerApp) initRestoreController() {
 	vca.restoreController.Init()
 }
 
+func (vca *VirtControllerApp) initExportController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "export-controller")
+	vca.exportController = &export.VMExportController{
+		Client:            vca.clientSet,
+		VMExportInformer:  vca.vmExportInformer,
+		VMInformer:        vca.vmInformer,
+		VMIInformer:       vca.vmiInformer,
+		PVCInformer:       vca.persistentVolumeClaimInformer,
+		PodInformer:       vca.allPodInformer,
+		Recorder:          recorder,
+		ExportServerImage: vca.launcherImage,
+		ImagePullSecret:   vca.imagePullSecret,
+		ResyncPeriod:      vca.snapshotControllerResyncPeriod,
+	}
+	vca.exportController.Init()
+}
+
+func (vca *VirtControllerApp) initCloneController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "clone-controller")
+	vca.cloneController = &clone.VMCloneController{
+		Client:                    vca.clientSet,
+		VMCloneInformer:           vca.vmCloneInformer,
+		VMSnapshotInformer:        vca.vmSnapshotInformer,
+		VMSnapshotContentInformer: vca.vmSnapshotContentInformer,
+		VMRestoreInformer:         vca.vmRestoreInformer,
+		VMInformer:                vca.vmInformer,
+		Recorder:                  recorder,
+	}
+	vca.cloneController.Init()
+}
+
+func (vca *VirtControllerApp) initBackupController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "backup-controller")
+	vca.backupController = &backup.VMBackupController{
+		Client:           vca.clientSet,
+		VMBackupInformer: vca.vmBackupInformer,
+		VMIInformer:      vca.vmiInformer,
+		PodInformer:      vca.kvPodInformer,
+		Recorder:         recorder,
+		LauncherImage:    vca.launcherImage,
+	}
+	vca.backupController.Init()
+}
+
 func (vca *VirtControllerApp) leaderProbe(_ *restful.Request, response *restful.Response) {
 	res := map[string]interface{}{}
 
@@ -617,6 +698,15 @@ func (vca *VirtControllerApp) AddFlags() {
 	flag.IntVar(&vca.restoreControllerThreads, "restore-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for restore controller")
 
+	flag.IntVar(&vca.exportControllerThreads, "export-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for export controller")
+
+	flag.IntVar(&vca.cloneControllerThreads, "clone-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for clone controller")
+
+	flag.IntVar(&vca.backupControllerThreads, "backup-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for backup controller")
+
 	flag.DurationVar(&vca.snapshotControllerResyncPeriod, "snapshot-controller-resync-period", defaultSnapshotControllerResyncPeriod,
 		"Number of goroutines to run for snapshot controller")
 
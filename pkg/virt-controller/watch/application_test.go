@@ -41,15 +41,23 @@ import (
 	io_prometheus_client "github.com/prometheus/client_model/go"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	backupv1alpha1 "kubevirt.io/client-go/apis/backup/v1alpha1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/instancetype"
 	"kubevirt.io/kubevirt/pkg/rest"
 	testutils "kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/backup"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/clone"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/disruptionbudget"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/evacuation"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/export"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/snapshot"
 
 	storagev1 "k8s.io/api/storage/v1"
@@ -88,6 +96,9 @@ var _ = Describe("Application", func() {
 		crdInformer, _ := testutils.NewFakeInformerFor(&extv1.CustomResourceDefinition{})
 		vmRestoreInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
 		dvInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		vmExportInformer, _ := testutils.NewFakeInformerFor(&exportv1alpha1.VirtualMachineExport{})
+		vmCloneInformer, _ := testutils.NewFakeInformerFor(&clonev1alpha1.VirtualMachineClone{})
+		vmBackupInformer, _ := testutils.NewFakeInformerFor(&backupv1alpha1.VirtualMachineBackup{})
 
 		var qemuGid int64 = 107
 
@@ -102,9 +113,10 @@ var _ = Describe("Application", func() {
 			recorder,
 			virtClient,
 			dataVolumeInformer,
+			config,
 		)
 		app.rsController = NewVMIReplicaSet(vmiInformer, rsInformer, recorder, virtClient, uint(10))
-		app.vmController = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, recorder, virtClient)
+		app.vmController = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, migrationInformer, instancetype.NewMethods(virtClient), recorder, virtClient, config, macpool.NewMacPool(virtClient, k8sv1.NamespaceDefault, vmInformer, vmiInformer))
 		app.migrationController = NewMigrationController(services.NewTemplateService("a", "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid),
 			vmiInformer,
 			podInformer,
@@ -141,6 +153,35 @@ var _ = Describe("Application", func() {
 			Recorder:                  recorder,
 		}
 		app.restoreController.Init()
+		app.exportController = &export.VMExportController{
+			Client:           virtClient,
+			VMExportInformer: vmExportInformer,
+			VMInformer:       vmInformer,
+			VMIInformer:      vmiInformer,
+			PVCInformer:      pvcInformer,
+			PodInformer:      podInformer,
+			Recorder:         recorder,
+			ResyncPeriod:     60 * time.Second,
+		}
+		app.exportController.Init()
+		app.cloneController = &clone.VMCloneController{
+			Client:                    virtClient,
+			VMCloneInformer:           vmCloneInformer,
+			VMSnapshotInformer:        vmSnapshotInformer,
+			VMSnapshotContentInformer: vmSnapshotContentInformer,
+			VMRestoreInformer:         vmRestoreInformer,
+			VMInformer:                vmInformer,
+			Recorder:                  recorder,
+		}
+		app.cloneController.Init()
+		app.backupController = &backup.VMBackupController{
+			Client:           virtClient,
+			VMBackupInformer: vmBackupInformer,
+			VMIInformer:      vmiInformer,
+			PodInformer:      podInformer,
+			Recorder:         recorder,
+		}
+		app.backupController.Init()
 		app.persistentVolumeClaimInformer = pvcInformer
 
 		app.readyChan = make(chan bool)
@@ -0,0 +1,468 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	backupv1alpha1 "kubevirt.io/client-go/apis/backup/v1alpha1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	vmBackupKind = "VirtualMachineBackup"
+
+	vmBackupFinalizer = "backup.kubevirt.io/vmbackup-protection"
+
+	backupPodNameBase = "virt-backup"
+	backupNBDVolume   = "backup-nbd"
+	backupTargetMount = "/target"
+	backupNBDMount    = "/nbd"
+	qemuUID           = int64(107)
+
+	reasonSourceNotRunning   = "SourceNotRunning"
+	reasonBackupInProgress   = "BackupInProgress"
+	reasonMigrationRunning   = "MigrationInProgress"
+	reasonCheckpointNotFound = "CheckpointNotFound"
+	reasonCopyInProgress     = "CopyInProgress"
+	reasonCopyFailed         = "CopyFailed"
+	reasonBackupFailed       = "BackupFailed"
+	reasonSucceeded          = "Succeeded"
+
+	vmBackupFailedEvent    = "VirtualMachineBackupFailed"
+	vmBackupStartedEvent   = "VirtualMachineBackupStarted"
+	vmBackupSucceededEvent = "VirtualMachineBackupSucceeded"
+	backupPodCreatedEvent  = "BackupPodCreated"
+)
+
+// variable so can be overridden in tests
+var currentTime = func() *metav1.Time {
+	t := metav1.Now()
+	return &t
+}
+
+// checkpointName is the name of the checkpoint created by the backup, later
+// backups are incremental to it
+func checkpointName(vmBackup *backupv1alpha1.VirtualMachineBackup) string {
+	return string(vmBackup.UID)
+}
+
+func backupPodName(vmBackup *backupv1alpha1.VirtualMachineBackup) string {
+	return fmt.Sprintf("%s-%s", backupPodNameBase, vmBackup.UID)
+}
+
+func isPushMode(vmBackup *backupv1alpha1.VirtualMachineBackup) bool {
+	return vmBackup.Spec.Mode != nil && *vmBackup.Spec.Mode == backupv1alpha1.BackupModePush
+}
+
+func vmBackupFinished(vmBackup *backupv1alpha1.VirtualMachineBackup) bool {
+	return vmBackup.Status != nil &&
+		(vmBackup.Status.Phase == backupv1alpha1.Succeeded || vmBackup.Status.Phase == backupv1alpha1.Failed)
+}
+
+func hasFinalizer(vmBackup *backupv1alpha1.VirtualMachineBackup) bool {
+	for _, f := range vmBackup.Finalizers {
+		if f == vmBackupFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func removeFinalizer(vmBackup *backupv1alpha1.VirtualMachineBackup) {
+	var finalizers []string
+	for _, f := range vmBackup.Finalizers {
+		if f != vmBackupFinalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	vmBackup.Finalizers = finalizers
+}
+
+// backupState returns the backup job of the VirtualMachineInstance if it
+// belongs to the VirtualMachineBackup
+func backupState(vmBackup *backupv1alpha1.VirtualMachineBackup, vmi *v1.VirtualMachineInstance) *v1.VirtualMachineInstanceBackupState {
+	if vmi == nil || vmi.Status.Backup == nil || vmi.Status.Backup.Name != vmBackup.Name {
+		return nil
+	}
+	return vmi.Status.Backup
+}
+
+func (ctrl *VMBackupController) updateVMBackup(vmBackup *backupv1alpha1.VirtualMachineBackup) error {
+	log.Log.V(3).Infof("Updating VirtualMachineBackup %s/%s", vmBackup.Namespace, vmBackup.Name)
+
+	vmi, err := ctrl.getVMI(vmBackup.Namespace, vmBackup.Spec.Source.Name)
+	if err != nil {
+		return err
+	}
+
+	if vmBackup.DeletionTimestamp != nil {
+		return ctrl.cleanup(vmBackup, vmi)
+	}
+
+	if vmBackupFinished(vmBackup) {
+		return nil
+	}
+
+	if !hasFinalizer(vmBackup) {
+		vmBackupCpy := vmBackup.DeepCopy()
+		vmBackupCpy.Finalizers = append(vmBackupCpy.Finalizers, vmBackupFinalizer)
+		_, err := ctrl.Client.VirtualMachineBackup(vmBackupCpy.Namespace).Update(context.Background(), vmBackupCpy, metav1.UpdateOptions{})
+		return err
+	}
+
+	vmBackupCpy := vmBackup.DeepCopy()
+	if vmBackupCpy.Status == nil {
+		vmBackupCpy.Status = &backupv1alpha1.VirtualMachineBackupStatus{}
+	}
+
+	if vmBackupCpy.Status.Phase != backupv1alpha1.InProgress {
+		return ctrl.begin(vmBackup, vmBackupCpy, vmi)
+	}
+
+	state := backupState(vmBackupCpy, vmi)
+	if vmi == nil || !vmi.IsRunning() {
+		return ctrl.fail(vmBackup, vmBackupCpy, reasonSourceNotRunning, "the VirtualMachineInstance stopped during the backup")
+	}
+	if state == nil {
+		// the VirtualMachineInstance did not report the backup yet
+		return nil
+	}
+
+	vmBackupCpy.Status.Exports = state.Exports
+	if state.Checkpoint != "" {
+		checkpoint := state.Checkpoint
+		vmBackupCpy.Status.CheckpointName = &checkpoint
+	}
+
+	if state.Completed {
+		if state.Failed {
+			return ctrl.fail(vmBackup, vmBackupCpy, reasonBackupFailed, state.Message)
+		}
+		return ctrl.succeed(vmBackup, vmBackupCpy)
+	}
+
+	if !isPushMode(vmBackupCpy) {
+		return ctrl.updateStatus(vmBackup, vmBackupCpy)
+	}
+
+	pod, err := ctrl.getPod(vmBackupCpy.Namespace, backupPodName(vmBackupCpy))
+	if err != nil {
+		return err
+	}
+	if pod == nil {
+		// the pod may not be in the cache yet
+		pod, err = ctrl.Client.CoreV1().Pods(vmBackupCpy.Namespace).Get(context.Background(), backupPodName(vmBackupCpy), metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return ctrl.endAndFail(vmBackup, vmBackupCpy, vmi, reasonCopyFailed, fmt.Sprintf("pod %s copying the disks does not exist", backupPodName(vmBackupCpy)))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		if err := ctrl.Client.VirtualMachineInstance(vmi.Namespace).EndBackup(vmi.Name); err != nil {
+			return err
+		}
+		return ctrl.succeed(vmBackup, vmBackupCpy)
+	case corev1.PodFailed:
+		return ctrl.endAndFail(vmBackup, vmBackupCpy, vmi, reasonCopyFailed, fmt.Sprintf("pod %s failed to copy the disks", pod.Name))
+	}
+
+	return ctrl.progress(vmBackup, vmBackupCpy, backupv1alpha1.InProgress, reasonCopyInProgress, "copying the disks to the target")
+}
+
+// begin starts the backup job on the VirtualMachineInstance once it is
+// running and no other backup or migration is in progress
+func (ctrl *VMBackupController) begin(vmBackup, vmBackupCpy *backupv1alpha1.VirtualMachineBackup, vmi *v1.VirtualMachineInstance) error {
+	if vmi == nil || !vmi.IsRunning() {
+		return ctrl.progress(vmBackup, vmBackupCpy, backupv1alpha1.Pending, reasonSourceNotRunning,
+			fmt.Sprintf("waiting for VirtualMachineInstance %s to run", vmBackup.Spec.Source.Name))
+	}
+
+	if state := vmi.Status.Backup; state != nil && state.Name == vmBackup.Name {
+		// the backup job began but the phase was not recorded, calling the
+		// backup subresource again would conflict with the running job
+		vmBackupCpy.Status.Type = backupv1alpha1.Full
+		if state.Incremental != "" {
+			vmBackupCpy.Status.Type = backupv1alpha1.Incremental
+		}
+		if vmBackupCpy.Status.StartTimestamp == nil {
+			vmBackupCpy.Status.StartTimestamp = state.StartTimestamp
+		}
+		if vmBackupCpy.Status.StartTimestamp == nil {
+			vmBackupCpy.Status.StartTimestamp = currentTime()
+		}
+		return ctrl.progress(vmBackup, vmBackupCpy, backupv1alpha1.InProgress, reasonBackupInProgress, "the disks are exported")
+	}
+
+	if vmi.Status.Backup != nil && !vmi.Status.Backup.Completed {
+		return ctrl.progress(vmBackup, vmBackupCpy, backupv1alpha1.Pending, reasonBackupInProgress,
+			fmt.Sprintf("waiting for backup %s to end", vmi.Status.Backup.Name))
+	}
+
+	if vmi.Status.MigrationState != nil && !vmi.Status.MigrationState.Completed && !vmi.Status.MigrationState.Failed {
+		return ctrl.progress(vmBackup, vmBackupCpy, backupv1alpha1.Pending, reasonMigrationRunning, "waiting for the migration to end")
+	}
+
+	vmBackupCpy.Status.Type = backupv1alpha1.Full
+	if incremental := vmBackup.Spec.Incremental; incremental != nil {
+		found := false
+		for _, checkpoint := range vmi.Status.Checkpoints {
+			if checkpoint.Name == *incremental {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ctrl.fail(vmBackup, vmBackupCpy, reasonCheckpointNotFound,
+				fmt.Sprintf("checkpoint %s does not exist, a full backup is required", *incremental))
+		}
+		vmBackupCpy.Status.Type = backupv1alpha1.Incremental
+	}
+
+	options := &v1.BackupOptions{
+		Name:        vmBackup.Name,
+		Checkpoint:  checkpointName(vmBackup),
+		Incremental: vmBackup.Spec.Incremental,
+	}
+
+	if isPushMode(vmBackup) {
+		pod, err := ctrl.getOrCreatePod(vmBackupCpy, vmi)
+		if err != nil {
+			return err
+		}
+		options.TargetPodUID = &pod.UID
+	}
+
+	if err := ctrl.Client.VirtualMachineInstance(vmi.Namespace).Backup(vmi.Name, options); err != nil {
+		return err
+	}
+
+	ctrl.Recorder.Eventf(vmBackupCpy, corev1.EventTypeNormal, vmBackupStartedEvent, "Started %s backup of VirtualMachineInstance %s", vmBackupCpy.Status.Type, vmi.Name)
+
+	vmBackupCpy.Status.StartTimestamp = currentTime()
+	return ctrl.progress(vmBackup, vmBackupCpy, backupv1alpha1.InProgress, reasonBackupInProgress, "the disks are exported")
+}
+
+// cleanup ends the backup job before the VirtualMachineBackup is deleted
+func (ctrl *VMBackupController) cleanup(vmBackup *backupv1alpha1.VirtualMachineBackup, vmi *v1.VirtualMachineInstance) error {
+	if !hasFinalizer(vmBackup) {
+		return nil
+	}
+
+	if state := backupState(vmBackup, vmi); state != nil && !state.Completed && vmi.IsRunning() {
+		if err := ctrl.Client.VirtualMachineInstance(vmi.Namespace).EndBackup(vmi.Name); err != nil {
+			return err
+		}
+	}
+
+	vmBackupCpy := vmBackup.DeepCopy()
+	removeFinalizer(vmBackupCpy)
+	_, err := ctrl.Client.VirtualMachineBackup(vmBackupCpy.Namespace).Update(context.Background(), vmBackupCpy, metav1.UpdateOptions{})
+	return err
+}
+
+func (ctrl *VMBackupController) getOrCreatePod(vmBackup *backupv1alpha1.VirtualMachineBackup, vmi *v1.VirtualMachineInstance) (*corev1.Pod, error) {
+	pod, err := ctrl.getPod(vmBackup.Namespace, backupPodName(vmBackup))
+	if err != nil || pod != nil {
+		return pod, err
+	}
+
+	pod, err = ctrl.Client.CoreV1().Pods(vmBackup.Namespace).Create(context.Background(), ctrl.renderPod(vmBackup, vmi), metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	if errors.IsAlreadyExists(err) {
+		pod, err = ctrl.Client.CoreV1().Pods(vmBackup.Namespace).Get(context.Background(), backupPodName(vmBackup), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		ctrl.Recorder.Eventf(vmBackup, corev1.EventTypeNormal, backupPodCreatedEvent, "Created pod %s copying the disks", pod.Name)
+	}
+
+	return pod, nil
+}
+
+// renderPod returns the pod copying the disks of a push mode backup to the
+// target claim. It runs on the node of the VirtualMachineInstance, where
+// virt-handler exposes the NBD server as a socket in its backup-nbd emptyDir
+// next to the list of exports.
+func (ctrl *VMBackupController) renderPod(vmBackup *backupv1alpha1.VirtualMachineBackup, vmi *v1.VirtualMachineInstance) *corev1.Pod {
+	uid := qemuUID
+	nonRoot := true
+	dir := fmt.Sprintf("%s/%s", backupTargetMount, vmBackup.Name)
+	script := fmt.Sprintf(`set -e
+until [ -S %[1]s/nbd.sock ]; do sleep 1; done
+mkdir -p %[2]s
+while read volume export; do
+  qemu-img convert -O qcow2 "nbd+unix:///${export}?socket=%[1]s/nbd.sock" "%[2]s/${volume}.qcow2" < /dev/null
+done < %[1]s/exports
+`, backupNBDMount, dir)
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backupPodName(vmBackup),
+			Namespace: vmBackup.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(vmBackup, backupv1alpha1.SchemeGroupVersion.WithKind(vmBackupKind)),
+			},
+			Labels: map[string]string{
+				v1.AppLabel: "virt-backup",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:      vmi.Status.NodeName,
+			RestartPolicy: corev1.RestartPolicyNever,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:    &uid,
+				RunAsNonRoot: &nonRoot,
+				FSGroup:      &uid,
+			},
+			Containers: []corev1.Container{
+				{
+					Name:    "backup",
+					Image:   ctrl.LauncherImage,
+					Command: []string{"/bin/bash", "-c", script},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "target", MountPath: backupTargetMount},
+						{Name: backupNBDVolume, MountPath: backupNBDMount},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "target",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: vmBackup.Spec.Target.PersistentVolumeClaimName,
+						},
+					},
+				},
+				{
+					Name: backupNBDVolume,
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (ctrl *VMBackupController) succeed(vmBackup, vmBackupCpy *backupv1alpha1.VirtualMachineBackup) error {
+	ctrl.Recorder.Eventf(vmBackupCpy, corev1.EventTypeNormal, vmBackupSucceededEvent, "Successfully backed up VirtualMachineInstance %s", vmBackupCpy.Spec.Source.Name)
+
+	vmBackupCpy.Status.Phase = backupv1alpha1.Succeeded
+	vmBackupCpy.Status.CompletionTimestamp = currentTime()
+	updateBackupConditions(vmBackupCpy,
+		newProgressingCondition(corev1.ConditionFalse, reasonSucceeded, ""),
+		newReadyCondition(corev1.ConditionTrue, reasonSucceeded, ""))
+
+	return ctrl.updateStatus(vmBackup, vmBackupCpy)
+}
+
+// endAndFail ends the backup job on the VirtualMachineInstance before
+// failing the VirtualMachineBackup
+func (ctrl *VMBackupController) endAndFail(vmBackup, vmBackupCpy *backupv1alpha1.VirtualMachineBackup, vmi *v1.VirtualMachineInstance, reason, message string) error {
+	if err := ctrl.Client.VirtualMachineInstance(vmi.Namespace).EndBackup(vmi.Name); err != nil {
+		return err
+	}
+	return ctrl.fail(vmBackup, vmBackupCpy, reason, message)
+}
+
+func (ctrl *VMBackupController) progress(vmBackup, vmBackupCpy *backupv1alpha1.VirtualMachineBackup, phase backupv1alpha1.VirtualMachineBackupPhase, reason, message string) error {
+	vmBackupCpy.Status.Phase = phase
+	updateBackupConditions(vmBackupCpy,
+		newProgressingCondition(corev1.ConditionTrue, reason, message),
+		newReadyCondition(corev1.ConditionFalse, reason, message))
+
+	return ctrl.updateStatus(vmBackup, vmBackupCpy)
+}
+
+func (ctrl *VMBackupController) fail(vmBackup, vmBackupCpy *backupv1alpha1.VirtualMachineBackup, reason, message string) error {
+	ctrl.Recorder.Event(vmBackupCpy, corev1.EventTypeWarning, vmBackupFailedEvent, message)
+
+	vmBackupCpy.Status.Phase = backupv1alpha1.Failed
+	vmBackupCpy.Status.CompletionTimestamp = currentTime()
+	updateBackupConditions(vmBackupCpy,
+		newProgressingCondition(corev1.ConditionFalse, reason, message),
+		newReadyCondition(corev1.ConditionFalse, reason, message))
+
+	return ctrl.updateStatus(vmBackup, vmBackupCpy)
+}
+
+func (ctrl *VMBackupController) updateStatus(vmBackup, vmBackupCpy *backupv1alpha1.VirtualMachineBackup) error {
+	if reflect.DeepEqual(vmBackup, vmBackupCpy) {
+		return nil
+	}
+
+	_, err := ctrl.Client.VirtualMachineBackup(vmBackupCpy.Namespace).Update(context.Background(), vmBackupCpy, metav1.UpdateOptions{})
+	return err
+}
+
+func newReadyCondition(status corev1.ConditionStatus, reason, message string) backupv1alpha1.VirtualMachineBackupCondition {
+	return backupv1alpha1.VirtualMachineBackupCondition{
+		Type:               backupv1alpha1.ConditionReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: *currentTime(),
+	}
+}
+
+func newProgressingCondition(status corev1.ConditionStatus, reason, message string) backupv1alpha1.VirtualMachineBackupCondition {
+	return backupv1alpha1.VirtualMachineBackupCondition{
+		Type:               backupv1alpha1.ConditionProgressing,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: *currentTime(),
+	}
+}
+
+func updateBackupConditions(vmBackup *backupv1alpha1.VirtualMachineBackup, conditions ...backupv1alpha1.VirtualMachineBackupCondition) {
+	for _, c := range conditions {
+		found := false
+		for i := range vmBackup.Status.Conditions {
+			if vmBackup.Status.Conditions[i].Type == c.Type {
+				if vmBackup.Status.Conditions[i].Status != c.Status || vmBackup.Status.Conditions[i].Reason != c.Reason || vmBackup.Status.Conditions[i].Message != c.Message {
+					vmBackup.Status.Conditions[i] = c
+				}
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			vmBackup.Status.Conditions = append(vmBackup.Status.Conditions, c)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	backupv1alpha1 "kubevirt.io/client-go/apis/backup/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+)
+
+// VMBackupController is responsible for backing up running VirtualMachines
+type VMBackupController struct {
+	Client kubecli.KubevirtClient
+
+	VMBackupInformer cache.SharedIndexInformer
+	VMIInformer      cache.SharedIndexInformer
+	PodInformer      cache.SharedIndexInformer
+
+	Recorder record.EventRecorder
+
+	// LauncherImage is the image of the pods copying the disks of push mode backups
+	LauncherImage string
+
+	vmBackupQueue workqueue.RateLimitingInterface
+}
+
+// Init initializes the backup controller
+func (ctrl *VMBackupController) Init() {
+	ctrl.vmBackupQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "backup-controller-vmbackup")
+
+	ctrl.VMBackupInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMBackup,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMBackup(newObj) },
+		},
+	)
+
+	ctrl.VMIInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMI,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMI(newObj) },
+			DeleteFunc: ctrl.handleVMI,
+		},
+	)
+
+	ctrl.PodInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handlePod,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handlePod(newObj) },
+			DeleteFunc: ctrl.handlePod,
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *VMBackupController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmBackupQueue.ShutDown()
+
+	log.Log.Info("Starting backup controller.")
+	defer log.Log.Info("Shutting down backup controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMBackupInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+		ctrl.PodInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmBackupWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *VMBackupController) vmBackupWorker() {
+	for ctrl.processVMBackupWorkItem() {
+	}
+}
+
+func (ctrl *VMBackupController) processVMBackupWorkItem() bool {
+	obj, shutdown := ctrl.vmBackupQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer ctrl.vmBackupQueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		ctrl.vmBackupQueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	log.Log.V(3).Infof("vmBackup worker processing key [%s]", key)
+
+	if err := ctrl.execute(key); err != nil {
+		utilruntime.HandleError(err)
+		ctrl.vmBackupQueue.AddRateLimited(key)
+		return true
+	}
+
+	ctrl.vmBackupQueue.Forget(obj)
+	return true
+}
+
+func (ctrl *VMBackupController) execute(key string) error {
+	storeObj, exists, err := ctrl.VMBackupInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	vmBackup, ok := storeObj.(*backupv1alpha1.VirtualMachineBackup)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateVMBackup(vmBackup.DeepCopy())
+}
+
+func (ctrl *VMBackupController) handleVMBackup(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmBackup, ok := obj.(*backupv1alpha1.VirtualMachineBackup); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(vmBackup)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, vmBackup)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmBackupQueue.Add(objName)
+	}
+}
+
+// handleVMI enqueues the backups of a VirtualMachineInstance. Pending
+// backups are waiting for it to start or for another backup to end.
+func (ctrl *VMBackupController) handleVMI(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	vmi, ok := obj.(*v1.VirtualMachineInstance)
+	if !ok {
+		return
+	}
+
+	keys, err := ctrl.VMBackupInformer.GetIndexer().IndexKeys(cache.NamespaceIndex, vmi.Namespace)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to list backups in namespace %s", vmi.Namespace)
+		return
+	}
+
+	for _, key := range keys {
+		storeObj, exists, err := ctrl.VMBackupInformer.GetStore().GetByKey(key)
+		if err != nil || !exists {
+			continue
+		}
+		if vmBackup := storeObj.(*backupv1alpha1.VirtualMachineBackup); vmBackup.Spec.Source.Name == vmi.Name && !vmBackupFinished(vmBackup) {
+			ctrl.vmBackupQueue.Add(key)
+		}
+	}
+}
+
+// handlePod enqueues the VirtualMachineBackup owning a pod copying disks
+func (ctrl *VMBackupController) handlePod(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	o, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+
+	if owner := metav1.GetControllerOf(o); owner != nil && owner.Kind == vmBackupKind {
+		ctrl.vmBackupQueue.Add(cacheKeyFunc(o.GetNamespace(), owner.Name))
+	}
+}
+
+func cacheKeyFunc(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+func (ctrl *VMBackupController) getVMI(namespace, name string) (*v1.VirtualMachineInstance, error) {
+	obj, exists, err := ctrl.VMIInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*v1.VirtualMachineInstance).DeepCopy(), nil
+}
+
+func (ctrl *VMBackupController) getPod(namespace, name string) (*corev1.Pod, error) {
+	obj, exists, err := ctrl.PodInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*corev1.Pod).DeepCopy(), nil
+}
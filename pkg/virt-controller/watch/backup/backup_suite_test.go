@@ -0,0 +1,11 @@
+package backup
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestBackup(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t, "Backup Suite")
+}
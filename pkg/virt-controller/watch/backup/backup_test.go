@@ -0,0 +1,368 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package backup
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	backupv1alpha1 "kubevirt.io/client-go/apis/backup/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+const (
+	testNamespace = "default"
+	backupName    = "backup"
+	backupUID     = "backup-uid"
+)
+
+var _ = Describe("Backup controller", func() {
+	var (
+		ctrl             *gomock.Controller
+		controller       *VMBackupController
+		vmBackupInformer cache.SharedIndexInformer
+		vmiInformer      cache.SharedIndexInformer
+		podInformer      cache.SharedIndexInformer
+		vmiInterface     *kubecli.MockVirtualMachineInstanceInterface
+		kubevirtClient   *kubevirtfake.Clientset
+		kubeClient       *k8sfake.Clientset
+		recorder         *record.FakeRecorder
+	)
+
+	kubevirtGroup := v1.GroupName
+
+	newVMBackup := func() *backupv1alpha1.VirtualMachineBackup {
+		return &backupv1alpha1.VirtualMachineBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       backupName,
+				Namespace:  testNamespace,
+				UID:        backupUID,
+				Finalizers: []string{vmBackupFinalizer},
+			},
+			Spec: backupv1alpha1.VirtualMachineBackupSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: &kubevirtGroup,
+					Kind:     "VirtualMachine",
+					Name:     "vm",
+				},
+			},
+		}
+	}
+
+	newVMI := func() *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("vm")
+		vmi.Namespace = testNamespace
+		vmi.Status.Phase = v1.Running
+		vmi.Status.NodeName = "node01"
+		return vmi
+	}
+
+	addVMBackup := func(vmBackup *backupv1alpha1.VirtualMachineBackup) {
+		Expect(vmBackupInformer.GetStore().Add(vmBackup)).To(Succeed())
+		_, err := kubevirtClient.BackupV1alpha1().VirtualMachineBackups(testNamespace).Create(context.Background(), vmBackup, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	sync := func() *backupv1alpha1.VirtualMachineBackup {
+		Expect(controller.execute(cacheKeyFunc(testNamespace, backupName))).To(Succeed())
+		vmBackup, err := kubevirtClient.BackupV1alpha1().VirtualMachineBackups(testNamespace).Get(context.Background(), backupName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return vmBackup
+	}
+
+	inProgress := func(vmBackup *backupv1alpha1.VirtualMachineBackup) *backupv1alpha1.VirtualMachineBackup {
+		vmBackup.Status = &backupv1alpha1.VirtualMachineBackupStatus{
+			Phase: backupv1alpha1.InProgress,
+			Type:  backupv1alpha1.Full,
+		}
+		return vmBackup
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+
+		vmBackupInformer, _ = testutils.NewFakeInformerFor(&backupv1alpha1.VirtualMachineBackup{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		podInformer, _ = testutils.NewFakeInformerFor(&corev1.Pod{})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = &VMBackupController{
+			Client:           virtClient,
+			VMBackupInformer: vmBackupInformer,
+			VMIInformer:      vmiInformer,
+			PodInformer:      podInformer,
+			Recorder:         recorder,
+			LauncherImage:    "virt-launcher",
+		}
+		controller.Init()
+
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+		kubeClient = k8sfake.NewSimpleClientset()
+		virtClient.EXPECT().VirtualMachineBackup(testNamespace).
+			Return(kubevirtClient.BackupV1alpha1().VirtualMachineBackups(testNamespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstance(testNamespace).Return(vmiInterface).AnyTimes()
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should add the finalizer first", func() {
+		vmBackup := newVMBackup()
+		vmBackup.Finalizers = nil
+		addVMBackup(vmBackup)
+
+		vmBackup = sync()
+		Expect(vmBackup.Finalizers).To(ContainElement(vmBackupFinalizer))
+		Expect(vmBackup.Status).To(BeNil())
+	})
+
+	It("should wait for the VirtualMachineInstance to run", func() {
+		addVMBackup(newVMBackup())
+
+		vmBackup := sync()
+		Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.Pending))
+		Expect(vmBackup.Status.Conditions[0].Reason).To(Equal(reasonSourceNotRunning))
+	})
+
+	It("should wait for another backup to end", func() {
+		addVMBackup(newVMBackup())
+		vmi := newVMI()
+		vmi.Status.Backup = &v1.VirtualMachineInstanceBackupState{Name: "other"}
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+		vmBackup := sync()
+		Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.Pending))
+		Expect(vmBackup.Status.Conditions[0].Reason).To(Equal(reasonBackupInProgress))
+	})
+
+	It("should begin a full pull mode backup with a checkpoint named after the backup", func() {
+		addVMBackup(newVMBackup())
+		Expect(vmiInformer.GetStore().Add(newVMI())).To(Succeed())
+
+		vmiInterface.EXPECT().Backup("vm", gomock.Any()).DoAndReturn(func(name string, options *v1.BackupOptions) error {
+			Expect(options.Name).To(Equal(backupName))
+			Expect(options.Checkpoint).To(Equal(backupUID))
+			Expect(options.Incremental).To(BeNil())
+			Expect(options.TargetPodUID).To(BeNil())
+			return nil
+		})
+
+		vmBackup := sync()
+		Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.InProgress))
+		Expect(vmBackup.Status.Type).To(Equal(backupv1alpha1.Full))
+		Expect(vmBackup.Status.StartTimestamp).ToNot(BeNil())
+		testutils.ExpectEvent(recorder, vmBackupStartedEvent)
+	})
+
+	It("should begin an incremental backup to an existing checkpoint", func() {
+		vmBackup := newVMBackup()
+		incremental := "previous-uid"
+		vmBackup.Spec.Incremental = &incremental
+		addVMBackup(vmBackup)
+		vmi := newVMI()
+		vmi.Status.Checkpoints = []v1.VirtualMachineInstanceCheckpoint{{Name: incremental}}
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+		vmiInterface.EXPECT().Backup("vm", gomock.Any()).DoAndReturn(func(name string, options *v1.BackupOptions) error {
+			Expect(*options.Incremental).To(Equal(incremental))
+			return nil
+		})
+
+		vmBackup = sync()
+		Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.InProgress))
+		Expect(vmBackup.Status.Type).To(Equal(backupv1alpha1.Incremental))
+		testutils.ExpectEvent(recorder, vmBackupStartedEvent)
+	})
+
+	It("should not begin the backup job again if the VirtualMachineInstance reports it", func() {
+		addVMBackup(newVMBackup())
+		vmi := newVMI()
+		startTimestamp := metav1.Now()
+		vmi.Status.Backup = &v1.VirtualMachineInstanceBackupState{Name: backupName, Checkpoint: backupUID, StartTimestamp: &startTimestamp}
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+		vmBackup := sync()
+		Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.InProgress))
+		Expect(vmBackup.Status.Type).To(Equal(backupv1alpha1.Full))
+		Expect(vmBackup.Status.StartTimestamp).To(Equal(&startTimestamp))
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should fail an incremental backup to an unknown checkpoint", func() {
+		vmBackup := newVMBackup()
+		incremental := "unknown"
+		vmBackup.Spec.Incremental = &incremental
+		addVMBackup(vmBackup)
+		Expect(vmiInformer.GetStore().Add(newVMI())).To(Succeed())
+
+		vmBackup = sync()
+		Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.Failed))
+		Expect(vmBackup.Status.Conditions[0].Reason).To(Equal(reasonCheckpointNotFound))
+		testutils.ExpectEvent(recorder, vmBackupFailedEvent)
+	})
+
+	It("should report the exports and the checkpoint of a backup in progress", func() {
+		addVMBackup(inProgress(newVMBackup()))
+		vmi := newVMI()
+		exports := []v1.BackupExport{{VolumeName: "rootdisk", ExportName: "vda"}}
+		vmi.Status.Backup = &v1.VirtualMachineInstanceBackupState{Name: backupName, Checkpoint: backupUID, Exports: exports}
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+		vmBackup := sync()
+		Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.InProgress))
+		Expect(vmBackup.Status.Exports).To(Equal(exports))
+		Expect(*vmBackup.Status.CheckpointName).To(Equal(backupUID))
+	})
+
+	It("should succeed once the backup job completed", func() {
+		addVMBackup(inProgress(newVMBackup()))
+		vmi := newVMI()
+		vmi.Status.Backup = &v1.VirtualMachineInstanceBackupState{Name: backupName, Checkpoint: backupUID, Completed: true}
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+		vmBackup := sync()
+		Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.Succeeded))
+		Expect(vmBackup.Status.CompletionTimestamp).ToNot(BeNil())
+		testutils.ExpectEvent(recorder, vmBackupSucceededEvent)
+	})
+
+	It("should fail if the VirtualMachineInstance stopped", func() {
+		addVMBackup(inProgress(newVMBackup()))
+
+		vmBackup := sync()
+		Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.Failed))
+		Expect(vmBackup.Status.Conditions[0].Reason).To(Equal(reasonSourceNotRunning))
+		testutils.ExpectEvent(recorder, vmBackupFailedEvent)
+	})
+
+	It("should end the backup job and remove the finalizer on deletion", func() {
+		vmBackup := inProgress(newVMBackup())
+		now := metav1.Now()
+		vmBackup.DeletionTimestamp = &now
+		addVMBackup(vmBackup)
+		vmi := newVMI()
+		vmi.Status.Backup = &v1.VirtualMachineInstanceBackupState{Name: backupName}
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+		vmiInterface.EXPECT().EndBackup("vm").Return(nil)
+
+		vmBackup = sync()
+		Expect(vmBackup.Finalizers).To(BeEmpty())
+	})
+
+	Context("in push mode", func() {
+		push := backupv1alpha1.BackupModePush
+
+		newPushVMBackup := func() *backupv1alpha1.VirtualMachineBackup {
+			vmBackup := newVMBackup()
+			vmBackup.Spec.Mode = &push
+			vmBackup.Spec.Target = &backupv1alpha1.BackupTarget{PersistentVolumeClaimName: "target"}
+			return vmBackup
+		}
+
+		addPod := func(phase corev1.PodPhase) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "virt-backup-" + backupUID, Namespace: testNamespace},
+				Status:     corev1.PodStatus{Phase: phase},
+			}
+			Expect(podInformer.GetStore().Add(pod)).To(Succeed())
+		}
+
+		It("should create the pod copying the disks on the node of the VirtualMachineInstance", func() {
+			addVMBackup(newPushVMBackup())
+			Expect(vmiInformer.GetStore().Add(newVMI())).To(Succeed())
+
+			vmiInterface.EXPECT().Backup("vm", gomock.Any()).DoAndReturn(func(name string, options *v1.BackupOptions) error {
+				Expect(options.TargetPodUID).ToNot(BeNil())
+				return nil
+			})
+
+			vmBackup := sync()
+			Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.InProgress))
+
+			pod, err := kubeClient.CoreV1().Pods(testNamespace).Get(context.Background(), "virt-backup-"+backupUID, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod.Spec.NodeName).To(Equal("node01"))
+			Expect(pod.Spec.Containers[0].Image).To(Equal("virt-launcher"))
+			Expect(pod.Spec.Containers[0].Command[2]).To(ContainSubstring(`"/target/backup/${volume}.qcow2"`))
+			Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("target"))
+			Expect(pod.Spec.Volumes[1].Name).To(Equal("backup-nbd"))
+			Expect(metav1.GetControllerOf(pod).Kind).To(Equal(vmBackupKind))
+			testutils.ExpectEvent(recorder, backupPodCreatedEvent)
+			testutils.ExpectEvent(recorder, vmBackupStartedEvent)
+		})
+
+		It("should end the backup job once the disks are copied", func() {
+			addVMBackup(inProgress(newPushVMBackup()))
+			vmi := newVMI()
+			vmi.Status.Backup = &v1.VirtualMachineInstanceBackupState{Name: backupName}
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+			addPod(corev1.PodSucceeded)
+
+			vmiInterface.EXPECT().EndBackup("vm").Return(nil)
+
+			vmBackup := sync()
+			Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.Succeeded))
+			testutils.ExpectEvent(recorder, vmBackupSucceededEvent)
+		})
+
+		It("should end the backup job and fail if the disks could not be copied", func() {
+			addVMBackup(inProgress(newPushVMBackup()))
+			vmi := newVMI()
+			vmi.Status.Backup = &v1.VirtualMachineInstanceBackupState{Name: backupName}
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+			addPod(corev1.PodFailed)
+
+			vmiInterface.EXPECT().EndBackup("vm").Return(nil)
+
+			vmBackup := sync()
+			Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.Failed))
+			Expect(vmBackup.Status.Conditions[0].Reason).To(Equal(reasonCopyFailed))
+			testutils.ExpectEvent(recorder, vmBackupFailedEvent)
+		})
+
+		It("should wait while the disks are copied", func() {
+			addVMBackup(inProgress(newPushVMBackup()))
+			vmi := newVMI()
+			vmi.Status.Backup = &v1.VirtualMachineInstanceBackupState{Name: backupName}
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+			addPod(corev1.PodRunning)
+
+			vmBackup := sync()
+			Expect(vmBackup.Status.Phase).To(Equal(backupv1alpha1.InProgress))
+			Expect(vmBackup.Status.Conditions[0].Reason).To(Equal(reasonCopyInProgress))
+		})
+	})
+})
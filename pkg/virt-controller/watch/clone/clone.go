@@ -0,0 +1,498 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package clone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/vmrestore"
+)
+
+const (
+	vmCloneKind                = "VirtualMachineClone"
+	virtualMachineKind         = "VirtualMachine"
+	virtualMachineSnapshotKind = "VirtualMachineSnapshot"
+
+	temporarySnapshotNameBase = "tmp-snapshot"
+
+	reasonSourceNotFound     = "SourceNotFound"
+	reasonTargetExists       = "TargetExists"
+	reasonSnapshotInProgress = "SnapshotInProgress"
+	reasonRestoreInProgress  = "RestoreInProgress"
+	reasonSucceeded          = "Succeeded"
+
+	vmCloneFailedEvent    = "VirtualMachineCloneFailed"
+	vmCloneSucceededEvent = "VirtualMachineCloneSucceeded"
+	snapshotCreatedEvent  = "SnapshotCreated"
+	restoreCreatedEvent   = "RestoreCreated"
+)
+
+// variable so can be overridden in tests
+var currentTime = func() *metav1.Time {
+	t := metav1.Now()
+	return &t
+}
+
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func temporarySnapshotName(vmClone *clonev1alpha1.VirtualMachineClone) string {
+	return fmt.Sprintf("%s-%s", temporarySnapshotNameBase, vmClone.UID)
+}
+
+func restoreName(vmClone *clonev1alpha1.VirtualMachineClone) string {
+	return vmrestore.CloneRestoreName(vmClone)
+}
+
+func vmCloneFinished(vmClone *clonev1alpha1.VirtualMachineClone) bool {
+	return vmClone.Status != nil &&
+		(vmClone.Status.Phase == clonev1alpha1.Succeeded || vmClone.Status.Phase == clonev1alpha1.Failed)
+}
+
+func (ctrl *VMCloneController) updateVMClone(vmClone *clonev1alpha1.VirtualMachineClone) error {
+	log.Log.V(3).Infof("Updating VirtualMachineClone %s/%s", vmClone.Namespace, vmClone.Name)
+
+	if vmClone.DeletionTimestamp != nil || vmCloneFinished(vmClone) {
+		return nil
+	}
+
+	vmCloneCpy := vmClone.DeepCopy()
+	if vmCloneCpy.Status == nil {
+		vmCloneCpy.Status = &clonev1alpha1.VirtualMachineCloneStatus{}
+	}
+
+	restore, err := ctrl.getRestore(vmCloneCpy.Namespace, restoreName(vmCloneCpy))
+	if err != nil {
+		return err
+	}
+
+	// the snapshot is only needed until the restore is created
+	if restore == nil {
+		snapshot, err := ctrl.getOrCreateSnapshot(vmCloneCpy)
+		if err != nil {
+			return err
+		}
+
+		if snapshot == nil {
+			return ctrl.fail(vmClone, vmCloneCpy, reasonSourceNotFound, fmt.Sprintf("%s %s does not exist", vmClone.Spec.Source.Kind, vmClone.Spec.Source.Name))
+		}
+
+		if !vmSnapshotReady(snapshot) {
+			message := "waiting for the snapshot to become ready"
+			if snapshot.Status != nil && snapshot.Status.Error != nil && snapshot.Status.Error.Message != nil {
+				message = *snapshot.Status.Error.Message
+			}
+			return ctrl.progress(vmClone, vmCloneCpy, clonev1alpha1.SnapshotInProgress, reasonSnapshotInProgress, message)
+		}
+
+		content, err := ctrl.getSnapshotContent(snapshot)
+		if err != nil {
+			return err
+		}
+
+		sourceVM := content.Spec.Source.VirtualMachine
+		if sourceVM == nil {
+			return fmt.Errorf("VirtualMachineSnapshotContent %s has no VirtualMachine", content.Name)
+		}
+
+		name := targetName(vmCloneCpy, sourceVM.Name)
+		target, err := ctrl.getVM(vmCloneCpy.Namespace, name)
+		if err != nil {
+			return err
+		}
+
+		if target != nil {
+			return ctrl.fail(vmClone, vmCloneCpy, reasonTargetExists, fmt.Sprintf("VirtualMachine %s already exists", target.Name))
+		}
+
+		if restore, err = ctrl.createRestore(vmCloneCpy, snapshot.Name, sourceVM, name); err != nil {
+			return err
+		}
+	}
+
+	targetName := restore.Spec.Target.Name
+	vmCloneCpy.Status.TargetName = &targetName
+	vmCloneCpy.Status.RestoreName = &restore.Name
+	vmCloneCpy.Status.SnapshotName = &restore.Spec.VirtualMachineSnapshotName
+
+	if restore.Status == nil || restore.Status.Complete == nil || !*restore.Status.Complete {
+		return ctrl.progress(vmClone, vmCloneCpy, clonev1alpha1.RestoreInProgress, reasonRestoreInProgress, "waiting for the restore to complete")
+	}
+
+	if vmCloneCpy.Spec.Source.Kind == virtualMachineKind {
+		if err := ctrl.deleteTemporarySnapshot(vmCloneCpy); err != nil {
+			return err
+		}
+	}
+
+	ctrl.Recorder.Eventf(vmCloneCpy, corev1.EventTypeNormal, vmCloneSucceededEvent, "Successfully cloned %s %s into VirtualMachine %s",
+		vmCloneCpy.Spec.Source.Kind, vmCloneCpy.Spec.Source.Name, targetName)
+
+	vmCloneCpy.Status.Phase = clonev1alpha1.Succeeded
+	vmCloneCpy.Status.CreationTime = currentTime()
+	updateCloneConditions(vmCloneCpy,
+		newProgressingCondition(corev1.ConditionFalse, reasonSucceeded, ""),
+		newReadyCondition(corev1.ConditionTrue, reasonSucceeded, ""))
+
+	return ctrl.updateStatus(vmClone, vmCloneCpy)
+}
+
+// getOrCreateSnapshot returns the snapshot the clone is restored from. A
+// temporary snapshot is taken from VirtualMachine sources. Nil is returned
+// if the source does not exist.
+func (ctrl *VMCloneController) getOrCreateSnapshot(vmClone *clonev1alpha1.VirtualMachineClone) (*snapshotv1.VirtualMachineSnapshot, error) {
+	source := vmClone.Spec.Source
+
+	if source.Kind == virtualMachineSnapshotKind {
+		vmClone.Status.SnapshotName = &source.Name
+		return ctrl.getSnapshot(vmClone.Namespace, source.Name)
+	}
+
+	snapshotName := temporarySnapshotName(vmClone)
+	vmClone.Status.SnapshotName = &snapshotName
+
+	snapshot, err := ctrl.getSnapshot(vmClone.Namespace, snapshotName)
+	if err != nil || snapshot != nil {
+		return snapshot, err
+	}
+
+	vm, err := ctrl.getVM(vmClone.Namespace, source.Name)
+	if err != nil || vm == nil {
+		return nil, err
+	}
+
+	apiGroup := v1.GroupName
+	snapshot = &snapshotv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            snapshotName,
+			Namespace:       vmClone.Namespace,
+			OwnerReferences: ownerReferences(vmClone),
+		},
+		Spec: snapshotv1.VirtualMachineSnapshotSpec{
+			Source: corev1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     virtualMachineKind,
+				Name:     vm.Name,
+			},
+		},
+	}
+
+	snapshot, err = ctrl.Client.VirtualMachineSnapshot(vmClone.Namespace).Create(context.Background(), snapshot, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ctrl.Recorder.Eventf(vmClone, corev1.EventTypeNormal, snapshotCreatedEvent, "Created VirtualMachineSnapshot %s", snapshot.Name)
+
+	return snapshot, nil
+}
+
+func (ctrl *VMCloneController) createRestore(vmClone *clonev1alpha1.VirtualMachineClone, snapshotName string, sourceVM *v1.VirtualMachine, targetName string) (*snapshotv1.VirtualMachineRestore, error) {
+	patches, err := generatePatches(sourceVM, &vmClone.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	apiGroup := v1.GroupName
+	restore := &snapshotv1.VirtualMachineRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            restoreName(vmClone),
+			Namespace:       vmClone.Namespace,
+			OwnerReferences: ownerReferences(vmClone),
+		},
+		Spec: snapshotv1.VirtualMachineRestoreSpec{
+			Target: corev1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     virtualMachineKind,
+				Name:     targetName,
+			},
+			VirtualMachineSnapshotName: snapshotName,
+			Patches:                    patches,
+		},
+	}
+
+	restore, err = ctrl.Client.VirtualMachineRestore(vmClone.Namespace).Create(context.Background(), restore, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ctrl.Recorder.Eventf(vmClone, corev1.EventTypeNormal, restoreCreatedEvent, "Created VirtualMachineRestore %s", restore.Name)
+
+	return restore, nil
+}
+
+// targetName returns the name of the VirtualMachine to create, a name based
+// on the source VirtualMachine is generated once if the clone does not specify one
+func targetName(vmClone *clonev1alpha1.VirtualMachineClone, sourceName string) string {
+	if vmClone.Spec.Target != nil && vmClone.Spec.Target.Name != "" {
+		return vmClone.Spec.Target.Name
+	}
+
+	if vmClone.Status.TargetName == nil {
+		name := fmt.Sprintf("%s-clone-%s", sourceName, rand.String(5))
+		vmClone.Status.TargetName = &name
+	}
+
+	return *vmClone.Status.TargetName
+}
+
+// generatePatches returns the JSON patch operations turning the snapshotted
+// VirtualMachine into the clone
+func generatePatches(sourceVM *v1.VirtualMachine, spec *clonev1alpha1.VirtualMachineCloneSpec) ([]string, error) {
+	var ops []patchOperation
+
+	ops = append(ops,
+		patchOperation{Op: "add", Path: "/metadata/labels", Value: filterMap(sourceVM.Labels, spec.LabelFilters)},
+		patchOperation{Op: "add", Path: "/metadata/annotations", Value: filterMap(sourceVM.Annotations, spec.AnnotationFilters)},
+	)
+
+	if sourceVM.Spec.Template == nil {
+		return marshalPatches(ops)
+	}
+
+	domain := sourceVM.Spec.Template.Spec.Domain
+	for i, iface := range domain.Devices.Interfaces {
+		path := fmt.Sprintf("/spec/template/spec/domain/devices/interfaces/%d/macAddress", i)
+		if mac, ok := spec.NewMacAddresses[iface.Name]; ok {
+			ops = append(ops, patchOperation{Op: "add", Path: path, Value: mac})
+		} else if iface.MacAddress != "" {
+			// an empty MAC address is assigned by the cluster
+			ops = append(ops, patchOperation{Op: "remove", Path: path})
+		}
+	}
+
+	if domain.Firmware == nil {
+		if spec.NewSMBiosSerial != nil {
+			ops = append(ops, patchOperation{Op: "add", Path: "/spec/template/spec/domain/firmware", Value: &v1.Firmware{Serial: *spec.NewSMBiosSerial}})
+		}
+		return marshalPatches(ops)
+	}
+
+	// without UUID the firmware UUID is derived from the name of the clone
+	if domain.Firmware.UUID != "" {
+		ops = append(ops, patchOperation{Op: "remove", Path: "/spec/template/spec/domain/firmware/uuid"})
+	}
+
+	if spec.NewSMBiosSerial != nil {
+		ops = append(ops, patchOperation{Op: "add", Path: "/spec/template/spec/domain/firmware/serial", Value: *spec.NewSMBiosSerial})
+	} else if domain.Firmware.Serial != "" {
+		ops = append(ops, patchOperation{Op: "remove", Path: "/spec/template/spec/domain/firmware/serial"})
+	}
+
+	return marshalPatches(ops)
+}
+
+func marshalPatches(ops []patchOperation) ([]string, error) {
+	var patches []string
+	for _, op := range ops {
+		patch, err := json.Marshal(op)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, string(patch))
+	}
+
+	return patches, nil
+}
+
+// filterMap returns the entries of m whose key is selected by filters
+func filterMap(m map[string]string, filters []string) map[string]string {
+	filtered := map[string]string{}
+	for key, value := range m {
+		if matchFilters(key, filters) {
+			filtered[key] = value
+		}
+	}
+
+	return filtered
+}
+
+// matchFilters returns true if the last filter matching key is not negated.
+// Every key matches an empty filter list.
+func matchFilters(key string, filters []string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	match := false
+	for _, filter := range filters {
+		negated := strings.HasPrefix(filter, "!")
+		pattern := strings.TrimPrefix(filter, "!")
+		if matchWildcard(pattern, key) {
+			match = !negated
+		}
+	}
+
+	return match
+}
+
+func matchWildcard(pattern, s string) bool {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	return regexp.MustCompile("^" + expr + "$").MatchString(s)
+}
+
+func ownerReferences(vmClone *clonev1alpha1.VirtualMachineClone) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		*metav1.NewControllerRef(vmClone, clonev1alpha1.SchemeGroupVersion.WithKind(vmCloneKind)),
+	}
+}
+
+func (ctrl *VMCloneController) deleteTemporarySnapshot(vmClone *clonev1alpha1.VirtualMachineClone) error {
+	err := ctrl.Client.VirtualMachineSnapshot(vmClone.Namespace).Delete(context.Background(), temporarySnapshotName(vmClone), metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (ctrl *VMCloneController) getVM(namespace, name string) (*v1.VirtualMachine, error) {
+	obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*v1.VirtualMachine).DeepCopy(), nil
+}
+
+func (ctrl *VMCloneController) getSnapshot(namespace, name string) (*snapshotv1.VirtualMachineSnapshot, error) {
+	obj, exists, err := ctrl.VMSnapshotInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*snapshotv1.VirtualMachineSnapshot).DeepCopy(), nil
+}
+
+func (ctrl *VMCloneController) getRestore(namespace, name string) (*snapshotv1.VirtualMachineRestore, error) {
+	obj, exists, err := ctrl.VMRestoreInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*snapshotv1.VirtualMachineRestore).DeepCopy(), nil
+}
+
+func (ctrl *VMCloneController) getSnapshotContent(snapshot *snapshotv1.VirtualMachineSnapshot) (*snapshotv1.VirtualMachineSnapshotContent, error) {
+	if snapshot.Status == nil || snapshot.Status.VirtualMachineSnapshotContentName == nil {
+		return nil, fmt.Errorf("no snapshot content name in %s/%s", snapshot.Namespace, snapshot.Name)
+	}
+
+	objKey := cacheKeyFunc(snapshot.Namespace, *snapshot.Status.VirtualMachineSnapshotContentName)
+	obj, exists, err := ctrl.VMSnapshotContentInformer.GetStore().GetByKey(objKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("VirtualMachineSnapshotContent %s does not exist", objKey)
+	}
+
+	return obj.(*snapshotv1.VirtualMachineSnapshotContent).DeepCopy(), nil
+}
+
+func vmSnapshotReady(snapshot *snapshotv1.VirtualMachineSnapshot) bool {
+	return snapshot.Status != nil && snapshot.Status.ReadyToUse != nil && *snapshot.Status.ReadyToUse
+}
+
+func (ctrl *VMCloneController) progress(vmClone, vmCloneCpy *clonev1alpha1.VirtualMachineClone, phase clonev1alpha1.VirtualMachineClonePhase, reason, message string) error {
+	vmCloneCpy.Status.Phase = phase
+	updateCloneConditions(vmCloneCpy,
+		newProgressingCondition(corev1.ConditionTrue, reason, message),
+		newReadyCondition(corev1.ConditionFalse, reason, message))
+
+	return ctrl.updateStatus(vmClone, vmCloneCpy)
+}
+
+func (ctrl *VMCloneController) fail(vmClone, vmCloneCpy *clonev1alpha1.VirtualMachineClone, reason, message string) error {
+	ctrl.Recorder.Event(vmCloneCpy, corev1.EventTypeWarning, vmCloneFailedEvent, message)
+
+	vmCloneCpy.Status.Phase = clonev1alpha1.Failed
+	updateCloneConditions(vmCloneCpy,
+		newProgressingCondition(corev1.ConditionFalse, reason, message),
+		newReadyCondition(corev1.ConditionFalse, reason, message))
+
+	return ctrl.updateStatus(vmClone, vmCloneCpy)
+}
+
+func (ctrl *VMCloneController) updateStatus(vmClone, vmCloneCpy *clonev1alpha1.VirtualMachineClone) error {
+	if reflect.DeepEqual(vmClone, vmCloneCpy) {
+		return nil
+	}
+
+	_, err := ctrl.Client.VirtualMachineClone(vmCloneCpy.Namespace).Update(context.Background(), vmCloneCpy, metav1.UpdateOptions{})
+	return err
+}
+
+func newReadyCondition(status corev1.ConditionStatus, reason, message string) clonev1alpha1.VirtualMachineCloneCondition {
+	return clonev1alpha1.VirtualMachineCloneCondition{
+		Type:               clonev1alpha1.ConditionReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: *currentTime(),
+	}
+}
+
+func newProgressingCondition(status corev1.ConditionStatus, reason, message string) clonev1alpha1.VirtualMachineCloneCondition {
+	return clonev1alpha1.VirtualMachineCloneCondition{
+		Type:               clonev1alpha1.ConditionProgressing,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: *currentTime(),
+	}
+}
+
+func updateCloneConditions(vmClone *clonev1alpha1.VirtualMachineClone, conditions ...clonev1alpha1.VirtualMachineCloneCondition) {
+	for _, c := range conditions {
+		found := false
+		for i := range vmClone.Status.Conditions {
+			if vmClone.Status.Conditions[i].Type == c.Type {
+				if vmClone.Status.Conditions[i].Status != c.Status || vmClone.Status.Conditions[i].Reason != c.Reason || vmClone.Status.Conditions[i].Message != c.Message {
+					vmClone.Status.Conditions[i] = c
+				}
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			vmClone.Status.Conditions = append(vmClone.Status.Conditions, c)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package clone
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+)
+
+// VMCloneController is responsible for cloning VirtualMachines
+type VMCloneController struct {
+	Client kubecli.KubevirtClient
+
+	VMCloneInformer           cache.SharedIndexInformer
+	VMSnapshotInformer        cache.SharedIndexInformer
+	VMSnapshotContentInformer cache.SharedIndexInformer
+	VMRestoreInformer         cache.SharedIndexInformer
+	VMInformer                cache.SharedIndexInformer
+
+	Recorder record.EventRecorder
+
+	vmCloneQueue workqueue.RateLimitingInterface
+}
+
+// Init initializes the clone controller
+func (ctrl *VMCloneController) Init() {
+	ctrl.vmCloneQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "clone-controller-vmclone")
+
+	ctrl.VMCloneInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMClone,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMClone(newObj) },
+		},
+	)
+
+	ctrl.VMSnapshotInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleOwned,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleOwned(newObj) },
+		},
+	)
+
+	ctrl.VMRestoreInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleOwned,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleOwned(newObj) },
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *VMCloneController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmCloneQueue.ShutDown()
+
+	log.Log.Info("Starting clone controller.")
+	defer log.Log.Info("Shutting down clone controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMCloneInformer.HasSynced,
+		ctrl.VMSnapshotInformer.HasSynced,
+		ctrl.VMSnapshotContentInformer.HasSynced,
+		ctrl.VMRestoreInformer.HasSynced,
+		ctrl.VMInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmCloneWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *VMCloneController) vmCloneWorker() {
+	for ctrl.processVMCloneWorkItem() {
+	}
+}
+
+func (ctrl *VMCloneController) processVMCloneWorkItem() bool {
+	obj, shutdown := ctrl.vmCloneQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer ctrl.vmCloneQueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		ctrl.vmCloneQueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	log.Log.V(3).Infof("vmClone worker processing key [%s]", key)
+
+	if err := ctrl.execute(key); err != nil {
+		utilruntime.HandleError(err)
+		ctrl.vmCloneQueue.AddRateLimited(key)
+		return true
+	}
+
+	ctrl.vmCloneQueue.Forget(obj)
+	return true
+}
+
+func (ctrl *VMCloneController) execute(key string) error {
+	storeObj, exists, err := ctrl.VMCloneInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	vmClone, ok := storeObj.(*clonev1alpha1.VirtualMachineClone)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateVMClone(vmClone.DeepCopy())
+}
+
+func (ctrl *VMCloneController) handleVMClone(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmClone, ok := obj.(*clonev1alpha1.VirtualMachineClone); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(vmClone)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, vmClone)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmCloneQueue.Add(objName)
+	}
+}
+
+// handleOwned enqueues the VirtualMachineClone owning a snapshot or restore
+func (ctrl *VMCloneController) handleOwned(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	o, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+
+	if owner := metav1.GetControllerOf(o); owner != nil && owner.Kind == vmCloneKind {
+		ctrl.vmCloneQueue.Add(cacheKeyFunc(o.GetNamespace(), owner.Name))
+	}
+}
+
+func cacheKeyFunc(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
@@ -0,0 +1,373 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package clone
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+const (
+	testNamespace = "default"
+	cloneName     = "clone"
+)
+
+var _ = Describe("Clone controller", func() {
+	var (
+		ctrl                      *gomock.Controller
+		controller                *VMCloneController
+		vmCloneInformer           cache.SharedIndexInformer
+		vmSnapshotInformer        cache.SharedIndexInformer
+		vmSnapshotContentInformer cache.SharedIndexInformer
+		vmRestoreInformer         cache.SharedIndexInformer
+		vmInformer                cache.SharedIndexInformer
+		kubevirtClient            *kubevirtfake.Clientset
+		recorder                  *record.FakeRecorder
+	)
+
+	kubevirtGroup := v1.GroupName
+	snapshotGroup := snapshotv1.SchemeGroupVersion.Group
+	t := true
+
+	newVMClone := func(apiGroup *string, kind, name string) *clonev1alpha1.VirtualMachineClone {
+		return &clonev1alpha1.VirtualMachineClone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cloneName,
+				Namespace: testNamespace,
+				UID:       "clone-uid",
+			},
+			Spec: clonev1alpha1.VirtualMachineCloneSpec{
+				Source: &corev1.TypedLocalObjectReference{
+					APIGroup: apiGroup,
+					Kind:     kind,
+					Name:     name,
+				},
+				Target: &corev1.TypedLocalObjectReference{
+					APIGroup: &kubevirtGroup,
+					Kind:     "VirtualMachine",
+					Name:     "vm-clone",
+				},
+			},
+		}
+	}
+
+	newVM := func() *v1.VirtualMachine {
+		return &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vm",
+				Namespace: testNamespace,
+				UID:       "vm-uid",
+				Labels: map[string]string{
+					"app":                "demo",
+					"kubevirt.io/domain": "vm",
+				},
+				Annotations: map[string]string{
+					"description": "demo",
+				},
+			},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Firmware: &v1.Firmware{
+								UUID:   "5d307ca9-b3ef-428c-8861-06e72d69f223",
+								Serial: "serial",
+							},
+							Devices: v1.Devices{
+								Interfaces: []v1.Interface{
+									{Name: "default", MacAddress: "de:ad:00:00:be:ef"},
+									{Name: "secondary", MacAddress: "de:ad:00:00:be:f0"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newSnapshot := func(name string, ready bool) *snapshotv1.VirtualMachineSnapshot {
+		contentName := "content-" + name
+		return &snapshotv1.VirtualMachineSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+			},
+			Status: &snapshotv1.VirtualMachineSnapshotStatus{
+				ReadyToUse:                        &ready,
+				VirtualMachineSnapshotContentName: &contentName,
+			},
+		}
+	}
+
+	addSnapshotContent := func(snapshot *snapshotv1.VirtualMachineSnapshot) {
+		content := &snapshotv1.VirtualMachineSnapshotContent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      *snapshot.Status.VirtualMachineSnapshotContentName,
+				Namespace: testNamespace,
+			},
+			Spec: snapshotv1.VirtualMachineSnapshotContentSpec{
+				Source: snapshotv1.SourceSpec{
+					VirtualMachine: newVM(),
+				},
+			},
+		}
+		Expect(vmSnapshotContentInformer.GetStore().Add(content)).To(Succeed())
+	}
+
+	addVMClone := func(vmClone *clonev1alpha1.VirtualMachineClone) {
+		Expect(vmCloneInformer.GetStore().Add(vmClone)).To(Succeed())
+		_, err := kubevirtClient.CloneV1alpha1().VirtualMachineClones(testNamespace).Create(context.Background(), vmClone, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	sync := func() *clonev1alpha1.VirtualMachineClone {
+		Expect(controller.execute(cacheKeyFunc(testNamespace, cloneName))).To(Succeed())
+		vmClone, err := kubevirtClient.CloneV1alpha1().VirtualMachineClones(testNamespace).Get(context.Background(), cloneName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return vmClone
+	}
+
+	getRestore := func() *snapshotv1.VirtualMachineRestore {
+		restore, err := kubevirtClient.SnapshotV1alpha1().VirtualMachineRestores(testNamespace).Get(context.Background(), "tmp-restore-clone-uid", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return restore
+	}
+
+	applyPatches := func(vm *v1.VirtualMachine, patches []string) *v1.VirtualMachine {
+		patch, err := jsonpatch.DecodePatch([]byte("[" + strings.Join(patches, ",") + "]"))
+		Expect(err).ToNot(HaveOccurred())
+		vmBytes, err := json.Marshal(vm)
+		Expect(err).ToNot(HaveOccurred())
+		patchedBytes, err := patch.Apply(vmBytes)
+		Expect(err).ToNot(HaveOccurred())
+		patchedVM := &v1.VirtualMachine{}
+		Expect(json.Unmarshal(patchedBytes, patchedVM)).To(Succeed())
+		return patchedVM
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+
+		vmCloneInformer, _ = testutils.NewFakeInformerFor(&clonev1alpha1.VirtualMachineClone{})
+		vmSnapshotInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshot{})
+		vmSnapshotContentInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotContent{})
+		vmRestoreInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = &VMCloneController{
+			Client:                    virtClient,
+			VMCloneInformer:           vmCloneInformer,
+			VMSnapshotInformer:        vmSnapshotInformer,
+			VMSnapshotContentInformer: vmSnapshotContentInformer,
+			VMRestoreInformer:         vmRestoreInformer,
+			VMInformer:                vmInformer,
+			Recorder:                  recorder,
+		}
+		controller.Init()
+
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+		virtClient.EXPECT().VirtualMachineClone(testNamespace).
+			Return(kubevirtClient.CloneV1alpha1().VirtualMachineClones(testNamespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineSnapshot(testNamespace).
+			Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots(testNamespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineRestore(testNamespace).
+			Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineRestores(testNamespace)).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("with a VirtualMachine source", func() {
+		BeforeEach(func() {
+			addVMClone(newVMClone(&kubevirtGroup, "VirtualMachine", "vm"))
+		})
+
+		It("should fail if the VirtualMachine does not exist", func() {
+			vmClone := sync()
+			Expect(vmClone.Status.Phase).To(Equal(clonev1alpha1.Failed))
+			testutils.ExpectEvent(recorder, vmCloneFailedEvent)
+		})
+
+		It("should create a temporary snapshot owned by the clone", func() {
+			Expect(vmInformer.GetStore().Add(newVM())).To(Succeed())
+
+			vmClone := sync()
+			Expect(vmClone.Status.Phase).To(Equal(clonev1alpha1.SnapshotInProgress))
+			Expect(*vmClone.Status.SnapshotName).To(Equal("tmp-snapshot-clone-uid"))
+
+			snapshot, err := kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots(testNamespace).Get(context.Background(), "tmp-snapshot-clone-uid", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshot.Spec.Source.Name).To(Equal("vm"))
+			Expect(metav1.GetControllerOf(snapshot).UID).To(Equal(vmClone.UID))
+			testutils.ExpectEvent(recorder, snapshotCreatedEvent)
+		})
+
+		It("should create a restore once the snapshot is ready", func() {
+			snapshot := newSnapshot("tmp-snapshot-clone-uid", true)
+			Expect(vmSnapshotInformer.GetStore().Add(snapshot)).To(Succeed())
+			addSnapshotContent(snapshot)
+
+			vmClone := sync()
+			Expect(vmClone.Status.Phase).To(Equal(clonev1alpha1.RestoreInProgress))
+			Expect(*vmClone.Status.TargetName).To(Equal("vm-clone"))
+
+			restore := getRestore()
+			Expect(restore.Spec.Target.Name).To(Equal("vm-clone"))
+			Expect(restore.Spec.VirtualMachineSnapshotName).To(Equal(snapshot.Name))
+			Expect(metav1.GetControllerOf(restore).Kind).To(Equal(vmCloneKind))
+			testutils.ExpectEvent(recorder, restoreCreatedEvent)
+		})
+
+		It("should fail if the target already exists", func() {
+			snapshot := newSnapshot("tmp-snapshot-clone-uid", true)
+			Expect(vmSnapshotInformer.GetStore().Add(snapshot)).To(Succeed())
+			addSnapshotContent(snapshot)
+			target := newVM()
+			target.Name = "vm-clone"
+			Expect(vmInformer.GetStore().Add(target)).To(Succeed())
+
+			vmClone := sync()
+			Expect(vmClone.Status.Phase).To(Equal(clonev1alpha1.Failed))
+			Expect(vmClone.Status.Conditions[1].Reason).To(Equal(reasonTargetExists))
+		})
+
+		It("should succeed and delete the temporary snapshot once the restore completes", func() {
+			snapshot := newSnapshot("tmp-snapshot-clone-uid", true)
+			_, err := kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots(testNamespace).Create(context.Background(), snapshot, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmRestoreInformer.GetStore().Add(&snapshotv1.VirtualMachineRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tmp-restore-clone-uid",
+					Namespace: testNamespace,
+				},
+				Spec: snapshotv1.VirtualMachineRestoreSpec{
+					Target: corev1.TypedLocalObjectReference{
+						APIGroup: &kubevirtGroup,
+						Kind:     "VirtualMachine",
+						Name:     "vm-clone",
+					},
+					VirtualMachineSnapshotName: snapshot.Name,
+				},
+				Status: &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &t,
+				},
+			})).To(Succeed())
+
+			vmClone := sync()
+			Expect(vmClone.Status.Phase).To(Equal(clonev1alpha1.Succeeded))
+			Expect(vmClone.Status.CreationTime).ToNot(BeNil())
+
+			_, err = kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots(testNamespace).Get(context.Background(), snapshot.Name, metav1.GetOptions{})
+			Expect(err).To(HaveOccurred())
+			testutils.ExpectEvent(recorder, vmCloneSucceededEvent)
+		})
+	})
+
+	Context("with a VirtualMachineSnapshot source", func() {
+		It("should fail if the snapshot does not exist", func() {
+			addVMClone(newVMClone(&snapshotGroup, "VirtualMachineSnapshot", "snapshot"))
+
+			vmClone := sync()
+			Expect(vmClone.Status.Phase).To(Equal(clonev1alpha1.Failed))
+			Expect(vmClone.Status.Conditions[1].Reason).To(Equal(reasonSourceNotFound))
+		})
+
+		It("should wait for the snapshot to become ready", func() {
+			addVMClone(newVMClone(&snapshotGroup, "VirtualMachineSnapshot", "snapshot"))
+			Expect(vmSnapshotInformer.GetStore().Add(newSnapshot("snapshot", false))).To(Succeed())
+
+			vmClone := sync()
+			Expect(vmClone.Status.Phase).To(Equal(clonev1alpha1.SnapshotInProgress))
+			Expect(*vmClone.Status.SnapshotName).To(Equal("snapshot"))
+		})
+
+		It("should generate a target name and new identifiers", func() {
+			vmClone := newVMClone(&snapshotGroup, "VirtualMachineSnapshot", "snapshot")
+			vmClone.Spec.Target = nil
+			vmClone.Spec.LabelFilters = []string{"*", "!kubevirt.io/*"}
+			vmClone.Spec.AnnotationFilters = []string{"other"}
+			vmClone.Spec.NewMacAddresses = map[string]string{"default": "de:ad:00:00:00:01"}
+			addVMClone(vmClone)
+			snapshot := newSnapshot("snapshot", true)
+			Expect(vmSnapshotInformer.GetStore().Add(snapshot)).To(Succeed())
+			addSnapshotContent(snapshot)
+
+			vmClone = sync()
+			Expect(*vmClone.Status.TargetName).To(HavePrefix("vm-clone-"))
+
+			restore := getRestore()
+			Expect(restore.Spec.Target.Name).To(Equal(*vmClone.Status.TargetName))
+
+			clonedVM := applyPatches(newVM(), restore.Spec.Patches)
+			Expect(clonedVM.Labels).To(Equal(map[string]string{"app": "demo"}))
+			Expect(clonedVM.Annotations).To(BeEmpty())
+			interfaces := clonedVM.Spec.Template.Spec.Domain.Devices.Interfaces
+			Expect(interfaces[0].MacAddress).To(Equal("de:ad:00:00:00:01"))
+			Expect(interfaces[1].MacAddress).To(BeEmpty())
+			Expect(clonedVM.Spec.Template.Spec.Domain.Firmware.UUID).To(BeEmpty())
+			Expect(clonedVM.Spec.Template.Spec.Domain.Firmware.Serial).To(BeEmpty())
+		})
+	})
+
+	table.DescribeTable("filters", func(key string, filters []string, expected bool) {
+		Expect(matchFilters(key, filters)).To(Equal(expected))
+	},
+		table.Entry("match all without filters", "app", nil, true),
+		table.Entry("match exact key", "app", []string{"app"}, true),
+		table.Entry("not match other key", "app", []string{"other"}, false),
+		table.Entry("match wildcard", "kubevirt.io/domain", []string{"kubevirt.io/*"}, true),
+		table.Entry("not match negated key", "app", []string{"*", "!app"}, false),
+		table.Entry("let the last match win", "app", []string{"!app", "a*"}, true),
+	)
+
+	It("should set a new serial on VMs without firmware", func() {
+		vm := newVM()
+		vm.Spec.Template.Spec.Domain.Firmware = nil
+		serial := "new-serial"
+
+		patches, err := generatePatches(vm, &clonev1alpha1.VirtualMachineCloneSpec{NewSMBiosSerial: &serial})
+		Expect(err).ToNot(HaveOccurred())
+
+		clonedVM := applyPatches(vm, patches)
+		Expect(clonedVM.Spec.Template.Spec.Domain.Firmware.Serial).To(Equal(serial))
+	})
+})
@@ -0,0 +1,720 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/yaml"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/certificates/triple"
+	certutil "kubevirt.io/kubevirt/pkg/certificates/triple/cert"
+	exportserver "kubevirt.io/kubevirt/pkg/virt-exportserver"
+)
+
+const (
+	vmExportKind = "VirtualMachineExport"
+
+	exporterPrefix = "virt-export"
+	exporterApp    = "virt-exporter"
+	// exportNameLabel selects the export server pod of a VirtualMachineExport
+	exportNameLabel = "export.kubevirt.io/export-name"
+
+	exportServerPort   = 8443
+	exportServicePort  = 443
+	exportCertDuration = 30 * 24 * time.Hour
+	// defaultTTLDuration limits how long an export is served if the
+	// VirtualMachineExport does not specify a TTL itself
+	defaultTTLDuration = 2 * time.Hour
+
+	certMountPath     = "/cert"
+	tokenMountPath    = "/token"
+	manifestMountPath = "/manifest"
+	volumeMountPath   = "/export-volumes"
+	deviceMountPath   = "/dev/export-volumes"
+
+	caCertKey     = "ca.crt"
+	tokenKey      = "token"
+	manifestKey   = "virtualmachine.yaml"
+	diskImageName = "disk.img"
+
+	reasonVMNotFound  = "VirtualMachineNotFound"
+	reasonVMRunning   = "VirtualMachineRunning"
+	reasonPVCNotFound = "PVCNotFound"
+	reasonPVCInUse    = "PVCInUse"
+	reasonPodNotReady = "PodNotReady"
+	reasonPodReady    = "PodReady"
+
+	exportServerCreatedEvent = "ExportServerCreated"
+	exportServerDeletedEvent = "ExportServerDeleted"
+	exportExpiredEvent       = "ExportExpired"
+
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// variable so can be overridden in tests
+var currentTime = func() *metav1.Time {
+	t := metav1.Now()
+	return &t
+}
+
+type exportSource struct {
+	vm   *v1.VirtualMachine
+	pvcs []*corev1.PersistentVolumeClaim
+
+	notReadyReason  string
+	notReadyMessage string
+}
+
+func exporterName(vmExport *exportv1alpha1.VirtualMachineExport) string {
+	return fmt.Sprintf("%s-%s", exporterPrefix, vmExport.Name)
+}
+
+func (ctrl *VMExportController) updateVMExport(vmExport *exportv1alpha1.VirtualMachineExport) error {
+	log.Log.V(3).Infof("Updating VirtualMachineExport %s/%s", vmExport.Namespace, vmExport.Name)
+
+	if vmExport.DeletionTimestamp != nil {
+		return nil
+	}
+
+	expiration := ttlExpirationTime(vmExport)
+	remaining := expiration.Sub(currentTime().Time)
+	if remaining <= 0 {
+		return ctrl.expireVMExport(vmExport)
+	}
+	// sync again once the export expired
+	ctrl.vmExportQueue.AddAfter(cacheKeyFunc(vmExport.Namespace, vmExport.Name), remaining)
+
+	source, err := ctrl.getExportSource(vmExport)
+	if err != nil {
+		return err
+	}
+
+	if source.notReadyReason != "" {
+		// the source is not exportable (anymore), stop serving it
+		if err := ctrl.deleteExporterPod(vmExport); err != nil {
+			return err
+		}
+		return ctrl.updateVMExportStatus(vmExport, exportv1alpha1.Pending, "", nil,
+			newReadyCondition(corev1.ConditionFalse, source.notReadyReason, source.notReadyMessage))
+	}
+
+	caCert, err := ctrl.getOrCreateCertSecret(vmExport)
+	if err != nil {
+		return err
+	}
+
+	service, err := ctrl.getOrCreateService(vmExport)
+	if err != nil {
+		return err
+	}
+
+	if source.vm != nil {
+		if err := ctrl.getOrCreateManifest(vmExport, source.vm); err != nil {
+			return err
+		}
+	}
+
+	pod, err := ctrl.getOrCreateExporterPod(vmExport, source)
+	if err != nil {
+		return err
+	}
+
+	if !isPodReady(pod) {
+		return ctrl.updateVMExportStatus(vmExport, exportv1alpha1.Pending, service.Name, nil,
+			newReadyCondition(corev1.ConditionFalse, reasonPodNotReady, "export server pod is not ready"))
+	}
+
+	return ctrl.updateVMExportStatus(vmExport, exportv1alpha1.Ready, service.Name, getLinks(service, caCert, source),
+		newReadyCondition(corev1.ConditionTrue, reasonPodReady, ""))
+}
+
+func ttlExpirationTime(vmExport *exportv1alpha1.VirtualMachineExport) metav1.Time {
+	ttl := defaultTTLDuration
+	if vmExport.Spec.TTLDuration != nil {
+		ttl = vmExport.Spec.TTLDuration.Duration
+	}
+	return metav1.NewTime(vmExport.CreationTimestamp.Add(ttl))
+}
+
+// expireVMExport stops serving an expired export and deletes it. Everything
+// the controller created is owned by the VirtualMachineExport and is garbage
+// collected together with it. The token secret belongs to the user and is kept.
+func (ctrl *VMExportController) expireVMExport(vmExport *exportv1alpha1.VirtualMachineExport) error {
+	if err := ctrl.deleteExporterPod(vmExport); err != nil {
+		return err
+	}
+
+	err := ctrl.Client.VirtualMachineExport(vmExport.Namespace).Delete(context.Background(), vmExport.Name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	ctrl.Recorder.Eventf(vmExport, corev1.EventTypeNormal, exportExpiredEvent, "VirtualMachineExport %s expired", vmExport.Name)
+	return nil
+}
+
+func (ctrl *VMExportController) getExportSource(vmExport *exportv1alpha1.VirtualMachineExport) (*exportSource, error) {
+	source := &exportSource{}
+	var pvcNames []string
+
+	if vmExport.Spec.Source.Kind == "VirtualMachine" {
+		vmKey := cacheKeyFunc(vmExport.Namespace, vmExport.Spec.Source.Name)
+		obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(vmKey)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			source.notReadyReason = reasonVMNotFound
+			source.notReadyMessage = fmt.Sprintf("VirtualMachine %s does not exist", vmKey)
+			return source, nil
+		}
+		source.vm = obj.(*v1.VirtualMachine)
+
+		_, running, err := ctrl.VMIInformer.GetStore().GetByKey(vmKey)
+		if err != nil {
+			return nil, err
+		}
+		if running {
+			source.notReadyReason = reasonVMRunning
+			source.notReadyMessage = fmt.Sprintf("VirtualMachine %s is running", vmKey)
+			return source, nil
+		}
+
+		pvcNames = volumeClaimNames(source.vm)
+	} else {
+		pvcNames = []string{vmExport.Spec.Source.Name}
+	}
+
+	for _, name := range pvcNames {
+		pvcKey := cacheKeyFunc(vmExport.Namespace, name)
+		obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(pvcKey)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			source.notReadyReason = reasonPVCNotFound
+			source.notReadyMessage = fmt.Sprintf("PersistentVolumeClaim %s does not exist", pvcKey)
+			return source, nil
+		}
+		source.pvcs = append(source.pvcs, obj.(*corev1.PersistentVolumeClaim))
+	}
+
+	pod, err := ctrl.podUsingPVCs(vmExport, pvcNames)
+	if err != nil {
+		return nil, err
+	}
+	if pod != nil {
+		source.notReadyReason = reasonPVCInUse
+		source.notReadyMessage = fmt.Sprintf("pod %s/%s is using the exported volumes", pod.Namespace, pod.Name)
+	}
+
+	return source, nil
+}
+
+// volumeClaimNames returns the PVCs backing the volumes of a VirtualMachine,
+// DataVolumes are backed by a PVC of the same name
+func volumeClaimNames(vm *v1.VirtualMachine) []string {
+	var names []string
+	seen := map[string]bool{}
+
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		name := ""
+		if volume.PersistentVolumeClaim != nil {
+			name = volume.PersistentVolumeClaim.ClaimName
+		} else if volume.DataVolume != nil {
+			name = volume.DataVolume.Name
+		}
+
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// podUsingPVCs returns a pod other than the export server using one of the PVCs
+func (ctrl *VMExportController) podUsingPVCs(vmExport *exportv1alpha1.VirtualMachineExport, pvcNames []string) (*corev1.Pod, error) {
+	if len(pvcNames) == 0 {
+		return nil, nil
+	}
+
+	claims := map[string]bool{}
+	for _, name := range pvcNames {
+		claims[name] = true
+	}
+
+	objs, err := ctrl.PodInformer.GetIndexer().ByIndex(cache.NamespaceIndex, vmExport.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objs {
+		pod := obj.(*corev1.Pod)
+		if owner := metav1.GetControllerOf(pod); owner != nil && owner.UID == vmExport.UID {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && claims[volume.PersistentVolumeClaim.ClaimName] {
+				return pod, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func ownerReferences(vmExport *exportv1alpha1.VirtualMachineExport) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		*metav1.NewControllerRef(vmExport, exportv1alpha1.SchemeGroupVersion.WithKind(vmExportKind)),
+	}
+}
+
+// getOrCreateCertSecret makes sure the export server has a certificate signed by
+// a CA dedicated to the export and returns the PEM encoded CA certificate
+func (ctrl *VMExportController) getOrCreateCertSecret(vmExport *exportv1alpha1.VirtualMachineExport) (string, error) {
+	name := exporterName(vmExport)
+
+	secret, err := ctrl.Client.CoreV1().Secrets(vmExport.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err == nil {
+		return string(secret.Data[caCertKey]), nil
+	}
+	if !errors.IsNotFound(err) {
+		return "", err
+	}
+
+	ca, err := triple.NewCA(name, exportCertDuration)
+	if err != nil {
+		return "", err
+	}
+
+	keyPair, err := triple.NewServerKeyPair(ca, name, name, vmExport.Namespace, "cluster.local", nil, nil, exportCertDuration)
+	if err != nil {
+		return "", err
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       vmExport.Namespace,
+			OwnerReferences: ownerReferences(vmExport),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certutil.EncodeCertPEM(keyPair.Cert),
+			corev1.TLSPrivateKeyKey: certutil.EncodePrivateKeyPEM(keyPair.Key),
+			caCertKey:               certutil.EncodeCertPEM(ca.Cert),
+		},
+	}
+
+	secret, err = ctrl.Client.CoreV1().Secrets(vmExport.Namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret.Data[caCertKey]), nil
+}
+
+func (ctrl *VMExportController) getOrCreateService(vmExport *exportv1alpha1.VirtualMachineExport) (*corev1.Service, error) {
+	name := exporterName(vmExport)
+
+	service, err := ctrl.Client.CoreV1().Services(vmExport.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err == nil || !errors.IsNotFound(err) {
+		return service, err
+	}
+
+	service = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       vmExport.Namespace,
+			OwnerReferences: ownerReferences(vmExport),
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "export",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       exportServicePort,
+					TargetPort: intstr.FromInt(exportServerPort),
+				},
+			},
+			Selector: map[string]string{
+				exportNameLabel: vmExport.Name,
+			},
+		},
+	}
+
+	return ctrl.Client.CoreV1().Services(vmExport.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
+}
+
+func (ctrl *VMExportController) getOrCreateManifest(vmExport *exportv1alpha1.VirtualMachineExport, vm *v1.VirtualMachine) error {
+	name := exporterName(vmExport)
+
+	manifest, err := yaml.Marshal(sanitizeVirtualMachine(vm))
+	if err != nil {
+		return err
+	}
+
+	existing, err := ctrl.Client.CoreV1().ConfigMaps(vmExport.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err == nil {
+		// the VM may have been changed since the manifest was written
+		if existing.Data[manifestKey] == string(manifest) {
+			return nil
+		}
+		existing = existing.DeepCopy()
+		existing.Data = map[string]string{
+			manifestKey: string(manifest),
+		}
+		_, err = ctrl.Client.CoreV1().ConfigMaps(vmExport.Namespace).Update(context.Background(), existing, metav1.UpdateOptions{})
+		return err
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       vmExport.Namespace,
+			OwnerReferences: ownerReferences(vmExport),
+		},
+		Data: map[string]string{
+			manifestKey: string(manifest),
+		},
+	}
+
+	_, err = ctrl.Client.CoreV1().ConfigMaps(vmExport.Namespace).Create(context.Background(), configMap, metav1.CreateOptions{})
+	return err
+}
+
+// sanitizeVirtualMachine strips everything from the VirtualMachine which is
+// specific to the source cluster. DataVolumes are turned into plain PVC
+// volumes, the exported disks have to be imported on the target anyway.
+// Annotations maintained by KubeVirt or kubectl are dropped as well.
+func sanitizeVirtualMachine(vm *v1.VirtualMachine) *v1.VirtualMachine {
+	var annotations map[string]string
+	for key, value := range vm.Annotations {
+		if isInternalAnnotation(key) {
+			continue
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[key] = value
+	}
+
+	exported := &v1.VirtualMachine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.GroupVersion.String(),
+			Kind:       "VirtualMachine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        vm.Name,
+			Labels:      vm.Labels,
+			Annotations: annotations,
+		},
+		Spec: *vm.Spec.DeepCopy(),
+	}
+
+	exported.Spec.DataVolumeTemplates = nil
+	for i, volume := range exported.Spec.Template.Spec.Volumes {
+		if volume.DataVolume != nil {
+			exported.Spec.Template.Spec.Volumes[i].VolumeSource = v1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volume.DataVolume.Name,
+				},
+			}
+		}
+	}
+
+	return exported
+}
+
+// isInternalAnnotation reports annotations of the kubevirt.io domain and its
+// subdomains, and the last applied configuration stored by kubectl
+func isInternalAnnotation(key string) bool {
+	if key == lastAppliedConfigAnnotation {
+		return true
+	}
+	i := strings.Index(key, "/")
+	if i < 0 {
+		return false
+	}
+	domain := key[:i]
+	return domain == v1.GroupName || strings.HasSuffix(domain, "."+v1.GroupName)
+}
+
+func (ctrl *VMExportController) getOrCreateExporterPod(vmExport *exportv1alpha1.VirtualMachineExport, source *exportSource) (*corev1.Pod, error) {
+	obj, exists, err := ctrl.PodInformer.GetStore().GetByKey(cacheKeyFunc(vmExport.Namespace, exporterName(vmExport)))
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return obj.(*corev1.Pod), nil
+	}
+
+	pod, err := ctrl.Client.CoreV1().Pods(vmExport.Namespace).Create(context.Background(), ctrl.newExporterPod(vmExport, source), metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ctrl.Recorder.Eventf(vmExport, corev1.EventTypeNormal, exportServerCreatedEvent, "Created export server pod %s", pod.Name)
+	return pod, nil
+}
+
+func (ctrl *VMExportController) newExporterPod(vmExport *exportv1alpha1.VirtualMachineExport, source *exportSource) *corev1.Pod {
+	name := exporterName(vmExport)
+
+	container := corev1.Container{
+		Name:            "exporter",
+		Image:           ctrl.ExportServerImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"/usr/bin/virt-exportserver"},
+		Args: []string{
+			"--listen", fmt.Sprintf(":%d", exportServerPort),
+			"--cert-file", certMountPath + "/" + corev1.TLSCertKey,
+			"--key-file", certMountPath + "/" + corev1.TLSPrivateKeyKey,
+			"--token-file", tokenMountPath + "/" + tokenKey,
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "export",
+				ContainerPort: exportServerPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   exportserver.HealthzPath,
+					Port:   intstr.FromInt(exportServerPort),
+					Scheme: corev1.URISchemeHTTPS,
+				},
+			},
+			PeriodSeconds: 5,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "cert", MountPath: certMountPath, ReadOnly: true},
+			{Name: "token", MountPath: tokenMountPath, ReadOnly: true},
+		},
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: "cert",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: name},
+			},
+		},
+		{
+			Name: "token",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: vmExport.Spec.TokenSecretRef},
+			},
+		},
+	}
+
+	if source.vm != nil {
+		container.Args = append(container.Args, "--manifest-file", manifestMountPath+"/"+manifestKey)
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: "manifest", MountPath: manifestMountPath, ReadOnly: true})
+		volumes = append(volumes, corev1.Volume{
+			Name: "manifest",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				},
+			},
+		})
+	}
+
+	for i, pvc := range source.pvcs {
+		// PVC names may exceed the length allowed for pod volume names
+		volumeName := fmt.Sprintf("export-volume-%d", i)
+		var path string
+		if pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == corev1.PersistentVolumeBlock {
+			path = fmt.Sprintf("%s/%s", deviceMountPath, pvc.Name)
+			container.VolumeDevices = append(container.VolumeDevices, corev1.VolumeDevice{Name: volumeName, DevicePath: path})
+		} else {
+			mountPath := fmt.Sprintf("%s/%s", volumeMountPath, pvc.Name)
+			path = mountPath + "/" + diskImageName
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: mountPath, ReadOnly: true})
+		}
+		container.Args = append(container.Args, "--volume", fmt.Sprintf("%s=%s", pvc.Name, path))
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvc.Name,
+					ReadOnly:  true,
+				},
+			},
+		})
+	}
+
+	// the export server must not outlive the export, even if the controller
+	// is not around to delete it
+	deadline := int64(math.Ceil(ttlExpirationTime(vmExport).Sub(currentTime().Time).Seconds()))
+	if deadline < 1 {
+		deadline = 1
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: vmExport.Namespace,
+			Labels: map[string]string{
+				v1.AppLabel:     exporterApp,
+				exportNameLabel: vmExport.Name,
+			},
+			OwnerReferences: ownerReferences(vmExport),
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:         corev1.RestartPolicyAlways,
+			ActiveDeadlineSeconds: &deadline,
+			Containers:            []corev1.Container{container},
+			Volumes:               volumes,
+		},
+	}
+
+	if ctrl.ImagePullSecret != "" {
+		pod.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: ctrl.ImagePullSecret}}
+	}
+
+	return pod
+}
+
+func (ctrl *VMExportController) deleteExporterPod(vmExport *exportv1alpha1.VirtualMachineExport) error {
+	name := exporterName(vmExport)
+
+	_, exists, err := ctrl.PodInformer.GetStore().GetByKey(cacheKeyFunc(vmExport.Namespace, name))
+	if err != nil || !exists {
+		return err
+	}
+
+	err = ctrl.Client.CoreV1().Pods(vmExport.Namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	ctrl.Recorder.Eventf(vmExport, corev1.EventTypeNormal, exportServerDeletedEvent, "Deleted export server pod %s", name)
+	return nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func getLinks(service *corev1.Service, caCert string, source *exportSource) *exportv1alpha1.VirtualMachineExportLinks {
+	host := fmt.Sprintf("https://%s.%s.svc", service.Name, service.Namespace)
+	link := &exportv1alpha1.VirtualMachineExportLink{
+		Cert: caCert,
+	}
+
+	for _, pvc := range source.pvcs {
+		link.Volumes = append(link.Volumes, exportv1alpha1.VirtualMachineExportVolume{
+			Name: pvc.Name,
+			Formats: []exportv1alpha1.VirtualMachineExportVolumeFormat{
+				{Format: exportv1alpha1.KubeVirtRaw, Url: host + exportserver.VolumeRawPath(pvc.Name)},
+				{Format: exportv1alpha1.KubeVirtGz, Url: host + exportserver.VolumeGzipPath(pvc.Name)},
+			},
+		})
+	}
+
+	if source.vm != nil {
+		link.Manifests = append(link.Manifests, exportv1alpha1.VirtualMachineExportManifest{
+			Type: exportv1alpha1.VirtualMachineManifest,
+			Url:  host + exportserver.ManifestPath,
+		})
+	}
+
+	return &exportv1alpha1.VirtualMachineExportLinks{Internal: link}
+}
+
+func newReadyCondition(status corev1.ConditionStatus, reason, message string) exportv1alpha1.VirtualMachineExportCondition {
+	return exportv1alpha1.VirtualMachineExportCondition{
+		Type:               exportv1alpha1.ConditionReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: *currentTime(),
+	}
+}
+
+func updateCondition(conditions []exportv1alpha1.VirtualMachineExportCondition, c exportv1alpha1.VirtualMachineExportCondition) []exportv1alpha1.VirtualMachineExportCondition {
+	for i := range conditions {
+		if conditions[i].Type == c.Type {
+			if conditions[i].Status != c.Status || conditions[i].Reason != c.Reason || conditions[i].Message != c.Message {
+				conditions[i] = c
+			}
+			return conditions
+		}
+	}
+
+	return append(conditions, c)
+}
+
+func (ctrl *VMExportController) updateVMExportStatus(vmExport *exportv1alpha1.VirtualMachineExport, phase exportv1alpha1.VirtualMachineExportPhase, serviceName string, links *exportv1alpha1.VirtualMachineExportLinks, condition exportv1alpha1.VirtualMachineExportCondition) error {
+	vmExportCpy := vmExport.DeepCopy()
+	if vmExportCpy.Status == nil {
+		vmExportCpy.Status = &exportv1alpha1.VirtualMachineExportStatus{}
+	}
+
+	expiration := ttlExpirationTime(vmExport)
+	vmExportCpy.Status.Phase = phase
+	vmExportCpy.Status.TTLExpirationTime = &expiration
+	vmExportCpy.Status.ServiceName = serviceName
+	vmExportCpy.Status.Links = links
+	vmExportCpy.Status.Conditions = updateCondition(vmExportCpy.Status.Conditions, condition)
+
+	if reflect.DeepEqual(vmExport, vmExportCpy) {
+		return nil
+	}
+
+	_, err := ctrl.Client.VirtualMachineExport(vmExportCpy.Namespace).Update(context.Background(), vmExportCpy, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,264 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package export
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+)
+
+// VMExportController is responsible for serving VirtualMachineExports
+type VMExportController struct {
+	Client kubecli.KubevirtClient
+
+	VMExportInformer cache.SharedIndexInformer
+	VMInformer       cache.SharedIndexInformer
+	VMIInformer      cache.SharedIndexInformer
+	PVCInformer      cache.SharedIndexInformer
+	PodInformer      cache.SharedIndexInformer
+
+	Recorder record.EventRecorder
+
+	// ExportServerImage is the image providing the virt-exportserver binary
+	ExportServerImage string
+	ImagePullSecret   string
+
+	ResyncPeriod time.Duration
+
+	vmExportQueue workqueue.RateLimitingInterface
+}
+
+// Init initializes the export controller
+func (ctrl *VMExportController) Init() {
+	ctrl.vmExportQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "export-controller-vmexport")
+
+	ctrl.VMExportInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMExport,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMExport(newObj) },
+		},
+		ctrl.ResyncPeriod,
+	)
+
+	ctrl.VMInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVM,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVM(newObj) },
+			DeleteFunc: ctrl.handleVM,
+		},
+	)
+
+	ctrl.VMIInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMI,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMI(newObj) },
+			DeleteFunc: ctrl.handleVMI,
+		},
+	)
+
+	ctrl.PVCInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handlePVC,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handlePVC(newObj) },
+			DeleteFunc: ctrl.handlePVC,
+		},
+	)
+
+	ctrl.PodInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handlePod,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handlePod(newObj) },
+			DeleteFunc: ctrl.handlePod,
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *VMExportController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmExportQueue.ShutDown()
+
+	log.Log.Info("Starting export controller.")
+	defer log.Log.Info("Shutting down export controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMExportInformer.HasSynced,
+		ctrl.VMInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+		ctrl.PVCInformer.HasSynced,
+		ctrl.PodInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmExportWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *VMExportController) vmExportWorker() {
+	for ctrl.processVMExportWorkItem() {
+	}
+}
+
+func (ctrl *VMExportController) processVMExportWorkItem() bool {
+	obj, shutdown := ctrl.vmExportQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer ctrl.vmExportQueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		ctrl.vmExportQueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	log.Log.V(3).Infof("vmExport worker processing key [%s]", key)
+
+	if err := ctrl.execute(key); err != nil {
+		utilruntime.HandleError(err)
+		ctrl.vmExportQueue.AddRateLimited(key)
+		return true
+	}
+
+	ctrl.vmExportQueue.Forget(obj)
+	return true
+}
+
+func (ctrl *VMExportController) execute(key string) error {
+	storeObj, exists, err := ctrl.VMExportInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	vmExport, ok := storeObj.(*exportv1alpha1.VirtualMachineExport)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateVMExport(vmExport.DeepCopy())
+}
+
+func (ctrl *VMExportController) handleVMExport(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmExport, ok := obj.(*exportv1alpha1.VirtualMachineExport); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(vmExport)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, vmExport)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmExportQueue.Add(objName)
+	}
+}
+
+func (ctrl *VMExportController) handleVM(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vm, ok := obj.(*v1.VirtualMachine); ok {
+		ctrl.enqueueIndexed("vm", &vm.ObjectMeta)
+	}
+}
+
+func (ctrl *VMExportController) handleVMI(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	// VMIs share the name of their VM, a running VM blocks its export
+	if vmi, ok := obj.(*v1.VirtualMachineInstance); ok {
+		ctrl.enqueueIndexed("vm", &vmi.ObjectMeta)
+	}
+}
+
+func (ctrl *VMExportController) handlePVC(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
+		ctrl.enqueueIndexed("pvc", &pvc.ObjectMeta)
+	}
+}
+
+func (ctrl *VMExportController) handlePod(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == vmExportKind {
+		ctrl.vmExportQueue.Add(cacheKeyFunc(pod.Namespace, owner.Name))
+		return
+	}
+
+	// Pods using a PVC block its export, requeue exports waiting for them
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			ctrl.enqueueIndexed("pvc", &metav1.ObjectMeta{Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName})
+		}
+	}
+}
+
+func (ctrl *VMExportController) enqueueIndexed(index string, meta *metav1.ObjectMeta) {
+	keys, err := ctrl.VMExportInformer.GetIndexer().IndexKeys(index, cacheKeyFunc(meta.Namespace, meta.Name))
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, k := range keys {
+		ctrl.vmExportQueue.Add(k)
+	}
+}
+
+func cacheKeyFunc(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
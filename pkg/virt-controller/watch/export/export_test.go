@@ -0,0 +1,460 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package export
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/yaml"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	exportserver "kubevirt.io/kubevirt/pkg/virt-exportserver"
+)
+
+const (
+	testNamespace = "default"
+	exportName    = "export"
+)
+
+var _ = Describe("Export controller", func() {
+	var (
+		ctrl             *gomock.Controller
+		controller       *VMExportController
+		vmExportInformer cache.SharedIndexInformer
+		vmInformer       cache.SharedIndexInformer
+		vmiInformer      cache.SharedIndexInformer
+		pvcInformer      cache.SharedIndexInformer
+		podInformer      cache.SharedIndexInformer
+		k8sClient        *k8sfake.Clientset
+		exportClient     *kubevirtfake.Clientset
+		recorder         *record.FakeRecorder
+	)
+
+	kubevirtGroup := v1.GroupName
+
+	newVMExport := func(apiGroup *string, kind, name string) *exportv1alpha1.VirtualMachineExport {
+		return &exportv1alpha1.VirtualMachineExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              exportName,
+				Namespace:         testNamespace,
+				UID:               "export-uid",
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: exportv1alpha1.VirtualMachineExportSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: apiGroup,
+					Kind:     kind,
+					Name:     name,
+				},
+				TokenSecretRef: "token",
+			},
+		}
+	}
+
+	newPVC := func(name string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+			},
+		}
+	}
+
+	newVM := func() *v1.VirtualMachine {
+		return &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "vm",
+				Namespace:       testNamespace,
+				ResourceVersion: "1",
+				UID:             "vm-uid",
+			},
+			Spec: v1.VirtualMachineSpec{
+				DataVolumeTemplates: []v1.DataVolumeTemplateSpec{
+					{ObjectMeta: metav1.ObjectMeta{Name: "dv"}},
+				},
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{
+						Volumes: []v1.Volume{
+							{
+								Name: "disk0",
+								VolumeSource: v1.VolumeSource{
+									DataVolume: &v1.DataVolumeSource{Name: "dv"},
+								},
+							},
+							{
+								Name: "disk1",
+								VolumeSource: v1.VolumeSource{
+									PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+								},
+							},
+							{
+								Name: "cloudinit",
+								VolumeSource: v1.VolumeSource{
+									CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: v1.VirtualMachineStatus{
+				Created: true,
+			},
+		}
+	}
+
+	addVMExport := func(vmExport *exportv1alpha1.VirtualMachineExport) {
+		Expect(vmExportInformer.GetStore().Add(vmExport)).To(Succeed())
+		_, err := exportClient.ExportV1alpha1().VirtualMachineExports(testNamespace).Create(context.Background(), vmExport, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	sync := func() *exportv1alpha1.VirtualMachineExport {
+		Expect(controller.execute(cacheKeyFunc(testNamespace, exportName))).To(Succeed())
+		vmExport, err := exportClient.ExportV1alpha1().VirtualMachineExports(testNamespace).Get(context.Background(), exportName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return vmExport
+	}
+
+	expectCondition := func(vmExport *exportv1alpha1.VirtualMachineExport, status corev1.ConditionStatus, reason string) {
+		Expect(vmExport.Status).ToNot(BeNil())
+		Expect(vmExport.Status.Conditions).To(HaveLen(1))
+		Expect(vmExport.Status.Conditions[0].Type).To(Equal(exportv1alpha1.ConditionReady))
+		Expect(vmExport.Status.Conditions[0].Status).To(Equal(status))
+		Expect(vmExport.Status.Conditions[0].Reason).To(Equal(reason))
+	}
+
+	getExporterPod := func() *corev1.Pod {
+		pod, err := k8sClient.CoreV1().Pods(testNamespace).Get(context.Background(), exporterPrefix+"-"+exportName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return pod
+	}
+
+	markPodReady := func(pod *corev1.Pod) {
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{
+			{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+		}
+		Expect(podInformer.GetStore().Add(pod)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+
+		vmExportInformer, _ = testutils.NewFakeInformerFor(&exportv1alpha1.VirtualMachineExport{})
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		pvcInformer, _ = testutils.NewFakeInformerFor(&corev1.PersistentVolumeClaim{})
+		podInformer, _ = testutils.NewFakeInformerWithIndexersFor(&corev1.Pod{}, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = &VMExportController{
+			Client:            virtClient,
+			VMExportInformer:  vmExportInformer,
+			VMInformer:        vmInformer,
+			VMIInformer:       vmiInformer,
+			PVCInformer:       pvcInformer,
+			PodInformer:       podInformer,
+			Recorder:          recorder,
+			ExportServerImage: "virt-launcher",
+		}
+		controller.Init()
+
+		k8sClient = k8sfake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+
+		exportClient = kubevirtfake.NewSimpleClientset()
+		virtClient.EXPECT().VirtualMachineExport(testNamespace).
+			Return(exportClient.ExportV1alpha1().VirtualMachineExports(testNamespace)).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("with a PersistentVolumeClaim source", func() {
+		BeforeEach(func() {
+			addVMExport(newVMExport(nil, "PersistentVolumeClaim", "pvc"))
+		})
+
+		It("should wait for the PVC to exist", func() {
+			vmExport := sync()
+			Expect(vmExport.Status.Phase).To(Equal(exportv1alpha1.Pending))
+			expectCondition(vmExport, corev1.ConditionFalse, reasonPVCNotFound)
+		})
+
+		It("should wait for other pods to release the PVC", func() {
+			Expect(pvcInformer.GetStore().Add(newPVC("pvc"))).To(Succeed())
+			Expect(podInformer.GetStore().Add(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "importer", Namespace: testNamespace},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+							},
+						},
+					},
+				},
+			})).To(Succeed())
+
+			vmExport := sync()
+			expectCondition(vmExport, corev1.ConditionFalse, reasonPVCInUse)
+		})
+
+		It("should create the export server", func() {
+			Expect(pvcInformer.GetStore().Add(newPVC("pvc"))).To(Succeed())
+
+			vmExport := sync()
+			Expect(vmExport.Status.Phase).To(Equal(exportv1alpha1.Pending))
+			Expect(vmExport.Status.ServiceName).To(Equal("virt-export-export"))
+			Expect(vmExport.Status.TTLExpirationTime).ToNot(BeNil())
+			Expect(vmExport.Status.TTLExpirationTime.Time).To(Equal(vmExport.CreationTimestamp.Add(defaultTTLDuration)))
+			expectCondition(vmExport, corev1.ConditionFalse, reasonPodNotReady)
+			testutils.ExpectEvent(recorder, exportServerCreatedEvent)
+
+			secret, err := k8sClient.CoreV1().Secrets(testNamespace).Get(context.Background(), "virt-export-export", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data).To(HaveKey(corev1.TLSCertKey))
+			Expect(secret.Data).To(HaveKey(corev1.TLSPrivateKeyKey))
+			Expect(secret.Data).To(HaveKey(caCertKey))
+
+			service, err := k8sClient.CoreV1().Services(testNamespace).Get(context.Background(), "virt-export-export", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Spec.Selector).To(HaveKeyWithValue(exportNameLabel, exportName))
+
+			pod := getExporterPod()
+			Expect(pod.Labels).To(HaveKeyWithValue(exportNameLabel, exportName))
+			Expect(pod.OwnerReferences).To(HaveLen(1))
+			Expect(pod.OwnerReferences[0].UID).To(Equal(types.UID("export-uid")))
+			Expect(pod.Spec.Containers[0].Image).To(Equal("virt-launcher"))
+			Expect(pod.Spec.ActiveDeadlineSeconds).ToNot(BeNil())
+			Expect(*pod.Spec.ActiveDeadlineSeconds).To(BeNumerically("~", defaultTTLDuration.Seconds(), 5))
+			Expect(pod.Spec.Containers[0].Args).To(ContainElement("pvc=/export-volumes/pvc/disk.img"))
+			Expect(pod.Spec.Containers[0].Args).ToNot(ContainElement("--manifest-file"))
+			Expect(pod.Spec.Volumes).To(ContainElement(corev1.Volume{
+				Name: "export-volume-0",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc", ReadOnly: true},
+				},
+			}))
+		})
+
+		It("should expose block volumes as devices", func() {
+			pvc := newPVC("pvc")
+			blockMode := corev1.PersistentVolumeBlock
+			pvc.Spec.VolumeMode = &blockMode
+			Expect(pvcInformer.GetStore().Add(pvc)).To(Succeed())
+
+			sync()
+			pod := getExporterPod()
+			Expect(pod.Spec.Containers[0].VolumeDevices).To(Equal([]corev1.VolumeDevice{
+				{Name: "export-volume-0", DevicePath: "/dev/export-volumes/pvc"},
+			}))
+			Expect(pod.Spec.Containers[0].Args).To(ContainElement("pvc=/dev/export-volumes/pvc"))
+		})
+
+		It("should become ready with links once the export server is ready", func() {
+			Expect(pvcInformer.GetStore().Add(newPVC("pvc"))).To(Succeed())
+			sync()
+			markPodReady(getExporterPod())
+
+			vmExport := sync()
+			Expect(vmExport.Status.Phase).To(Equal(exportv1alpha1.Ready))
+			expectCondition(vmExport, corev1.ConditionTrue, reasonPodReady)
+
+			links := vmExport.Status.Links
+			Expect(links).ToNot(BeNil())
+			Expect(links.Internal).ToNot(BeNil())
+			Expect(links.Internal.Cert).To(ContainSubstring("BEGIN CERTIFICATE"))
+			Expect(links.Internal.Manifests).To(BeEmpty())
+			Expect(links.Internal.Volumes).To(Equal([]exportv1alpha1.VirtualMachineExportVolume{
+				{
+					Name: "pvc",
+					Formats: []exportv1alpha1.VirtualMachineExportVolumeFormat{
+						{Format: exportv1alpha1.KubeVirtRaw, Url: "https://virt-export-export.default.svc" + exportserver.VolumeRawPath("pvc")},
+						{Format: exportv1alpha1.KubeVirtGz, Url: "https://virt-export-export.default.svc" + exportserver.VolumeGzipPath("pvc")},
+					},
+				},
+			}))
+		})
+	})
+
+	Context("with an expired export", func() {
+		BeforeEach(func() {
+			vmExport := newVMExport(nil, "PersistentVolumeClaim", "pvc")
+			vmExport.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+			vmExport.Spec.TTLDuration = &metav1.Duration{Duration: time.Minute}
+			addVMExport(vmExport)
+			Expect(pvcInformer.GetStore().Add(newPVC("pvc"))).To(Succeed())
+		})
+
+		It("should delete the export server and the export but keep the token", func() {
+			pod := controller.newExporterPod(newVMExport(nil, "PersistentVolumeClaim", "pvc"), &exportSource{})
+			Expect(podInformer.GetStore().Add(pod)).To(Succeed())
+			_, err := k8sClient.CoreV1().Pods(testNamespace).Create(context.Background(), pod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = k8sClient.CoreV1().Secrets(testNamespace).Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: testNamespace},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(controller.execute(cacheKeyFunc(testNamespace, exportName))).To(Succeed())
+			testutils.ExpectEvents(recorder, exportServerDeletedEvent, exportExpiredEvent)
+
+			_, err = k8sClient.CoreV1().Pods(testNamespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			_, err = k8sClient.CoreV1().Secrets(testNamespace).Get(context.Background(), "token", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = exportClient.ExportV1alpha1().VirtualMachineExports(testNamespace).Get(context.Background(), exportName, metav1.GetOptions{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should not create an export server", func() {
+			Expect(controller.execute(cacheKeyFunc(testNamespace, exportName))).To(Succeed())
+			testutils.ExpectEvent(recorder, exportExpiredEvent)
+
+			pods, err := k8sClient.CoreV1().Pods(testNamespace).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pods.Items).To(BeEmpty())
+		})
+	})
+
+	Context("with a VirtualMachine source", func() {
+		BeforeEach(func() {
+			addVMExport(newVMExport(&kubevirtGroup, "VirtualMachine", "vm"))
+		})
+
+		It("should wait for the VM to exist", func() {
+			vmExport := sync()
+			expectCondition(vmExport, corev1.ConditionFalse, reasonVMNotFound)
+		})
+
+		It("should serve all PVC backed volumes and the manifest", func() {
+			Expect(vmInformer.GetStore().Add(newVM())).To(Succeed())
+			Expect(pvcInformer.GetStore().Add(newPVC("dv"))).To(Succeed())
+			Expect(pvcInformer.GetStore().Add(newPVC("pvc"))).To(Succeed())
+
+			sync()
+			pod := getExporterPod()
+			Expect(pod.Spec.Containers[0].Args).To(ContainElement("dv=/export-volumes/dv/disk.img"))
+			Expect(pod.Spec.Containers[0].Args).To(ContainElement("pvc=/export-volumes/pvc/disk.img"))
+			Expect(pod.Spec.Containers[0].Args).To(ContainElement("--manifest-file"))
+
+			configMap, err := k8sClient.CoreV1().ConfigMaps(testNamespace).Get(context.Background(), "virt-export-export", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			exported := &v1.VirtualMachine{}
+			Expect(yaml.Unmarshal([]byte(configMap.Data[manifestKey]), exported)).To(Succeed())
+			Expect(exported.Name).To(Equal("vm"))
+			Expect(exported.Namespace).To(BeEmpty())
+			Expect(exported.UID).To(BeEmpty())
+			Expect(exported.Status).To(Equal(v1.VirtualMachineStatus{}))
+			Expect(exported.Spec.DataVolumeTemplates).To(BeEmpty())
+			Expect(exported.Spec.Template.Spec.Volumes[0].DataVolume).To(BeNil())
+			Expect(exported.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("dv"))
+
+			markPodReady(pod)
+			vmExport := sync()
+			Expect(vmExport.Status.Phase).To(Equal(exportv1alpha1.Ready))
+			Expect(vmExport.Status.Links.Internal.Volumes).To(HaveLen(2))
+			Expect(vmExport.Status.Links.Internal.Manifests).To(Equal([]exportv1alpha1.VirtualMachineExportManifest{
+				{Type: exportv1alpha1.VirtualMachineManifest, Url: "https://virt-export-export.default.svc" + exportserver.ManifestPath},
+			}))
+		})
+
+		It("should drop internal annotations from the manifest", func() {
+			vm := newVM()
+			vm.Annotations = map[string]string{
+				"kubevirt.io/latest-observed-api-version": "v1",
+				"kubemacpool.io/transaction-timestamp":    "now",
+				"vm.kubevirt.io/validations":              "[]",
+				lastAppliedConfigAnnotation:               "{}",
+				"description":                             "my vm",
+			}
+
+			exported := sanitizeVirtualMachine(vm)
+			Expect(exported.Annotations).To(Equal(map[string]string{
+				"kubemacpool.io/transaction-timestamp": "now",
+				"description":                          "my vm",
+			}))
+		})
+
+		It("should refresh the manifest when the VM changes", func() {
+			vm := newVM()
+			Expect(vmInformer.GetStore().Add(vm)).To(Succeed())
+			Expect(pvcInformer.GetStore().Add(newPVC("dv"))).To(Succeed())
+			Expect(pvcInformer.GetStore().Add(newPVC("pvc"))).To(Succeed())
+			sync()
+			Expect(podInformer.GetStore().Add(getExporterPod())).To(Succeed())
+
+			vm = vm.DeepCopy()
+			vm.Labels = map[string]string{"changed": "true"}
+			Expect(vmInformer.GetStore().Update(vm)).To(Succeed())
+			sync()
+
+			configMap, err := k8sClient.CoreV1().ConfigMaps(testNamespace).Get(context.Background(), "virt-export-export", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			exported := &v1.VirtualMachine{}
+			Expect(yaml.Unmarshal([]byte(configMap.Data[manifestKey]), exported)).To(Succeed())
+			Expect(exported.Labels).To(HaveKeyWithValue("changed", "true"))
+		})
+
+		It("should stop serving when the VM is started", func() {
+			Expect(vmInformer.GetStore().Add(newVM())).To(Succeed())
+			Expect(pvcInformer.GetStore().Add(newPVC("dv"))).To(Succeed())
+			Expect(pvcInformer.GetStore().Add(newPVC("pvc"))).To(Succeed())
+			sync()
+			markPodReady(getExporterPod())
+
+			Expect(vmiInformer.GetStore().Add(&v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: testNamespace},
+			})).To(Succeed())
+
+			vmExport := sync()
+			Expect(vmExport.Status.Phase).To(Equal(exportv1alpha1.Pending))
+			Expect(vmExport.Status.Links).To(BeNil())
+			expectCondition(vmExport, corev1.ConditionFalse, reasonVMRunning)
+			testutils.ExpectEvents(recorder, exportServerCreatedEvent, exportServerDeletedEvent)
+
+			pods, err := k8sClient.CoreV1().Pods(testNamespace).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pods.Items).To(BeEmpty())
+		})
+	})
+})
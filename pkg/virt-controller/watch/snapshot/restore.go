@@ -29,6 +29,7 @@ import (
 	vsv1beta1 "github.com/kubernetes-csi/external-snapshotter/v2/pkg/apis/volumesnapshot/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -36,6 +37,7 @@ import (
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/vmrestore"
 )
 
 const (
@@ -59,9 +61,10 @@ type restoreTarget interface {
 }
 
 type vmRestoreTarget struct {
-	controller *VMRestoreController
-	vmRestore  *snapshotv1.VirtualMachineRestore
-	vm         *kubevirtv1.VirtualMachine
+	controller   *VMRestoreController
+	vmRestore    *snapshotv1.VirtualMachineRestore
+	vm           *kubevirtv1.VirtualMachine
+	cloneRestore bool
 }
 
 var restoreAnnotationsToDelete = []string{
@@ -78,6 +81,10 @@ func restoreDVName(vmRestore *snapshotv1.VirtualMachineRestore, name string) str
 	return restorePVCName(vmRestore, name)
 }
 
+func restoreID(vmRestore *snapshotv1.VirtualMachineRestore) string {
+	return fmt.Sprintf("%s-%s", vmRestore.Name, vmRestore.UID)
+}
+
 func vmRestoreProgressing(vmRestore *snapshotv1.VirtualMachineRestore) bool {
 	return vmRestore.Status == nil || vmRestore.Status.Complete == nil || !*vmRestore.Status.Complete
 }
@@ -297,13 +304,27 @@ func (ctrl *VMRestoreController) getBindingMode(pvc *corev1.PersistentVolumeClai
 	return sc.VolumeBindingMode, nil
 }
 
+// UID returns the UID the snapshot source has to match, VMs created
+// by the restore of a clone accept any source
 func (t *vmRestoreTarget) UID() types.UID {
+	if t.vm == nil || (t.cloneRestore && t.restored()) {
+		return ""
+	}
+
 	return t.vm.UID
 }
 
+func (t *vmRestoreTarget) restored() bool {
+	return t.vm.Annotations[lastRestoreAnnotation] == restoreID(t.vmRestore)
+}
+
 func (t *vmRestoreTarget) Ready() (bool, error) {
 	log.Log.Object(t.vmRestore).V(3).Info("Checking VM ready")
 
+	if t.vm == nil {
+		return true, nil
+	}
+
 	rs, err := t.vm.RunStrategy()
 	if err != nil {
 		return false, err
@@ -329,10 +350,9 @@ func (t *vmRestoreTarget) Ready() (bool, error) {
 func (t *vmRestoreTarget) Reconcile() (bool, error) {
 	log.Log.Object(t.vmRestore).V(3).Info("Reconciling VM")
 
-	restoreID := fmt.Sprintf("%s-%s", t.vmRestore.Name, t.vmRestore.UID)
-
-	if lastRestoreID, ok := t.vm.Annotations[lastRestoreAnnotation]; ok && lastRestoreID == restoreID {
-		return false, nil
+	if t.vm != nil && t.restored() {
+		// the PVCs restored before the VM of a clone was created are not owned yet
+		return false, t.ownRestoredPVCs()
 	}
 
 	content, err := t.controller.getSnapshotContent(t.vmRestore, t.UID())
@@ -435,6 +455,10 @@ func (t *vmRestoreTarget) Reconcile() (bool, error) {
 	}
 
 	if updatedStatus {
+		if t.vm == nil {
+			return true, nil
+		}
+
 		// find DataVolumes that will no longer exist
 		for _, cdv := range t.vm.Spec.DataVolumeTemplates {
 			found := false
@@ -453,16 +477,47 @@ func (t *vmRestoreTarget) Reconcile() (bool, error) {
 		return true, nil
 	}
 
-	newVM := t.vm.DeepCopy()
+	var newVM *kubevirtv1.VirtualMachine
+	if t.vm != nil {
+		newVM = t.vm.DeepCopy()
+	} else {
+		newVM = &kubevirtv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        t.vmRestore.Spec.Target.Name,
+				Namespace:   t.vmRestore.Namespace,
+				Labels:      snapshotVM.Labels,
+				Annotations: snapshotVM.Annotations,
+			},
+		}
+	}
 	newVM.Spec = snapshotVM.Spec
 	newVM.Spec.DataVolumeTemplates = newTemplates
 	newVM.Spec.Template.Spec.Volumes = newVolumes
+
+	if len(t.vmRestore.Spec.Patches) > 0 && !t.cloneRestore {
+		return false, fmt.Errorf("patches are only applied for the restore of a VirtualMachineClone")
+	}
+
+	newVM, err = vmrestore.ApplyPatches(newVM, t.vmRestore.Spec.Patches)
+	if err != nil {
+		return false, err
+	}
+
 	if newVM.Annotations == nil {
 		newVM.Annotations = make(map[string]string)
 	}
-	newVM.Annotations[lastRestoreAnnotation] = restoreID
+	newVM.Annotations[lastRestoreAnnotation] = restoreID(t.vmRestore)
+
+	if t.vm == nil {
+		_, err = t.controller.Client.VirtualMachine(t.vmRestore.Namespace).Create(newVM)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return false, err
+		}
 
-	_, err = t.controller.Client.VirtualMachine(newVM.Namespace).Update(newVM)
+		return true, nil
+	}
+
+	_, err = t.controller.Client.VirtualMachine(t.vmRestore.Namespace).Update(newVM)
 	if err != nil {
 		return false, err
 	}
@@ -471,6 +526,10 @@ func (t *vmRestoreTarget) Reconcile() (bool, error) {
 }
 
 func (t *vmRestoreTarget) Own(obj metav1.Object) {
+	if t.vm == nil {
+		return
+	}
+
 	b := true
 	obj.SetOwnerReferences([]metav1.OwnerReference{
 		{
@@ -484,6 +543,33 @@ func (t *vmRestoreTarget) Own(obj metav1.Object) {
 	})
 }
 
+// ownRestoredPVCs sets the VM as owner of the restored PVCs which are not owned
+// by it or by a DataVolume
+func (t *vmRestoreTarget) ownRestoredPVCs() error {
+	for _, vr := range t.vmRestore.Status.Restores {
+		if vr.DataVolumeName != nil {
+			continue
+		}
+
+		pvc, err := t.controller.getPVC(t.vmRestore.Namespace, vr.PersistentVolumeClaimName)
+		if err != nil {
+			return err
+		}
+
+		if pvc == nil || len(pvc.OwnerReferences) > 0 {
+			continue
+		}
+
+		t.Own(pvc)
+		_, err = t.controller.Client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(context.Background(), pvc, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (t *vmRestoreTarget) Cleanup() error {
 	for _, dvName := range t.vmRestore.Status.DeletedDataVolumes {
 		objKey := cacheKeyFunc(t.vmRestore.Namespace, dvName)
@@ -520,7 +606,7 @@ func (ctrl *VMRestoreController) getSnapshotContent(vmRestore *snapshotv1.Virtua
 		return nil, fmt.Errorf("VMSnapshot %s not ready", objKey)
 	}
 
-	if vms.Status.SourceUID == nil || *vms.Status.SourceUID != targetUID {
+	if vms.Status.SourceUID == nil || (targetUID != "" && *vms.Status.SourceUID != targetUID) {
 		return nil, fmt.Errorf("VMSnapshot source and restore target differ")
 	}
 
@@ -554,7 +640,7 @@ func (ctrl *VMRestoreController) getVM(namespace, name string) (*kubevirtv1.Virt
 	}
 
 	if !exists {
-		return nil, fmt.Errorf("VirtualMachine %s/%s does not exist", namespace, name)
+		return nil, nil
 	}
 
 	return obj.(*kubevirtv1.VirtualMachine).DeepCopy(), nil
@@ -583,10 +669,21 @@ func (ctrl *VMRestoreController) getTarget(vmRestore *snapshotv1.VirtualMachineR
 			return nil, err
 		}
 
+		cloneRestore, err := vmrestore.IsCloneRestore(ctrl.Client, vmRestore)
+		if err != nil {
+			return nil, err
+		}
+
+		// only the restore of a clone creates a missing VM
+		if vm == nil && !cloneRestore {
+			return nil, fmt.Errorf("VirtualMachine %s/%s does not exist", vmRestore.Namespace, vmRestore.Spec.Target.Name)
+		}
+
 		return &vmRestoreTarget{
-			controller: ctrl,
-			vmRestore:  vmRestore,
-			vm:         vm,
+			controller:   ctrl,
+			vmRestore:    vmRestore,
+			vm:           vm,
+			cloneRestore: cloneRestore,
 		}, nil
 	}
 
@@ -9,6 +9,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -21,6 +22,7 @@ import (
 
 	kubevirtv1 "kubevirt.io/client-go/api/v1"
 	v1 "kubevirt.io/client-go/api/v1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	cdifake "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned/fake"
 	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
@@ -276,6 +278,8 @@ var _ = Describe("Restore controlleer", func() {
 				Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots(testNamespace)).AnyTimes()
 			virtClient.EXPECT().VirtualMachineSnapshotContent(testNamespace).
 				Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotContents(testNamespace)).AnyTimes()
+			virtClient.EXPECT().VirtualMachineClone(testNamespace).
+				Return(kubevirtClient.CloneV1alpha1().VirtualMachineClones(testNamespace)).AnyTimes()
 
 			k8sClient = k8sfake.NewSimpleClientset()
 			virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
@@ -295,6 +299,32 @@ var _ = Describe("Restore controlleer", func() {
 			currentTime = timeFunc
 		})
 
+		setVMCloneOwner := func(r *snapshotv1.VirtualMachineRestore, exists bool) {
+			r.Name = "tmp-restore-clone-uid"
+			r.OwnerReferences = []metav1.OwnerReference{
+				{
+					APIVersion: clonev1alpha1.SchemeGroupVersion.String(),
+					Kind:       "VirtualMachineClone",
+					Name:       "clone",
+					UID:        "clone-uid",
+					Controller: &t,
+				},
+			}
+			kubevirtClient.Fake.PrependReactor("get", "virtualmachineclones", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				Expect(action.(testing.GetAction).GetName()).To(Equal("clone"))
+				if !exists {
+					return true, nil, errors.NewNotFound(clonev1alpha1.Resource("virtualmachineclones"), "clone")
+				}
+				return true, &clonev1alpha1.VirtualMachineClone{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "clone",
+						Namespace: testNamespace,
+						UID:       "clone-uid",
+					},
+				}, nil
+			})
+		}
+
 		addVirtualMachineRestore := func(r *snapshotv1.VirtualMachineRestore) {
 			syncCaches(stop)
 			mockVMRestoreQueue.ExpectAdds(1)
@@ -372,6 +402,41 @@ var _ = Describe("Restore controlleer", func() {
 				testutils.ExpectEvent(recorder, "VirtualMachineRestoreError")
 			})
 
+			It("should error if the target VM does not exist and the restore is not owned by a clone", func() {
+				r := createRestoreWithOwner()
+				rc := r.DeepCopy()
+				rc.ResourceVersion = "1"
+				rc.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionFalse, "VirtualMachine default/testvm does not exist"),
+						newReadyCondition(corev1.ConditionFalse, "VirtualMachine default/testvm does not exist"),
+					},
+				}
+				expectVMRestoreUpdate(kubevirtClient, rc)
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+				testutils.ExpectEvent(recorder, "VirtualMachineRestoreError")
+			})
+
+			It("should error if the target VM does not exist and the VirtualMachineClone owning the restore does not exist", func() {
+				r := createRestoreWithOwner()
+				setVMCloneOwner(r, false)
+				rc := r.DeepCopy()
+				rc.ResourceVersion = "1"
+				rc.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionFalse, "VirtualMachine default/testvm does not exist"),
+						newReadyCondition(corev1.ConditionFalse, "VirtualMachine default/testvm does not exist"),
+					},
+				}
+				expectVMRestoreUpdate(kubevirtClient, rc)
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+				testutils.ExpectEvent(recorder, "VirtualMachineRestoreError")
+			})
+
 			It("should update restore status, initializing conditions and add owner", func() {
 				r := createRestoreWithOwner()
 				refs := r.OwnerReferences
@@ -546,6 +611,102 @@ var _ = Describe("Restore controlleer", func() {
 				controller.processVMRestoreWorkItem()
 			})
 
+			It("should create a new VM with patches applied", func() {
+				r := createRestore()
+				r.Spec.Target.Name = "new-vm"
+				setVMCloneOwner(r, true)
+				r.Spec.Patches = []string{
+					`{"op": "add", "path": "/metadata/labels", "value": {"clone": "true"}}`,
+					`{"op": "replace", "path": "/spec/template/spec/domain/devices/disks/0/disk/bus", "value": "sata"}`,
+				}
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+					},
+				}
+				addVolumeRestores(r)
+				for i := range r.Status.Restores {
+					r.Status.Restores[i].DataVolumeName = &r.Status.Restores[i].PersistentVolumeClaimName
+				}
+
+				vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+					Expect(vm.Name).To(Equal("new-vm"))
+					Expect(vm.UID).To(BeEmpty())
+					Expect(vm.Labels).To(Equal(map[string]string{"clone": "true"}))
+					Expect(vm.Annotations).To(HaveKeyWithValue("restore.kubevirt.io/lastRestoreUID", "tmp-restore-clone-uid-uid"))
+					Expect(vm.Spec.Template.Spec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("sata"))
+					Expect(vm.Spec.DataVolumeTemplates[0].Name).To(Equal("restore-uid-disk1"))
+					Expect(vm.Spec.Template.Spec.Volumes[0].DataVolume.Name).To(Equal("restore-uid-disk1"))
+					return vm, nil
+				})
+				for _, pvc := range getRestorePVCs(r) {
+					pvc.Annotations["cdi.kubevirt.io/storage.populatedFor"] = pvc.Name
+					pvc.Status.Phase = corev1.ClaimBound
+					pvcSource.Add(&pvc)
+				}
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+			})
+
+			It("should set the created VM of a clone as owner of the restored PVCs", func() {
+				r := createRestore()
+				r.Spec.Target.Name = "new-vm"
+				setVMCloneOwner(r, true)
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+					},
+				}
+				addVolumeRestores(r)
+
+				vm := &v1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "new-vm",
+						Namespace: testNamespace,
+						UID:       "new-vm-uid",
+						Annotations: map[string]string{
+							"restore.kubevirt.io/lastRestoreUID": "tmp-restore-clone-uid-uid",
+						},
+					},
+				}
+
+				updated := 0
+				k8sClient.Fake.PrependReactor("update", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					pvc := action.(testing.UpdateAction).GetObject().(*corev1.PersistentVolumeClaim)
+					Expect(pvc.OwnerReferences).To(HaveLen(1))
+					Expect(pvc.OwnerReferences[0].Kind).To(Equal("VirtualMachine"))
+					Expect(pvc.OwnerReferences[0].UID).To(Equal(vm.UID))
+					updated++
+					return true, pvc, nil
+				})
+
+				for _, pvc := range getRestorePVCs(r) {
+					pvc.OwnerReferences = nil
+					pvc.Status.Phase = corev1.ClaimBound
+					pvcSource.Add(&pvc)
+				}
+
+				ur := r.DeepCopy()
+				ur.ResourceVersion = "1"
+				ur.Status.Complete = &t
+				ur.Status.RestoreTime = timeFunc()
+				ur.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionFalse, "Operation complete"),
+					newReadyCondition(corev1.ConditionTrue, "Operation complete"),
+				}
+				expectVMRestoreUpdate(kubevirtClient, ur)
+
+				vmRestoreSource.Add(r)
+				addVM(vm)
+				controller.processVMRestoreWorkItem()
+				Expect(updated).To(Equal(len(r.Status.Restores)))
+				testutils.ExpectEvent(recorder, "VirtualMachineRestoreComplete")
+			})
+
 			It("should cleanup and complete", func() {
 				r := createRestoreWithOwner()
 				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
@@ -57,7 +57,20 @@ const (
 
 	volumeSnapshotMissingEvent = "VolumeSnapshotMissing"
 
+	freezeFailedEvent = "FreezeFailed"
+
+	// frozenAtAnnotation records on the content when the guest filesystems were frozen
+	frozenAtAnnotation = "snapshot.kubevirt.io/frozen-at"
+
+	// freezeFailureAnnotation records on the content why the guest filesystems
+	// of a running source were not frozen
+	freezeFailureAnnotation = "snapshot.kubevirt.io/freeze-failure"
+
 	snapshotRetryInterval = 5 * time.Second
+
+	// unfreezeTimeout is how long the guest filesystems stay frozen if
+	// the controller fails to unfreeze them after the volume snapshots were taken
+	unfreezeTimeout = 5 * time.Minute
 )
 
 type snapshotSource interface {
@@ -66,6 +79,10 @@ type snapshotSource interface {
 	Lock() (bool, error)
 	Unlock() (bool, error)
 	Spec() snapshotv1.SourceSpec
+	Online() (bool, error)
+	GuestAgent() (bool, error)
+	Freeze() error
+	Unfreeze() error
 	PersistentVolumeClaims() map[string]string
 }
 
@@ -95,6 +112,20 @@ func vmSnapshotProgressing(vmSnapshot *snapshotv1.VirtualMachineSnapshot) bool {
 		(vmSnapshot.Status == nil || vmSnapshot.Status.ReadyToUse == nil || !*vmSnapshot.Status.ReadyToUse)
 }
 
+func vmSnapshotContentCreated(vmSnapshotContent *snapshotv1.VirtualMachineSnapshotContent) bool {
+	if vmSnapshotContent.Status == nil {
+		return false
+	}
+
+	for _, vss := range vmSnapshotContent.Status.VolumeSnapshotStatus {
+		if vss.CreationTime == nil && vss.Error == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
 func getVMSnapshotContentName(vmSnapshot *snapshotv1.VirtualMachineSnapshot) string {
 	if vmSnapshot.Status != nil && vmSnapshot.Status.VirtualMachineSnapshotContentName != nil {
 		return *vmSnapshot.Status.VirtualMachineSnapshotContentName
@@ -166,7 +197,20 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 
 		// create content if does not exist
 		if content == nil {
-			return 0, ctrl.createContent(vmSnapshot)
+			freezeAnnotations, err := ctrl.freezeSource(vmSnapshot, source)
+			if err != nil {
+				return 0, err
+			}
+
+			return 0, ctrl.createContent(vmSnapshot, freezeAnnotations)
+		}
+
+		// the guest can be unfrozen as soon as the point in time
+		// of every volume snapshot is fixed
+		if _, frozen := content.Annotations[frozenAtAnnotation]; frozen && vmSnapshotContentCreated(content) {
+			if err := source.Unfreeze(); err != nil {
+				return 0, err
+			}
 		}
 	}
 
@@ -430,7 +474,40 @@ func (ctrl *VMSnapshotController) cleanupVMSnapshot(vmSnapshot *snapshotv1.Virtu
 	return nil
 }
 
-func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMachineSnapshot) error {
+// freezeSource freezes the guest filesystems of a running source and returns the annotations
+// recording the result on the content. If the guest can not be frozen, the snapshot is taken
+// crash consistent instead of retrying.
+func (ctrl *VMSnapshotController) freezeSource(vmSnapshot *snapshotv1.VirtualMachineSnapshot, source snapshotSource) (map[string]string, error) {
+	online, err := source.Online()
+	if err != nil || !online {
+		return nil, err
+	}
+
+	guestAgent, err := source.GuestAgent()
+	if err != nil {
+		return nil, err
+	}
+	if !guestAgent {
+		return map[string]string{freezeFailureAnnotation: "No guest agent connected"}, nil
+	}
+
+	frozenAt := currentTime()
+	if err := source.Freeze(); err != nil {
+		log.Log.Reason(err).Warningf("Failed to freeze the source of VirtualMachineSnapshot %s/%s", vmSnapshot.Namespace, vmSnapshot.Name)
+		ctrl.Recorder.Eventf(
+			vmSnapshot,
+			corev1.EventTypeWarning,
+			freezeFailedEvent,
+			"Failed to freeze the guest filesystems, the snapshot is crash consistent: %v",
+			err,
+		)
+		return map[string]string{freezeFailureAnnotation: fmt.Sprintf("Failed to freeze the guest filesystems: %v", err)}, nil
+	}
+
+	return map[string]string{frozenAtAnnotation: frozenAt.Format(time.RFC3339)}, nil
+}
+
+func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMachineSnapshot, annotations map[string]string) error {
 	source, err := ctrl.getSnapshotSource(vmSnapshot)
 	if err != nil {
 		return err
@@ -463,9 +540,10 @@ func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMa
 
 	content := &snapshotv1.VirtualMachineSnapshotContent{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:       getVMSnapshotContentName(vmSnapshot),
-			Namespace:  vmSnapshot.Namespace,
-			Finalizers: []string{vmSnapshotContentFinalizer},
+			Name:        getVMSnapshotContentName(vmSnapshot),
+			Namespace:   vmSnapshot.Namespace,
+			Annotations: annotations,
+			Finalizers:  []string{vmSnapshotContentFinalizer},
 		},
 		Spec: snapshotv1.VirtualMachineSnapshotContentSpec{
 			VirtualMachineSnapshotName: &vmSnapshot.Name,
@@ -561,6 +639,7 @@ func (ctrl *VMSnapshotController) getVolumeSnapshotClass(storageClassName string
 }
 
 func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.VirtualMachineSnapshot, source snapshotSource) error {
+	var filesystemConsistent *snapshotv1.Condition
 	f := false
 	vmSnapshotCpy := vmSnapshot.DeepCopy()
 	if vmSnapshotCpy.Status == nil {
@@ -594,6 +673,7 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 			vmSnapshotCpy.Status.CreationTime = content.Status.CreationTime
 			vmSnapshotCpy.Status.ReadyToUse = content.Status.ReadyToUse
 			vmSnapshotCpy.Status.Error = content.Status.Error
+			filesystemConsistent = newFilesystemConsistentCondition(content)
 		}
 	}
 
@@ -624,6 +704,10 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 		updateSnapshotCondition(vmSnapshotCpy, newReadyCondition(corev1.ConditionUnknown, "Unknown state"))
 	}
 
+	if filesystemConsistent != nil {
+		updateSnapshotCondition(vmSnapshotCpy, *filesystemConsistent)
+	}
+
 	if !reflect.DeepEqual(vmSnapshot, vmSnapshotCpy) {
 		if _, err := ctrl.Client.VirtualMachineSnapshot(vmSnapshotCpy.Namespace).Update(context.Background(), vmSnapshotCpy, metav1.UpdateOptions{}); err != nil {
 			return err
@@ -633,6 +717,52 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 	return nil
 }
 
+// newFilesystemConsistentCondition reports whether the guest filesystems of a running source
+// stayed frozen until every volume snapshot was taken. It returns nil for sources which were not
+// running and while the outcome is not known yet.
+func newFilesystemConsistentCondition(content *snapshotv1.VirtualMachineSnapshotContent) *snapshotv1.Condition {
+	condition := &snapshotv1.Condition{
+		Type:               snapshotv1.ConditionFilesystemConsistent,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: *currentTime(),
+	}
+
+	if failure, failed := content.Annotations[freezeFailureAnnotation]; failed {
+		condition.Reason = fmt.Sprintf("%s, the snapshot is crash consistent", failure)
+		return condition
+	}
+
+	frozenAt, frozen := content.Annotations[frozenAtAnnotation]
+	if !frozen {
+		return nil
+	}
+	frozenAtTime, err := time.Parse(time.RFC3339, frozenAt)
+	if err != nil {
+		condition.Reason = fmt.Sprintf("Invalid freeze time %q, the snapshot is crash consistent", frozenAt)
+		return condition
+	}
+	// the guest unfreezes itself once the timeout passed
+	thawedAt := frozenAtTime.Add(unfreezeTimeout)
+
+	for _, vss := range content.Status.VolumeSnapshotStatus {
+		if vss.CreationTime == nil {
+			if currentTime().Time.After(thawedAt) {
+				condition.Reason = "Guest filesystems thawed before the volume snapshots were taken, the snapshot is crash consistent"
+				return condition
+			}
+			return nil
+		}
+		if vss.CreationTime.Time.After(thawedAt) {
+			condition.Reason = "Guest filesystems thawed before the volume snapshots were taken, the snapshot is crash consistent"
+			return condition
+		}
+	}
+
+	condition.Status = corev1.ConditionTrue
+	condition.Reason = "Guest filesystems frozen through the guest agent"
+	return condition
+}
+
 func (ctrl *VMSnapshotController) updateVolumeSnapshotStatuses(vm *kubevirtv1.VirtualMachine) error {
 	log.Log.V(3).Infof("Update volume snapshot status for VM [%s/%s]", vm.Namespace, vm.Name)
 
@@ -815,40 +945,44 @@ func (s *vmSnapshotSource) Lock() (bool, error) {
 		return true, nil
 	}
 
-	rs, err := s.vm.RunStrategy()
+	online, err := s.Online()
 	if err != nil {
 		return false, err
 	}
 
-	if rs != kubevirtv1.RunStrategyHalted {
-		log.Log.V(3).Infof("Snapshottting a running VM is not supported yet")
-		return false, nil
-	}
+	// an online snapshot relies on the guest being frozen (if possible)
+	// instead of on nothing else using the PVCs
+	if !online {
+		rs, err := s.vm.RunStrategy()
+		if err != nil {
+			return false, err
+		}
 
-	key, err := controller.KeyFunc(s.vm)
-	if err != nil {
-		return false, err
-	}
+		if rs != kubevirtv1.RunStrategyHalted {
+			log.Log.V(3).Infof("VM is neither halted nor running")
+			return false, nil
+		}
 
-	_, exists, err := s.controller.VMIInformer.GetStore().GetByKey(key)
-	if err != nil {
-		return false, err
-	}
+		_, exists, err := s.getVMI()
+		if err != nil {
+			return false, err
+		}
 
-	if exists {
-		log.Log.V(3).Infof("VMI still running")
-		return false, nil
-	}
+		if exists {
+			log.Log.V(3).Infof("VMI still running")
+			return false, nil
+		}
 
-	pvcNames := s.pvcNames()
-	pods, err := podsUsingPVCs(s.controller.PodInformer, s.vm.Namespace, pvcNames)
-	if err != nil {
-		return false, err
-	}
+		pvcNames := s.pvcNames()
+		pods, err := podsUsingPVCs(s.controller.PodInformer, s.vm.Namespace, pvcNames)
+		if err != nil {
+			return false, err
+		}
 
-	if len(pods) > 0 {
-		log.Log.V(3).Infof("%d pods using PVCs %+v", len(pods), pvcNames)
-		return false, nil
+		if len(pods) > 0 {
+			log.Log.V(3).Infof("%d pods using PVCs %+v", len(pods), pvcNames)
+			return false, nil
+		}
 	}
 
 	if s.vm.Status.SnapshotInProgress != nil && *s.vm.Status.SnapshotInProgress != s.snapshot.Name {
@@ -883,6 +1017,10 @@ func (s *vmSnapshotSource) Unlock() (bool, error) {
 		return false, nil
 	}
 
+	if err := s.Unfreeze(); err != nil {
+		return false, err
+	}
+
 	var err error
 	vmCopy := s.vm.DeepCopy()
 
@@ -911,6 +1049,79 @@ func (s *vmSnapshotSource) Spec() snapshotv1.SourceSpec {
 	}
 }
 
+func (s *vmSnapshotSource) getVMI() (*kubevirtv1.VirtualMachineInstance, bool, error) {
+	key, err := controller.KeyFunc(s.vm)
+	if err != nil {
+		return nil, false, err
+	}
+
+	obj, exists, err := s.controller.VMIInformer.GetStore().GetByKey(key)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+
+	return obj.(*kubevirtv1.VirtualMachineInstance), true, nil
+}
+
+func (s *vmSnapshotSource) Online() (bool, error) {
+	rs, err := s.vm.RunStrategy()
+	if err != nil {
+		return false, err
+	}
+
+	if rs == kubevirtv1.RunStrategyHalted {
+		return false, nil
+	}
+
+	vmi, exists, err := s.getVMI()
+	if err != nil || !exists {
+		return false, err
+	}
+
+	return vmi.IsRunning(), nil
+}
+
+func (s *vmSnapshotSource) GuestAgent() (bool, error) {
+	vmi, exists, err := s.getVMI()
+	if err != nil || !exists {
+		return false, err
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	return condManager.HasConditionWithStatus(vmi, kubevirtv1.VirtualMachineInstanceAgentConnected, corev1.ConditionTrue), nil
+}
+
+func (s *vmSnapshotSource) canFreeze() (bool, error) {
+	online, err := s.Online()
+	if err != nil || !online {
+		return false, err
+	}
+
+	return s.GuestAgent()
+}
+
+func (s *vmSnapshotSource) Freeze() error {
+	canFreeze, err := s.canFreeze()
+	if err != nil || !canFreeze {
+		return err
+	}
+
+	log.Log.V(3).Infof("Freezing VM %s/%s", s.vm.Namespace, s.vm.Name)
+
+	return s.controller.Client.VirtualMachineInstance(s.vm.Namespace).Freeze(s.vm.Name, unfreezeTimeout)
+}
+
+func (s *vmSnapshotSource) Unfreeze() error {
+	canFreeze, err := s.canFreeze()
+	if err != nil || !canFreeze {
+		return err
+	}
+
+	log.Log.V(3).Infof("Unfreezing VM %s/%s", s.vm.Namespace, s.vm.Name)
+
+	return s.controller.Client.VirtualMachineInstance(s.vm.Namespace).Unfreeze(s.vm.Name)
+}
+
 func (s *vmSnapshotSource) PersistentVolumeClaims() map[string]string {
 	return getPVCsFromVolumes(s.vm.Spec.Template.Spec.Volumes)
 }
@@ -106,6 +106,20 @@ var _ = Describe("Snapshot controlleer", func() {
 		}
 	}
 
+	createRunningVMI := func(vm *v1.VirtualMachine, guestAgent bool) *v1.VirtualMachineInstance {
+		vmi := createVMI(vm)
+		vmi.Status.Phase = v1.Running
+		if guestAgent {
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceAgentConnected,
+					Status: corev1.ConditionTrue,
+				},
+			}
+		}
+		return vmi
+	}
+
 	createPersistentVolumeClaims := func() []corev1.PersistentVolumeClaim {
 		return createPVCsForVM(createLockedVM())
 	}
@@ -174,6 +188,7 @@ var _ = Describe("Snapshot controlleer", func() {
 
 		var ctrl *gomock.Controller
 		var vmInterface *kubecli.MockVirtualMachineInterface
+		var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
 		var vmSnapshotSource *framework.FakeControllerSource
 		var vmSnapshotInformer cache.SharedIndexInformer
 		var vmSnapshotContentSource *framework.FakeControllerSource
@@ -237,6 +252,7 @@ var _ = Describe("Snapshot controlleer", func() {
 			ctrl = gomock.NewController(GinkgoT())
 			virtClient := kubecli.NewMockKubevirtClient(ctrl)
 			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
 
 			vmSnapshotInformer, vmSnapshotSource = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshot{}, cache.Indexers{
 				"vm": func(obj interface{}) ([]string, error) {
@@ -305,6 +321,7 @@ var _ = Describe("Snapshot controlleer", func() {
 
 			// Set up mock client
 			virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+			virtClient.EXPECT().VirtualMachineInstance(testNamespace).Return(vmiInterface).AnyTimes()
 
 			vmSnapshotClient = kubevirtfake.NewSimpleClientset()
 			virtClient.EXPECT().VirtualMachineSnapshot(testNamespace).
@@ -450,6 +467,24 @@ var _ = Describe("Snapshot controlleer", func() {
 				controller.processVMSnapshotWorkItem()
 			})
 
+			It("should unfreeze and unlock source VirtualMachine", func() {
+				vmSnapshot := createVMSnapshotSuccess()
+				vm := createLockedVM()
+				vm.Spec.Running = &t
+				updatedVM := vm.DeepCopy()
+				updatedVM.Finalizers = []string{}
+				updatedVM.ResourceVersion = "1"
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm, true))
+				vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+				vmInterface.EXPECT().Update(updatedVM).Return(updatedVM, nil)
+				statusUpdate := updatedVM.DeepCopy()
+				statusUpdate.Status.SnapshotInProgress = nil
+				vmInterface.EXPECT().UpdateStatus(statusUpdate).Return(statusUpdate, nil)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
 			It("should finish unlock source VirtualMachine", func() {
 				vmSnapshot := createVMSnapshotSuccess()
 				vm := createLockedVM()
@@ -600,6 +635,35 @@ var _ = Describe("Snapshot controlleer", func() {
 				controller.processVMSnapshotWorkItem()
 			})
 
+			It("should lock source if VM is running", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vm := createVM()
+				vm.Spec.Running = &t
+				vmStatusUpdate := vm.DeepCopy()
+				vmStatusUpdate.ResourceVersion = "1"
+				vmStatusUpdate.Status.SnapshotInProgress = &vmSnapshotName
+
+				// the virt-launcher pod of a running VM always uses its PVCs
+				pods := createPodsUsingPVCs(vm)
+				podSource.Add(&pods[0])
+				vmiSource.Add(createRunningVMI(vm, false))
+				vmSource.Add(vm)
+				vmInterface.EXPECT().UpdateStatus(vmStatusUpdate).Return(vmStatusUpdate, nil)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
+			It("should not lock source if VMI is not running yet", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vm := createVM()
+				vm.Spec.Running = &t
+
+				vmiSource.Add(createVMI(vm))
+				vmSource.Add(vm)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
 			It("should not lock source if another snapshot in progress", func() {
 				n := "otherSnapshot"
 				vmSnapshot := createVMSnapshotInProgress()
@@ -645,6 +709,136 @@ var _ = Describe("Snapshot controlleer", func() {
 				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
 			})
 
+			It("should freeze VM before creating VirtualMachineSnapshotContent", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vm := createLockedVM()
+				vm.Spec.Running = &t
+				storageClass := createStorageClass()
+				volumeSnapshotClass := &createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVirtualMachineSnapshotContent(vmSnapshot, vm.DeepCopy())
+
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm, true))
+				storageClassSource.Add(storageClass)
+				volumeSnapshotClassSource.Add(volumeSnapshotClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+				vmSnapshotContent.Annotations = map[string]string{"snapshot.kubevirt.io/frozen-at": timeFunc().Format(time.RFC3339)}
+				vmiInterface.EXPECT().Freeze(vm.Name, 5*time.Minute).Return(nil)
+				expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+			})
+
+			It("should create a crash consistent VirtualMachineSnapshotContent if the freeze fails", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vm := createLockedVM()
+				vm.Spec.Running = &t
+				storageClass := createStorageClass()
+				volumeSnapshotClass := &createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVirtualMachineSnapshotContent(vmSnapshot, vm.DeepCopy())
+				vmSnapshotContent.Annotations = map[string]string{"snapshot.kubevirt.io/freeze-failure": "Failed to freeze the guest filesystems: agent error"}
+
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm, true))
+				storageClassSource.Add(storageClass)
+				volumeSnapshotClassSource.Add(volumeSnapshotClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+				vmiInterface.EXPECT().Freeze(vm.Name, 5*time.Minute).Return(fmt.Errorf("agent error"))
+				expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+				testutils.ExpectEvent(recorder, "FreezeFailed")
+				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+			})
+
+			It("should not freeze VM without guest agent before creating VirtualMachineSnapshotContent", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vm := createLockedVM()
+				vm.Spec.Running = &t
+				storageClass := createStorageClass()
+				volumeSnapshotClass := &createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVirtualMachineSnapshotContent(vmSnapshot, vm.DeepCopy())
+
+				vmSource.Add(vm)
+				vmSnapshotContent.Annotations = map[string]string{"snapshot.kubevirt.io/freeze-failure": "No guest agent connected"}
+
+				vmiSource.Add(createRunningVMI(vm, false))
+				storageClassSource.Add(storageClass)
+				volumeSnapshotClassSource.Add(volumeSnapshotClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+				expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+			})
+
+			DescribeTable("should set the filesystem consistency once the VolumeSnapshots are created", func(annotations map[string]string, creationTime *metav1.Time, unfreeze bool, consistent corev1.ConditionStatus, reason string) {
+				vm := createLockedVM()
+				vm.Spec.Running = &t
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshotContent := createVirtualMachineSnapshotContent(vmSnapshot, vm.DeepCopy())
+				vmSnapshotContent.Annotations = annotations
+				vmSnapshotContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					ReadyToUse: &f,
+				}
+				for _, vb := range vmSnapshotContent.Spec.VolumeBackups {
+					vmSnapshotContent.Status.VolumeSnapshotStatus = append(vmSnapshotContent.Status.VolumeSnapshotStatus,
+						snapshotv1.VolumeSnapshotStatus{
+							VolumeSnapshotName: *vb.VolumeSnapshotName,
+							CreationTime:       creationTime,
+							ReadyToUse:         &f,
+						})
+				}
+
+				updatedSnapshot := vmSnapshot.DeepCopy()
+				updatedSnapshot.ResourceVersion = "1"
+				updatedSnapshot.Status.SourceUID = &vmUID
+				updatedSnapshot.Status.VirtualMachineSnapshotContentName = &vmSnapshotContent.Name
+				updatedSnapshot.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionTrue, "Source locked and operation in progress"),
+					newReadyCondition(corev1.ConditionFalse, "Not ready"),
+					{
+						Type:               snapshotv1.ConditionFilesystemConsistent,
+						Status:             consistent,
+						Reason:             reason,
+						LastTransitionTime: *currentTime(),
+					},
+				}
+
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm, true))
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+				if unfreeze {
+					vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+				}
+				expectVMSnapshotUpdate(vmSnapshotClient, updatedSnapshot)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			},
+				Entry("when frozen while the VolumeSnapshots were taken",
+					map[string]string{"snapshot.kubevirt.io/frozen-at": timeFunc().Format(time.RFC3339)},
+					timeFunc(), true, corev1.ConditionTrue, "Guest filesystems frozen through the guest agent"),
+				Entry("when thawed before the VolumeSnapshots were taken",
+					map[string]string{"snapshot.kubevirt.io/frozen-at": timeFunc().Add(-10 * time.Minute).Format(time.RFC3339)},
+					timeFunc(), true, corev1.ConditionFalse, "Guest filesystems thawed before the volume snapshots were taken, the snapshot is crash consistent"),
+				Entry("when the freeze failed",
+					map[string]string{"snapshot.kubevirt.io/freeze-failure": "Failed to freeze the guest filesystems: agent error"},
+					timeFunc(), false, corev1.ConditionFalse, "Failed to freeze the guest filesystems: agent error, the snapshot is crash consistent"),
+				Entry("when thawed before the VolumeSnapshots were created",
+					map[string]string{"snapshot.kubevirt.io/frozen-at": timeFunc().Add(-10 * time.Minute).Format(time.RFC3339)},
+					nil, false, corev1.ConditionFalse, "Guest filesystems thawed before the volume snapshots were taken, the snapshot is crash consistent"),
+			)
+
 			It("should update VirtualMachineSnapshotStatus", func() {
 				vmSnapshotContent := createVMSnapshotContent()
 				vmSnapshotContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
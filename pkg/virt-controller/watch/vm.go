@@ -21,8 +21,10 @@ package watch
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,8 +32,11 @@ import (
 	authv1 "k8s.io/api/authorization/v1"
 	k8score "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -43,7 +48,11 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/instancetype"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
+	"kubevirt.io/kubevirt/pkg/util/persistentstate"
 	"kubevirt.io/kubevirt/pkg/util/status"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 type CloneAuthFunc func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error)
@@ -61,8 +70,12 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 	vmiVMInformer cache.SharedIndexInformer,
 	dataVolumeInformer cache.SharedIndexInformer,
 	pvcInformer cache.SharedIndexInformer,
+	migrationInformer cache.SharedIndexInformer,
+	instancetypeMethods instancetype.Methods,
 	recorder record.EventRecorder,
-	clientset kubecli.KubevirtClient) *VMController {
+	clientset kubecli.KubevirtClient,
+	clusterConfig *virtconfig.ClusterConfig,
+	macPool *macpool.MacPool) *VMController {
 
 	proxy := &sarProxy{client: clientset}
 
@@ -72,6 +85,8 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 		vmiVMInformer:          vmiVMInformer,
 		dataVolumeInformer:     dataVolumeInformer,
 		pvcInformer:            pvcInformer,
+		migrationInformer:      migrationInformer,
+		instancetypeMethods:    instancetypeMethods,
 		recorder:               recorder,
 		clientset:              clientset,
 		expectations:           controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
@@ -80,6 +95,8 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 			return cdiclone.CanServiceAccountClonePVC(proxy, pvcNamespace, pvcName, saNamespace, saName)
 		},
 		statusUpdater: status.NewVMStatusUpdater(clientset),
+		clusterConfig: clusterConfig,
+		macPool:       macPool,
 	}
 
 	c.vmiVMInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -100,6 +117,10 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 		UpdateFunc: c.updateDataVolume,
 	})
 
+	c.migrationInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: c.updateMigration,
+	})
+
 	return c
 }
 
@@ -118,11 +139,15 @@ type VMController struct {
 	vmiVMInformer          cache.SharedIndexInformer
 	dataVolumeInformer     cache.SharedIndexInformer
 	pvcInformer            cache.SharedIndexInformer
+	migrationInformer      cache.SharedIndexInformer
+	instancetypeMethods    instancetype.Methods
 	recorder               record.EventRecorder
 	expectations           *controller.UIDTrackingControllerExpectations
 	dataVolumeExpectations *controller.UIDTrackingControllerExpectations
 	cloneAuthFunc          CloneAuthFunc
 	statusUpdater          *status.VMStatusUpdater
+	clusterConfig          *virtconfig.ClusterConfig
+	macPool                *macpool.MacPool
 }
 
 func (c *VMController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -263,6 +288,9 @@ func (c *VMController) execute(key string) error {
 	if c.needsSync(key) && vm.ObjectMeta.DeletionTimestamp == nil {
 
 		dataVolumesReady, err := c.handleDataVolumes(vm, dataVolumes)
+		if err == nil {
+			err = c.handlePersistentState(vm)
+		}
 		if err != nil {
 			createErr = err
 		} else if dataVolumesReady == true {
@@ -278,6 +306,18 @@ func (c *VMController) execute(key string) error {
 
 			createErr = c.handleVolumeRequests(vm, vmi)
 		}
+
+		if c.needsSync(key) && createErr == nil {
+			createErr = c.handleInterfaceRequests(vm, vmi)
+		}
+
+		if c.needsSync(key) && createErr == nil {
+			createErr = c.handleCPUHotplug(vm, vmi)
+		}
+
+		if c.needsSync(key) && createErr == nil {
+			createErr = c.handleVolumeMigration(vm, vmi)
+		}
 	}
 
 	// If the controller is going to be deleted and the orphan finalizer is the next one, release the VMIs. Don't update the status
@@ -589,6 +629,413 @@ func (c *VMController) handleVolumeRequests(vm *virtv1.VirtualMachine, vmi *virt
 	return nil
 }
 
+func (c *VMController) handleInterfaceRequests(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if len(vm.Status.InterfaceRequests) == 0 {
+		return nil
+	}
+
+	vmCopy := vm.DeepCopy()
+	vmiNetworkMap := make(map[string]virtv1.Network)
+	if vmi != nil {
+		for _, network := range vmi.Spec.Networks {
+			vmiNetworkMap[network.Name] = network
+		}
+	}
+
+	for i, request := range vm.Status.InterfaceRequests {
+		vmCopy.Spec.Template.Spec = *controller.ApplyInterfaceRequestOnVMISpec(&vmCopy.Spec.Template.Spec, &vm.Status.InterfaceRequests[i])
+
+		if vmi == nil || vmi.DeletionTimestamp != nil {
+			continue
+		}
+
+		if request.AddInterfaceOptions != nil {
+			if _, exists := vmiNetworkMap[request.AddInterfaceOptions.Name]; exists {
+				continue
+			}
+
+			if err := c.clientset.VirtualMachineInstance(vmi.Namespace).AddInterface(vmi.Name, request.AddInterfaceOptions); err != nil {
+				return err
+			}
+		} else if request.RemoveInterfaceOptions != nil {
+			if _, exists := vmiNetworkMap[request.RemoveInterfaceOptions.Name]; !exists {
+				continue
+			}
+
+			if err := c.clientset.VirtualMachineInstance(vmi.Namespace).RemoveInterface(vmi.Name, request.RemoveInterfaceOptions); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(vm, vmCopy) {
+		_, err := c.clientset.VirtualMachine(vmCopy.Namespace).Update(vmCopy)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handlePersistentState creates the PVC holding the EFI NVRAM and the TPM state of
+// the VM, if the VM asks for persistent state and the PVC does not exist yet.
+func (c *VMController) handlePersistentState(vm *virtv1.VirtualMachine) error {
+	if !persistentstate.HasPersistentState(&vm.Spec.Template.Spec) {
+		return nil
+	}
+
+	pvcName := persistentstate.PVCName(vm.Name)
+	_, exists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, pvcName))
+	if err != nil || exists {
+		return err
+	}
+
+	pvc := &k8score.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      pvcName,
+			Namespace: vm.Namespace,
+			OwnerReferences: []v1.OwnerReference{
+				*v1.NewControllerRef(vm, virtv1.VirtualMachineGroupVersionKind),
+			},
+		},
+		Spec: k8score.PersistentVolumeClaimSpec{
+			// The state is accessed by the source and the target pod during migrations
+			AccessModes: []k8score.PersistentVolumeAccessMode{k8score.ReadWriteMany},
+			Resources: k8score.ResourceRequirements{
+				Requests: k8score.ResourceList{
+					k8score.ResourceStorage: resource.MustParse(persistentstate.PVCSize),
+				},
+			},
+		},
+	}
+	if storageClass := c.clusterConfig.GetVMStateStorageClass(); storageClass != "" {
+		pvc.Spec.StorageClassName = &storageClass
+	}
+
+	_, err = c.clientset.CoreV1().PersistentVolumeClaims(vm.Namespace).Create(context.Background(), pvc, v1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		return nil
+	} else if err != nil {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedPersistentStateCreateReason, "Error creating persistent state PVC %s: %v", pvcName, err)
+		return err
+	}
+	c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulPersistentStateCreateReason, "Created persistent state PVC %s", pvcName)
+	return nil
+}
+
+// handleCPUHotplug applies a change of the number of sockets in the VM template
+// to its running VMI, as long as it stays within the maximum the VMI was started with.
+func (c *VMController) handleCPUHotplug(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if vmi == nil || vmi.DeletionTimestamp != nil || !vmi.IsRunning() {
+		return nil
+	}
+
+	vmCPU := vm.Spec.Template.Spec.Domain.CPU
+	vmiCPU := vmi.Spec.Domain.CPU
+	if vmCPU == nil || vmiCPU == nil || vmiCPU.MaxSockets == 0 {
+		return nil
+	}
+	// A different maximum only takes effect with the next VMI
+	if vmCPU.MaxSockets != vmiCPU.MaxSockets || vmCPU.Sockets == 0 || vmCPU.Sockets == vmiCPU.Sockets {
+		return nil
+	}
+	// Wait until an ongoing migration is done, the VMI gets re-enqueued once it finishes
+	if vmi.Status.MigrationState != nil && !vmi.Status.MigrationState.Completed {
+		return nil
+	}
+
+	log.Log.Object(vm).Infof("Changing the number of sockets of the running VMI from %d to %d", vmiCPU.Sockets, vmCPU.Sockets)
+	patch := fmt.Sprintf(`[{ "op": "test", "path": "/spec/domain/cpu/sockets", "value": %d}, { "op": "replace", "path": "/spec/domain/cpu/sockets", "value": %d}]`, vmiCPU.Sockets, vmCPU.Sockets)
+	_, err := c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+	return err
+}
+
+// handleVolumeMigration copies the volumes of a running VMI to the claims they were
+// switched to in the VM template with a live migration, if the VM asks for it. Once the
+// migration finished, the copy is either confirmed or reverted on the VM and the VMI.
+func (c *VMController) handleVolumeMigration(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if vmi == nil || vmi.DeletionTimestamp != nil || !vmi.IsRunning() {
+		return nil
+	}
+	if vmi.Status.VolumeMigration != nil {
+		return c.syncVolumeMigration(vm, vmi)
+	}
+
+	if vm.Spec.UpdateVolumesStrategy == nil || *vm.Spec.UpdateVolumesStrategy != virtv1.UpdateVolumesStrategyMigration ||
+		!c.clusterConfig.VolumeMigrationEnabled() {
+		return nil
+	}
+	migratedVolumes := getMigratedVolumes(vm.Spec.Template.Spec.Volumes, vmi)
+	if len(migratedVolumes) == 0 {
+		return nil
+	}
+	// Wait until an ongoing migration is done, the VMI gets re-enqueued once it finishes
+	if vmi.Status.MigrationState != nil && !vmi.Status.MigrationState.Completed {
+		return nil
+	}
+	if err := c.validateMigratedVolumes(vmi.Namespace, migratedVolumes); err != nil {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedVolumeMigrationReason, "Cannot copy the volumes to the new claims: %v", err)
+		return nil
+	}
+
+	state := &virtv1.VolumeMigrationState{
+		MigrationName:   fmt.Sprintf("%s-volume-migration-%s", vmi.Name, rand.String(5)),
+		MigratedVolumes: migratedVolumes,
+	}
+	patch, err := startVolumeMigrationPatch(vmi, state)
+	if err != nil {
+		return err
+	}
+	log.Log.Object(vm).Infof("Copying the volumes of the running VMI to new claims with migration %s", state.MigrationName)
+	if _, err := c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, patch); err != nil {
+		return err
+	}
+	return c.createVolumeMigration(vm, vmi, state.MigrationName)
+}
+
+func (c *VMController) syncVolumeMigration(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	state := vmi.Status.VolumeMigration
+	obj, exists, err := c.migrationInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vmi.Namespace, state.MigrationName))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return c.createVolumeMigration(vm, vmi, state.MigrationName)
+	}
+
+	migration := obj.(*virtv1.VirtualMachineInstanceMigration)
+	switch migration.Status.Phase {
+	case virtv1.MigrationSucceeded:
+		patch, err := finishVolumeMigrationPatch(vmi, state, false)
+		if err != nil {
+			return err
+		}
+		if _, err := c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, patch); err != nil {
+			return err
+		}
+		c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulVolumeMigrationReason, "Copied the volumes %s to the new claims", migratedVolumeNames(state))
+	case virtv1.MigrationFailed:
+		// The VM is reverted first, otherwise the reverted VMI would get migrated again
+		if err := c.revertVolumeMigrationOnVM(vm, state); err != nil {
+			return err
+		}
+		patch, err := finishVolumeMigrationPatch(vmi, state, true)
+		if err != nil {
+			return err
+		}
+		if _, err := c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, patch); err != nil {
+			return err
+		}
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedVolumeMigrationReason, "Migration %s failed, reverted the volumes %s to the previous claims", migration.Name, migratedVolumeNames(state))
+	}
+	return nil
+}
+
+func (c *VMController) createVolumeMigration(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, name string) error {
+	migration := &virtv1.VirtualMachineInstanceMigration{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: vmi.Namespace,
+		},
+		Spec: virtv1.VirtualMachineInstanceMigrationSpec{
+			VMIName: vmi.Name,
+		},
+	}
+	_, err := c.clientset.VirtualMachineInstanceMigration(vmi.Namespace).Create(migration)
+	if errors.IsAlreadyExists(err) {
+		return nil
+	} else if err != nil {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedVolumeMigrationReason, "Error creating migration %s: %v", name, err)
+		return err
+	}
+	return nil
+}
+
+// revertVolumeMigrationOnVM switches the volumes of the VM template back to their source
+// claims, unless they were changed again in the meantime.
+func (c *VMController) revertVolumeMigrationOnVM(vm *virtv1.VirtualMachine, state *virtv1.VolumeMigrationState) error {
+	vmCopy := vm.DeepCopy()
+	for _, migratedVolume := range state.MigratedVolumes {
+		for i := range vmCopy.Spec.Template.Spec.Volumes {
+			volume := &vmCopy.Spec.Template.Spec.Volumes[i]
+			if volume.Name == migratedVolume.VolumeName && getVolumeClaimName(volume) == migratedVolume.DestinationClaim {
+				setVolumeClaimName(volume, migratedVolume.SourceClaim)
+			}
+		}
+	}
+	if reflect.DeepEqual(vm, vmCopy) {
+		return nil
+	}
+	_, err := c.clientset.VirtualMachine(vmCopy.Namespace).Update(vmCopy)
+	return err
+}
+
+// validateMigratedVolumes ensures that the destination claims exist and can hold the disks of the source claims.
+func (c *VMController) validateMigratedVolumes(namespace string, migratedVolumes []virtv1.MigratedVolume) error {
+	getPVC := func(name string) (*k8score.PersistentVolumeClaim, error) {
+		obj, exists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, fmt.Errorf("persistentvolumeclaim %s not found", name)
+		}
+		return obj.(*k8score.PersistentVolumeClaim), nil
+	}
+
+	for _, migratedVolume := range migratedVolumes {
+		source, err := getPVC(migratedVolume.SourceClaim)
+		if err != nil {
+			return err
+		}
+		destination, err := getPVC(migratedVolume.DestinationClaim)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(getVolumeMode(source), getVolumeMode(destination)) {
+			return fmt.Errorf("persistentvolumeclaim %s has a different volume mode than %s", destination.Name, source.Name)
+		}
+		sourceSize := getClaimSize(source)
+		destinationSize := getClaimSize(destination)
+		if destinationSize.Cmp(sourceSize) < 0 {
+			return fmt.Errorf("persistentvolumeclaim %s is smaller than %s", destination.Name, source.Name)
+		}
+	}
+	return nil
+}
+
+// getClaimSize returns the capacity of a bound claim and the requested size otherwise, since the capacity
+// of a claim, e.g. with the WaitForFirstConsumer binding mode, is only known once it is bound.
+func getClaimSize(pvc *k8score.PersistentVolumeClaim) resource.Quantity {
+	if pvc.Status.Phase == k8score.ClaimBound {
+		return pvc.Status.Capacity[k8score.ResourceStorage]
+	}
+	return pvc.Spec.Resources.Requests[k8score.ResourceStorage]
+}
+
+func getVolumeMode(pvc *k8score.PersistentVolumeClaim) k8score.PersistentVolumeMode {
+	if pvc.Spec.VolumeMode == nil {
+		return k8score.PersistentVolumeFilesystem
+	}
+	return *pvc.Spec.VolumeMode
+}
+
+// getMigratedVolumes returns the permanent volumes of the VMI whose claim differs in the VM template.
+func getMigratedVolumes(templateVolumes []virtv1.Volume, vmi *virtv1.VirtualMachineInstance) []virtv1.MigratedVolume {
+	templateClaims := make(map[string]string)
+	for i := range templateVolumes {
+		templateClaims[templateVolumes[i].Name] = getVolumeClaimName(&templateVolumes[i])
+	}
+	hotplugVolumes := make(map[string]bool)
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.HotplugVolume != nil {
+			hotplugVolumes[volumeStatus.Name] = true
+		}
+	}
+
+	var migratedVolumes []virtv1.MigratedVolume
+	for i := range vmi.Spec.Volumes {
+		volume := &vmi.Spec.Volumes[i]
+		claim := getVolumeClaimName(volume)
+		if claim == "" || hotplugVolumes[volume.Name] {
+			continue
+		}
+		if templateClaim := templateClaims[volume.Name]; templateClaim != "" && templateClaim != claim {
+			migratedVolumes = append(migratedVolumes, virtv1.MigratedVolume{
+				VolumeName:       volume.Name,
+				SourceClaim:      claim,
+				DestinationClaim: templateClaim,
+			})
+		}
+	}
+	return migratedVolumes
+}
+
+func getVolumeClaimName(volume *virtv1.Volume) string {
+	if volume.PersistentVolumeClaim != nil {
+		return volume.PersistentVolumeClaim.ClaimName
+	} else if volume.DataVolume != nil {
+		return volume.DataVolume.Name
+	}
+	return ""
+}
+
+func setVolumeClaimName(volume *virtv1.Volume, claim string) {
+	if volume.PersistentVolumeClaim != nil {
+		volume.PersistentVolumeClaim.ClaimName = claim
+	} else if volume.DataVolume != nil {
+		volume.DataVolume.Name = claim
+	}
+}
+
+func getVolumeClaimPath(volume *virtv1.Volume) string {
+	if volume.PersistentVolumeClaim != nil {
+		return "persistentVolumeClaim/claimName"
+	}
+	return "dataVolume/name"
+}
+
+// startVolumeMigrationPatch switches the migrated volumes of the VMI to their
+// destination claims and records the volume migration.
+func startVolumeMigrationPatch(vmi *virtv1.VirtualMachineInstance, state *virtv1.VolumeMigrationState) ([]byte, error) {
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	ops := []string{
+		`{ "op": "test", "path": "/status/volumeMigration", "value": null}`,
+		fmt.Sprintf(`{ "op": "add", "path": "/status/volumeMigration", "value": %s}`, string(stateJSON)),
+	}
+	ops = append(ops, switchVolumeClaimsPatch(vmi, state, false)...)
+	return []byte(fmt.Sprintf("[ %s ]", strings.Join(ops, ", "))), nil
+}
+
+// finishVolumeMigrationPatch removes the record of the volume migration from the VMI
+// and, if requested, switches the migrated volumes back to their source claims.
+func finishVolumeMigrationPatch(vmi *virtv1.VirtualMachineInstance, state *virtv1.VolumeMigrationState, revert bool) ([]byte, error) {
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	ops := []string{
+		fmt.Sprintf(`{ "op": "test", "path": "/status/volumeMigration", "value": %s}`, string(stateJSON)),
+		`{ "op": "remove", "path": "/status/volumeMigration"}`,
+	}
+	if revert {
+		ops = append(ops, switchVolumeClaimsPatch(vmi, state, true)...)
+	}
+	return []byte(fmt.Sprintf("[ %s ]", strings.Join(ops, ", "))), nil
+}
+
+func switchVolumeClaimsPatch(vmi *virtv1.VirtualMachineInstance, state *virtv1.VolumeMigrationState, toSource bool) []string {
+	var ops []string
+	for _, migratedVolume := range state.MigratedVolumes {
+		from, to := migratedVolume.SourceClaim, migratedVolume.DestinationClaim
+		if toSource {
+			from, to = to, from
+		}
+		for i := range vmi.Spec.Volumes {
+			volume := &vmi.Spec.Volumes[i]
+			if volume.Name != migratedVolume.VolumeName || getVolumeClaimName(volume) != from {
+				continue
+			}
+			path := fmt.Sprintf("/spec/volumes/%d/%s", i, getVolumeClaimPath(volume))
+			ops = append(ops,
+				fmt.Sprintf(`{ "op": "test", "path": "%s", "value": "%s"}`, path, from),
+				fmt.Sprintf(`{ "op": "replace", "path": "%s", "value": "%s"}`, path, to))
+		}
+	}
+	return ops
+}
+
+func migratedVolumeNames(state *virtv1.VolumeMigrationState) string {
+	var names []string
+	for _, migratedVolume := range state.MigratedVolumes {
+		names = append(names, migratedVolume.VolumeName)
+	}
+	return strings.Join(names, ", ")
+}
+
 func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
 	runStrategy, err := vm.RunStrategy()
 	if err != nil {
@@ -674,6 +1121,18 @@ func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualM
 		}
 		return nil
 
+	case virtv1.RunStrategyOnce:
+		// For this RunStrategy, a VMI is started once and never restarted,
+		// regardless of whether it ended up Succeeded or Failed.
+		if vmi == nil {
+			log.Log.Object(vm).V(4).Info(startingVmiMsg)
+			err := c.startVMI(vm)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+
 	case virtv1.RunStrategyManual:
 		// For this RunStrategy, VMI's will be started/stopped/restarted using api endpoints only
 		if vmi != nil {
@@ -741,6 +1200,29 @@ func (c *VMController) startVMI(vm *virtv1.VirtualMachine) error {
 	// start it
 	vmi := c.setupVMIFromVM(vm)
 
+	err = c.applyInstancetypeToVmi(vm, vmi)
+	if err != nil {
+		log.Log.Object(vm).Reason(err).Error("Failed to apply instancetype to VirtualMachineInstance")
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreateVirtualMachineReason, "Error creating virtual machine instance: Failed to apply instancetype: %v", err)
+		return err
+	}
+
+	// VMs created before the MAC pool was configured have no MAC addresses on their Multus interfaces yet
+	if config := c.clusterConfig.GetMacPool(); config != nil {
+		err = c.macPool.AssignMacAddresses(config, macpool.OwnerKey(vm), &vmi.Spec, &vm.Spec.Template.Spec)
+		if err != nil {
+			log.Log.Object(vm).Reason(err).Error("Failed to assign MAC addresses to VirtualMachineInstance")
+			c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreateVirtualMachineReason, "Error creating virtual machine instance: Failed to assign MAC addresses: %v", err)
+			return err
+		}
+		// persist the assigned addresses in the VM, so that the VMI gets them again on the next start
+		if err = c.persistAssignedMacAddresses(vm, vmi); err != nil {
+			log.Log.Object(vm).Reason(err).Error("Failed to persist the assigned MAC addresses in VirtualMachine")
+			c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreateVirtualMachineReason, "Error creating virtual machine instance: Failed to persist the assigned MAC addresses: %v", err)
+			return err
+		}
+	}
+
 	c.expectations.ExpectCreations(vmKey, 1)
 	vmi, err = c.clientset.VirtualMachineInstance(vm.ObjectMeta.Namespace).Create(vmi)
 	if err != nil {
@@ -754,6 +1236,27 @@ func (c *VMController) startVMI(vm *virtv1.VirtualMachine) error {
 	return nil
 }
 
+// persistAssignedMacAddresses writes the MAC addresses assigned to the interfaces of the VMI to the
+// interfaces of the VM template which have none
+func (c *VMController) persistAssignedMacAddresses(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	assigned := map[string]string{}
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		assigned[iface.Name] = iface.MacAddress
+	}
+
+	vmCopy := vm.DeepCopy()
+	for i, iface := range vmCopy.Spec.Template.Spec.Domain.Devices.Interfaces {
+		if iface.MacAddress == "" {
+			vmCopy.Spec.Template.Spec.Domain.Devices.Interfaces[i].MacAddress = assigned[iface.Name]
+		}
+	}
+	if reflect.DeepEqual(vm.Spec.Template.Spec.Domain.Devices.Interfaces, vmCopy.Spec.Template.Spec.Domain.Devices.Interfaces) {
+		return nil
+	}
+	_, err := c.clientset.VirtualMachine(vmCopy.Namespace).Update(vmCopy)
+	return err
+}
+
 func (c *VMController) stopVMI(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
 	if vmi == nil || vmi.DeletionTimestamp != nil {
 		// nothing to do
@@ -805,6 +1308,30 @@ func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.Virtual
 	return vmi
 }
 
+// applyInstancetypeToVmi expands the instancetype and preference referenced by the VirtualMachine into the VirtualMachineInstance
+func (c *VMController) applyInstancetypeToVmi(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	instancetypeSpec, err := c.instancetypeMethods.FindInstancetypeSpec(vm)
+	if err != nil {
+		return err
+	}
+
+	preferenceSpec, err := c.instancetypeMethods.FindPreferenceSpec(vm)
+	if err != nil {
+		return err
+	}
+
+	if instancetypeSpec == nil && preferenceSpec == nil {
+		return nil
+	}
+
+	conflicts := c.instancetypeMethods.ApplyToVmi(k8sfield.NewPath("spec"), instancetypeSpec, preferenceSpec, &vmi.Spec)
+	if len(conflicts) > 0 {
+		return fmt.Errorf("VMI conflicts with instancetype spec in fields: [%s]", conflicts.String())
+	}
+
+	return nil
+}
+
 // no special meaning, randomly generated on my box.
 // TODO: do we want to use another constants? see examples in RFC4122
 const magicUUID = "6a1a24a1-4061-4607-8bf4-a3963d0c5895"
@@ -1147,6 +1674,16 @@ func (c *VMController) deleteDataVolume(obj interface{}) {
 	c.enqueueVm(vm)
 }
 
+// updateMigration re-enqueues the VM of a migrated VMI, so that a finished volume migration gets handled
+func (c *VMController) updateMigration(old, cur interface{}) {
+	curMigration := cur.(*virtv1.VirtualMachineInstanceMigration)
+	oldMigration := old.(*virtv1.VirtualMachineInstanceMigration)
+	if curMigration.Status.Phase == oldMigration.Status.Phase || !curMigration.IsFinal() {
+		return
+	}
+	c.Queue.Add(fmt.Sprintf("%s/%s", curMigration.Namespace, curMigration.Spec.VMIName))
+}
+
 func (c *VMController) addVm(obj interface{}) {
 	c.enqueueVm(obj)
 }
@@ -1290,6 +1827,41 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 		vm.Status.VolumeRequests = tmpVolRequests
 	}
 
+	if len(vm.Status.InterfaceRequests) > 0 {
+		networkMap := make(map[string]virtv1.Network)
+		interfaceMap := make(map[string]virtv1.Interface)
+
+		for _, network := range vm.Spec.Template.Spec.Networks {
+			networkMap[network.Name] = network
+		}
+		for _, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+			interfaceMap[iface.Name] = iface
+		}
+
+		tmpInterfaceRequests := vm.Status.InterfaceRequests[:0]
+		for _, request := range vm.Status.InterfaceRequests {
+
+			var added bool
+			var name string
+
+			if request.AddInterfaceOptions != nil {
+				name = request.AddInterfaceOptions.Name
+				added = true
+			} else if request.RemoveInterfaceOptions != nil {
+				name = request.RemoveInterfaceOptions.Name
+			}
+
+			_, networkExists := networkMap[name]
+			_, interfaceExists := interfaceMap[name]
+
+			handled := (added && networkExists && interfaceExists) || (!added && !networkExists && !interfaceExists)
+			if !handled {
+				tmpInterfaceRequests = append(tmpInterfaceRequests, request)
+			}
+		}
+		vm.Status.InterfaceRequests = tmpInterfaceRequests
+	}
+
 	if vmRenamedAndDeleted {
 		return nil
 	}
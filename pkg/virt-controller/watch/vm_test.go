@@ -1,6 +1,7 @@
 package watch
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-openapi/errors"
@@ -10,9 +11,11 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pborman/uuid"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	framework "k8s.io/client-go/tools/cache/testing"
@@ -20,11 +23,16 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	virtv1 "kubevirt.io/client-go/api/v1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
 	cdifake "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned/fake"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/instancetype"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 var _ = Describe("VirtualMachine", func() {
@@ -41,6 +49,9 @@ var _ = Describe("VirtualMachine", func() {
 		var dataVolumeInformer cache.SharedIndexInformer
 		var dataVolumeSource *framework.FakeControllerSource
 		var pvcInformer cache.SharedIndexInformer
+		var migrationInformer cache.SharedIndexInformer
+		var configMapInformer cache.SharedIndexInformer
+		var migrationInterface *kubecli.MockVirtualMachineInstanceMigrationInterface
 		var stop chan struct{}
 		var controller *VMController
 		var recorder *record.FakeRecorder
@@ -48,6 +59,8 @@ var _ = Describe("VirtualMachine", func() {
 		var vmiFeeder *testutils.VirtualMachineFeeder
 		var dataVolumeFeeder *testutils.DataVolumeFeeder
 		var cdiClient *cdifake.Clientset
+		var kubevirtClient *kubevirtfake.Clientset
+		var kubeClient *k8sfake.Clientset
 
 		syncCaches := func(stop chan struct{}) {
 			go vmiInformer.Run(stop)
@@ -62,14 +75,22 @@ var _ = Describe("VirtualMachine", func() {
 			virtClient := kubecli.NewMockKubevirtClient(ctrl)
 			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
 			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+			migrationInterface = kubecli.NewMockVirtualMachineInstanceMigrationInterface(ctrl)
 
 			dataVolumeInformer, dataVolumeSource = testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
-			vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
-			vmInformer, vmSource = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+			macIndexers := cache.Indexers{
+				cache.NamespaceIndex:    cache.MetaNamespaceIndexFunc,
+				macpool.MacAddressIndex: macpool.IndexByMacAddress,
+			}
+			vmiInformer, vmiSource = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachineInstance{}, macIndexers)
+			vmInformer, vmSource = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachine{}, macIndexers)
 			pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+			migrationInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceMigration{})
 			recorder = record.NewFakeRecorder(100)
 
-			controller = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, recorder, virtClient)
+			var config *virtconfig.ClusterConfig
+			config, configMapInformer, _, _ = testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+			controller = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, migrationInformer, instancetype.NewMethods(virtClient), recorder, virtClient, config, macpool.NewMacPool(virtClient, k8sv1.NamespaceDefault, vmInformer, vmiInformer))
 			// Wrap our workqueue to have a way to detect when we are done processing updates
 			mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 			controller.Queue = mockQueue
@@ -80,9 +101,17 @@ var _ = Describe("VirtualMachine", func() {
 			// Set up mock client
 			virtClient.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
 			virtClient.EXPECT().VirtualMachine(metav1.NamespaceDefault).Return(vmInterface).AnyTimes()
+			virtClient.EXPECT().VirtualMachineInstanceMigration(metav1.NamespaceDefault).Return(migrationInterface).AnyTimes()
 
 			cdiClient = cdifake.NewSimpleClientset()
 			virtClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+
+			kubeClient = k8sfake.NewSimpleClientset()
+			virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+
+			kubevirtClient = kubevirtfake.NewSimpleClientset()
+			virtClient.EXPECT().VirtualMachineClusterInstancetype().Return(kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterInstancetypes()).AnyTimes()
+			virtClient.EXPECT().VirtualMachineClusterPreference().Return(kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterPreferences()).AnyTimes()
 			cdiClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
 				Expect(action).To(BeNil())
 				return true, nil, nil
@@ -328,6 +357,339 @@ var _ = Describe("VirtualMachine", func() {
 			table.Entry("that is not running", false),
 		)
 
+		table.DescribeTable("should hotplug an interface", func(isRunning bool) {
+			vm, vmi := DefaultVirtualMachine(isRunning)
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Status.InterfaceRequests = []v1.VirtualMachineInterfaceRequest{
+				{
+					AddInterfaceOptions: &v1.AddInterfaceOptions{
+						NetworkAttachmentDefinitionName: "blue-net",
+						Name:                            "blue",
+					},
+				},
+			}
+
+			addVirtualMachine(vm)
+
+			if isRunning {
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+				vmiInterface.EXPECT().AddInterface(vmi.ObjectMeta.Name, vm.Status.InterfaceRequests[0].AddInterfaceOptions)
+			}
+
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				spec := arg.(*v1.VirtualMachine).Spec.Template.Spec
+				Expect(spec.Networks[len(spec.Networks)-1].Multus.NetworkName).To(Equal("blue-net"))
+				Expect(spec.Domain.Devices.Interfaces[len(spec.Domain.Devices.Interfaces)-1].Name).To(Equal("blue"))
+			}).Return(nil, nil)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				// interface request shouldn't be cleared until update status observes the new interface
+				Expect(arg.(*v1.VirtualMachine).Status.InterfaceRequests).To(HaveLen(1))
+			}).Return(nil, nil)
+
+			controller.Execute()
+		},
+
+			table.Entry("that is running", true),
+			table.Entry("that is not running", false),
+		)
+
+		table.DescribeTable("should change the sockets of a running VMI", func(vmiSockets, vmSockets, vmMaxSockets uint32, migrating bool, expectPatch bool) {
+			vm, vmi := DefaultVirtualMachine(true)
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{Sockets: vmSockets, MaxSockets: vmMaxSockets}
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: vmiSockets, MaxSockets: 4}
+			if migrating {
+				vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{}
+			}
+
+			addVirtualMachine(vm)
+			markAsReady(vmi)
+			vmiFeeder.Add(vmi)
+
+			if expectPatch {
+				patch := fmt.Sprintf(`[{ "op": "test", "path": "/spec/domain/cpu/sockets", "value": %d}, { "op": "replace", "path": "/spec/domain/cpu/sockets", "value": %d}]`, vmiSockets, vmSockets)
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, []byte(patch)).Return(vmi, nil)
+			}
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+			controller.Execute()
+		},
+			table.Entry("when sockets are added", uint32(2), uint32(3), uint32(4), false, true),
+			table.Entry("when sockets are removed", uint32(2), uint32(1), uint32(4), false, true),
+			table.Entry("not when sockets are unchanged", uint32(2), uint32(2), uint32(4), false, false),
+			table.Entry("not when maxSockets changed", uint32(2), uint32(3), uint32(8), false, false),
+			table.Entry("not while the VMI is migrating", uint32(2), uint32(3), uint32(4), true, false),
+		)
+
+		Context("with persistent state", func() {
+			newVMWithPersistentTPM := func() *v1.VirtualMachine {
+				vm, _ := DefaultVirtualMachine(false)
+				persistent := true
+				vm.Spec.Template.Spec.Domain.Devices.TPM = &v1.TPMDevice{Persistent: &persistent}
+				return vm
+			}
+
+			It("should create the persistent state PVC", func() {
+				vm := newVMWithPersistentTPM()
+				config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+					Data: map[string]string{virtconfig.VMStateStorageClassKey: "rwx-storage"},
+				})
+				controller.clusterConfig = config
+				addVirtualMachine(vm)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+
+				pvc, err := kubeClient.CoreV1().PersistentVolumeClaims(vm.Namespace).Get(context.Background(), "persistent-state-for-testvmi", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pvc.OwnerReferences).To(HaveLen(1))
+				Expect(pvc.OwnerReferences[0].UID).To(Equal(vm.UID))
+				Expect(pvc.Spec.AccessModes).To(ConsistOf(k8sv1.ReadWriteMany))
+				Expect(*pvc.Spec.StorageClassName).To(Equal("rwx-storage"))
+				testutils.ExpectEvent(recorder, SuccessfulPersistentStateCreateReason)
+			})
+
+			It("should not create the persistent state PVC if it exists", func() {
+				vm := newVMWithPersistentTPM()
+				Expect(pvcInformer.GetStore().Add(&k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "persistent-state-for-testvmi", Namespace: vm.Namespace},
+				})).To(Succeed())
+				addVirtualMachine(vm)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+
+				for _, action := range kubeClient.Actions() {
+					Expect(action.GetVerb()).ToNot(Equal("create"))
+				}
+			})
+
+			It("should not create a PVC without persistent state", func() {
+				vm, _ := DefaultVirtualMachine(false)
+				addVirtualMachine(vm)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+
+				Expect(kubeClient.Actions()).To(BeEmpty())
+			})
+		})
+
+		Context("with a volume migration", func() {
+			newPVC := func(name, size string) *k8sv1.PersistentVolumeClaim {
+				return &k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+					Status: k8sv1.PersistentVolumeClaimStatus{
+						Phase:    k8sv1.ClaimBound,
+						Capacity: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse(size)},
+					},
+				}
+			}
+
+			newPendingPVC := func(name, size string) *k8sv1.PersistentVolumeClaim {
+				return &k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+					Spec: k8sv1.PersistentVolumeClaimSpec{
+						Resources: k8sv1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse(size)},
+						},
+					},
+					Status: k8sv1.PersistentVolumeClaimStatus{Phase: k8sv1.ClaimPending},
+				}
+			}
+
+			newVMWithSwitchedClaim := func() (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Status.Created = true
+				vm.Status.Ready = true
+				strategy := v1.UpdateVolumesStrategyMigration
+				vm.Spec.UpdateVolumesStrategy = &strategy
+				vmi.Spec.Volumes = []v1.Volume{{
+					Name: "disk0",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "src"},
+					},
+				}}
+				vm.Spec.Template.Spec.Volumes = []v1.Volume{*vmi.Spec.Volumes[0].DeepCopy()}
+				vm.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName = "dst"
+				return vm, vmi
+			}
+
+			migratedVolumes := []v1.MigratedVolume{{VolumeName: "disk0", SourceClaim: "src", DestinationClaim: "dst"}}
+
+			BeforeEach(func() {
+				testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+					Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.VolumeMigrationGate},
+				})
+				Expect(pvcInformer.GetStore().Add(newPVC("src", "10Gi"))).To(Succeed())
+			})
+
+			It("should switch the claim of the VMI and create a migration", func() {
+				Expect(pvcInformer.GetStore().Add(newPVC("dst", "20Gi"))).To(Succeed())
+				vm, vmi := newVMWithSwitchedClaim()
+				addVirtualMachine(vm)
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+
+				var migrationName string
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, _ types.PatchType, patch []byte) (*v1.VirtualMachineInstance, error) {
+					Expect(string(patch)).To(ContainSubstring(`{ "op": "replace", "path": "/spec/volumes/0/persistentVolumeClaim/claimName", "value": "dst"}`))
+					Expect(string(patch)).To(ContainSubstring(`"migratedVolumes":[{"volumeName":"disk0","sourceClaim":"src","destinationClaim":"dst"}]`))
+					return vmi, nil
+				})
+				migrationInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(migration *v1.VirtualMachineInstanceMigration) (*v1.VirtualMachineInstanceMigration, error) {
+					Expect(migration.Spec.VMIName).To(Equal(vmi.Name))
+					migrationName = migration.Name
+					return migration, nil
+				})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+
+				Expect(migrationName).To(HavePrefix("testvmi-volume-migration-"))
+			})
+
+			It("should not migrate to a claim which is too small", func() {
+				Expect(pvcInformer.GetStore().Add(newPVC("dst", "5Gi"))).To(Succeed())
+				vm, vmi := newVMWithSwitchedClaim()
+				addVirtualMachine(vm)
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, FailedVolumeMigrationReason)
+			})
+
+			It("should migrate to a pending claim which requests enough storage", func() {
+				Expect(pvcInformer.GetStore().Add(newPendingPVC("dst", "20Gi"))).To(Succeed())
+				vm, vmi := newVMWithSwitchedClaim()
+				addVirtualMachine(vm)
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).Return(vmi, nil)
+				migrationInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(migration *v1.VirtualMachineInstanceMigration) (*v1.VirtualMachineInstanceMigration, error) {
+					return migration, nil
+				})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+			})
+
+			It("should not migrate to a pending claim which requests too little storage", func() {
+				Expect(pvcInformer.GetStore().Add(newPendingPVC("dst", "5Gi"))).To(Succeed())
+				vm, vmi := newVMWithSwitchedClaim()
+				addVirtualMachine(vm)
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, FailedVolumeMigrationReason)
+			})
+
+			It("should not migrate without the Migration strategy", func() {
+				Expect(pvcInformer.GetStore().Add(newPVC("dst", "20Gi"))).To(Succeed())
+				vm, vmi := newVMWithSwitchedClaim()
+				vm.Spec.UpdateVolumesStrategy = nil
+				addVirtualMachine(vm)
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+			})
+
+			It("should remove the volume migration once the migration succeeded", func() {
+				vm, vmi := newVMWithSwitchedClaim()
+				vmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName = "dst"
+				vmi.Status.VolumeMigration = &v1.VolumeMigrationState{MigrationName: "volume-migration", MigratedVolumes: migratedVolumes}
+				Expect(migrationInformer.GetStore().Add(&v1.VirtualMachineInstanceMigration{
+					ObjectMeta: metav1.ObjectMeta{Name: "volume-migration", Namespace: metav1.NamespaceDefault},
+					Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationSucceeded},
+				})).To(Succeed())
+				addVirtualMachine(vm)
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, _ types.PatchType, patch []byte) (*v1.VirtualMachineInstance, error) {
+					Expect(string(patch)).To(ContainSubstring(`{ "op": "remove", "path": "/status/volumeMigration"}`))
+					Expect(string(patch)).ToNot(ContainSubstring("/spec/volumes"))
+					return vmi, nil
+				})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulVolumeMigrationReason)
+			})
+
+			It("should revert the VM and the VMI once the migration failed", func() {
+				vm, vmi := newVMWithSwitchedClaim()
+				vmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName = "dst"
+				vmi.Status.VolumeMigration = &v1.VolumeMigrationState{MigrationName: "volume-migration", MigratedVolumes: migratedVolumes}
+				Expect(migrationInformer.GetStore().Add(&v1.VirtualMachineInstanceMigration{
+					ObjectMeta: metav1.ObjectMeta{Name: "volume-migration", Namespace: metav1.NamespaceDefault},
+					Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationFailed},
+				})).To(Succeed())
+				addVirtualMachine(vm)
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().Update(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+					Expect(vm.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("src"))
+					return vm, nil
+				})
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, _ types.PatchType, patch []byte) (*v1.VirtualMachineInstance, error) {
+					Expect(string(patch)).To(ContainSubstring(`{ "op": "remove", "path": "/status/volumeMigration"}`))
+					Expect(string(patch)).To(ContainSubstring(`{ "op": "replace", "path": "/spec/volumes/0/persistentVolumeClaim/claimName", "value": "src"}`))
+					return vmi, nil
+				})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, FailedVolumeMigrationReason)
+			})
+		})
+
+		table.DescribeTable("should clear InterfaceRequests that are satisfied", func(request v1.VirtualMachineInterfaceRequest, templateHasInterface bool) {
+			vm, _ := DefaultVirtualMachine(false)
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Status.InterfaceRequests = []v1.VirtualMachineInterfaceRequest{request}
+			if templateHasInterface {
+				vm.Spec.Template.Spec.Networks = append(vm.Spec.Template.Spec.Networks, v1.Network{
+					Name:          "blue",
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}},
+				})
+				vm.Spec.Template.Spec.Domain.Devices.Interfaces = append(vm.Spec.Template.Spec.Domain.Devices.Interfaces, v1.Interface{
+					Name:                   "blue",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				})
+			}
+
+			addVirtualMachine(vm)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.InterfaceRequests).To(BeEmpty())
+			}).Return(nil, nil)
+
+			controller.Execute()
+		},
+			table.Entry("for added interfaces",
+				v1.VirtualMachineInterfaceRequest{AddInterfaceOptions: &v1.AddInterfaceOptions{NetworkAttachmentDefinitionName: "blue-net", Name: "blue"}}, true),
+			table.Entry("for removed interfaces",
+				v1.VirtualMachineInterfaceRequest{RemoveInterfaceOptions: &v1.RemoveInterfaceOptions{Name: "blue"}}, false),
+		)
+
 		It("should not delete failed DataVolume for VirtualMachineInstance", func() {
 			vm, _ := DefaultVirtualMachine(true)
 			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
@@ -748,6 +1110,67 @@ var _ = Describe("VirtualMachine", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
 		})
 
+		It("should assign MAC addresses of the MAC pool to the created VirtualMachineInstance", func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+				Data: map[string]string{
+					virtconfig.MacPoolKey: "ranges:\n- start: 02:00:00:00:00:00\n  end: 02:00:00:00:00:01\n",
+				},
+			})
+			vm, vmi := DefaultVirtualMachine(true)
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "secondary", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+			vm.Spec.Template.Spec.Networks = []v1.Network{
+				{Name: "secondary", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "net"}}},
+			}
+			// another VM holds one of the addresses of the pool
+			otherVM, _ := DefaultVirtualMachineWithNames(false, "othervm", "othervmi")
+			otherVM.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "secondary", MacAddress: "02:00:00:00:00:00"},
+			}
+
+			addVirtualMachine(vm)
+			Expect(vmInformer.GetStore().Add(otherVM)).To(Succeed())
+
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:01"))
+			}).Return(vm, nil)
+			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:01"))
+			}).Return(vmi, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should not update the VirtualMachine if its template has the MAC addresses of the MAC pool", func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+				Data: map[string]string{
+					virtconfig.MacPoolKey: "ranges:\n- start: 02:00:00:00:00:00\n  end: 02:00:00:00:00:01\n",
+				},
+			})
+			vm, vmi := DefaultVirtualMachine(true)
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "secondary", MacAddress: "02:00:00:00:00:01", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+			vm.Spec.Template.Spec.Networks = []v1.Network{
+				{Name: "secondary", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "net"}}},
+			}
+
+			addVirtualMachine(vm)
+
+			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:01"))
+			}).Return(vmi, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
 		It("should ignore the name of a VirtualMachineInstance templates", func() {
 			vm, vmi := DefaultVirtualMachineWithNames(true, "vmname", "vminame")
 
@@ -854,6 +1277,50 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		table.DescribeTable("should stop a finished VirtualMachineInstance only if the RunStrategy restarts it", func(runStrategy v1.VirtualMachineRunStrategy, phase v1.VirtualMachineInstancePhase, shouldRestart bool) {
+			vm, vmi := DefaultVirtualMachine(true)
+			vm.Spec.Running = nil
+			vm.Spec.RunStrategy = &runStrategy
+			vmi.Status.Phase = phase
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			if shouldRestart {
+				vmiInterface.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+			}
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
+
+			controller.Execute()
+
+			if shouldRestart {
+				testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+			}
+		},
+			table.Entry("Always with VMI in phase Succeeded", v1.RunStrategyAlways, v1.Succeeded, true),
+			table.Entry("Always with VMI in phase Failed", v1.RunStrategyAlways, v1.Failed, true),
+			table.Entry("RerunOnFailure with VMI in phase Succeeded", v1.RunStrategyRerunOnFailure, v1.Succeeded, false),
+			table.Entry("RerunOnFailure with VMI in phase Failed", v1.RunStrategyRerunOnFailure, v1.Failed, true),
+			table.Entry("Once with VMI in phase Succeeded", v1.RunStrategyOnce, v1.Succeeded, false),
+			table.Entry("Once with VMI in phase Failed", v1.RunStrategyOnce, v1.Failed, false),
+		)
+
+		It("should create missing VirtualMachineInstance with RunStrategyOnce", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			runStrategy := v1.RunStrategyOnce
+			vm.Spec.Running = nil
+			vm.Spec.RunStrategy = &runStrategy
+
+			addVirtualMachine(vm)
+
+			vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
 		It("should ignore non-matching VMIs", func() {
 			vm, vmi := DefaultVirtualMachine(true)
 
@@ -1091,6 +1558,95 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		Context("Instancetype", func() {
+			var clusterInstancetype *instancetypev1alpha1.VirtualMachineClusterInstancetype
+
+			BeforeEach(func() {
+				clusterInstancetype = &instancetypev1alpha1.VirtualMachineClusterInstancetype{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster-instancetype",
+					},
+					Spec: instancetypev1alpha1.VirtualMachineInstancetypeSpec{
+						CPU: instancetypev1alpha1.CPUInstancetype{
+							Guest: uint32(2),
+						},
+						Memory: instancetypev1alpha1.MemoryInstancetype{
+							Guest: resource.MustParse("128M"),
+						},
+					},
+				}
+				_, err := kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterInstancetypes().Create(context.Background(), clusterInstancetype, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should apply the instancetype to the created VirtualMachineInstance", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+					Name: clusterInstancetype.Name,
+				}
+				// Resources are provided by the instancetype
+				vm.Spec.Template.Spec.Domain.Resources = v1.ResourceRequirements{}
+
+				addVirtualMachine(vm)
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+					createdVMI := arg.(*v1.VirtualMachineInstance)
+					Expect(createdVMI.Spec.Domain.CPU.Sockets).To(Equal(clusterInstancetype.Spec.CPU.Guest))
+					Expect(*createdVMI.Spec.Domain.Memory.Guest).To(Equal(clusterInstancetype.Spec.Memory.Guest))
+				}).Return(vmi, nil)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+
+			It("should add a fail condition if the VirtualMachineInstance conflicts with the instancetype", func() {
+				vm, _ := DefaultVirtualMachine(true)
+				vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+					Name: clusterInstancetype.Name,
+				}
+				vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{
+					Sockets: 4,
+				}
+
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(objVM.Status.Conditions).To(HaveLen(1))
+					cond := objVM.Status.Conditions[0]
+					Expect(cond.Type).To(Equal(v1.VirtualMachineFailure))
+					Expect(cond.Reason).To(Equal("FailedCreate"))
+					Expect(cond.Message).To(ContainSubstring("spec.domain.cpu.sockets"))
+				}).Return(vm, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvents(recorder, FailedCreateVirtualMachineReason)
+			})
+
+			It("should add a fail condition if the instancetype does not exist", func() {
+				vm, _ := DefaultVirtualMachine(true)
+				vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+					Name: "missing",
+				}
+
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(objVM.Status.Conditions).To(HaveLen(1))
+					Expect(objVM.Status.Conditions[0].Reason).To(Equal("FailedCreate"))
+				}).Return(vm, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvents(recorder, FailedCreateVirtualMachineReason)
+			})
+		})
+
 		Context("VM rename", func() {
 			Context("source VM", func() {
 				var vm *v1.VirtualMachine
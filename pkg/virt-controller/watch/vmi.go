@@ -42,7 +42,9 @@ import (
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util"
 	kubevirttypes "kubevirt.io/kubevirt/pkg/util/types"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
@@ -108,6 +110,14 @@ const (
 	PVCNotReadyReason = "PVCNotReady"
 	// FailedHotplugSyncReason is set when a hotplug specific failure occurs during sync
 	FailedHotplugSyncReason = "FailedHotplugSync"
+	// FailedPersistentStateCreateReason is added in an event if creating the persistent state PVC of a VM failed.
+	FailedPersistentStateCreateReason = "FailedPersistentStateCreate"
+	// SuccessfulPersistentStateCreateReason is added in an event if the persistent state PVC of a VM got created.
+	SuccessfulPersistentStateCreateReason = "SuccessfulPersistentStateCreate"
+	// SuccessfulVolumeMigrationReason is added in an event if the volumes of a running VM got copied to new claims.
+	SuccessfulVolumeMigrationReason = "SuccessfulVolumeMigration"
+	// FailedVolumeMigrationReason is added in an event if copying the volumes of a running VM to new claims failed.
+	FailedVolumeMigrationReason = "FailedVolumeMigration"
 )
 
 const failedToRenderLaunchManifestErrFormat = "failed to render launch manifest: %v"
@@ -118,7 +128,8 @@ func NewVMIController(templateService services.TemplateService,
 	pvcInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
-	dataVolumeInformer cache.SharedIndexInformer) *VMIController {
+	dataVolumeInformer cache.SharedIndexInformer,
+	clusterConfig *virtconfig.ClusterConfig) *VMIController {
 
 	c := &VMIController{
 		templateService:    templateService,
@@ -130,6 +141,7 @@ func NewVMIController(templateService services.TemplateService,
 		clientset:          clientset,
 		podExpectations:    controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
 		dataVolumeInformer: dataVolumeInformer,
+		clusterConfig:      clusterConfig,
 	}
 
 	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -150,6 +162,10 @@ func NewVMIController(templateService services.TemplateService,
 		UpdateFunc: c.updateDataVolume,
 	})
 
+	c.pvcInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: c.updatePVC,
+	})
+
 	return c
 }
 
@@ -181,6 +197,7 @@ type VMIController struct {
 	recorder           record.EventRecorder
 	podExpectations    *controller.UIDTrackingControllerExpectations
 	dataVolumeInformer cache.SharedIndexInformer
+	clusterConfig      *virtconfig.ClusterConfig
 }
 
 func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -309,6 +326,181 @@ func conditionsEqual(a []virtv1.VirtualMachineInstanceCondition, b []virtv1.Virt
 	return true
 }
 
+// updateLiveMigrationCondition sets the LiveMigratable condition and the
+// migration method of a running VMI.
+func (c *VMIController) updateLiveMigrationCondition(vmi *virtv1.VirtualMachineInstance) {
+	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+
+	condition, isBlockMigration := c.calculateLiveMigrationCondition(vmi)
+	if cond := conditionManager.GetCondition(vmi, virtv1.VirtualMachineInstanceIsMigratable); cond == nil || !reflect.DeepEqual(*cond, *condition) {
+		conditionManager.RemoveCondition(vmi, virtv1.VirtualMachineInstanceIsMigratable)
+		vmi.Status.Conditions = append(vmi.Status.Conditions, *condition)
+	}
+
+	if isBlockMigration {
+		vmi.Status.MigrationMethod = virtv1.BlockMigration
+	} else {
+		vmi.Status.MigrationMethod = virtv1.LiveMigration
+	}
+}
+
+func (c *VMIController) calculateLiveMigrationCondition(vmi *virtv1.VirtualMachineInstance) (*virtv1.VirtualMachineInstanceCondition, bool) {
+	liveMigrationCondition := virtv1.VirtualMachineInstanceCondition{
+		Type:   virtv1.VirtualMachineInstanceIsMigratable,
+		Status: k8sv1.ConditionTrue,
+	}
+
+	// Report every reason which blocks the migration, so that they do not
+	// have to be discovered one at a time. The condition reason is the one
+	// of the first check which failed.
+	var messages []string
+	notMigratable := func(reason string, message string) {
+		if liveMigrationCondition.Reason == "" {
+			liveMigrationCondition.Reason = reason
+		}
+		messages = append(messages, message)
+	}
+
+	isBlockMigration, err := c.checkVolumesForMigration(vmi)
+	if err != nil {
+		notMigratable(virtv1.VirtualMachineInstanceReasonDisksNotMigratable, err.Error())
+	}
+	if err := c.checkNetworkInterfacesForMigration(vmi); err != nil {
+		notMigratable(virtv1.VirtualMachineInstanceReasonInterfaceNotMigratable, err.Error())
+	}
+	if hasHotplugVolumes(vmi) {
+		notMigratable(virtv1.VirtualMachineInstanceReasonHotplugNotMigratable, "VMI has hotplugged disks")
+	}
+	if backup := vmi.Status.Backup; backup != nil && !backup.Completed {
+		notMigratable(virtv1.VirtualMachineInstanceReasonBackupNotMigratable, fmt.Sprintf("backup %s is in progress", backup.Name))
+	}
+
+	if len(messages) > 0 {
+		liveMigrationCondition.Status = k8sv1.ConditionFalse
+		liveMigrationCondition.Message = strings.Join(messages, "; ")
+	}
+	return &liveMigrationCondition, isBlockMigration
+}
+
+func hasHotplugVolumes(vmi *virtv1.VirtualMachineInstance) bool {
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.HotplugVolume != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *VMIController) checkNetworkInterfacesForMigration(vmi *virtv1.VirtualMachineInstance) error {
+	err := c.validatePodNetworkInterfaceForMigration(vmi)
+	if err != nil {
+		return err
+	}
+
+	err = c.validateSRIOVInterfacesForMigration(vmi)
+	if err != nil {
+		return err
+	}
+
+	if util.IsVhostUserVmi(vmi) {
+		return fmt.Errorf("cannot migrate VMI with vhostuser interfaces")
+	}
+
+	return nil
+}
+
+// validatePodNetworkInterfaceForMigration verifies that the guest can keep its
+// connectivity when the pod network address changes with the target pod.
+// Masquerade hides the pod address from the guest, while a bridge binding
+// requires the guest to renew its DHCP lease once the migration completed.
+func (c *VMIController) validatePodNetworkInterfaceForMigration(vmi *virtv1.VirtualMachineInstance) error {
+	interfacesByName := map[string]virtv1.Interface{}
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		interfacesByName[iface.Name] = iface
+	}
+
+	vmiPodNetworkName := lookupVMIPodNetworkName(vmi.Spec.Networks)
+	if vmiPodNetworkName == "" {
+		return nil
+	}
+
+	iface := interfacesByName[vmiPodNetworkName]
+	switch {
+	case iface.Masquerade != nil:
+		return nil
+	case iface.Bridge != nil && !c.clusterConfig.BridgeLiveMigrationEnabled():
+		return fmt.Errorf("BridgeLiveMigration feature-gate is closed, can't migrate VMI with bridge binding on the pod network")
+	case iface.Bridge != nil:
+		return nil
+	}
+	return fmt.Errorf("cannot migrate VMI which does not use masquerade or bridge to connect to the pod network")
+}
+
+func lookupVMIPodNetworkName(networks []virtv1.Network) string {
+	for _, network := range networks {
+		if network.Pod != nil {
+			return network.Name
+		}
+	}
+
+	return ""
+}
+
+func (c *VMIController) validateSRIOVInterfacesForMigration(vmi *virtv1.VirtualMachineInstance) error {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.SRIOV != nil && !c.clusterConfig.SRIOVLiveMigrationEnabled() {
+			return fmt.Errorf("SRIOVLiveMigration feature-gate is closed, can't migrate VMI with SRIOV interfaces")
+		}
+	}
+
+	return nil
+}
+
+func (c *VMIController) checkVolumesForMigration(vmi *virtv1.VirtualMachineInstance) (blockMigrate bool, err error) {
+	// Check if all VMI volumes can be shared between the source and the destination
+	// of a live migration. blockMigrate will be returned as false, only if all volumes
+	// are shared and the VMI has no local disks
+	// Some combinations of disks makes the VMI no suitable for live migration.
+	// A relevant error will be returned in this case.
+	// Volumes which are migrated to new claims are always copied to the destination.
+	migratedVolumes := make(map[string]bool)
+	if vmi.Status.VolumeMigration != nil {
+		for _, migratedVolume := range vmi.Status.VolumeMigration.MigratedVolumes {
+			migratedVolumes[migratedVolume.VolumeName] = true
+		}
+	}
+	for _, volume := range vmi.Spec.Volumes {
+		volSrc := volume.VolumeSource
+		if migratedVolumes[volume.Name] {
+			blockMigrate = true
+		} else if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil {
+			var volName string
+			if volSrc.PersistentVolumeClaim != nil {
+				volName = volSrc.PersistentVolumeClaim.ClaimName
+			} else {
+				volName = volSrc.DataVolume.Name
+			}
+			obj, exists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vmi.Namespace, volName))
+			if err != nil {
+				return blockMigrate, err
+			} else if !exists {
+				return blockMigrate, fmt.Errorf("persistentvolumeclaim %v not found", volName)
+			}
+			if !kubevirttypes.IsPVCShared(obj.(*k8sv1.PersistentVolumeClaim)) {
+				return true, fmt.Errorf("cannot migrate VMI: PVC %v is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)", volName)
+			}
+		} else if volSrc.HostDisk != nil {
+			shared := volSrc.HostDisk.Shared != nil && *volSrc.HostDisk.Shared
+			if !shared {
+				return true, fmt.Errorf("cannot migrate VMI with non-shared HostDisk")
+			}
+		} else {
+			blockMigrate = true
+		}
+	}
+	return
+}
+
 func (c *VMIController) setLauncherContainerInfo(vmi *virtv1.VirtualMachineInstance, curPodImage string) *virtv1.VirtualMachineInstance {
 
 	if curPodImage != "" && curPodImage != c.templateService.GetLauncherImage() {
@@ -494,6 +686,7 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 		if vmiPodExists {
 			c.updateVolumeStatus(vmiCopy, pod)
 		}
+		c.updateLiveMigrationCondition(vmiCopy)
 		if !reflect.DeepEqual(vmiCopy.Status.VolumeStatus, vmi.Status.VolumeStatus) {
 			// VolumeStatus changed which means either removed or added volumes.
 			newVolumeStatus, err := json.Marshal(vmiCopy.Status.VolumeStatus)
@@ -530,6 +723,15 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 			log.Log.V(3).Object(vmi).Infof("Patching VMI conditions")
 		}
 
+		if vmiCopy.Status.MigrationMethod != vmi.Status.MigrationMethod {
+			if vmi.Status.MigrationMethod == "" {
+				patchOps = append(patchOps, fmt.Sprintf(`{ "op": "add", "path": "/status/migrationMethod", "value": "%s" }`, vmiCopy.Status.MigrationMethod))
+			} else {
+				patchOps = append(patchOps, fmt.Sprintf(`{ "op": "test", "path": "/status/migrationMethod", "value": "%s" }`, vmi.Status.MigrationMethod))
+				patchOps = append(patchOps, fmt.Sprintf(`{ "op": "replace", "path": "/status/migrationMethod", "value": "%s" }`, vmiCopy.Status.MigrationMethod))
+			}
+		}
+
 		if !reflect.DeepEqual(vmiCopy.Status.ActivePods, vmi.Status.ActivePods) {
 			newPods, err := json.Marshal(vmiCopy.Status.ActivePods)
 			if err != nil {
@@ -768,10 +970,64 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 				}
 			}
 		}
+
+		if pod.DeletionTimestamp == nil && vmi.IsRunning() && !isMigrating(vmi) {
+			if err := c.syncPodNetworksAnnotation(vmi, pod); err != nil {
+				return &syncErrorImpl{fmt.Errorf("failed to update multus networks of the virt-launcher pod: %v", err), FailedHotplugSyncReason}
+			}
+		}
 	}
 	return nil
 }
 
+func isMigrating(vmi *virtv1.VirtualMachineInstance) bool {
+	return vmi.Status.MigrationState != nil && !vmi.Status.MigrationState.Completed
+}
+
+// syncPodNetworksAnnotation propagates networks hotplugged into or unplugged from a
+// running VMI to the multus networks annotation of its virt-launcher pod.
+func (c *VMIController) syncPodNetworksAnnotation(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) error {
+	currentAnnotation, hasAnnotation := pod.Annotations[services.MultusNetworksAnnotation]
+	if _, userDefined := vmi.Annotations[services.MultusNetworksAnnotation]; userDefined && !hasSecondaryMultusNetworks(vmi) {
+		// the annotation was copied from the VMI and is not managed by KubeVirt
+		return nil
+	}
+
+	newAnnotation, err := services.GenerateHotplugMultusCNIAnnotation(vmi, currentAnnotation)
+	if err != nil {
+		return err
+	}
+	if newAnnotation == currentAnnotation {
+		return nil
+	}
+
+	path := "/metadata/annotations/" + strings.Replace(services.MultusNetworksAnnotation, "/", "~1", -1)
+	var patch string
+	switch {
+	case newAnnotation == "":
+		patch = fmt.Sprintf(`[{ "op": "test", "path": "%s", "value": %q }, { "op": "remove", "path": "%s" }]`, path, currentAnnotation, path)
+	case hasAnnotation:
+		patch = fmt.Sprintf(`[{ "op": "test", "path": "%s", "value": %q }, { "op": "replace", "path": "%s", "value": %q }]`, path, currentAnnotation, path, newAnnotation)
+	case pod.Annotations == nil:
+		patch = fmt.Sprintf(`[{ "op": "add", "path": "/metadata/annotations", "value": {%q: %q} }]`, services.MultusNetworksAnnotation, newAnnotation)
+	default:
+		patch = fmt.Sprintf(`[{ "op": "add", "path": "%s", "value": %q }]`, path, newAnnotation)
+	}
+
+	log.Log.Object(vmi).V(3).Infof("Updating multus networks of pod %s: %s", pod.Name, newAnnotation)
+	_, err = c.clientset.CoreV1().Pods(pod.Namespace).Patch(context.Background(), pod.Name, types.JSONPatchType, []byte(patch), v1.PatchOptions{})
+	return err
+}
+
+func hasSecondaryMultusNetworks(vmi *virtv1.VirtualMachineInstance) bool {
+	for _, network := range vmi.Spec.Networks {
+		if network.Multus != nil && !network.Multus.Default {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *VMIController) handleSyncDataVolumes(vmi *virtv1.VirtualMachineInstance, dataVolumes []*cdiv1.DataVolume) (bool, bool, syncError) {
 
 	ready := true
@@ -1051,6 +1307,46 @@ func (c *VMIController) resolveControllerRef(namespace string, controllerRef *v1
 	return vmi.(*virtv1.VirtualMachineInstance)
 }
 
+// updatePVC enqueues the VMIs using an expanded PVC, so that they pick up the new capacity
+func (c *VMIController) updatePVC(old, cur interface{}) {
+	curPVC := cur.(*k8sv1.PersistentVolumeClaim)
+	oldPVC := old.(*k8sv1.PersistentVolumeClaim)
+	if curPVC.ResourceVersion == oldPVC.ResourceVersion {
+		return
+	}
+	if reflect.DeepEqual(curPVC.Status.Capacity, oldPVC.Status.Capacity) {
+		return
+	}
+
+	vmis, err := c.listVMIsMatchingPVC(curPVC.Namespace, curPVC.Name)
+	if err != nil {
+		log.Log.V(4).Object(curPVC).Errorf("Error encountered during pvc update: %v", err)
+		return
+	}
+	for _, vmi := range vmis {
+		log.Log.V(4).Object(curPVC).Infof("PVC capacity updated for vmi %s", vmi.Name)
+		c.enqueueVirtualMachine(vmi)
+	}
+}
+
+func (c *VMIController) listVMIsMatchingPVC(namespace string, claimName string) ([]*virtv1.VirtualMachineInstance, error) {
+	objs, err := c.vmiInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil, err
+	}
+	vmis := []*virtv1.VirtualMachineInstance{}
+	for _, obj := range objs {
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		for i := range vmi.Spec.Volumes {
+			if getVolumeClaimName(&vmi.Spec.Volumes[i]) == claimName {
+				vmis = append(vmis, vmi)
+				break
+			}
+		}
+	}
+	return vmis, nil
+}
+
 // takes a namespace and returns all Pods from the pod cache which run in this namespace
 func (c *VMIController) listVMIsMatchingDataVolume(namespace string, dataVolumeName string) ([]*virtv1.VirtualMachineInstance, error) {
 	objs, err := c.vmiInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
@@ -1213,14 +1509,34 @@ func (c *VMIController) getHotplugVolumes(vmi *virtv1.VirtualMachineInstance, vi
 	for _, podVolume := range podVolumes {
 		podVolumeMap[podVolume.Name] = podVolume
 	}
+	cdRoms := make(map[string]bool)
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.CDRom != nil {
+			cdRoms[disk.Name] = true
+		}
+	}
 	for _, vmiVolume := range vmiVolumes {
-		if _, ok := podVolumeMap[vmiVolume.Name]; !ok && (vmiVolume.DataVolume != nil || vmiVolume.PersistentVolumeClaim != nil) {
+		if vmiVolume.DataVolume == nil && vmiVolume.PersistentVolumeClaim == nil {
+			continue
+		}
+		podVolume, ok := podVolumeMap[vmiVolume.Name]
+		if !ok || (cdRoms[vmiVolume.Name] && isInjectedMedia(vmi, &vmiVolume, &podVolume)) {
 			hotplugVolumes = append(hotplugVolumes, vmiVolume.DeepCopy())
 		}
 	}
 	return hotplugVolumes
 }
 
+// isInjectedMedia returns true if the media of a CD-ROM was swapped for a
+// claim other than the one the launcher pod was started with.
+func isInjectedMedia(vmi *virtv1.VirtualMachineInstance, vmiVolume *virtv1.Volume, podVolume *k8sv1.Volume) bool {
+	if vmi.Status.VolumeMigration != nil || podVolume.PersistentVolumeClaim == nil {
+		// volumes being migrated switch their claims before the pod does
+		return false
+	}
+	return podVolume.PersistentVolumeClaim.ClaimName != getVolumeClaimName(vmiVolume)
+}
+
 func (c *VMIController) cleanupWaitForFirstConsumerTemporaryPods(vmi *virtv1.VirtualMachineInstance) error {
 	// Get all pods from the namespace
 	pods, err := c.listPodsFromNamespace(vmi.Namespace)
@@ -1643,6 +1959,9 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 				}
 			}
 		}
+		if claimInfo := c.getPersistentVolumeClaimInfo(vmi.Namespace, &vmi.Spec.Volumes[i]); claimInfo != nil {
+			status.PersistentVolumeClaimInfo = claimInfo
+		}
 		newStatus = append(newStatus, status)
 	}
 
@@ -1670,6 +1989,24 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 	return nil
 }
 
+// getPersistentVolumeClaimInfo returns the information about the PVC backing the volume, if the volume is backed by a PVC
+func (c *VMIController) getPersistentVolumeClaimInfo(namespace string, volume *virtv1.Volume) *virtv1.PersistentVolumeClaimInfo {
+	claimName := getVolumeClaimName(volume)
+	if claimName == "" {
+		return nil
+	}
+	obj, exists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, claimName))
+	if err != nil || !exists {
+		return nil
+	}
+	pvc := obj.(*k8sv1.PersistentVolumeClaim).DeepCopy()
+	return &virtv1.PersistentVolumeClaimInfo{
+		AccessModes: pvc.Spec.AccessModes,
+		VolumeMode:  pvc.Spec.VolumeMode,
+		Capacity:    pvc.Status.Capacity,
+	}
+}
+
 func (c *VMIController) canMoveToAttachedPhase(currentPhase virtv1.VolumePhase) bool {
 	return currentPhase == "" || currentPhase == virtv1.VolumeBound || currentPhase == virtv1.VolumePending ||
 		currentPhase == virtv1.HotplugVolumeAttachedToNode
@@ -50,6 +50,8 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/net/namescheme"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
@@ -199,6 +201,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			recorder,
 			virtClient,
 			dataVolumeInformer,
+			config,
 		)
 		// Wrap our workqueue to have a way to detect when we are done processing updates
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
@@ -645,6 +648,9 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = phase
 			vmi.DeletionTimestamp = now()
+			if phase == v1.Running {
+				markAsMigratable(vmi)
+			}
 
 			// 2 pods are owned by VMI
 			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
@@ -1247,6 +1253,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		It("should add outdated label if pod's image is outdated and VMI is in running state", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = v1.Running
+			markAsMigratable(vmi)
 			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
 			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue}}
 
@@ -1259,7 +1266,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			addActivePods(vmi, pod.UID, "")
 			podFeeder.Add(pod)
 
-			patch := `[ { "op": "test", "path": "/status/conditions", "value": null }, { "op": "replace", "path": "/status/conditions", "value": [{"type":"Ready","status":"True","lastProbeTime":null,"lastTransitionTime":null}] }, { "op": "add", "path": "/status/launcherContainerImageVersion", "value": "madeup" }, { "op": "add", "path": "/metadata/labels", "value": {"kubevirt.io/outdatedLauncherImage":""} } ]`
+			patch := `[ { "op": "test", "path": "/status/conditions", "value": [{"type":"LiveMigratable","status":"True","lastProbeTime":null,"lastTransitionTime":null}] }, { "op": "replace", "path": "/status/conditions", "value": [{"type":"LiveMigratable","status":"True","lastProbeTime":null,"lastTransitionTime":null},{"type":"Ready","status":"True","lastProbeTime":null,"lastTransitionTime":null}] }, { "op": "add", "path": "/status/launcherContainerImageVersion", "value": "madeup" }, { "op": "add", "path": "/metadata/labels", "value": {"kubevirt.io/outdatedLauncherImage":""} } ]`
 
 			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, []byte(patch)).Return(vmi, nil)
 
@@ -1268,6 +1275,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		It("should remove outdated label if pod's image up-to-date and VMI is in running state", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = v1.Running
+			markAsMigratable(vmi)
 			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
 			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue}}
 
@@ -1286,7 +1294,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			addActivePods(vmi, pod.UID, "")
 			podFeeder.Add(pod)
 
-			patch := `[ { "op": "test", "path": "/status/conditions", "value": null }, { "op": "replace", "path": "/status/conditions", "value": [{"type":"Ready","status":"True","lastProbeTime":null,"lastTransitionTime":null}] }, { "op": "add", "path": "/status/launcherContainerImageVersion", "value": "a" }, { "op": "test", "path": "/metadata/labels", "value": {"kubevirt.io/outdatedLauncherImage":""} }, { "op": "replace", "path": "/metadata/labels", "value": {} } ]`
+			patch := `[ { "op": "test", "path": "/status/conditions", "value": [{"type":"LiveMigratable","status":"True","lastProbeTime":null,"lastTransitionTime":null}] }, { "op": "replace", "path": "/status/conditions", "value": [{"type":"LiveMigratable","status":"True","lastProbeTime":null,"lastTransitionTime":null},{"type":"Ready","status":"True","lastProbeTime":null,"lastTransitionTime":null}] }, { "op": "add", "path": "/status/launcherContainerImageVersion", "value": "a" }, { "op": "test", "path": "/metadata/labels", "value": {"kubevirt.io/outdatedLauncherImage":""} }, { "op": "replace", "path": "/metadata/labels", "value": {} } ]`
 
 			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, []byte(patch)).Return(vmi, nil)
 
@@ -1296,6 +1304,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		It("should add a ready condition if it is present on the pod and the VMI is in running state", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = v1.Running
+			markAsMigratable(vmi)
 			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
 			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue}}
 
@@ -1303,7 +1312,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			addActivePods(vmi, pod.UID, "")
 			podFeeder.Add(pod)
 
-			patch := `[ { "op": "test", "path": "/status/conditions", "value": null }, { "op": "replace", "path": "/status/conditions", "value": [{"type":"Ready","status":"True","lastProbeTime":null,"lastTransitionTime":null}] } ]`
+			patch := `[ { "op": "test", "path": "/status/conditions", "value": [{"type":"LiveMigratable","status":"True","lastProbeTime":null,"lastTransitionTime":null}] }, { "op": "replace", "path": "/status/conditions", "value": [{"type":"LiveMigratable","status":"True","lastProbeTime":null,"lastTransitionTime":null},{"type":"Ready","status":"True","lastProbeTime":null,"lastTransitionTime":null}] } ]`
 			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, []byte(patch)).Return(vmi, nil)
 
 			controller.Execute()
@@ -1312,6 +1321,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		It("should indicate on the ready condition if the pod is terminating", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = v1.Running
+			markAsMigratable(vmi)
 			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
 			pod.DeletionTimestamp = now()
 			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue}}
@@ -1330,8 +1340,8 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 				patchedVMI := &v1.VirtualMachineInstance{}
 				err = json.Unmarshal(vmiBytes, patchedVMI)
 				Expect(err).ToNot(HaveOccurred())
-				Expect(patchedVMI.Status.Conditions).To(HaveLen(1))
-				cond := patchedVMI.Status.Conditions[0]
+				Expect(patchedVMI.Status.Conditions).To(HaveLen(2))
+				cond := patchedVMI.Status.Conditions[1]
 				Expect(cond.Reason).To(Equal(v1.PodTerminatingReason))
 				Expect(string(cond.Type)).To(Equal(string(k8sv1.PodReady)))
 				Expect(cond.Status).To(Equal(k8sv1.ConditionFalse))
@@ -1341,9 +1351,27 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			testutils.ExpectEvent(recorder, v1.PodTerminatingReason)
 		})
 
+		It("should compute the migratable condition of a running VMI", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultSlirpNetworkInterface()}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+
+			addVirtualMachine(vmi)
+			addActivePods(vmi, pod.UID, "")
+			podFeeder.Add(pod)
+
+			patch := `[ { "op": "test", "path": "/status/conditions", "value": null }, { "op": "replace", "path": "/status/conditions", "value": [{"type":"LiveMigratable","status":"False","lastProbeTime":null,"lastTransitionTime":null,"reason":"InterfaceNotLiveMigratable","message":"cannot migrate VMI which does not use masquerade or bridge to connect to the pod network"}] }, { "op": "add", "path": "/status/migrationMethod", "value": "LiveMigration" } ]`
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, []byte(patch)).Return(vmi, nil)
+
+			controller.Execute()
+		})
+
 		It("should add active pods to status if VMI is in running state", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = v1.Running
+			markAsMigratable(vmi)
 			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
 			pod.UID = "someUID"
 			pod.Spec.NodeName = "someHost"
@@ -1392,6 +1420,9 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		table.DescribeTable("should do nothing if the vmi is handed over to virt-handler, the pod disappears", func(phase v1.VirtualMachineInstancePhase) {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = phase
+			if phase == v1.Running {
+				markAsMigratable(vmi)
+			}
 
 			addVirtualMachine(vmi)
 
@@ -1418,6 +1449,82 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		)
 	})
 
+	Context("hotplug network interfaces", func() {
+		newRunningVMIWithNetworks := func(networkNames ...string) (*v1.VirtualMachineInstance, *k8sv1.Pod) {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			for _, name := range networkNames {
+				vmi.Spec.Networks = append(vmi.Spec.Networks, v1.Network{
+					Name:          name,
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: name + "-net"}},
+				})
+			}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue}}
+			pod.Spec.Containers = append(pod.Spec.Containers, k8sv1.Container{
+				Image: controller.templateService.GetLauncherImage(),
+				Name:  "compute",
+			})
+			markAsReady(vmi)
+			vmi.Status.LauncherContainerImageVersion = controller.templateService.GetLauncherImage()
+			return vmi, pod
+		}
+
+		expectPodPatch := func(pod *k8sv1.Pod, expectedPatch string) *int {
+			patchCount := 0
+			kubeClient.Fake.PrependReactor("patch", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				patch, ok := action.(testing.PatchAction)
+				Expect(ok).To(BeTrue())
+				Expect(patch.GetName()).To(Equal(pod.Name))
+				Expect(string(patch.GetPatch())).To(Equal(expectedPatch))
+				patchCount++
+				return true, pod, nil
+			})
+			return &patchCount
+		}
+
+		It("should add hotplugged networks to the multus annotation of the pod", func() {
+			vmi, pod := newRunningVMIWithNetworks("red", "blue")
+			pod.Annotations[services.MultusNetworksAnnotation] = `[{"interface":"net1","name":"red-net","namespace":"default"}]`
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			newAnnotation := `[{"interface":"net1","name":"red-net","namespace":"default"},{"interface":"` +
+				namescheme.HotplugPodInterfaceName("blue") + `","name":"blue-net","namespace":"default"}]`
+			patchCount := expectPodPatch(pod, fmt.Sprintf(`[{ "op": "test", "path": "/metadata/annotations/k8s.v1.cni.cncf.io~1networks", "value": %q }, { "op": "replace", "path": "/metadata/annotations/k8s.v1.cni.cncf.io~1networks", "value": %q }]`,
+				pod.Annotations[services.MultusNetworksAnnotation], newAnnotation))
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).Return(vmi, nil).AnyTimes()
+
+			controller.Execute()
+			Expect(*patchCount).To(Equal(1))
+		})
+
+		It("should remove the multus annotation when the last secondary network is unplugged", func() {
+			vmi, pod := newRunningVMIWithNetworks()
+			pod.Annotations[services.MultusNetworksAnnotation] = `[{"interface":"net1","name":"red-net","namespace":"default"}]`
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			patchCount := expectPodPatch(pod, fmt.Sprintf(`[{ "op": "test", "path": "/metadata/annotations/k8s.v1.cni.cncf.io~1networks", "value": %q }, { "op": "remove", "path": "/metadata/annotations/k8s.v1.cni.cncf.io~1networks" }]`,
+				pod.Annotations[services.MultusNetworksAnnotation]))
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).Return(vmi, nil).AnyTimes()
+
+			controller.Execute()
+			Expect(*patchCount).To(Equal(1))
+		})
+
+		It("should not touch the pod if the networks did not change", func() {
+			vmi, pod := newRunningVMIWithNetworks("red")
+			pod.Annotations[services.MultusNetworksAnnotation] = `[{"interface":"net1","name":"red-net","namespace":"default"}]`
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).Return(vmi, nil).AnyTimes()
+
+			controller.Execute()
+		})
+	})
+
 	Context("hotplug volume", func() {
 		It("Should find vmi, from virt-launcher pod", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
@@ -2026,6 +2133,29 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			table.Entry("should return multiple volumes if vmi has multiple more than virtlauncher, with matching volumes", makeK8sVolumes(1, 3), makeVolumes(1, 2, 3, 4, 5), 2, 4, 5),
 		)
 
+		It("getHotplugVolumes should return the media injected into a CD-ROM in place of the pod claim", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			for _, volume := range makeVolumes(1, 2) {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, *volume)
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+					Name: volume.Name,
+					DiskDevice: v1.DiskDevice{
+						CDRom: &v1.CDRomTarget{},
+					},
+				})
+			}
+			virtlauncherPod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			virtlauncherPod.Spec.Volumes = makeK8sVolumes(1, 2)
+			virtlauncherPod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName = "virtio-win"
+			res := controller.getHotplugVolumes(vmi, virtlauncherPod)
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].Name).To(Equal("volume2"))
+
+			By("ignoring the claims switched by a volume migration")
+			vmi.Status.VolumeMigration = &v1.VolumeMigrationState{}
+			Expect(controller.getHotplugVolumes(vmi, virtlauncherPod)).To(BeEmpty())
+		})
+
 		truncateSprintf := func(str string, args ...interface{}) string {
 			n := strings.Count(str, "%d")
 			return fmt.Sprintf(str, args[:n]...)
@@ -2048,6 +2178,15 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			return res
 		}
 
+		withClaimInfo := func(statuses []v1.VolumeStatus) []v1.VolumeStatus {
+			for i := range statuses {
+				statuses[i].PersistentVolumeClaimInfo = &v1.PersistentVolumeClaimInfo{
+					AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadOnlyMany},
+				}
+			}
+			return statuses
+		}
+
 		makeVolumeStatusesForUpdate := func(indexes ...int) []v1.VolumeStatus {
 			return makeVolumeStatusesForUpdateWithMessage("test-pod%d", "abcd%d", v1.HotplugVolumeAttachedToNode, "Created hotplug attachment pod test-pod%d, for volume volume%d", SuccessfulCreatePodReason, indexes...)
 		}
@@ -2095,14 +2234,14 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 				makeVolumes(0),
 				[]int{},
 				[]int{0},
-				makeVolumeStatusesForUpdateWithMessage("", "", v1.VolumeBound, "PVC is in phase Bound", PVCNotReadyReason, 0),
+				withClaimInfo(makeVolumeStatusesForUpdateWithMessage("", "", v1.VolumeBound, "PVC is in phase Bound", PVCNotReadyReason, 0)),
 				[]string{}),
 			table.Entry("should update volume status, if a existing volume is changed, and pod does not exist",
 				makeVolumeStatusesForUpdateWithMessage("", "", v1.VolumePending, "PVC is in phase Pending", PVCNotReadyReason, 0),
 				makeVolumes(0),
 				[]int{},
 				[]int{0},
-				makeVolumeStatusesForUpdateWithMessage("", "", v1.VolumeBound, "PVC is in phase Bound", PVCNotReadyReason, 0),
+				withClaimInfo(makeVolumeStatusesForUpdateWithMessage("", "", v1.VolumeBound, "PVC is in phase Bound", PVCNotReadyReason, 0)),
 				[]string{}),
 			table.Entry("should keep status, if volume removed and if pod still exists",
 				makeVolumeStatusesForUpdate(0),
@@ -2120,6 +2259,45 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 				[]string{}),
 		)
 
+		It("should record the PVC information in the volume status", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{*makeVolumes(0)[0]}
+			virtlauncherPod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			virtlauncherPod.Spec.Volumes = makeK8sVolumes(0)
+			filesystem := k8sv1.PersistentVolumeFilesystem
+			pvc := NewHotplugPVC("claim0", k8sv1.NamespaceDefault, k8sv1.ClaimBound)
+			pvc.Spec.VolumeMode = &filesystem
+			pvc.Status.Capacity = k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("2Gi")}
+			pvcInformer.GetIndexer().Add(pvc)
+
+			Expect(controller.updateVolumeStatus(vmi, virtlauncherPod)).To(Succeed())
+			Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+			Expect(vmi.Status.VolumeStatus[0].PersistentVolumeClaimInfo).To(Equal(&v1.PersistentVolumeClaimInfo{
+				AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadOnlyMany},
+				VolumeMode:  &filesystem,
+				Capacity:    k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("2Gi")},
+			}))
+		})
+
+		table.DescribeTable("should enqueue the VMIs using a PVC", func(claimName string, oldCapacity, newCapacity string, expectedLen int) {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{*makeVolumes(0)[0]}
+			vmiInformer.GetIndexer().Add(vmi)
+			oldPVC := NewHotplugPVC(claimName, vmi.Namespace, k8sv1.ClaimBound)
+			oldPVC.ResourceVersion = "1"
+			oldPVC.Status.Capacity = k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse(oldCapacity)}
+			newPVC := oldPVC.DeepCopy()
+			newPVC.ResourceVersion = "2"
+			newPVC.Status.Capacity = k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse(newCapacity)}
+
+			controller.updatePVC(oldPVC, newPVC)
+			Expect(controller.Queue.Len()).To(Equal(expectedLen))
+		},
+			table.Entry("when its capacity changed", "claim0", "1Gi", "2Gi", 1),
+			table.Entry("not when its capacity did not change", "claim0", "1Gi", "1Gi", 0),
+			table.Entry("not when no VMI uses it", "claim1", "1Gi", "2Gi", 0),
+		)
+
 		It("Should properly create attachmentpod, if correct volume and disk are added", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			volumes := make([]v1.Volume, 0)
@@ -2202,7 +2380,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			addVirtualMachine(vmi)
 			podInformer.GetIndexer().Add(virtlauncherPod)
 			//Modify by adding a new hotplugged disk
-			patch := `[ { "op": "test", "path": "/status/volumeStatus", "value": [{"name":"existing","target":""}] }, { "op": "replace", "path": "/status/volumeStatus", "value": [{"name":"existing","target":""},{"name":"hotplug","target":"","phase":"Bound","reason":"PVCNotReady","message":"PVC is in phase Bound","hotplugVolume":{}}] } ]`
+			patch := `[ { "op": "test", "path": "/status/volumeStatus", "value": [{"name":"existing","target":""}] }, { "op": "replace", "path": "/status/volumeStatus", "value": [{"name":"existing","target":"","persistentVolumeClaimInfo":{}},{"name":"hotplug","target":"","phase":"Bound","reason":"PVCNotReady","message":"PVC is in phase Bound","hotplugVolume":{},"persistentVolumeClaimInfo":{}}] }, { "op": "test", "path": "/status/conditions", "value": null }, { "op": "replace", "path": "/status/conditions", "value": [{"type":"LiveMigratable","status":"False","lastProbeTime":null,"lastTransitionTime":null,"reason":"DisksNotLiveMigratable","message":"cannot migrate VMI: PVC existing is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode); VMI has hotplugged disks"}] }, { "op": "add", "path": "/status/migrationMethod", "value": "BlockMigration" } ]`
 			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, []byte(patch)).Return(vmi, nil)
 			controller.Execute()
 			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
@@ -2291,13 +2469,543 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			addVirtualMachine(vmi)
 			podInformer.GetIndexer().Add(virtlauncherPod)
 			//Modify by adding a new hotplugged disk
-			patch := `[ { "op": "test", "path": "/status/volumeStatus", "value": [{"name":"existing","target":""},{"name":"hotplug","target":"","hotplugVolume":{"attachPodName":"hp-volume-hotplug","attachPodUID":"abcd"}}] }, { "op": "replace", "path": "/status/volumeStatus", "value": [{"name":"existing","target":""},{"name":"hotplug","target":"","phase":"Detaching","hotplugVolume":{"attachPodName":"hp-volume-hotplug","attachPodUID":"abcd"}}] } ]`
+			patch := `[ { "op": "test", "path": "/status/volumeStatus", "value": [{"name":"existing","target":""},{"name":"hotplug","target":"","hotplugVolume":{"attachPodName":"hp-volume-hotplug","attachPodUID":"abcd"}}] }, { "op": "replace", "path": "/status/volumeStatus", "value": [{"name":"existing","target":"","persistentVolumeClaimInfo":{}},{"name":"hotplug","target":"","phase":"Detaching","hotplugVolume":{"attachPodName":"hp-volume-hotplug","attachPodUID":"abcd"}}] }, { "op": "test", "path": "/status/conditions", "value": null }, { "op": "replace", "path": "/status/conditions", "value": [{"type":"LiveMigratable","status":"False","lastProbeTime":null,"lastTransitionTime":null,"reason":"DisksNotLiveMigratable","message":"cannot migrate VMI: PVC existing is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode); VMI has hotplugged disks"}] }, { "op": "add", "path": "/status/migrationMethod", "value": "BlockMigration" } ]`
 			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, []byte(patch)).Return(vmi, nil)
 			controller.Execute()
 			testutils.ExpectEvent(recorder, SuccessfulDeletePodReason)
 			Expect(vmi.Status.Phase).To(Equal(v1.Running))
 		})
 	})
+
+	Context("check if migratable", func() {
+
+		var testBlockPvc *k8sv1.PersistentVolumeClaim
+
+		BeforeEach(func() {
+			// create a test block pvc
+			mode := k8sv1.PersistentVolumeBlock
+			testBlockPvc = &k8sv1.PersistentVolumeClaim{
+				TypeMeta:   metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: k8sv1.NamespaceDefault, Name: "testblock"},
+				Spec: k8sv1.PersistentVolumeClaimSpec{
+					VolumeMode:  &mode,
+					AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany},
+				},
+			}
+
+		})
+		It("should block migrate non-shared disks ", func() {
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{
+					Name: "mydisk",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						Ephemeral: &v1.EphemeralVolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "testclaim",
+							},
+						},
+					},
+				},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+		It("should migrate shared disks without blockMigration flag", func() {
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{
+					Name: "mydisk",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testblock",
+						},
+					},
+				},
+			}
+
+			Expect(pvcInformer.GetIndexer().Add(testBlockPvc)).To(Succeed())
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeFalse())
+			Expect(err).To(BeNil())
+		})
+		It("should fail migration for non-shared PVCs", func() {
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{
+					Name: "mydisk",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testblock",
+						},
+					},
+				},
+			}
+
+			testBlockPvc.Spec.AccessModes = []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce}
+
+			Expect(pvcInformer.GetIndexer().Add(testBlockPvc)).To(Succeed())
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI: PVC testblock is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)")))
+		})
+		It("should block migrate non-shared PVCs which are migrated to new claims", func() {
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testblock",
+						},
+					},
+				},
+			}
+			vmi.Status.VolumeMigration = &v1.VolumeMigrationState{
+				MigrationName:   "volume-migration",
+				MigratedVolumes: []v1.MigratedVolume{{VolumeName: "myvolume", SourceClaim: "source", DestinationClaim: "testblock"}},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("should fail migration for non-shared data volume PVCs", func() {
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{
+					Name: "mydisk",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{
+							Name: "testblock",
+						},
+					},
+				},
+			}
+
+			testBlockPvc.Spec.AccessModes = []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce}
+
+			Expect(pvcInformer.GetIndexer().Add(testBlockPvc)).To(Succeed())
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI: PVC testblock is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)")))
+		})
+		It("should be allowed to migrate a mix of shared and non-shared disks", func() {
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{
+					Name: "mydisk",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				},
+				{
+					Name: "mydisk1",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testblock",
+						},
+					},
+				},
+				{
+					Name: "myvolume1",
+					VolumeSource: v1.VolumeSource{
+						Ephemeral: &v1.EphemeralVolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "testclaim",
+							},
+						},
+					},
+				},
+			}
+
+			Expect(pvcInformer.GetIndexer().Add(testBlockPvc)).To(Succeed())
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+		It("should be allowed to migrate a mix of non-shared and shared disks", func() {
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{
+					Name: "mydisk",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				},
+				{
+					Name: "mydisk1",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume1",
+					VolumeSource: v1.VolumeSource{
+						Ephemeral: &v1.EphemeralVolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "testclaim",
+							},
+						},
+					},
+				},
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testblock",
+						},
+					},
+				},
+			}
+
+			Expect(pvcInformer.GetIndexer().Add(testBlockPvc)).To(Succeed())
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+		It("should be allowed to live-migrate shared HostDisks ", func() {
+			_true := true
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{
+					Name: "myvolume",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						HostDisk: &v1.HostDisk{
+							Path:     "/var/run/kubevirt-private/vmi-disks/volume3/disk.img",
+							Type:     v1.HostDiskExistsOrCreate,
+							Capacity: resource.MustParse("1Gi"),
+							Shared:   &_true,
+						},
+					},
+				},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeFalse())
+			Expect(err).To(BeNil())
+		})
+		It("should not be allowed to live-migrate shared and non-shared HostDisks ", func() {
+			_true := true
+			_false := false
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{
+					Name: "mydisk",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				},
+				{
+					Name: "mydisk1",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						HostDisk: &v1.HostDisk{
+							Path:     "/var/run/kubevirt-private/vmi-disks/volume3/disk.img",
+							Type:     v1.HostDiskExistsOrCreate,
+							Capacity: resource.MustParse("1Gi"),
+							Shared:   &_true,
+						},
+					},
+				},
+				{
+					Name: "myvolume1",
+					VolumeSource: v1.VolumeSource{
+						HostDisk: &v1.HostDisk{
+							Path:     "/var/run/kubevirt-private/vmi-disks/volume31/disk.img",
+							Type:     v1.HostDiskExistsOrCreate,
+							Capacity: resource.MustParse("1Gi"),
+							Shared:   &_false,
+						},
+					},
+				},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with non-shared HostDisk")))
+		})
+
+		Context("with network configuration", func() {
+			It("should block migration for bridge binding assigned to the pod network", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				interface_name := "interface_name"
+
+				vmi.Spec.Networks = []v1.Network{
+					{
+						Name:          interface_name,
+						NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
+					},
+				}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					{
+						Name: interface_name,
+						InterfaceBindingMethod: v1.InterfaceBindingMethod{
+							Bridge: &v1.InterfaceBridge{},
+						},
+					},
+				}
+
+				err := controller.checkNetworkInterfacesForMigration(vmi)
+				Expect(err).To(HaveOccurred())
+			})
+			It("should not block migration for bridge binding assigned to the pod network when feature-gate BridgeLiveMigration is on", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+				config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+					Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.BridgeLiveMigrationGate},
+				})
+				controller.clusterConfig = config
+
+				Expect(controller.checkNetworkInterfacesForMigration(vmi)).To(Succeed())
+			})
+
+			It("should block migration for slirp binding assigned to the pod network", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultSlirpNetworkInterface()}
+				config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+					Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.BridgeLiveMigrationGate},
+				})
+				controller.clusterConfig = config
+
+				err := controller.checkNetworkInterfacesForMigration(vmi)
+				Expect(err).To(MatchError("cannot migrate VMI which does not use masquerade or bridge to connect to the pod network"))
+			})
+
+			It("should report every reason blocking the migration in the condition", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+
+				vmi.Status.VolumeStatus = []v1.VolumeStatus{{Name: "hotplug", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+
+				condition, _ := controller.calculateLiveMigrationCondition(vmi)
+				Expect(condition.Type).To(Equal(v1.VirtualMachineInstanceIsMigratable))
+				Expect(condition.Status).To(Equal(k8sv1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonInterfaceNotMigratable))
+				Expect(condition.Message).To(Equal("BridgeLiveMigration feature-gate is closed, can't migrate VMI with bridge binding on the pod network; VMI has hotplugged disks"))
+			})
+
+			It("should block migration while a backup is in progress", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Status.Backup = &v1.VirtualMachineInstanceBackupState{Name: "backup"}
+
+				condition, _ := controller.calculateLiveMigrationCondition(vmi)
+				Expect(condition.Status).To(Equal(k8sv1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonBackupNotMigratable))
+				Expect(condition.Message).To(Equal("backup backup is in progress"))
+			})
+
+			It("should block migration for VMI with SRIOV interface when feature-gate SRIOVLiveMigration is off", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				sriovInterfaceName := "sriovnet1"
+				vmi.Spec.Networks = []v1.Network{
+					{
+						Name: sriovInterfaceName,
+						NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{
+							NetworkName: "sriov-network1",
+						}},
+					},
+				}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					{
+						Name: sriovInterfaceName,
+						InterfaceBindingMethod: v1.InterfaceBindingMethod{
+							SRIOV: &v1.InterfaceSRIOV{},
+						},
+					},
+				}
+
+				Expect(controller.checkNetworkInterfacesForMigration(vmi)).ShouldNot(Succeed())
+			})
+
+			It("should not block migration for VMI with SRIOV interface when feature-gate SRIOVLiveMigration is on", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				sriovInterfaceName := "sriovnet1"
+				kubevirtConfigMapFeatureGate := map[string]string{virtconfig.FeatureGatesKey: virtconfig.SRIOVLiveMigrationGate}
+				vmi.Spec.Networks = []v1.Network{
+					{
+						Name: sriovInterfaceName,
+						NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{
+							NetworkName: "sriov-network1",
+						}},
+					},
+				}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					{
+						Name: sriovInterfaceName,
+						InterfaceBindingMethod: v1.InterfaceBindingMethod{
+							SRIOV: &v1.InterfaceSRIOV{},
+						},
+					},
+				}
+				config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+					Data: kubevirtConfigMapFeatureGate,
+				})
+				controller.clusterConfig = config
+
+				Expect(controller.checkNetworkInterfacesForMigration(vmi)).Should(Succeed())
+			})
+
+			It("should not block migration for masquerade binding assigned to the pod network", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				interface_name := "interface_name"
+
+				vmi.Spec.Networks = []v1.Network{
+					{
+						Name:          interface_name,
+						NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
+					},
+				}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					{
+						Name: interface_name,
+						InterfaceBindingMethod: v1.InterfaceBindingMethod{
+							Masquerade: &v1.InterfaceMasquerade{},
+						},
+					},
+				}
+
+				err := controller.checkNetworkInterfacesForMigration(vmi)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should not block migration for bridge binding assigned to a multus network", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				interface_name := "interface_name"
+
+				vmi.Spec.Networks = []v1.Network{
+					{
+						Name:          interface_name,
+						NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{}},
+					},
+				}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					{
+						Name: interface_name,
+						InterfaceBindingMethod: v1.InterfaceBindingMethod{
+							Bridge: &v1.InterfaceBridge{},
+						},
+					},
+				}
+
+				err := controller.checkNetworkInterfacesForMigration(vmi)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should block migration for vhostuser interfaces", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Networks = []v1.Network{
+					{
+						Name:          "dpdk",
+						NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "ovs-dpdk"}},
+					},
+				}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					{
+						Name: "dpdk",
+						InterfaceBindingMethod: v1.InterfaceBindingMethod{
+							VhostUser: &v1.InterfaceVhostUser{},
+						},
+					},
+				}
+
+				err := controller.checkNetworkInterfacesForMigration(vmi)
+				Expect(err).To(MatchError("cannot migrate VMI with vhostuser interfaces"))
+			})
+		})
+
+	})
 })
 
 func NewDv(namespace string, name string, phase cdiv1.DataVolumePhase) *cdiv1.DataVolume {
@@ -2430,6 +3138,11 @@ func now() *metav1.Time {
 	return &now
 }
 
+func markAsMigratable(vmi *v1.VirtualMachineInstance) {
+	vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{Type: v1.VirtualMachineInstanceIsMigratable, Status: k8sv1.ConditionTrue})
+	vmi.Status.MigrationMethod = v1.LiveMigration
+}
+
 func markAsReady(vmi *v1.VirtualMachineInstance) {
 	kvcontroller.NewVirtualMachineInstanceConditionManager().AddPodCondition(vmi, &k8sv1.PodCondition{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue})
 }
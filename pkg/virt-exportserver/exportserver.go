@@ -0,0 +1,199 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package exportserver
+
+import (
+	"compress/gzip"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// TokenHeader is the header clients pass the export token in
+	TokenHeader = "x-kubevirt-export-token"
+	// TokenQueryParam is the query parameter clients can pass the export token in
+	TokenQueryParam = "x-kubevirt-export-token"
+
+	// HealthzPath is served without authentication and used for the readiness probe
+	HealthzPath = "/healthz"
+	// ManifestPath serves the sanitized VirtualMachine definition
+	ManifestPath = "/manifests/virtualmachine.yaml"
+
+	volumesPath = "/volumes/"
+	rawFile     = "disk.img"
+	gzipFile    = "disk.img.gz"
+)
+
+// VolumeRawPath returns the path the raw image of a volume is served at
+func VolumeRawPath(name string) string {
+	return volumesPath + name + "/" + rawFile
+}
+
+// VolumeGzipPath returns the path the gzip compressed image of a volume is served at
+func VolumeGzipPath(name string) string {
+	return volumesPath + name + "/" + gzipFile
+}
+
+// ExportServer serves exported volumes and manifests over HTTPS
+type ExportServer struct {
+	ListenAddr   string
+	CertFile     string
+	KeyFile      string
+	TokenFile    string
+	ManifestFile string
+	// Volumes maps the volume name to the disk image file or block device to serve
+	Volumes map[string]string
+}
+
+// Run starts serving and blocks until the server fails
+func (s *ExportServer) Run() error {
+	handler, err := s.Handler()
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:    s.ListenAddr,
+		Handler: handler,
+	}
+
+	log.Log.Infof("Export server listening on %s", s.ListenAddr)
+	return server.ListenAndServeTLS(s.CertFile, s.KeyFile)
+}
+
+// Handler returns the http.Handler serving all export paths
+func (s *ExportServer) Handler() (http.Handler, error) {
+	content, err := ioutil.ReadFile(s.TokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token: %v", err)
+	}
+	token := []byte(strings.TrimSpace(string(content)))
+	if len(token) == 0 {
+		return nil, fmt.Errorf("token file %s is empty", s.TokenFile)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(HealthzPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for name, path := range s.Volumes {
+		mux.Handle(VolumeRawPath(name), rawHandler(path))
+		mux.Handle(VolumeGzipPath(name), gzipHandler(path))
+	}
+
+	if s.ManifestFile != "" {
+		mux.Handle(ManifestPath, manifestHandler(s.ManifestFile))
+	}
+
+	return tokenChecker(token, mux), nil
+}
+
+// tokenChecker only lets requests with the token through, an empty token rejects all of them
+func tokenChecker(token []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != HealthzPath {
+			provided := r.Header.Get(TokenHeader)
+			if provided == "" {
+				provided = r.URL.Query().Get(TokenQueryParam)
+			}
+
+			if len(token) == 0 || subtle.ConstantTimeCompare([]byte(provided), token) != 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func rawHandler(path string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to open %s", path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		// ServeContent determines the size by seeking, which works for block devices too
+		http.ServeContent(w, r, rawFile, time.Time{}, f)
+	})
+}
+
+func gzipHandler(path string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to open %s", path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		w.Header().Set("Content-Type", "application/gzip")
+		gw := gzip.NewWriter(w)
+		defer gw.Close()
+
+		if _, err := io.Copy(gw, f); err != nil {
+			log.Log.Reason(err).Errorf("Failed to serve %s", path)
+		}
+	})
+}
+
+func manifestHandler(path string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		manifest, err := ioutil.ReadFile(path)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to read %s", path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(manifest)
+	})
+}
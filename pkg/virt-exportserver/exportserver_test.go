@@ -0,0 +1,175 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package exportserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Export server", func() {
+	const token = "secret-token"
+
+	var (
+		tempDir string
+		server  *httptest.Server
+		disk    []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "exportserver")
+		Expect(err).ToNot(HaveOccurred())
+
+		disk = bytes.Repeat([]byte("kubevirt"), 1024)
+		Expect(ioutil.WriteFile(filepath.Join(tempDir, "disk.img"), disk, 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(tempDir, "vm.yaml"), []byte("kind: VirtualMachine\n"), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(tempDir, "token"), []byte(token+"\n"), 0600)).To(Succeed())
+
+		exportServer := &ExportServer{
+			TokenFile:    filepath.Join(tempDir, "token"),
+			ManifestFile: filepath.Join(tempDir, "vm.yaml"),
+			Volumes: map[string]string{
+				"pvc": filepath.Join(tempDir, "disk.img"),
+			},
+		}
+		handler, err := exportServer.Handler()
+		Expect(err).ToNot(HaveOccurred())
+		server = httptest.NewServer(handler)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(tempDir)
+	})
+
+	get := func(path string, header http.Header) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		if header != nil {
+			req.Header = header
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	withToken := func() http.Header {
+		header := http.Header{}
+		header.Set(TokenHeader, token)
+		return header
+	}
+
+	It("should serve healthz without token", func() {
+		resp := get(HealthzPath, nil)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	table.DescribeTable("should reject requests without a valid token", func(path string, header http.Header) {
+		resp := get(path, header)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	},
+		table.Entry("raw volume without token", VolumeRawPath("pvc"), nil),
+		table.Entry("gzip volume with wrong token", VolumeGzipPath("pvc"), http.Header{TokenHeader: []string{"wrong"}}),
+		table.Entry("manifest without token", ManifestPath, nil),
+	)
+
+	It("should serve the raw volume", func() {
+		resp := get(VolumeRawPath("pvc"), withToken())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(Equal(disk))
+	})
+
+	It("should serve a range of the raw volume", func() {
+		header := withToken()
+		header.Set("Range", "bytes=8-15")
+		resp := get(VolumeRawPath("pvc"), header)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusPartialContent))
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(Equal([]byte("kubevirt")))
+	})
+
+	It("should serve the gzip compressed volume with the token as query parameter", func() {
+		resp := get(VolumeGzipPath("pvc")+"?"+TokenQueryParam+"="+token, nil)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("application/gzip"))
+		gr, err := gzip.NewReader(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		body, err := ioutil.ReadAll(gr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(Equal(disk))
+	})
+
+	It("should serve the manifest", func() {
+		resp := get(ManifestPath, withToken())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("kind: VirtualMachine\n"))
+	})
+
+	It("should return not found for unknown volumes", func() {
+		resp := get(VolumeRawPath("unknown"), withToken())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	It("should refuse to start with an empty token", func() {
+		Expect(ioutil.WriteFile(filepath.Join(tempDir, "empty-token"), []byte("\n"), 0600)).To(Succeed())
+		exportServer := &ExportServer{
+			TokenFile: filepath.Join(tempDir, "empty-token"),
+			Volumes: map[string]string{
+				"pvc": filepath.Join(tempDir, "disk.img"),
+			},
+		}
+		_, err := exportServer.Handler()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject all requests if the token is empty", func() {
+		emptyTokenServer := httptest.NewServer(tokenChecker(nil, http.NotFoundHandler()))
+		defer emptyTokenServer.Close()
+		req, err := http.NewRequest(http.MethodGet, emptyTokenServer.URL+VolumeRawPath("pvc"), nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(TokenHeader, "")
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+})
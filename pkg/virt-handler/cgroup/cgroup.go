@@ -58,6 +58,31 @@ func cpuSetPath(isCgroup2UnifiedMode bool, cgroupMount string) string {
 	return filepath.Join(cgroupMount, "cpuset", "cpuset.cpus")
 }
 
+// CPUSetMemsPath returns the path of the file holding the NUMA nodes the memory of the cgroup is allocated on
+func CPUSetMemsPath() string {
+	return cpuSetMemsPath(cgroups.IsCgroup2UnifiedMode(), cgroupMountPoint)
+}
+
+func cpuSetMemsPath(isCgroup2UnifiedMode bool, cgroupMount string) string {
+	if isCgroup2UnifiedMode {
+		return filepath.Join(cgroupMount, "cpuset.mems.effective")
+	}
+	return filepath.Join(cgroupMount, "cpuset", "cpuset.mems")
+}
+
+// HugetlbLimitPath returns the path of the file holding the hugepages limit of the cgroup for the given page size,
+// e.g. "2MB" or "1GB"
+func HugetlbLimitPath(pageSize string) string {
+	return hugetlbLimitPath(cgroups.IsCgroup2UnifiedMode(), cgroupMountPoint, pageSize)
+}
+
+func hugetlbLimitPath(isCgroup2UnifiedMode bool, cgroupMount, pageSize string) string {
+	if isCgroup2UnifiedMode {
+		return filepath.Join(cgroupMount, fmt.Sprintf("hugetlb.%s.max", pageSize))
+	}
+	return filepath.Join(cgroupMount, "hugetlb", fmt.Sprintf("hugetlb.%s.limit_in_bytes", pageSize))
+}
+
 type Parser interface {
 	// Parse retrieves the cgroup data for the given process id and returns a
 	// map of controllers to slice paths.
@@ -175,4 +175,11 @@ var _ = Describe("Cgroup", func() {
 			}
 		})
 	})
+
+	It("Should point to the memory nodes and the hugepages limit of the cgroup", func() {
+		Expect(cpuSetMemsPath(false, "/sys/fs/cgroup")).To(Equal("/sys/fs/cgroup/cpuset/cpuset.mems"))
+		Expect(cpuSetMemsPath(true, "/sys/fs/cgroup")).To(Equal("/sys/fs/cgroup/cpuset.mems.effective"))
+		Expect(hugetlbLimitPath(false, "/sys/fs/cgroup", "2MB")).To(Equal("/sys/fs/cgroup/hugetlb/hugetlb.2MB.limit_in_bytes"))
+		Expect(hugetlbLimitPath(true, "/sys/fs/cgroup", "1GB")).To(Equal("/sys/fs/cgroup/hugetlb.1GB.max"))
+	})
 })
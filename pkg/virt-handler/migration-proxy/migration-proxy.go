@@ -51,6 +51,9 @@ type ProxyManager interface {
 	GetSourceListenerFiles(key string) []string
 	StopSourceListener(key string)
 
+	StartBackupListener(key string, listenerSocket string, targetSocket string) error
+	StopBackupListener(key string)
+
 	OpenListenerCount() int
 
 	InitiateGracefulShutdown()
@@ -59,6 +62,7 @@ type ProxyManager interface {
 type migrationProxyManager struct {
 	sourceProxies   map[string][]*migrationProxy
 	targetProxies   map[string][]*migrationProxy
+	backupProxies   map[string]*migrationProxy
 	managerLock     sync.Mutex
 	serverTLSConfig *tls.Config
 	clientTLSConfig *tls.Config
@@ -107,6 +111,7 @@ func NewMigrationProxyManager(serverTLSConfig *tls.Config, clientTLSConfig *tls.
 	return &migrationProxyManager{
 		sourceProxies:   make(map[string][]*migrationProxy),
 		targetProxies:   make(map[string][]*migrationProxy),
+		backupProxies:   make(map[string]*migrationProxy),
 		serverTLSConfig: serverTLSConfig,
 		clientTLSConfig: clientTLSConfig,
 	}
@@ -316,6 +321,57 @@ func (m *migrationProxyManager) StopSourceListener(key string) {
 	}
 }
 
+// StartBackupListener exposes the NBD server of a backup to the pod which
+// copies the disks. The listener socket is created in a directory shared with
+// that pod, the target socket is the NBD server in the virt-launcher pod.
+func (m *migrationProxyManager) StartBackupListener(key string, listenerSocket string, targetSocket string) error {
+	m.managerLock.Lock()
+	defer m.managerLock.Unlock()
+
+	if m.isShuttingDown {
+		return fmt.Errorf("unable to process new backup connections during virt-handler shutdown")
+	}
+
+	curProxy, exists := m.backupProxies[key]
+	if exists {
+		if curProxy.unixSocketPath == listenerSocket && curProxy.targetAddress == targetSocket {
+			// No Op, already exists
+			return nil
+		}
+		curProxy.StopListening()
+		os.RemoveAll(curProxy.unixSocketPath)
+		delete(m.backupProxies, key)
+	}
+
+	proxy := NewUnixProxy(listenerSocket, targetSocket)
+	err := proxy.StartListening()
+	if err != nil {
+		proxy.StopListening()
+		return err
+	}
+	// The pod copying the disks does not run as root
+	if err := os.Chmod(listenerSocket, 0666); err != nil {
+		proxy.StopListening()
+		os.RemoveAll(listenerSocket)
+		return err
+	}
+	m.backupProxies[key] = proxy
+	log.Log.Infof("Proxy Backup listening on unix file %s for key %s", listenerSocket, key)
+	return nil
+}
+
+func (m *migrationProxyManager) StopBackupListener(key string) {
+	m.managerLock.Lock()
+	defer m.managerLock.Unlock()
+
+	curProxy, exists := m.backupProxies[key]
+	if exists {
+		curProxy.StopListening()
+		os.RemoveAll(curProxy.unixSocketPath)
+		delete(m.backupProxies, key)
+	}
+}
+
 // SRC POD ENV(migration unix socket) <-> HOST ENV (tcp client) <-----> HOST ENV (tcp server) <-> TARGET POD ENV (libvirtd unix socket)
 
 // Source proxy exposes a unix socket server and pipes to an outbound TCP connection.
@@ -332,6 +388,18 @@ func NewSourceProxy(unixSocketPath string, tcpTargetAddress string, serverTLSCon
 	}
 }
 
+// Unix proxy exposes a unix socket server and pipes to another unix socket.
+func NewUnixProxy(unixSocketPath string, targetSocketPath string) *migrationProxy {
+	return &migrationProxy{
+		unixSocketPath: unixSocketPath,
+		targetAddress:  targetSocketPath,
+		targetProtocol: "unix",
+		stopChan:       make(chan struct{}),
+		fdChan:         make(chan net.Conn, 1),
+		listenErrChan:  make(chan error, 1),
+	}
+}
+
 // Target proxy listens on a tcp socket and pipes to a libvirtd unix socket
 func NewTargetProxy(tcpBindAddress string, tcpBindPort int, serverTLSConfig *tls.Config, clientTLSConfig *tls.Config, libvirtdSocketPath string) *migrationProxy {
 
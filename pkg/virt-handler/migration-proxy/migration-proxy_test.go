@@ -199,6 +199,43 @@ var _ = Describe("MigrationProxy", func() {
 				}
 			})
 
+			It("by forwarding a backup listener to the NBD socket", func() {
+				nbdSock := tmpDir + "/nbd-sock"
+				nbdListener, err := net.Listen("unix", nbdSock)
+				Expect(err).ShouldNot(HaveOccurred())
+				defer nbdListener.Close()
+
+				manager := NewMigrationProxyManager(tlsConfig, tlsConfig)
+				backupSock := tmpDir + "/backup/nbd.sock"
+				Expect(manager.StartBackupListener("mykey", backupSock, nbdSock)).To(Succeed())
+				defer manager.StopBackupListener("mykey")
+
+				info, err := os.Stat(backupSock)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0666)))
+
+				numBytes := make(chan int)
+				go func() {
+					defer GinkgoRecover()
+					fd, err := nbdListener.Accept()
+					Expect(err).ShouldNot(HaveOccurred())
+					var bytes [1024]byte
+					n, err := fd.Read(bytes[0:])
+					Expect(err).ShouldNot(HaveOccurred())
+					numBytes <- n
+				}()
+
+				conn, err := net.Dial("unix", backupSock)
+				Expect(err).ShouldNot(HaveOccurred())
+				sentLen, err := conn.Write([]byte("some nbd message"))
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(<-numBytes).To(Equal(sentLen))
+
+				manager.StopBackupListener("mykey")
+				_, err = os.Stat(backupSock)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+
 			It("by ensuring no new listeners can be created after shutdown", func() {
 
 				key1 := "key1"
@@ -20,6 +20,7 @@
 package rest
 
 import (
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -35,6 +36,7 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/net/portforward"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 )
 
@@ -76,7 +78,7 @@ func (t *ConsoleHandler) VNCHandler(request *restful.Request, response *restful.
 	cleanup := func() {
 		deleteStopChan(uid, stopChn, t.vncLock, t.vncStopChans)
 	}
-	t.stream(vmi, request, response, unixSocketPath, stopChn, cleanup)
+	t.stream(vmi, request, response, unixSocketPath, dialUnixSocket(unixSocketPath), stopChn, cleanup)
 }
 
 func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restful.Response) {
@@ -97,7 +99,67 @@ func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restf
 	cleanup := func() {
 		deleteStopChan(uid, stopCh, t.serialLock, t.serialStopChans)
 	}
-	t.stream(vmi, request, response, unixSocketPath, stopCh, cleanup)
+	t.stream(vmi, request, response, unixSocketPath, dialUnixSocket(unixSocketPath), stopCh, cleanup)
+}
+
+// PortForwardHandler streams a TCP connection to a port of the guest. The connection
+// is established from within the network namespace of the virt-launcher pod.
+// Unlike the consoles, a VMI can have any number of port forward connections.
+func (t *ConsoleHandler) PortForwardHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	port, err := strconv.Atoi(request.PathParameter("port"))
+	if err != nil || port < portforward.MinPort || port > portforward.MaxPort {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("invalid port %s", request.PathParameter("port")))
+		return
+	}
+	guestAddress, err := portforward.GuestAddress(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed finding the guest address to forward a port to")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	isolationResult, err := t.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect the isolation of the VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	address := net.JoinHostPort(guestAddress, strconv.Itoa(port))
+	dial := func() (conn net.Conn, err error) {
+		nsErr := isolationResult.DoNetNS(func() error {
+			conn, err = net.Dial("tcp", address)
+			return nil
+		})
+		if nsErr != nil {
+			return nil, nsErr
+		}
+		return conn, err
+	}
+	t.stream(vmi, request, response, address, dial, make(chan struct{}), func() {})
+}
+
+// BackupNBDHandler streams a connection to the NBD server exporting the disks
+// of the backup in progress. Backup clients may open several connections at once.
+func (t *ConsoleHandler) BackupNBDHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	unixSocketPath, err := t.getUnixSocketPath(vmi, "virt-backup-nbd")
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed finding unix socket for the backup NBD server")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	t.stream(vmi, request, response, unixSocketPath, dialUnixSocket(unixSocketPath), make(chan struct{}), func() {})
 }
 
 func newStopChan(uid types.UID, lock *sync.Mutex, stopChans map[types.UID](chan struct{})) chan struct{} {
@@ -139,7 +201,15 @@ func (t *ConsoleHandler) getUnixSocketPath(vmi *v1.VirtualMachineInstance, socke
 
 type cleanupOnError func()
 
-func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, unixSocketPath string, stopCh chan struct{}, cleanup cleanupOnError) {
+type dialer func() (net.Conn, error)
+
+func dialUnixSocket(unixSocketPath string) dialer {
+	return func() (net.Conn, error) {
+		return net.Dial("unix", unixSocketPath)
+	}
+}
+
+func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, target string, dial dialer, stopCh chan struct{}, cleanup cleanupOnError) {
 	var upgrader = kubecli.NewUpgrader()
 	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
 	if err != nil {
@@ -150,22 +220,22 @@ func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful
 	defer clientSocket.Close()
 
 	log.Log.Object(vmi).Infof("Websocket connection upgraded")
-	log.Log.Object(vmi).Infof("Connecting to %s", unixSocketPath)
+	log.Log.Object(vmi).Infof("Connecting to %s", target)
 
-	fd, err := net.Dial("unix", unixSocketPath)
+	fd, err := dial()
 	if err != nil {
-		log.Log.Object(vmi).Reason(err).Errorf("failed to dial unix socket %s", unixSocketPath)
+		log.Log.Object(vmi).Reason(err).Errorf("failed to dial %s", target)
 		response.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 	defer fd.Close()
 
-	log.Log.Object(vmi).Infof("Connected to %s", unixSocketPath)
+	log.Log.Object(vmi).Infof("Connected to %s", target)
 
-	errCh := make(chan error)
+	errCh := make(chan error, 2)
 	go func() {
 		_, err := kubecli.CopyTo(clientSocket, fd)
-		log.Log.Object(vmi).Reason(err).Error("error encountered reading from unix socket")
+		log.Log.Object(vmi).Reason(err).Errorf("error encountered reading from %s", target)
 		errCh <- err
 	}()
 
@@ -180,7 +250,7 @@ func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful
 		break
 	case err := <-errCh:
 		if err != nil && err != io.EOF {
-			log.Log.Object(vmi).Reason(err).Error("Error in proxing websocket and unix socket")
+			log.Log.Object(vmi).Reason(err).Errorf("Error in proxing websocket and %s", target)
 			response.WriteHeader(http.StatusInternalServerError)
 		}
 
@@ -24,12 +24,14 @@ import (
 	goerror "errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -37,7 +39,6 @@ import (
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 
 	k8sv1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -120,6 +121,7 @@ func NewController(
 	ipAddress string,
 	virtShareDir string,
 	virtPrivateDir string,
+	kubeletPodsDir string,
 	vmiSourceInformer cache.SharedIndexInformer,
 	vmiTargetInformer cache.SharedIndexInformer,
 	domainInformer cache.SharedInformer,
@@ -153,6 +155,7 @@ func NewController(
 		clusterConfig:               clusterConfig,
 		networkCacheStoreFactory:    netcache.NewInterfaceCacheFactory(),
 		virtLauncherFSRunDirPattern: "/proc/%d/root/var/run",
+		backupNBDDirPattern:         filepath.Join(util.HostRootMount, kubeletPodsDir, "%s/volumes/kubernetes.io~empty-dir/backup-nbd"),
 	}
 
 	vmiSourceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -241,6 +244,7 @@ type VirtualMachineController struct {
 	domainNotifyPipes           map[string]string
 	networkCacheStoreFactory    netcache.InterfaceCacheFactory
 	virtLauncherFSRunDirPattern string
+	backupNBDDirPattern         string
 	heartBeat                   *heartbeat.HeartBeat
 }
 
@@ -504,6 +508,45 @@ func (d *VirtualMachineController) setPodNetworkPhase1(vmi *v1.VirtualMachineIns
 	return false, nil
 }
 
+// hotplugPodNetworkPhase1 prepares the pod networking of interfaces which were
+// hotplugged into the running VMI and are not yet reported in its status.
+func (d *VirtualMachineController) hotplugPodNetworkPhase1(vmi *v1.VirtualMachineInstance) error {
+	if !d.clusterConfig.HotplugNetworkInterfacesEnabled() {
+		return nil
+	}
+
+	reported := map[string]struct{}{}
+	for _, ifaceStatus := range vmi.Status.Interfaces {
+		reported[ifaceStatus.Name] = struct{}{}
+	}
+	networks := map[string]v1.Network{}
+	for _, network := range vmi.Spec.Networks {
+		networks[network.Name] = network
+	}
+
+	var ifaces []v1.Interface
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if _, exists := reported[iface.Name]; exists {
+			continue
+		}
+		if vmiNetwork, exists := networks[iface.Name]; exists && network.IsHotpluggableInterface(iface, vmiNetwork) {
+			ifaces = append(ifaces, iface)
+		}
+	}
+	if len(ifaces) == 0 {
+		return nil
+	}
+
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return fmt.Errorf("failed to detect isolation for launcher pod: %v", err)
+	}
+	pid := res.Pid()
+	return res.DoNetNS(func() error {
+		return network.SetupPodNetworkHotplugPhase1(vmi, ifaces, pid, d.networkCacheStoreFactory)
+	})
+}
+
 func domainMigrated(domain *api.Domain) bool {
 	if domain != nil && domain.Status.Status == api.Shutoff && domain.Status.Reason == api.ReasonMigrated {
 		return true
@@ -571,6 +614,12 @@ func (d *VirtualMachineController) setMigrationProgressStatus(vmi *v1.VirtualMac
 	vmi.Status.MigrationState.Completed = migrationMetadata.Completed
 	vmi.Status.MigrationState.Failed = migrationMetadata.Failed
 	vmi.Status.MigrationState.Mode = migrationMetadata.Mode
+	if migrationMetadata.DiskTotal > 0 {
+		vmi.Status.MigrationState.DiskCopyProgress = &v1.MigrationDiskCopyProgress{
+			TotalBytes:     int64(migrationMetadata.DiskTotal),
+			ProcessedBytes: int64(migrationMetadata.DiskProcessed),
+		}
+	}
 	return vmi
 }
 
@@ -661,8 +710,10 @@ func (d *VirtualMachineController) migrationTargetUpdateVMIStatus(vmi *v1.Virtua
 	if domainExists && vmi.Status.MigrationState != nil && !vmi.Status.MigrationState.TargetNodeDomainDetected {
 		// record that we've see the domain populated on the target's node
 		log.Log.Object(vmi).Info("The target node received the migrated domain")
+		if err := d.finalizeMigration(vmi); err != nil {
+			return err
+		}
 		vmiCopy.Status.MigrationState.TargetNodeDomainDetected = true
-		d.finalizeMigration(vmi)
 	}
 
 	if !migrations.IsMigrating(vmi) {
@@ -762,8 +813,16 @@ func (d *VirtualMachineController) updateVolumeStatusesFromDomain(vmi *v1.Virtua
 	if len(vmi.Status.VolumeStatus) > 0 {
 		diskDeviceMap := make(map[string]string)
 		for _, disk := range domain.Spec.Devices.Disks {
+			if disk.Device == "cdrom" && disk.Source.File == "" && disk.Source.Dev == "" {
+				// media injected into an empty CD-ROM is not attached yet
+				continue
+			}
 			diskDeviceMap[disk.Alias.GetName()] = disk.Target.Device
 		}
+		diskSizeMap := make(map[string]int64)
+		for _, diskSize := range domain.Spec.Metadata.KubeVirt.DiskSizes {
+			diskSizeMap[diskSize.Name] = diskSize.Size
+		}
 		specVolumeMap := make(map[string]v1.Volume)
 		for _, volume := range vmi.Spec.Volumes {
 			specVolumeMap[volume.Name] = volume
@@ -775,6 +834,9 @@ func (d *VirtualMachineController) updateVolumeStatusesFromDomain(vmi *v1.Virtua
 			if _, ok := diskDeviceMap[volumeStatus.Name]; ok {
 				volumeStatus.Target = diskDeviceMap[volumeStatus.Name]
 			}
+			if size, ok := diskSizeMap[volumeStatus.Name]; ok {
+				volumeStatus.Size = size
+			}
 			if volumeStatus.HotplugVolume != nil {
 				hasHotplug = true
 				volumeStatus, needsRefresh = d.updateHotplugVolumeStatus(vmi, volumeStatus, specVolumeMap)
@@ -794,9 +856,86 @@ func (d *VirtualMachineController) updateVolumeStatusesFromDomain(vmi *v1.Virtua
 	return hasHotplug
 }
 
+// updateCPUTopologyStatus reports the topology of the online vCPUs of the
+// domain next to the topology it can be scaled up to.
+func updateCPUTopologyStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	topology := domain.Spec.CPU.Topology
+	if topology == nil || domain.Spec.VCPU == nil || topology.Cores == 0 || topology.Threads == 0 {
+		return
+	}
+
+	online := domain.Spec.VCPU.CPUs
+	if domain.Spec.VCPU.Current != 0 {
+		online = domain.Spec.VCPU.Current
+	}
+	vmi.Status.CurrentCPUTopology = &v1.CPUTopology{
+		Sockets: online / (topology.Cores * topology.Threads),
+		Cores:   topology.Cores,
+		Threads: topology.Threads,
+	}
+	vmi.Status.MaxCPUTopology = &v1.CPUTopology{
+		Sockets: topology.Sockets,
+		Cores:   topology.Cores,
+		Threads: topology.Threads,
+	}
+}
+
+// updateBackupStatus reports the backup job and the checkpoints recorded in
+// the domain metadata. Incremental exports carry the NBD metadata context the
+// changed blocks can be queried from.
+func updateBackupStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	backup := domain.Spec.Metadata.KubeVirt.Backup
+	if backup != nil {
+		state := &v1.VirtualMachineInstanceBackupState{
+			Name:           backup.Name,
+			Checkpoint:     backup.Checkpoint,
+			Incremental:    backup.Incremental,
+			TargetPodUID:   backup.TargetPodUID,
+			StartTimestamp: backup.StartTimestamp,
+			EndTimestamp:   backup.EndTimestamp,
+			Completed:      backup.Completed,
+			Failed:         backup.Failed,
+			Message:        backup.Message,
+		}
+		for _, disk := range backup.Disks {
+			export := v1.BackupExport{
+				VolumeName: disk.Name,
+				ExportName: disk.Target,
+			}
+			if backup.Incremental != "" {
+				export.DirtyBitmap = "qemu:dirty-bitmap:backup-" + disk.Target
+			}
+			state.Exports = append(state.Exports, export)
+		}
+		vmi.Status.Backup = state
+	}
+
+	vmi.Status.Checkpoints = mergeCheckpoints(vmi.Status.Checkpoints, domain.Spec.Metadata.KubeVirt)
+}
+
+// mergeCheckpoints merges the checkpoints recorded in the domain metadata into
+// the reported ones. A domain which did not record any backup or checkpoint yet,
+// like a migration target before the checkpoints are restored on it, keeps the
+// reported checkpoints. Otherwise the domain metadata is authoritative and the
+// checkpoints pruned from it are dropped.
+func mergeCheckpoints(reported []v1.VirtualMachineInstanceCheckpoint, metadata api.KubeVirtMetadata) []v1.VirtualMachineInstanceCheckpoint {
+	if metadata.Backup == nil && len(metadata.Checkpoints) == 0 {
+		return reported
+	}
+
+	var checkpoints []v1.VirtualMachineInstanceCheckpoint
+	for _, checkpoint := range metadata.Checkpoints {
+		checkpoints = append(checkpoints, v1.VirtualMachineInstanceCheckpoint{
+			Name:              checkpoint.Name,
+			CreationTimestamp: checkpoint.CreationTimestamp,
+			Volumes:           checkpoint.Volumes,
+		})
+	}
+	return checkpoints
+}
+
 func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineInstance, domain *api.Domain, syncError error) (err error) {
 	condManager := controller.NewVirtualMachineInstanceConditionManager()
-	hasHotplug := false
 
 	// Don't update the VirtualMachineInstance if it is already in a final state
 	if origVMI.IsFinal() {
@@ -834,7 +973,7 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 			}
 		}
 
-		hasHotplug = d.updateVolumeStatusesFromDomain(vmi, domain)
+		d.updateVolumeStatusesFromDomain(vmi, domain)
 		if len(vmi.Status.Interfaces) == 0 {
 			// Set Pod Interface
 			interfaces := make([]v1.VirtualMachineInstanceNetworkInterface, 0)
@@ -922,18 +1061,45 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 					}
 				}
 
+				newInterface.LinkState = v1.InterfaceLinkStateUp
+				if domainInterface.LinkState != nil && domainInterface.LinkState.State == string(v1.InterfaceLinkStateDown) {
+					newInterface.LinkState = v1.InterfaceLinkStateDown
+				}
+				if domainInterface.MTU != nil {
+					newInterface.MTU, _ = strconv.Atoi(domainInterface.MTU.Size)
+				}
+
+				// The firewall rules are recorded in the file cache once they are enforced in the pod
+				newInterface.Firewall = nil
+				if existingInterfacesSpecByName[domainInterface.Alias.GetName()].Firewall != nil {
+					podIface, err := d.getPodInterfacefromFileCache(vmi, domainInterface.Alias.GetName())
+					if err != nil {
+						return err
+					}
+					if podIface != nil {
+						newInterface.Firewall = podIface.Firewall
+					}
+				}
+
 				// Update IP info based on information from domain.Status.Interfaces (Qemu guest)
 				// Remove the interface from domainInterfaceStatusByMac to mark it as handled
+				var agentInterfaceStatus *api.InterfaceStatus
 				if interfaceStatus, exists := domainInterfaceStatusByMac[interfaceMAC]; exists {
 					newInterface.InterfaceName = interfaceStatus.InterfaceName
+					newInterface.Routes = convertInterfaceRoutes(interfaceStatus.Routes)
 					// Do not update if interface has Masquerede binding
 					// virt-controller should update VMI status interface with Pod IP instead
 					if !isForwardingBindingInterface {
 						newInterface.IP = interfaceStatus.Ip
 						newInterface.IPs = interfaceStatus.IPs
+						agentInterfaceStatus = &interfaceStatus
 					}
 					delete(domainInterfaceStatusByMac, interfaceMAC)
 				}
+				newInterface.IPDetails, err = d.interfaceIPDetails(vmi, newInterface, agentInterfaceStatus)
+				if err != nil {
+					return err
+				}
 				newInterfaces = append(newInterfaces, newInterface)
 			}
 
@@ -948,11 +1114,25 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 					IP:            domainInterfaceStatus.Ip,
 					IPs:           domainInterfaceStatus.IPs,
 					InterfaceName: domainInterfaceStatus.InterfaceName,
+					Routes:        convertInterfaceRoutes(domainInterfaceStatus.Routes),
+				}
+				interfaceStatus := domainInterfaceStatus
+				newInterface.IPDetails, err = d.interfaceIPDetails(vmi, newInterface, &interfaceStatus)
+				if err != nil {
+					return err
 				}
 				newInterfaces = append(newInterfaces, newInterface)
 			}
 			vmi.Status.Interfaces = newInterfaces
 		}
+		if domain.Status.DNS != nil {
+			vmi.Status.GuestDNS = &v1.VirtualMachineInstanceGuestDNS{
+				Nameservers: domain.Status.DNS.Nameservers,
+				Search:      domain.Status.DNS.Search,
+			}
+		}
+		updateCPUTopologyStatus(vmi, domain)
+		updateBackupStatus(vmi, domain)
 	}
 
 	// Update AccessCredential conditions
@@ -995,23 +1175,6 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 		return err
 	}
 
-	// Cacluate whether the VM is migratable
-	liveMigrationCondition, isBlockMigration := d.calculateLiveMigrationCondition(vmi, hasHotplug)
-	if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceIsMigratable) {
-		vmi.Status.Conditions = append(vmi.Status.Conditions, *liveMigrationCondition)
-		// Set VMI Migration Method
-		if isBlockMigration {
-			vmi.Status.MigrationMethod = v1.BlockMigration
-		} else {
-			vmi.Status.MigrationMethod = v1.LiveMigration
-		}
-	} else {
-		cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceIsMigratable)
-		if !reflect.DeepEqual(cond, liveMigrationCondition) {
-			condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceIsMigratable)
-			vmi.Status.Conditions = append(vmi.Status.Conditions, *liveMigrationCondition)
-		}
-	}
 	// Update the condition when GA is connected
 	channelConnected := false
 	if domain != nil {
@@ -1121,40 +1284,6 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 	return nil
 }
 
-func (d *VirtualMachineController) calculateLiveMigrationCondition(vmi *v1.VirtualMachineInstance, hasHotplug bool) (*v1.VirtualMachineInstanceCondition, bool) {
-	liveMigrationCondition := v1.VirtualMachineInstanceCondition{
-		Type:   v1.VirtualMachineInstanceIsMigratable,
-		Status: k8sv1.ConditionTrue,
-	}
-	isBlockMigration, err := d.checkVolumesForMigration(vmi)
-	if err != nil {
-		liveMigrationCondition.Status = k8sv1.ConditionFalse
-		liveMigrationCondition.Message = err.Error()
-		liveMigrationCondition.Reason = v1.VirtualMachineInstanceReasonDisksNotMigratable
-		return &liveMigrationCondition, isBlockMigration
-	}
-	err = d.checkNetworkInterfacesForMigration(vmi)
-	if err != nil {
-		liveMigrationCondition = v1.VirtualMachineInstanceCondition{
-			Type:    v1.VirtualMachineInstanceIsMigratable,
-			Status:  k8sv1.ConditionFalse,
-			Message: err.Error(),
-			Reason:  v1.VirtualMachineInstanceReasonInterfaceNotMigratable,
-		}
-		return &liveMigrationCondition, isBlockMigration
-	}
-	if hasHotplug {
-		liveMigrationCondition = v1.VirtualMachineInstanceCondition{
-			Type:    v1.VirtualMachineInstanceIsMigratable,
-			Status:  k8sv1.ConditionFalse,
-			Message: "VMI has hotplugged disks",
-			Reason:  v1.VirtualMachineInstanceReasonHotplugNotMigratable,
-		}
-		return &liveMigrationCondition, isBlockMigration
-	}
-	return &liveMigrationCondition, isBlockMigration
-}
-
 func (c *VirtualMachineController) Run(threadiness int, stopCh chan struct{}) {
 	defer c.Queue.ShutDown()
 	log.Log.Info("Starting virt-handler controller.")
@@ -1668,6 +1797,7 @@ func (d *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstan
 
 	d.migrationProxy.StopTargetListener(vmiId)
 	d.migrationProxy.StopSourceListener(vmiId)
+	d.migrationProxy.StopBackupListener(vmiId)
 
 	// Unmount container disks and clean up remaining files
 	if err := d.containerDiskMounter.Unmount(vmi); err != nil {
@@ -2000,90 +2130,6 @@ func (d *VirtualMachineController) isPreMigrationTarget(vmi *v1.VirtualMachineIn
 	return false
 }
 
-func (d *VirtualMachineController) checkNetworkInterfacesForMigration(vmi *v1.VirtualMachineInstance) error {
-	err := validatePodNetworkInterfaceUsesMasqueradeBinding(vmi)
-	if err != nil {
-		return err
-	}
-
-	err = d.validateSRIOVInterfacesForMigration(vmi)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func validatePodNetworkInterfaceUsesMasqueradeBinding(vmi *v1.VirtualMachineInstance) error {
-	interfacesByName := map[string]v1.Interface{}
-	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
-		interfacesByName[iface.Name] = iface
-	}
-
-	vmiPodNetworkName := lookupVMIPodNetworkName(vmi.Spec.Networks)
-	if vmiPodNetworkName != "" && interfacesByName[vmiPodNetworkName].Masquerade == nil {
-		return fmt.Errorf("cannot migrate VMI which does not use masquerade to connect to the pod network")
-	}
-
-	return nil
-}
-
-func lookupVMIPodNetworkName(networks []v1.Network) string {
-	for _, network := range networks {
-		if network.Pod != nil {
-			return network.Name
-		}
-	}
-
-	return ""
-}
-
-func (d *VirtualMachineController) validateSRIOVInterfacesForMigration(vmi *v1.VirtualMachineInstance) error {
-	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
-		if iface.SRIOV != nil && !d.clusterConfig.SRIOVLiveMigrationEnabled() {
-			return fmt.Errorf("SRIOVLiveMigration feature-gate is closed, can't migrate VMI with SRIOV interfaces")
-		}
-	}
-
-	return nil
-}
-
-func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachineInstance) (blockMigrate bool, err error) {
-	// Check if all VMI volumes can be shared between the source and the destination
-	// of a live migration. blockMigrate will be returned as false, only if all volumes
-	// are shared and the VMI has no local disks
-	// Some combinations of disks makes the VMI no suitable for live migration.
-	// A relevant error will be returned in this case.
-	for _, volume := range vmi.Spec.Volumes {
-		volSrc := volume.VolumeSource
-		if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil {
-			var volName string
-			if volSrc.PersistentVolumeClaim != nil {
-				volName = volSrc.PersistentVolumeClaim.ClaimName
-			} else {
-				volName = volSrc.DataVolume.Name
-			}
-			_, shared, err := pvcutils.IsSharedPVCFromClient(d.clientset, vmi.Namespace, volName)
-			if errors.IsNotFound(err) {
-				return blockMigrate, fmt.Errorf("persistentvolumeclaim %v not found", volName)
-			} else if err != nil {
-				return blockMigrate, err
-			}
-			if !shared {
-				return true, fmt.Errorf("cannot migrate VMI: PVC %v is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)", volName)
-			}
-		} else if volSrc.HostDisk != nil {
-			shared := volSrc.HostDisk.Shared != nil && *volSrc.HostDisk.Shared
-			if !shared {
-				return true, fmt.Errorf("cannot migrate VMI with non-shared HostDisk")
-			}
-		} else {
-			blockMigrate = true
-		}
-	}
-	return
-}
-
 func (d *VirtualMachineController) isMigrationSource(vmi *v1.VirtualMachineInstance) bool {
 
 	if vmi.Status.MigrationState != nil &&
@@ -2136,6 +2182,47 @@ func (d *VirtualMachineController) handlePostMigrationProxyCleanup(vmi *v1.Virtu
 	return nil
 }
 
+// handleBackupProxy exposes the NBD server of a backup in progress to the pod
+// copying the disks to the backup target, through a socket in its emptyDir.
+func (d *VirtualMachineController) handleBackupProxy(vmi *v1.VirtualMachineInstance) error {
+	backup := vmi.Status.Backup
+	if backup == nil || backup.Completed || backup.TargetPodUID == "" {
+		d.migrationProxy.StopBackupListener(string(vmi.UID))
+		return nil
+	}
+
+	nbdDir := fmt.Sprintf(d.backupNBDDirPattern, string(backup.TargetPodUID))
+	exists, err := diskutils.FileExists(nbdDir)
+	if err != nil {
+		return err
+	}
+	if !exists || len(backup.Exports) == 0 {
+		// the pod copying the disks is not running yet or the backup did not begin
+		d.Queue.AddAfter(controller.VirtualMachineKey(vmi), time.Second*1)
+		return nil
+	}
+
+	// the pod waits for the socket, so the export list has to be written first
+	var exports strings.Builder
+	for _, export := range backup.Exports {
+		fmt.Fprintf(&exports, "%s %s\n", export.VolumeName, export.ExportName)
+	}
+	exportsFile := filepath.Join(nbdDir, "exports")
+	if err := ioutil.WriteFile(exportsFile+".tmp", []byte(exports.String()), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(exportsFile+".tmp", exportsFile); err != nil {
+		return err
+	}
+
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return err
+	}
+	nbdSocket := fmt.Sprintf(filepath.Join(d.virtLauncherFSRunDirPattern, "kubevirt-private", string(vmi.UID), "virt-backup-nbd"), res.Pid())
+	return d.migrationProxy.StartBackupListener(string(vmi.UID), filepath.Join(nbdDir, "nbd.sock"), nbdSocket)
+}
+
 func (d *VirtualMachineController) handleSourceMigrationProxy(vmi *v1.VirtualMachineInstance) error {
 
 	res, err := d.podIsolationDetector.Detect(vmi)
@@ -2339,6 +2426,12 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 		if err := d.hotplugVolumeMounter.Mount(vmi); err != nil {
 			return err
 		}
+		if err := d.hotplugPodNetworkPhase1(vmi); err != nil {
+			return fmt.Errorf("failed to hotplug network interfaces: %v", err)
+		}
+		if err := d.handleBackupProxy(vmi); err != nil {
+			return fmt.Errorf("failed to handle backup proxy: %v", err)
+		}
 	}
 
 	smbios := d.clusterConfig.GetSMBIOS()
@@ -2352,8 +2445,10 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 			Sku:          smbios.Sku,
 			Version:      smbios.Version,
 		},
-		MemBalloonStatsPeriod: period,
-		PreallocatedVolumes:   preallocatedVolumes,
+		MemBalloonStatsPeriod:    period,
+		PreallocatedVolumes:      preallocatedVolumes,
+		ExpandDisksEnabled:       d.clusterConfig.ExpandDisksEnabled(),
+		IncrementalBackupEnabled: d.clusterConfig.IncrementalBackupEnabled(),
 	}
 
 	err = client.SyncVirtualMachine(vmi, options)
@@ -2546,6 +2641,80 @@ func isACPIEnabled(vmi *v1.VirtualMachineInstance, domain *api.Domain) bool {
 		domain.Spec.Features.ACPI != nil
 }
 
+func convertInterfaceRoutes(routes []api.InterfaceRoute) []v1.VirtualMachineInstanceNetworkRoute {
+	var converted []v1.VirtualMachineInstanceNetworkRoute
+	for _, route := range routes {
+		converted = append(converted, v1.VirtualMachineInstanceNetworkRoute{
+			Destination: route.Destination,
+			Gateway:     route.Gateway,
+			Metric:      route.Metric,
+		})
+	}
+	return converted
+}
+
+// interfaceIPDetails marks the source of each IP address of the interface. Without an agent status the
+// addresses are the addresses of the pod. Addresses reported by the guest agent which were served to the
+// guest by the DHCP server of the interface, the pod address of a bridge on the pod network or a static
+// IPAM address, are marked as DHCP addresses.
+func (d *VirtualMachineController) interfaceIPDetails(vmi *v1.VirtualMachineInstance, iface v1.VirtualMachineInstanceNetworkInterface, agentStatus *api.InterfaceStatus) ([]v1.VirtualMachineInstanceNetworkInterfaceIP, error) {
+	if agentStatus == nil {
+		var details []v1.VirtualMachineInstanceNetworkInterfaceIP
+		for _, ip := range iface.IPs {
+			details = append(details, v1.VirtualMachineInstanceNetworkInterfaceIP{IP: ip, Source: v1.InterfaceIPSourcePod})
+		}
+		return details, nil
+	}
+
+	dhcpIPs := map[string]bool{}
+	for _, ifaceSpec := range vmi.Spec.Domain.Devices.Interfaces {
+		if ifaceSpec.Name != iface.Name {
+			continue
+		}
+		if ifaceSpec.IPAM != nil {
+			for _, address := range ifaceSpec.IPAM.Addresses {
+				if ip, _, err := net.ParseCIDR(address); err == nil {
+					dhcpIPs[ip.String()] = true
+				}
+			}
+		}
+		if ifaceSpec.Bridge != nil && isPodNetwork(vmi, iface.Name) {
+			podIface, err := d.getPodInterfacefromFileCache(vmi, iface.Name)
+			if err != nil {
+				return nil, err
+			}
+			if podIface != nil {
+				for _, podIP := range podIface.PodIPs {
+					dhcpIPs[podIP] = true
+				}
+			}
+		}
+	}
+
+	var details []v1.VirtualMachineInstanceNetworkInterfaceIP
+	for _, reported := range agentStatus.IPDetails {
+		detail := v1.VirtualMachineInstanceNetworkInterfaceIP{
+			IP:           reported.IP,
+			PrefixLength: reported.PrefixLength,
+			Source:       v1.InterfaceIPSourceGuestAgent,
+		}
+		if ip := net.ParseIP(reported.IP); ip != nil && dhcpIPs[ip.String()] {
+			detail.Source = v1.InterfaceIPSourceDHCP
+		}
+		details = append(details, detail)
+	}
+	return details, nil
+}
+
+func isPodNetwork(vmi *v1.VirtualMachineInstance, name string) bool {
+	for _, network := range vmi.Spec.Networks {
+		if network.Name == name {
+			return network.Pod != nil
+		}
+	}
+	return false
+}
+
 func setMissingSRIOVInterfacesNames(interfacesSpecByName map[string]v1.Interface, interfacesStatusByMac map[string]api.InterfaceStatus) {
 	for name, ifaceSpec := range interfacesSpecByName {
 		if ifaceSpec.SRIOV == nil || ifaceSpec.MacAddress == "" {
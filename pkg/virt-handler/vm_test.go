@@ -20,7 +20,6 @@
 package virthandler
 
 import (
-	"context"
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
@@ -188,6 +187,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 			podIpAddress,
 			shareDir,
 			privateDir,
+			podsDir,
 			vmiSourceInformer,
 			vmiTargetInformer,
 			domainInformer,
@@ -201,6 +201,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 		controller.hotplugVolumeMounter = mockHotplugVolumeMounter
 		controller.networkCacheStoreFactory = networkingfake.NewFakeInMemoryNetworkCacheFactory()
 		controller.virtLauncherFSRunDirPattern = filepath.Join(shareDir, "%d")
+		controller.backupNBDDirPattern = filepath.Join(podsDir, "%s", "backup-nbd")
 
 		vmiTestUUID = uuid.NewUUID()
 		podTestUUID = uuid.NewUUID()
@@ -657,13 +658,6 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			updatedVMI := vmi.DeepCopy()
 			updatedVMI.Status.Phase = v1.Running
-			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
-				{
-					Type:   v1.VirtualMachineInstanceIsMigratable,
-					Status: k8sv1.ConditionTrue,
-				},
-			}
-			updatedVMI.Status.MigrationMethod = v1.LiveMigration
 			updatedVMI.Status.Interfaces = make([]v1.VirtualMachineInstanceNetworkInterface, 0)
 			mockWatchdog.CreateFile(vmi)
 			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
@@ -713,10 +707,6 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			updatedVMI := vmi.DeepCopy()
 			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
-				{
-					Type:   v1.VirtualMachineInstanceIsMigratable,
-					Status: k8sv1.ConditionTrue,
-				},
 				{
 					Type:          v1.VirtualMachineInstanceAgentConnected,
 					LastProbeTime: metav1.Now(),
@@ -879,10 +869,6 @@ var _ = Describe("VirtualMachineInstance", func() {
 					LastProbeTime: metav1.Now(),
 					Status:        k8sv1.ConditionTrue,
 				},
-				{
-					Type:   v1.VirtualMachineInstanceIsMigratable,
-					Status: k8sv1.ConditionTrue,
-				},
 			}
 
 			vmiFeeder.Add(vmi)
@@ -1009,10 +995,6 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			updatedVMI := vmi.DeepCopy()
 			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
-				{
-					Type:   v1.VirtualMachineInstanceIsMigratable,
-					Status: k8sv1.ConditionTrue,
-				},
 				{
 					Type:   v1.VirtualMachineInstancePaused,
 					Status: k8sv1.ConditionTrue,
@@ -1034,12 +1016,6 @@ var _ = Describe("VirtualMachineInstance", func() {
 			domain.Status.Reason = ""
 
 			updatedVMI = vmi.DeepCopy()
-			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
-				{
-					Type:   v1.VirtualMachineInstanceIsMigratable,
-					Status: k8sv1.ConditionTrue,
-				},
-			}
 
 			vmiFeeder.Add(vmi)
 			domainFeeder.Add(domain)
@@ -1131,13 +1107,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 			vmi = addActivePods(vmi, podTestUUID, host)
 
 			updatedVMI := vmi.DeepCopy()
-			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
-				{
-					Type:   v1.VirtualMachineInstanceIsMigratable,
-					Status: k8sv1.ConditionTrue,
-				},
-			}
-			updatedVMI.Status.MigrationMethod = v1.LiveMigration
+			updatedVMI.Status.Conditions = nil
 
 			mockWatchdog.CreateFile(vmi)
 			vmiFeeder.Add(vmi)
@@ -1247,6 +1217,60 @@ var _ = Describe("VirtualMachineInstance", func() {
 				testutils.ExpectEvent(recorder, v1.SyncFailed.String())
 			})
 
+			Context("with hotplugged network interfaces", func() {
+				newVMIWithHotpluggedInterface := func() *v1.VirtualMachineInstance {
+					vmi := v1.NewMinimalVMI("testvmi")
+					vmi.UID = vmiTestUUID
+					vmi.Status.Phase = v1.Running
+					vmi.Spec.Networks = []v1.Network{
+						*v1.DefaultPodNetwork(),
+						{
+							Name:          "blue",
+							NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}},
+						},
+					}
+					vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+						*v1.DefaultBridgeNetworkInterface(),
+						{
+							Name:                   "blue",
+							InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+						},
+					}
+					vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{{Name: "default"}}
+					return vmi
+				}
+
+				It("should set up the pod network of a new interface when HotplugNICs is enabled", func() {
+					config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+						Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.HotplugNICsGate},
+					})
+					controller.clusterConfig = config
+					mockIsolationResult.EXPECT().DoNetNS(gomock.Any()).Return(nil).Times(1)
+
+					vmi := newVMIWithHotpluggedInterface()
+					Expect(controller.hotplugPodNetworkPhase1(vmi)).To(Succeed())
+				})
+
+				It("should not touch the pod network when HotplugNICs is disabled", func() {
+					mockIsolationResult.EXPECT().DoNetNS(gomock.Any()).Times(0)
+
+					vmi := newVMIWithHotpluggedInterface()
+					Expect(controller.hotplugPodNetworkPhase1(vmi)).To(Succeed())
+				})
+
+				It("should not touch the pod network once the interface is reported", func() {
+					config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+						Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.HotplugNICsGate},
+					})
+					controller.clusterConfig = config
+					mockIsolationResult.EXPECT().DoNetNS(gomock.Any()).Times(0)
+
+					vmi := newVMIWithHotpluggedInterface()
+					vmi.Status.Interfaces = append(vmi.Status.Interfaces, v1.VirtualMachineInstanceNetworkInterface{Name: "blue"})
+					Expect(controller.hotplugPodNetworkPhase1(vmi)).To(Succeed())
+				})
+			})
+
 			It("should call unmountAll from processVmCleanup", func() {
 				vmi := v1.NewMinimalVMI("testvmi")
 				vmi.UID = vmiTestUUID
@@ -1409,6 +1433,75 @@ var _ = Describe("VirtualMachineInstance", func() {
 				controller.updateVolumeStatusesFromDomain(vmi, domain)
 			})
 
+			It("should not mark media injected into an empty CD-ROM ready before it is changed", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.UID = vmiTestUUID
+				vmi.Status.Phase = v1.Running
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "cdrom",
+				})
+				vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+					Name:  "cdrom",
+					Phase: v1.HotplugVolumeMounted,
+					HotplugVolume: &v1.HotplugVolumeStatus{
+						AttachPodName: "testpod",
+						AttachPodUID:  "1234",
+					},
+				})
+				domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+				domain.Status.Status = api.Running
+				domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, api.Disk{
+					Device: "cdrom",
+					Alias:  api.NewUserDefinedAlias("cdrom"),
+					Target: api.DiskTarget{
+						Device: "sda",
+					},
+				})
+				mockHotplugVolumeMounter.EXPECT().IsMounted(vmi, "cdrom", gomock.Any()).Return(true, nil)
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+				Expect(vmi.Status.VolumeStatus[0].Phase).To(Equal(v1.HotplugVolumeMounted))
+				Expect(vmi.Status.VolumeStatus[0].Target).To(BeEmpty())
+
+				By("Changing the media of the drive")
+				domain.Spec.Devices.Disks[0].Source.File = "/var/run/kubevirt/hotplug-disks/cdrom/disk.img"
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+				Expect(vmi.Status.VolumeStatus[0].Phase).To(Equal(v1.VolumeReady))
+				Expect(vmi.Status.VolumeStatus[0].Target).To(Equal("sda"))
+				testutils.ExpectEvent(recorder, "Successfully attach hotplugged volume cdrom to VM")
+			})
+
+			It("should record the disk sizes reported by the domain", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.UID = vmiTestUUID
+				vmi.Status.Phase = v1.Running
+				vmi.Status.VolumeStatus = []v1.VolumeStatus{
+					{
+						Name: "permvolume",
+						Size: 1 << 30,
+					},
+					{
+						Name:  "hpvolume",
+						Phase: v1.VolumeReady,
+						HotplugVolume: &v1.HotplugVolumeStatus{
+							AttachPodName: "testpod",
+							AttachPodUID:  "1234",
+						},
+					},
+				}
+				domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+				domain.Status.Status = api.Running
+				domain.Spec.Metadata.KubeVirt.DiskSizes = []api.DiskSizeMetadata{
+					{Name: "permvolume", Size: 2 << 30},
+					{Name: "hpvolume", Size: 3 << 30},
+				}
+				mockHotplugVolumeMounter.EXPECT().IsMounted(vmi, "hpvolume", gomock.Any()).Return(true, nil)
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+				Expect(vmi.Status.VolumeStatus[0].Name).To(Equal("hpvolume"))
+				Expect(vmi.Status.VolumeStatus[0].Size).To(Equal(int64(3 << 30)))
+				Expect(vmi.Status.VolumeStatus[1].Name).To(Equal("permvolume"))
+				Expect(vmi.Status.VolumeStatus[1].Size).To(Equal(int64(2 << 30)))
+			})
+
 			It("generateEventsForVolumeStatusChange should not modify arguments", func() {
 				vmi := v1.NewMinimalVMI("testvmi")
 				vmi.UID = vmiTestUUID
@@ -1775,446 +1868,6 @@ var _ = Describe("VirtualMachineInstance", func() {
 		}, 3)
 	})
 
-	Context("check if migratable", func() {
-
-		var testBlockPvc *k8sv1.PersistentVolumeClaim
-
-		BeforeEach(func() {
-			kubeClient := fake.NewSimpleClientset()
-			virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
-
-			// create a test block pvc
-			mode := k8sv1.PersistentVolumeBlock
-			testBlockPvc = &k8sv1.PersistentVolumeClaim{
-				TypeMeta:   metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
-				ObjectMeta: metav1.ObjectMeta{Namespace: k8sv1.NamespaceDefault, Name: "testblock"},
-				Spec: k8sv1.PersistentVolumeClaimSpec{
-					VolumeMode:  &mode,
-					AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany},
-				},
-			}
-
-		})
-		It("should block migrate non-shared disks ", func() {
-
-			vmi := v1.NewMinimalVMI("testvmi")
-			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
-				{
-					Name: "mydisk",
-					DiskDevice: v1.DiskDevice{
-						Disk: &v1.DiskTarget{
-							Bus: "virtio",
-						},
-					},
-				},
-			}
-			vmi.Spec.Volumes = []v1.Volume{
-				{
-					Name: "myvolume",
-					VolumeSource: v1.VolumeSource{
-						Ephemeral: &v1.EphemeralVolumeSource{
-							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
-								ClaimName: "testclaim",
-							},
-						},
-					},
-				},
-			}
-
-			blockMigrate, err := controller.checkVolumesForMigration(vmi)
-			Expect(blockMigrate).To(BeTrue())
-			Expect(err).To(BeNil())
-		})
-		It("should migrate shared disks without blockMigration flag", func() {
-
-			vmi := v1.NewMinimalVMI("testvmi")
-			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
-				{
-					Name: "mydisk",
-					DiskDevice: v1.DiskDevice{
-						Disk: &v1.DiskTarget{
-							Bus: "virtio",
-						},
-					},
-				},
-			}
-			vmi.Spec.Volumes = []v1.Volume{
-				{
-					Name: "myvolume",
-					VolumeSource: v1.VolumeSource{
-						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
-							ClaimName: "testblock",
-						},
-					},
-				},
-			}
-
-			virtClient.CoreV1().PersistentVolumeClaims(vmi.Namespace).Create(context.Background(), testBlockPvc, metav1.CreateOptions{})
-			blockMigrate, err := controller.checkVolumesForMigration(vmi)
-			Expect(blockMigrate).To(BeFalse())
-			Expect(err).To(BeNil())
-		})
-		It("should fail migration for non-shared PVCs", func() {
-
-			vmi := v1.NewMinimalVMI("testvmi")
-			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
-				{
-					Name: "mydisk",
-					DiskDevice: v1.DiskDevice{
-						Disk: &v1.DiskTarget{
-							Bus: "virtio",
-						},
-					},
-				},
-			}
-			vmi.Spec.Volumes = []v1.Volume{
-				{
-					Name: "myvolume",
-					VolumeSource: v1.VolumeSource{
-						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
-							ClaimName: "testblock",
-						},
-					},
-				},
-			}
-
-			testBlockPvc.Spec.AccessModes = []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce}
-
-			virtClient.CoreV1().PersistentVolumeClaims(vmi.Namespace).Create(context.Background(), testBlockPvc, metav1.CreateOptions{})
-			blockMigrate, err := controller.checkVolumesForMigration(vmi)
-			Expect(blockMigrate).To(BeTrue())
-			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI: PVC testblock is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)")))
-		})
-		It("should fail migration for non-shared data volume PVCs", func() {
-
-			vmi := v1.NewMinimalVMI("testvmi")
-			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
-				{
-					Name: "mydisk",
-					DiskDevice: v1.DiskDevice{
-						Disk: &v1.DiskTarget{
-							Bus: "virtio",
-						},
-					},
-				},
-			}
-			vmi.Spec.Volumes = []v1.Volume{
-				{
-					Name: "myvolume",
-					VolumeSource: v1.VolumeSource{
-						DataVolume: &v1.DataVolumeSource{
-							Name: "testblock",
-						},
-					},
-				},
-			}
-
-			testBlockPvc.Spec.AccessModes = []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce}
-
-			virtClient.CoreV1().PersistentVolumeClaims(vmi.Namespace).Create(context.Background(), testBlockPvc, metav1.CreateOptions{})
-			blockMigrate, err := controller.checkVolumesForMigration(vmi)
-			Expect(blockMigrate).To(BeTrue())
-			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI: PVC testblock is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)")))
-		})
-		It("should be allowed to migrate a mix of shared and non-shared disks", func() {
-
-			vmi := v1.NewMinimalVMI("testvmi")
-			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
-				{
-					Name: "mydisk",
-					DiskDevice: v1.DiskDevice{
-						Disk: &v1.DiskTarget{
-							Bus: "virtio",
-						},
-					},
-				},
-				{
-					Name: "mydisk1",
-					DiskDevice: v1.DiskDevice{
-						Disk: &v1.DiskTarget{
-							Bus: "virtio",
-						},
-					},
-				},
-			}
-			vmi.Spec.Volumes = []v1.Volume{
-				{
-					Name: "myvolume",
-					VolumeSource: v1.VolumeSource{
-						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
-							ClaimName: "testblock",
-						},
-					},
-				},
-				{
-					Name: "myvolume1",
-					VolumeSource: v1.VolumeSource{
-						Ephemeral: &v1.EphemeralVolumeSource{
-							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
-								ClaimName: "testclaim",
-							},
-						},
-					},
-				},
-			}
-
-			virtClient.CoreV1().PersistentVolumeClaims(vmi.Namespace).Create(context.Background(), testBlockPvc, metav1.CreateOptions{})
-			blockMigrate, err := controller.checkVolumesForMigration(vmi)
-			Expect(blockMigrate).To(BeTrue())
-			Expect(err).To(BeNil())
-		})
-		It("should be allowed to migrate a mix of non-shared and shared disks", func() {
-
-			vmi := v1.NewMinimalVMI("testvmi")
-			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
-				{
-					Name: "mydisk",
-					DiskDevice: v1.DiskDevice{
-						Disk: &v1.DiskTarget{
-							Bus: "virtio",
-						},
-					},
-				},
-				{
-					Name: "mydisk1",
-					DiskDevice: v1.DiskDevice{
-						Disk: &v1.DiskTarget{
-							Bus: "virtio",
-						},
-					},
-				},
-			}
-			vmi.Spec.Volumes = []v1.Volume{
-				{
-					Name: "myvolume1",
-					VolumeSource: v1.VolumeSource{
-						Ephemeral: &v1.EphemeralVolumeSource{
-							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
-								ClaimName: "testclaim",
-							},
-						},
-					},
-				},
-				{
-					Name: "myvolume",
-					VolumeSource: v1.VolumeSource{
-						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
-							ClaimName: "testblock",
-						},
-					},
-				},
-			}
-
-			virtClient.CoreV1().PersistentVolumeClaims(vmi.Namespace).Create(context.Background(), testBlockPvc, metav1.CreateOptions{})
-			blockMigrate, err := controller.checkVolumesForMigration(vmi)
-			Expect(blockMigrate).To(BeTrue())
-			Expect(err).To(BeNil())
-		})
-		It("should be allowed to live-migrate shared HostDisks ", func() {
-			_true := true
-			vmi := v1.NewMinimalVMI("testvmi")
-			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
-				{
-					Name: "myvolume",
-					DiskDevice: v1.DiskDevice{
-						Disk: &v1.DiskTarget{
-							Bus: "virtio",
-						},
-					},
-				},
-			}
-			vmi.Spec.Volumes = []v1.Volume{
-				{
-					Name: "myvolume",
-					VolumeSource: v1.VolumeSource{
-						HostDisk: &v1.HostDisk{
-							Path:     "/var/run/kubevirt-private/vmi-disks/volume3/disk.img",
-							Type:     v1.HostDiskExistsOrCreate,
-							Capacity: resource.MustParse("1Gi"),
-							Shared:   &_true,
-						},
-					},
-				},
-			}
-
-			blockMigrate, err := controller.checkVolumesForMigration(vmi)
-			Expect(blockMigrate).To(BeFalse())
-			Expect(err).To(BeNil())
-		})
-		It("should not be allowed to live-migrate shared and non-shared HostDisks ", func() {
-			_true := true
-			_false := false
-			vmi := v1.NewMinimalVMI("testvmi")
-			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
-				{
-					Name: "mydisk",
-					DiskDevice: v1.DiskDevice{
-						Disk: &v1.DiskTarget{
-							Bus: "virtio",
-						},
-					},
-				},
-				{
-					Name: "mydisk1",
-					DiskDevice: v1.DiskDevice{
-						Disk: &v1.DiskTarget{
-							Bus: "virtio",
-						},
-					},
-				},
-			}
-			vmi.Spec.Volumes = []v1.Volume{
-				{
-					Name: "myvolume",
-					VolumeSource: v1.VolumeSource{
-						HostDisk: &v1.HostDisk{
-							Path:     "/var/run/kubevirt-private/vmi-disks/volume3/disk.img",
-							Type:     v1.HostDiskExistsOrCreate,
-							Capacity: resource.MustParse("1Gi"),
-							Shared:   &_true,
-						},
-					},
-				},
-				{
-					Name: "myvolume1",
-					VolumeSource: v1.VolumeSource{
-						HostDisk: &v1.HostDisk{
-							Path:     "/var/run/kubevirt-private/vmi-disks/volume31/disk.img",
-							Type:     v1.HostDiskExistsOrCreate,
-							Capacity: resource.MustParse("1Gi"),
-							Shared:   &_false,
-						},
-					},
-				},
-			}
-
-			blockMigrate, err := controller.checkVolumesForMigration(vmi)
-			Expect(blockMigrate).To(BeTrue())
-			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with non-shared HostDisk")))
-		})
-
-		Context("with network configuration", func() {
-			It("should block migration for bridge binding assigned to the pod network", func() {
-				vmi := v1.NewMinimalVMI("testvmi")
-				interface_name := "interface_name"
-
-				vmi.Spec.Networks = []v1.Network{
-					{
-						Name:          interface_name,
-						NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
-					},
-				}
-				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
-					{
-						Name: interface_name,
-						InterfaceBindingMethod: v1.InterfaceBindingMethod{
-							Bridge: &v1.InterfaceBridge{},
-						},
-					},
-				}
-
-				err := controller.checkNetworkInterfacesForMigration(vmi)
-				Expect(err).To(HaveOccurred())
-			})
-			It("should block migration for VMI with SRIOV interface when feature-gate SRIOVLiveMigration is off", func() {
-				vmi := v1.NewMinimalVMI("testvmi")
-				sriovInterfaceName := "sriovnet1"
-				vmi.Spec.Networks = []v1.Network{
-					{
-						Name: sriovInterfaceName,
-						NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{
-							NetworkName: "sriov-network1",
-						}},
-					},
-				}
-				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
-					{
-						Name: sriovInterfaceName,
-						InterfaceBindingMethod: v1.InterfaceBindingMethod{
-							SRIOV: &v1.InterfaceSRIOV{},
-						},
-					},
-				}
-
-				Expect(controller.checkNetworkInterfacesForMigration(vmi)).ShouldNot(Succeed())
-			})
-
-			It("should not block migration for VMI with SRIOV interface when feature-gate SRIOVLiveMigration is on", func() {
-				vmi := v1.NewMinimalVMI("testvmi")
-				sriovInterfaceName := "sriovnet1"
-				kubevirtConfigMapFeatureGate := map[string]string{virtconfig.FeatureGatesKey: virtconfig.SRIOVLiveMigrationGate}
-				vmi.Spec.Networks = []v1.Network{
-					{
-						Name: sriovInterfaceName,
-						NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{
-							NetworkName: "sriov-network1",
-						}},
-					},
-				}
-				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
-					{
-						Name: sriovInterfaceName,
-						InterfaceBindingMethod: v1.InterfaceBindingMethod{
-							SRIOV: &v1.InterfaceSRIOV{},
-						},
-					},
-				}
-				config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
-					Data: kubevirtConfigMapFeatureGate,
-				})
-				controller.clusterConfig = config
-
-				Expect(controller.checkNetworkInterfacesForMigration(vmi)).Should(Succeed())
-			})
-
-			It("should not block migration for masquerade binding assigned to the pod network", func() {
-				vmi := v1.NewMinimalVMI("testvmi")
-				interface_name := "interface_name"
-
-				vmi.Spec.Networks = []v1.Network{
-					{
-						Name:          interface_name,
-						NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
-					},
-				}
-				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
-					{
-						Name: interface_name,
-						InterfaceBindingMethod: v1.InterfaceBindingMethod{
-							Masquerade: &v1.InterfaceMasquerade{},
-						},
-					},
-				}
-
-				err := controller.checkNetworkInterfacesForMigration(vmi)
-				Expect(err).ToNot(HaveOccurred())
-			})
-
-			It("should not block migration for bridge binding assigned to a multus network", func() {
-				vmi := v1.NewMinimalVMI("testvmi")
-				interface_name := "interface_name"
-
-				vmi.Spec.Networks = []v1.Network{
-					{
-						Name:          interface_name,
-						NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{}},
-					},
-				}
-				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
-					{
-						Name: interface_name,
-						InterfaceBindingMethod: v1.InterfaceBindingMethod{
-							Bridge: &v1.InterfaceBridge{},
-						},
-					},
-				}
-
-				err := controller.checkNetworkInterfacesForMigration(vmi)
-				Expect(err).ToNot(HaveOccurred())
-			})
-		})
-
-	})
 	Context("When VirtualMachineInstance is connected to a network", func() {
 
 		It("should only report the pod network in status", func() {
@@ -2438,6 +2091,127 @@ var _ = Describe("VirtualMachineInstance", func() {
 			testutils.ExpectEvent(recorder, VMIStarted)
 		})
 
+		It("should report the guest network configuration in VMI status", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{
+					Name:                   "blue",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+					IPAM:                   &v1.InterfaceIPAM{Addresses: []string{"192.168.100.10/24"}},
+				},
+			}
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			}
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{{Name: "blue"}}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.Devices.Interfaces = []api.Interface{
+				{
+					MAC:       &api.MAC{MAC: "02:00:00:00:00:01"},
+					MTU:       &api.MTU{Size: "1400"},
+					LinkState: &api.LinkState{State: "down"},
+					Alias:     api.NewUserDefinedAlias("blue"),
+				},
+			}
+			domain.Status.Interfaces = []api.InterfaceStatus{
+				{
+					Name:          "blue",
+					Mac:           "02:00:00:00:00:01",
+					Ip:            "192.168.100.10",
+					IPs:           []string{"192.168.100.10", "fe80::1"},
+					InterfaceName: "enp1s0",
+					IPDetails:     []api.InterfaceIP{{IP: "192.168.100.10", PrefixLength: 24}, {IP: "fe80::1", PrefixLength: 64}},
+					Routes:        []api.InterfaceRoute{{Destination: "0.0.0.0/0", Gateway: "192.168.100.1", Metric: 100}},
+				},
+			}
+			domain.Status.DNS = &api.GuestDNS{Nameservers: []string{"192.168.100.1"}}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				status := arg.(*v1.VirtualMachineInstance).Status
+				Expect(status.Interfaces).To(Equal([]v1.VirtualMachineInstanceNetworkInterface{
+					{
+						Name:          "blue",
+						MAC:           "02:00:00:00:00:01",
+						IP:            "192.168.100.10",
+						IPs:           []string{"192.168.100.10", "fe80::1"},
+						InterfaceName: "enp1s0",
+						IPDetails: []v1.VirtualMachineInstanceNetworkInterfaceIP{
+							{IP: "192.168.100.10", PrefixLength: 24, Source: v1.InterfaceIPSourceDHCP},
+							{IP: "fe80::1", PrefixLength: 64, Source: v1.InterfaceIPSourceGuestAgent},
+						},
+						LinkState: v1.InterfaceLinkStateDown,
+						MTU:       1400,
+						Routes:    []v1.VirtualMachineInstanceNetworkRoute{{Destination: "0.0.0.0/0", Gateway: "192.168.100.1", Metric: 100}},
+					},
+				}))
+				Expect(status.GuestDNS).To(Equal(&v1.VirtualMachineInstanceGuestDNS{Nameservers: []string{"192.168.100.1"}}))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIStarted)
+		})
+
+		It("should report the enforced firewall in VMI status", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			firewall := &v1.InterfaceFirewall{
+				Ingress: &v1.FirewallPolicy{
+					Rules:         []v1.FirewallRule{{Action: v1.FirewallActionAllow, Protocol: "TCP", Port: 22}},
+					DefaultAction: v1.FirewallActionDeny,
+				},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{
+					Name:                   "blue",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+					Firewall:               firewall,
+				},
+			}
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			}
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{{Name: "blue"}}
+
+			podCacheInterface := &cache2.PodCacheInterface{
+				Iface:    &vmi.Spec.Domain.Devices.Interfaces[0],
+				Firewall: firewall,
+			}
+			Expect(controller.networkCacheStoreFactory.CacheForVMI(vmi).Write("blue", podCacheInterface)).To(Succeed())
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.Devices.Interfaces = []api.Interface{
+				{
+					MAC:   &api.MAC{MAC: "02:00:00:00:00:01"},
+					Alias: api.NewUserDefinedAlias("blue"),
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				status := arg.(*v1.VirtualMachineInstance).Status
+				Expect(status.Interfaces).To(HaveLen(1))
+				Expect(status.Interfaces[0].Firewall).To(Equal(firewall))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIStarted)
+		})
+
 		It("should update Guest OS Information in VMI status", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -2466,6 +2240,129 @@ var _ = Describe("VirtualMachineInstance", func() {
 			testutils.ExpectEvent(recorder, VMIStarted)
 		})
 
+		It("should update the current and maximum CPU topology in VMI status", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.CPU.Topology = &api.CPUTopology{Sockets: 4, Cores: 2, Threads: 1}
+			domain.Spec.VCPU = &api.VCPU{Placement: "static", CPUs: 8, Current: 4}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.CurrentCPUTopology).To(Equal(&v1.CPUTopology{Sockets: 2, Cores: 2, Threads: 1}))
+				Expect(arg.(*v1.VirtualMachineInstance).Status.MaxCPUTopology).To(Equal(&v1.CPUTopology{Sockets: 4, Cores: 2, Threads: 1}))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIStarted)
+		})
+
+		It("should report the backup and the checkpoints in VMI status and block migrations during the backup", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			now := metav1.Now()
+			domain.Spec.Metadata.KubeVirt.Backup = &api.BackupMetadata{
+				Name:           "backup",
+				Checkpoint:     "cp-2",
+				Incremental:    "cp-1",
+				Disks:          []api.BackupDiskMetadata{{Name: "rootdisk", Target: "vda"}},
+				StartTimestamp: &now,
+			}
+			domain.Spec.Metadata.KubeVirt.Checkpoints = []api.CheckpointMetadata{
+				{Name: "cp-1", CreationTimestamp: now, Volumes: []string{"rootdisk"}},
+				{Name: "cp-2", CreationTimestamp: now, Volumes: []string{"rootdisk"}},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				status := arg.(*v1.VirtualMachineInstance).Status
+				Expect(status.Backup).ToNot(BeNil())
+				Expect(status.Backup.Name).To(Equal("backup"))
+				Expect(status.Backup.Checkpoint).To(Equal("cp-2"))
+				Expect(status.Backup.Incremental).To(Equal("cp-1"))
+				Expect(status.Backup.Exports).To(Equal([]v1.BackupExport{
+					{VolumeName: "rootdisk", ExportName: "vda", DirtyBitmap: "qemu:dirty-bitmap:backup-vda"},
+				}))
+				Expect(status.Checkpoints).To(HaveLen(2))
+				Expect(status.Checkpoints[1].Name).To(Equal("cp-2"))
+				Expect(status.Checkpoints[1].Volumes).To(Equal([]string{"rootdisk"}))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIStarted)
+		})
+
+		table.DescribeTable("should merge the checkpoints of the domain metadata into the VMI status", func(metadata api.KubeVirtMetadata, expected []string) {
+			now := metav1.Now()
+			reported := []v1.VirtualMachineInstanceCheckpoint{
+				{Name: "cp-1", CreationTimestamp: now, Volumes: []string{"rootdisk"}},
+				{Name: "cp-2", CreationTimestamp: now, Volumes: []string{"rootdisk"}},
+			}
+
+			var names []string
+			for _, checkpoint := range mergeCheckpoints(reported, metadata) {
+				names = append(names, checkpoint.Name)
+			}
+			Expect(names).To(Equal(expected))
+		},
+			table.Entry("keeping the reported checkpoints before the domain recorded any",
+				api.KubeVirtMetadata{}, []string{"cp-1", "cp-2"}),
+			table.Entry("adding the checkpoints recorded by the domain",
+				api.KubeVirtMetadata{Checkpoints: []api.CheckpointMetadata{{Name: "cp-1"}, {Name: "cp-2"}, {Name: "cp-3"}}}, []string{"cp-1", "cp-2", "cp-3"}),
+			table.Entry("dropping the checkpoints pruned from the domain",
+				api.KubeVirtMetadata{Checkpoints: []api.CheckpointMetadata{{Name: "cp-2"}}}, []string{"cp-2"}),
+			table.Entry("dropping all checkpoints after a full backup without checkpoint",
+				api.KubeVirtMetadata{Backup: &api.BackupMetadata{Name: "backup"}}, nil),
+		)
+
+		It("should expose the NBD server of a backup to the pod copying the disks", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.Status.Backup = &v1.VirtualMachineInstanceBackupState{
+				Name:         "backup",
+				TargetPodUID: podTestUUID,
+				Exports: []v1.BackupExport{
+					{VolumeName: "rootdisk", ExportName: "vda"},
+					{VolumeName: "datadisk", ExportName: "vdb"},
+				},
+			}
+			nbdDir := filepath.Join(podsDir, string(podTestUUID), "backup-nbd")
+			nbdSocket := filepath.Join(nbdDir, "nbd.sock")
+
+			Expect(controller.handleBackupProxy(vmi)).To(Succeed())
+			Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+			_, err := os.Stat(nbdSocket)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+
+			Expect(os.MkdirAll(nbdDir, 0755)).To(Succeed())
+			Expect(controller.handleBackupProxy(vmi)).To(Succeed())
+			_, err = os.Stat(nbdSocket)
+			Expect(err).ToNot(HaveOccurred())
+			exports, err := ioutil.ReadFile(filepath.Join(nbdDir, "exports"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(exports)).To(Equal("rootdisk vda\ndatadisk vdb\n"))
+
+			vmi.Status.Backup.Completed = true
+			Expect(controller.handleBackupProxy(vmi)).To(Succeed())
+			_, err = os.Stat(nbdSocket)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
 		It("should add new vmi interfaces for new domain interfaces", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -2,9 +2,13 @@ package agentpoller
 
 import (
 	"encoding/json"
+	"net"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/sriov"
 )
 
 // GuestOsInfo is the response from 'guest-get-osinfo'
@@ -33,6 +37,22 @@ type IP struct {
 	Prefix int    `json:"prefix"`
 }
 
+// Route is the response from 'guest-network-get-route'.
+// IPv4 routes carry a mask and a gateway, IPv6 routes a prefix length and a next hop.
+type Route struct {
+	Interface         string `json:"iface"`
+	Destination       string `json:"destination"`
+	Metric            int    `json:"metric"`
+	Gateway           string `json:"gateway,omitempty"`
+	Mask              string `json:"mask,omitempty"`
+	DestinationPrefix string `json:"desprefixlen,omitempty"`
+	NextHop           string `json:"nexthop,omitempty"`
+	Version           int    `json:"version"`
+}
+
+// guestPCIAddressRE matches PCI addresses as reported by the guest kernel, e.g. 0000:01:00.0
+var guestPCIAddressRE = regexp.MustCompile(`^([0-9a-fA-F]{4}):([0-9a-fA-F]{2}):([0-9a-fA-F]{2})\.([0-7])$`)
+
 var stripRE = regexp.MustCompile(`{\s*\"return\":\s*([{\[][\s\S]*[}\]])\s*}`)
 
 // stripAgentResponse use regex to strip the wrapping item and returns the
@@ -116,6 +136,85 @@ func parseInterfaces(agentReply string) ([]api.InterfaceStatus, error) {
 	return resultInterfaces, nil
 }
 
+// parseRoutes parses agent reply string and groups the routes by the guest interface name
+func parseRoutes(agentReply string) (map[string][]api.InterfaceRoute, error) {
+	routes := []Route{}
+	response := stripAgentResponse(agentReply)
+
+	err := json.Unmarshal([]byte(response), &routes)
+	if err != nil {
+		return map[string][]api.InterfaceRoute{}, err
+	}
+
+	routesByInterface := map[string][]api.InterfaceRoute{}
+	for _, route := range routes {
+		converted, ok := convertRoute(route)
+		if !ok {
+			continue
+		}
+		routesByInterface[route.Interface] = append(routesByInterface[route.Interface], converted)
+	}
+	return routesByInterface, nil
+}
+
+func convertRoute(route Route) (api.InterfaceRoute, bool) {
+	destination := net.ParseIP(route.Destination)
+	if destination == nil {
+		return api.InterfaceRoute{}, false
+	}
+
+	var prefixLength, bits int
+	var gateway net.IP
+	if route.Version == 6 {
+		length, err := strconv.Atoi(route.DestinationPrefix)
+		if err != nil {
+			return api.InterfaceRoute{}, false
+		}
+		prefixLength, bits = length, net.IPv6len*8
+		gateway = net.ParseIP(route.NextHop)
+	} else {
+		mask := net.ParseIP(route.Mask).To4()
+		if mask == nil || destination.To4() == nil {
+			return api.InterfaceRoute{}, false
+		}
+		prefixLength, bits = net.IPMask(mask).Size()
+		if bits == 0 {
+			// not a canonical mask
+			return api.InterfaceRoute{}, false
+		}
+		destination = destination.To4()
+		gateway = net.ParseIP(route.Gateway)
+	}
+
+	converted := api.InterfaceRoute{
+		Destination: (&net.IPNet{IP: destination, Mask: net.CIDRMask(prefixLength, bits)}).String(),
+		Metric:      route.Metric,
+	}
+	// directly connected networks have no gateway
+	if gateway != nil && !gateway.IsUnspecified() {
+		converted.Gateway = gateway.String()
+	}
+	return converted, true
+}
+
+// parseResolvConf extracts the DNS servers and search domains from the resolv.conf of the guest
+func parseResolvConf(content string) api.GuestDNS {
+	dns := api.GuestDNS{}
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			dns.Nameservers = append(dns.Nameservers, fields[1])
+		case "search":
+			dns.Search = append(dns.Search, fields[1:]...)
+		}
+	}
+	return dns
+}
+
 // parseHostname from the agent response
 func parseHostname(agentReply string) (string, error) {
 	result := Hostname{}
@@ -206,13 +305,29 @@ func parseAgent(agentReply string) (string, error) {
 	return result.Version, nil
 }
 
-// MergeAgentStatusesWithDomainData merge QEMU interfaces with agent interfaces
-func MergeAgentStatusesWithDomainData(domInterfaces []api.Interface, interfaceStatuses []api.InterfaceStatus) []api.InterfaceStatus {
+// MergeAgentStatusesWithDomainData merge QEMU interfaces with agent interfaces.
+// Agent interfaces are correlated with the domain interfaces by their MAC address. Interfaces whose
+// MAC address was changed in the guest and SR-IOV interfaces are correlated by the PCI address the
+// guest reported for them, which is matched against the addresses of the domain interfaces and host devices.
+func MergeAgentStatusesWithDomainData(domInterfaces []api.Interface, hostDevices []api.HostDevice, interfaceStatuses []api.InterfaceStatus) []api.InterfaceStatus {
 	aliasByMac := map[string]string{}
+	aliasByPCIAddress := map[pciAddress]string{}
 	for _, ifc := range domInterfaces {
 		mac := ifc.MAC.MAC
 		alias := ifc.Alias.GetName()
 		aliasByMac[mac] = alias
+		if address, ok := domainPCIAddress(ifc.Address); ok {
+			aliasByPCIAddress[address] = alias
+		}
+	}
+	for _, hostDevice := range hostDevices {
+		alias := hostDevice.Alias.GetName()
+		if !strings.HasPrefix(alias, sriov.AliasPrefix) {
+			continue
+		}
+		if address, ok := domainPCIAddress(hostDevice.Address); ok {
+			aliasByPCIAddress[address] = strings.TrimPrefix(alias, sriov.AliasPrefix)
+		}
 	}
 
 	aliasesCoveredByAgent := []string{}
@@ -223,17 +338,23 @@ func MergeAgentStatusesWithDomainData(domInterfaces []api.Interface, interfaceSt
 			aliasesCoveredByAgent = append(aliasesCoveredByAgent, alias)
 		}
 	}
+	for i, interfaceStatus := range interfaceStatuses {
+		if interfaceStatus.Name != "" {
+			continue
+		}
+		address, ok := guestPCIAddress(interfaceStatus.PCIAddress)
+		if !ok {
+			continue
+		}
+		if alias, exists := aliasByPCIAddress[address]; exists && !containsAlias(aliasesCoveredByAgent, alias) {
+			interfaceStatuses[i].Name = alias
+			aliasesCoveredByAgent = append(aliasesCoveredByAgent, alias)
+		}
+	}
 
 	// If interface present in domain was not found in interfaceStatuses, add it
 	for mac, alias := range aliasByMac {
-		isCoveredByAgentData := false
-		for _, coveredAlias := range aliasesCoveredByAgent {
-			if alias == coveredAlias {
-				isCoveredByAgentData = true
-				break
-			}
-		}
-		if !isCoveredByAgentData {
+		if !containsAlias(aliasesCoveredByAgent, alias) {
 			interfaceStatuses = append(interfaceStatuses,
 				api.InterfaceStatus{
 					Mac:  mac,
@@ -245,6 +366,80 @@ func MergeAgentStatusesWithDomainData(domInterfaces []api.Interface, interfaceSt
 	return interfaceStatuses
 }
 
+func containsAlias(aliases []string, alias string) bool {
+	for _, a := range aliases {
+		if a == alias {
+			return true
+		}
+	}
+	return false
+}
+
+type pciAddress struct {
+	domain   uint64
+	bus      uint64
+	slot     uint64
+	function uint64
+}
+
+func domainPCIAddress(address *api.Address) (pciAddress, bool) {
+	if address == nil || address.Type != "pci" {
+		return pciAddress{}, false
+	}
+	var result pciAddress
+	for _, field := range []struct {
+		value  string
+		target *uint64
+	}{
+		{address.Domain, &result.domain},
+		{address.Bus, &result.bus},
+		{address.Slot, &result.slot},
+		{address.Function, &result.function},
+	} {
+		if field.value == "" {
+			continue
+		}
+		value, err := strconv.ParseUint(field.value, 0, 32)
+		if err != nil {
+			return pciAddress{}, false
+		}
+		*field.target = value
+	}
+	return result, true
+}
+
+// guestPCIAddress parses a PCI address in the format of the guest kernel, e.g. 0000:01:00.0
+func guestPCIAddress(address string) (pciAddress, bool) {
+	match := guestPCIAddressRE.FindStringSubmatch(address)
+	if match == nil {
+		return pciAddress{}, false
+	}
+	var result pciAddress
+	for i, target := range []*uint64{&result.domain, &result.bus, &result.slot, &result.function} {
+		value, err := strconv.ParseUint(match[i+1], 16, 32)
+		if err != nil {
+			return pciAddress{}, false
+		}
+		*target = value
+	}
+	return result, true
+}
+
+// parsePCISlotName returns the PCI address from the uevent file of a guest network device
+func parsePCISlotName(uevent string) (string, bool) {
+	for _, line := range strings.Split(uevent, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "PCI_SLOT_NAME=") {
+			continue
+		}
+		value := strings.TrimPrefix(line, "PCI_SLOT_NAME=")
+		if _, ok := guestPCIAddress(value); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
 // convertInterfaceStatusesFromAgentJSON does the conversion from agent info to api domain interfaces
 func convertInterfaceStatusesFromAgentJSON(agentResult []Interface) []api.InterfaceStatus {
 	interfaceStatuses := []api.InterfaceStatus{}
@@ -254,11 +449,16 @@ func convertInterfaceStatusesFromAgentJSON(agentResult []Interface) []api.Interf
 		}
 
 		interfaceIP, interfaceIPs := extractIPs(ifc.IPs)
+		var ipDetails []api.InterfaceIP
+		for _, ipAddr := range ifc.IPs {
+			ipDetails = append(ipDetails, api.InterfaceIP{IP: ipAddr.IP, PrefixLength: ipAddr.Prefix})
+		}
 		interfaceStatuses = append(interfaceStatuses, api.InterfaceStatus{
 			Mac:           ifc.MAC,
 			Ip:            interfaceIP,
 			IPs:           interfaceIPs,
 			InterfaceName: ifc.Name,
+			IPDetails:     ipDetails,
 		})
 	}
 	return interfaceStatuses
@@ -131,6 +131,7 @@ var _ = Describe("Qemu agent poller", func() {
 					Ip:            "10.244.0.81",
 					IPs:           []string{"10.244.0.81", "fe80::858:aff:fef4:51"},
 					InterfaceName: "eth0",
+					IPDetails:     []api.InterfaceIP{{IP: "10.244.0.81", PrefixLength: 24}, {IP: "fe80::858:aff:fef4:51", PrefixLength: 64}},
 				})
 			expectedStatuses = append(expectedStatuses,
 				api.InterfaceStatus{
@@ -139,6 +140,7 @@ var _ = Describe("Qemu agent poller", func() {
 					Ip:            "fe80::ff:feb0:1766",
 					IPs:           []string{"fe80::ff:feb0:1766"},
 					InterfaceName: "eth1",
+					IPDetails:     []api.InterfaceIP{{IP: "fe80::ff:feb0:1766", PrefixLength: 64}},
 				})
 			expectedStatuses = append(expectedStatuses,
 				api.InterfaceStatus{
@@ -146,6 +148,7 @@ var _ = Describe("Qemu agent poller", func() {
 					Ip:            "1.2.3.4",
 					IPs:           []string{"1.2.3.4", "fe80::ff:1111:2222"},
 					InterfaceName: "eth5",
+					IPDetails:     []api.InterfaceIP{{IP: "1.2.3.4", PrefixLength: 24}, {IP: "fe80::ff:1111:2222", PrefixLength: 64}},
 				})
 			Expect(interfaceStatuses).To(Equal(expectedStatuses))
 		})
@@ -175,7 +178,7 @@ var _ = Describe("Qemu agent poller", func() {
 				},
 			}
 
-			interfaceStatuses = MergeAgentStatusesWithDomainData(domInterfaces, interfaceStatuses)
+			interfaceStatuses = MergeAgentStatusesWithDomainData(domInterfaces, nil, interfaceStatuses)
 
 			expectedStatuses := []api.InterfaceStatus{}
 			expectedStatuses = append(expectedStatuses,
@@ -185,6 +188,7 @@ var _ = Describe("Qemu agent poller", func() {
 					Ip:            "10.244.0.81",
 					IPs:           []string{"10.244.0.81", "fe80::858:aff:fef4:51"},
 					InterfaceName: "eth0",
+					IPDetails:     []api.InterfaceIP{{IP: "10.244.0.81", PrefixLength: 24}, {IP: "fe80::858:aff:fef4:51", PrefixLength: 64}},
 				})
 			expectedStatuses = append(expectedStatuses,
 				api.InterfaceStatus{
@@ -193,6 +197,7 @@ var _ = Describe("Qemu agent poller", func() {
 					Ip:            "fe80::ff:feb0:1766",
 					IPs:           []string{"fe80::ff:feb0:1766"},
 					InterfaceName: "eth1",
+					IPDetails:     []api.InterfaceIP{{IP: "fe80::ff:feb0:1766", PrefixLength: 64}},
 				})
 			expectedStatuses = append(expectedStatuses,
 				api.InterfaceStatus{
@@ -200,6 +205,7 @@ var _ = Describe("Qemu agent poller", func() {
 					Ip:            "1.2.3.4",
 					IPs:           []string{"1.2.3.4", "fe80::ff:1111:2222"},
 					InterfaceName: "eth5",
+					IPDetails:     []api.InterfaceIP{{IP: "1.2.3.4", PrefixLength: 24}, {IP: "fe80::ff:1111:2222", PrefixLength: 64}},
 				})
 			expectedStatuses = append(expectedStatuses,
 				api.InterfaceStatus{
@@ -210,6 +216,83 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(interfaceStatuses).To(Equal(expectedStatuses))
 		})
 
+		It("should correlate agent interfaces with domain interfaces by the PCI address", func() {
+			interfaceStatuses := []api.InterfaceStatus{
+				{Mac: "02:00:00:00:00:01", InterfaceName: "eth0", PCIAddress: "0000:01:00.0"},
+				{Mac: "02:00:00:00:00:02", InterfaceName: "eth1"},
+				{Mac: "02:00:00:00:00:03", InterfaceName: "eth2", PCIAddress: "0000:07:00.1"},
+			}
+			domInterfaces := []api.Interface{
+				{
+					MAC:     &api.MAC{MAC: "02:00:00:00:00:0a"},
+					Alias:   api.NewUserDefinedAlias("default"),
+					Address: &api.Address{Type: "pci", Domain: "0x0000", Bus: "0x01", Slot: "0x00", Function: "0x0"},
+				},
+				{
+					MAC:     &api.MAC{MAC: "02:00:00:00:00:0b"},
+					Alias:   api.NewUserDefinedAlias("blue"),
+					Address: &api.Address{Type: "pci", Domain: "0x0000", Bus: "0x02", Slot: "0x00", Function: "0x0"},
+				},
+			}
+			hostDevices := []api.HostDevice{
+				{
+					Alias:   api.NewUserDefinedAlias("sriov-red"),
+					Address: &api.Address{Type: "pci", Domain: "0x0000", Bus: "0x07", Slot: "0x00", Function: "0x1"},
+				},
+			}
+
+			interfaceStatuses = MergeAgentStatusesWithDomainData(domInterfaces, hostDevices, interfaceStatuses)
+
+			Expect(interfaceStatuses).To(Equal([]api.InterfaceStatus{
+				{Name: "default", Mac: "02:00:00:00:00:01", InterfaceName: "eth0", PCIAddress: "0000:01:00.0"},
+				{Mac: "02:00:00:00:00:02", InterfaceName: "eth1"},
+				{Name: "red", Mac: "02:00:00:00:00:03", InterfaceName: "eth2", PCIAddress: "0000:07:00.1"},
+				{Name: "blue", Mac: "02:00:00:00:00:0b"},
+			}))
+		})
+
+		It("should parse the PCI address from the uevent file of a guest interface", func() {
+			address, ok := parsePCISlotName("DRIVER=virtio-pci\nPCI_CLASS=20000\nPCI_ID=1AF4:1041\nPCI_SLOT_NAME=0000:01:00.0\nMODALIAS=pci:v00001AF4d00001041\n")
+			Expect(ok).To(BeTrue())
+			Expect(address).To(Equal("0000:01:00.0"))
+
+			_, ok = parsePCISlotName("DRIVER=virtio_net\nMODALIAS=virtio:d00000001v00001AF4\n")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should parse the routes by interface", func() {
+			JSONInput := `{
+                "return": [
+                    {"iface": "eth0", "destination": "0.0.0.0", "mask": "0.0.0.0", "gateway": "10.0.2.1", "metric": 100, "version": 4},
+                    {"iface": "eth0", "destination": "10.0.2.0", "mask": "255.255.255.0", "gateway": "0.0.0.0", "metric": 100, "version": 4},
+                    {"iface": "eth1", "destination": "fd10::", "desprefixlen": "64", "nexthop": "::", "metric": 256, "version": 6},
+                    {"iface": "eth1", "destination": "::", "desprefixlen": "0", "nexthop": "fd10::1", "metric": 1024, "version": 6}
+                ]
+            }`
+
+			routes, err := parseRoutes(JSONInput)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(routes).To(Equal(map[string][]api.InterfaceRoute{
+				"eth0": {
+					{Destination: "0.0.0.0/0", Gateway: "10.0.2.1", Metric: 100},
+					{Destination: "10.0.2.0/24", Metric: 100},
+				},
+				"eth1": {
+					{Destination: "fd10::/64", Metric: 256},
+					{Destination: "::/0", Gateway: "fd10::1", Metric: 1024},
+				},
+			}))
+		})
+
+		It("should parse the DNS configuration of resolv.conf", func() {
+			resolvConf := "# generated\nsearch default.svc.cluster.local svc.cluster.local\nnameserver 10.96.0.10\nnameserver fd10::a\noptions ndots:5\n"
+
+			Expect(parseResolvConf(resolvConf)).To(Equal(api.GuestDNS{
+				Nameservers: []string{"10.96.0.10", "fd10::a"},
+				Search:      []string{"default.svc.cluster.local", "svc.cluster.local"},
+			}))
+		})
+
 		It("should parse Guest OS Info", func() {
 
 			JSONInput := `{
@@ -19,7 +19,11 @@
 package agentpoller
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
@@ -45,8 +49,20 @@ const (
 	GET_USERS      AgentCommand = "guest-get-users"
 	GET_FILESYSTEM AgentCommand = "guest-get-fsinfo"
 	GET_AGENT      AgentCommand = "guest-info"
+	GET_ROUTES     AgentCommand = "guest-network-get-route"
+	// GET_DNS is no agent command, the resolv.conf of the guest is read with the guest-file commands
+	GET_DNS AgentCommand = "guest-dns"
+	// GET_PCI_ADDRESSES is no agent command either, the PCI addresses of the guest interfaces are
+	// read from sysfs with the guest-file commands
+	GET_PCI_ADDRESSES AgentCommand = "guest-pci-addresses"
 
 	pollInitialInterval = 10 * time.Second
+
+	resolvConfPath = "/etc/resolv.conf"
+	// interfaceUeventPathFormat is the uevent file of the device of a guest interface, which holds its PCI address
+	interfaceUeventPathFormat = "/sys/class/net/%s/device/uevent"
+	// guestFileReadLimit bounds the bytes read from a guest file
+	guestFileReadLimit = 64 * 1024
 )
 
 // AgentUpdatedEvent fire up when data is changes in the store
@@ -88,8 +104,11 @@ func (s *AsyncAgentStore) Store(key AgentCommand, value interface{}) {
 		case GET_OSINFO:
 			info := value.(api.GuestOSInfo)
 			domainInfo.OSInfo = &info
-		case GET_INTERFACES:
-			domainInfo.Interfaces = value.([]api.InterfaceStatus)
+		case GET_INTERFACES, GET_ROUTES, GET_PCI_ADDRESSES:
+			domainInfo.Interfaces = s.GetInterfaces()
+		case GET_DNS:
+			dns := value.(api.GuestDNS)
+			domainInfo.DNS = &dns
 		}
 
 		s.AgentUpdated <- AgentUpdatedEvent{
@@ -130,6 +149,39 @@ func (s *AsyncAgentStore) GetSysInfo() api.DomainSysInfo {
 	}
 }
 
+// GetInterfaces returns the interfaces reported by the guest agent together with their routes and
+// PCI addresses, nil if the guest agent did not report interfaces yet
+func (s *AsyncAgentStore) GetInterfaces() []api.InterfaceStatus {
+	data, ok := s.store.Load(GET_INTERFACES)
+	if !ok {
+		return nil
+	}
+	stored := data.([]api.InterfaceStatus)
+	interfaces := make([]api.InterfaceStatus, len(stored))
+	copy(interfaces, stored)
+
+	if data, ok := s.store.Load(GET_ROUTES); ok {
+		routes := data.(map[string][]api.InterfaceRoute)
+		for i := range interfaces {
+			interfaces[i].Routes = routes[interfaces[i].InterfaceName]
+		}
+	}
+	pciAddresses := s.getPCIAddresses()
+	for i := range interfaces {
+		interfaces[i].PCIAddress = pciAddresses[interfaces[i].InterfaceName]
+	}
+	return interfaces
+}
+
+// getPCIAddresses returns the PCI addresses of the guest interfaces by their name
+func (s *AsyncAgentStore) getPCIAddresses() map[string]string {
+	data, ok := s.store.Load(GET_PCI_ADDRESSES)
+	if !ok {
+		return map[string]string{}
+	}
+	return data.(map[string]string)
+}
+
 // GetGA returns guest agent record with its version if present
 func (s *AsyncAgentStore) GetGA() string {
 	data, ok := s.store.Load(GET_AGENT)
@@ -253,6 +305,7 @@ func CreatePoller(
 	qemuAgentFileInterval time.Duration,
 	qemuAgentUserInterval time.Duration,
 	qemuAgentVersionInterval time.Duration,
+	reportGuestDNS bool,
 ) *AgentPoller {
 	p := &AgentPoller{
 		Connection: connecton,
@@ -270,12 +323,16 @@ func CreatePoller(
 	// sys command group
 	p.workers = append(p.workers, PollerWorker{
 		CallTick:      qemuAgentSysInterval,
-		AgentCommands: []AgentCommand{GET_INTERFACES, GET_OSINFO, GET_TIMEZONE, GET_HOSTNAME},
+		AgentCommands: []AgentCommand{GET_INTERFACES, GET_ROUTES, GET_OSINFO, GET_TIMEZONE, GET_HOSTNAME},
 	})
-	// filesystem command group
+	// filesystem command group, the guest DNS is only read from the guest if explicitly requested
+	fileCommands := []AgentCommand{GET_FILESYSTEM, GET_PCI_ADDRESSES}
+	if reportGuestDNS {
+		fileCommands = append(fileCommands, GET_DNS)
+	}
 	p.workers = append(p.workers, PollerWorker{
 		CallTick:      qemuAgentFileInterval,
-		AgentCommands: []AgentCommand{GET_FILESYSTEM},
+		AgentCommands: fileCommands,
 	})
 	// user command group
 	p.workers = append(p.workers, PollerWorker{
@@ -312,6 +369,20 @@ func (p *AgentPoller) Stop() {
 // With libvirt 5.6.0 direct call to agent can be replaced with call to libvirt Domain.GetGuestInfo
 func executeAgentCommands(commands []AgentCommand, con cli.Connection, agentStore *AsyncAgentStore, domainName string) {
 	for _, command := range commands {
+		if command == GET_DNS {
+			content, err := readGuestFile(con, domainName, resolvConfPath)
+			if err != nil {
+				// skip the command on error, the guest may not allow file access
+				continue
+			}
+			agentStore.Store(GET_DNS, parseResolvConf(content))
+			continue
+		}
+		if command == GET_PCI_ADDRESSES {
+			agentStore.Store(GET_PCI_ADDRESSES, readGuestPCIAddresses(con, agentStore, domainName))
+			continue
+		}
+
 		// replace with direct call to libvirt function when 5.6.0 is available
 		cmdResult, err := con.QemuAgentCommand(`{"execute":"`+string(command)+`"}`, domainName)
 		if err != nil {
@@ -328,6 +399,12 @@ func executeAgentCommands(commands []AgentCommand, con cli.Connection, agentStor
 				log.Log.Errorf("Cannot parse guest agent interface %s", err.Error())
 			}
 			agentStore.Store(GET_INTERFACES, interfaces)
+		case GET_ROUTES:
+			routes, err := parseRoutes(cmdResult)
+			if err != nil {
+				log.Log.Errorf("Cannot parse guest agent routes %s", err.Error())
+			}
+			agentStore.Store(GET_ROUTES, routes)
 		case GET_OSINFO:
 			osInfo, err := parseGuestOSInfo(cmdResult)
 			if err != nil {
@@ -367,3 +444,72 @@ func executeAgentCommands(commands []AgentCommand, con cli.Connection, agentStor
 		}
 	}
 }
+
+// readGuestPCIAddresses reads the PCI addresses of the guest interfaces. Addresses which are already
+// known are kept, so the guest files are read once per interface.
+func readGuestPCIAddresses(con cli.Connection, agentStore *AsyncAgentStore, domainName string) map[string]string {
+	known := agentStore.getPCIAddresses()
+	addresses := map[string]string{}
+	for _, ifc := range agentStore.GetInterfaces() {
+		name := ifc.InterfaceName
+		if address, exists := known[name]; exists {
+			addresses[name] = address
+			continue
+		}
+		if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+			continue
+		}
+		content, err := readGuestFile(con, domainName, fmt.Sprintf(interfaceUeventPathFormat, name))
+		if err != nil {
+			// skip the interface on error, it may be no PCI device or the guest may not allow file access
+			continue
+		}
+		if address, ok := parsePCISlotName(content); ok {
+			addresses[name] = address
+		}
+	}
+	return addresses
+}
+
+type guestFileHandle struct {
+	Handle int `json:"return"`
+}
+
+type guestFileRead struct {
+	Return struct {
+		Count  int    `json:"count"`
+		Buffer string `json:"buf-b64"`
+		EOF    bool   `json:"eof"`
+	} `json:"return"`
+}
+
+// readGuestFile reads a file of the guest with the guest-file commands of the guest agent
+func readGuestFile(con cli.Connection, domainName string, path string) (string, error) {
+	openArgs, err := json.Marshal(map[string]string{"path": path, "mode": "r"})
+	if err != nil {
+		return "", err
+	}
+	reply, err := con.QemuAgentCommand(`{"execute":"guest-file-open","arguments":`+string(openArgs)+`}`, domainName)
+	if err != nil {
+		return "", err
+	}
+	handle := guestFileHandle{}
+	if err := json.Unmarshal([]byte(reply), &handle); err != nil {
+		return "", err
+	}
+	defer con.QemuAgentCommand(fmt.Sprintf(`{"execute":"guest-file-close","arguments":{"handle":%d}}`, handle.Handle), domainName)
+
+	reply, err = con.QemuAgentCommand(fmt.Sprintf(`{"execute":"guest-file-read","arguments":{"handle":%d,"count":%d}}`, handle.Handle, guestFileReadLimit), domainName)
+	if err != nil {
+		return "", err
+	}
+	read := guestFileRead{}
+	if err := json.Unmarshal([]byte(reply), &read); err != nil {
+		return "", err
+	}
+	content, err := base64.StdEncoding.DecodeString(read.Return.Buffer)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
@@ -20,12 +20,15 @@
 package agentpoller
 
 import (
+	"encoding/base64"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 )
 
 var _ = Describe("Qemu agent poller", func() {
@@ -85,6 +88,80 @@ var _ = Describe("Qemu agent poller", func() {
 		})
 	})
 
+	Context("with interfaces and routes", func() {
+		It("should fire an event with the routes attached to the interfaces", func() {
+			var agentStore = NewAsyncAgentStore()
+			interfaces := []api.InterfaceStatus{{Mac: "02:00:00:00:00:01", InterfaceName: "eth0"}}
+			routes := map[string][]api.InterfaceRoute{
+				"eth0": {{Destination: "0.0.0.0/0", Gateway: "10.0.2.1", Metric: 100}},
+			}
+
+			agentStore.Store(GET_INTERFACES, interfaces)
+			Expect(agentStore.AgentUpdated).To(Receive(Equal(AgentUpdatedEvent{
+				Type:       GET_INTERFACES,
+				DomainInfo: api.DomainGuestInfo{Interfaces: interfaces},
+			})))
+
+			agentStore.Store(GET_ROUTES, routes)
+			Expect(agentStore.AgentUpdated).To(Receive(Equal(AgentUpdatedEvent{
+				Type: GET_ROUTES,
+				DomainInfo: api.DomainGuestInfo{Interfaces: []api.InterfaceStatus{
+					{Mac: "02:00:00:00:00:01", InterfaceName: "eth0", Routes: routes["eth0"]},
+				}},
+			})))
+		})
+	})
+
+	Context("with PCI addresses", func() {
+		It("should read the PCI address of each guest interface once", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			defer ctrl.Finish()
+			con := cli.NewMockConnection(ctrl)
+
+			agentStore := NewAsyncAgentStore()
+			agentStore.Store(GET_INTERFACES, []api.InterfaceStatus{{Mac: "02:00:00:00:00:01", InterfaceName: "eth0"}})
+			Expect(agentStore.AgentUpdated).To(Receive())
+
+			uevent := base64.StdEncoding.EncodeToString([]byte("DRIVER=virtio-pci\nPCI_SLOT_NAME=0000:01:00.0\n"))
+			gomock.InOrder(
+				con.EXPECT().QemuAgentCommand(`{"execute":"guest-file-open","arguments":{"mode":"r","path":"/sys/class/net/eth0/device/uevent"}}`, "dom").Return(`{"return":1000}`, nil),
+				con.EXPECT().QemuAgentCommand(`{"execute":"guest-file-read","arguments":{"handle":1000,"count":65536}}`, "dom").Return(`{"return":{"count":46,"buf-b64":"`+uevent+`","eof":true}}`, nil),
+				con.EXPECT().QemuAgentCommand(`{"execute":"guest-file-close","arguments":{"handle":1000}}`, "dom").Return(`{"return":{}}`, nil),
+			)
+
+			executeAgentCommands([]AgentCommand{GET_PCI_ADDRESSES}, con, &agentStore, "dom")
+			Expect(agentStore.AgentUpdated).To(Receive(Equal(AgentUpdatedEvent{
+				Type: GET_PCI_ADDRESSES,
+				DomainInfo: api.DomainGuestInfo{Interfaces: []api.InterfaceStatus{
+					{Mac: "02:00:00:00:00:01", InterfaceName: "eth0", PCIAddress: "0000:01:00.0"},
+				}},
+			})))
+
+			// the address is known now, the guest files are not read again
+			executeAgentCommands([]AgentCommand{GET_PCI_ADDRESSES}, con, &agentStore, "dom")
+			Expect(agentStore.AgentUpdated).ToNot(Receive())
+		})
+	})
+
+	Context("with the guest DNS", func() {
+		pollerCommands := func(p *AgentPoller) []AgentCommand {
+			var commands []AgentCommand
+			for _, worker := range p.workers {
+				commands = append(commands, worker.AgentCommands...)
+			}
+			return commands
+		}
+
+		It("should only read the DNS configuration of the guest if requested", func() {
+			agentStore := NewAsyncAgentStore()
+			p := CreatePoller(nil, "", "", &agentStore, time.Second, time.Second, time.Second, time.Second, false)
+			Expect(pollerCommands(p)).ToNot(ContainElement(GET_DNS))
+
+			p = CreatePoller(nil, "", "", &agentStore, time.Second, time.Second, time.Second, time.Second, true)
+			Expect(pollerCommands(p)).To(ContainElement(GET_DNS))
+		})
+	})
+
 	Context("PollerWorker", func() {
 		It("executes the agent commands at least once", func() {
 			const interval = 1
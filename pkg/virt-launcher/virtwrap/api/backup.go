@@ -0,0 +1,69 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package api
+
+import "encoding/xml"
+
+// DomainBackup mirrors the libvirt backup XML
+type DomainBackup struct {
+	XMLName     xml.Name           `xml:"domainbackup"`
+	Mode        string             `xml:"mode,attr"`
+	Incremental string             `xml:"incremental,omitempty"`
+	Server      *BackupServer      `xml:"server,omitempty"`
+	Disks       *DomainBackupDisks `xml:"disks,omitempty"`
+}
+
+// BackupServer is the NBD server of a pull mode backup
+type BackupServer struct {
+	Transport string `xml:"transport,attr"`
+	Socket    string `xml:"socket,attr,omitempty"`
+}
+
+type DomainBackupDisks struct {
+	Disks []DomainBackupDisk `xml:"disk"`
+}
+
+type DomainBackupDisk struct {
+	Name   string `xml:"name,attr"`
+	Backup string `xml:"backup,attr"`
+}
+
+// DomainCheckpoint mirrors the libvirt checkpoint XML
+type DomainCheckpoint struct {
+	XMLName      xml.Name               `xml:"domaincheckpoint"`
+	Name         string                 `xml:"name"`
+	CreationTime int64                  `xml:"creationTime,omitempty"`
+	Parent       *CheckpointParent      `xml:"parent,omitempty"`
+	Disks        *DomainCheckpointDisks `xml:"disks,omitempty"`
+}
+
+type CheckpointParent struct {
+	Name string `xml:"name"`
+}
+
+type DomainCheckpointDisks struct {
+	Disks []DomainCheckpointDisk `xml:"disk"`
+}
+
+type DomainCheckpointDisk struct {
+	Name       string `xml:"name,attr"`
+	Checkpoint string `xml:"checkpoint,attr"`
+	Bitmap     string `xml:"bitmap,attr,omitempty"`
+}
@@ -0,0 +1,73 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package api
+
+import "encoding/xml"
+
+// Capabilities mirrors the parts of the libvirt host capabilities XML which are of interest to KubeVirt
+type Capabilities struct {
+	XMLName xml.Name `xml:"capabilities"`
+	Host    Host     `xml:"host"`
+}
+
+type Host struct {
+	UUID     string   `xml:"uuid"`
+	Topology Topology `xml:"topology"`
+}
+
+// Topology describes the NUMA cells of the host
+type Topology struct {
+	Cells Cells `xml:"cells"`
+}
+
+type Cells struct {
+	Num  uint32 `xml:"num,attr"`
+	Cell []Cell `xml:"cell"`
+}
+
+type Cell struct {
+	ID     uint32      `xml:"id,attr"`
+	Memory CellMemory  `xml:"memory"`
+	Pages  []CellPages `xml:"pages"`
+	CPUs   CellCPUs    `xml:"cpus"`
+}
+
+type CellMemory struct {
+	Amount uint64 `xml:",chardata"`
+	Unit   string `xml:"unit,attr"`
+}
+
+type CellPages struct {
+	Count uint64 `xml:",chardata"`
+	Unit  string `xml:"unit,attr"`
+	Size  uint32 `xml:"size,attr"`
+}
+
+type CellCPUs struct {
+	Num uint32    `xml:"num,attr"`
+	CPU []CellCPU `xml:"cpu"`
+}
+
+type CellCPU struct {
+	ID       uint32 `xml:"id,attr"`
+	SocketID uint32 `xml:"socket_id,attr"`
+	CoreID   uint32 `xml:"core_id,attr"`
+	Siblings string `xml:"siblings,attr"`
+}
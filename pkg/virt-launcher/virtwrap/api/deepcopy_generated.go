@@ -146,9 +146,80 @@ func (in *BackingStoreFormat) DeepCopy() *BackingStoreFormat {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupDiskMetadata) DeepCopyInto(out *BackupDiskMetadata) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDiskMetadata.
+func (in *BackupDiskMetadata) DeepCopy() *BackupDiskMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupDiskMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupMetadata) DeepCopyInto(out *BackupMetadata) {
+	*out = *in
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]BackupDiskMetadata, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupMetadata.
+func (in *BackupMetadata) DeepCopy() *BackupMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupServer) DeepCopyInto(out *BackupServer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupServer.
+func (in *BackupServer) DeepCopy() *BackupServer {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BandWidth) DeepCopyInto(out *BandWidth) {
 	*out = *in
+	if in.Inbound != nil {
+		in, out := &in.Inbound, &out.Inbound
+		*out = new(BandWidthLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outbound != nil {
+		in, out := &in.Outbound, &out.Outbound
+		*out = new(BandWidthLimits)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -162,6 +233,32 @@ func (in *BandWidth) DeepCopy() *BandWidth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BandWidthLimits) DeepCopyInto(out *BandWidthLimits) {
+	*out = *in
+	if in.Peak != nil {
+		in, out := &in.Peak, &out.Peak
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandWidthLimits.
+func (in *BandWidthLimits) DeepCopy() *BandWidthLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(BandWidthLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Boot) DeepCopyInto(out *Boot) {
 	*out = *in
@@ -357,6 +454,139 @@ func (in *CPUTuneVCPUPin) DeepCopy() *CPUTuneVCPUPin {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Capabilities) DeepCopyInto(out *Capabilities) {
+	*out = *in
+	out.XMLName = in.XMLName
+	in.Host.DeepCopyInto(&out.Host)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Capabilities.
+func (in *Capabilities) DeepCopy() *Capabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(Capabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cell) DeepCopyInto(out *Cell) {
+	*out = *in
+	out.Memory = in.Memory
+	if in.Pages != nil {
+		in, out := &in.Pages, &out.Pages
+		*out = make([]CellPages, len(*in))
+		copy(*out, *in)
+	}
+	in.CPUs.DeepCopyInto(&out.CPUs)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cell.
+func (in *Cell) DeepCopy() *Cell {
+	if in == nil {
+		return nil
+	}
+	out := new(Cell)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CellCPU) DeepCopyInto(out *CellCPU) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CellCPU.
+func (in *CellCPU) DeepCopy() *CellCPU {
+	if in == nil {
+		return nil
+	}
+	out := new(CellCPU)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CellCPUs) DeepCopyInto(out *CellCPUs) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = make([]CellCPU, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CellCPUs.
+func (in *CellCPUs) DeepCopy() *CellCPUs {
+	if in == nil {
+		return nil
+	}
+	out := new(CellCPUs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CellMemory) DeepCopyInto(out *CellMemory) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CellMemory.
+func (in *CellMemory) DeepCopy() *CellMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(CellMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CellPages) DeepCopyInto(out *CellPages) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CellPages.
+func (in *CellPages) DeepCopy() *CellPages {
+	if in == nil {
+		return nil
+	}
+	out := new(CellPages)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cells) DeepCopyInto(out *Cells) {
+	*out = *in
+	if in.Cell != nil {
+		in, out := &in.Cell, &out.Cell
+		*out = make([]Cell, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cells.
+func (in *Cells) DeepCopy() *Cells {
+	if in == nil {
+		return nil
+	}
+	out := new(Cells)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Channel) DeepCopyInto(out *Channel) {
 	*out = *in
@@ -415,6 +645,44 @@ func (in *ChannelTarget) DeepCopy() *ChannelTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointMetadata) DeepCopyInto(out *CheckpointMetadata) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointMetadata.
+func (in *CheckpointMetadata) DeepCopy() *CheckpointMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointParent) DeepCopyInto(out *CheckpointParent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointParent.
+func (in *CheckpointParent) DeepCopy() *CheckpointParent {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointParent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Clock) DeepCopyInto(out *Clock) {
 	*out = *in
@@ -698,6 +966,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TPMs != nil {
+		in, out := &in.TPMs, &out.TPMs
+		*out = make([]TPM, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -751,6 +1024,11 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(Address)
 		**out = **in
 	}
+	if in.IOTune != nil {
+		in, out := &in.IOTune, &out.IOTune
+		*out = new(DiskIOTune)
+		**out = **in
+	}
 	return
 }
 
@@ -811,6 +1089,22 @@ func (in *DiskDriver) DeepCopy() *DiskDriver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskIOTune) DeepCopyInto(out *DiskIOTune) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskIOTune.
+func (in *DiskIOTune) DeepCopy() *DiskIOTune {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskIOTune)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskSecret) DeepCopyInto(out *DiskSecret) {
 	*out = *in
@@ -827,6 +1121,22 @@ func (in *DiskSecret) DeepCopy() *DiskSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskSizeMetadata) DeepCopyInto(out *DiskSizeMetadata) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskSizeMetadata.
+func (in *DiskSizeMetadata) DeepCopy() *DiskSizeMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskSizeMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskSource) DeepCopyInto(out *DiskSource) {
 	*out = *in
@@ -908,6 +1218,134 @@ func (in *Domain) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainBackup) DeepCopyInto(out *DomainBackup) {
+	*out = *in
+	out.XMLName = in.XMLName
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(BackupServer)
+		**out = **in
+	}
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = new(DomainBackupDisks)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainBackup.
+func (in *DomainBackup) DeepCopy() *DomainBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainBackupDisk) DeepCopyInto(out *DomainBackupDisk) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainBackupDisk.
+func (in *DomainBackupDisk) DeepCopy() *DomainBackupDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainBackupDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainBackupDisks) DeepCopyInto(out *DomainBackupDisks) {
+	*out = *in
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]DomainBackupDisk, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainBackupDisks.
+func (in *DomainBackupDisks) DeepCopy() *DomainBackupDisks {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainBackupDisks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainCheckpoint) DeepCopyInto(out *DomainCheckpoint) {
+	*out = *in
+	out.XMLName = in.XMLName
+	if in.Parent != nil {
+		in, out := &in.Parent, &out.Parent
+		*out = new(CheckpointParent)
+		**out = **in
+	}
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = new(DomainCheckpointDisks)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainCheckpoint.
+func (in *DomainCheckpoint) DeepCopy() *DomainCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainCheckpointDisk) DeepCopyInto(out *DomainCheckpointDisk) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainCheckpointDisk.
+func (in *DomainCheckpointDisk) DeepCopy() *DomainCheckpointDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainCheckpointDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainCheckpointDisks) DeepCopyInto(out *DomainCheckpointDisks) {
+	*out = *in
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]DomainCheckpointDisk, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainCheckpointDisks.
+func (in *DomainCheckpointDisks) DeepCopy() *DomainCheckpointDisks {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainCheckpointDisks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainGuestInfo) DeepCopyInto(out *DomainGuestInfo) {
 	*out = *in
@@ -923,6 +1361,11 @@ func (in *DomainGuestInfo) DeepCopyInto(out *DomainGuestInfo) {
 		*out = new(GuestOSInfo)
 		**out = **in
 	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(GuestDNS)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1018,6 +1461,11 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 		*out = new(CPUTune)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NUMATune != nil {
+		in, out := &in.NUMATune, &out.NUMATune
+		*out = new(NUMATune)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IOThreads != nil {
 		in, out := &in.IOThreads, &out.IOThreads
 		*out = new(IOThreads)
@@ -1047,6 +1495,11 @@ func (in *DomainStatus) DeepCopyInto(out *DomainStatus) {
 		}
 	}
 	out.OSInfo = in.OSInfo
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(GuestDNS)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1584,6 +2037,32 @@ func (in *GraphicsListen) DeepCopy() *GraphicsListen {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestDNS) DeepCopyInto(out *GuestDNS) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Search != nil {
+		in, out := &in.Search, &out.Search
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestDNS.
+func (in *GuestDNS) DeepCopy() *GuestDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GuestOSInfo) DeepCopyInto(out *GuestOSInfo) {
 	*out = *in
@@ -1600,6 +2079,23 @@ func (in *GuestOSInfo) DeepCopy() *GuestOSInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Host) DeepCopyInto(out *Host) {
+	*out = *in
+	in.Topology.DeepCopyInto(&out.Topology)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Host.
+func (in *Host) DeepCopy() *Host {
+	if in == nil {
+		return nil
+	}
+	out := new(Host)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostDevice) DeepCopyInto(out *HostDevice) {
 	*out = *in
@@ -1765,7 +2261,7 @@ func (in *Interface) DeepCopyInto(out *Interface) {
 	if in.BandWidth != nil {
 		in, out := &in.BandWidth, &out.BandWidth
 		*out = new(BandWidth)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.BootOrder != nil {
 		in, out := &in.BootOrder, &out.BootOrder
@@ -1831,6 +2327,38 @@ func (in *InterfaceDriver) DeepCopy() *InterfaceDriver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceIP) DeepCopyInto(out *InterfaceIP) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceIP.
+func (in *InterfaceIP) DeepCopy() *InterfaceIP {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceRoute) DeepCopyInto(out *InterfaceRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceRoute.
+func (in *InterfaceRoute) DeepCopy() *InterfaceRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceSource) DeepCopyInto(out *InterfaceSource) {
 	*out = *in
@@ -1860,6 +2388,16 @@ func (in *InterfaceStatus) DeepCopyInto(out *InterfaceStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPDetails != nil {
+		in, out := &in.IPDetails, &out.IPDetails
+		*out = make([]InterfaceIP, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]InterfaceRoute, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1907,6 +2445,23 @@ func (in *KubeVirtMetadata) DeepCopyInto(out *KubeVirtMetadata) {
 		*out = new(AccessCredentialMetadata)
 		**out = **in
 	}
+	if in.DiskSizes != nil {
+		in, out := &in.DiskSizes, &out.DiskSizes
+		*out = make([]DiskSizeMetadata, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checkpoints != nil {
+		in, out := &in.Checkpoints, &out.Checkpoints
+		*out = make([]CheckpointMetadata, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2010,6 +2565,22 @@ func (in *MemBalloon) DeepCopy() *MemBalloon {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemNode) DeepCopyInto(out *MemNode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemNode.
+func (in *MemNode) DeepCopy() *MemNode {
+	if in == nil {
+		return nil
+	}
+	out := new(MemNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Memory) DeepCopyInto(out *Memory) {
 	*out = *in
@@ -2183,6 +2754,28 @@ func (in *NUMACell) DeepCopy() *NUMACell {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMATune) DeepCopyInto(out *NUMATune) {
+	*out = *in
+	out.Memory = in.Memory
+	if in.MemNodes != nil {
+		in, out := &in.MemNodes, &out.MemNodes
+		*out = make([]MemNode, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMATune.
+func (in *NUMATune) DeepCopy() *NUMATune {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMATune)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NVRam) DeepCopyInto(out *NVRam) {
 	*out = *in
@@ -2199,6 +2792,22 @@ func (in *NVRam) DeepCopy() *NVRam {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NumaTuneMemory) DeepCopyInto(out *NumaTuneMemory) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NumaTuneMemory.
+func (in *NumaTuneMemory) DeepCopy() *NumaTuneMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(NumaTuneMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OS) DeepCopyInto(out *OS) {
 	*out = *in
@@ -2262,6 +2871,43 @@ func (in *OSType) DeepCopy() *OSType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QEMUCapabilities) DeepCopyInto(out *QEMUCapabilities) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]QEMUCapability, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QEMUCapabilities.
+func (in *QEMUCapabilities) DeepCopy() *QEMUCapabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(QEMUCapabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QEMUCapability) DeepCopyInto(out *QEMUCapability) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QEMUCapability.
+func (in *QEMUCapability) DeepCopy() *QEMUCapability {
+	if in == nil {
+		return nil
+	}
+	out := new(QEMUCapability)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReadOnly) DeepCopyInto(out *ReadOnly) {
 	*out = *in
@@ -2559,6 +3205,39 @@ func (in *SysInfo) DeepCopy() *SysInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TPM) DeepCopyInto(out *TPM) {
+	*out = *in
+	out.Backend = in.Backend
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TPM.
+func (in *TPM) DeepCopy() *TPM {
+	if in == nil {
+		return nil
+	}
+	out := new(TPM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TPMBackend) DeepCopyInto(out *TPMBackend) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TPMBackend.
+func (in *TPMBackend) DeepCopy() *TPMBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(TPMBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Timer) DeepCopyInto(out *Timer) {
 	*out = *in
@@ -2591,6 +3270,23 @@ func (in *Timezone) DeepCopy() *Timezone {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Topology) DeepCopyInto(out *Topology) {
+	*out = *in
+	in.Cells.DeepCopyInto(&out.Cells)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Topology.
+func (in *Topology) DeepCopy() *Topology {
+	if in == nil {
+		return nil
+	}
+	out := new(Topology)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *User) DeepCopyInto(out *User) {
 	*out = *in
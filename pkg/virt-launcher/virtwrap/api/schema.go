@@ -106,6 +106,7 @@ type DomainStatus struct {
 	Reason     StateChangeReason
 	Interfaces []InterfaceStatus
 	OSInfo     GuestOSInfo
+	DNS        *GuestDNS
 }
 
 type DomainSysInfo struct {
@@ -131,6 +132,25 @@ type InterfaceStatus struct {
 	Ip            string
 	IPs           []string
 	InterfaceName string
+	IPDetails     []InterfaceIP
+	Routes        []InterfaceRoute
+	PCIAddress    string
+}
+
+type InterfaceIP struct {
+	IP           string
+	PrefixLength int
+}
+
+type InterfaceRoute struct {
+	Destination string
+	Gateway     string
+	Metric      int
+}
+
+type GuestDNS struct {
+	Nameservers []string
+	Search      []string
 }
 
 type Timezone struct {
@@ -156,6 +176,7 @@ type User struct {
 type DomainGuestInfo struct {
 	Interfaces []InterfaceStatus
 	OSInfo     *GuestOSInfo
+	DNS        *GuestDNS
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -169,25 +190,27 @@ type DomainList struct {
 // tagged, and they must correspond to the libvirt domain as described in
 // https://libvirt.org/formatdomain.html.
 type DomainSpec struct {
-	XMLName       xml.Name       `xml:"domain"`
-	Type          string         `xml:"type,attr"`
-	XmlNS         string         `xml:"xmlns:qemu,attr,omitempty"`
-	Name          string         `xml:"name"`
-	UUID          string         `xml:"uuid,omitempty"`
-	Memory        Memory         `xml:"memory"`
-	MemoryBacking *MemoryBacking `xml:"memoryBacking,omitempty"`
-	OS            OS             `xml:"os"`
-	SysInfo       *SysInfo       `xml:"sysinfo,omitempty"`
-	Devices       Devices        `xml:"devices"`
-	Clock         *Clock         `xml:"clock,omitempty"`
-	Resource      *Resource      `xml:"resource,omitempty"`
-	QEMUCmd       *Commandline   `xml:"qemu:commandline,omitempty"`
-	Metadata      Metadata       `xml:"metadata,omitempty"`
-	Features      *Features      `xml:"features,omitempty"`
-	CPU           CPU            `xml:"cpu"`
-	VCPU          *VCPU          `xml:"vcpu"`
-	CPUTune       *CPUTune       `xml:"cputune"`
-	IOThreads     *IOThreads     `xml:"iothreads,omitempty"`
+	XMLName          xml.Name          `xml:"domain"`
+	Type             string            `xml:"type,attr"`
+	XmlNS            string            `xml:"xmlns:qemu,attr,omitempty"`
+	Name             string            `xml:"name"`
+	UUID             string            `xml:"uuid,omitempty"`
+	Memory           Memory            `xml:"memory"`
+	MemoryBacking    *MemoryBacking    `xml:"memoryBacking,omitempty"`
+	OS               OS                `xml:"os"`
+	SysInfo          *SysInfo          `xml:"sysinfo,omitempty"`
+	Devices          Devices           `xml:"devices"`
+	Clock            *Clock            `xml:"clock,omitempty"`
+	Resource         *Resource         `xml:"resource,omitempty"`
+	QEMUCmd          *Commandline      `xml:"qemu:commandline,omitempty"`
+	QEMUCapabilities *QEMUCapabilities `xml:"qemu:capabilities,omitempty"`
+	Metadata         Metadata          `xml:"metadata,omitempty"`
+	Features         *Features         `xml:"features,omitempty"`
+	CPU              CPU               `xml:"cpu"`
+	VCPU             *VCPU             `xml:"vcpu"`
+	CPUTune          *CPUTune          `xml:"cputune"`
+	NUMATune         *NUMATune         `xml:"numatune,omitempty"`
+	IOThreads        *IOThreads        `xml:"iothreads,omitempty"`
 }
 
 type CPUTune struct {
@@ -196,6 +219,22 @@ type CPUTune struct {
 	EmulatorPin *CPUEmulatorPin      `xml:"emulatorpin"`
 }
 
+type NUMATune struct {
+	Memory   NumaTuneMemory `xml:"memory"`
+	MemNodes []MemNode      `xml:"memnode"`
+}
+
+type NumaTuneMemory struct {
+	Mode    string `xml:"mode,attr"`
+	NodeSet string `xml:"nodeset,attr"`
+}
+
+type MemNode struct {
+	CellID  uint32 `xml:"cellid,attr"`
+	Mode    string `xml:"mode,attr"`
+	NodeSet string `xml:"nodeset,attr"`
+}
+
 type CPUTuneVCPUPin struct {
 	VCPU   uint   `xml:"vcpu,attr"`
 	CPUSet string `xml:"cpuset,attr"`
@@ -212,6 +251,7 @@ type CPUEmulatorPin struct {
 
 type VCPU struct {
 	Placement string `xml:"placement,attr"`
+	Current   uint32 `xml:"current,attr,omitempty"`
 	CPUs      uint32 `xml:",chardata"`
 }
 
@@ -313,6 +353,42 @@ type KubeVirtMetadata struct {
 	GracePeriod      *GracePeriodMetadata      `xml:"graceperiod,omitempty"`
 	Migration        *MigrationMetadata        `xml:"migration,omitempty"`
 	AccessCredential *AccessCredentialMetadata `xml:"accessCredential,omitempty"`
+	DiskSizes        []DiskSizeMetadata        `xml:"diskSize,omitempty"`
+	Backup           *BackupMetadata           `xml:"backup,omitempty"`
+	Checkpoints      []CheckpointMetadata      `xml:"checkpoint,omitempty"`
+}
+
+// DiskSizeMetadata records the size of a PVC backed disk as seen by the guest
+type DiskSizeMetadata struct {
+	Name string `xml:"name,attr"`
+	Size int64  `xml:"size,attr"`
+}
+
+// BackupMetadata records the last backup job of the domain
+type BackupMetadata struct {
+	Name           string               `xml:"name"`
+	Checkpoint     string               `xml:"checkpoint,omitempty"`
+	Incremental    string               `xml:"incremental,omitempty"`
+	TargetPodUID   types.UID            `xml:"targetPodUID,omitempty"`
+	Disks          []BackupDiskMetadata `xml:"disk,omitempty"`
+	StartTimestamp *metav1.Time         `xml:"startTimestamp,omitempty"`
+	EndTimestamp   *metav1.Time         `xml:"endTimestamp,omitempty"`
+	Completed      bool                 `xml:"completed,omitempty"`
+	Failed         bool                 `xml:"failed,omitempty"`
+	Message        string               `xml:"message,omitempty"`
+}
+
+// BackupDiskMetadata is a disk exported by a backup
+type BackupDiskMetadata struct {
+	Name   string `xml:"name,attr"`
+	Target string `xml:"target,attr"`
+}
+
+// CheckpointMetadata records a checkpoint created by a backup, in the order of creation
+type CheckpointMetadata struct {
+	Name              string      `xml:"name"`
+	CreationTimestamp metav1.Time `xml:"creationTimestamp"`
+	Volumes           []string    `xml:"volume,omitempty"`
 }
 
 type AccessCredentialMetadata struct {
@@ -329,6 +405,8 @@ type MigrationMetadata struct {
 	FailureReason  string           `xml:"failureReason,omitempty"`
 	AbortStatus    string           `xml:"abortStatus,omitempty"`
 	Mode           v1.MigrationMode `xml:"mode,omitempty"`
+	DiskTotal      uint64           `xml:"diskTotal,omitempty"`
+	DiskProcessed  uint64           `xml:"diskProcessed,omitempty"`
 }
 
 type GracePeriodMetadata struct {
@@ -342,6 +420,16 @@ type Commandline struct {
 	QEMUArg []Arg `xml:"qemu:arg,omitempty"`
 }
 
+// QEMUCapabilities adds or removes QEMU capabilities which libvirt would otherwise
+// detect on its own, see https://libvirt.org/drvqemu.html#overriding-qemu-capabilities
+type QEMUCapabilities struct {
+	Add []QEMUCapability `xml:"qemu:add,omitempty"`
+}
+
+type QEMUCapability struct {
+	Capability string `xml:"capability,attr"`
+}
+
 type Env struct {
 	Name  string `xml:"name,attr"`
 	Value string `xml:"value,attr"`
@@ -402,6 +490,7 @@ type Devices struct {
 	Watchdog    *Watchdog          `xml:"watchdog,omitempty"`
 	Rng         *Rng               `xml:"rng,omitempty"`
 	Filesystems []FilesystemDevice `xml:"filesystem,omitempty"`
+	TPMs        []TPM              `xml:"tpm,omitempty"`
 }
 
 type FilesystemDevice struct {
@@ -508,6 +597,28 @@ type Disk struct {
 	BootOrder    *BootOrder    `xml:"boot,omitempty"`
 	Address      *Address      `xml:"address,omitempty"`
 	Model        string        `xml:"model,attr,omitempty"`
+	IOTune       *DiskIOTune   `xml:"iotune,omitempty"`
+}
+
+type DiskIOTune struct {
+	TotalBytesSec          uint64 `xml:"total_bytes_sec,omitempty"`
+	ReadBytesSec           uint64 `xml:"read_bytes_sec,omitempty"`
+	WriteBytesSec          uint64 `xml:"write_bytes_sec,omitempty"`
+	TotalIopsSec           uint64 `xml:"total_iops_sec,omitempty"`
+	ReadIopsSec            uint64 `xml:"read_iops_sec,omitempty"`
+	WriteIopsSec           uint64 `xml:"write_iops_sec,omitempty"`
+	TotalBytesSecMax       uint64 `xml:"total_bytes_sec_max,omitempty"`
+	ReadBytesSecMax        uint64 `xml:"read_bytes_sec_max,omitempty"`
+	WriteBytesSecMax       uint64 `xml:"write_bytes_sec_max,omitempty"`
+	TotalIopsSecMax        uint64 `xml:"total_iops_sec_max,omitempty"`
+	ReadIopsSecMax         uint64 `xml:"read_iops_sec_max,omitempty"`
+	WriteIopsSecMax        uint64 `xml:"write_iops_sec_max,omitempty"`
+	TotalBytesSecMaxLength uint64 `xml:"total_bytes_sec_max_length,omitempty"`
+	ReadBytesSecMaxLength  uint64 `xml:"read_bytes_sec_max_length,omitempty"`
+	WriteBytesSecMaxLength uint64 `xml:"write_bytes_sec_max_length,omitempty"`
+	TotalIopsSecMaxLength  uint64 `xml:"total_iops_sec_max_length,omitempty"`
+	ReadIopsSecMaxLength   uint64 `xml:"read_iops_sec_max_length,omitempty"`
+	WriteIopsSecMaxLength  uint64 `xml:"write_iops_sec_max_length,omitempty"`
 }
 
 type DiskAuth struct {
@@ -637,6 +748,14 @@ type LinkState struct {
 }
 
 type BandWidth struct {
+	Inbound  *BandWidthLimits `xml:"inbound,omitempty"`
+	Outbound *BandWidthLimits `xml:"outbound,omitempty"`
+}
+
+type BandWidthLimits struct {
+	Average uint32  `xml:"average,attr"`
+	Peak    *uint32 `xml:"peak,attr,omitempty"`
+	Burst   *uint32 `xml:"burst,attr,omitempty"`
 }
 
 type BootOrder struct {
@@ -660,6 +779,8 @@ type InterfaceSource struct {
 	Device  string   `xml:"dev,attr,omitempty"`
 	Bridge  string   `xml:"bridge,attr,omitempty"`
 	Mode    string   `xml:"mode,attr,omitempty"`
+	Type    string   `xml:"type,attr,omitempty"`
+	Path    string   `xml:"path,attr,omitempty"`
 	Address *Address `xml:"address,omitempty"`
 }
 
@@ -933,6 +1054,20 @@ type RngBackend struct {
 	Source string `xml:",chardata"`
 }
 
+// TPM represents an emulated TPM device
+type TPM struct {
+	Model   string     `xml:"model,attr"`
+	Backend TPMBackend `xml:"backend"`
+}
+
+// TPMBackend describes the software TPM emulating the device
+type TPMBackend struct {
+	Type    string `xml:"type,attr"`
+	Version string `xml:"version,attr"`
+	// PersistentState keeps the TPM state when the domain is undefined
+	PersistentState string `xml:"persistent_state,attr,omitempty"`
+}
+
 type IOThreads struct {
 	IOThreads uint `xml:",chardata"`
 }
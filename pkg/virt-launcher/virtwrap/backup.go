@@ -0,0 +1,588 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"libvirt.org/libvirt-go"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	ephemeraldiskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
+)
+
+// backupNBDSocket is the unix socket of the NBD server exporting the disks of a pull mode backup,
+// virt-handler streams connections to it
+func backupNBDSocket(vmi *v1.VirtualMachineInstance) string {
+	return fmt.Sprintf("/var/run/kubevirt-private/%s/virt-backup-nbd", vmi.ObjectMeta.UID)
+}
+
+var defineCheckpoint = func(dom cli.VirDomain, checkpointXML string, flags libvirt.DomainCheckpointCreateFlags) error {
+	checkpoint, err := dom.CreateCheckpointXML(checkpointXML, flags)
+	if err != nil {
+		return err
+	}
+	return checkpoint.Free()
+}
+
+var checkpointDefined = func(dom cli.VirDomain, name string) (bool, error) {
+	checkpoint, err := dom.CheckpointLookupByName(name, 0)
+	if err != nil {
+		if libvirtError, ok := err.(libvirt.Error); ok && libvirtError.Code == libvirt.ERR_NO_DOMAIN_CHECKPOINT {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, checkpoint.Free()
+}
+
+var deleteCheckpoint = func(dom cli.VirDomain, name string, flags libvirt.DomainCheckpointDeleteFlags) error {
+	checkpoint, err := dom.CheckpointLookupByName(name, 0)
+	if err != nil {
+		return err
+	}
+	defer checkpoint.Free()
+	return checkpoint.Delete(flags)
+}
+
+// bitmapImageSuffix is appended to the path of a raw disk image to get the qcow2 image which
+// uses the raw image as its data file and holds the persistent dirty bitmaps of the disk
+const bitmapImageSuffix = ".bitmaps.qcow2"
+
+// createBitmapImage creates a qcow2 image on top of an existing raw image. With data_file_raw
+// the raw image stays a consistent standalone image, only the metadata goes to the qcow2 image.
+var createBitmapImage = func(image string, dataFile string, size int64) error {
+	// the volumes are mounted at the same path in every virt-launcher pod, so the absolute
+	// path of the data file stays valid on migration targets and after restarts
+	output, err := exec.Command("/usr/bin/qemu-img", "create", "-f", "qcow2",
+		"-o", fmt.Sprintf("data_file=%s,data_file_raw=on", dataFile),
+		image, strconv.FormatInt(size, 10)).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, output)
+	}
+	return ephemeraldiskutils.DefaultOwnershipManager.SetFileOwnership(image)
+}
+
+type backupDisk struct {
+	name         string
+	target       string
+	checkpointed bool
+}
+
+// BackupVMI begins a pull mode backup of the disks of the running domain. The disks are exported
+// through an NBD server until EndBackupVMI is called. Disks in the qcow2 format, including raw
+// disks started on their bitmap image, get a persistent bitmap for the new checkpoint, so that
+// later backups can be incremental to it.
+func (l *LibvirtDomainManager) BackupVMI(vmi *v1.VirtualMachineInstance, options *v1.BackupOptions) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return err
+	}
+	defer dom.Free()
+
+	// metadata is only kept in the inactive domain XML
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return err
+	}
+	metadata := &domainSpec.Metadata.KubeVirt
+	if metadata.Backup != nil && !metadata.Backup.Completed {
+		if metadata.Backup.Name == options.Name {
+			return nil
+		}
+		return fmt.Errorf("backup %s is still in progress", metadata.Backup.Name)
+	}
+	if metadata.Migration != nil && !metadata.Migration.Completed {
+		return fmt.Errorf("a backup can not begin while the VMI is migrating")
+	}
+
+	activeSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		return err
+	}
+
+	var base *api.CheckpointMetadata
+	if options.Incremental != nil {
+		for i := range metadata.Checkpoints {
+			if metadata.Checkpoints[i].Name == *options.Incremental {
+				base = &metadata.Checkpoints[i]
+				break
+			}
+		}
+		if base == nil {
+			return fmt.Errorf("checkpoint %s does not exist, a full backup is required", *options.Incremental)
+		}
+	}
+
+	disks := getBackupDisks(vmi, activeSpec, base)
+	if len(disks) == 0 {
+		return fmt.Errorf("the VMI has no disk which can be backed up")
+	}
+
+	if err := l.redefineCheckpoints(dom, activeSpec, metadata.Checkpoints); err != nil {
+		return err
+	}
+
+	backupXML, checkpointXML, err := buildBackupXML(vmi, activeSpec, disks, options)
+	if err != nil {
+		return err
+	}
+
+	// the checkpoints older than the base are not needed anymore, even if the backup fails
+	metadata.Checkpoints = l.pruneCheckpoints(vmi, dom, metadata.Checkpoints, base)
+
+	logger.Infof("Beginning backup %s", options.Name)
+	if err := dom.BackupBegin(backupXML, checkpointXML, 0); err != nil {
+		logger.Reason(err).Errorf("beginning backup %s failed", options.Name)
+		if d, defineErr := l.setDomainSpecWithHooks(vmi, domainSpec); defineErr == nil {
+			d.Free()
+		}
+		return err
+	}
+
+	now := metav1.Now()
+	backup := &api.BackupMetadata{
+		Name:           options.Name,
+		StartTimestamp: &now,
+	}
+	if options.TargetPodUID != nil {
+		backup.TargetPodUID = *options.TargetPodUID
+	}
+	if base != nil {
+		backup.Incremental = base.Name
+	}
+	checkpoint := api.CheckpointMetadata{
+		Name:              options.Checkpoint,
+		CreationTimestamp: now,
+	}
+	for _, disk := range disks {
+		backup.Disks = append(backup.Disks, api.BackupDiskMetadata{Name: disk.name, Target: disk.target})
+		if disk.checkpointed {
+			checkpoint.Volumes = append(checkpoint.Volumes, disk.name)
+		}
+	}
+
+	if checkpointXML != "" {
+		backup.Checkpoint = checkpoint.Name
+		metadata.Checkpoints = append(metadata.Checkpoints, checkpoint)
+	}
+	metadata.Backup = backup
+
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+	return nil
+}
+
+// EndBackupVMI stops the NBD server of the backup. If the backup job ended on its own before,
+// the backup failed.
+func (l *LibvirtDomainManager) EndBackupVMI(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return err
+	}
+	defer dom.Free()
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return err
+	}
+	backup := domainSpec.Metadata.KubeVirt.Backup
+	if backup == nil || backup.Completed {
+		return nil
+	}
+
+	jobStats, err := dom.GetJobStats(0)
+	if err != nil {
+		return err
+	}
+	if jobStats.Type != libvirt.DOMAIN_JOB_NONE && jobStats.Operation == libvirt.DOMAIN_JOB_OPERATION_BACKUP {
+		if err := dom.AbortJob(); err != nil {
+			log.Log.Object(vmi).Reason(err).Errorf("ending backup %s failed", backup.Name)
+			return err
+		}
+	} else {
+		backup.Failed = true
+		backup.Message = "the backup job ended before the backup was completed"
+	}
+
+	now := metav1.Now()
+	backup.Completed = true
+	backup.EndTimestamp = &now
+	log.Log.Object(vmi).Infof("Ended backup %s", backup.Name)
+
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+	return nil
+}
+
+// getBackupDisks returns the writable disks of the domain which can be backed up. Incremental
+// backups only contain the disks whose changed blocks are tracked since the base checkpoint.
+func getBackupDisks(vmi *v1.VirtualMachineInstance, domainSpec *api.DomainSpec, base *api.CheckpointMetadata) []backupDisk {
+	volumes := map[string]v1.Volume{}
+	for _, volume := range vmi.Spec.Volumes {
+		volumes[volume.Name] = volume
+	}
+	var baseVolumes map[string]bool
+	if base != nil {
+		baseVolumes = map[string]bool{}
+		for _, name := range base.Volumes {
+			baseVolumes[name] = true
+		}
+	}
+
+	var disks []backupDisk
+	for _, disk := range domainSpec.Devices.Disks {
+		if disk.Alias == nil || disk.Device != "disk" || disk.ReadOnly != nil {
+			continue
+		}
+		name := disk.Alias.GetName()
+		volume, ok := volumes[name]
+		if !ok || !canBackupVolume(volume) {
+			continue
+		}
+		if baseVolumes != nil && !baseVolumes[name] {
+			continue
+		}
+		disks = append(disks, backupDisk{
+			name:         name,
+			target:       disk.Target.Device,
+			checkpointed: disk.Driver != nil && disk.Driver.Type == "qcow2",
+		})
+	}
+	return disks
+}
+
+// useBitmapImages switches the raw file disks of persistent volumes to their bitmap image, so
+// that their changed blocks can be tracked. Hotplugged volumes keep their raw image.
+func useBitmapImages(vmi *v1.VirtualMachineInstance, domainSpec *api.DomainSpec) {
+	hotplugged := map[string]bool{}
+	for _, status := range vmi.Status.VolumeStatus {
+		if status.HotplugVolume != nil {
+			hotplugged[status.Name] = true
+		}
+	}
+	persistent := map[string]bool{}
+	for _, volume := range vmi.Spec.Volumes {
+		if (volume.PersistentVolumeClaim != nil || volume.DataVolume != nil || volume.HostDisk != nil) && !hotplugged[volume.Name] {
+			persistent[volume.Name] = true
+		}
+	}
+
+	for i := range domainSpec.Devices.Disks {
+		disk := &domainSpec.Devices.Disks[i]
+		if disk.Alias != nil && persistent[disk.Alias.GetName()] && isRawFileDisk(disk) {
+			disk.Driver.Type = "qcow2"
+			disk.Source.File += bitmapImageSuffix
+		}
+	}
+}
+
+// keepBitmapImages makes the disks of a running domain stay on the image they were started
+// with, since switching between the raw and the bitmap image would detach the disk
+func keepBitmapImages(runningSpec *api.DomainSpec, domainSpec *api.DomainSpec) {
+	running := map[string]bool{}
+	for _, disk := range runningSpec.Devices.Disks {
+		if disk.Source.File != "" {
+			running[disk.Source.File] = true
+		}
+	}
+	for i := range domainSpec.Devices.Disks {
+		disk := &domainSpec.Devices.Disks[i]
+		if isRawFileDisk(disk) && running[disk.Source.File+bitmapImageSuffix] {
+			disk.Driver.Type = "qcow2"
+			disk.Source.File += bitmapImageSuffix
+		}
+	}
+}
+
+func isRawFileDisk(disk *api.Disk) bool {
+	return disk.Type == "file" && disk.Device == "disk" && disk.ReadOnly == nil &&
+		disk.Driver != nil && disk.Driver.Type == "raw" && disk.Source.File != ""
+}
+
+// createBitmapImages creates the missing bitmap images of the domain. They are stored next to
+// the raw images on the volumes, so that the bitmaps outlive the virt-launcher pod.
+func createBitmapImages(domainSpec *api.DomainSpec) error {
+	for _, disk := range domainSpec.Devices.Disks {
+		if disk.Driver == nil || disk.Driver.Type != "qcow2" || !strings.HasSuffix(disk.Source.File, bitmapImageSuffix) {
+			continue
+		}
+		if _, err := os.Stat(disk.Source.File); err == nil {
+			continue
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+		rawImage := strings.TrimSuffix(disk.Source.File, bitmapImageSuffix)
+		info, err := os.Stat(rawImage)
+		if err != nil {
+			return err
+		}
+		if err := createBitmapImage(disk.Source.File, rawImage, info.Size()); err != nil {
+			return fmt.Errorf("creating the bitmap image of %s failed: %v", rawImage, err)
+		}
+	}
+	return nil
+}
+
+func canBackupVolume(volume v1.Volume) bool {
+	return volume.PersistentVolumeClaim != nil ||
+		volume.DataVolume != nil ||
+		volume.Ephemeral != nil ||
+		volume.ContainerDisk != nil ||
+		volume.EmptyDisk != nil ||
+		volume.HostDisk != nil
+}
+
+// buildBackupXML returns the backup XML and, if any disk supports changed block tracking,
+// the XML of the checkpoint created with the backup
+func buildBackupXML(vmi *v1.VirtualMachineInstance, domainSpec *api.DomainSpec, disks []backupDisk, options *v1.BackupOptions) (string, string, error) {
+	backedUp := map[string]backupDisk{}
+	for _, disk := range disks {
+		backedUp[disk.target] = disk
+	}
+
+	backup := api.DomainBackup{
+		Mode: "pull",
+		Server: &api.BackupServer{
+			Transport: "unix",
+			Socket:    backupNBDSocket(vmi),
+		},
+		Disks: &api.DomainBackupDisks{},
+	}
+	if options.Incremental != nil {
+		backup.Incremental = *options.Incremental
+	}
+	checkpoint := api.DomainCheckpoint{
+		Name:  options.Checkpoint,
+		Disks: &api.DomainCheckpointDisks{},
+	}
+	hasCheckpoint := false
+
+	for _, disk := range domainSpec.Devices.Disks {
+		target := disk.Target.Device
+		if target == "" {
+			continue
+		}
+		backupDisk := api.DomainBackupDisk{Name: target, Backup: "no"}
+		checkpointDisk := api.DomainCheckpointDisk{Name: target, Checkpoint: "no"}
+		if d, ok := backedUp[target]; ok {
+			backupDisk.Backup = "yes"
+			if d.checkpointed {
+				checkpointDisk.Checkpoint = "bitmap"
+				hasCheckpoint = true
+			}
+		}
+		backup.Disks.Disks = append(backup.Disks.Disks, backupDisk)
+		checkpoint.Disks.Disks = append(checkpoint.Disks.Disks, checkpointDisk)
+	}
+
+	backupXML, err := xml.Marshal(backup)
+	if err != nil {
+		return "", "", err
+	}
+	if !hasCheckpoint {
+		return string(backupXML), "", nil
+	}
+	checkpointXML, err := xml.Marshal(checkpoint)
+	if err != nil {
+		return "", "", err
+	}
+	return string(backupXML), string(checkpointXML), nil
+}
+
+// redefineCheckpoints makes libvirt aware of the recorded checkpoints it does not know. The
+// checkpoint definitions are removed before a migration, the bitmaps stay in the disk images.
+func (l *LibvirtDomainManager) redefineCheckpoints(dom cli.VirDomain, domainSpec *api.DomainSpec, checkpoints []api.CheckpointMetadata) error {
+	targets := map[string]string{}
+	for _, disk := range domainSpec.Devices.Disks {
+		if disk.Alias != nil {
+			targets[disk.Alias.GetName()] = disk.Target.Device
+		}
+	}
+
+	parent := ""
+	for _, checkpoint := range checkpoints {
+		defined, err := checkpointDefined(dom, checkpoint.Name)
+		if err != nil {
+			return err
+		}
+		if !defined {
+			domainCheckpoint := api.DomainCheckpoint{
+				Name:         checkpoint.Name,
+				CreationTime: checkpoint.CreationTimestamp.Unix(),
+				Disks:        &api.DomainCheckpointDisks{},
+			}
+			if parent != "" {
+				domainCheckpoint.Parent = &api.CheckpointParent{Name: parent}
+			}
+			for _, volume := range checkpoint.Volumes {
+				target, ok := targets[volume]
+				if !ok {
+					return fmt.Errorf("disk %s of checkpoint %s does not exist", volume, checkpoint.Name)
+				}
+				domainCheckpoint.Disks.Disks = append(domainCheckpoint.Disks.Disks, api.DomainCheckpointDisk{
+					Name:       target,
+					Checkpoint: "bitmap",
+					Bitmap:     checkpoint.Name,
+				})
+			}
+			checkpointXML, err := xml.Marshal(domainCheckpoint)
+			if err != nil {
+				return err
+			}
+			if err := defineCheckpoint(dom, string(checkpointXML), libvirt.DOMAIN_CHECKPOINT_CREATE_REDEFINE); err != nil {
+				return fmt.Errorf("redefining checkpoint %s failed: %v", checkpoint.Name, err)
+			}
+		}
+		parent = checkpoint.Name
+	}
+	return nil
+}
+
+// pruneCheckpoints deletes the checkpoints which are older than the base of the backup,
+// a full backup deletes all of them. It returns the checkpoints which are kept.
+func (l *LibvirtDomainManager) pruneCheckpoints(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, checkpoints []api.CheckpointMetadata, base *api.CheckpointMetadata) []api.CheckpointMetadata {
+	keepFrom := len(checkpoints)
+	if base != nil {
+		for i, checkpoint := range checkpoints {
+			if checkpoint.Name == base.Name {
+				keepFrom = i
+				break
+			}
+		}
+	}
+
+	// checkpoints are deleted oldest first, libvirt merges their bitmaps into the children
+	var kept []api.CheckpointMetadata
+	for i, checkpoint := range checkpoints {
+		if i >= keepFrom {
+			kept = append(kept, checkpoint)
+			continue
+		}
+		if err := deleteCheckpoint(dom, checkpoint.Name, 0); err != nil {
+			log.Log.Object(vmi).Reason(err).Warningf("deleting checkpoint %s failed", checkpoint.Name)
+			kept = append(kept, checkpoint)
+		}
+	}
+	return kept
+}
+
+// prepareCheckpointsForMigration refuses to migrate a domain during a backup and removes
+// the checkpoint definitions before a migration
+func prepareCheckpointsForMigration(dom cli.VirDomain) error {
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return err
+	}
+	if backup := domainSpec.Metadata.KubeVirt.Backup; backup != nil && !backup.Completed {
+		return fmt.Errorf("backup %s is in progress", backup.Name)
+	}
+	return undefineCheckpoints(dom, domainSpec.Metadata.KubeVirt.Checkpoints)
+}
+
+// undefineCheckpoints removes the checkpoint definitions from libvirt, since domains with
+// checkpoints can not be migrated. The bitmaps are kept in the disk images, the checkpoints
+// are redefined from the VMI status on the migration target.
+func undefineCheckpoints(dom cli.VirDomain, checkpoints []api.CheckpointMetadata) error {
+	for i := len(checkpoints) - 1; i >= 0; i-- {
+		defined, err := checkpointDefined(dom, checkpoints[i].Name)
+		if err != nil {
+			return err
+		}
+		if !defined {
+			continue
+		}
+		if err := deleteCheckpoint(dom, checkpoints[i].Name, libvirt.DOMAIN_CHECKPOINT_DELETE_METADATA_ONLY); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreCheckpoints redefines the checkpoints of the VMI on the migration target and
+// records them in the domain metadata again
+func (l *LibvirtDomainManager) restoreCheckpoints(vmi *v1.VirtualMachineInstance) error {
+	if len(vmi.Status.Checkpoints) == 0 {
+		return nil
+	}
+
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return err
+	}
+	defer dom.Free()
+
+	activeSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		return err
+	}
+	var checkpoints []api.CheckpointMetadata
+	for _, checkpoint := range vmi.Status.Checkpoints {
+		checkpoints = append(checkpoints, api.CheckpointMetadata{
+			Name:              checkpoint.Name,
+			CreationTimestamp: checkpoint.CreationTimestamp,
+			Volumes:           checkpoint.Volumes,
+		})
+	}
+	if err := l.redefineCheckpoints(dom, activeSpec, checkpoints); err != nil {
+		return err
+	}
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return err
+	}
+	domainSpec.Metadata.KubeVirt.Checkpoints = checkpoints
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+	return nil
+}
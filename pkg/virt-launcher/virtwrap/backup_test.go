@@ -0,0 +1,465 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	libvirt "libvirt.org/libvirt-go"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+var _ = Describe("Backup", func() {
+	var mockConn *cli.MockConnection
+	var mockDomain *cli.MockVirDomain
+	var ctrl *gomock.Controller
+	var manager *LibvirtDomainManager
+	var vmi *v1.VirtualMachineInstance
+
+	var origDefineCheckpoint func(cli.VirDomain, string, libvirt.DomainCheckpointCreateFlags) error
+	var origCheckpointDefined func(cli.VirDomain, string) (bool, error)
+	var origDeleteCheckpoint func(cli.VirDomain, string, libvirt.DomainCheckpointDeleteFlags) error
+	var definedCheckpoints []string
+	var redefinedCheckpoints []string
+	var deletedCheckpoints []string
+
+	activeSpec := func() *api.DomainSpec {
+		return &api.DomainSpec{
+			Devices: api.Devices{
+				Disks: []api.Disk{
+					{
+						Device: "disk",
+						Target: api.DiskTarget{Device: "vda"},
+						Driver: &api.DiskDriver{Type: "qcow2"},
+						Alias:  api.NewUserDefinedAlias("rootdisk"),
+					},
+					{
+						Device: "disk",
+						Target: api.DiskTarget{Device: "vdb"},
+						Driver: &api.DiskDriver{Type: "raw"},
+						Alias:  api.NewUserDefinedAlias("datadisk"),
+					},
+					{
+						Device:   "disk",
+						Target:   api.DiskTarget{Device: "vdc"},
+						Driver:   &api.DiskDriver{Type: "raw"},
+						ReadOnly: &api.ReadOnly{},
+						Alias:    api.NewUserDefinedAlias("cloudinit"),
+					},
+				},
+			},
+		}
+	}
+
+	expectDomainSpecs := func(active *api.DomainSpec, inactive *api.DomainSpec) {
+		activeXML, err := xml.Marshal(active)
+		Expect(err).ToNot(HaveOccurred())
+		inactiveXML, err := xml.Marshal(inactive)
+		Expect(err).ToNot(HaveOccurred())
+		mockConn.EXPECT().LookupDomainByName(gomock.Any()).Return(mockDomain, nil)
+		mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).AnyTimes().Return(string(activeXML), nil)
+		mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).Return(string(inactiveXML), nil)
+	}
+
+	expectDomainDefined := func() *api.DomainSpec {
+		defined := &api.DomainSpec{}
+		mockConn.EXPECT().DomainDefineXML(gomock.Any()).DoAndReturn(func(domainXML string) (cli.VirDomain, error) {
+			Expect(xml.Unmarshal([]byte(domainXML), defined)).To(Succeed())
+			return mockDomain, nil
+		})
+		mockDomain.EXPECT().Free().Times(2)
+		return defined
+	}
+
+	checkpointMetadata := func(names ...string) []api.CheckpointMetadata {
+		var checkpoints []api.CheckpointMetadata
+		for _, name := range names {
+			checkpoints = append(checkpoints, api.CheckpointMetadata{Name: name, Volumes: []string{"rootdisk"}})
+		}
+		return checkpoints
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockConn = cli.NewMockConnection(ctrl)
+		mockDomain = cli.NewMockVirDomain(ctrl)
+		mockDomain.EXPECT().IsPersistent().AnyTimes().Return(true, nil)
+		m, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+		manager = m.(*LibvirtDomainManager)
+
+		vmi = newVMI("testnamespace", "testvmi")
+		vmi.UID = "1234"
+		vmi.Spec.Volumes = []v1.Volume{
+			{Name: "rootdisk", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{}}},
+			{Name: "datadisk", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{}}},
+			{Name: "cloudinit", VolumeSource: v1.VolumeSource{CloudInitNoCloud: &v1.CloudInitNoCloudSource{}}},
+		}
+
+		definedCheckpoints, redefinedCheckpoints, deletedCheckpoints = nil, nil, nil
+		origDefineCheckpoint = defineCheckpoint
+		origCheckpointDefined = checkpointDefined
+		origDeleteCheckpoint = deleteCheckpoint
+		defineCheckpoint = func(_ cli.VirDomain, checkpointXML string, flags libvirt.DomainCheckpointCreateFlags) error {
+			Expect(flags).To(Equal(libvirt.DOMAIN_CHECKPOINT_CREATE_REDEFINE))
+			redefinedCheckpoints = append(redefinedCheckpoints, checkpointXML)
+			return nil
+		}
+		checkpointDefined = func(_ cli.VirDomain, name string) (bool, error) {
+			for _, defined := range definedCheckpoints {
+				if defined == name {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+		deleteCheckpoint = func(_ cli.VirDomain, name string, flags libvirt.DomainCheckpointDeleteFlags) error {
+			deletedCheckpoints = append(deletedCheckpoints, fmt.Sprintf("%s/%d", name, flags))
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		defineCheckpoint = origDefineCheckpoint
+		checkpointDefined = origCheckpointDefined
+		deleteCheckpoint = origDeleteCheckpoint
+		ctrl.Finish()
+	})
+
+	Context("beginning a backup", func() {
+		It("should export all writable disks and checkpoint the qcow2 disks of a full backup", func() {
+			inactive := &api.DomainSpec{}
+			inactive.Metadata.KubeVirt.Checkpoints = checkpointMetadata("cp-1", "cp-2")
+			definedCheckpoints = []string{"cp-1", "cp-2"}
+			expectDomainSpecs(activeSpec(), inactive)
+			mockDomain.EXPECT().BackupBegin(gomock.Any(), gomock.Any(), libvirt.DomainBackupBeginFlags(0)).DoAndReturn(func(backupXML string, checkpointXML string, _ libvirt.DomainBackupBeginFlags) error {
+				Expect(backupXML).To(ContainSubstring(`<domainbackup mode="pull">`))
+				Expect(backupXML).ToNot(ContainSubstring(`<incremental>`))
+				Expect(backupXML).To(ContainSubstring(`<server transport="unix" socket="/var/run/kubevirt-private/1234/virt-backup-nbd"></server>`))
+				Expect(backupXML).To(ContainSubstring(`<disk name="vda" backup="yes"></disk>`))
+				Expect(backupXML).To(ContainSubstring(`<disk name="vdb" backup="yes"></disk>`))
+				Expect(backupXML).To(ContainSubstring(`<disk name="vdc" backup="no"></disk>`))
+				Expect(checkpointXML).To(ContainSubstring(`<name>cp-3</name>`))
+				Expect(checkpointXML).To(ContainSubstring(`<disk name="vda" checkpoint="bitmap"></disk>`))
+				Expect(checkpointXML).To(ContainSubstring(`<disk name="vdb" checkpoint="no"></disk>`))
+				Expect(checkpointXML).To(ContainSubstring(`<disk name="vdc" checkpoint="no"></disk>`))
+				return nil
+			})
+			defined := expectDomainDefined()
+
+			Expect(manager.BackupVMI(vmi, &v1.BackupOptions{Name: "backup", Checkpoint: "cp-3"})).To(Succeed())
+
+			Expect(deletedCheckpoints).To(Equal([]string{"cp-1/0", "cp-2/0"}))
+			Expect(redefinedCheckpoints).To(BeEmpty())
+			backup := defined.Metadata.KubeVirt.Backup
+			Expect(backup).ToNot(BeNil())
+			Expect(backup.Name).To(Equal("backup"))
+			Expect(backup.Checkpoint).To(Equal("cp-3"))
+			Expect(backup.Incremental).To(BeEmpty())
+			Expect(backup.StartTimestamp).ToNot(BeNil())
+			Expect(backup.Disks).To(Equal([]api.BackupDiskMetadata{{Name: "rootdisk", Target: "vda"}, {Name: "datadisk", Target: "vdb"}}))
+			Expect(defined.Metadata.KubeVirt.Checkpoints).To(HaveLen(1))
+			Expect(defined.Metadata.KubeVirt.Checkpoints[0].Name).To(Equal("cp-3"))
+			Expect(defined.Metadata.KubeVirt.Checkpoints[0].Volumes).To(Equal([]string{"rootdisk"}))
+		})
+
+		It("should only export the checkpointed disks of an incremental backup and keep the checkpoints since its base", func() {
+			inactive := &api.DomainSpec{}
+			inactive.Metadata.KubeVirt.Checkpoints = checkpointMetadata("cp-1", "cp-2", "cp-3")
+			definedCheckpoints = []string{"cp-1", "cp-3"}
+			expectDomainSpecs(activeSpec(), inactive)
+			mockDomain.EXPECT().BackupBegin(gomock.Any(), gomock.Any(), libvirt.DomainBackupBeginFlags(0)).DoAndReturn(func(backupXML string, checkpointXML string, _ libvirt.DomainBackupBeginFlags) error {
+				Expect(backupXML).To(ContainSubstring(`<incremental>cp-2</incremental>`))
+				Expect(backupXML).To(ContainSubstring(`<disk name="vda" backup="yes"></disk>`))
+				Expect(backupXML).To(ContainSubstring(`<disk name="vdb" backup="no"></disk>`))
+				Expect(checkpointXML).To(ContainSubstring(`<name>cp-4</name>`))
+				return nil
+			})
+			defined := expectDomainDefined()
+
+			incremental := "cp-2"
+			Expect(manager.BackupVMI(vmi, &v1.BackupOptions{Name: "backup", Checkpoint: "cp-4", Incremental: &incremental})).To(Succeed())
+
+			Expect(redefinedCheckpoints).To(HaveLen(1))
+			Expect(redefinedCheckpoints[0]).To(ContainSubstring(`<name>cp-2</name>`))
+			Expect(redefinedCheckpoints[0]).To(ContainSubstring(`<parent><name>cp-1</name></parent>`))
+			Expect(redefinedCheckpoints[0]).To(ContainSubstring(`<disk name="vda" checkpoint="bitmap" bitmap="cp-2"></disk>`))
+			Expect(deletedCheckpoints).To(Equal([]string{"cp-1/0"}))
+			Expect(defined.Metadata.KubeVirt.Backup.Incremental).To(Equal("cp-2"))
+			Expect(defined.Metadata.KubeVirt.Backup.Disks).To(Equal([]api.BackupDiskMetadata{{Name: "rootdisk", Target: "vda"}}))
+			var names []string
+			for _, checkpoint := range defined.Metadata.KubeVirt.Checkpoints {
+				names = append(names, checkpoint.Name)
+			}
+			Expect(names).To(Equal([]string{"cp-2", "cp-3", "cp-4"}))
+		})
+
+		It("should not create a checkpoint if no disk supports changed block tracking", func() {
+			vmi.Spec.Volumes[0].VolumeSource = v1.VolumeSource{CloudInitNoCloud: &v1.CloudInitNoCloudSource{}}
+			expectDomainSpecs(activeSpec(), &api.DomainSpec{})
+			mockDomain.EXPECT().BackupBegin(gomock.Any(), "", libvirt.DomainBackupBeginFlags(0)).Return(nil)
+			defined := expectDomainDefined()
+
+			Expect(manager.BackupVMI(vmi, &v1.BackupOptions{Name: "backup", Checkpoint: "cp-1"})).To(Succeed())
+
+			Expect(defined.Metadata.KubeVirt.Backup.Checkpoint).To(BeEmpty())
+			Expect(defined.Metadata.KubeVirt.Checkpoints).To(BeEmpty())
+		})
+
+		It("should fail if the base checkpoint of an incremental backup does not exist", func() {
+			expectDomainSpecs(activeSpec(), &api.DomainSpec{})
+			mockDomain.EXPECT().Free()
+
+			incremental := "cp-1"
+			err := manager.BackupVMI(vmi, &v1.BackupOptions{Name: "backup", Checkpoint: "cp-2", Incremental: &incremental})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("checkpoint cp-1 does not exist"))
+		})
+
+		It("should refuse to begin a backup while another one is in progress", func() {
+			inactive := &api.DomainSpec{}
+			inactive.Metadata.KubeVirt.Backup = &api.BackupMetadata{Name: "other"}
+			expectDomainSpecs(activeSpec(), inactive)
+			mockDomain.EXPECT().Free()
+
+			err := manager.BackupVMI(vmi, &v1.BackupOptions{Name: "backup", Checkpoint: "cp-1"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("backup other is still in progress"))
+		})
+
+		It("should do nothing if the backup already began", func() {
+			inactive := &api.DomainSpec{}
+			inactive.Metadata.KubeVirt.Backup = &api.BackupMetadata{Name: "backup"}
+			expectDomainSpecs(activeSpec(), inactive)
+			mockDomain.EXPECT().Free()
+
+			Expect(manager.BackupVMI(vmi, &v1.BackupOptions{Name: "backup", Checkpoint: "cp-1"})).To(Succeed())
+		})
+	})
+
+	Context("ending a backup", func() {
+		It("should abort the backup job and mark the backup as completed", func() {
+			inactive := &api.DomainSpec{}
+			inactive.Metadata.KubeVirt.Backup = &api.BackupMetadata{Name: "backup"}
+			expectDomainSpecs(activeSpec(), inactive)
+			mockDomain.EXPECT().GetJobStats(libvirt.DomainGetJobStatsFlags(0)).Return(&libvirt.DomainJobInfo{
+				Type:      libvirt.DOMAIN_JOB_UNBOUNDED,
+				Operation: libvirt.DOMAIN_JOB_OPERATION_BACKUP,
+			}, nil)
+			mockDomain.EXPECT().AbortJob().Return(nil)
+			defined := expectDomainDefined()
+
+			Expect(manager.EndBackupVMI(vmi)).To(Succeed())
+
+			Expect(defined.Metadata.KubeVirt.Backup.Completed).To(BeTrue())
+			Expect(defined.Metadata.KubeVirt.Backup.Failed).To(BeFalse())
+			Expect(defined.Metadata.KubeVirt.Backup.EndTimestamp).ToNot(BeNil())
+		})
+
+		It("should mark the backup as failed if the backup job is gone", func() {
+			inactive := &api.DomainSpec{}
+			inactive.Metadata.KubeVirt.Backup = &api.BackupMetadata{Name: "backup"}
+			expectDomainSpecs(activeSpec(), inactive)
+			mockDomain.EXPECT().GetJobStats(libvirt.DomainGetJobStatsFlags(0)).Return(&libvirt.DomainJobInfo{Type: libvirt.DOMAIN_JOB_NONE}, nil)
+			defined := expectDomainDefined()
+
+			Expect(manager.EndBackupVMI(vmi)).To(Succeed())
+
+			Expect(defined.Metadata.KubeVirt.Backup.Completed).To(BeTrue())
+			Expect(defined.Metadata.KubeVirt.Backup.Failed).To(BeTrue())
+		})
+
+		It("should do nothing if the backup already ended", func() {
+			now := metav1.Now()
+			inactive := &api.DomainSpec{}
+			inactive.Metadata.KubeVirt.Backup = &api.BackupMetadata{Name: "backup", Completed: true, EndTimestamp: &now}
+			expectDomainSpecs(activeSpec(), inactive)
+			mockDomain.EXPECT().Free()
+
+			Expect(manager.EndBackupVMI(vmi)).To(Succeed())
+		})
+	})
+
+	Context("tracking the changed blocks of raw disks", func() {
+		rawFileDisk := func(name string, file string) api.Disk {
+			return api.Disk{
+				Type:   "file",
+				Device: "disk",
+				Driver: &api.DiskDriver{Type: "raw"},
+				Source: api.DiskSource{File: file},
+				Alias:  api.NewUserDefinedAlias(name),
+			}
+		}
+
+		It("should switch the raw disks of persistent volumes to their bitmap image", func() {
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes,
+				v1.Volume{Name: "dvdisk", VolumeSource: v1.VolumeSource{DataVolume: &v1.DataVolumeSource{}}},
+				v1.Volume{Name: "hotplugdisk", VolumeSource: v1.VolumeSource{DataVolume: &v1.DataVolumeSource{}}},
+			)
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{{Name: "hotplugdisk", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+			cloudInit := rawFileDisk("cloudinit", "/var/run/kubevirt-ephemeral-disks/cloud-init-data/noCloud.iso")
+			cloudInit.ReadOnly = &api.ReadOnly{}
+			domainSpec := &api.DomainSpec{Devices: api.Devices{Disks: []api.Disk{
+				rawFileDisk("rootdisk", "/var/run/kubevirt-ephemeral-disks/disk-data/rootdisk/disk.qcow2"),
+				rawFileDisk("datadisk", "/var/run/kubevirt-private/vmi-disks/datadisk/disk.img"),
+				rawFileDisk("dvdisk", "/var/run/kubevirt-private/vmi-disks/dvdisk/disk.img"),
+				rawFileDisk("hotplugdisk", "/var/run/kubevirt/hotplug-disks/hotplugdisk.img"),
+				cloudInit,
+			}}}
+
+			useBitmapImages(vmi, domainSpec)
+
+			disks := domainSpec.Devices.Disks
+			Expect(disks[0].Source.File).To(Equal("/var/run/kubevirt-ephemeral-disks/disk-data/rootdisk/disk.qcow2"))
+			Expect(disks[1].Source.File).To(Equal("/var/run/kubevirt-private/vmi-disks/datadisk/disk.img.bitmaps.qcow2"))
+			Expect(disks[1].Driver.Type).To(Equal("qcow2"))
+			Expect(disks[2].Source.File).To(Equal("/var/run/kubevirt-private/vmi-disks/dvdisk/disk.img.bitmaps.qcow2"))
+			Expect(disks[2].Driver.Type).To(Equal("qcow2"))
+			Expect(disks[3].Source.File).To(Equal("/var/run/kubevirt/hotplug-disks/hotplugdisk.img"))
+			Expect(disks[3].Driver.Type).To(Equal("raw"))
+			Expect(disks[4].Driver.Type).To(Equal("raw"))
+		})
+
+		It("should keep the disks of a running domain on the image they were started with", func() {
+			running := &api.DomainSpec{Devices: api.Devices{Disks: []api.Disk{
+				{Source: api.DiskSource{File: "/var/run/kubevirt-private/vmi-disks/datadisk/disk.img.bitmaps.qcow2"}},
+				{Source: api.DiskSource{File: "/var/run/kubevirt-private/vmi-disks/dvdisk/disk.img"}},
+			}}}
+			domainSpec := &api.DomainSpec{Devices: api.Devices{Disks: []api.Disk{
+				rawFileDisk("datadisk", "/var/run/kubevirt-private/vmi-disks/datadisk/disk.img"),
+				rawFileDisk("dvdisk", "/var/run/kubevirt-private/vmi-disks/dvdisk/disk.img"),
+			}}}
+
+			keepBitmapImages(running, domainSpec)
+
+			Expect(domainSpec.Devices.Disks[0].Source.File).To(Equal("/var/run/kubevirt-private/vmi-disks/datadisk/disk.img.bitmaps.qcow2"))
+			Expect(domainSpec.Devices.Disks[0].Driver.Type).To(Equal("qcow2"))
+			Expect(domainSpec.Devices.Disks[1].Source.File).To(Equal("/var/run/kubevirt-private/vmi-disks/dvdisk/disk.img"))
+			Expect(domainSpec.Devices.Disks[1].Driver.Type).To(Equal("raw"))
+		})
+
+		Context("creating the bitmap images", func() {
+			var tmpDir string
+			var origCreateBitmapImage func(string, string, int64) error
+			var created []string
+
+			BeforeEach(func() {
+				var err error
+				tmpDir, err = ioutil.TempDir("", "bitmaps")
+				Expect(err).ToNot(HaveOccurred())
+				created = nil
+				origCreateBitmapImage = createBitmapImage
+				createBitmapImage = func(image string, dataFile string, size int64) error {
+					created = append(created, fmt.Sprintf("%s/%s/%d", image, dataFile, size))
+					return nil
+				}
+			})
+
+			AfterEach(func() {
+				createBitmapImage = origCreateBitmapImage
+				os.RemoveAll(tmpDir)
+			})
+
+			It("should only create the missing bitmap images on top of the raw images", func() {
+				for _, name := range []string{"new.img", "existing.img"} {
+					Expect(ioutil.WriteFile(filepath.Join(tmpDir, name), make([]byte, 1024), 0644)).To(Succeed())
+				}
+				Expect(ioutil.WriteFile(filepath.Join(tmpDir, "existing.img"+bitmapImageSuffix), nil, 0644)).To(Succeed())
+				domainSpec := &api.DomainSpec{Devices: api.Devices{Disks: []api.Disk{
+					rawFileDisk("new", filepath.Join(tmpDir, "new.img")),
+					rawFileDisk("existing", filepath.Join(tmpDir, "existing.img")),
+					rawFileDisk("raw", filepath.Join(tmpDir, "raw.img")),
+				}}}
+				domainSpec.Devices.Disks[0].Driver.Type = "qcow2"
+				domainSpec.Devices.Disks[0].Source.File += bitmapImageSuffix
+				domainSpec.Devices.Disks[1].Driver.Type = "qcow2"
+				domainSpec.Devices.Disks[1].Source.File += bitmapImageSuffix
+
+				Expect(createBitmapImages(domainSpec)).To(Succeed())
+
+				newImage := filepath.Join(tmpDir, "new.img")
+				Expect(created).To(Equal([]string{fmt.Sprintf("%s/%s/1024", newImage+bitmapImageSuffix, newImage)}))
+			})
+
+			It("should fail if the raw image does not exist", func() {
+				domainSpec := &api.DomainSpec{Devices: api.Devices{Disks: []api.Disk{
+					rawFileDisk("missing", filepath.Join(tmpDir, "missing.img")+bitmapImageSuffix),
+				}}}
+				domainSpec.Devices.Disks[0].Driver.Type = "qcow2"
+
+				Expect(createBitmapImages(domainSpec)).ToNot(Succeed())
+				Expect(created).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("migrating", func() {
+		It("should remove the checkpoint definitions, newest first", func() {
+			inactive := &api.DomainSpec{}
+			inactive.Metadata.KubeVirt.Checkpoints = checkpointMetadata("cp-1", "cp-2")
+			definedCheckpoints = []string{"cp-1", "cp-2"}
+			inactiveXML, err := xml.Marshal(inactive)
+			Expect(err).ToNot(HaveOccurred())
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).Return(string(inactiveXML), nil)
+
+			Expect(prepareCheckpointsForMigration(mockDomain)).To(Succeed())
+
+			metadataOnly := int(libvirt.DOMAIN_CHECKPOINT_DELETE_METADATA_ONLY)
+			Expect(deletedCheckpoints).To(Equal([]string{fmt.Sprintf("cp-2/%d", metadataOnly), fmt.Sprintf("cp-1/%d", metadataOnly)}))
+		})
+
+		It("should refuse to migrate during a backup", func() {
+			inactive := &api.DomainSpec{}
+			inactive.Metadata.KubeVirt.Backup = &api.BackupMetadata{Name: "backup"}
+			inactiveXML, err := xml.Marshal(inactive)
+			Expect(err).ToNot(HaveOccurred())
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).Return(string(inactiveXML), nil)
+
+			Expect(prepareCheckpointsForMigration(mockDomain)).ToNot(Succeed())
+		})
+
+		It("should redefine the checkpoints of the VMI on the target", func() {
+			vmi.Status.Checkpoints = []v1.VirtualMachineInstanceCheckpoint{
+				{Name: "cp-1", Volumes: []string{"rootdisk"}},
+				{Name: "cp-2", Volumes: []string{"rootdisk"}},
+			}
+			expectDomainSpecs(activeSpec(), &api.DomainSpec{})
+			defined := expectDomainDefined()
+
+			Expect(manager.restoreCheckpoints(vmi)).To(Succeed())
+
+			Expect(redefinedCheckpoints).To(HaveLen(2))
+			Expect(redefinedCheckpoints[0]).ToNot(ContainSubstring(`<parent>`))
+			Expect(redefinedCheckpoints[1]).To(ContainSubstring(`<parent><name>cp-1</name></parent>`))
+			Expect(defined.Metadata.KubeVirt.Checkpoints).To(HaveLen(2))
+		})
+	})
+})
@@ -166,6 +166,17 @@ func (_mr *_MockConnectionRecorder) QemuAgentCommand(arg0, arg1 interface{}) *go
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "QemuAgentCommand", arg0, arg1)
 }
 
+func (_m *MockConnection) GetCapabilities() (string, error) {
+	ret := _m.ctrl.Call(_m, "GetCapabilities")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockConnectionRecorder) GetCapabilities() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCapabilities")
+}
+
 func (_m *MockConnection) GetAllDomainStats(statsTypes libvirt_go.DomainStatsTypes, flags libvirt_go.ConnectGetAllDomainStatsFlags) ([]libvirt_go.DomainStats, error) {
 	ret := _m.ctrl.Call(_m, "GetAllDomainStats", statsTypes, flags)
 	ret0, _ := ret[0].([]libvirt_go.DomainStats)
@@ -354,6 +365,26 @@ func (_mr *_MockVirDomainRecorder) DetachDeviceFlags(arg0, arg1 interface{}) *go
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DetachDeviceFlags", arg0, arg1)
 }
 
+func (_m *MockVirDomain) SetVcpusFlags(vcpu uint, flags libvirt_go.DomainVcpuFlags) error {
+	ret := _m.ctrl.Call(_m, "SetVcpusFlags", vcpu, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) SetVcpusFlags(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetVcpusFlags", arg0, arg1)
+}
+
+func (_m *MockVirDomain) UpdateDeviceFlags(xml string, flags libvirt_go.DomainDeviceModifyFlags) error {
+	ret := _m.ctrl.Call(_m, "UpdateDeviceFlags", xml, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) UpdateDeviceFlags(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateDeviceFlags", arg0, arg1)
+}
+
 func (_m *MockVirDomain) DestroyFlags(flags libvirt_go.DomainDestroyFlags) error {
 	ret := _m.ctrl.Call(_m, "DestroyFlags", flags)
 	ret0, _ := ret[0].(error)
@@ -502,6 +533,37 @@ func (_mr *_MockVirDomainRecorder) GetDiskErrors(arg0 interface{}) *gomock.Call
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDiskErrors", arg0)
 }
 
+func (_m *MockVirDomain) GetBlockInfo(disk string, flags uint) (*libvirt_go.DomainBlockInfo, error) {
+	ret := _m.ctrl.Call(_m, "GetBlockInfo", disk, flags)
+	ret0, _ := ret[0].(*libvirt_go.DomainBlockInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirDomainRecorder) GetBlockInfo(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBlockInfo", arg0, arg1)
+}
+
+func (_m *MockVirDomain) BlockResize(disk string, size uint64, flags libvirt_go.DomainBlockResizeFlags) error {
+	ret := _m.ctrl.Call(_m, "BlockResize", disk, size, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) BlockResize(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BlockResize", arg0, arg1, arg2)
+}
+
+func (_m *MockVirDomain) SetBlockIoTune(disk string, params *libvirt_go.DomainBlockIoTuneParameters, flags libvirt_go.DomainModificationImpact) error {
+	ret := _m.ctrl.Call(_m, "SetBlockIoTune", disk, params, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) SetBlockIoTune(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetBlockIoTune", arg0, arg1, arg2)
+}
+
 func (_m *MockVirDomain) SetTime(secs int64, nsecs uint, flags libvirt_go.DomainSetTimeFlags) error {
 	ret := _m.ctrl.Call(_m, "SetTime", secs, nsecs, flags)
 	ret0, _ := ret[0].(error)
@@ -533,6 +595,38 @@ func (_mr *_MockVirDomainRecorder) AbortJob() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "AbortJob")
 }
 
+func (_m *MockVirDomain) BackupBegin(backupXML string, checkpointXML string, flags libvirt_go.DomainBackupBeginFlags) error {
+	ret := _m.ctrl.Call(_m, "BackupBegin", backupXML, checkpointXML, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) BackupBegin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BackupBegin", arg0, arg1, arg2)
+}
+
+func (_m *MockVirDomain) CreateCheckpointXML(xml string, flags libvirt_go.DomainCheckpointCreateFlags) (*libvirt_go.DomainCheckpoint, error) {
+	ret := _m.ctrl.Call(_m, "CreateCheckpointXML", xml, flags)
+	ret0, _ := ret[0].(*libvirt_go.DomainCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirDomainRecorder) CreateCheckpointXML(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateCheckpointXML", arg0, arg1)
+}
+
+func (_m *MockVirDomain) CheckpointLookupByName(name string, flags uint32) (*libvirt_go.DomainCheckpoint, error) {
+	ret := _m.ctrl.Call(_m, "CheckpointLookupByName", name, flags)
+	ret0, _ := ret[0].(*libvirt_go.DomainCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirDomainRecorder) CheckpointLookupByName(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CheckpointLookupByName", arg0, arg1)
+}
+
 func (_m *MockVirDomain) Free() error {
 	ret := _m.ctrl.Call(_m, "Free")
 	ret0, _ := ret[0].(error)
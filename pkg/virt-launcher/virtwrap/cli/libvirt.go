@@ -56,6 +56,7 @@ type Connection interface {
 	NewStream(flags libvirt.StreamFlags) (Stream, error)
 	SetReconnectChan(reconnect chan bool)
 	QemuAgentCommand(command string, domainName string) (string, error)
+	GetCapabilities() (string, error)
 	GetAllDomainStats(statsTypes libvirt.DomainStatsTypes, flags libvirt.ConnectGetAllDomainStatsFlags) ([]libvirt.DomainStats, error)
 	// helper method, not found in libvirt
 	// We add this helper to
@@ -250,6 +251,13 @@ func (l *LibvirtConnection) QemuAgentCommand(command string, domainName string)
 	return result, err
 }
 
+func (l *LibvirtConnection) GetCapabilities() (string, error) {
+	if err := l.reconnectIfNecessary(); err != nil {
+		return "", err
+	}
+	return l.Connect.GetCapabilities()
+}
+
 func (l *LibvirtConnection) GetAllDomainStats(statsTypes libvirt.DomainStatsTypes, flags libvirt.ConnectGetAllDomainStatsFlags) ([]libvirt.DomainStats, error) {
 	if err := l.reconnectIfNecessary(); err != nil {
 		return nil, err
@@ -458,6 +466,8 @@ type VirDomain interface {
 	AttachDeviceFlags(xml string, flags libvirt.DomainDeviceModifyFlags) error
 	DetachDevice(xml string) error
 	DetachDeviceFlags(xml string, flags libvirt.DomainDeviceModifyFlags) error
+	SetVcpusFlags(vcpu uint, flags libvirt.DomainVcpuFlags) error
+	UpdateDeviceFlags(xml string, flags libvirt.DomainDeviceModifyFlags) error
 	DestroyFlags(flags libvirt.DomainDestroyFlags) error
 	ShutdownFlags(flags libvirt.DomainShutdownFlags) error
 	UndefineFlags(flags libvirt.DomainUndefineFlagsValues) error
@@ -472,9 +482,15 @@ type VirDomain interface {
 	GetJobStats(flags libvirt.DomainGetJobStatsFlags) (*libvirt.DomainJobInfo, error)
 	GetJobInfo() (*libvirt.DomainJobInfo, error)
 	GetDiskErrors(flags uint32) ([]libvirt.DomainDiskError, error)
+	GetBlockInfo(disk string, flags uint) (*libvirt.DomainBlockInfo, error)
+	BlockResize(disk string, size uint64, flags libvirt.DomainBlockResizeFlags) error
+	SetBlockIoTune(disk string, params *libvirt.DomainBlockIoTuneParameters, flags libvirt.DomainModificationImpact) error
 	SetTime(secs int64, nsecs uint, flags libvirt.DomainSetTimeFlags) error
 	IsPersistent() (bool, error)
 	AbortJob() error
+	BackupBegin(backupXML string, checkpointXML string, flags libvirt.DomainBackupBeginFlags) error
+	CreateCheckpointXML(xml string, flags libvirt.DomainCheckpointCreateFlags) (*libvirt.DomainCheckpoint, error)
+	CheckpointLookupByName(name string, flags uint32) (*libvirt.DomainCheckpoint, error)
 	Free() error
 }
 
@@ -118,6 +118,49 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should freeze a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().FreezeVMI(vmi, int32(0))
+			err := client.FreezeVirtualMachine(vmi, 0)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should unfreeze a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().UnfreezeVMI(vmi)
+			err := client.UnfreezeVirtualMachine(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should start a backup of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			incremental := "cp-1"
+			options := &v1.BackupOptions{
+				Name:        "backup",
+				Checkpoint:  "cp-2",
+				Incremental: &incremental,
+			}
+			domainManager.EXPECT().BackupVMI(vmi, options)
+			err := client.BackupVirtualMachine(vmi, options)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should report a failure to start a backup", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			options := &v1.BackupOptions{Name: "backup", Checkpoint: "cp-1"}
+			domainManager.EXPECT().BackupVMI(vmi, options).Return(errors.New("a backup job is already active"))
+			err := client.BackupVirtualMachine(vmi, options)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("a backup job is already active"))
+		})
+
+		It("should end the backup of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().EndBackupVMI(vmi)
+			err := client.EndBackupVirtualMachine(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("should list domains", func() {
 			var list []*api.Domain
 			list = append(list, api.NewMinimalDomain("testvmi1"))
@@ -50,6 +50,7 @@ import (
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/persistentstate"
 )
 
 type HostDeviceType string
@@ -100,6 +101,15 @@ type ConverterContext struct {
 	MemBalloonStatsPeriod uint
 	UseVirtioTransitional bool
 	VolumesDiscardIgnore  []string
+	Topology              *api.Topology
+	// PodMemoryNodes holds the host NUMA nodes the memory of the pod is allocated on
+	PodMemoryNodes []int
+	// PodHugepages holds how many bytes of hugepages the pod may allocate
+	PodHugepages uint64
+	// DomainAttachmentByInterfaceName holds the domain attachment type of interfaces bound by a network binding plugin
+	DomainAttachmentByInterfaceName map[string]string
+	// IncrementalBackup enables the backup and checkpoint APIs of libvirt for the domain
+	IncrementalBackup bool
 }
 
 func contains(volumes []string, name string) bool {
@@ -230,10 +240,54 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 	if diskDevice.BootOrder != nil {
 		disk.BootOrder = &api.BootOrder{Order: *diskDevice.BootOrder}
 	}
+	disk.IOTune = ConvertDiskIOTune(diskDevice.IOTune)
 
 	return nil
 }
 
+// ConvertDiskIOTune translates the I/O limits of a disk into the libvirt iotune element,
+// the burst length applies to all burst limits which are set and defaults to 1 second
+// like in qemu, so that the element matches the one reported by the running domain.
+func ConvertDiskIOTune(ioTune *v1.DiskIOTune) *api.DiskIOTune {
+	if ioTune == nil {
+		return nil
+	}
+	domainIOTune := &api.DiskIOTune{
+		TotalBytesSec: ioTune.TotalBytesSec,
+		ReadBytesSec:  ioTune.ReadBytesSec,
+		WriteBytesSec: ioTune.WriteBytesSec,
+		TotalIopsSec:  ioTune.TotalIOPSSec,
+		ReadIopsSec:   ioTune.ReadIOPSSec,
+		WriteIopsSec:  ioTune.WriteIOPSSec,
+	}
+	if burst := ioTune.Burst; burst != nil {
+		domainIOTune.TotalBytesSecMax = burst.TotalBytesSec
+		domainIOTune.ReadBytesSecMax = burst.ReadBytesSec
+		domainIOTune.WriteBytesSecMax = burst.WriteBytesSec
+		domainIOTune.TotalIopsSecMax = burst.TotalIOPSSec
+		domainIOTune.ReadIopsSecMax = burst.ReadIOPSSec
+		domainIOTune.WriteIopsSecMax = burst.WriteIOPSSec
+		burstLength := func(max uint64) uint64 {
+			if max == 0 {
+				return 0
+			} else if burst.LengthSeconds == 0 {
+				return 1
+			}
+			return burst.LengthSeconds
+		}
+		domainIOTune.TotalBytesSecMaxLength = burstLength(burst.TotalBytesSec)
+		domainIOTune.ReadBytesSecMaxLength = burstLength(burst.ReadBytesSec)
+		domainIOTune.WriteBytesSecMaxLength = burstLength(burst.WriteBytesSec)
+		domainIOTune.TotalIopsSecMaxLength = burstLength(burst.TotalIOPSSec)
+		domainIOTune.ReadIopsSecMaxLength = burstLength(burst.ReadIOPSSec)
+		domainIOTune.WriteIopsSecMaxLength = burstLength(burst.WriteIOPSSec)
+	}
+	if *domainIOTune == (api.DiskIOTune{}) {
+		return nil
+	}
+	return domainIOTune
+}
+
 func checkDirectIOFlag(path string) bool {
 	// check if fs where disk.img file is located or block device
 	// support direct i/o
@@ -255,6 +309,9 @@ func SetDriverCacheMode(disk *api.Disk) error {
 		path = disk.Source.File
 	} else if disk.Source.Dev != "" {
 		path = disk.Source.Dev
+	} else if disk.Device == "cdrom" {
+		// an empty CD-ROM drive has no media to check
+		return nil
 	} else {
 		return fmt.Errorf("Unable to set a driver cache mode, disk is neither a block device nor a file")
 	}
@@ -480,6 +537,14 @@ func Convert_v1_Hotplug_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c
 	return fmt.Errorf("hotplug disk %s references an unsupported source", disk.Alias.GetName())
 }
 
+// Convert_v1_Empty_CDRom_To_api_Disk turns the disk into a CD-ROM drive
+// without media.
+func Convert_v1_Empty_CDRom_To_api_Disk(disk *api.Disk) {
+	disk.Type = "file"
+	disk.Driver.Type = "raw"
+	disk.Source = api.DiskSource{}
+}
+
 func Convert_v1_Config_To_api_Disk(volumeName string, disk *api.Disk, configType config.Type) error {
 	disk.Type = "file"
 	disk.Driver.Type = "raw"
@@ -940,6 +1005,17 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		Placement: "static",
 		CPUs:      cpuCount,
 	}
+	// Define the domain with the maximum topology and keep the vCPUs
+	// of the additional sockets offline until they get hotplugged
+	if maxSockets := getMaxSockets(vmi); maxSockets > cpuTopology.Sockets {
+		domain.Spec.CPU.Topology = &api.CPUTopology{
+			Sockets: maxSockets,
+			Cores:   cpuTopology.Cores,
+			Threads: cpuTopology.Threads,
+		}
+		domain.Spec.VCPU.CPUs = calculateRequestedVCPUs(domain.Spec.CPU.Topology)
+		domain.Spec.VCPU.Current = cpuCount
+	}
 
 	if _, err := os.Stat("/dev/kvm"); os.IsNotExist(err) {
 		if c.UseEmulation {
@@ -989,6 +1065,10 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		}
 
 		if vmi.Spec.Domain.Firmware.Bootloader != nil && vmi.Spec.Domain.Firmware.Bootloader.EFI != nil {
+			nvramPath := filepath.Join("/tmp", domain.Spec.Name)
+			if persistentstate.HasPersistentEFI(&vmi.Spec) {
+				nvramPath = filepath.Join(persistentstate.NVRAMPath, domain.Spec.Name+"_VARS.fd")
+			}
 			if vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot == nil || *vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot {
 				domain.Spec.OS.BootLoader = &api.Loader{
 					Path:     filepath.Join(c.OVMFPath, EFICodeSecureBoot),
@@ -998,7 +1078,7 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 				}
 
 				domain.Spec.OS.NVRam = &api.NVRam{
-					NVRam:    filepath.Join("/tmp", domain.Spec.Name),
+					NVRam:    nvramPath,
 					Template: filepath.Join(c.OVMFPath, EFIVarsSecureBoot),
 				}
 			} else {
@@ -1010,7 +1090,7 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 				}
 
 				domain.Spec.OS.NVRam = &api.NVRam{
-					NVRam:    filepath.Join("/tmp", domain.Spec.Name),
+					NVRam:    nvramPath,
 					Template: filepath.Join(c.OVMFPath, EFIVars),
 				}
 			}
@@ -1100,8 +1180,8 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			isMemfdRequired = true
 		}
 	}
-	// virtiofs require shared access
-	if util.IsVMIVirtiofsEnabled(vmi) {
+	// virtiofs and vhost-user require shared access
+	if util.IsVMIVirtiofsEnabled(vmi) || util.IsVhostUserVmi(vmi) {
 		if domain.Spec.MemoryBacking == nil {
 			domain.Spec.MemoryBacking = &api.MemoryBacking{}
 		}
@@ -1212,18 +1292,26 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		if err != nil {
 			return err
 		}
+		hpStatus, hpOk := c.HotplugVolumes[disk.Name]
+		hpReady := hpOk && (hpStatus.Phase == v1.HotplugVolumeMounted || hpStatus.Phase == v1.VolumeReady)
 		volume := volumes[disk.Name]
-		if volume == nil {
-			return fmt.Errorf("No matching volume with name %s found", disk.Name)
-		}
-
-		if _, ok := c.HotplugVolumes[disk.Name]; !ok {
-			err = Convert_v1_Volume_To_api_Disk(volume, &newDisk, c, volumeIndices[disk.Name])
+		if disk.CDRom != nil && (volume == nil || (hpOk && !hpReady)) {
+			// The drive is kept in the domain without media until a volume
+			// is injected and its attachment is mounted.
+			Convert_v1_Empty_CDRom_To_api_Disk(&newDisk)
 		} else {
-			err = Convert_v1_Hotplug_Volume_To_api_Disk(volume, &newDisk, c)
-		}
-		if err != nil {
-			return err
+			if volume == nil {
+				return fmt.Errorf("No matching volume with name %s found", disk.Name)
+			}
+
+			if !hpOk {
+				err = Convert_v1_Volume_To_api_Disk(volume, &newDisk, c, volumeIndices[disk.Name])
+			} else {
+				err = Convert_v1_Hotplug_Volume_To_api_Disk(volume, &newDisk, c)
+			}
+			if err != nil {
+				return err
+			}
 		}
 
 		if useIOThreads {
@@ -1245,9 +1333,8 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			newDisk.Driver.IOThread = &ioThreadId
 		}
 
-		hpStatus, hpOk := c.HotplugVolumes[disk.Name]
 		// if len(c.PermanentVolumes) == 0, it means the vmi is not ready yet, add all disks
-		if _, ok := c.PermanentVolumes[disk.Name]; ok || len(c.PermanentVolumes) == 0 || (hpOk && (hpStatus.Phase == v1.HotplugVolumeMounted || hpStatus.Phase == v1.VolumeReady)) {
+		if _, ok := c.PermanentVolumes[disk.Name]; ok || len(c.PermanentVolumes) == 0 || hpReady || disk.CDRom != nil {
 			domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, newDisk)
 		}
 	}
@@ -1306,6 +1393,24 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		domain.Spec.Devices.Rng = newRng
 	}
 
+	if vmi.Spec.Domain.Devices.TPM != nil {
+		tpm := api.TPM{
+			Model: "tpm-tis",
+			Backend: api.TPMBackend{
+				Type:    "emulator",
+				Version: "2.0",
+			},
+		}
+		if persistentstate.HasPersistentTPM(&vmi.Spec) {
+			tpm.Backend.PersistentState = "yes"
+			// libvirt keeps the TPM state per domain UUID, it has to be stable across restarts
+			if vmi.Spec.Domain.Firmware != nil && vmi.Spec.Domain.Firmware.UUID != "" {
+				domain.Spec.UUID = string(vmi.Spec.Domain.Firmware.UUID)
+			}
+		}
+		domain.Spec.Devices.TPMs = append(domain.Spec.Devices.TPMs, tpm)
+	}
+
 	isUSBDevicePresent := false
 	if vmi.Spec.Domain.Devices.Inputs != nil {
 		inputDevices := make([]api.Input, 0)
@@ -1404,6 +1509,12 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 				log.Log.Reason(err).Error("failed to format domain cputune.")
 				return err
 			}
+			if vmi.Spec.Domain.CPU.NUMA != nil && vmi.Spec.Domain.CPU.NUMA.GuestMappingPassthrough != nil {
+				if err := numaMapping(vmi, &domain.Spec, c); err != nil {
+					log.Log.Reason(err).Error("failed to create the guest NUMA mapping.")
+					return err
+				}
+			}
 			if vmi.Spec.Domain.CPU.IsolateEmulatorThread {
 				if c.EmulatorThreadCpu == nil {
 					err := fmt.Errorf("no CPUs allocated for the emulation thread")
@@ -1514,6 +1625,13 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 	domain.Spec.Devices.Interfaces = append(domain.Spec.Devices.Interfaces, domainInterfaces...)
 	domain.Spec.Devices.HostDevices = append(domain.Spec.Devices.HostDevices, c.SRIOVDevices...)
 
+	// libvirt before 7.6 only offers the backup and checkpoint APIs if the capability is added explicitly
+	if c.IncrementalBackup {
+		domain.Spec.QEMUCapabilities = &api.QEMUCapabilities{
+			Add: []api.QEMUCapability{{Capability: "incremental-backup"}},
+		}
+	}
+
 	// Add Ignition Command Line if present
 	ignitiondata, _ := vmi.Annotations[v1.IgnitionAnnotation]
 	if ignitiondata != "" && strings.Contains(ignitiondata, "ignition") {
@@ -1620,6 +1738,13 @@ func getCPUTopology(vmi *v1.VirtualMachineInstance) *api.CPUTopology {
 	}
 }
 
+func getMaxSockets(vmi *v1.VirtualMachineInstance) uint32 {
+	if vmi.Spec.Domain.CPU == nil {
+		return 0
+	}
+	return vmi.Spec.Domain.CPU.MaxSockets
+}
+
 func calculateRequestedVCPUs(cpuTopology *api.CPUTopology) uint32 {
 	return cpuTopology.Cores * cpuTopology.Sockets * cpuTopology.Threads
 }
@@ -1706,6 +1831,31 @@ func QuantityToByte(quantity resource.Quantity) (api.Memory, error) {
 	}, nil
 }
 
+// MemoryToByte converts a memory size with one of the units supported by libvirt to bytes.
+func MemoryToByte(memory api.Memory) (uint64, error) {
+	switch memory.Unit {
+	case "b", "bytes":
+		return memory.Value, nil
+	case "KB":
+		return memory.Value * 1000, nil
+	case "", "k", "KiB":
+		return memory.Value * 1024, nil
+	case "MB":
+		return memory.Value * 1000 * 1000, nil
+	case "M", "MiB":
+		return memory.Value * 1024 * 1024, nil
+	case "GB":
+		return memory.Value * 1000 * 1000 * 1000, nil
+	case "G", "GiB":
+		return memory.Value * 1024 * 1024 * 1024, nil
+	case "TB":
+		return memory.Value * 1000 * 1000 * 1000 * 1000, nil
+	case "T", "TiB":
+		return memory.Value * 1024 * 1024 * 1024 * 1024, nil
+	}
+	return 0, fmt.Errorf("unknown memory unit %s", memory.Unit)
+}
+
 func QuantityToMebiByte(quantity resource.Quantity) (uint64, error) {
 	q := int64(float64(0.953674) * float64(quantity.ScaledValue(resource.Mega)))
 	if q < 0 {
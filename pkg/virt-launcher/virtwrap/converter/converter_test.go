@@ -117,6 +117,45 @@ var _ = Describe("Converter", func() {
 			Expect(xml).To(Equal(expectedXML))
 		})
 
+		It("should render the I/O limits of the disk into iotune", func() {
+			v1Disk := &v1.Disk{
+				IOTune: &v1.DiskIOTune{
+					ReadBytesSec:  10485760,
+					WriteBytesSec: 5242880,
+					TotalIOPSSec:  500,
+					Burst: &v1.DiskIOTuneBurst{
+						ReadBytesSec:  20971520,
+						TotalIOPSSec:  1000,
+						LengthSeconds: 30,
+					},
+				},
+			}
+			xml := diskToDiskXML(v1Disk)
+			expectedXML := `<Disk device="" type="">
+  <source></source>
+  <target></target>
+  <driver error_policy="stop" name="qemu" type=""></driver>
+  <alias name="ua-"></alias>
+  <iotune>
+    <read_bytes_sec>10485760</read_bytes_sec>
+    <write_bytes_sec>5242880</write_bytes_sec>
+    <total_iops_sec>500</total_iops_sec>
+    <read_bytes_sec_max>20971520</read_bytes_sec_max>
+    <total_iops_sec_max>1000</total_iops_sec_max>
+    <read_bytes_sec_max_length>30</read_bytes_sec_max_length>
+    <total_iops_sec_max_length>30</total_iops_sec_max_length>
+  </iotune>
+</Disk>`
+			Expect(xml).To(Equal(expectedXML))
+		})
+
+		It("should not render iotune without limits", func() {
+			v1Disk := &v1.Disk{
+				IOTune: &v1.DiskIOTune{},
+			}
+			Expect(diskToDiskXML(v1Disk)).ToNot(ContainSubstring("iotune"))
+		})
+
 		It("Should omit boot order when not provided", func() {
 			kubevirtDisk := &v1.Disk{
 				Name: "mydisk",
@@ -1264,6 +1303,35 @@ var _ = Describe("Converter", func() {
 				Expect(domainSpec.VCPU.CPUs).To(Equal(uint32(3)), "Expect vcpus")
 			})
 
+			It("should define offline vCPUs for the sockets up to MaxSockets", func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Spec.Domain.CPU = &v1.CPU{
+					Cores:      2,
+					Sockets:    2,
+					MaxSockets: 4,
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.CPU.Topology.Cores).To(Equal(uint32(2)), "Expect cores")
+				Expect(domainSpec.CPU.Topology.Sockets).To(Equal(uint32(4)), "Expect sockets")
+				Expect(domainSpec.CPU.Topology.Threads).To(Equal(uint32(1)), "Expect threads")
+				Expect(domainSpec.VCPU.CPUs).To(Equal(uint32(8)), "Expect vcpus")
+				Expect(domainSpec.VCPU.Current).To(Equal(uint32(4)), "Expect current vcpus")
+			})
+
+			It("should not define offline vCPUs if MaxSockets equals Sockets", func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Spec.Domain.CPU = &v1.CPU{
+					Sockets:    2,
+					MaxSockets: 2,
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.CPU.Topology.Sockets).To(Equal(uint32(2)), "Expect sockets")
+				Expect(domainSpec.VCPU.CPUs).To(Equal(uint32(2)), "Expect vcpus")
+				Expect(domainSpec.VCPU.Current).To(BeZero(), "Expect no current vcpus")
+			})
+
 			It("should convert CPU sockets", func() {
 				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 				vmi.Spec.Domain.CPU = &v1.CPU{
@@ -1772,6 +1840,69 @@ var _ = Describe("Converter", func() {
 			Expect(domain.Spec.Devices.Interfaces[1].Type).To(Equal("user"))
 			Expect(domain.Spec.Devices.Interfaces[1].Model.Type).To(Equal("e1000"))
 		})
+		It("Should set bandwidth limits and link state of the interface", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			peak := uint32(2048)
+			burst := uint32(512)
+			iface := v1.DefaultBridgeNetworkInterface()
+			iface.Bandwidth = &v1.InterfaceBandwidth{
+				Inbound:  &v1.BandwidthLimits{Average: 1024, Peak: &peak, Burst: &burst},
+				Outbound: &v1.BandwidthLimits{Average: 128},
+			}
+			iface.LinkState = v1.InterfaceLinkStateDown
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).ToNot(BeNil())
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].BandWidth).To(Equal(&api.BandWidth{
+				Inbound:  &api.BandWidthLimits{Average: 1024, Peak: &peak, Burst: &burst},
+				Outbound: &api.BandWidthLimits{Average: 128},
+			}))
+			Expect(domain.Spec.Devices.Interfaces[0].LinkState).To(Equal(&api.LinkState{State: "down"}))
+		})
+		It("Should not set bandwidth and link state by default", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).ToNot(BeNil())
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].BandWidth).To(BeNil())
+			Expect(domain.Spec.Devices.Interfaces[0].LinkState).To(BeNil())
+		})
+		It("Should create network configuration for vhostuser interface and a multus network", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+			vmi.Spec.Networks = []v1.Network{{
+				Name:          "dpdk",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "ovs-dpdk"}},
+			}}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "dpdk",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+			}}
+			vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = True()
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).ToNot(BeNil())
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].Type).To(Equal("vhostuser"))
+			Expect(domain.Spec.Devices.Interfaces[0].Source).To(Equal(api.InterfaceSource{Type: "unix"}))
+			Expect(domain.Spec.Devices.Interfaces[0].Driver).To(BeNil())
+			Expect(domain.Spec.MemoryBacking.Access).To(Equal(&api.MemoryBackingAccess{Mode: "shared"}))
+		})
+		It("Should reject vhostuser interface on the pod network", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+			}}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, &api.Domain{}, c)).To(MatchError("vhostuser interface default requires Multus meta-cni"))
+		})
 		It("Should set domain interface source correctly for multus", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
@@ -1996,6 +2127,25 @@ var _ = Describe("Converter", func() {
 			domain := &api.Domain{}
 			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).To(HaveOccurred(), "conversion should fail because a macvtap interface requires a multus network attachment")
 		})
+		It("Should create an ethernet interface for a binding plugin with a tap domain attachment", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", Binding: &v1.PluginBinding{Name: "custom"}}}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			c.DomainAttachmentByInterfaceName = map[string]string{"default": string(v1.Tap)}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].Type).To(Equal("ethernet"))
+			Expect(domain.Spec.Devices.Interfaces[0].Alias.GetName()).To(Equal("default"))
+		})
+		It("Should leave the domain interface of a binding plugin without domain attachment to its sidecar", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", Binding: &v1.PluginBinding{Name: "custom"}}}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(BeEmpty())
+		})
 		It("creates SRIOV hostdev", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			domain := &api.Domain{}
@@ -2611,6 +2761,208 @@ var _ = Describe("Converter", func() {
 				Expect(path.Base(domainSpec.OS.NVRam.Template)).To(Equal(EFIVarsSecureBoot))
 				Expect(domainSpec.OS.NVRam.NVRam).To(Equal("/tmp/mynamespace_testvmi"))
 			})
+
+			It("should store the NVRAM on the persistent state volume if EFI is persistent", func() {
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					Bootloader: &v1.Bootloader{
+						EFI: &v1.EFI{
+							Persistent: True(),
+						},
+					},
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(path.Base(domainSpec.OS.NVRam.Template)).To(Equal(EFIVarsSecureBoot))
+				Expect(domainSpec.OS.NVRam.NVRam).To(Equal("/var/lib/libvirt/qemu/nvram/mynamespace_testvmi_VARS.fd"))
+			})
+		})
+	})
+
+	Context("guest NUMA passthrough", func() {
+		var vmi *v1.VirtualMachineInstance
+		var c *ConverterContext
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU: &v1.CPU{
+							Cores:                 3,
+							DedicatedCPUPlacement: true,
+							NUMA:                  &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}},
+						},
+						Memory: &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}},
+						Resources: v1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{
+								k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+							},
+						},
+					},
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			cell := func(id uint32, cpus ...uint32) api.Cell {
+				cell := api.Cell{ID: id}
+				for _, cpu := range cpus {
+					cell.CPUs.CPU = append(cell.CPUs.CPU, api.CellCPU{ID: cpu})
+				}
+				return cell
+			}
+			c = &ConverterContext{
+				CPUSet:       []int{2, 5, 6},
+				UseEmulation: true,
+				SMBios:       &cmdv1.SMBios{},
+				Topology: &api.Topology{
+					Cells: api.Cells{Num: 2, Cell: []api.Cell{cell(0, 0, 1, 2, 3), cell(1, 4, 5, 6, 7)}},
+				},
+				PodMemoryNodes: []int{0, 1},
+				PodHugepages:   64 * 1024 * 1024,
+			}
+		})
+
+		It("should create a guest NUMA cell per host NUMA cell of the pinned vCPUs", func() {
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.CPU.NUMA.Cells).To(Equal([]api.NUMACell{
+				{ID: "0", CPUs: "0", Memory: "22528", Unit: "KiB"},
+				{ID: "1", CPUs: "1,2", Memory: "43008", Unit: "KiB"},
+			}))
+			Expect(domain.Spec.NUMATune).To(Equal(&api.NUMATune{
+				Memory: api.NumaTuneMemory{Mode: "strict", NodeSet: "0,1"},
+				MemNodes: []api.MemNode{
+					{CellID: 0, Mode: "strict", NodeSet: "0"},
+					{CellID: 1, Mode: "strict", NodeSet: "1"},
+				},
+			}))
+		})
+
+		It("should create a single guest NUMA cell if all vCPUs are pinned to the same host NUMA cell", func() {
+			c.CPUSet = []int{4, 5, 6}
+			c.PodMemoryNodes = []int{1}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.CPU.NUMA.Cells).To(Equal([]api.NUMACell{
+				{ID: "0", CPUs: "0,1,2", Memory: "65536", Unit: "KiB"},
+			}))
+			Expect(domain.Spec.NUMATune.Memory.NodeSet).To(Equal("1"))
+		})
+
+		It("should give every guest NUMA cell at least one hugepage", func() {
+			vmi.Spec.Domain.CPU.Cores = 8
+			c.CPUSet = []int{0, 4, 5, 6, 7, 1, 2, 3}
+			vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory] = resource.MustParse("6Mi")
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.CPU.NUMA.Cells).To(Equal([]api.NUMACell{
+				{ID: "0", CPUs: "0,5,6,7", Memory: "4096", Unit: "KiB"},
+				{ID: "1", CPUs: "1,2,3,4", Memory: "2048", Unit: "KiB"},
+			}))
+		})
+
+		It("should fail if the pod has no memory on a host NUMA cell of the vCPUs", func() {
+			c.PodMemoryNodes = []int{1}
+			domain := &api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+		})
+
+		It("should only create guest NUMA cells for the host NUMA cells of the vCPUs if the pod has memory on further cells", func() {
+			c.CPUSet = []int{4, 5, 6}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.CPU.NUMA.Cells).To(Equal([]api.NUMACell{
+				{ID: "0", CPUs: "0,1,2", Memory: "65536", Unit: "KiB"},
+			}))
+			Expect(domain.Spec.NUMATune).To(Equal(&api.NUMATune{
+				Memory:   api.NumaTuneMemory{Mode: "strict", NodeSet: "1"},
+				MemNodes: []api.MemNode{{CellID: 0, Mode: "strict", NodeSet: "1"}},
+			}))
+		})
+
+		It("should fail if the pod may not allocate the hugepages of the guest", func() {
+			c.PodHugepages = 32 * 1024 * 1024
+			domain := &api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+		})
+
+		It("should fail without the host NUMA topology", func() {
+			c.Topology = nil
+			domain := &api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+		})
+
+		It("should fail if a pinned pCPU is not part of the host NUMA topology", func() {
+			c.CPUSet = []int{2, 5, 9}
+			domain := &api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+		})
+	})
+	Context("TPM device", func() {
+		var vmi *v1.VirtualMachineInstance
+		var c *ConverterContext
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "mynamespace",
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Firmware = &v1.Firmware{UUID: "e4686d2c-6e8d-4335-b8fd-81bee22f4814"}
+			c = &ConverterContext{
+				VirtualMachine: vmi,
+				UseEmulation:   true,
+			}
+		})
+
+		It("should not add a TPM device if not requested", func() {
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.Devices.TPMs).To(BeEmpty())
+		})
+
+		It("should add an emulated TPM 2.0 device", func() {
+			vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{}
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.Devices.TPMs).To(Equal([]api.TPM{
+				{Model: "tpm-tis", Backend: api.TPMBackend{Type: "emulator", Version: "2.0"}},
+			}))
+			Expect(domainSpec.UUID).To(BeEmpty())
+		})
+
+		It("should persist the TPM state under the firmware UUID", func() {
+			vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{Persistent: True()}
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.Devices.TPMs).To(Equal([]api.TPM{
+				{Model: "tpm-tis", Backend: api.TPMBackend{Type: "emulator", Version: "2.0", PersistentState: "yes"}},
+			}))
+			Expect(domainSpec.UUID).To(Equal("e4686d2c-6e8d-4335-b8fd-81bee22f4814"))
+		})
+	})
+
+	Context("incremental backup", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "mynamespace",
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+		})
+
+		It("should not add QEMU capabilities if incremental backups are disabled", func() {
+			domainXML := vmiToDomainXML(vmi, &ConverterContext{VirtualMachine: vmi, UseEmulation: true})
+			Expect(domainXML).ToNot(ContainSubstring("qemu:capabilities"))
+		})
+
+		It("should add the incremental-backup QEMU capability to the domain XML", func() {
+			domainXML := vmiToDomainXML(vmi, &ConverterContext{VirtualMachine: vmi, UseEmulation: true, IncrementalBackup: true})
+			Expect(domainXML).To(ContainSubstring(`xmlns:qemu="http://libvirt.org/schemas/domain/qemu/1.0"`))
+			Expect(domainXML).To(ContainSubstring(`<qemu:capabilities>
+    <qemu:add capability="incremental-backup"></qemu:add>
+  </qemu:capabilities>`))
 		})
 	})
 
@@ -2902,6 +3254,52 @@ var _ = Describe("Converter", func() {
 			domain := vmiToDomain(vmi, c)
 			Expect(len(domain.Spec.Devices.Controllers)).To(Equal(2))
 		})
+
+		Context("with a CD-ROM", func() {
+			BeforeEach(func() {
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+					Name: "cdrom1",
+					DiskDevice: v1.DiskDevice{
+						CDRom: &v1.CDRomTarget{Bus: "sata"},
+					},
+				}}
+				c.PermanentVolumes = map[string]v1.VolumeStatus{"other": {Name: "other"}}
+			})
+
+			injectVolume := func(phase v1.VolumePhase) {
+				vmi.Spec.Volumes = []v1.Volume{{
+					Name: "cdrom1",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "virtio-win"},
+					},
+				}}
+				c.HotplugVolumes = map[string]v1.VolumeStatus{
+					"cdrom1": {Name: "cdrom1", Phase: phase, HotplugVolume: &v1.HotplugVolumeStatus{}},
+				}
+			}
+
+			It("should add an empty drive without a volume", func() {
+				domain := vmiToDomain(vmi, c)
+				Expect(domain.Spec.Devices.Disks).To(HaveLen(1))
+				Expect(domain.Spec.Devices.Disks[0].Device).To(Equal("cdrom"))
+				Expect(domain.Spec.Devices.Disks[0].Type).To(Equal("file"))
+				Expect(domain.Spec.Devices.Disks[0].Source).To(Equal(api.DiskSource{}))
+			})
+
+			It("should keep the drive empty until the injected media is mounted", func() {
+				injectVolume(v1.HotplugVolumeAttachedToNode)
+				domain := vmiToDomain(vmi, c)
+				Expect(domain.Spec.Devices.Disks).To(HaveLen(1))
+				Expect(domain.Spec.Devices.Disks[0].Source).To(Equal(api.DiskSource{}))
+			})
+
+			It("should add the injected media once it is mounted", func() {
+				injectVolume(v1.HotplugVolumeMounted)
+				domain := vmiToDomain(vmi, c)
+				Expect(domain.Spec.Devices.Disks).To(HaveLen(1))
+				Expect(domain.Spec.Devices.Disks[0].Source.File).To(Equal("/var/run/kubevirt/hotplug-disks/cdrom1/disk.img"))
+			})
+		})
 	})
 
 })
@@ -52,6 +52,10 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 		if iface.SRIOV != nil {
 			continue
 		}
+		// without a domain attachment the sidecar of the binding plugin adds the domain interface
+		if iface.Binding != nil && c.DomainAttachmentByInterfaceName[iface.Name] != string(v1.Tap) {
+			continue
+		}
 
 		ifaceType := getInterfaceType(&vmi.Spec.Domain.Devices.Interfaces[i])
 		domainIface := api.Interface{
@@ -67,9 +71,12 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 		if mq := vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue; mq != nil {
 			virtioNetMQRequested = *mq
 		}
-		if ifaceType == "virtio" && virtioNetProhibited {
+		// vhost-user is connected to QEMU through a unix socket,
+		// so neither /dev/vhost-net nor multiqueue applies to it
+		usesVhostNet := iface.VhostUser == nil
+		if ifaceType == "virtio" && virtioNetProhibited && usesVhostNet {
 			return nil, fmt.Errorf("In-kernel virtio-net device emulation '/dev/vhost-net' not present")
-		} else if ifaceType == "virtio" && virtioNetMQRequested {
+		} else if ifaceType == "virtio" && virtioNetMQRequested && usesVhostNet {
 			queueCount := uint(CalculateNetworkQueues(vmi))
 			domainIface.Driver = &api.InterfaceDriver{Name: "vhost", Queues: &queueCount}
 		}
@@ -83,7 +90,7 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 			domainIface.Address = addr
 		}
 
-		if iface.Bridge != nil || iface.Masquerade != nil {
+		if iface.Bridge != nil || iface.Masquerade != nil || iface.Binding != nil {
 			// TODO:(ihar) consider abstracting interface type conversion /
 			// detection into drivers
 
@@ -107,6 +114,20 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 			if err != nil {
 				return nil, err
 			}
+		} else if iface.VhostUser != nil {
+			if net.Multus == nil {
+				return nil, fmt.Errorf("vhostuser interface %s requires Multus meta-cni", iface.Name)
+			}
+
+			// the socket path and mode are only known once Multus attached the
+			// network, they are filled in when the pod network is set up
+			domainIface.Type = "vhostuser"
+			domainIface.Source = api.InterfaceSource{Type: "unix"}
+			if iface.BootOrder != nil {
+				domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
+			} else {
+				domainIface.Rom = &api.Rom{Enabled: "no"}
+			}
 		} else if iface.Macvtap != nil {
 			if net.Multus == nil {
 				return nil, fmt.Errorf("macvtap interface %s requires Multus meta-cni", iface.Name)
@@ -119,12 +140,38 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 				domainIface.Rom = &api.Rom{Enabled: "no"}
 			}
 		}
+
+		domainIface.BandWidth = convertBandwidth(iface.Bandwidth)
+		if iface.LinkState == v1.InterfaceLinkStateDown {
+			domainIface.LinkState = &api.LinkState{State: string(v1.InterfaceLinkStateDown)}
+		}
 		domainInterfaces = append(domainInterfaces, domainIface)
 	}
 
 	return domainInterfaces, nil
 }
 
+func convertBandwidth(bandwidth *v1.InterfaceBandwidth) *api.BandWidth {
+	if bandwidth == nil || (bandwidth.Inbound == nil && bandwidth.Outbound == nil) {
+		return nil
+	}
+	return &api.BandWidth{
+		Inbound:  convertBandwidthLimits(bandwidth.Inbound),
+		Outbound: convertBandwidthLimits(bandwidth.Outbound),
+	}
+}
+
+func convertBandwidthLimits(limits *v1.BandwidthLimits) *api.BandWidthLimits {
+	if limits == nil {
+		return nil
+	}
+	return &api.BandWidthLimits{
+		Average: limits.Average,
+		Peak:    limits.Peak,
+		Burst:   limits.Burst,
+	}
+}
+
 func getInterfaceType(iface *v1.Interface) string {
 	if iface.Slirp != nil {
 		// Slirp configuration works only with e1000 or rtl8139
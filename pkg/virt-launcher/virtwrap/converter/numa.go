@@ -0,0 +1,152 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package converter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// numaMapping creates a guest NUMA cell for every host NUMA cell the vCPUs are pinned to.
+// The host cells have to be part of the NUMA nodes the pod memory was allocated on, further pod
+// memory nodes without vCPUs get no guest cell. The guest hugepages are split between the guest
+// cells by their vCPUs and have to fit into the hugepages of the pod. The memory of every guest
+// cell is strictly bound to its host cell.
+func numaMapping(vmi *v1.VirtualMachineInstance, domain *api.DomainSpec, c *ConverterContext) error {
+	topology := c.Topology
+	if topology == nil || len(topology.Cells.Cell) == 0 {
+		return fmt.Errorf("no host NUMA topology available for the guest NUMA mapping")
+	}
+	if domain.CPUTune == nil || len(domain.CPUTune.VCPUPin) == 0 {
+		return fmt.Errorf("the guest NUMA mapping requires pinned vCPUs")
+	}
+	if vmi.Spec.Domain.Memory == nil || vmi.Spec.Domain.Memory.Hugepages == nil {
+		return fmt.Errorf("the guest NUMA mapping requires hugepages")
+	}
+	pageSizeQuantity, err := resource.ParseQuantity(vmi.Spec.Domain.Memory.Hugepages.PageSize)
+	if err != nil {
+		return fmt.Errorf("invalid hugepage size %s: %v", vmi.Spec.Domain.Memory.Hugepages.PageSize, err)
+	}
+	pageSize := uint64(pageSizeQuantity.Value())
+
+	cpuToCell := map[string]uint32{}
+	for _, cell := range topology.Cells.Cell {
+		for _, cpu := range cell.CPUs.CPU {
+			cpuToCell[strconv.Itoa(int(cpu.ID))] = cell.ID
+		}
+	}
+
+	// Group the vCPUs by the host cell of their pCPU, in the order the host cells show up
+	var hostCells []uint32
+	vcpusByHostCell := map[uint32][]string{}
+	for _, pin := range domain.CPUTune.VCPUPin {
+		hostCell, ok := cpuToCell[pin.CPUSet]
+		if !ok {
+			return fmt.Errorf("pCPU %s is not part of the host NUMA topology", pin.CPUSet)
+		}
+		if _, exists := vcpusByHostCell[hostCell]; !exists {
+			hostCells = append(hostCells, hostCell)
+		}
+		vcpusByHostCell[hostCell] = append(vcpusByHostCell[hostCell], strconv.Itoa(int(pin.VCPU)))
+	}
+
+	podMemoryNodes := map[uint32]bool{}
+	for _, node := range c.PodMemoryNodes {
+		podMemoryNodes[uint32(node)] = true
+	}
+	for _, hostCell := range hostCells {
+		if !podMemoryNodes[hostCell] {
+			return fmt.Errorf("the pod has no memory on host NUMA cell %d, its memory is allocated on %v", hostCell, c.PodMemoryNodes)
+		}
+	}
+
+	memory, err := MemoryToByte(domain.Memory)
+	if err != nil {
+		return err
+	}
+	totalPages := memory / pageSize
+	if totalPages < uint64(len(hostCells)) {
+		return fmt.Errorf("%d hugepages are not enough to back %d guest NUMA cells", totalPages, len(hostCells))
+	}
+	if totalPages*pageSize > c.PodHugepages {
+		return fmt.Errorf("the pod may only allocate %d bytes of hugepages of %d bytes, the guest needs %d", c.PodHugepages, pageSize, totalPages*pageSize)
+	}
+
+	vcpus := make([]uint64, len(hostCells))
+	for i, hostCell := range hostCells {
+		vcpus[i] = uint64(len(vcpusByHostCell[hostCell]))
+	}
+	cellPages := splitHugepages(vcpus, totalPages)
+
+	numa := &api.NUMA{}
+	numaTune := &api.NUMATune{
+		Memory: api.NumaTuneMemory{Mode: "strict"},
+	}
+	var nodeSet []string
+	for i, hostCell := range hostCells {
+		numa.Cells = append(numa.Cells, api.NUMACell{
+			ID:     strconv.Itoa(i),
+			CPUs:   strings.Join(vcpusByHostCell[hostCell], ","),
+			Memory: strconv.FormatUint(cellPages[i]*pageSize/1024, 10),
+			Unit:   "KiB",
+		})
+		numaTune.MemNodes = append(numaTune.MemNodes, api.MemNode{
+			CellID:  uint32(i),
+			Mode:    "strict",
+			NodeSet: strconv.Itoa(int(hostCell)),
+		})
+		nodeSet = append(nodeSet, strconv.Itoa(int(hostCell)))
+	}
+	numaTune.Memory.NodeSet = strings.Join(nodeSet, ",")
+
+	domain.CPU.NUMA = numa
+	domain.NUMATune = numaTune
+	return nil
+}
+
+// splitHugepages splits the hugepages of the guest between the guest cells in proportion
+// to their vCPUs. Every cell gets at least one page.
+func splitHugepages(vcpus []uint64, totalPages uint64) []uint64 {
+	var totalVCPUs uint64
+	for _, cellVCPUs := range vcpus {
+		totalVCPUs += cellVCPUs
+	}
+
+	// every guest cell needs at least one page, the rest is split by the vCPUs
+	sparePages := totalPages - uint64(len(vcpus))
+	cellPages := make([]uint64, len(vcpus))
+	assignedPages := uint64(0)
+	for i, cellVCPUs := range vcpus {
+		cellPages[i] = 1 + sparePages*cellVCPUs/totalVCPUs
+		assignedPages += cellPages[i]
+	}
+	// hand out the pages lost by rounding down, one per cell
+	for i := 0; assignedPages < totalPages; i = (i + 1) % len(cellPages) {
+		cellPages[i]++
+		assignedPages++
+	}
+	return cellPages
+}
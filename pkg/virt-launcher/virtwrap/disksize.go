@@ -0,0 +1,184 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"syscall"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"libvirt.org/libvirt-go"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	domainutil "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
+)
+
+// qemu only accepts sizes which are a multiple of the sector size, align to 1MiB to be safe
+const diskSizeAlignment = 1 << 20
+
+var dirBytesAvailable = func(path string) (int64, error) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, err
+	}
+	return int64(stat.Bavail) * stat.Bsize, nil
+}
+
+var blockDeviceSize = func(path string) (size int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer util.CloseIOAndCheckErr(f, &err)
+	return f.Seek(0, io.SeekEnd)
+}
+
+// syncDiskSizes grows the PVC backed disks of the running domain whose volume got expanded
+// and records the sizes of these disks, as seen by the guest, in the domain metadata.
+func (l *LibvirtDomainManager) syncDiskSizes(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, oldSpec *api.DomainSpec) error {
+	claimInfos := map[string]*v1.PersistentVolumeClaimInfo{}
+	for _, status := range vmi.Status.VolumeStatus {
+		if status.PersistentVolumeClaimInfo != nil {
+			claimInfos[status.Name] = status.PersistentVolumeClaimInfo
+		}
+	}
+	if len(claimInfos) == 0 {
+		return nil
+	}
+
+	var sizes []api.DiskSizeMetadata
+	for _, disk := range oldSpec.Devices.Disks {
+		if disk.Alias == nil || disk.Device != "disk" || disk.Driver == nil || disk.Driver.Type != "raw" {
+			continue
+		}
+		name := disk.Alias.GetName()
+		claimInfo, ok := claimInfos[name]
+		if !ok {
+			continue
+		}
+
+		blockInfo, err := dom.GetBlockInfo(disk.Target.Device, 0)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Errorf("getting the size of disk %s failed", name)
+			return err
+		}
+		size := int64(blockInfo.Capacity)
+
+		if disk.ReadOnly == nil {
+			possibleSize, err := possibleGuestSize(disk, claimInfo)
+			if err != nil {
+				log.Log.Object(vmi).Reason(err).Warningf("unable to determine the size disk %s can grow to", name)
+			} else if possibleSize > size {
+				if err := expandDisk(vmi, dom, disk, possibleSize); err != nil {
+					return err
+				}
+				size = possibleSize
+			}
+		}
+		sizes = append(sizes, api.DiskSizeMetadata{Name: name, Size: size})
+	}
+
+	// metadata is only kept in the inactive domain XML
+	domainSpec, err := domainutil.GetDomainSpecWithFlags(dom, libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(domainSpec.Metadata.KubeVirt.DiskSizes, sizes) {
+		return nil
+	}
+	domainSpec.Metadata.KubeVirt.DiskSizes = sizes
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+	return nil
+}
+
+// possibleGuestSize returns the size a disk can grow to. Block devices are exposed with their full size,
+// disk images grow up to the capacity of the PVC as long as the filesystem has enough space left.
+func possibleGuestSize(disk api.Disk, claimInfo *v1.PersistentVolumeClaimInfo) (int64, error) {
+	if disk.Source.Dev != "" {
+		size, err := blockDeviceSize(disk.Source.Dev)
+		if err != nil {
+			return 0, err
+		}
+		return alignDiskSize(size), nil
+	}
+	if disk.Source.File == "" {
+		return 0, fmt.Errorf("disk %s is neither backed by a block device nor by a file", disk.Alias.GetName())
+	}
+
+	capacity, ok := claimInfo.Capacity[k8sv1.ResourceStorage]
+	if !ok {
+		return 0, fmt.Errorf("the capacity of the PVC backing disk %s is unknown", disk.Alias.GetName())
+	}
+	size := capacity.Value()
+
+	fileInfo, err := os.Stat(disk.Source.File)
+	if err != nil {
+		return 0, err
+	}
+	available, err := dirBytesAvailable(filepath.Dir(disk.Source.File))
+	if err != nil {
+		return 0, err
+	}
+	if fileInfo.Size()+available < size {
+		size = fileInfo.Size() + available
+	}
+	return alignDiskSize(size), nil
+}
+
+func alignDiskSize(size int64) int64 {
+	return size - size%diskSizeAlignment
+}
+
+// expandDisk grows the disk image if the disk is backed by a file and lets qemu know about the new size
+func expandDisk(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, disk api.Disk, size int64) error {
+	logger := log.Log.Object(vmi)
+	if disk.Source.File != "" {
+		fileInfo, err := os.Stat(disk.Source.File)
+		if err != nil {
+			return err
+		}
+		if fileInfo.Size() < size {
+			if err := os.Truncate(disk.Source.File, size); err != nil {
+				logger.Reason(err).Errorf("growing disk image %s failed", disk.Source.File)
+				return err
+			}
+		}
+	}
+
+	logger.Infof("Expanding disk %s to %d bytes", disk.Alias.GetName(), size)
+	if err := dom.BlockResize(disk.Target.Device, uint64(size), libvirt.DOMAIN_BLOCK_RESIZE_BYTES); err != nil {
+		logger.Reason(err).Errorf("resizing disk %s failed", disk.Alias.GetName())
+		return err
+	}
+	return nil
+}
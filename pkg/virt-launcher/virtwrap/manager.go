@@ -28,11 +28,13 @@ package virtwrap
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"net"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -65,6 +67,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/ignition"
 	kutil "kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/net/ip"
+	"kubevirt.io/kubevirt/pkg/util/persistentstate"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
 	accesscredentials "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/access-credentials"
 	agentpoller "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent-poller"
@@ -85,6 +88,25 @@ const (
 	MDEV_RESOURCE_PREFIX       = "MDEV_PCI_RESOURCE"
 )
 
+const (
+	agentCommandFSFreeze       = `{"execute":"guest-fsfreeze-freeze"}`
+	agentCommandFSThaw         = `{"execute":"guest-fsfreeze-thaw"}`
+	agentCommandFSFreezeStatus = `{"execute":"guest-fsfreeze-status"}`
+
+	fsFrozen = "frozen"
+
+	// defaultUnfreezeTimeout is used when the caller does not pass a timeout
+	defaultUnfreezeTimeout = 5 * time.Minute
+)
+
+// linkFlapInterval is how long the link of the pod network interfaces stays down
+// after a migration, so that the guest renews its DHCP lease
+var linkFlapInterval = 2 * time.Second
+
+// diskCopyProgressReportInterval is the interval in seconds in which the progress of
+// copying the non-shared disks during a migration is reported
+var diskCopyProgressReportInterval int64 = 5
+
 type contextStore struct {
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -94,6 +116,10 @@ type DomainManager interface {
 	SyncVMI(*v1.VirtualMachineInstance, bool, *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error)
 	PauseVMI(*v1.VirtualMachineInstance) error
 	UnpauseVMI(*v1.VirtualMachineInstance) error
+	FreezeVMI(*v1.VirtualMachineInstance, int32) error
+	UnfreezeVMI(*v1.VirtualMachineInstance) error
+	BackupVMI(*v1.VirtualMachineInstance, *v1.BackupOptions) error
+	EndBackupVMI(*v1.VirtualMachineInstance) error
 	KillVMI(*v1.VirtualMachineInstance) error
 	DeleteVMI(*v1.VirtualMachineInstance) error
 	SignalShutdownVMI(*v1.VirtualMachineInstance) error
@@ -126,6 +152,7 @@ type LibvirtDomainManager struct {
 	agentData                *agentpoller.AsyncAgentStore
 	cloudInitDataStore       *cloudinit.CloudInitData
 	setGuestTimeContextPtr   *contextStore
+	safetyUnfreezeContextPtr *contextStore
 	ovmfPath                 string
 	networkCacheStoreFactory cache.InterfaceCacheFactory
 }
@@ -357,14 +384,24 @@ func classifyVolumesForMigration(vmi *v1.VirtualMachineInstance) *migrationDisks
 	// live migration. It also collects all generated disks suck as cloudinit, secrets, ServiceAccount and ConfigMaps
 	// to make sure that these are being copied during migration.
 	// Persistent volume claims without ReadWriteMany access mode
-	// should be filtered out earlier in the process
+	// should be filtered out earlier in the process, unless they are
+	// migrated to new claims, which requires copying them.
 
 	disks := &migrationDisks{
 		shared:    make(map[string]bool),
 		generated: make(map[string]bool),
 	}
+	migratedVolumes := make(map[string]bool)
+	if vmi.Status.VolumeMigration != nil {
+		for _, migratedVolume := range vmi.Status.VolumeMigration.MigratedVolumes {
+			migratedVolumes[migratedVolume.VolumeName] = true
+		}
+	}
 	for _, volume := range vmi.Spec.Volumes {
 		volSrc := volume.VolumeSource
+		if migratedVolumes[volume.Name] {
+			continue
+		}
 		if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil ||
 			(volSrc.HostDisk != nil && *volSrc.HostDisk.Shared) {
 			disks.shared[volume.Name] = true
@@ -542,6 +579,12 @@ func (l *LibvirtDomainManager) asyncMigrate(vmi *v1.VirtualMachineInstance, opti
 			l.setMigrationResult(vmi, true, fmt.Sprintf("%v", err), "")
 		}
 
+		if err := prepareCheckpointsForMigration(dom); err != nil {
+			log.Log.Object(vmi).Reason(err).Error("Live migration failed.")
+			l.setMigrationResult(vmi, true, fmt.Sprintf("%v", err), "")
+			return
+		}
+
 		xmlstr, err := domXMLWithoutKubevirtMetadata(dom, vmi)
 		if err != nil {
 			log.Log.Object(vmi).Reason(err).Error("Live migration failed. Could not compute target XML.")
@@ -661,12 +704,156 @@ func (l *LibvirtDomainManager) getGuestTimeContext() context.Context {
 	return ctx
 }
 
+func (l *LibvirtDomainManager) getFSFreezeStatus(domName string) (string, error) {
+	cmdResult, err := l.virConn.QemuAgentCommand(agentCommandFSFreezeStatus, domName)
+	if err != nil {
+		return "", err
+	}
+
+	status := struct {
+		Return string `json:"return"`
+	}{}
+	if err := json.Unmarshal([]byte(cmdResult), &status); err != nil {
+		return "", err
+	}
+
+	return status.Return, nil
+}
+
+// FreezeVMI freezes the guest filesystems through the guest agent. The
+// filesystems are thawed again after unfreezeTimeoutSeconds, in case the
+// caller never sends an unfreeze request.
+func (l *LibvirtDomainManager) FreezeVMI(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+	domName := util.VMINamespaceKeyFunc(vmi)
+
+	status, err := l.getFSFreezeStatus(domName)
+	if err != nil {
+		logger.Reason(err).Error("Failed to get the guest filesystem freeze status.")
+		return err
+	}
+
+	if status != fsFrozen {
+		if _, err := l.virConn.QemuAgentCommand(agentCommandFSFreeze, domName); err != nil {
+			logger.Reason(err).Error("Failed to freeze the guest filesystems.")
+			return err
+		}
+		logger.Info("Froze the guest filesystems")
+	}
+
+	unfreezeTimeout := defaultUnfreezeTimeout
+	if unfreezeTimeoutSeconds > 0 {
+		unfreezeTimeout = time.Duration(unfreezeTimeoutSeconds) * time.Second
+	}
+	l.scheduleSafetyUnfreeze(vmi, unfreezeTimeout)
+
+	return nil
+}
+
+// UnfreezeVMI thaws the guest filesystems if they are frozen.
+func (l *LibvirtDomainManager) UnfreezeVMI(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	l.cancelSafetyUnfreeze()
+	return l.thawFilesystems(vmi)
+}
+
+// thawFilesystems must be called with the domainModifyLock held
+func (l *LibvirtDomainManager) thawFilesystems(vmi *v1.VirtualMachineInstance) error {
+	logger := log.Log.Object(vmi)
+	domName := util.VMINamespaceKeyFunc(vmi)
+
+	status, err := l.getFSFreezeStatus(domName)
+	if err != nil {
+		logger.Reason(err).Error("Failed to get the guest filesystem freeze status.")
+		return err
+	}
+
+	if status != fsFrozen {
+		return nil
+	}
+
+	if _, err := l.virConn.QemuAgentCommand(agentCommandFSThaw, domName); err != nil {
+		logger.Reason(err).Error("Failed to thaw the guest filesystems.")
+		return err
+	}
+	logger.Info("Thawed the guest filesystems")
+
+	return nil
+}
+
+// scheduleSafetyUnfreeze must be called with the domainModifyLock held
+func (l *LibvirtDomainManager) scheduleSafetyUnfreeze(vmi *v1.VirtualMachineInstance, unfreezeTimeout time.Duration) {
+	l.cancelSafetyUnfreeze()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l.safetyUnfreezeContextPtr = &contextStore{ctx: ctx, cancel: cancel}
+
+	go func() {
+		timer := time.NewTimer(unfreezeTimeout)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+			l.domainModifyLock.Lock()
+			defer l.domainModifyLock.Unlock()
+
+			// superseded by an unfreeze or a new freeze while waiting for the lock
+			if ctx.Err() != nil {
+				return
+			}
+			l.cancelSafetyUnfreeze()
+
+			log.Log.Object(vmi).Warningf("Guest filesystems not unfrozen within %v, thawing them", unfreezeTimeout)
+			if err := l.thawFilesystems(vmi); err != nil {
+				log.Log.Object(vmi).Reason(err).Error("Failed to thaw the guest filesystems after the freeze timeout.")
+			}
+		}
+	}()
+}
+
+// cancelSafetyUnfreeze must be called with the domainModifyLock held
+func (l *LibvirtDomainManager) cancelSafetyUnfreeze() {
+	if l.safetyUnfreezeContextPtr != nil {
+		l.safetyUnfreezeContextPtr.cancel()
+		l.safetyUnfreezeContextPtr = nil
+	}
+}
+
+// checkLauncherSupport fails early if the VMI uses a device whose helper binary
+// is not installed in the launcher, since libvirt would reject it
+func (l *LibvirtDomainManager) checkLauncherSupport(vmi *v1.VirtualMachineInstance) error {
+	if vmi.Spec.Domain.Devices.TPM != nil {
+		if _, err := lookPath("swtpm"); err != nil {
+			return fmt.Errorf("an emulated TPM requires swtpm, which is not installed in the launcher")
+		}
+	}
+	return nil
+}
+
+// lookPath is replaced in tests
+var lookPath = exec.LookPath
+
 // FinalizeVirtualMachineMigration apply all post-migration changes on the domain.
 func (l *LibvirtDomainManager) FinalizeVirtualMachineMigration(vmi *v1.VirtualMachineInstance) error {
+	// restore the checkpoints first, the finalization is retried if they can not be restored
+	if err := l.restoreCheckpoints(vmi); err != nil {
+		return fmt.Errorf("failed to restore the backup checkpoints: %v", err)
+	}
+
 	if err := l.hotPlugHostDevices(vmi); err != nil {
 		log.Log.Object(vmi).Reason(err).Error("failed to hot-plug host-devices")
 	}
 
+	if err := l.renewPodNetworkLeases(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to renew the pod network leases of the guest")
+	}
+
 	if err := l.setGuestTime(vmi); err != nil {
 		return err
 	}
@@ -756,6 +943,7 @@ func liveMigrationMonitor(vmi *v1.VirtualMachineInstance, l *LibvirtDomainManage
 
 	start := time.Now().UTC().Unix()
 	lastProgressUpdate := start
+	lastDiskCopyProgressReport := int64(0)
 	progressWatermark := int64(0)
 
 	// update timeouts from migration config
@@ -805,6 +993,13 @@ monitorLoop:
 				break
 			}
 
+			if stats.DiskTotalSet && stats.DiskTotal > 0 && now-lastDiskCopyProgressReport >= diskCopyProgressReportInterval {
+				lastDiskCopyProgressReport = now
+				if err := l.updateVMIMigrationDiskCopyProgress(vmi, domainSpec, stats.DiskTotal, stats.DiskProcessed); err != nil {
+					logger.Reason(err).Warning("failed to report the progress of the disk copy")
+				}
+			}
+
 			// check if the migration is progressing
 			progressDelay := now - lastProgressUpdate
 			if progressTimeout != 0 &&
@@ -871,6 +1066,23 @@ monitorLoop:
 	}
 }
 
+// updateVMIMigrationDiskCopyProgress records how much of the non-shared disks was copied
+// to the target in the migration metadata, from where virt-handler reports it.
+func (l *LibvirtDomainManager) updateVMIMigrationDiskCopyProgress(vmi *v1.VirtualMachineInstance, domainSpec *api.DomainSpec, total, processed uint64) error {
+	if domainSpec.Metadata.KubeVirt.Migration == nil {
+		return nil
+	}
+	domainSpec.Metadata.KubeVirt.Migration.DiskTotal = total
+	domainSpec.Metadata.KubeVirt.Migration.DiskProcessed = processed
+
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+	return nil
+}
+
 func shouldTriggerTimeout(acceptableCompletionTime, elapsed int64, domSpec *api.DomainSpec) bool {
 	if acceptableCompletionTime == 0 {
 		return false
@@ -1035,6 +1247,13 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 		OVMFPath:              l.ovmfPath,
 		UseVirtioTransitional: vmi.Spec.Domain.Devices.UseVirtioTransitional != nil && *vmi.Spec.Domain.Devices.UseVirtioTransitional,
 	}
+	c.DomainAttachmentByInterfaceName = getDomainAttachmentByInterfaceName(vmi.Spec.Domain.Devices.Interfaces)
+	if c.Topology, err = l.getHostTopology(vmi); err != nil {
+		return err
+	}
+	if c.PodMemoryNodes, c.PodHugepages, err = getPodNUMAAllocation(vmi); err != nil {
+		return err
+	}
 	if err := converter.Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c); err != nil {
 		return fmt.Errorf("conversion failed: %v", err)
 	}
@@ -1161,6 +1380,22 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 		return domain, fmt.Errorf("preparing the pod network failed: %v", err)
 	}
 
+	// the MAC addresses of the interfaces are only known once the pod network is prepared
+	if l.cloudInitDataStore != nil && l.cloudInitDataStore.DataSource == cloudinit.DataSourceNoCloud &&
+		l.cloudInitDataStore.NetworkData == "" && cloudinit.HasStaticIPAM(vmi) {
+		macs := map[string]string{}
+		for _, iface := range domain.Spec.Devices.Interfaces {
+			if iface.MAC != nil {
+				macs[iface.Alias.GetName()] = iface.MAC.MAC
+			}
+		}
+		networkData, err := cloudinit.GenerateStaticNetworkData(vmi, macs)
+		if err != nil {
+			return domain, fmt.Errorf("generating the cloud-init network-config failed: %v", err)
+		}
+		l.cloudInitDataStore.NetworkData = networkData
+	}
+
 	// create disks images on the cluster lever
 	// or initialize disks images for empty PVC
 	hostDiskCreator := hostdisk.NewHostDiskCreator(l.notifier, l.lessPVCSpaceToleration)
@@ -1168,6 +1403,10 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 	if err != nil {
 		return domain, fmt.Errorf("preparing host-disks failed: %v", err)
 	}
+	// the bitmap images of raw disks need the raw images to exist
+	if err := createBitmapImages(&domain.Spec); err != nil {
+		return domain, fmt.Errorf("preparing the bitmap images failed: %v", err)
+	}
 
 	// Create ephemeral disk for container disks
 	err = containerdisk.CreateEphemeralImages(vmi)
@@ -1292,6 +1531,22 @@ func getDevicesForAssignment(devices v1.Devices) map[string]converter.HostDevice
 
 }
 
+// getDomainAttachmentByInterfaceName returns the domain attachment types of the interfaces bound by a network
+// binding plugin. virt-controller passes them in variables of the following format:
+// KUBEVIRT_DOMAIN_ATTACHMENT_<interfaceName>=<domainAttachmentType>
+func getDomainAttachmentByInterfaceName(ifaces []v1.Interface) map[string]string {
+	domainAttachments := map[string]string{}
+	for _, iface := range ifaces {
+		if iface.Binding == nil {
+			continue
+		}
+		if attachment, isSet := os.LookupEnv(fmt.Sprintf("KUBEVIRT_DOMAIN_ATTACHMENT_%s", iface.Name)); isSet {
+			domainAttachments[iface.Name] = attachment
+		}
+	}
+	return domainAttachments
+}
+
 // This function parses all environment variables with prefix string that is set by a Device Plugin.
 // Device plugin that passes GPU devices by setting these env variables is https://github.com/NVIDIA/kubevirt-gpu-device-plugin
 // It returns address list for devices set in the env variable.
@@ -1420,6 +1675,7 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		c.MemBalloonStatsPeriod = uint(options.MemBalloonStatsPeriod)
 		// Add preallocated and thick-provisioned volumes for which we need to avoid the discard=unmap option
 		c.VolumesDiscardIgnore = options.PreallocatedVolumes
+		c.IncrementalBackup = options.IncrementalBackupEnabled
 	}
 
 	if err := converter.CheckEFI_OVMFRoms(vmi, c); err != nil {
@@ -1427,6 +1683,21 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		return nil, err
 	}
 
+	if err := l.checkLauncherSupport(vmi); err != nil {
+		logger.Reason(err).Error("libvirt does not support the VMI")
+		return nil, err
+	}
+
+	c.DomainAttachmentByInterfaceName = getDomainAttachmentByInterfaceName(vmi.Spec.Domain.Devices.Interfaces)
+	if c.Topology, err = l.getHostTopology(vmi); err != nil {
+		logger.Reason(err).Error("failed to fetch the host NUMA topology")
+		return nil, err
+	}
+	if c.PodMemoryNodes, c.PodHugepages, err = getPodNUMAAllocation(vmi); err != nil {
+		logger.Reason(err).Error("failed to fetch the NUMA allocation of the pod")
+		return nil, err
+	}
+
 	if err := converter.Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c); err != nil {
 		logger.Error("Conversion failed.")
 		return nil, err
@@ -1441,6 +1712,9 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		// We need the domain but it does not exist, so create it
 		if domainerrors.IsNotFound(err) {
 			newDomain = true
+			if c.IncrementalBackup {
+				useBitmapImages(vmi, &domain.Spec)
+			}
 			domain, err = l.preStartHook(vmi, domain)
 			if err != nil {
 				logger.Reason(err).Error("pre start setup for VirtualMachineInstance failed.")
@@ -1467,6 +1741,12 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 	// To make sure, that we set the right qemu wrapper arguments,
 	// we update the domain XML whenever a VirtualMachineInstance was already defined but not running
 	if !newDomain && cli.IsDown(domState) {
+		if c.IncrementalBackup {
+			useBitmapImages(vmi, &domain.Spec)
+			if err := createBitmapImages(&domain.Spec); err != nil {
+				return nil, err
+			}
+		}
 		dom, err = l.setDomainSpecWithHooks(vmi, &domain.Spec)
 		if err != nil {
 			return nil, err
@@ -1511,6 +1791,7 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		logger.Reason(err).Error("Parsing domain XML failed.")
 		return nil, err
 	}
+	keepBitmapImages(&oldSpec, &domain.Spec)
 
 	//Look up all the disks to detach
 	for _, detachDisk := range getDetachedDisks(oldSpec.Devices.Disks, domain.Spec.Devices.Disks) {
@@ -1548,10 +1829,356 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		}
 	}
 
+	if err := syncCDRomMedia(vmi, dom, &oldSpec, domain); err != nil {
+		return nil, err
+	}
+
+	if err := l.syncNetworkInterfaces(vmi, dom, &oldSpec, domain); err != nil {
+		return nil, err
+	}
+
+	if err := syncInterfaceLinkStates(vmi, dom, &oldSpec, domain); err != nil {
+		return nil, err
+	}
+
+	if err := syncDiskIOTunes(vmi, dom, &oldSpec, domain); err != nil {
+		return nil, err
+	}
+
+	if err := syncVCPUs(vmi, dom, &oldSpec, domain); err != nil {
+		return nil, err
+	}
+
+	if vmi.IsRunning() && options.GetExpandDisksEnabled() {
+		if err := l.syncDiskSizes(vmi, dom, &oldSpec); err != nil {
+			return nil, err
+		}
+	}
+
 	// TODO: check if VirtualMachineInstance Spec and Domain Spec are equal or if we have to sync
 	return &oldSpec, nil
 }
 
+// syncVCPUs plugs or unplugs vCPUs of the running domain until the number of
+// online vCPUs matches the sockets requested by the VMI.
+func syncVCPUs(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, oldSpec *api.DomainSpec, domain *api.Domain) error {
+	if oldSpec.VCPU == nil || domain.Spec.VCPU == nil {
+		return nil
+	}
+	// The maximum number of vCPUs can only change with a restart
+	if oldSpec.VCPU.CPUs != domain.Spec.VCPU.CPUs {
+		return nil
+	}
+
+	current := getOnlineVCPUs(oldSpec.VCPU)
+	desired := getOnlineVCPUs(domain.Spec.VCPU)
+	if current == desired {
+		return nil
+	}
+
+	log.Log.Object(vmi).Infof("Changing the number of online vCPUs from %d to %d", current, desired)
+	err := dom.SetVcpusFlags(uint(desired), libvirt.DOMAIN_VCPU_LIVE|libvirt.DOMAIN_VCPU_CONFIG)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("setting the number of vCPUs failed")
+		return err
+	}
+	return nil
+}
+
+func getOnlineVCPUs(vcpu *api.VCPU) uint32 {
+	if vcpu.Current != 0 {
+		return vcpu.Current
+	}
+	return vcpu.CPUs
+}
+
+// interfaceDevice wraps a domain interface so it marshals as a standalone
+// <interface> element, as expected when attaching or detaching a device.
+type interfaceDevice struct {
+	XMLName xml.Name `xml:"interface"`
+	api.Interface
+}
+
+// syncNetworkInterfaces attaches interfaces hotplugged into the VMI to the
+// running domain and detaches the ones that were unplugged.
+func (l *LibvirtDomainManager) syncNetworkInterfaces(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, oldSpec *api.DomainSpec, domain *api.Domain) error {
+	logger := log.Log.Object(vmi)
+
+	networks := map[string]v1.Network{}
+	for _, net := range vmi.Spec.Networks {
+		networks[net.Name] = net
+	}
+	oldInterfaces := map[string]api.Interface{}
+	for _, iface := range oldSpec.Devices.Interfaces {
+		oldInterfaces[iface.Alias.GetName()] = iface
+	}
+	newInterfaces := map[string]int{}
+	for i, iface := range domain.Spec.Devices.Interfaces {
+		newInterfaces[iface.Alias.GetName()] = i
+	}
+
+	for name, iface := range oldInterfaces {
+		if _, exists := newInterfaces[name]; exists || name == "" {
+			continue
+		}
+		logger.V(1).Infof("Detaching interface %s", name)
+		detachBytes, err := xml.Marshal(interfaceDevice{Interface: iface})
+		if err != nil {
+			logger.Reason(err).Error("marshalling detached interface failed")
+			return err
+		}
+		if err := dom.DetachDeviceFlags(string(detachBytes), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG); err != nil {
+			logger.Reason(err).Error("detaching interface")
+			return err
+		}
+	}
+
+	var hotplugIfaces []v1.Interface
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if _, exists := newInterfaces[iface.Name]; !exists {
+			continue
+		}
+		if _, exists := oldInterfaces[iface.Name]; exists {
+			continue
+		}
+		if network.IsHotpluggableInterface(iface, networks[iface.Name]) {
+			hotplugIfaces = append(hotplugIfaces, iface)
+		}
+	}
+	if len(hotplugIfaces) == 0 {
+		return nil
+	}
+
+	plugged, err := network.SetupPodNetworkHotplugPhase2(vmi, hotplugIfaces, domain, l.networkCacheStoreFactory)
+	if err != nil {
+		logger.Reason(err).Error("failed to configure hotplugged interfaces")
+		return err
+	}
+	for _, name := range plugged {
+		logger.V(1).Infof("Attaching interface %s", name)
+		attachBytes, err := xml.Marshal(interfaceDevice{Interface: domain.Spec.Devices.Interfaces[newInterfaces[name]]})
+		if err != nil {
+			logger.Reason(err).Error("marshalling attached interface failed")
+			return err
+		}
+		if err := dom.AttachDeviceFlags(string(attachBytes), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG); err != nil {
+			logger.Reason(err).Error("attaching interface")
+			return err
+		}
+	}
+	return nil
+}
+
+// syncInterfaceLinkStates brings the link of the running domain interfaces up or down,
+// like virsh domif-setlink does, when the link state requested by the VMI changed.
+func syncInterfaceLinkStates(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, oldSpec *api.DomainSpec, domain *api.Domain) error {
+	newLinkStates := map[string]string{}
+	for _, iface := range domain.Spec.Devices.Interfaces {
+		newLinkStates[iface.Alias.GetName()] = getLinkState(iface)
+	}
+
+	for _, iface := range oldSpec.Devices.Interfaces {
+		name := iface.Alias.GetName()
+		linkState, exists := newLinkStates[name]
+		if !exists || name == "" || linkState == getLinkState(iface) {
+			continue
+		}
+
+		if err := setInterfaceLinkState(vmi, dom, iface, linkState); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setInterfaceLinkState(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, iface api.Interface, linkState string) error {
+	log.Log.Object(vmi).V(1).Infof("Setting the link state of interface %s to %s", iface.Alias.GetName(), linkState)
+	iface.LinkState = &api.LinkState{State: linkState}
+	updateBytes, err := xml.Marshal(interfaceDevice{Interface: iface})
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("marshalling updated interface failed")
+		return err
+	}
+	if err := dom.UpdateDeviceFlags(string(updateBytes), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("updating the link state of the interface failed")
+		return err
+	}
+	return nil
+}
+
+// renewPodNetworkLeases flaps the link of the bridge interfaces connected to the
+// pod network once a migration completed. The target pod got a different
+// address, and bringing the link down and up again makes the DHCP client of the
+// guest request a new lease from the DHCP server of the target launcher.
+func (l *LibvirtDomainManager) renewPodNetworkLeases(vmi *v1.VirtualMachineInstance) error {
+	bridgeInterfaces := map[string]bool{}
+	for _, network := range vmi.Spec.Networks {
+		if network.Pod == nil {
+			continue
+		}
+		for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+			if iface.Name == network.Name && iface.Bridge != nil {
+				bridgeInterfaces[iface.Name] = true
+			}
+		}
+	}
+	if len(bridgeInterfaces) == 0 {
+		return nil
+	}
+
+	flapped, err := l.bringLinksDown(vmi, bridgeInterfaces)
+	if err != nil {
+		// do not leave the interfaces which were already brought down without a link
+		if len(flapped) > 0 {
+			if upErr := l.bringLinksUp(vmi, flapped); upErr != nil {
+				log.Log.Object(vmi).Reason(upErr).Error("failed to restore the link of the interfaces")
+			}
+		}
+		return err
+	}
+	if len(flapped) == 0 {
+		return nil
+	}
+
+	// give the guest the time to notice that the carrier is gone, without
+	// holding off other changes of the domain meanwhile
+	time.Sleep(linkFlapInterval)
+
+	return l.bringLinksUp(vmi, flapped)
+}
+
+// bringLinksDown sets the link of the given interfaces down and returns the
+// interfaces it changed. Interfaces whose link is down already are left alone.
+func (l *LibvirtDomainManager) bringLinksDown(vmi *v1.VirtualMachineInstance, interfaces map[string]bool) ([]api.Interface, error) {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return nil, err
+	}
+	defer dom.Free()
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []api.Interface
+	for _, iface := range domainSpec.Devices.Interfaces {
+		// interfaces whose link was brought down on purpose have to stay down
+		if !interfaces[iface.Alias.GetName()] || getLinkState(iface) != string(v1.InterfaceLinkStateUp) {
+			continue
+		}
+		if err := setInterfaceLinkState(vmi, dom, iface, string(v1.InterfaceLinkStateDown)); err != nil {
+			return changed, err
+		}
+		changed = append(changed, iface)
+	}
+	return changed, nil
+}
+
+// bringLinksUp sets the link of the given interfaces up again
+func (l *LibvirtDomainManager) bringLinksUp(vmi *v1.VirtualMachineInstance, interfaces []api.Interface) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return err
+	}
+	defer dom.Free()
+
+	for _, iface := range interfaces {
+		if err := setInterfaceLinkState(vmi, dom, iface, string(v1.InterfaceLinkStateUp)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncDiskIOTunes applies the I/O limits of the disks to the running domain, like
+// virsh blkdeviotune does, when the limits requested by the VMI changed.
+func syncDiskIOTunes(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, oldSpec *api.DomainSpec, domain *api.Domain) error {
+	newIOTunes := map[string]*api.DiskIOTune{}
+	for _, disk := range domain.Spec.Devices.Disks {
+		newIOTunes[disk.Alias.GetName()] = disk.IOTune
+	}
+
+	for _, disk := range oldSpec.Devices.Disks {
+		name := disk.Alias.GetName()
+		ioTune, exists := newIOTunes[name]
+		if !exists || name == "" || toDiskIOTune(ioTune) == toDiskIOTune(disk.IOTune) {
+			continue
+		}
+
+		log.Log.Object(vmi).V(1).Infof("Setting the I/O limits of disk %s", name)
+		if err := dom.SetBlockIoTune(disk.Target.Device, toBlockIoTuneParameters(toDiskIOTune(ioTune)), libvirt.DOMAIN_AFFECT_LIVE|libvirt.DOMAIN_AFFECT_CONFIG); err != nil {
+			log.Log.Object(vmi).Reason(err).Errorf("setting the I/O limits of disk %s failed", name)
+			return err
+		}
+	}
+	return nil
+}
+
+func toDiskIOTune(ioTune *api.DiskIOTune) api.DiskIOTune {
+	if ioTune == nil {
+		return api.DiskIOTune{}
+	}
+	return *ioTune
+}
+
+// toBlockIoTuneParameters sets all limits, so that the limits which were removed are reset to 0
+func toBlockIoTuneParameters(ioTune api.DiskIOTune) *libvirt.DomainBlockIoTuneParameters {
+	return &libvirt.DomainBlockIoTuneParameters{
+		TotalBytesSecSet:          true,
+		TotalBytesSec:             ioTune.TotalBytesSec,
+		ReadBytesSecSet:           true,
+		ReadBytesSec:              ioTune.ReadBytesSec,
+		WriteBytesSecSet:          true,
+		WriteBytesSec:             ioTune.WriteBytesSec,
+		TotalIopsSecSet:           true,
+		TotalIopsSec:              ioTune.TotalIopsSec,
+		ReadIopsSecSet:            true,
+		ReadIopsSec:               ioTune.ReadIopsSec,
+		WriteIopsSecSet:           true,
+		WriteIopsSec:              ioTune.WriteIopsSec,
+		TotalBytesSecMaxSet:       true,
+		TotalBytesSecMax:          ioTune.TotalBytesSecMax,
+		ReadBytesSecMaxSet:        true,
+		ReadBytesSecMax:           ioTune.ReadBytesSecMax,
+		WriteBytesSecMaxSet:       true,
+		WriteBytesSecMax:          ioTune.WriteBytesSecMax,
+		TotalIopsSecMaxSet:        true,
+		TotalIopsSecMax:           ioTune.TotalIopsSecMax,
+		ReadIopsSecMaxSet:         true,
+		ReadIopsSecMax:            ioTune.ReadIopsSecMax,
+		WriteIopsSecMaxSet:        true,
+		WriteIopsSecMax:           ioTune.WriteIopsSecMax,
+		TotalBytesSecMaxLengthSet: true,
+		TotalBytesSecMaxLength:    ioTune.TotalBytesSecMaxLength,
+		ReadBytesSecMaxLengthSet:  true,
+		ReadBytesSecMaxLength:     ioTune.ReadBytesSecMaxLength,
+		WriteBytesSecMaxLengthSet: true,
+		WriteBytesSecMaxLength:    ioTune.WriteBytesSecMaxLength,
+		TotalIopsSecMaxLengthSet:  true,
+		TotalIopsSecMaxLength:     ioTune.TotalIopsSecMaxLength,
+		ReadIopsSecMaxLengthSet:   true,
+		ReadIopsSecMaxLength:      ioTune.ReadIopsSecMaxLength,
+		WriteIopsSecMaxLengthSet:  true,
+		WriteIopsSecMaxLength:     ioTune.WriteIopsSecMaxLength,
+	}
+}
+
+func getLinkState(iface api.Interface) string {
+	if iface.LinkState == nil || iface.LinkState.State == "" {
+		return string(v1.InterfaceLinkStateUp)
+	}
+	return iface.LinkState.State
+}
+
 func getSourceFile(disk api.Disk) string {
 	file := disk.Source.File
 	if disk.Source.File == "" {
@@ -1593,6 +2220,67 @@ func checkIfDiskReadyToUseFunc(filename string) (bool, error) {
 	return true, nil
 }
 
+// diskDevice wraps a domain disk so it marshals as a standalone <disk>
+// element, as expected when updating a device.
+type diskDevice struct {
+	XMLName xml.Name `xml:"disk"`
+	api.Disk
+}
+
+// syncCDRomMedia changes the media of the CD-ROM drives whose source differs
+// from the desired domain. CD-ROM drives are never detached, their media is
+// injected and ejected instead.
+func syncCDRomMedia(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, oldSpec *api.DomainSpec, domain *api.Domain) error {
+	newCDRoms := map[string]api.Disk{}
+	for _, disk := range domain.Spec.Devices.Disks {
+		if disk.Device == "cdrom" {
+			newCDRoms[disk.Alias.GetName()] = disk
+		}
+	}
+
+	for _, disk := range oldSpec.Devices.Disks {
+		name := disk.Alias.GetName()
+		newDisk, exists := newCDRoms[name]
+		if !exists || disk.Device != "cdrom" || getSourceFile(disk) == getSourceFile(newDisk) {
+			continue
+		}
+
+		flags := libvirt.DOMAIN_DEVICE_MODIFY_LIVE | libvirt.DOMAIN_DEVICE_MODIFY_CONFIG
+		if source := getSourceFile(newDisk); source != "" {
+			ready, err := checkIfDiskReadyToUse(source)
+			if err != nil {
+				return err
+			}
+			if !ready {
+				continue
+			}
+			log.Log.Object(vmi).V(1).Infof("Injecting %s into CD-ROM %s", source, name)
+		} else {
+			// the guest may have locked the tray
+			flags |= libvirt.DOMAIN_DEVICE_MODIFY_FORCE
+			log.Log.Object(vmi).V(1).Infof("Ejecting the media of CD-ROM %s", name)
+		}
+
+		update := disk.DeepCopy()
+		update.Type = newDisk.Type
+		update.Source = newDisk.Source
+		update.BackingStore = nil
+		if update.Driver != nil && newDisk.Driver != nil {
+			update.Driver.Type = newDisk.Driver.Type
+		}
+		updateBytes, err := xml.Marshal(diskDevice{Disk: *update})
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Error("marshalling CD-ROM failed")
+			return err
+		}
+		if err := dom.UpdateDeviceFlags(string(updateBytes), flags); err != nil {
+			log.Log.Object(vmi).Reason(err).Errorf("changing the media of CD-ROM %s failed", name)
+			return err
+		}
+	}
+	return nil
+}
+
 func getDetachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	newDiskMap := make(map[string]api.Disk)
 	for _, disk := range newDisks {
@@ -1603,6 +2291,10 @@ func getDetachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	}
 	res := make([]api.Disk, 0)
 	for _, oldDisk := range oldDisks {
+		if oldDisk.Device == "cdrom" {
+			// the media of a CD-ROM is changed in place
+			continue
+		}
 		if _, ok := newDiskMap[getSourceFile(oldDisk)]; !ok {
 			// This disk got detached, add it to the list
 			res = append(res, oldDisk)
@@ -1621,6 +2313,9 @@ func getAttachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	}
 	res := make([]api.Disk, 0)
 	for _, newDisk := range newDisks {
+		if newDisk.Device == "cdrom" {
+			continue
+		}
 		if _, ok := oldDiskMap[getSourceFile(newDisk)]; !ok {
 			// This disk got attached, add it to the list
 			res = append(res, newDisk)
@@ -1671,6 +2366,48 @@ func isBlockDeviceVolumeFunc(volumeName string) (bool, error) {
 	return false, fmt.Errorf("error checking for block device: %v", err)
 }
 
+// getHostTopology returns the host NUMA topology reported by libvirt, if the VMI requests a guest NUMA mapping
+func (l *LibvirtDomainManager) getHostTopology(vmi *v1.VirtualMachineInstance) (*api.Topology, error) {
+	if vmi.Spec.Domain.CPU == nil || vmi.Spec.Domain.CPU.NUMA == nil || vmi.Spec.Domain.CPU.NUMA.GuestMappingPassthrough == nil {
+		return nil, nil
+	}
+	capsXML, err := l.virConn.GetCapabilities()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the host capabilities: %v", err)
+	}
+	caps := &api.Capabilities{}
+	if err := xml.Unmarshal([]byte(capsXML), caps); err != nil {
+		return nil, fmt.Errorf("failed to parse the host capabilities: %v", err)
+	}
+	return &caps.Host.Topology, nil
+}
+
+// getPodMemoryNodes and getPodHugepagesLimit are replaced in tests
+var getPodMemoryNodes = util.GetPodMemoryNodes
+var getPodHugepagesLimit = util.GetPodHugepagesLimit
+
+// getPodNUMAAllocation returns the host NUMA nodes the pod memory is allocated on and how many bytes of hugepages
+// the pod may allocate, if the VMI requests a guest NUMA mapping
+func getPodNUMAAllocation(vmi *v1.VirtualMachineInstance) ([]int, uint64, error) {
+	if vmi.Spec.Domain.CPU == nil || vmi.Spec.Domain.CPU.NUMA == nil || vmi.Spec.Domain.CPU.NUMA.GuestMappingPassthrough == nil ||
+		vmi.Spec.Domain.Memory == nil || vmi.Spec.Domain.Memory.Hugepages == nil {
+		return nil, 0, nil
+	}
+	pageSize, err := resource.ParseQuantity(vmi.Spec.Domain.Memory.Hugepages.PageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid hugepage size %s: %v", vmi.Spec.Domain.Memory.Hugepages.PageSize, err)
+	}
+	nodes, err := getPodMemoryNodes()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read the memory nodes of the pod: %v", err)
+	}
+	hugepages, err := getPodHugepagesLimit(uint64(pageSize.Value()))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read the hugepages limit of the pod: %v", err)
+	}
+	return nodes, hugepages, nil
+}
+
 func (l *LibvirtDomainManager) getDomainSpec(dom cli.VirDomain) (*api.DomainSpec, error) {
 	domainSpec, err := util.GetDomainSpecWithRuntimeInfo(dom)
 	if err != nil {
@@ -1919,7 +2656,11 @@ func (l *LibvirtDomainManager) DeleteVMI(vmi *v1.VirtualMachineInstance) error {
 	}
 	defer dom.Free()
 
-	err = dom.UndefineFlags(libvirt.DOMAIN_UNDEFINE_NVRAM)
+	undefineFlags := libvirt.DOMAIN_UNDEFINE_NVRAM
+	if persistentstate.HasPersistentEFI(&vmi.Spec) {
+		undefineFlags = libvirt.DOMAIN_UNDEFINE_KEEP_NVRAM
+	}
+	err = dom.UndefineFlags(undefineFlags)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Undefining the domain failed.")
 		return err
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"runtime"
 	"strings"
 	"time"
@@ -50,6 +51,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
 )
 
 var _ = Describe("Manager", func() {
@@ -129,6 +131,28 @@ var _ = Describe("Manager", func() {
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
 		})
+		It("should define a new VirtualMachineInstance with the incremental-backup capability if incremental backups are enabled", func() {
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, libvirt.Error{Code: libvirt.ERR_NO_DOMAIN})
+
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			domainSpec.QEMUCapabilities = &api.QEMUCapabilities{
+				Add: []api.QEMUCapability{{Capability: "incremental-backup"}},
+			}
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).To(BeNil())
+			Expect(string(xml)).To(ContainSubstring(`<qemu:add capability="incremental-backup"></qemu:add>`))
+			mockConn.EXPECT().DomainDefineXML(string(xml)).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_SHUTDOWN, 1, nil)
+			mockDomain.EXPECT().Create().Return(nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).MaxTimes(2).Return(string(xml), nil)
+			mockDomain.EXPECT().Free()
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}, IncrementalBackupEnabled: true})
+			Expect(err).To(BeNil())
+			Expect(newspec).ToNot(BeNil())
+		})
 		It("should define and start a new VirtualMachineInstance with userData", func() {
 			// Make sure that we always free the domain after use
 			mockDomain.EXPECT().Free()
@@ -188,6 +212,286 @@ var _ = Describe("Manager", func() {
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
 		})
+		table.DescribeTable("should change the number of online vCPUs of a running VirtualMachineInstance", func(domainSockets, vmiSockets uint32) {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: domainSockets, MaxSockets: 4}
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).NotTo(HaveOccurred())
+			vmi.Spec.Domain.CPU.Sockets = vmiSockets
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+			mockDomain.EXPECT().SetVcpusFlags(uint(vmiSockets), libvirt.DOMAIN_VCPU_LIVE|libvirt.DOMAIN_VCPU_CONFIG).Return(nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).To(BeNil())
+			Expect(newspec).ToNot(BeNil())
+		},
+			table.Entry("when sockets are added", uint32(2), uint32(3)),
+			table.Entry("when scaling up to the maximum sockets", uint32(2), uint32(4)),
+			table.Entry("when sockets are removed", uint32(3), uint32(1)),
+		)
+		It("should not define a VirtualMachineInstance with a TPM if swtpm is not installed", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{}
+
+			lookPath = func(file string) (string, error) {
+				return "", fmt.Errorf("%s not found", file)
+			}
+			defer func() { lookPath = exec.LookPath }()
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			_, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).To(MatchError("an emulated TPM requires swtpm, which is not installed in the launcher"))
+		})
+		It("should detach an unplugged interface from a running VirtualMachineInstance", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			unpluggedIface := api.Interface{
+				Type:  "ethernet",
+				MAC:   &api.MAC{MAC: "02:00:00:00:00:01"},
+				Alias: api.NewUserDefinedAlias("blue"),
+			}
+			domainSpec.Devices.Interfaces = append(domainSpec.Devices.Interfaces, unpluggedIface)
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).NotTo(HaveOccurred())
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+			mockDomain.EXPECT().DetachDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG).Do(func(detachXML string, _ libvirt.DomainDeviceModifyFlags) {
+				Expect(detachXML).To(HavePrefix("<interface "))
+				Expect(detachXML).To(ContainSubstring(`<alias name="ua-blue"></alias>`))
+			}).Return(nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).To(BeNil())
+			Expect(newspec).ToNot(BeNil())
+		})
+		It("should update the link state of an interface of a running VirtualMachineInstance", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Domain.Devices.Interfaces[0].Model = "e1000"
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			domainSpec.Devices.Interfaces = []api.Interface{{
+				Type:  "ethernet",
+				MAC:   &api.MAC{MAC: "02:00:00:00:00:01"},
+				Alias: api.NewUserDefinedAlias("default"),
+			}}
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).NotTo(HaveOccurred())
+			vmi.Spec.Domain.Devices.Interfaces[0].LinkState = v1.InterfaceLinkStateDown
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+			mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG).Do(func(deviceXML string, _ libvirt.DomainDeviceModifyFlags) {
+				Expect(deviceXML).To(HavePrefix("<interface "))
+				Expect(deviceXML).To(ContainSubstring(`<mac address="02:00:00:00:00:01"></mac>`))
+				Expect(deviceXML).To(ContainSubstring(`<link state="down"></link>`))
+			}).Return(nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).To(BeNil())
+			Expect(newspec).ToNot(BeNil())
+		})
+		Context("with I/O limits of a disk", func() {
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				vmi = newVMI(testNamespace, testVmName)
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+					Name: "permvolume1",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{
+							Bus: "virtio",
+						},
+					},
+				}}
+				vmi.Spec.Volumes = []v1.Volume{{
+					Name: "permvolume1",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{
+							Name: "dv1",
+						},
+					},
+				}}
+				isBlockDeviceVolume = func(volumeName string) (bool, error) {
+					return false, nil
+				}
+			})
+
+			expectSetBlockIoTune := func(domainSpec *api.DomainSpec, matcher func(*libvirt.DomainBlockIoTuneParameters)) {
+				// Make sure that we always free the domain after use
+				mockDomain.EXPECT().Free()
+				xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+				Expect(err).NotTo(HaveOccurred())
+
+				mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+				mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+				mockDomain.EXPECT().SetBlockIoTune("vda", gomock.Any(), libvirt.DOMAIN_AFFECT_LIVE|libvirt.DOMAIN_AFFECT_CONFIG).Do(func(_ string, params *libvirt.DomainBlockIoTuneParameters, _ libvirt.DomainModificationImpact) {
+					matcher(params)
+				}).Return(nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+				newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+				Expect(err).To(BeNil())
+				Expect(newspec).ToNot(BeNil())
+			}
+
+			It("should apply changed limits to the running domain", func() {
+				domainSpec := expectIsolationDetectionForVMI(vmi)
+				vmi.Spec.Domain.Devices.Disks[0].IOTune = &v1.DiskIOTune{
+					ReadBytesSec: 1048576,
+					TotalIOPSSec: 100,
+					Burst:        &v1.DiskIOTuneBurst{TotalIOPSSec: 200, LengthSeconds: 10},
+				}
+
+				expectSetBlockIoTune(domainSpec, func(params *libvirt.DomainBlockIoTuneParameters) {
+					Expect(params.ReadBytesSecSet).To(BeTrue())
+					Expect(params.ReadBytesSec).To(Equal(uint64(1048576)))
+					Expect(params.TotalIopsSec).To(Equal(uint64(100)))
+					Expect(params.TotalIopsSecMax).To(Equal(uint64(200)))
+					Expect(params.TotalIopsSecMaxLength).To(Equal(uint64(10)))
+					Expect(params.WriteBytesSecSet).To(BeTrue())
+					Expect(params.WriteBytesSec).To(BeZero())
+				})
+			})
+
+			It("should reset removed limits of the running domain", func() {
+				domainSpec := expectIsolationDetectionForVMI(vmi)
+				domainSpec.Devices.Disks[0].IOTune = &api.DiskIOTune{TotalBytesSec: 1048576}
+
+				expectSetBlockIoTune(domainSpec, func(params *libvirt.DomainBlockIoTuneParameters) {
+					Expect(params.TotalBytesSecSet).To(BeTrue())
+					Expect(params.TotalBytesSec).To(BeZero())
+				})
+			})
+		})
+		Context("with the media of a CD-ROM", func() {
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				vmi = newVMI(testNamespace, testVmName)
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+					Name: "cdrom1",
+					DiskDevice: v1.DiskDevice{
+						CDRom: &v1.CDRomTarget{
+							Bus: "sata",
+						},
+					},
+				}}
+				isHotplugBlockDeviceVolume = func(volumeName string) bool {
+					return false
+				}
+			})
+
+			injectVolume := func(phase v1.VolumePhase) {
+				vmi.Spec.Volumes = []v1.Volume{{
+					Name: "cdrom1",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "virtio-win",
+						},
+					},
+				}}
+				vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+					Name:  "cdrom1",
+					Phase: phase,
+					HotplugVolume: &v1.HotplugVolumeStatus{
+						AttachPodName: "testpod1",
+						AttachPodUID:  "abcd",
+					},
+				}}
+			}
+
+			expectSync := func(domainSpec *api.DomainSpec) DomainManager {
+				// Make sure that we always free the domain after use
+				mockDomain.EXPECT().Free()
+				xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+				Expect(err).NotTo(HaveOccurred())
+
+				mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+				mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+				return manager
+			}
+
+			It("should inject mounted media into an empty drive", func() {
+				domainSpec := expectIsolationDetectionForVMI(vmi)
+				Expect(domainSpec.Devices.Disks[0].Source.File).To(BeEmpty())
+				injectVolume(v1.HotplugVolumeMounted)
+				checkIfDiskReadyToUse = func(filename string) (bool, error) {
+					Expect(filename).To(Equal("/var/run/kubevirt/hotplug-disks/cdrom1/disk.img"))
+					return true, nil
+				}
+
+				manager := expectSync(domainSpec)
+				mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG).Do(func(xml string, _ libvirt.DomainDeviceModifyFlags) {
+					Expect(xml).To(HavePrefix("<disk device=\"cdrom\""))
+					Expect(xml).To(ContainSubstring("file=\"/var/run/kubevirt/hotplug-disks/cdrom1/disk.img\""))
+				}).Return(nil)
+				_, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should not inject media which is not ready yet", func() {
+				domainSpec := expectIsolationDetectionForVMI(vmi)
+				injectVolume(v1.HotplugVolumeMounted)
+				checkIfDiskReadyToUse = func(filename string) (bool, error) {
+					return false, nil
+				}
+
+				manager := expectSync(domainSpec)
+				_, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should force the ejection of removed media", func() {
+				domainSpec := expectIsolationDetectionForVMI(vmi)
+				domainSpec.Devices.Disks[0].Source.File = "/var/run/kubevirt/hotplug-disks/cdrom1/disk.img"
+
+				manager := expectSync(domainSpec)
+				mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG|libvirt.DOMAIN_DEVICE_MODIFY_FORCE).Do(func(xml string, _ libvirt.DomainDeviceModifyFlags) {
+					Expect(xml).ToNot(ContainSubstring("file="))
+				}).Return(nil)
+				_, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+		It("should not attach a hotplugged interface before its pod network is configured", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).NotTo(HaveOccurred())
+			vmi.Spec.Networks = append(vmi.Spec.Networks, v1.Network{
+				Name:          "blue",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}},
+			})
+			vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces, v1.Interface{
+				Name:                   "blue",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+			})
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).To(BeNil())
+			Expect(newspec).ToNot(BeNil())
+		})
 		table.DescribeTable("should try to start a VirtualMachineInstance in state",
 			func(state libvirt.DomainState) {
 				// Make sure that we always free the domain after use
@@ -323,6 +627,81 @@ var _ = Describe("Manager", func() {
 			Expect(err).To(BeNil())
 
 		})
+		It("should freeze and unfreeze a VirtualMachineInstance", func() {
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"thawed"}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, testDomainName).Return(`{"return":2}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+			err := manager.FreezeVMI(vmi, 300)
+			Expect(err).To(BeNil())
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"frozen"}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-thaw"}`, testDomainName).Return(`{"return":2}`, nil)
+
+			err = manager.UnfreezeVMI(vmi)
+			Expect(err).To(BeNil())
+		})
+		It("should not try to freeze a frozen VirtualMachineInstance", func() {
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"frozen"}`, nil).Times(2)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-thaw"}`, testDomainName).Return(`{"return":2}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			// no call to freeze
+
+			err := manager.FreezeVMI(vmi, 300)
+			Expect(err).To(BeNil())
+			err = manager.UnfreezeVMI(vmi)
+			Expect(err).To(BeNil())
+		})
+		It("should not try to unfreeze a thawed VirtualMachineInstance", func() {
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"thawed"}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			// no call to thaw
+
+			err := manager.UnfreezeVMI(vmi)
+			Expect(err).To(BeNil())
+		})
+		It("should fail to freeze a VirtualMachineInstance without guest agent", func() {
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return("", fmt.Errorf("guest agent is not connected"))
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+			err := manager.FreezeVMI(vmi, 300)
+			Expect(err).To(HaveOccurred())
+		})
+		It("should unfreeze a VirtualMachineInstance after the unfreeze timeout", func() {
+			isThawCalled := make(chan bool, 1)
+			defer close(isThawCalled)
+
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"thawed"}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, testDomainName).Return(`{"return":2}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+			err := manager.FreezeVMI(vmi, 1)
+			Expect(err).To(BeNil())
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"frozen"}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-thaw"}`, testDomainName).Do(func(interface{}, interface{}) {
+				isThawCalled <- true
+			}).Return(`{"return":2}`, nil)
+
+			Eventually(func() bool {
+				select {
+				case isCalled := <-isThawCalled:
+					return isCalled
+				default:
+				}
+				return false
+			}, 10*time.Second, 1).Should(BeTrue(), "guest filesystems weren't thawed")
+		})
 		It("should not add discard=unmap if a disk is preallocated", func() {
 			// Make sure that we always free the domain after use
 			mockDomain.EXPECT().Free()
@@ -1282,11 +1661,164 @@ var _ = Describe("Manager", func() {
 			copyDisks := getDiskTargetsForMigration(mockDomain, vmi)
 			Expect(copyDisks).Should(ConsistOf("vdb", "vdd"))
 		})
+		It("should copy PVCs which are migrated to new claims", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "migrated",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "destination"},
+					},
+				},
+				{
+					Name: "shared",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "rwx"},
+					},
+				},
+			}
+			vmi.Status.VolumeMigration = &v1.VolumeMigrationState{
+				MigrationName:   "volume-migration",
+				MigratedVolumes: []v1.MigratedVolume{{VolumeName: "migrated", SourceClaim: "source", DestinationClaim: "destination"}},
+			}
+
+			disks := classifyVolumesForMigration(vmi)
+			Expect(disks.isSharedVolume("migrated")).To(BeFalse())
+			Expect(disks.isSharedVolume("shared")).To(BeTrue())
+		})
 		AfterEach(func() {
 			ip.GetLoopbackAddress = funcPreviousValue
 		})
 	})
 
+	Context("on a completed migration", func() {
+		var previousLinkFlapInterval time.Duration
+
+		BeforeEach(func() {
+			previousLinkFlapInterval = linkFlapInterval
+			linkFlapInterval = 0
+		})
+
+		AfterEach(func() {
+			linkFlapInterval = previousLinkFlapInterval
+		})
+
+		It("should flap the link of bridge interfaces on the pod network", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			domainSpec.Devices.Interfaces = []api.Interface{{
+				Type:  "ethernet",
+				MAC:   &api.MAC{MAC: "02:00:00:00:00:01"},
+				Alias: api.NewUserDefinedAlias("default"),
+			}}
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).NotTo(HaveOccurred())
+
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			libvirtManager := manager.(*LibvirtDomainManager)
+			unlocked := make(chan struct{})
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil).Times(2)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+			mockDomain.EXPECT().Free().Times(2)
+			gomock.InOrder(
+				mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG).Do(func(deviceXML string, _ libvirt.DomainDeviceModifyFlags) {
+					Expect(deviceXML).To(ContainSubstring(`<mac address="02:00:00:00:00:01"></mac>`))
+					Expect(deviceXML).To(ContainSubstring(`<link state="down"></link>`))
+					go func() {
+						libvirtManager.domainModifyLock.Lock()
+						libvirtManager.domainModifyLock.Unlock()
+						close(unlocked)
+					}()
+				}).Return(nil),
+				mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG).Do(func(deviceXML string, _ libvirt.DomainDeviceModifyFlags) {
+					Expect(deviceXML).To(ContainSubstring(`<mac address="02:00:00:00:00:01"></mac>`))
+					Expect(deviceXML).To(ContainSubstring(`<link state="up"></link>`))
+					// the domain was not locked while the link was down
+					Expect(unlocked).To(BeClosed())
+				}).Return(nil),
+			)
+
+			linkFlapInterval = 100 * time.Millisecond
+			Expect(libvirtManager.renewPodNetworkLeases(vmi)).To(Succeed())
+		})
+
+		It("should bring the links up again when bringing a link down failed", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			secondNetwork := v1.DefaultPodNetwork()
+			secondNetwork.Name = "second"
+			secondInterface := v1.DefaultBridgeNetworkInterface()
+			secondInterface.Name = "second"
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork(), *secondNetwork}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface(), *secondInterface}
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			domainSpec.Devices.Interfaces = []api.Interface{
+				{
+					Type:  "ethernet",
+					MAC:   &api.MAC{MAC: "02:00:00:00:00:01"},
+					Alias: api.NewUserDefinedAlias("default"),
+				},
+				{
+					Type:  "ethernet",
+					MAC:   &api.MAC{MAC: "02:00:00:00:00:02"},
+					Alias: api.NewUserDefinedAlias("second"),
+				},
+			}
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).NotTo(HaveOccurred())
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil).Times(2)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+			mockDomain.EXPECT().Free().Times(2)
+			gomock.InOrder(
+				mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), gomock.Any()).Do(func(deviceXML string, _ libvirt.DomainDeviceModifyFlags) {
+					Expect(deviceXML).To(ContainSubstring(`<mac address="02:00:00:00:00:01"></mac>`))
+					Expect(deviceXML).To(ContainSubstring(`<link state="down"></link>`))
+				}).Return(nil),
+				mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), gomock.Any()).Return(fmt.Errorf("update failed")),
+				mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), gomock.Any()).Do(func(deviceXML string, _ libvirt.DomainDeviceModifyFlags) {
+					Expect(deviceXML).To(ContainSubstring(`<mac address="02:00:00:00:00:01"></mac>`))
+					Expect(deviceXML).To(ContainSubstring(`<link state="up"></link>`))
+				}).Return(nil),
+			)
+
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			Expect(manager.(*LibvirtDomainManager).renewPodNetworkLeases(vmi)).ToNot(Succeed())
+		})
+
+		It("should not flap the link of bridge interfaces which are down", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			domainSpec.Devices.Interfaces = []api.Interface{{
+				Type:      "ethernet",
+				Alias:     api.NewUserDefinedAlias("default"),
+				LinkState: &api.LinkState{State: "down"},
+			}}
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).NotTo(HaveOccurred())
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+			mockDomain.EXPECT().Free()
+
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			Expect(manager.(*LibvirtDomainManager).renewPodNetworkLeases(vmi)).To(Succeed())
+		})
+
+		It("should not touch the domain when the pod network uses masquerade", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			Expect(manager.(*LibvirtDomainManager).renewPodNetworkLeases(vmi)).To(Succeed())
+		})
+	})
+
 	Context("on successful VirtualMachineInstance kill", func() {
 		table.DescribeTable("should try to undefine a VirtualMachineInstance in state",
 			func(state libvirt.DomainState) {
@@ -1301,6 +1833,18 @@ var _ = Describe("Manager", func() {
 			table.Entry("crashed", libvirt.DOMAIN_CRASHED),
 			table.Entry("shutoff", libvirt.DOMAIN_SHUTOFF),
 		)
+		It("should keep the persistent EFI NVRAM when undefining a VirtualMachineInstance", func() {
+			mockDomain.EXPECT().Free()
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().UndefineFlags(libvirt.DOMAIN_UNDEFINE_KEEP_NVRAM).Return(nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			vmi := newVMI(testNamespace, testVmName)
+			persistent := true
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{EFI: &v1.EFI{Persistent: &persistent}},
+			}
+			Expect(manager.DeleteVMI(vmi)).To(Succeed())
+		})
 		table.DescribeTable("should try to destroy a VirtualMachineInstance in state",
 			func(state libvirt.DomainState) {
 				// Make sure that we always free the domain after use
@@ -1433,6 +1977,209 @@ var _ = Describe("Manager", func() {
 		})
 	})
 
+	Context("on fetching the host NUMA topology", func() {
+		It("should only query the capabilities if a guest NUMA mapping is requested", func() {
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			libvirtmanager := manager.(*LibvirtDomainManager)
+
+			vmi := newVMI(testNamespace, testVmName)
+			topology, err := libvirtmanager.getHostTopology(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(topology).To(BeNil())
+		})
+
+		It("should return the host NUMA cells", func() {
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			libvirtmanager := manager.(*LibvirtDomainManager)
+
+			mockConn.EXPECT().GetCapabilities().Return(`<capabilities>
+  <host>
+    <uuid>5e4e2c3b-5b74-4e1d-9d4c-8b5b6b0b2d1a</uuid>
+    <topology>
+      <cells num="2">
+        <cell id="0">
+          <memory unit="KiB">8388608</memory>
+          <pages unit="KiB" size="2048">512</pages>
+          <cpus num="2">
+            <cpu id="0" socket_id="0" core_id="0" siblings="0"/>
+            <cpu id="1" socket_id="0" core_id="1" siblings="1"/>
+          </cpus>
+        </cell>
+        <cell id="1">
+          <memory unit="KiB">8388608</memory>
+          <pages unit="KiB" size="2048">512</pages>
+          <cpus num="2">
+            <cpu id="2" socket_id="1" core_id="0" siblings="2"/>
+            <cpu id="3" socket_id="1" core_id="1" siblings="3"/>
+          </cpus>
+        </cell>
+      </cells>
+    </topology>
+  </host>
+</capabilities>`, nil)
+
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Domain.CPU = &v1.CPU{NUMA: &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}}}
+			topology, err := libvirtmanager.getHostTopology(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(topology.Cells.Num).To(Equal(uint32(2)))
+			Expect(topology.Cells.Cell).To(HaveLen(2))
+			Expect(topology.Cells.Cell[1].ID).To(Equal(uint32(1)))
+			Expect(topology.Cells.Cell[1].CPUs.CPU[0].ID).To(Equal(uint32(2)))
+			Expect(topology.Cells.Cell[1].Pages[0].Count).To(Equal(uint64(512)))
+		})
+
+		It("should read the NUMA allocation of the pod if a guest NUMA mapping is requested", func() {
+			defer func() {
+				getPodMemoryNodes = util.GetPodMemoryNodes
+				getPodHugepagesLimit = util.GetPodHugepagesLimit
+			}()
+			getPodMemoryNodes = func() ([]int, error) {
+				return []int{0, 1}, nil
+			}
+			getPodHugepagesLimit = func(pageSize uint64) (uint64, error) {
+				Expect(pageSize).To(Equal(uint64(2 * 1024 * 1024)))
+				return 64 * 1024 * 1024, nil
+			}
+
+			vmi := newVMI(testNamespace, testVmName)
+			nodes, hugepages, err := getPodNUMAAllocation(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodes).To(BeNil())
+
+			vmi.Spec.Domain.CPU = &v1.CPU{NUMA: &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}}}
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+			nodes, hugepages, err = getPodNUMAAllocation(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodes).To(Equal([]int{0, 1}))
+			Expect(hugepages).To(Equal(uint64(64 * 1024 * 1024)))
+		})
+	})
+
+	Context("on syncing the sizes of PVC backed disks", func() {
+		var libvirtmanager *LibvirtDomainManager
+		var vmi *v1.VirtualMachineInstance
+		var origBlockDeviceSize func(string) (int64, error)
+		var origDirBytesAvailable func(string) (int64, error)
+
+		newDomainSpec := func(source api.DiskSource) *api.DomainSpec {
+			return &api.DomainSpec{
+				Devices: api.Devices{
+					Disks: []api.Disk{
+						{
+							Device: "disk",
+							Type:   "file",
+							Source: source,
+							Target: api.DiskTarget{Device: "vda"},
+							Driver: &api.DiskDriver{Type: "raw"},
+							Alias:  api.NewUserDefinedAlias("rootdisk"),
+						},
+					},
+				},
+			}
+		}
+
+		expectDiskSizesDefined := func(size int64) {
+			inactiveXML, err := xml.Marshal(&api.DomainSpec{})
+			Expect(err).ToNot(HaveOccurred())
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).Return(string(inactiveXML), nil)
+			mockConn.EXPECT().DomainDefineXML(gomock.Any()).DoAndReturn(func(xml string) (cli.VirDomain, error) {
+				Expect(xml).To(ContainSubstring(fmt.Sprintf(`<diskSize name="rootdisk" size="%d"></diskSize>`, size)))
+				return mockDomain, nil
+			})
+			mockDomain.EXPECT().Free()
+		}
+
+		BeforeEach(func() {
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			libvirtmanager = manager.(*LibvirtDomainManager)
+			vmi = newVMI(testNamespace, testVmName)
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name: "rootdisk",
+					PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+						Capacity: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("2Gi")},
+					},
+				},
+			}
+			origBlockDeviceSize = blockDeviceSize
+			origDirBytesAvailable = dirBytesAvailable
+		})
+
+		AfterEach(func() {
+			blockDeviceSize = origBlockDeviceSize
+			dirBytesAvailable = origDirBytesAvailable
+		})
+
+		It("should expand a disk backed by a block device to the size of the device", func() {
+			blockDeviceSize = func(path string) (int64, error) {
+				Expect(path).To(Equal("/dev/rootdisk"))
+				return 3 << 30, nil
+			}
+			mockDomain.EXPECT().GetBlockInfo("vda", uint(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1 << 30}, nil)
+			mockDomain.EXPECT().BlockResize("vda", uint64(3<<30), libvirt.DOMAIN_BLOCK_RESIZE_BYTES).Return(nil)
+			expectDiskSizesDefined(3 << 30)
+
+			Expect(libvirtmanager.syncDiskSizes(vmi, mockDomain, newDomainSpec(api.DiskSource{Dev: "/dev/rootdisk"}))).To(Succeed())
+		})
+
+		It("should grow the disk image to the capacity of the PVC", func() {
+			diskDir, err := ioutil.TempDir("", "disksize")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(diskDir)
+			diskImg := diskDir + "/disk.img"
+			Expect(createSparseFile(diskImg, 1<<30)).To(Succeed())
+			dirBytesAvailable = func(path string) (int64, error) {
+				Expect(path).To(Equal(diskDir))
+				return 5 << 30, nil
+			}
+			mockDomain.EXPECT().GetBlockInfo("vda", uint(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1 << 30}, nil)
+			mockDomain.EXPECT().BlockResize("vda", uint64(2<<30), libvirt.DOMAIN_BLOCK_RESIZE_BYTES).Return(nil)
+			expectDiskSizesDefined(2 << 30)
+
+			Expect(libvirtmanager.syncDiskSizes(vmi, mockDomain, newDomainSpec(api.DiskSource{File: diskImg}))).To(Succeed())
+			info, err := os.Stat(diskImg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Size()).To(Equal(int64(2 << 30)))
+		})
+
+		It("should not grow the disk image beyond the space left on the filesystem", func() {
+			diskDir, err := ioutil.TempDir("", "disksize")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(diskDir)
+			diskImg := diskDir + "/disk.img"
+			Expect(createSparseFile(diskImg, 1<<30)).To(Succeed())
+			dirBytesAvailable = func(path string) (int64, error) {
+				return 512<<20 + 4096, nil
+			}
+			mockDomain.EXPECT().GetBlockInfo("vda", uint(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1 << 30}, nil)
+			mockDomain.EXPECT().BlockResize("vda", uint64(1<<30+512<<20), libvirt.DOMAIN_BLOCK_RESIZE_BYTES).Return(nil)
+			expectDiskSizesDefined(1<<30 + 512<<20)
+
+			Expect(libvirtmanager.syncDiskSizes(vmi, mockDomain, newDomainSpec(api.DiskSource{File: diskImg}))).To(Succeed())
+		})
+
+		It("should not redefine the domain if the recorded sizes did not change", func() {
+			inactiveSpec := &api.DomainSpec{}
+			inactiveSpec.Metadata.KubeVirt.DiskSizes = []api.DiskSizeMetadata{{Name: "rootdisk", Size: 1 << 30}}
+			inactiveXML, err := xml.Marshal(inactiveSpec)
+			Expect(err).ToNot(HaveOccurred())
+			blockDeviceSize = func(path string) (int64, error) {
+				return 1 << 30, nil
+			}
+			mockDomain.EXPECT().GetBlockInfo("vda", uint(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1 << 30}, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).Return(string(inactiveXML), nil)
+
+			Expect(libvirtmanager.syncDiskSizes(vmi, mockDomain, newDomainSpec(api.DiskSource{Dev: "/dev/rootdisk"}))).To(Succeed())
+		})
+
+		It("should ignore disks which are not backed by a PVC", func() {
+			vmi.Status.VolumeStatus = nil
+
+			Expect(libvirtmanager.syncDiskSizes(vmi, mockDomain, newDomainSpec(api.DiskSource{Dev: "/dev/rootdisk"}))).To(Succeed())
+		})
+	})
+
 	// TODO: test error reporting on non successful VirtualMachineInstance syncs and kill attempts
 
 	AfterEach(func() {
@@ -1461,6 +2208,22 @@ var _ = Describe("getEnvAddressListByPrefix with gpu prefix", func() {
 	})
 })
 
+var _ = Describe("getDomainAttachmentByInterfaceName", func() {
+	AfterEach(func() {
+		os.Unsetenv("KUBEVIRT_DOMAIN_ATTACHMENT_blue")
+	})
+
+	It("returns the domain attachment of interfaces bound by a plugin", func() {
+		os.Setenv("KUBEVIRT_DOMAIN_ATTACHMENT_blue", "tap")
+		ifaces := []v1.Interface{
+			*v1.DefaultBridgeNetworkInterface(),
+			{Name: "blue", Binding: &v1.PluginBinding{Name: "custom"}},
+			{Name: "red", Binding: &v1.PluginBinding{Name: "custom"}},
+		}
+		Expect(getDomainAttachmentByInterfaceName(ifaces)).To(Equal(map[string]string{"blue": "tap"}))
+	})
+})
+
 var _ = Describe("getEnvAddressListByPrefix with vgpu prefix", func() {
 	It("returns empty if Mdev Uuid is not set", func() {
 		Expect(len(getEnvAddressListByPrefix(vgpuEnvPrefix))).To(Equal(0))
@@ -1682,3 +2445,12 @@ func addCloudInitDisk(vmi *v1.VirtualMachineInstance, userData string, networkDa
 		},
 	})
 }
+
+func createSparseFile(path string, size int64) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Truncate(size)
+}
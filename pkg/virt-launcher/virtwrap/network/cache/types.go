@@ -6,4 +6,6 @@ type PodCacheInterface struct {
 	Iface  *v1.Interface `json:"iface,omitempty"`
 	PodIP  string        `json:"podIP,omitempty"`
 	PodIPs []string      `json:"podIPs,omitempty"`
+	// Firewall holds the rules enforced for the interface in the pod
+	Firewall *v1.InterfaceFirewall `json:"firewall,omitempty"`
 }
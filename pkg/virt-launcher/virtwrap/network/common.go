@@ -62,6 +62,11 @@ type VIF struct {
 	Routes       *[]netlink.Route
 	Mtu          uint16
 	IPAMDisabled bool
+	// StaticIPAM is set when the addresses come from the interface spec
+	// instead of the pod interface
+	StaticIPAM    bool
+	Nameservers   []net.IP
+	SearchDomains []string
 }
 
 type CriticalNetworkError struct {
@@ -114,6 +119,8 @@ type NetworkHandler interface {
 	NftablesAppendRule(proto iptables.Protocol, table, chain string, rulespec ...string) error
 	NftablesLoad(proto iptables.Protocol) error
 	GetNFTIPString(proto iptables.Protocol) string
+	NftablesNewFilterChain(family, table, chain, hook string) error
+	NftablesAddRule(family, table, chain string, rulespec ...string) error
 	CreateTapDevice(tapName string, queueNumber uint32, launcherPID int, mtu int, tapOwner string) error
 	BindTapDeviceToBridge(tapName string, bridgeName string) error
 	DisableTXOffloadChecksum(ifaceName string) error
@@ -261,6 +268,36 @@ func (h *NetworkUtilsHandler) NftablesAppendRule(proto iptables.Protocol, table,
 	return nil
 }
 
+// NftablesNewFilterChain creates the table and a filter chain attached to the given hook,
+// accepting the traffic which is not dropped by its rules. Existing ones are kept.
+func (h *NetworkUtilsHandler) NftablesNewFilterChain(family, table, chain, hook string) error {
+	// #nosec No risk for attacker injection. CMD variables are predefined strings
+	output, err := exec.Command("nft", "add", "table", family, table).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to add nftable %s %s error %s", family, table, string(output))
+	}
+
+	spec := fmt.Sprintf("{ type filter hook %s priority 0; policy accept; }", hook)
+	// #nosec No risk for attacker injection. CMD variables are predefined strings
+	output, err = exec.Command("nft", "add", "chain", family, table, chain, spec).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to add nfchain %s error %s", chain, string(output))
+	}
+
+	return nil
+}
+
+func (h *NetworkUtilsHandler) NftablesAddRule(family, table, chain string, rulespec ...string) error {
+	cmd := append([]string{"add", "rule", family, table, chain}, rulespec...)
+	// #nosec No risk for attacker injection. The rules are built from validated VMI fields
+	output, err := exec.Command("nft", cmd...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to add nfrule error %s", string(output))
+	}
+
+	return nil
+}
+
 func (h *NetworkUtilsHandler) GetNFTIPString(proto iptables.Protocol) string {
 	if proto == iptables.ProtocolIPv6 {
 		return "ip6"
@@ -373,34 +410,60 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *VIF, serverAddr net.IP, bridgeInter
 	if err != nil {
 		return fmt.Errorf("Failed to get DNS servers from resolv.conf: %v", err)
 	}
-
-	// panic in case the DHCP server failed during the vm creation
-	// but ignore dhcp errors when the vm is destroyed or shutting down
-	go func() {
-		if err = DHCPServer(
-			nic.MAC,
-			filterByMAC,
-			nic.IP.IP,
-			nic.IP.Mask,
-			bridgeInterfaceName,
-			serverAddr,
-			nic.Gateway,
-			nameservers,
-			nic.Routes,
-			searchDomains,
-			nic.Mtu,
-			dhcpOptions,
-		); err != nil {
-			log.Log.Errorf("failed to run DHCP: %v", err)
-			panic(err)
+	var ipv6Nameservers []net.IP
+	if len(nic.Nameservers) > 0 {
+		nameservers = nil
+		for _, nameserver := range nic.Nameservers {
+			if ipv4 := nameserver.To4(); ipv4 != nil {
+				nameservers = append(nameservers, []byte(ipv4))
+			} else {
+				ipv6Nameservers = append(ipv6Nameservers, nameserver)
+			}
 		}
-	}()
+	}
+	if nic.SearchDomains != nil {
+		searchDomains = nic.SearchDomains
+	}
+
+	// a statically configured interface may come without an IPv4 address
+	if nic.IP.IPNet == nil && nic.StaticIPAM {
+		log.Log.V(4).Infof("Skipping DHCP for %s, no IPv4 address is configured", nic.Name)
+	} else {
+		// panic in case the DHCP server failed during the vm creation
+		// but ignore dhcp errors when the vm is destroyed or shutting down
+		go func() {
+			if err = DHCPServer(
+				nic.MAC,
+				filterByMAC,
+				nic.IP.IP,
+				nic.IP.Mask,
+				bridgeInterfaceName,
+				serverAddr,
+				nic.Gateway,
+				nameservers,
+				nic.Routes,
+				searchDomains,
+				nic.Mtu,
+				dhcpOptions,
+			); err != nil {
+				log.Log.Errorf("failed to run DHCP: %v", err)
+				panic(err)
+			}
+		}()
+	}
 
 	if nic.IPv6.IPNet != nil {
+		// on a bridge the server also sees the requests of other hosts on the network
+		var clientMAC net.HardwareAddr
+		if filterByMAC {
+			clientMAC = nic.MAC
+		}
 		go func() {
 			if err = DHCPv6Server(
 				nic.IPv6.IP,
 				bridgeInterfaceName,
+				clientMAC,
+				ipv6Nameservers,
 			); err != nil {
 				log.Log.Reason(err).Error("failed to run DHCPv6")
 				panic(err)
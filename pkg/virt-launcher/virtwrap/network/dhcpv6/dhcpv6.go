@@ -20,6 +20,7 @@
 package dhcpv6
 
 import (
+	"bytes"
 	"fmt"
 	"net"
 	"time"
@@ -37,10 +38,13 @@ const (
 
 type DHCPv6Handler struct {
 	clientIP  net.IP
+	clientMAC net.HardwareAddr
 	modifiers []dhcpv6.Modifier
 }
 
-func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string) error {
+// SingleClientDHCPv6Server serves clientIP on the given interface. When clientMAC is set,
+// only requests of that client are answered.
+func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string, clientMAC net.HardwareAddr, dnsIPs []net.IP) error {
 	log.Log.Info("Starting SingleClientDHCPv6Server")
 
 	iface, err := net.InterfaceByName(serverIfaceName)
@@ -49,9 +53,13 @@ func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string) error {
 	}
 
 	modifiers := prepareDHCPv6Modifiers(clientIP, iface.HardwareAddr)
+	if len(dnsIPs) > 0 {
+		modifiers = append(modifiers, dhcpv6.WithDNS(dnsIPs...))
+	}
 
 	handler := &DHCPv6Handler{
 		clientIP:  clientIP,
+		clientMAC: clientMAC,
 		modifiers: modifiers,
 	}
 
@@ -76,7 +84,10 @@ func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string) error {
 func (h *DHCPv6Handler) ServeDHCPv6(conn net.PacketConn, peer net.Addr, m dhcpv6.DHCPv6) {
 	log.Log.V(4).Info("DHCPv6 serving a new request")
 
-	// TODO if we extend the server to support bridge binding, we need to filter out non-vm requests
+	if h.clientMAC != nil && !h.isRequestFromClient(m, peer) {
+		log.Log.V(4).Info("DHCPv6 - ignoring a request of another client")
+		return
+	}
 
 	response, err := h.buildResponse(m)
 	if err != nil {
@@ -89,6 +100,36 @@ func (h *DHCPv6Handler) ServeDHCPv6(conn net.PacketConn, peer net.Addr, m dhcpv6
 	}
 }
 
+// isRequestFromClient identifies the client by the link-layer address in its DUID,
+// or by the EUI-64 link-local address it sent the request from.
+func (h *DHCPv6Handler) isRequestFromClient(m dhcpv6.DHCPv6, peer net.Addr) bool {
+	msg, ok := m.(*dhcpv6.Message)
+	if !ok {
+		return false
+	}
+	if duid := msg.Options.ClientID(); duid != nil && (duid.Type == dhcpv6.DUID_LL || duid.Type == dhcpv6.DUID_LLT) {
+		return bytes.Equal(duid.LinkLayerAddr, h.clientMAC)
+	}
+	udpAddr, ok := peer.(*net.UDPAddr)
+	if !ok {
+		return false
+	}
+	return udpAddr.IP.Equal(eui64LinkLocalAddress(h.clientMAC))
+}
+
+func eui64LinkLocalAddress(mac net.HardwareAddr) net.IP {
+	if len(mac) != 6 {
+		return nil
+	}
+	ip := make(net.IP, net.IPv6len)
+	ip[0], ip[1] = 0xfe, 0x80
+	ip[8] = mac[0] ^ 0x02
+	ip[9], ip[10] = mac[1], mac[2]
+	ip[11], ip[12] = 0xff, 0xfe
+	ip[13], ip[14], ip[15] = mac[3], mac[4], mac[5]
+	return ip
+}
+
 func (h *DHCPv6Handler) buildResponse(msg dhcpv6.DHCPv6) (*dhcpv6.Message, error) {
 	var response *dhcpv6.Message
 	var err error
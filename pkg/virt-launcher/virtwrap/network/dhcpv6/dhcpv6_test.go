@@ -111,6 +111,32 @@ var _ = Describe("DHCPv6", func() {
 			Expect(len(replyMessage.Options.Options)).To(Equal(len(handler.modifiers) + 1))
 		})
 	})
+	Context("isRequestFromClient", func() {
+		var handler *DHCPv6Handler
+
+		BeforeEach(func() {
+			clientMac, _ := net.ParseMAC("34:56:78:9A:BC:DE")
+			handler = &DHCPv6Handler{clientIP: net.ParseIP("fd10:0:2::2"), clientMAC: clientMac}
+		})
+
+		It("should accept a request with the link-layer address of the client in the DUID", func() {
+			clientMessage, err := newMessage(dhcpv6.MessageTypeSolicit)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(handler.isRequestFromClient(clientMessage, &net.UDPAddr{IP: net.ParseIP("fe80::1")})).To(BeTrue())
+		})
+		It("should ignore a request with the link-layer address of another client in the DUID", func() {
+			handler.clientMAC, _ = net.ParseMAC("02:00:00:00:00:01")
+			clientMessage, err := newMessage(dhcpv6.MessageTypeSolicit)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(handler.isRequestFromClient(clientMessage, &net.UDPAddr{IP: net.ParseIP("fe80::1")})).To(BeFalse())
+		})
+		It("should fall back to the EUI-64 link-local address of the client", func() {
+			clientMessage, err := dhcpv6.NewMessage(dhcpv6.WithClientID(dhcpv6.Duid{Type: dhcpv6.DUID_UUID, Uuid: make([]byte, 16)}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(handler.isRequestFromClient(clientMessage, &net.UDPAddr{IP: net.ParseIP("fe80::3656:78ff:fe9a:bcde")})).To(BeTrue())
+			Expect(handler.isRequestFromClient(clientMessage, &net.UDPAddr{IP: net.ParseIP("fe80::1")})).To(BeFalse())
+		})
+	})
 })
 
 func newMessage(messageType dhcpv6.MessageType) (*dhcpv6.Message, error) {
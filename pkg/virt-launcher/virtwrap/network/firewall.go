@@ -0,0 +1,149 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	firewallTable = "kubevirt_firewall"
+	firewallChain = "forward"
+
+	// masqueraded traffic is routed between the pod interface and the bridge
+	firewallFamilyInet = "inet"
+	// bridged traffic only passes the bridge family hooks
+	firewallFamilyBridge = "bridge"
+)
+
+// conntrackBridgeModulePath only exists if the kernel tracks the connections of bridged traffic,
+// without it the established connections of bridge interfaces are never matched. Replaced in tests.
+var conntrackBridgeModulePath = "/sys/module/nf_conntrack_bridge"
+
+// createFirewallRules filters the traffic forwarded to and from the guest through
+// the given device: the bridge of masquerade interfaces or the tap device of
+// bridge interfaces. The rules of all the interfaces of the pod share one table,
+// each rule matches the device it belongs to.
+func createFirewallRules(family, device string, firewall *v1.InterfaceFirewall) error {
+	if firewall == nil {
+		return nil
+	}
+
+	if family == firewallFamilyBridge {
+		if _, err := os.Stat(conntrackBridgeModulePath); err != nil {
+			err = fmt.Errorf("the firewall of %s requires the nf_conntrack_bridge kernel module (Linux 5.3 or newer) to be loaded on the node: %v", device, err)
+			log.Log.Reason(err).Error("bridge connection tracking is not available")
+			return err
+		}
+	}
+
+	if err := Handler.NftablesNewFilterChain(family, firewallTable, firewallChain, "forward"); err != nil {
+		log.Log.Reason(err).Errorf("failed to create the firewall chain for %s", device)
+		return err
+	}
+
+	for _, rule := range composeFirewallRules(family, device, firewall) {
+		if err := Handler.NftablesAddRule(family, firewallTable, firewallChain, rule...); err != nil {
+			log.Log.Reason(err).Errorf("failed to add a firewall rule for %s", device)
+			return err
+		}
+	}
+	return nil
+}
+
+func composeFirewallRules(family, device string, firewall *v1.InterfaceFirewall) [][]string {
+	var rules [][]string
+	// ingress traffic leaves the pod towards the guest through the device
+	rules = append(rules, composeFirewallPolicyRules(family, []string{"oifname", device}, "saddr", firewall.Ingress)...)
+	rules = append(rules, composeFirewallPolicyRules(family, []string{"iifname", device}, "daddr", firewall.Egress)...)
+	return rules
+}
+
+func composeFirewallPolicyRules(family string, deviceMatch []string, peerAddr string, policy *v1.FirewallPolicy) [][]string {
+	if policy == nil || (len(policy.Rules) == 0 && policy.DefaultAction != v1.FirewallActionDeny) {
+		return nil
+	}
+
+	withDevice := func(statement ...string) []string {
+		return append(append([]string{}, deviceMatch...), statement...)
+	}
+
+	var rules [][]string
+	if family == firewallFamilyBridge {
+		// address resolution has to keep working for the allowed traffic
+		rules = append(rules, withDevice("ether", "type", "arp", "accept"))
+	}
+	rules = append(rules, withDevice("ct", "state", "established,related", "accept"))
+
+	for _, rule := range policy.Rules {
+		statement := composeFirewallMatch(rule, peerAddr)
+		statement = append(statement, "counter", firewallVerdict(rule.Action))
+		rules = append(rules, withDevice(statement...))
+	}
+
+	if policy.DefaultAction == v1.FirewallActionDeny {
+		rules = append(rules, withDevice("counter", "drop"))
+	}
+	return rules
+}
+
+func composeFirewallMatch(rule v1.FirewallRule, peerAddr string) []string {
+	var match []string
+
+	isIPv6 := false
+	if rule.CIDR != "" {
+		ip, _, _ := net.ParseCIDR(rule.CIDR)
+		isIPv6 = ip.To4() == nil
+		if isIPv6 {
+			match = append(match, "ip6", peerAddr, rule.CIDR)
+		} else {
+			match = append(match, "ip", peerAddr, rule.CIDR)
+		}
+	}
+
+	protocol := strings.ToLower(rule.Protocol)
+	switch {
+	case protocol == "icmp" && rule.CIDR == "":
+		match = append(match, "meta", "l4proto", "{ icmp, ipv6-icmp }")
+	case protocol == "icmp" && isIPv6:
+		match = append(match, "meta", "l4proto", "ipv6-icmp")
+	case protocol == "icmp":
+		match = append(match, "meta", "l4proto", "icmp")
+	case protocol != "" && rule.Port != 0:
+		match = append(match, protocol, "dport", strconv.Itoa(int(rule.Port)))
+	case protocol != "":
+		match = append(match, "meta", "l4proto", protocol)
+	}
+	return match
+}
+
+func firewallVerdict(action v1.FirewallAction) string {
+	if action == v1.FirewallActionDeny {
+		return "drop"
+	}
+	return "accept"
+}
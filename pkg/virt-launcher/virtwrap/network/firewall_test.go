@@ -0,0 +1,142 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/cache/fake"
+)
+
+var _ = Describe("Interface firewall", func() {
+	var ctrl *gomock.Controller
+	var mockNetwork *MockNetworkHandler
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockNetwork = NewMockNetworkHandler(ctrl)
+		Handler = mockNetwork
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should not touch nftables without firewall", func() {
+		Expect(createFirewallRules(firewallFamilyInet, "k6t-eth0", nil)).To(Succeed())
+	})
+
+	It("should add the rules of both directions to the forward chain", func() {
+		firewall := &v1.InterfaceFirewall{
+			Ingress: &v1.FirewallPolicy{
+				Rules:         []v1.FirewallRule{{Action: v1.FirewallActionAllow, Protocol: "TCP", Port: 22}},
+				DefaultAction: v1.FirewallActionDeny,
+			},
+			Egress: &v1.FirewallPolicy{
+				Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, CIDR: "10.10.0.0/16"}},
+			},
+		}
+
+		gomock.InOrder(
+			mockNetwork.EXPECT().NftablesNewFilterChain("inet", "kubevirt_firewall", "forward", "forward").Return(nil),
+			mockNetwork.EXPECT().NftablesAddRule("inet", "kubevirt_firewall", "forward", "oifname", "k6t-eth0", "ct", "state", "established,related", "accept").Return(nil),
+			mockNetwork.EXPECT().NftablesAddRule("inet", "kubevirt_firewall", "forward", "oifname", "k6t-eth0", "tcp", "dport", "22", "counter", "accept").Return(nil),
+			mockNetwork.EXPECT().NftablesAddRule("inet", "kubevirt_firewall", "forward", "oifname", "k6t-eth0", "counter", "drop").Return(nil),
+			mockNetwork.EXPECT().NftablesAddRule("inet", "kubevirt_firewall", "forward", "iifname", "k6t-eth0", "ct", "state", "established,related", "accept").Return(nil),
+			mockNetwork.EXPECT().NftablesAddRule("inet", "kubevirt_firewall", "forward", "iifname", "k6t-eth0", "ip", "daddr", "10.10.0.0/16", "counter", "drop").Return(nil),
+		)
+
+		Expect(createFirewallRules(firewallFamilyInet, "k6t-eth0", firewall)).To(Succeed())
+	})
+
+	It("should refuse to filter bridged interfaces without bridge connection tracking", func() {
+		conntrackBridgeModulePath = "/nonexistent/nf_conntrack_bridge"
+		defer func() { conntrackBridgeModulePath = "/sys/module/nf_conntrack_bridge" }()
+
+		firewall := &v1.InterfaceFirewall{Egress: &v1.FirewallPolicy{DefaultAction: v1.FirewallActionDeny}}
+		err := createFirewallRules(firewallFamilyBridge, "tap0", firewall)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("nf_conntrack_bridge"))
+	})
+
+	It("should filter bridged interfaces with bridge connection tracking", func() {
+		moduleDir, err := ioutil.TempDir("", "nf_conntrack_bridge")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(moduleDir)
+		conntrackBridgeModulePath = moduleDir
+		defer func() { conntrackBridgeModulePath = "/sys/module/nf_conntrack_bridge" }()
+
+		gomock.InOrder(
+			mockNetwork.EXPECT().NftablesNewFilterChain("bridge", "kubevirt_firewall", "forward", "forward").Return(nil),
+			mockNetwork.EXPECT().NftablesAddRule("bridge", "kubevirt_firewall", "forward", "iifname", "tap0", "ether", "type", "arp", "accept").Return(nil),
+			mockNetwork.EXPECT().NftablesAddRule("bridge", "kubevirt_firewall", "forward", "iifname", "tap0", "ct", "state", "established,related", "accept").Return(nil),
+			mockNetwork.EXPECT().NftablesAddRule("bridge", "kubevirt_firewall", "forward", "iifname", "tap0", "counter", "drop").Return(nil),
+		)
+
+		firewall := &v1.InterfaceFirewall{Egress: &v1.FirewallPolicy{DefaultAction: v1.FirewallActionDeny}}
+		Expect(createFirewallRules(firewallFamilyBridge, "tap0", firewall)).To(Succeed())
+	})
+
+	It("should keep address resolution working on bridged interfaces", func() {
+		policy := &v1.FirewallPolicy{DefaultAction: v1.FirewallActionDeny}
+		rules := composeFirewallRules(firewallFamilyBridge, "tap0", &v1.InterfaceFirewall{Egress: policy})
+		Expect(rules).To(Equal([][]string{
+			{"iifname", "tap0", "ether", "type", "arp", "accept"},
+			{"iifname", "tap0", "ct", "state", "established,related", "accept"},
+			{"iifname", "tap0", "counter", "drop"},
+		}))
+	})
+
+	It("should skip a direction which allows everything", func() {
+		rules := composeFirewallRules(firewallFamilyInet, "k6t-eth0", &v1.InterfaceFirewall{Ingress: &v1.FirewallPolicy{}})
+		Expect(rules).To(BeEmpty())
+	})
+
+	table.DescribeTable("should match", func(rule v1.FirewallRule, peerAddr string, expected []string) {
+		Expect(composeFirewallMatch(rule, peerAddr)).To(Equal(expected))
+	},
+		table.Entry("any traffic", v1.FirewallRule{}, "saddr", nil),
+		table.Entry("an IPv4 source", v1.FirewallRule{CIDR: "10.10.0.0/16"}, "saddr", []string{"ip", "saddr", "10.10.0.0/16"}),
+		table.Entry("an IPv6 destination", v1.FirewallRule{CIDR: "fd10::/64"}, "daddr", []string{"ip6", "daddr", "fd10::/64"}),
+		table.Entry("a protocol", v1.FirewallRule{Protocol: "UDP"}, "saddr", []string{"meta", "l4proto", "udp"}),
+		table.Entry("a port", v1.FirewallRule{Protocol: "UDP", Port: 53}, "saddr", []string{"udp", "dport", "53"}),
+		table.Entry("ICMP of both families", v1.FirewallRule{Protocol: "ICMP"}, "saddr", []string{"meta", "l4proto", "{ icmp, ipv6-icmp }"}),
+		table.Entry("ICMPv6 of an IPv6 source", v1.FirewallRule{CIDR: "fd10::/64", Protocol: "ICMP"}, "saddr", []string{"ip6", "saddr", "fd10::/64", "meta", "l4proto", "ipv6-icmp"}),
+	)
+
+	It("should record the enforced firewall in the pod interface cache", func() {
+		cacheFactory := fake.NewFakeInMemoryNetworkCacheFactory()
+		vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+		iface := &vmi.Spec.Domain.Devices.Interfaces[0]
+		iface.Firewall = &v1.InterfaceFirewall{Egress: &v1.FirewallPolicy{DefaultAction: v1.FirewallActionDeny}}
+
+		Expect(setPodInterfaceFirewallCache(iface, vmi, cacheFactory)).To(Succeed())
+		cached, err := cacheFactory.CacheForVMI(vmi).Read(iface.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cached.Firewall).To(Equal(iface.Firewall))
+	})
+})
@@ -111,3 +111,44 @@ func (_m *MockBindMechanism) startDHCP(vmi *v1.VirtualMachineInstance) error {
 func (_mr *_MockBindMechanismRecorder) startDHCP(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "startDHCP", arg0)
 }
+
+// Mock of bindingPluginHooks interface
+type MockbindingPluginHooks struct {
+	ctrl     *gomock.Controller
+	recorder *_MockbindingPluginHooksRecorder
+}
+
+// Recorder for MockbindingPluginHooks (not exported)
+type _MockbindingPluginHooksRecorder struct {
+	mock *MockbindingPluginHooks
+}
+
+func NewMockbindingPluginHooks(ctrl *gomock.Controller) *MockbindingPluginHooks {
+	mock := &MockbindingPluginHooks{ctrl: ctrl}
+	mock.recorder = &_MockbindingPluginHooksRecorder{mock}
+	return mock
+}
+
+func (_m *MockbindingPluginHooks) EXPECT() *_MockbindingPluginHooksRecorder {
+	return _m.recorder
+}
+
+func (_m *MockbindingPluginHooks) PlugPhase1(vmi *v1.VirtualMachineInstance, pluginName string, interfaceName string, podInterfaceName string, tapDevice string) error {
+	ret := _m.ctrl.Call(_m, "PlugPhase1", vmi, pluginName, interfaceName, podInterfaceName, tapDevice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockbindingPluginHooksRecorder) PlugPhase1(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "PlugPhase1", arg0, arg1, arg2, arg3, arg4)
+}
+
+func (_m *MockbindingPluginHooks) PlugPhase2(vmi *v1.VirtualMachineInstance, pluginName string, interfaceName string, podInterfaceName string, tapDevice string) error {
+	ret := _m.ctrl.Call(_m, "PlugPhase2", vmi, pluginName, interfaceName, podInterfaceName, tapDevice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockbindingPluginHooksRecorder) PlugPhase2(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "PlugPhase2", arg0, arg1, arg2, arg3, arg4)
+}
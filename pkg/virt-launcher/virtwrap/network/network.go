@@ -29,6 +29,7 @@ import (
 	"fmt"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util/net/namescheme"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/cache"
 )
@@ -97,6 +98,56 @@ func SetupPodNetworkPhase2(vmi *v1.VirtualMachineInstance, domain *api.Domain, c
 	return nil
 }
 
+// SetupPodNetworkHotplugPhase1 runs phase1 for interfaces hotplugged into a
+// running VMI. Their pod interfaces were created by Multus after the pod
+// started and are therefore named after the network they connect to.
+func SetupPodNetworkHotplugPhase1(vmi *v1.VirtualMachineInstance, ifaces []v1.Interface, pid int, cacheFactory cache.InterfaceCacheFactory) error {
+	networks := mapNetworksByName(vmi.Spec.Networks)
+	for i, iface := range ifaces {
+		network, ok := networks[iface.Name]
+		if !ok {
+			return fmt.Errorf("failed to find a network %s", iface.Name)
+		}
+		podnic := podNICFactory(cacheFactory)
+		err := podNIC.PlugPhase1(podnic, vmi, &ifaces[i], &network, namescheme.HotplugPodInterfaceName(network.Name), pid)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetupPodNetworkHotplugPhase2 runs phase2 for interfaces hotplugged into a
+// running VMI, decorating their definitions in the given domain. Interfaces for
+// which virt-handler did not complete phase1 yet are skipped. The names of the
+// interfaces ready to be attached to the domain are returned.
+func SetupPodNetworkHotplugPhase2(vmi *v1.VirtualMachineInstance, ifaces []v1.Interface, domain *api.Domain, cacheFactory cache.InterfaceCacheFactory) ([]string, error) {
+	networks := mapNetworksByName(vmi.Spec.Networks)
+	var plugged []string
+	for i, iface := range ifaces {
+		network, ok := networks[iface.Name]
+		if !ok {
+			return nil, fmt.Errorf("failed to find a network %s", iface.Name)
+		}
+		if _, err := cacheFactory.CacheForPID("self").Read(iface.Name); err != nil {
+			continue
+		}
+		podnic := podNICFactory(cacheFactory)
+		err := podNIC.PlugPhase2(podnic, vmi, &ifaces[i], &network, domain, namescheme.HotplugPodInterfaceName(network.Name))
+		if err != nil {
+			return nil, err
+		}
+		plugged = append(plugged, iface.Name)
+	}
+	return plugged, nil
+}
+
+// IsHotpluggableInterface reports whether an interface can be plugged into or
+// unplugged from a running VMI.
+func IsHotpluggableInterface(iface v1.Interface, network v1.Network) bool {
+	return isSecondaryMultusNetwork(network) && iface.Bridge != nil
+}
+
 func mapNetworksByName(nets []v1.Network) map[string]v1.Network {
 	networks := map[string]v1.Network{}
 	for _, net := range nets {
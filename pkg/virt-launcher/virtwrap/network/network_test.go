@@ -26,6 +26,8 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"kubevirt.io/kubevirt/pkg/util/net/namescheme"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/cache"
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/cache/fake"
@@ -144,4 +146,48 @@ var _ = Describe("Network", func() {
 			Expect(err).To(BeNil())
 		})
 	})
+
+	Context("interface hotplug", func() {
+		var vmi *v1.VirtualMachineInstance
+		var hotplugNet v1.Network
+		var hotplugIfaces []v1.Interface
+
+		BeforeEach(func() {
+			podNICFactory = func(cacheFactory cache.InterfaceCacheFactory) podNIC {
+				return mockpodNIC
+			}
+			vmi = newVMIBridgeInterface("testnamespace", "testVmName")
+			hotplugNet = v1.Network{
+				Name:          "blue",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}},
+			}
+			hotplugIfaces = []v1.Interface{{
+				Name:                   "blue",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+			}}
+			vmi.Spec.Networks = append(vmi.Spec.Networks, hotplugNet)
+			vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces, hotplugIfaces...)
+		})
+
+		It("should run phase1 only for the hotplugged interfaces using their hotplug pod interface name", func() {
+			mockpodNIC.EXPECT().PlugPhase1(vmi, &hotplugIfaces[0], &hotplugNet, namescheme.HotplugPodInterfaceName("blue"), pid)
+			Expect(SetupPodNetworkHotplugPhase1(vmi, hotplugIfaces, pid, cacheFactory)).To(Succeed())
+		})
+
+		It("should skip phase2 for hotplugged interfaces without completed phase1", func() {
+			domain := &api.Domain{}
+			plugged, err := SetupPodNetworkHotplugPhase2(vmi, hotplugIfaces, domain, cacheFactory)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plugged).To(BeEmpty())
+		})
+
+		It("should run phase2 for hotplugged interfaces with completed phase1", func() {
+			domain := &api.Domain{}
+			Expect(cacheFactory.CacheForPID("self").Write("blue", &api.Interface{})).To(Succeed())
+			mockpodNIC.EXPECT().PlugPhase2(vmi, &hotplugIfaces[0], &hotplugNet, domain, namescheme.HotplugPodInterfaceName("blue"))
+			plugged, err := SetupPodNetworkHotplugPhase2(vmi, hotplugIfaces, domain, cacheFactory)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plugged).To(ConsistOf("blue"))
+		})
+	})
 })
@@ -38,6 +38,8 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/client-go/precond"
+	"kubevirt.io/kubevirt/pkg/hooks"
+	"kubevirt.io/kubevirt/pkg/util/net/vhostuser"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/cache"
@@ -122,6 +124,18 @@ func setPodInterfaceCache(iface *v1.Interface, podInterfaceName string, vmi *v1.
 	return nil
 }
 
+// setPodInterfaceFirewallCache records the firewall rules enforced for the interface,
+// so that virt-handler can report them in the VMI status
+func setPodInterfaceFirewallCache(iface *v1.Interface, vmi *v1.VirtualMachineInstance, cacheFactory cache.InterfaceCacheFactory) error {
+	store := cacheFactory.CacheForVMI(vmi)
+	ifCache, err := store.Read(iface.Name)
+	if err != nil {
+		ifCache = &cache.PodCacheInterface{Iface: iface}
+	}
+	ifCache.Firewall = iface.Firewall
+	return store.Write(iface.Name, ifCache)
+}
+
 func readIPAddressesFromLink(podInterfaceName string) (string, string, error) {
 	link, err := Handler.LinkByName(podInterfaceName)
 	if err != nil {
@@ -223,6 +237,13 @@ func (l *podNICImpl) PlugPhase1(vmi *v1.VirtualMachineInstance, iface *v1.Interf
 			log.Log.Reason(err).Error("failed to save vif configuration")
 			return createCriticalNetworkError(err)
 		}
+
+		if iface.Firewall != nil {
+			if err := setPodInterfaceFirewallCache(iface, vmi, l.cacheFactory); err != nil {
+				log.Log.Reason(err).Error("failed to save the firewall configuration")
+				return createCriticalNetworkError(err)
+			}
+		}
 	}
 
 	return nil
@@ -319,6 +340,12 @@ func getPhase2Binding(vmi *v1.VirtualMachineInstance, iface *v1.Interface, netwo
 		if err != nil {
 			return nil, err
 		}
+		if mac == nil {
+			mac, err = retrieveMigratedMacAddress(vmi, iface)
+			if err != nil {
+				return nil, err
+			}
+		}
 		vif := &VIF{Name: podInterfaceName}
 		if mac != nil {
 			vif.MAC = *mac
@@ -359,6 +386,41 @@ func getPhase2Binding(vmi *v1.VirtualMachineInstance, iface *v1.Interface, netwo
 	if iface.Slirp != nil {
 		return &SlirpBindMechanism{vmi: vmi, iface: iface, domain: domain}, nil
 	}
+	if iface.VhostUser != nil {
+		return &VhostUserBindMechanism{
+			iface:             iface,
+			domain:            domain,
+			podInterfaceName:  podInterfaceName,
+			networkStatusPath: vhostuser.NetworkStatusPath(),
+		}, nil
+	}
+	if iface.Binding != nil {
+		mac, err := retrieveMacAddress(iface)
+		if err != nil {
+			return nil, err
+		}
+		virtIface := &api.Interface{}
+		if mac != nil {
+			virtIface.MAC = &api.MAC{MAC: mac.String()}
+		}
+		return &PluginBindMechanism{
+			vmi:              vmi,
+			iface:            iface,
+			virtIface:        virtIface,
+			domain:           domain,
+			podInterfaceName: podInterfaceName,
+			storeFactory:     storeFactory,
+			launcherHooks: func(launcherPID int) (bindingPluginHooks, error) {
+				return hooks.NewManagerForPID(launcherPID)
+			},
+			hooks: func() bindingPluginHooks {
+				return hooks.GetManager()
+			},
+			domainAttachment: func(launcherPID int) (string, error) {
+				return lookupLauncherEnv(launcherPID, fmt.Sprintf("KUBEVIRT_DOMAIN_ATTACHMENT_%s", iface.Name))
+			},
+		}, nil
+	}
 	if iface.Macvtap != nil {
 		mac, err := retrieveMacAddress(iface)
 		if err != nil {
@@ -380,6 +442,26 @@ func getPhase2Binding(vmi *v1.VirtualMachineInstance, iface *v1.Interface, netwo
 	return nil, fmt.Errorf("Not implemented")
 }
 
+// retrieveMigratedMacAddress returns the MAC address the guest reported for the
+// interface when the VMI is being migrated. Without a MAC address in the spec the
+// bridge binding hands out the one of the pod interface, which differs on the
+// target pod, while the migrated guest keeps using the one it was started with.
+func retrieveMigratedMacAddress(vmi *v1.VirtualMachineInstance, iface *v1.Interface) (*net.HardwareAddr, error) {
+	if vmi.Status.MigrationState == nil {
+		return nil, nil
+	}
+	for _, ifaceStatus := range vmi.Status.Interfaces {
+		if ifaceStatus.Name == iface.Name && ifaceStatus.MAC != "" {
+			macAddress, err := net.ParseMAC(ifaceStatus.MAC)
+			if err != nil {
+				return nil, err
+			}
+			return &macAddress, nil
+		}
+	}
+	return nil, nil
+}
+
 type BridgeBindMechanism struct {
 	vmi                 *v1.VirtualMachineInstance
 	vif                 *VIF
@@ -407,7 +489,14 @@ func (b *BridgeBindMechanism) discoverPodNetworkInterface() error {
 		log.Log.Reason(err).Errorf("failed to get an ip address for %s", b.podInterfaceName)
 		return err
 	}
-	if len(addrList) == 0 {
+	if b.iface.IPAM != nil {
+		if len(addrList) > 0 {
+			return fmt.Errorf("interface %s has a static IPAM configuration, but its pod interface %s already has an address", b.iface.Name, b.podInterfaceName)
+		}
+		if err := setStaticIPAM(b.vif, b.iface.IPAM); err != nil {
+			return err
+		}
+	} else if len(addrList) == 0 {
 		b.vif.IPAMDisabled = true
 	} else {
 		b.vif.IP = addrList[0]
@@ -431,7 +520,7 @@ func (b *BridgeBindMechanism) discoverPodNetworkInterface() error {
 	// Get interface MTU
 	b.vif.Mtu = uint16(b.podNicLink.Attrs().MTU)
 
-	if !b.vif.IPAMDisabled {
+	if !b.vif.IPAMDisabled && !b.vif.StaticIPAM {
 		// Handle interface routes
 		if err := b.setInterfaceRoutes(); err != nil {
 			return err
@@ -440,6 +529,74 @@ func (b *BridgeBindMechanism) discoverPodNetworkInterface() error {
 	return nil
 }
 
+// setStaticIPAM fills the vif with the addresses, routes and DNS configuration of the interface spec
+func setStaticIPAM(vif *VIF, ipam *v1.InterfaceIPAM) error {
+	for _, address := range ipam.Addresses {
+		addr, err := netlink.ParseAddr(address)
+		if err != nil {
+			return fmt.Errorf("failed to parse static address %s: %v", address, err)
+		}
+		if addr.IP.To4() != nil {
+			vif.IP = *addr
+		} else {
+			vif.IPv6 = *addr
+		}
+	}
+
+	if ipam.Gateway != "" {
+		vif.Gateway = net.ParseIP(ipam.Gateway).To4()
+		if vif.Gateway == nil {
+			return fmt.Errorf("failed to parse static IPv4 gateway %s", ipam.Gateway)
+		}
+	}
+	if ipam.GatewayIPv6 != "" {
+		vif.GatewayIpv6 = net.ParseIP(ipam.GatewayIPv6)
+		if vif.GatewayIpv6 == nil {
+			return fmt.Errorf("failed to parse static IPv6 gateway %s", ipam.GatewayIPv6)
+		}
+	}
+
+	// DHCP clients ignore the router option once classless routes are handed out,
+	// so the default route has to be part of them
+	var routes []netlink.Route
+	for _, route := range ipam.Routes {
+		_, dst, err := net.ParseCIDR(route.Destination)
+		if err != nil {
+			return fmt.Errorf("failed to parse static route destination %s: %v", route.Destination, err)
+		}
+		gw := net.ParseIP(route.Gateway)
+		if gw == nil {
+			return fmt.Errorf("failed to parse static route gateway %s", route.Gateway)
+		}
+		// DHCPv4 can only announce IPv4 routes
+		if dst.IP.To4() == nil {
+			continue
+		}
+		routes = append(routes, netlink.Route{Dst: dst, Gw: gw.To4()})
+	}
+	if len(routes) > 0 {
+		if vif.Gateway != nil {
+			routes = append(routes, netlink.Route{Gw: vif.Gateway})
+		}
+		vif.Routes = &routes
+	}
+
+	if ipam.DNS != nil {
+		for _, nameserver := range ipam.DNS.Nameservers {
+			ip := net.ParseIP(nameserver)
+			if ip == nil {
+				return fmt.Errorf("failed to parse static nameserver %s", nameserver)
+			}
+			vif.Nameservers = append(vif.Nameservers, ip)
+		}
+		vif.SearchDomains = ipam.DNS.Search
+	}
+
+	vif.IPAMDisabled = false
+	vif.StaticIPAM = true
+	return nil
+}
+
 func (b *BridgeBindMechanism) getFakeBridgeIP() (string, error) {
 	ifaces := b.vmi.Spec.Domain.Devices.Interfaces
 	for i, iface := range ifaces {
@@ -475,7 +632,7 @@ func (b *BridgeBindMechanism) preparePodNetworkInterfaces(queueNumber uint32, la
 
 	tapDeviceName := generateTapDeviceName(b.podInterfaceName)
 
-	if !b.vif.IPAMDisabled {
+	if !b.vif.IPAMDisabled && !b.vif.StaticIPAM {
 		// Remove IP from POD interface
 		err := Handler.AddrDel(b.podNicLink, &b.vif.IP)
 
@@ -504,6 +661,10 @@ func (b *BridgeBindMechanism) preparePodNetworkInterfaces(queueNumber uint32, la
 		return err
 	}
 
+	if err := createFirewallRules(firewallFamilyBridge, tapDeviceName, b.iface.Firewall); err != nil {
+		return err
+	}
+
 	if b.arpIgnore {
 		if err := Handler.ConfigureIpv4ArpIgnore(); err != nil {
 			log.Log.Reason(err).Errorf("failed to set arp_ignore=1 on interface %s", b.bridgeInterfaceName)
@@ -851,6 +1012,10 @@ func (b *MasqueradeBindMechanism) preparePodNetworkInterfaces(queueNumber uint32
 		}
 	}
 
+	if err := createFirewallRules(firewallFamilyInet, b.bridgeInterfaceName, b.iface.Firewall); err != nil {
+		return err
+	}
+
 	b.virtIface.MTU = &api.MTU{Size: strconv.Itoa(b.podNicLink.Attrs().MTU)}
 	if b.vif.MAC != nil {
 		b.virtIface.MAC = &api.MAC{MAC: b.vif.MAC.String()}
@@ -1231,6 +1396,63 @@ func (s *SlirpBindMechanism) setCachedInterface(pid, name string) error {
 	return nil
 }
 
+// VhostUserBindMechanism connects the guest to the vhost-user socket of a userspace
+// switch. The pod network is not touched, the socket is found through the network
+// status Multus reported for the pod interface.
+type VhostUserBindMechanism struct {
+	iface             *v1.Interface
+	domain            *api.Domain
+	podInterfaceName  string
+	networkStatusPath string
+}
+
+func (v *VhostUserBindMechanism) discoverPodNetworkInterface() error {
+	return nil
+}
+
+func (v *VhostUserBindMechanism) preparePodNetworkInterfaces(queueNumber uint32, launcherPID int) error {
+	return nil
+}
+
+func (v *VhostUserBindMechanism) startDHCP(vmi *v1.VirtualMachineInstance) error {
+	return nil
+}
+
+func (v *VhostUserBindMechanism) decorateConfig() error {
+	socket, err := vhostuser.LookupSocket(v.networkStatusPath, v.podInterfaceName)
+	if err != nil {
+		return err
+	}
+	ifaces := v.domain.Spec.Devices.Interfaces
+	for i, iface := range ifaces {
+		if iface.Alias.GetName() == v.iface.Name {
+			ifaces[i].Source = api.InterfaceSource{Type: "unix", Path: socket.Path, Mode: socket.Mode}
+			if v.iface.MacAddress != "" {
+				// We assume address was already validated in API layer so just pass it to libvirt as-is.
+				ifaces[i].MAC = &api.MAC{MAC: v.iface.MacAddress}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to find interface %s in vmi spec", v.iface.Name)
+}
+
+func (v *VhostUserBindMechanism) loadCachedInterface(pid, name string) (bool, error) {
+	return true, nil
+}
+
+func (v *VhostUserBindMechanism) loadCachedVIF(pid, name string) (bool, error) {
+	return true, nil
+}
+
+func (v *VhostUserBindMechanism) setCachedVIF(pid, name string) error {
+	return nil
+}
+
+func (v *VhostUserBindMechanism) setCachedInterface(pid, name string) error {
+	return nil
+}
+
 type MacvtapBindMechanism struct {
 	vmi              *v1.VirtualMachineInstance
 	iface            *v1.Interface
@@ -1317,6 +1539,134 @@ func (b *MacvtapBindMechanism) startDHCP(vmi *v1.VirtualMachineInstance) error {
 	return nil
 }
 
+// bindingPluginHooks reaches the sidecars of the network binding plugins
+type bindingPluginHooks interface {
+	PlugPhase1(vmi *v1.VirtualMachineInstance, pluginName string, interfaceName string, podInterfaceName string, tapDevice string) error
+	PlugPhase2(vmi *v1.VirtualMachineInstance, pluginName string, interfaceName string, podInterfaceName string, tapDevice string) error
+}
+
+// PluginBindMechanism delegates the connection of an interface to a network binding plugin.
+// Both phases call the PlugPhase1 and PlugPhase2 hook points of the plugin sidecar, which owns
+// the pod network plumbing. If the domain interface is attached as a tap device, KubeVirt creates
+// the tap device in phase1 and passes its name to the plugin, which connects it to the pod network.
+type PluginBindMechanism struct {
+	vmi              *v1.VirtualMachineInstance
+	iface            *v1.Interface
+	virtIface        *api.Interface
+	domain           *api.Domain
+	podInterfaceName string
+	storeFactory     cache.InterfaceCacheFactory
+	// launcherHooks returns the plugin hooks of the given virt-launcher in virt-handler, hooks the ones of virt-launcher itself
+	launcherHooks    func(launcherPID int) (bindingPluginHooks, error)
+	hooks            func() bindingPluginHooks
+	domainAttachment func(launcherPID int) (string, error)
+}
+
+func (b *PluginBindMechanism) discoverPodNetworkInterface() error {
+	return nil
+}
+
+func (b *PluginBindMechanism) preparePodNetworkInterfaces(queueNumber uint32, launcherPID int) error {
+	attachment, err := b.domainAttachment(launcherPID)
+	if err != nil {
+		return err
+	}
+	if attachment == string(v1.Tap) {
+		link, err := Handler.LinkByName(b.podInterfaceName)
+		if err != nil {
+			log.Log.Reason(err).Errorf("failed to get a link for interface: %s", b.podInterfaceName)
+			return err
+		}
+		tapDeviceName := generateTapDeviceName(b.podInterfaceName)
+		if err := Handler.CreateTapDevice(tapDeviceName, queueNumber, launcherPID, link.Attrs().MTU, "0"); err != nil {
+			log.Log.Reason(err).Errorf("failed to create tap device named %s", tapDeviceName)
+			return err
+		}
+		b.virtIface.Target = &api.InterfaceTarget{
+			Device:  tapDeviceName,
+			Managed: "no",
+		}
+	}
+
+	pluginHooks, err := b.launcherHooks(launcherPID)
+	if err != nil {
+		return fmt.Errorf("failed to reach the network binding plugin of interface %s: %v", b.iface.Name, err)
+	}
+	if err := pluginHooks.PlugPhase1(b.vmi, b.iface.Binding.Name, b.iface.Name, b.podInterfaceName, b.tapDeviceName()); err != nil {
+		return fmt.Errorf("network binding plugin failed phase1 of interface %s: %v", b.iface.Name, err)
+	}
+	return nil
+}
+
+func (b *PluginBindMechanism) tapDeviceName() string {
+	if b.virtIface.Target == nil {
+		return ""
+	}
+	return b.virtIface.Target.Device
+}
+
+func (b *PluginBindMechanism) decorateConfig() error {
+	if err := b.hooks().PlugPhase2(b.vmi, b.iface.Binding.Name, b.iface.Name, b.podInterfaceName, b.tapDeviceName()); err != nil {
+		return fmt.Errorf("network binding plugin failed phase2 of interface %s: %v", b.iface.Name, err)
+	}
+
+	// the domain interface only exists if the plugin requested a domain attachment
+	ifaces := b.domain.Spec.Devices.Interfaces
+	for i, iface := range ifaces {
+		if iface.Alias.GetName() == b.iface.Name {
+			ifaces[i].MAC = b.virtIface.MAC
+			ifaces[i].Target = b.virtIface.Target
+			break
+		}
+	}
+	return nil
+}
+
+func (b *PluginBindMechanism) loadCachedInterface(pid, name string) (bool, error) {
+	ifaceConfig, err := b.storeFactory.CacheForPID(pid).Read(name)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	b.virtIface = ifaceConfig
+	return true, nil
+}
+
+func (b *PluginBindMechanism) setCachedInterface(pid, name string) error {
+	return b.storeFactory.CacheForPID(pid).Write(name, b.virtIface)
+}
+
+func (b *PluginBindMechanism) loadCachedVIF(pid, name string) (bool, error) {
+	return true, nil
+}
+
+func (b *PluginBindMechanism) setCachedVIF(pid, name string) error {
+	return nil
+}
+
+func (b *PluginBindMechanism) startDHCP(vmi *v1.VirtualMachineInstance) error {
+	// the plugin is responsible for the address configuration of the guest
+	return nil
+}
+
+// lookupLauncherEnv returns the value of an environment variable of the virt-launcher process with the given pid
+func lookupLauncherEnv(launcherPID int, name string) (string, error) {
+	environ, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/environ", launcherPID))
+	if err != nil {
+		return "", err
+	}
+	for _, env := range strings.Split(string(environ), "\x00") {
+		if strings.HasPrefix(env, name+"=") {
+			return strings.TrimPrefix(env, name+"="), nil
+		}
+	}
+	return "", nil
+}
+
 func createAndBindTapToBridge(deviceName string, bridgeIfaceName string, queueNumber uint32, launcherPID int, mtu int, tapOwner string) error {
 	err := Handler.CreateTapDevice(deviceName, queueNumber, launcherPID, mtu, tapOwner)
 	if err != nil {
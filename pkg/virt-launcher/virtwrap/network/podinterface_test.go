@@ -379,6 +379,43 @@ var _ = Describe("Pod Network", func() {
 				Expect(filterPodNetworkRoutes(staticRouteList, testNic)).To(Equal(expectedRouteList))
 			})
 		})
+		Context("func setStaticIPAM()", func() {
+			It("should configure the vif from the interface spec", func() {
+				vif := &VIF{Name: "blue"}
+				err := setStaticIPAM(vif, &v1.InterfaceIPAM{
+					Addresses:   []string{"192.168.100.10/24", "fd10:100::10/64"},
+					Gateway:     "192.168.100.1",
+					GatewayIPv6: "fd10:100::1",
+					Routes: []v1.InterfaceRoute{
+						{Destination: "10.10.0.0/16", Gateway: "192.168.100.254"},
+						{Destination: "fd20::/64", Gateway: "fd10:100::254"},
+					},
+					DNS: &v1.InterfaceDNS{Nameservers: []string{"192.168.100.2", "fd10:100::2"}, Search: []string{"example.com"}},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vif.StaticIPAM).To(BeTrue())
+				Expect(vif.IPAMDisabled).To(BeFalse())
+				Expect(vif.IP.String()).To(Equal("192.168.100.10/24"))
+				Expect(vif.IPv6.String()).To(Equal("fd10:100::10/64"))
+				Expect(vif.Gateway).To(Equal(net.IPv4(192, 168, 100, 1).To4()))
+				Expect(vif.GatewayIpv6).To(Equal(net.ParseIP("fd10:100::1")))
+				Expect(*vif.Routes).To(Equal([]netlink.Route{
+					{Dst: &net.IPNet{IP: net.IPv4(10, 10, 0, 0).To4(), Mask: net.CIDRMask(16, 32)}, Gw: net.IPv4(192, 168, 100, 254).To4()},
+					{Gw: net.IPv4(192, 168, 100, 1).To4()},
+				}))
+				Expect(vif.Nameservers).To(Equal([]net.IP{net.ParseIP("192.168.100.2"), net.ParseIP("fd10:100::2")}))
+				Expect(vif.SearchDomains).To(Equal([]string{"example.com"}))
+			})
+			It("should not hand out routes without static routes", func() {
+				vif := &VIF{Name: "blue"}
+				Expect(setStaticIPAM(vif, &v1.InterfaceIPAM{Addresses: []string{"192.168.100.10/24"}, Gateway: "192.168.100.1"})).To(Succeed())
+				Expect(vif.Routes).To(BeNil())
+				Expect(vif.IPv6.IPNet).To(BeNil())
+			})
+			It("should fail on a malformed address", func() {
+				Expect(setStaticIPAM(&VIF{}, &v1.InterfaceIPAM{Addresses: []string{"192.168.100.10"}})).ToNot(Succeed())
+			})
+		})
 		It("phase2 should panic if DHCP startup fails", func() {
 			testDhcpPanic := func() {
 				domain := NewDomainWithBridgeInterface()
@@ -400,6 +437,29 @@ var _ = Describe("Pod Network", func() {
 					Expect(ok).To(BeTrue())
 					Expect(bridge.vif.MAC.String()).To(Equal("de:ad:00:00:be:af"))
 				})
+				It("should keep the MAC address of the guest on a migration target", func() {
+					vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+					vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{}
+					vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+						{Name: vmi.Spec.Domain.Devices.Interfaces[0].Name, MAC: "de:ad:00:00:be:af"},
+					}
+					driver, err := getPhase1Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], primaryPodInterfaceName, cacheFactory)
+					Expect(err).ToNot(HaveOccurred())
+					bridge, ok := driver.(*BridgeBindMechanism)
+					Expect(ok).To(BeTrue())
+					Expect(bridge.vif.MAC.String()).To(Equal("de:ad:00:00:be:af"))
+				})
+				It("should not use the reported MAC address outside of a migration", func() {
+					vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+					vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+						{Name: vmi.Spec.Domain.Devices.Interfaces[0].Name, MAC: "de:ad:00:00:be:af"},
+					}
+					driver, err := getPhase1Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], primaryPodInterfaceName, cacheFactory)
+					Expect(err).ToNot(HaveOccurred())
+					bridge, ok := driver.(*BridgeBindMechanism)
+					Expect(ok).To(BeTrue())
+					Expect(bridge.vif.MAC).To(BeEmpty())
+				})
 			})
 		})
 		Context("SRIOV Plug", func() {
@@ -607,6 +667,125 @@ var _ = Describe("Pod Network", func() {
 				Expect(domain.Spec.Devices.Interfaces[0].MTU).To(Equal(&api.MTU{Size: "1410"}), "should have the expected MTU")
 			})
 		})
+		Context("vhost-user plug", func() {
+			var networkStatusDir string
+			var networkStatusPath string
+
+			BeforeEach(func() {
+				var err error
+				networkStatusDir, err = ioutil.TempDir("", "network-status")
+				Expect(err).ToNot(HaveOccurred())
+				networkStatusPath = networkStatusDir + "/network-status"
+				Expect(ioutil.WriteFile(networkStatusPath, []byte(`[{"name": "default/ovs-dpdk", "interface": "net1", "device-info": {"type": "vhost-user", "version": "1.0.0", "vhost-user": {"mode": "server", "path": "/var/lib/cni/usrspcni/net1"}}}]`), 0644)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(networkStatusDir)
+			})
+
+			It("Should connect the domain interface to the socket of the pod interface", func() {
+				domain := &api.Domain{}
+				domain.Spec.Devices.Interfaces = []api.Interface{{
+					Type:   "vhostuser",
+					Source: api.InterfaceSource{Type: "unix"},
+					Model:  &api.Model{Type: "virtio"},
+					Alias:  api.NewUserDefinedAlias("dpdk"),
+				}}
+				vmi := newVMI("testnamespace", "testVmName")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+					Name:                   "dpdk",
+					MacAddress:             "de:ad:00:00:be:af",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+				}}
+				vmi.Spec.Networks = []v1.Network{{Name: "dpdk", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "ovs-dpdk"}}}}
+
+				driver, err := getPhase2Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], domain, "net1", cacheFactory)
+				Expect(err).ToNot(HaveOccurred())
+				driver.(*VhostUserBindMechanism).networkStatusPath = networkStatusPath
+				TestRunPlug(driver)
+				Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+				Expect(domain.Spec.Devices.Interfaces[0].Source).To(Equal(api.InterfaceSource{Type: "unix", Path: "/var/lib/cni/usrspcni/net1", Mode: "client"}))
+				Expect(domain.Spec.Devices.Interfaces[0].MAC).To(Equal(&api.MAC{MAC: "de:ad:00:00:be:af"}))
+			})
+			It("Should fail if the pod interface has no vhost-user socket", func() {
+				domain := &api.Domain{}
+				domain.Spec.Devices.Interfaces = []api.Interface{{Type: "vhostuser", Alias: api.NewUserDefinedAlias("dpdk")}}
+				iface := v1.Interface{Name: "dpdk", InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}}}
+				driver := &VhostUserBindMechanism{iface: &iface, domain: domain, podInterfaceName: "net2", networkStatusPath: networkStatusPath}
+				Expect(driver.decorateConfig()).To(MatchError("pod interface net2 is not part of the network status"))
+			})
+		})
+		Context("Binding plugin plug", func() {
+			var pluginHooks *MockbindingPluginHooks
+
+			newPluginDriver := func(vmi *v1.VirtualMachineInstance, domain *api.Domain, domainAttachment string) *PluginBindMechanism {
+				driver, err := getPhase2Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], domain, primaryPodInterfaceName, cacheFactory)
+				Expect(err).ToNot(HaveOccurred())
+				pluginDriver, ok := driver.(*PluginBindMechanism)
+				Expect(ok).To(BeTrue())
+				pluginDriver.launcherHooks = func(launcherPID int) (bindingPluginHooks, error) {
+					Expect(launcherPID).To(Equal(pid))
+					return pluginHooks, nil
+				}
+				pluginDriver.hooks = func() bindingPluginHooks {
+					return pluginHooks
+				}
+				pluginDriver.domainAttachment = func(launcherPID int) (string, error) {
+					Expect(launcherPID).To(Equal(pid))
+					return domainAttachment, nil
+				}
+				return pluginDriver
+			}
+
+			BeforeEach(func() {
+				pluginHooks = NewMockbindingPluginHooks(ctrl)
+			})
+
+			It("Should create the tap device and delegate both phases to the plugin", func() {
+				domain := NewDomainWithMacvtapInterface("default")
+				vmi := newVMI("testnamespace", "testVmName")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+					Name:       "default",
+					MacAddress: "de-ad-00-00-be-af",
+					Binding:    &v1.PluginBinding{Name: "custom"},
+				}}
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+
+				mockNetwork.EXPECT().LinkByName(primaryPodInterfaceName).Return(primaryPodInterface, nil)
+				mockNetwork.EXPECT().CreateTapDevice("tap0", uint32(0), pid, mtu, "0").Return(nil)
+				phase1 := pluginHooks.EXPECT().PlugPhase1(vmi, "custom", "default", primaryPodInterfaceName, "tap0").Return(nil)
+				pluginHooks.EXPECT().PlugPhase2(vmi, "custom", "default", primaryPodInterfaceName, "tap0").Return(nil).After(phase1)
+
+				driver := newPluginDriver(vmi, domain, string(v1.Tap))
+				TestRunPlug(driver)
+				Expect(domain.Spec.Devices.Interfaces[0].Target).To(Equal(&api.InterfaceTarget{Device: "tap0", Managed: "no"}))
+				Expect(domain.Spec.Devices.Interfaces[0].MAC).To(Equal(&api.MAC{MAC: "de:ad:00:00:be:af"}))
+				Expect(driver.startDHCP(vmi)).To(Succeed())
+			})
+			It("Should not add a domain interface nor a tap device on its own", func() {
+				domain := &api.Domain{}
+				vmi := newVMI("testnamespace", "testVmName")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", Binding: &v1.PluginBinding{Name: "custom"}}}
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+
+				pluginHooks.EXPECT().PlugPhase1(vmi, "custom", "default", primaryPodInterfaceName, "").Return(nil)
+				pluginHooks.EXPECT().PlugPhase2(vmi, "custom", "default", primaryPodInterfaceName, "").Return(nil)
+
+				TestRunPlug(newPluginDriver(vmi, domain, ""))
+				Expect(domain.Spec.Devices.Interfaces).To(BeEmpty())
+			})
+			It("Should fail phase1 if the plugin fails", func() {
+				vmi := newVMI("testnamespace", "testVmName")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", Binding: &v1.PluginBinding{Name: "custom"}}}
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+
+				pluginHooks.EXPECT().PlugPhase1(vmi, "custom", "default", primaryPodInterfaceName, "").Return(fmt.Errorf("plugin failure"))
+
+				driver := newPluginDriver(vmi, &api.Domain{}, "")
+				Expect(driver.discoverPodNetworkInterface()).To(Succeed())
+				Expect(driver.preparePodNetworkInterfaces(0, pid)).To(MatchError(ContainSubstring("plugin failure")))
+			})
+		})
 	})
 
 	Context("Masquerade startDHCP", func() {
@@ -22,7 +22,10 @@ package util
 import (
 	"bufio"
 	"fmt"
+	"math"
 	"os"
+	"strconv"
+	"strings"
 
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
@@ -30,22 +33,71 @@ import (
 )
 
 func GetPodCPUSet() ([]int, error) {
-	var cpuset string
-	file, err := os.Open(cgroup.CPUSetPath())
+	cpuset, err := readFirstLine(cgroup.CPUSetPath())
 	if err != nil {
 		return nil, err
 	}
+	cpusList, err := hardware.ParseCPUSetLine(cpuset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cpuset file: %v", err)
+	}
+	return cpusList, nil
+}
+
+// GetPodMemoryNodes returns the host NUMA nodes the memory of the pod is allocated on
+func GetPodMemoryNodes() ([]int, error) {
+	mems, err := readFirstLine(cgroup.CPUSetMemsPath())
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := hardware.ParseCPUSetLine(mems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cpuset mems file: %v", err)
+	}
+	return nodes, nil
+}
+
+// GetPodHugepagesLimit returns how many bytes of hugepages of the given page size the pod may allocate
+func GetPodHugepagesLimit(pageSize uint64) (uint64, error) {
+	limit, err := readFirstLine(cgroup.HugetlbLimitPath(hugetlbPageSizeName(pageSize)))
+	if err != nil {
+		return 0, err
+	}
+	if limit == "max" {
+		return math.MaxUint64, nil
+	}
+	bytes, err := strconv.ParseUint(strings.TrimSpace(limit), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse hugetlb limit file: %v", err)
+	}
+	return bytes, nil
+}
+
+// hugetlbPageSizeName returns the page size the way the kernel names it in the hugetlb cgroup files
+func hugetlbPageSizeName(pageSize uint64) string {
+	switch {
+	case pageSize%(1<<30) == 0:
+		return fmt.Sprintf("%dGB", pageSize>>30)
+	case pageSize%(1<<20) == 0:
+		return fmt.Sprintf("%dMB", pageSize>>20)
+	default:
+		return fmt.Sprintf("%dKB", pageSize>>10)
+	}
+}
+
+func readFirstLine(path string) (string, error) {
+	var line string
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
 	defer util.CloseIOAndCheckErr(file, nil)
 	scanner := bufio.NewScanner(file)
 	if scanner.Scan() {
-		cpuset = scanner.Text()
+		line = scanner.Text()
 	}
 	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-	cpusList, err := hardware.ParseCPUSetLine(cpuset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse cpuset file: %v", err)
+		return "", err
 	}
-	return cpusList, nil
+	return line, nil
 }
@@ -0,0 +1,36 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package util
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CPU utils", func() {
+	table.DescribeTable("should name the hugepage size like the hugetlb cgroup", func(pageSize uint64, name string) {
+		Expect(hugetlbPageSizeName(pageSize)).To(Equal(name))
+	},
+		table.Entry("for 2Mi pages", uint64(2<<20), "2MB"),
+		table.Entry("for 1Gi pages", uint64(1<<30), "1GB"),
+		table.Entry("for 64Ki pages", uint64(64<<10), "64KB"),
+	)
+})
@@ -143,8 +143,8 @@ var _ = Describe("KubeVirt Operator", func() {
 	var totalDeletions int
 	var resourceChanges map[string]map[string]int
 
-	resourceCount := 53
-	patchCount := 34
+	resourceCount := 60
+	patchCount := 41
 	updateCount := 20
 
 	deleteFromCache := true
@@ -871,7 +871,10 @@ var _ = Describe("KubeVirt Operator", func() {
 			components.NewVirtualMachineInstanceCrd, components.NewPresetCrd, components.NewReplicaSetCrd,
 			components.NewVirtualMachineCrd, components.NewVirtualMachineInstanceMigrationCrd,
 			components.NewVirtualMachineSnapshotCrd, components.NewVirtualMachineSnapshotContentCrd,
-			components.NewVirtualMachineRestoreCrd,
+			components.NewVirtualMachineRestoreCrd, components.NewVirtualMachineInstancetypeCrd,
+			components.NewVirtualMachineClusterInstancetypeCrd, components.NewVirtualMachinePreferenceCrd,
+			components.NewVirtualMachineClusterPreferenceCrd, components.NewVirtualMachineExportCrd,
+			components.NewVirtualMachineCloneCrd, components.NewVirtualMachineBackupCrd,
 		}
 		for _, f := range functions {
 			crd, err := f()
@@ -2059,7 +2062,7 @@ var _ = Describe("KubeVirt Operator", func() {
 			Expect(len(controller.stores.ClusterRoleBindingCache.List())).To(Equal(5))
 			Expect(len(controller.stores.RoleCache.List())).To(Equal(3))
 			Expect(len(controller.stores.RoleBindingCache.List())).To(Equal(3))
-			Expect(len(controller.stores.CrdCache.List())).To(Equal(8))
+			Expect(len(controller.stores.CrdCache.List())).To(Equal(15))
 			Expect(len(controller.stores.ServiceCache.List())).To(Equal(3))
 			Expect(len(controller.stores.DeploymentCache.List())).To(Equal(1))
 			Expect(len(controller.stores.DaemonSetCache.List())).To(Equal(0))
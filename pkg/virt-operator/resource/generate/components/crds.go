@@ -32,6 +32,10 @@ import (
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
+	backupv1alpha1 "kubevirt.io/client-go/apis/backup/v1alpha1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 )
 
@@ -40,15 +44,22 @@ const (
 )
 
 var (
-	VIRTUALMACHINE                   = "virtualmachines." + virtv1.VirtualMachineInstanceGroupVersionKind.Group
-	VIRTUALMACHINEINSTANCE           = "virtualmachineinstances." + virtv1.VirtualMachineInstanceGroupVersionKind.Group
-	VIRTUALMACHINEINSTANCEPRESET     = "virtualmachineinstancepresets." + virtv1.VirtualMachineInstancePresetGroupVersionKind.Group
-	VIRTUALMACHINEINSTANCEREPLICASET = "virtualmachineinstancereplicasets." + virtv1.VirtualMachineInstanceReplicaSetGroupVersionKind.Group
-	VIRTUALMACHINEINSTANCEMIGRATION  = "virtualmachineinstancemigrations." + virtv1.VirtualMachineInstanceMigrationGroupVersionKind.Group
-	KUBEVIRT                         = "kubevirts." + virtv1.KubeVirtGroupVersionKind.Group
-	VIRTUALMACHINESNAPSHOT           = "virtualmachinesnapshots." + snapshotv1.SchemeGroupVersion.Group
-	VIRTUALMACHINESNAPSHOTCONTENT    = "virtualmachinesnapshotcontents." + snapshotv1.SchemeGroupVersion.Group
-	PreserveUnknownFieldsFalse       = false
+	VIRTUALMACHINE                    = "virtualmachines." + virtv1.VirtualMachineInstanceGroupVersionKind.Group
+	VIRTUALMACHINEINSTANCE            = "virtualmachineinstances." + virtv1.VirtualMachineInstanceGroupVersionKind.Group
+	VIRTUALMACHINEINSTANCEPRESET      = "virtualmachineinstancepresets." + virtv1.VirtualMachineInstancePresetGroupVersionKind.Group
+	VIRTUALMACHINEINSTANCEREPLICASET  = "virtualmachineinstancereplicasets." + virtv1.VirtualMachineInstanceReplicaSetGroupVersionKind.Group
+	VIRTUALMACHINEINSTANCEMIGRATION   = "virtualmachineinstancemigrations." + virtv1.VirtualMachineInstanceMigrationGroupVersionKind.Group
+	KUBEVIRT                          = "kubevirts." + virtv1.KubeVirtGroupVersionKind.Group
+	VIRTUALMACHINESNAPSHOT            = "virtualmachinesnapshots." + snapshotv1.SchemeGroupVersion.Group
+	VIRTUALMACHINESNAPSHOTCONTENT     = "virtualmachinesnapshotcontents." + snapshotv1.SchemeGroupVersion.Group
+	VIRTUALMACHINEINSTANCETYPE        = "virtualmachineinstancetypes." + instancetypev1alpha1.SchemeGroupVersion.Group
+	VIRTUALMACHINECLUSTERINSTANCETYPE = "virtualmachineclusterinstancetypes." + instancetypev1alpha1.SchemeGroupVersion.Group
+	VIRTUALMACHINEPREFERENCE          = "virtualmachinepreferences." + instancetypev1alpha1.SchemeGroupVersion.Group
+	VIRTUALMACHINECLUSTERPREFERENCE   = "virtualmachineclusterpreferences." + instancetypev1alpha1.SchemeGroupVersion.Group
+	VIRTUALMACHINEEXPORT              = "virtualmachineexports." + exportv1alpha1.SchemeGroupVersion.Group
+	VIRTUALMACHINECLONE               = "virtualmachineclones." + clonev1alpha1.SchemeGroupVersion.Group
+	VIRTUALMACHINEBACKUP              = "virtualmachinebackups." + backupv1alpha1.SchemeGroupVersion.Group
+	PreserveUnknownFieldsFalse        = false
 )
 
 func getVersion(crd *extv1.CustomResourceDefinition, version string) (*extv1.CustomResourceDefinitionVersion, error) {
@@ -479,6 +490,183 @@ func NewVirtualMachineRestoreCrd() (*extv1.CustomResourceDefinition, error) {
 	return crd, nil
 }
 
+func NewVirtualMachineInstancetypeCrd() (*extv1.CustomResourceDefinition, error) {
+	return newInstancetypeGroupCrd(VIRTUALMACHINEINSTANCETYPE, "Namespaced", extv1.CustomResourceDefinitionNames{
+		Plural:     "virtualmachineinstancetypes",
+		Singular:   "virtualmachineinstancetype",
+		Kind:       "VirtualMachineInstancetype",
+		ShortNames: []string{"vminstancetype", "vminstancetypes"},
+	})
+}
+
+func NewVirtualMachineClusterInstancetypeCrd() (*extv1.CustomResourceDefinition, error) {
+	return newInstancetypeGroupCrd(VIRTUALMACHINECLUSTERINSTANCETYPE, "Cluster", extv1.CustomResourceDefinitionNames{
+		Plural:     "virtualmachineclusterinstancetypes",
+		Singular:   "virtualmachineclusterinstancetype",
+		Kind:       "VirtualMachineClusterInstancetype",
+		ShortNames: []string{"vmclusterinstancetype", "vmclusterinstancetypes"},
+	})
+}
+
+func NewVirtualMachinePreferenceCrd() (*extv1.CustomResourceDefinition, error) {
+	return newInstancetypeGroupCrd(VIRTUALMACHINEPREFERENCE, "Namespaced", extv1.CustomResourceDefinitionNames{
+		Plural:     "virtualmachinepreferences",
+		Singular:   "virtualmachinepreference",
+		Kind:       "VirtualMachinePreference",
+		ShortNames: []string{"vmpref", "vmprefs"},
+	})
+}
+
+func NewVirtualMachineClusterPreferenceCrd() (*extv1.CustomResourceDefinition, error) {
+	return newInstancetypeGroupCrd(VIRTUALMACHINECLUSTERPREFERENCE, "Cluster", extv1.CustomResourceDefinitionNames{
+		Plural:     "virtualmachineclusterpreferences",
+		Singular:   "virtualmachineclusterpreference",
+		Kind:       "VirtualMachineClusterPreference",
+		ShortNames: []string{"vmclusterpref", "vmclusterprefs"},
+	})
+}
+
+func newInstancetypeGroupCrd(name string, scope extv1.ResourceScope, names extv1.CustomResourceDefinitionNames) (*extv1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = name
+	crd.Spec = extv1.CustomResourceDefinitionSpec{
+		Group: instancetypev1alpha1.SchemeGroupVersion.Group,
+		Versions: []extv1.CustomResourceDefinitionVersion{
+			{
+				Name:    instancetypev1alpha1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: scope,
+		Names: names,
+	}
+
+	if err := patchValidationForAllVersions(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineExportCrd() (*extv1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINEEXPORT
+	crd.Spec = extv1.CustomResourceDefinitionSpec{
+		Group: exportv1alpha1.SchemeGroupVersion.Group,
+		Versions: []extv1.CustomResourceDefinitionVersion{
+			{
+				Name:    exportv1alpha1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachineexports",
+			Singular:   "virtualmachineexport",
+			Kind:       "VirtualMachineExport",
+			ShortNames: []string{"vmexport", "vmexports"},
+			Categories: []string{
+				"all",
+			},
+		},
+	}
+	err := addFieldsToAllVersions(crd, []extv1.CustomResourceColumnDefinition{
+		{Name: "SourceKind", Type: "string", JSONPath: ".spec.source.kind"},
+		{Name: "SourceName", Type: "string", JSONPath: ".spec.source.name"},
+		{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = patchValidationForAllVersions(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineCloneCrd() (*extv1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINECLONE
+	crd.Spec = extv1.CustomResourceDefinitionSpec{
+		Group: clonev1alpha1.SchemeGroupVersion.Group,
+		Versions: []extv1.CustomResourceDefinitionVersion{
+			{
+				Name:    clonev1alpha1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachineclones",
+			Singular:   "virtualmachineclone",
+			Kind:       "VirtualMachineClone",
+			ShortNames: []string{"vmclone", "vmclones"},
+			Categories: []string{
+				"all",
+			},
+		},
+	}
+	err := addFieldsToAllVersions(crd, []extv1.CustomResourceColumnDefinition{
+		{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+		{Name: "SourceVirtualMachine", Type: "string", JSONPath: ".spec.source.name"},
+		{Name: "TargetVirtualMachine", Type: "string", JSONPath: ".status.targetName"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = patchValidationForAllVersions(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineBackupCrd() (*extv1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINEBACKUP
+	crd.Spec = extv1.CustomResourceDefinitionSpec{
+		Group: backupv1alpha1.SchemeGroupVersion.Group,
+		Versions: []extv1.CustomResourceDefinitionVersion{
+			{
+				Name:    backupv1alpha1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinebackups",
+			Singular:   "virtualmachinebackup",
+			Kind:       "VirtualMachineBackup",
+			ShortNames: []string{"vmbackup", "vmbackups"},
+			Categories: []string{
+				"all",
+			},
+		},
+	}
+	err := addFieldsToAllVersions(crd, []extv1.CustomResourceColumnDefinition{
+		{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+		{Name: "SourceVirtualMachine", Type: "string", JSONPath: ".spec.source.name"},
+		{Name: "Type", Type: "string", JSONPath: ".status.type"},
+		{Name: "Checkpoint", Type: "string", JSONPath: ".status.checkpointName"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = patchValidationForAllVersions(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
 func NewServiceMonitorCR(namespace string, monitorNamespace string, insecureSkipVerify bool) *promv1.ServiceMonitor {
 	return &promv1.ServiceMonitor{
 		TypeMeta: metav1.TypeMeta{
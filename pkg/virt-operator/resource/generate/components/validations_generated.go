@@ -371,12 +371,50 @@ var CRDsValidation map[string]string = map[string]string{
             network:
               description: NetworkConfiguration holds network options
               properties:
+                binding:
+                  additionalProperties:
+                    description: InterfaceBindingPlugin describes a network binding plugin which connects the pod network to the guest
+                    properties:
+                      domainAttachmentType:
+                        description: DomainAttachmentType is the way the domain interface is attached to the pod network. If not set, the sidecar is responsible for adding the domain interface.
+                        type: string
+                      sidecarImage:
+                        description: SidecarImage references a container image which runs in the virt-launcher pod. The sidecar implements the hook points of the plugin, e.g. to adjust the domain.
+                        type: string
+                    type: object
+                  description: Binding registers network binding plugins by name, interfaces refer to them through their binding.
+                  type: object
                 defaultNetworkInterface:
                   type: string
+                macPool:
+                  description: MacPool assigns MAC addresses from its ranges to the interfaces on Multus networks which have none, and rejects MAC addresses which are already in use by another VirtualMachine or VirtualMachineInstance.
+                  properties:
+                    ranges:
+                      description: Ranges are the MAC address ranges addresses are allocated from
+                      items:
+                        description: MacPoolRange is a range of MAC addresses, both ends are included
+                        properties:
+                          end:
+                            description: End is the last MAC address of the range, e.g. 02:00:00:ff:ff:ff
+                            type: string
+                          start:
+                            description: Start is the first MAC address of the range, e.g. 02:00:00:00:00:00
+                            type: string
+                        required:
+                        - end
+                        - start
+                        type: object
+                      type: array
+                  required:
+                  - ranges
+                  type: object
                 permitBridgeInterfaceOnPodNetwork:
                   type: boolean
                 permitSlirpInterface:
                   type: boolean
+                vhostUserSocketDir:
+                  description: VhostUserSocketDir is the host directory holding the vhost-user socket directories of the VMIs. The socket directory of a VMI is mounted into its virt-launcher pod, defaults to /var/lib/cni/usrspcni.
+                  type: string
               type: object
             obsoleteCPUModels:
               additionalProperties:
@@ -439,6 +477,8 @@ var CRDsValidation map[string]string = map[string]string{
               items:
                 type: string
               type: array
+            vmStateStorageClass:
+              type: string
           type: object
         customizeComponents:
           properties:
@@ -1605,6 +1645,30 @@ var CRDsValidation map[string]string = map[string]string{
             - spec
             type: object
           type: array
+        instancetype:
+          description: Instancetype references a resource that contains quantitative and resource related VirtualMachine configuration that is expanded into the VirtualMachineInstance.
+          properties:
+            kind:
+              description: 'Kind specifies which instancetype resource is referenced. Allowed values are: "VirtualMachineInstancetype" and "VirtualMachineClusterInstancetype". If not specified, "VirtualMachineClusterInstancetype" is used by default.'
+              type: string
+            name:
+              description: Name is the name of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype
+              type: string
+          required:
+          - name
+          type: object
+        preference:
+          description: Preference references a resource that contains a set of preferred values that are only applied when not already provided by the VirtualMachineInstance template.
+          properties:
+            kind:
+              description: 'Kind specifies which preference resource is referenced. Allowed values are: "VirtualMachinePreference" and "VirtualMachineClusterPreference". If not specified, "VirtualMachineClusterPreference" is used by default.'
+              type: string
+            name:
+              description: Name is the name of the VirtualMachinePreference or VirtualMachineClusterPreference
+              type: string
+          required:
+          - name
+          type: object
         runStrategy:
           description: Running state indicates the requested running state of the VirtualMachineInstance mutually exclusive with Running
           type: string
@@ -2175,9 +2239,20 @@ var CRDsValidation map[string]string = map[string]string{
                         isolateEmulatorThread:
                           description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
                           type: boolean
+                        maxSockets:
+                          description: MaxSockets specifies the maximum number of sockets the vmi can be scaled up to while it is running. The domain is defined with the additional sockets offline. Requires the CPUHotplug feature gate.
+                          format: int32
+                          type: integer
                         model:
                           description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                           type: string
+                        numa:
+                          description: NUMA allows specifying settings for the guest NUMA topology.
+                          properties:
+                            guestMappingPassthrough:
+                              description: GuestMappingPassthrough creates a guest NUMA topology which mirrors the host NUMA nodes of the dedicated pCPUs and hugepages of the VMI. Guest NUMA nodes never cross host NUMA nodes. Requires DedicatedCPUPlacement, hugepages and the NUMA feature gate.
+                              type: object
+                          type: object
                         sockets:
                           description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                           format: int32
@@ -2260,6 +2335,66 @@ var CRDsValidation map[string]string = map[string]string{
                               io:
                                 description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                                 type: string
+                              ioTune:
+                                description: IOTune limits the throughput and the I/O operations of the disk. It can be changed on a running VMI through the setiotune subresource.
+                                properties:
+                                  burst:
+                                    description: Burst allows exceeding the limits for a short time.
+                                    properties:
+                                      lengthSeconds:
+                                        description: LengthSeconds is how long the burst limits can be sustained. Defaults to 1.
+                                        format: int64
+                                        type: integer
+                                      readBytesSec:
+                                        description: Read throughput burst limit in bytes per second.
+                                        format: int64
+                                        type: integer
+                                      readIopsSec:
+                                        description: Read I/O operations per second burst limit.
+                                        format: int64
+                                        type: integer
+                                      totalBytesSec:
+                                        description: Total throughput burst limit in bytes per second.
+                                        format: int64
+                                        type: integer
+                                      totalIopsSec:
+                                        description: Total I/O operations per second burst limit.
+                                        format: int64
+                                        type: integer
+                                      writeBytesSec:
+                                        description: Write throughput burst limit in bytes per second.
+                                        format: int64
+                                        type: integer
+                                      writeIopsSec:
+                                        description: Write I/O operations per second burst limit.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                  readBytesSec:
+                                    description: Read throughput limit in bytes per second.
+                                    format: int64
+                                    type: integer
+                                  readIopsSec:
+                                    description: Read I/O operations per second.
+                                    format: int64
+                                    type: integer
+                                  totalBytesSec:
+                                    description: Total throughput limit in bytes per second.
+                                    format: int64
+                                    type: integer
+                                  totalIopsSec:
+                                    description: Total I/O operations per second.
+                                    format: int64
+                                    type: integer
+                                  writeBytesSec:
+                                    description: Write throughput limit in bytes per second.
+                                    format: int64
+                                    type: integer
+                                  writeIopsSec:
+                                    description: Write I/O operations per second.
+                                    format: int64
+                                    type: integer
+                                type: object
                               lun:
                                 description: Attach a volume as a LUN to the vmi.
                                 properties:
@@ -2351,6 +2486,55 @@ var CRDsValidation map[string]string = map[string]string{
                           description: Interfaces describe network interfaces which are added to the vmi.
                           items:
                             properties:
+                              bandwidth:
+                                description: If specified, limits the inbound and outbound traffic of the interface.
+                                properties:
+                                  inbound:
+                                    description: BandwidthLimits of a single traffic direction.
+                                    properties:
+                                      average:
+                                        description: Average bit rate in kilobytes per second.
+                                        format: int32
+                                        type: integer
+                                      burst:
+                                        description: Amount of kilobytes that can be burst at peak speed.
+                                        format: int32
+                                        type: integer
+                                      peak:
+                                        description: Maximum rate at which the interface can send data, in kilobytes per second.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - average
+                                    type: object
+                                  outbound:
+                                    description: BandwidthLimits of a single traffic direction.
+                                    properties:
+                                      average:
+                                        description: Average bit rate in kilobytes per second.
+                                        format: int32
+                                        type: integer
+                                      burst:
+                                        description: Amount of kilobytes that can be burst at peak speed.
+                                        format: int32
+                                        type: integer
+                                      peak:
+                                        description: Maximum rate at which the interface can send data, in kilobytes per second.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - average
+                                    type: object
+                                type: object
+                              binding:
+                                description: Binding specifies a network binding plugin which connects the interface to the guest. It can not be combined with the other binding methods.
+                                properties:
+                                  name:
+                                    description: Name of the network binding plugin.
+                                    type: string
+                                required:
+                                - name
+                                type: object
                               bootOrder:
                                 description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                                 type: integer
@@ -2387,6 +2571,118 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: If specified will pass option 66 to interface's DHCP server
                                     type: string
                                 type: object
+                              firewall:
+                                description: If specified, filters the traffic of the interface in the virt-launcher pod. Only supported for bridge and masquerade interfaces.
+                                properties:
+                                  egress:
+                                    description: FirewallPolicy filters a single traffic direction.
+                                    properties:
+                                      defaultAction:
+                                        description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                                        type: string
+                                      rules:
+                                        description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                                        items:
+                                          description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                                          properties:
+                                            action:
+                                              description: 'Action taken for the matching traffic. One of: allow, deny.'
+                                              type: string
+                                            cidr:
+                                              description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                                              type: string
+                                            port:
+                                              description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                                              format: int32
+                                              type: integer
+                                            protocol:
+                                              description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                                              type: string
+                                          required:
+                                          - action
+                                          type: object
+                                        type: array
+                                    type: object
+                                  ingress:
+                                    description: FirewallPolicy filters a single traffic direction.
+                                    properties:
+                                      defaultAction:
+                                        description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                                        type: string
+                                      rules:
+                                        description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                                        items:
+                                          description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                                          properties:
+                                            action:
+                                              description: 'Action taken for the matching traffic. One of: allow, deny.'
+                                              type: string
+                                            cidr:
+                                              description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                                              type: string
+                                            port:
+                                              description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                                              format: int32
+                                              type: integer
+                                            protocol:
+                                              description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                                              type: string
+                                          required:
+                                          - action
+                                          type: object
+                                        type: array
+                                    type: object
+                                type: object
+                              ipam:
+                                description: If specified, the guest addresses of the interface are assigned statically instead of being discovered on the pod interface. Only supported for bridge interfaces on secondary Multus networks without an IPAM plugin.
+                                properties:
+                                  addresses:
+                                    description: Addresses of the interface in CIDR notation, e.g. 192.168.100.10/24 or fd10:100::10/64. At most one IPv4 and one IPv6 address can be specified.
+                                    items:
+                                      type: string
+                                    type: array
+                                  dns:
+                                    description: DNS configuration of the interface. Defaults to the DNS configuration of the virt-launcher pod.
+                                    properties:
+                                      nameservers:
+                                        description: Nameservers are the IP addresses of the DNS servers.
+                                        items:
+                                          type: string
+                                        type: array
+                                      search:
+                                        description: Search is the list of DNS search domains.
+                                        items:
+                                          type: string
+                                        type: array
+                                    type: object
+                                  gateway:
+                                    description: Gateway is the IPv4 default gateway.
+                                    type: string
+                                  gatewayIPv6:
+                                    description: GatewayIPv6 is the IPv6 default gateway. DHCPv6 can not announce gateways, it is only applied through cloud-init.
+                                    type: string
+                                  routes:
+                                    description: Routes in addition to the default routes.
+                                    items:
+                                      description: InterfaceRoute is a static route of an interface.
+                                      properties:
+                                        destination:
+                                          description: Destination network in CIDR notation.
+                                          type: string
+                                        gateway:
+                                          description: Gateway through which the destination is reached.
+                                          type: string
+                                      required:
+                                      - destination
+                                      - gateway
+                                      type: object
+                                    type: array
+                                required:
+                                - addresses
+                                type: object
+                              linkState:
+                                description: 'LinkState of the virtual network interface as seen by the guest. One of: up, down. Defaults to up.'
+                                type: string
                               macAddress:
                                 description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                                 type: string
@@ -2429,6 +2725,9 @@ var CRDsValidation map[string]string = map[string]string{
                               tag:
                                 description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
                                 type: string
+                              vhostuser:
+                                description: InterfaceVhostUser connects to a vhost-user socket provided by a userspace switch, e.g. OVS-DPDK, through a Multus network.
+                                type: object
                             required:
                             - name
                             type: object
@@ -2439,6 +2738,13 @@ var CRDsValidation map[string]string = map[string]string{
                         rng:
                           description: Whether to have random number generator from host
                           type: object
+                        tpm:
+                          description: Whether to emulate a TPM 2.0 device backed by a software TPM.
+                          properties:
+                            persistent:
+                              description: If set to true, the TPM state is stored in the persistent state volume of the VirtualMachine and survives restarts and migrations. Requires the VMPersistentState feature gate. Defaults to false
+                              type: boolean
+                          type: object
                         useVirtioTransitional:
                           description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
                           type: boolean
@@ -2632,6 +2938,9 @@ var CRDsValidation map[string]string = map[string]string{
                             efi:
                               description: If set, EFI will be used instead of BIOS.
                               properties:
+                                persistent:
+                                  description: If set to true, the EFI NVRAM is stored in the persistent state volume of the VirtualMachine and survives restarts and migrations. Requires the VMPersistentState feature gate. Defaults to false
+                                  type: boolean
                                 secureBoot:
                                   description: If set, SecureBoot will be enabled and the OVMF roms will be swapped for SecureBoot-enabled ones. Requires SMM to be enabled. Defaults to true
                                   type: boolean
@@ -3204,6 +3513,9 @@ var CRDsValidation map[string]string = map[string]string{
               - domain
               type: object
           type: object
+        updateVolumesStrategy:
+          description: UpdateVolumesStrategy controls how a change of the claims of the volumes in the template applies to a running VirtualMachineInstance. Defaults to Replacement.
+          type: string
       required:
       - template
       type: object
@@ -3239,6 +3551,35 @@ var CRDsValidation map[string]string = map[string]string{
         created:
           description: Created indicates if the virtual machine is created in the cluster
           type: boolean
+        interfaceRequests:
+          description: InterfaceRequests indicates a list of interfaces added to or removed from the VMI template and hotplugged on an active running VMI.
+          items:
+            properties:
+              addInterfaceOptions:
+                description: AddInterfaceOptions when set indicates a network interface should be added. The details within this field specify how to add the interface
+                properties:
+                  name:
+                    description: Name indicates the logical name of the interface and of the network it is connected to.
+                    type: string
+                  networkAttachmentDefinitionName:
+                    description: 'NetworkAttachmentDefinitionName references a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
+                    type: string
+                required:
+                - name
+                - networkAttachmentDefinitionName
+                type: object
+              removeInterfaceOptions:
+                description: RemoveInterfaceOptions when set indicates a network interface should be removed. The details within this field specify which interface to remove
+                properties:
+                  name:
+                    description: Name indicates the logical name of the interface and network to remove.
+                    type: string
+                required:
+                - name
+                type: object
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
         ready:
           description: Ready indicates if the virtual machine is running and ready
           type: boolean
@@ -3322,6 +3663,66 @@ var CRDsValidation map[string]string = map[string]string{
                       io:
                         description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: IOTune limits the throughput and the I/O operations of the disk. It can be changed on a running VMI through the setiotune subresource.
+                        properties:
+                          burst:
+                            description: Burst allows exceeding the limits for a short time.
+                            properties:
+                              lengthSeconds:
+                                description: LengthSeconds is how long the burst limits can be sustained. Defaults to 1.
+                                format: int64
+                                type: integer
+                              readBytesSec:
+                                description: Read throughput burst limit in bytes per second.
+                                format: int64
+                                type: integer
+                              readIopsSec:
+                                description: Read I/O operations per second burst limit.
+                                format: int64
+                                type: integer
+                              totalBytesSec:
+                                description: Total throughput burst limit in bytes per second.
+                                format: int64
+                                type: integer
+                              totalIopsSec:
+                                description: Total I/O operations per second burst limit.
+                                format: int64
+                                type: integer
+                              writeBytesSec:
+                                description: Write throughput burst limit in bytes per second.
+                                format: int64
+                                type: integer
+                              writeIopsSec:
+                                description: Write I/O operations per second burst limit.
+                                format: int64
+                                type: integer
+                            type: object
+                          readBytesSec:
+                            description: Read throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          readIopsSec:
+                            description: Read I/O operations per second.
+                            format: int64
+                            type: integer
+                          totalBytesSec:
+                            description: Total throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          totalIopsSec:
+                            description: Total I/O operations per second.
+                            format: int64
+                            type: integer
+                          writeBytesSec:
+                            description: Write throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          writeIopsSec:
+                            description: Write I/O operations per second.
+                            format: int64
+                            type: integer
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -3412,8 +3813,8 @@ var CRDsValidation map[string]string = map[string]string{
   - spec
   type: object
 `,
-	"virtualmachineinstance": `openAPIV3Schema:
-  description: VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.
+	"virtualmachinebackup": `openAPIV3Schema:
+  description: VirtualMachineBackup defines the operation of backing up the disks of a running VirtualMachine
   properties:
     apiVersion:
       description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
@@ -3424,74 +3825,779 @@ var CRDsValidation map[string]string = map[string]string{
     metadata:
       type: object
     spec:
-      description: VirtualMachineInstance Spec contains the VirtualMachineInstance specification.
+      description: VirtualMachineBackupSpec is the spec for a VirtualMachineBackup resource
       properties:
-        accessCredentials:
-          description: Specifies a set of public keys to inject into the vm guest
+        incremental:
+          description: Incremental is the checkpoint of a previous backup. Only the blocks changed since that checkpoint are reported as changed. Only supported by pull mode backups.
+          type: string
+        mode:
+          description: Mode is Pull, the default, or Push
+          type: string
+        source:
+          description: Source is the VirtualMachine to back up, it has to be running
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced. If APIGroup is not specified, the specified Kind must be in the core API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+        target:
+          description: Target is where push mode backups are written to
+          properties:
+            persistentVolumeClaimName:
+              description: PersistentVolumeClaimName is the name of a filesystem mode claim, each disk is written to <backup name>/<volume name>.qcow2 on it
+              type: string
+          required:
+          - persistentVolumeClaimName
+          type: object
+      required:
+      - source
+      type: object
+    status:
+      description: VirtualMachineBackupStatus is the status for a VirtualMachineBackup resource
+      properties:
+        checkpointName:
+          description: CheckpointName is the checkpoint created by the backup, later backups can be incremental to it. It is not set if no disk supports changed block tracking.
+          type: string
+        completionTimestamp:
+          format: date-time
+          type: string
+        conditions:
           items:
-            description: AccessCredential represents a credential source that can be used to authorize remote access to the vm guest Only one of its members may be specified.
+            description: VirtualMachineBackupCondition defines conditions of a VirtualMachineBackup
             properties:
-              sshPublicKey:
-                description: SSHPublicKey represents the source and method of applying a ssh public key into a guest virtual machine.
-                properties:
-                  propagationMethod:
-                    description: PropagationMethod represents how the public key is injected into the vm guest.
-                    properties:
-                      configDrive:
-                        description: ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider
-                        type: object
-                      qemuGuestAgent:
-                        description: QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
-                        properties:
-                          users:
-                            description: Users represents a list of guest users that should have the ssh public keys added to their authorized_keys file.
-                            items:
-                              type: string
-                            type: array
-                            x-kubernetes-list-type: set
-                        required:
-                        - users
-                        type: object
-                    type: object
-                  source:
-                    description: Source represents where the public keys are pulled from
-                    properties:
-                      secret:
-                        description: Secret means that the access credential is pulled from a kubernetes secret
-                        properties:
-                          secretName:
-                            description: SecretName represents the name of the secret in the VMI's namespace
-                            type: string
-                        required:
-                        - secretName
-                        type: object
-                    type: object
-                required:
-                - propagationMethod
-                - source
-                type: object
-              userPassword:
-                description: UserPassword represents the source and method for applying a guest user's password
-                properties:
-                  propagationMethod:
-                    description: propagationMethod represents how the user passwords are injected into the vm guest.
-                    properties:
-                      qemuGuestAgent:
-                        description: QemuGuestAgentAccessCredentailPropagation means passwords are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
-                        type: object
-                    type: object
-                  source:
-                    description: Source represents where the user passwords are pulled from
-                    properties:
-                      secret:
-                        description: Secret means that the access credential is pulled from a kubernetes secret
-                        properties:
-                          secretName:
-                            description: SecretName represents the name of the secret in the VMI's namespace
-                            type: string
-                        required:
-                        - secretName
-                        type: object
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                description: ConditionType is the const type for VirtualMachineBackup conditions
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+        exports:
+          description: Exports are the disks exported by the NBD server, which is reachable through the backupnbd subresource of the VirtualMachineInstance
+          items:
+            description: BackupExport is a disk exported by the NBD server of a backup.
+            properties:
+              dirtyBitmap:
+                description: The NBD metadata context listing the changed blocks of an incremental backup, e.g. qemu:dirty-bitmap:backup-vda
+                type: string
+              exportName:
+                description: The NBD export name of the disk
+                type: string
+              volumeName:
+                description: The name of the volume
+                type: string
+            required:
+            - exportName
+            - volumeName
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        phase:
+          description: VirtualMachineBackupPhase is the current phase of the VirtualMachineBackup
+          type: string
+        startTimestamp:
+          format: date-time
+          type: string
+        type:
+          description: BackupType is the type of a backup
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineclone": `openAPIV3Schema:
+  description: VirtualMachineClone defines the operation of cloning a VirtualMachine or a VirtualMachineSnapshot into a new VirtualMachine
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineCloneSpec is the spec for a VirtualMachineClone resource
+      properties:
+        annotationFilters:
+          description: AnnotationFilters select the annotations copied from the source. Filters support the '*' wildcard and are negated with a leading '!'. The last matching filter wins. All annotations are copied if empty.
+          items:
+            type: string
+          type: array
+        labelFilters:
+          description: LabelFilters select the labels copied from the source, see AnnotationFilters
+          items:
+            type: string
+          type: array
+        newMacAddresses:
+          additionalProperties:
+            type: string
+          description: NewMacAddresses maps interface names to the MAC address of the clone. Interfaces not listed get a new MAC address assigned.
+          type: object
+        newSMBiosSerial:
+          description: NewSMBiosSerial is the SMBios serial of the clone. A new serial is assigned if omitted.
+          type: string
+        source:
+          description: Source is the VirtualMachine or VirtualMachineSnapshot to clone
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced. If APIGroup is not specified, the specified Kind must be in the core API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+        target:
+          description: Target is the VirtualMachine to create. A name is generated if omitted.
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced. If APIGroup is not specified, the specified Kind must be in the core API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+      required:
+      - source
+      type: object
+    status:
+      description: VirtualMachineCloneStatus is the status for a VirtualMachineClone resource
+      properties:
+        conditions:
+          items:
+            description: VirtualMachineCloneCondition defines conditions of a VirtualMachineClone
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                description: ConditionType is the const type for VirtualMachineClone conditions
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+        creationTime:
+          format: date-time
+          type: string
+        phase:
+          description: VirtualMachineClonePhase is the current phase of the VirtualMachineClone
+          type: string
+        restoreName:
+          description: RestoreName is the name of the VirtualMachineRestore creating the target
+          type: string
+        snapshotName:
+          description: SnapshotName is the name of the VirtualMachineSnapshot being restored
+          type: string
+        targetName:
+          description: TargetName is the name of the created VirtualMachine
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineclusterinstancetype": `openAPIV3Schema:
+  description: VirtualMachineClusterInstancetype is a cluster scoped version of VirtualMachineInstancetype resource.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: Required spec describing the instancetype
+      properties:
+        cpu:
+          description: Required CPU related attributes of the instancetype.
+          properties:
+            dedicatedCPUPlacement:
+              description: DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node with enough dedicated pCPUs and pin the vCPUs to it.
+              type: boolean
+            guest:
+              description: "Required number of vCPUs to expose to the guest. \n The resulting CPU topology being derived from the optional PreferredCPUTopology attribute of CPUPreferences that itself defaults to PreferSockets."
+              format: int32
+              type: integer
+            isolateEmulatorThread:
+              description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
+              type: boolean
+            model:
+              description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
+              type: string
+          required:
+          - guest
+          type: object
+        gpus:
+          description: Optionally defines any GPU devices associated with the instancetype.
+          items:
+            properties:
+              deviceName:
+                type: string
+              name:
+                description: Name of the GPU device as exposed by a device plugin
+                type: string
+            required:
+            - deviceName
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        hostDevices:
+          description: Optionally defines any HostDevices associated with the instancetype.
+          items:
+            properties:
+              deviceName:
+                description: DeviceName is the resource name of the host device exposed by a device plugin
+                type: string
+              name:
+                type: string
+            required:
+            - deviceName
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        ioThreadsPolicy:
+          description: Optionally defines the IOThreadsPolicy to be used by the instancetype.
+          type: string
+        memory:
+          description: Required Memory related attributes of the instancetype.
+          properties:
+            guest:
+              anyOf:
+              - type: integer
+              - type: string
+              description: Required amount of memory which is visible inside the guest OS.
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            hugepages:
+              description: Optionally enables the use of hugepages for the VirtualMachineInstance instead of regular memory.
+              properties:
+                pageSize:
+                  description: PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
+                  type: string
+              type: object
+          required:
+          - guest
+          type: object
+      required:
+      - cpu
+      - memory
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineclusterpreference": `openAPIV3Schema:
+  description: VirtualMachineClusterPreference is a cluster scoped version of the VirtualMachinePreference resource.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: Required spec describing the preferences
+      properties:
+        cpu:
+          description: CPU optionally defines preferences associated with the CPU attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredCPUTopology:
+              description: PreferredCPUTopology optionally defines the preferred guest visible CPU topology, defaults to PreferSockets.
+              type: string
+          type: object
+        devices:
+          description: Devices optionally defines preferences associated with the Devices attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredAutoattachGraphicsDevice:
+              description: PreferredAutoattachGraphicsDevice optionally defines the preferred value of AutoattachGraphicsDevice
+              type: boolean
+            preferredAutoattachMemBalloon:
+              description: PreferredAutoattachMemBalloon optionally defines the preferred value of AutoattachMemBalloon
+              type: boolean
+            preferredAutoattachPodInterface:
+              description: PreferredAutoattachPodInterface optionally defines the preferred value of AutoattachPodInterface
+              type: boolean
+            preferredAutoattachSerialConsole:
+              description: PreferredAutoattachSerialConsole optionally defines the preferred value of AutoattachSerialConsole
+              type: boolean
+            preferredBlockMultiQueue:
+              description: PreferredBlockMultiQueue optionally enables the vhost multiqueue feature for virtio disks.
+              type: boolean
+            preferredCdromBus:
+              description: PreferredCdromBus optionally defines the preferred bus for Cdrom Disk devices.
+              type: string
+            preferredDiskBus:
+              description: PreferredDiskBus optionally defines the preferred bus for Disk Disk devices.
+              type: string
+            preferredInputBus:
+              description: PreferredInputBus optionally defines the preferred bus for Input devices.
+              type: string
+            preferredInputType:
+              description: PreferredInputType optionally defines the preferred type for Input devices.
+              type: string
+            preferredInterfaceModel:
+              description: PreferredInterfaceModel optionally defines the preferred model to be used by Interface devices.
+              type: string
+            preferredLunBus:
+              description: PreferredLunBus optionally defines the preferred bus for Lun Disk devices.
+              type: string
+            preferredNetworkInterfaceMultiQueue:
+              description: PreferredNetworkInterfaceMultiQueue optionally enables the vhost multiqueue feature for virtio interfaces.
+              type: boolean
+            preferredUseVirtioTransitional:
+              description: PreferredUseVirtioTransitional optionally defines the preferred value of UseVirtioTransitional
+              type: boolean
+          type: object
+        features:
+          description: Features optionally defines preferences associated with the Features attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredAcpi:
+              description: PreferredAcpi optionally enables the ACPI feature
+              properties:
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
+              type: object
+            preferredApic:
+              description: PreferredApic optionally enables and configures the APIC feature
+              properties:
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
+                endOfInterrupt:
+                  description: EndOfInterrupt enables the end of interrupt notification in the guest. Defaults to false.
+                  type: boolean
+              type: object
+            preferredHyperv:
+              description: PreferredHyperv optionally enables and configures HyperV features
+              properties:
+                evmcs:
+                  description: EVMCS Speeds up L2 vmexits, but disables other virtualization features. Requires vapic. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                frequencies:
+                  description: Frequencies improves the TSC clock source handling for Hyper-V on KVM. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                ipi:
+                  description: IPI improves performances in overcommited environments. Requires vpindex. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                reenlightenment:
+                  description: Reenlightenment enables the notifications on TSC frequency changes. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                relaxed:
+                  description: Relaxed instructs the guest OS to disable watchdog timeouts. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                reset:
+                  description: Reset enables Hyperv reboot/reset for the vmi. Requires synic. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                runtime:
+                  description: Runtime improves the time accounting to improve scheduling in the guest. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                spinlocks:
+                  description: Spinlocks allows to configure the spinlock retry attempts.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                    spinlocks:
+                      description: Retries indicates the number of retries. Must be a value greater or equal 4096. Defaults to 4096.
+                      format: int32
+                      type: integer
+                  type: object
+                synic:
+                  description: SyNIC enables the Synthetic Interrupt Controller. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                synictimer:
+                  description: SyNICTimer enables Synthetic Interrupt Controller Timers, reducing CPU load. Defaults to the machine type setting.
+                  properties:
+                    direct:
+                      description: Represents if a feature is enabled or disabled.
+                      properties:
+                        enabled:
+                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                          type: boolean
+                      type: object
+                    enabled:
+                      type: boolean
+                  type: object
+                tlbflush:
+                  description: TLBFlush improves performances in overcommited environments. Requires vpindex. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                vapic:
+                  description: VAPIC improves the paravirtualized handling of interrupts. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                vendorid:
+                  description: VendorID allows setting the hypervisor vendor id. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                    vendorid:
+                      description: VendorID sets the hypervisor vendor id, visible to the vmi. String up to twelve characters.
+                      type: string
+                  type: object
+                vpindex:
+                  description: VPIndex enables the Virtual Processor Index to help windows identifying virtual processors. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+              type: object
+            preferredKvm:
+              description: PreferredKvm optionally enables and configures KVM features
+              properties:
+                hidden:
+                  description: Hide the KVM hypervisor from standard MSR based discovery. Defaults to false
+                  type: boolean
+              type: object
+            preferredSmm:
+              description: PreferredSmm optionally enables the SMM feature
+              properties:
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
+              type: object
+          type: object
+        firmware:
+          description: Firmware optionally defines preferences associated with the Firmware attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredUseBios:
+              description: PreferredUseBios optionally enables BIOS
+              type: boolean
+            preferredUseBiosSerial:
+              description: "PreferredUseBiosSerial optionally transmits BIOS output over the serial. \n Requires PreferredUseBios to be enabled."
+              type: boolean
+            preferredUseEfi:
+              description: PreferredUseEfi optionally enables EFI
+              type: boolean
+            preferredUseSecureBoot:
+              description: "PreferredUseSecureBoot optionally enables SecureBoot and the OVMF roms will be swapped for SecureBoot-enabled ones. \n Requires PreferredUseEfi and PreferredSmm to be enabled."
+              type: boolean
+          type: object
+        machine:
+          description: Machine optionally defines preferences associated with the Machine attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredMachineType:
+              description: PreferredMachineType optionally defines the preferred machine type to use.
+              type: string
+          type: object
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineexport": `openAPIV3Schema:
+  description: VirtualMachineExport defines the operation of exporting the disks and definition of a stopped VirtualMachine or a single PersistentVolumeClaim
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineExportSpec is the spec for a VirtualMachineExport resource
+      properties:
+        source:
+          description: Source is the VirtualMachine or PersistentVolumeClaim to export
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced. If APIGroup is not specified, the specified Kind must be in the core API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+        tokenSecretRef:
+          description: TokenSecretRef is the name of the secret holding the token clients have to present to the export server. The token is read from the "token" key.
+          type: string
+        ttlDuration:
+          description: TTLDuration limits how long the export is served. Once it expired, the export server is stopped and the VirtualMachineExport is deleted. The token secret is left to the user. Defaults to 2 hours.
+          type: string
+      required:
+      - source
+      - tokenSecretRef
+      type: object
+    status:
+      description: VirtualMachineExportStatus is the status for a VirtualMachineExport resource
+      properties:
+        conditions:
+          items:
+            description: VirtualMachineExportCondition defines conditions of a VirtualMachineExport
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                description: ConditionType is the const type for VirtualMachineExport conditions
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+        links:
+          description: VirtualMachineExportLinks contains the links to the exported content
+          properties:
+            internal:
+              description: Internal links are reachable from within the cluster
+              properties:
+                cert:
+                  description: Cert is the PEM encoded CA certificate the export server certificate is signed with
+                  type: string
+                manifests:
+                  items:
+                    description: VirtualMachineExportManifest contains the type and the url of an exported manifest
+                    properties:
+                      type:
+                        description: ExportManifestType defines the kind of manifest served
+                        type: string
+                      url:
+                        type: string
+                    required:
+                    - type
+                    - url
+                    type: object
+                  type: array
+                volumes:
+                  items:
+                    description: VirtualMachineExportVolume contains the name and the available formats of an exported volume
+                    properties:
+                      formats:
+                        items:
+                          description: VirtualMachineExportVolumeFormat contains the format and the url of an exported volume
+                          properties:
+                            format:
+                              description: ExportVolumeFormat defines the format a volume is served in
+                              type: string
+                            url:
+                              type: string
+                          required:
+                          - format
+                          - url
+                          type: object
+                        type: array
+                      name:
+                        description: Name is the name of the exported PersistentVolumeClaim
+                        type: string
+                    required:
+                    - name
+                    type: object
+                  type: array
+              required:
+              - cert
+              type: object
+          type: object
+        phase:
+          description: VirtualMachineExportPhase is the current phase of the VirtualMachineExport
+          type: string
+        serviceName:
+          description: ServiceName is the name of the service exposing the export server
+          type: string
+        ttlExpirationTime:
+          description: TTLExpirationTime is the time the export expires at
+          format: date-time
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineinstance": `openAPIV3Schema:
+  description: VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineInstance Spec contains the VirtualMachineInstance specification.
+      properties:
+        accessCredentials:
+          description: Specifies a set of public keys to inject into the vm guest
+          items:
+            description: AccessCredential represents a credential source that can be used to authorize remote access to the vm guest Only one of its members may be specified.
+            properties:
+              sshPublicKey:
+                description: SSHPublicKey represents the source and method of applying a ssh public key into a guest virtual machine.
+                properties:
+                  propagationMethod:
+                    description: PropagationMethod represents how the public key is injected into the vm guest.
+                    properties:
+                      configDrive:
+                        description: ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider
+                        type: object
+                      qemuGuestAgent:
+                        description: QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
+                        properties:
+                          users:
+                            description: Users represents a list of guest users that should have the ssh public keys added to their authorized_keys file.
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: set
+                        required:
+                        - users
+                        type: object
+                    type: object
+                  source:
+                    description: Source represents where the public keys are pulled from
+                    properties:
+                      secret:
+                        description: Secret means that the access credential is pulled from a kubernetes secret
+                        properties:
+                          secretName:
+                            description: SecretName represents the name of the secret in the VMI's namespace
+                            type: string
+                        required:
+                        - secretName
+                        type: object
+                    type: object
+                required:
+                - propagationMethod
+                - source
+                type: object
+              userPassword:
+                description: UserPassword represents the source and method for applying a guest user's password
+                properties:
+                  propagationMethod:
+                    description: propagationMethod represents how the user passwords are injected into the vm guest.
+                    properties:
+                      qemuGuestAgent:
+                        description: QemuGuestAgentAccessCredentailPropagation means passwords are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
+                        type: object
+                    type: object
+                  source:
+                    description: Source represents where the user passwords are pulled from
+                    properties:
+                      secret:
+                        description: Secret means that the access credential is pulled from a kubernetes secret
+                        properties:
+                          secretName:
+                            description: SecretName represents the name of the secret in the VMI's namespace
+                            type: string
+                        required:
+                        - secretName
+                        type: object
                     type: object
                 required:
                 - propagationMethod
@@ -3980,9 +5086,20 @@ var CRDsValidation map[string]string = map[string]string{
                 isolateEmulatorThread:
                   description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
                   type: boolean
+                maxSockets:
+                  description: MaxSockets specifies the maximum number of sockets the vmi can be scaled up to while it is running. The domain is defined with the additional sockets offline. Requires the CPUHotplug feature gate.
+                  format: int32
+                  type: integer
                 model:
                   description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                   type: string
+                numa:
+                  description: NUMA allows specifying settings for the guest NUMA topology.
+                  properties:
+                    guestMappingPassthrough:
+                      description: GuestMappingPassthrough creates a guest NUMA topology which mirrors the host NUMA nodes of the dedicated pCPUs and hugepages of the VMI. Guest NUMA nodes never cross host NUMA nodes. Requires DedicatedCPUPlacement, hugepages and the NUMA feature gate.
+                      type: object
+                  type: object
                 sockets:
                   description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                   format: int32
@@ -4065,6 +5182,66 @@ var CRDsValidation map[string]string = map[string]string{
                       io:
                         description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: IOTune limits the throughput and the I/O operations of the disk. It can be changed on a running VMI through the setiotune subresource.
+                        properties:
+                          burst:
+                            description: Burst allows exceeding the limits for a short time.
+                            properties:
+                              lengthSeconds:
+                                description: LengthSeconds is how long the burst limits can be sustained. Defaults to 1.
+                                format: int64
+                                type: integer
+                              readBytesSec:
+                                description: Read throughput burst limit in bytes per second.
+                                format: int64
+                                type: integer
+                              readIopsSec:
+                                description: Read I/O operations per second burst limit.
+                                format: int64
+                                type: integer
+                              totalBytesSec:
+                                description: Total throughput burst limit in bytes per second.
+                                format: int64
+                                type: integer
+                              totalIopsSec:
+                                description: Total I/O operations per second burst limit.
+                                format: int64
+                                type: integer
+                              writeBytesSec:
+                                description: Write throughput burst limit in bytes per second.
+                                format: int64
+                                type: integer
+                              writeIopsSec:
+                                description: Write I/O operations per second burst limit.
+                                format: int64
+                                type: integer
+                            type: object
+                          readBytesSec:
+                            description: Read throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          readIopsSec:
+                            description: Read I/O operations per second.
+                            format: int64
+                            type: integer
+                          totalBytesSec:
+                            description: Total throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          totalIopsSec:
+                            description: Total I/O operations per second.
+                            format: int64
+                            type: integer
+                          writeBytesSec:
+                            description: Write throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          writeIopsSec:
+                            description: Write I/O operations per second.
+                            format: int64
+                            type: integer
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -4156,6 +5333,55 @@ var CRDsValidation map[string]string = map[string]string{
                   description: Interfaces describe network interfaces which are added to the vmi.
                   items:
                     properties:
+                      bandwidth:
+                        description: If specified, limits the inbound and outbound traffic of the interface.
+                        properties:
+                          inbound:
+                            description: BandwidthLimits of a single traffic direction.
+                            properties:
+                              average:
+                                description: Average bit rate in kilobytes per second.
+                                format: int32
+                                type: integer
+                              burst:
+                                description: Amount of kilobytes that can be burst at peak speed.
+                                format: int32
+                                type: integer
+                              peak:
+                                description: Maximum rate at which the interface can send data, in kilobytes per second.
+                                format: int32
+                                type: integer
+                            required:
+                            - average
+                            type: object
+                          outbound:
+                            description: BandwidthLimits of a single traffic direction.
+                            properties:
+                              average:
+                                description: Average bit rate in kilobytes per second.
+                                format: int32
+                                type: integer
+                              burst:
+                                description: Amount of kilobytes that can be burst at peak speed.
+                                format: int32
+                                type: integer
+                              peak:
+                                description: Maximum rate at which the interface can send data, in kilobytes per second.
+                                format: int32
+                                type: integer
+                            required:
+                            - average
+                            type: object
+                        type: object
+                      binding:
+                        description: Binding specifies a network binding plugin which connects the interface to the guest. It can not be combined with the other binding methods.
+                        properties:
+                          name:
+                            description: Name of the network binding plugin.
+                            type: string
+                        required:
+                        - name
+                        type: object
                       bootOrder:
                         description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                         type: integer
@@ -4192,6 +5418,118 @@ var CRDsValidation map[string]string = map[string]string{
                             description: If specified will pass option 66 to interface's DHCP server
                             type: string
                         type: object
+                      firewall:
+                        description: If specified, filters the traffic of the interface in the virt-launcher pod. Only supported for bridge and masquerade interfaces.
+                        properties:
+                          egress:
+                            description: FirewallPolicy filters a single traffic direction.
+                            properties:
+                              defaultAction:
+                                description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                                type: string
+                              rules:
+                                description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                                items:
+                                  description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                                  properties:
+                                    action:
+                                      description: 'Action taken for the matching traffic. One of: allow, deny.'
+                                      type: string
+                                    cidr:
+                                      description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                                      type: string
+                                    port:
+                                      description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                                      format: int32
+                                      type: integer
+                                    protocol:
+                                      description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                                      type: string
+                                  required:
+                                  - action
+                                  type: object
+                                type: array
+                            type: object
+                          ingress:
+                            description: FirewallPolicy filters a single traffic direction.
+                            properties:
+                              defaultAction:
+                                description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                                type: string
+                              rules:
+                                description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                                items:
+                                  description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                                  properties:
+                                    action:
+                                      description: 'Action taken for the matching traffic. One of: allow, deny.'
+                                      type: string
+                                    cidr:
+                                      description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                                      type: string
+                                    port:
+                                      description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                                      format: int32
+                                      type: integer
+                                    protocol:
+                                      description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                                      type: string
+                                  required:
+                                  - action
+                                  type: object
+                                type: array
+                            type: object
+                        type: object
+                      ipam:
+                        description: If specified, the guest addresses of the interface are assigned statically instead of being discovered on the pod interface. Only supported for bridge interfaces on secondary Multus networks without an IPAM plugin.
+                        properties:
+                          addresses:
+                            description: Addresses of the interface in CIDR notation, e.g. 192.168.100.10/24 or fd10:100::10/64. At most one IPv4 and one IPv6 address can be specified.
+                            items:
+                              type: string
+                            type: array
+                          dns:
+                            description: DNS configuration of the interface. Defaults to the DNS configuration of the virt-launcher pod.
+                            properties:
+                              nameservers:
+                                description: Nameservers are the IP addresses of the DNS servers.
+                                items:
+                                  type: string
+                                type: array
+                              search:
+                                description: Search is the list of DNS search domains.
+                                items:
+                                  type: string
+                                type: array
+                            type: object
+                          gateway:
+                            description: Gateway is the IPv4 default gateway.
+                            type: string
+                          gatewayIPv6:
+                            description: GatewayIPv6 is the IPv6 default gateway. DHCPv6 can not announce gateways, it is only applied through cloud-init.
+                            type: string
+                          routes:
+                            description: Routes in addition to the default routes.
+                            items:
+                              description: InterfaceRoute is a static route of an interface.
+                              properties:
+                                destination:
+                                  description: Destination network in CIDR notation.
+                                  type: string
+                                gateway:
+                                  description: Gateway through which the destination is reached.
+                                  type: string
+                              required:
+                              - destination
+                              - gateway
+                              type: object
+                            type: array
+                        required:
+                        - addresses
+                        type: object
+                      linkState:
+                        description: 'LinkState of the virtual network interface as seen by the guest. One of: up, down. Defaults to up.'
+                        type: string
                       macAddress:
                         description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                         type: string
@@ -4234,6 +5572,9 @@ var CRDsValidation map[string]string = map[string]string{
                       tag:
                         description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
                         type: string
+                      vhostuser:
+                        description: InterfaceVhostUser connects to a vhost-user socket provided by a userspace switch, e.g. OVS-DPDK, through a Multus network.
+                        type: object
                     required:
                     - name
                     type: object
@@ -4244,6 +5585,13 @@ var CRDsValidation map[string]string = map[string]string{
                 rng:
                   description: Whether to have random number generator from host
                   type: object
+                tpm:
+                  description: Whether to emulate a TPM 2.0 device backed by a software TPM.
+                  properties:
+                    persistent:
+                      description: If set to true, the TPM state is stored in the persistent state volume of the VirtualMachine and survives restarts and migrations. Requires the VMPersistentState feature gate. Defaults to false
+                      type: boolean
+                  type: object
                 useVirtioTransitional:
                   description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
                   type: boolean
@@ -4437,6 +5785,9 @@ var CRDsValidation map[string]string = map[string]string{
                     efi:
                       description: If set, EFI will be used instead of BIOS.
                       properties:
+                        persistent:
+                          description: If set to true, the EFI NVRAM is stored in the persistent state volume of the VirtualMachine and survives restarts and migrations. Requires the VMPersistentState feature gate. Defaults to false
+                          type: boolean
                         secureBoot:
                           description: If set, SecureBoot will be enabled and the OVMF roms will be swapped for SecureBoot-enabled ones. Requires SMM to be enabled. Defaults to true
                           type: boolean
@@ -5016,6 +6367,88 @@ var CRDsValidation map[string]string = map[string]string{
             type: string
           description: ActivePods is a mapping of pod UID to node name. It is possible for multiple pods to be running for a single VMI during migration.
           type: object
+        backup:
+          description: Backup shows the backup job exporting the disks of the VirtualMachineInstance.
+          properties:
+            checkpoint:
+              description: The checkpoint created by the backup, empty if no disk supports changed block tracking
+              type: string
+            completed:
+              description: Indicates the backup ended
+              type: boolean
+            endTimestamp:
+              description: The time the backup ended
+              format: date-time
+              nullable: true
+              type: string
+            exports:
+              description: The disks exported by the NBD server
+              items:
+                description: BackupExport is a disk exported by the NBD server of a backup.
+                properties:
+                  dirtyBitmap:
+                    description: The NBD metadata context listing the changed blocks of an incremental backup, e.g. qemu:dirty-bitmap:backup-vda
+                    type: string
+                  exportName:
+                    description: The NBD export name of the disk
+                    type: string
+                  volumeName:
+                    description: The name of the volume
+                    type: string
+                required:
+                - exportName
+                - volumeName
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+            failed:
+              description: Indicates the backup failed
+              type: boolean
+            incremental:
+              description: The checkpoint the backup is incremental to, empty for full backups
+              type: string
+            message:
+              description: Details about the failure of the backup
+              type: string
+            name:
+              description: The name of the VirtualMachineBackup
+              type: string
+            startTimestamp:
+              description: The time the backup began
+              format: date-time
+              nullable: true
+              type: string
+            targetPodUID:
+              description: The UID of the pod the NBD server is made available to
+              type: string
+          required:
+          - name
+          type: object
+        checkpoints:
+          description: Checkpoints are the checkpoints created by backups, later backups can be incremental to them.
+          items:
+            description: VirtualMachineInstanceCheckpoint is a point in time the changed blocks of disks are tracked from.
+            properties:
+              creationTimestamp:
+                description: The time the checkpoint was created
+                format: date-time
+                type: string
+              name:
+                description: The name of the checkpoint
+                type: string
+              volumes:
+                description: The volumes whose changed blocks are tracked
+                items:
+                  type: string
+                type: array
+                x-kubernetes-list-type: atomic
+            required:
+            - creationTimestamp
+            - name
+            - volumes
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
         conditions:
           description: Conditions are specific points in VirtualMachineInstance's pod runtime.
           items:
@@ -5041,9 +6474,39 @@ var CRDsValidation map[string]string = map[string]string{
             - type
             type: object
           type: array
+        currentCPUTopology:
+          description: CurrentCPUTopology is the CPU topology currently plugged into the guest.
+          properties:
+            cores:
+              description: Cores is the number of cores per socket.
+              format: int32
+              type: integer
+            sockets:
+              description: Sockets is the number of sockets.
+              format: int32
+              type: integer
+            threads:
+              description: Threads is the number of threads per core.
+              format: int32
+              type: integer
+          type: object
         evacuationNodeName:
           description: EvacuationNodeName is used to track the eviction process of a VMI. It stores the name of the node that we want to evacuate. It is meant to be used by KubeVirt core components only and can't be set or modified by users.
           type: string
+        guestDNS:
+          description: DNS configuration of the guest as reported by the guest agent
+          properties:
+            nameservers:
+              description: IP addresses of the DNS servers of the guest
+              items:
+                type: string
+              type: array
+            search:
+              description: DNS search domains of the guest
+              items:
+                type: string
+              type: array
+          type: object
         guestOSInfo:
           description: Guest OS Information
           properties:
@@ -5076,6 +6539,68 @@ var CRDsValidation map[string]string = map[string]string{
           description: Interfaces represent the details of available network interfaces.
           items:
             properties:
+              firewall:
+                description: Firewall rules enforced for the interface in the virt-launcher pod
+                properties:
+                  egress:
+                    description: FirewallPolicy filters a single traffic direction.
+                    properties:
+                      defaultAction:
+                        description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                        type: string
+                      rules:
+                        description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                        items:
+                          description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                          properties:
+                            action:
+                              description: 'Action taken for the matching traffic. One of: allow, deny.'
+                              type: string
+                            cidr:
+                              description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                              type: string
+                            port:
+                              description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                              format: int32
+                              type: integer
+                            protocol:
+                              description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                              type: string
+                          required:
+                          - action
+                          type: object
+                        type: array
+                    type: object
+                  ingress:
+                    description: FirewallPolicy filters a single traffic direction.
+                    properties:
+                      defaultAction:
+                        description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                        type: string
+                      rules:
+                        description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                        items:
+                          description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                          properties:
+                            action:
+                              description: 'Action taken for the matching traffic. One of: allow, deny.'
+                              type: string
+                            cidr:
+                              description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                              type: string
+                            port:
+                              description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                              format: int32
+                              type: integer
+                            protocol:
+                              description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                              type: string
+                          required:
+                          - action
+                          type: object
+                        type: array
+                    type: object
+                type: object
               interfaceName:
                 description: The interface name inside the Virtual Machine
                 type: string
@@ -5087,17 +6612,74 @@ var CRDsValidation map[string]string = map[string]string{
                 items:
                   type: string
                 type: array
+              ipDetails:
+                description: The IP addresses of the interface together with their source
+                items:
+                  properties:
+                    ip:
+                      description: The IP address
+                      type: string
+                    prefixLength:
+                      description: Prefix length of the network of the IP address, if known
+                      type: integer
+                    source:
+                      description: 'Source of the IP address: pod, dhcp or guest-agent'
+                      type: string
+                  required:
+                  - ip
+                  - source
+                  type: object
+                type: array
+              linkState:
+                description: 'Link state of the virtual network interface: up or down'
+                type: string
               mac:
                 description: Hardware address of a Virtual Machine interface
                 type: string
+              mtu:
+                description: MTU of the virtual network interface
+                type: integer
               name:
                 description: 'Name of the interface, corresponds to name of the network assigned to the interface TODO: remove omitempty, when api breaking changes are allowed'
                 type: string
+              routes:
+                description: Routes of the guest through the interface as reported by the guest agent
+                items:
+                  properties:
+                    destination:
+                      description: Destination network in CIDR notation
+                      type: string
+                    gateway:
+                      description: Gateway of the route, empty for directly connected networks
+                      type: string
+                    metric:
+                      description: Metric of the route
+                      type: integer
+                  required:
+                  - destination
+                  type: object
+                type: array
             type: object
           type: array
         launcherContainerImageVersion:
           description: LauncherContainerImageVersion indicates what container image is currently active for the vmi.
           type: string
+        maxCPUTopology:
+          description: MaxCPUTopology is the CPU topology the guest can be scaled up to without a restart.
+          properties:
+            cores:
+              description: Cores is the number of cores per socket.
+              format: int32
+              type: integer
+            sockets:
+              description: Sockets is the number of sockets.
+              format: int32
+              type: integer
+            threads:
+              description: Threads is the number of threads per core.
+              format: int32
+              type: integer
+          type: object
         migrationMethod:
           description: 'Represents the method using which the vmi can be migrated: live migration or block migration'
           type: string
@@ -5113,6 +6695,21 @@ var CRDsValidation map[string]string = map[string]string{
             completed:
               description: Indicates the migration completed
               type: boolean
+            diskCopyProgress:
+              description: The progress of the copy of the non-shared disks to the target
+              properties:
+                processedBytes:
+                  description: The bytes copied to the target so far
+                  format: int64
+                  type: integer
+                totalBytes:
+                  description: The size of the disks to copy in bytes
+                  format: int64
+                  type: integer
+              required:
+              - processedBytes
+              - totalBytes
+              type: object
             endTimestamp:
               description: The time the migration action ended
               format: date-time
@@ -5165,6 +6762,37 @@ var CRDsValidation map[string]string = map[string]string{
         reason:
           description: A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'
           type: string
+        volumeMigration:
+          description: VolumeMigration shows the volumes which are copied to new claims by a live migration.
+          properties:
+            migratedVolumes:
+              description: The volumes copied to new claims
+              items:
+                description: MigratedVolume is a volume copied from one claim to another.
+                properties:
+                  destinationClaim:
+                    description: The claim the volume is copied to
+                    type: string
+                  sourceClaim:
+                    description: The claim the volume is copied from
+                    type: string
+                  volumeName:
+                    description: The name of the volume
+                    type: string
+                required:
+                - destinationClaim
+                - sourceClaim
+                - volumeName
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+            migrationName:
+              description: The name of the VirtualMachineInstanceMigration copying the volumes
+              type: string
+          required:
+          - migratedVolumes
+          - migrationName
+          type: object
         volumeStatus:
           description: VolumeStatus contains the statuses of all the volumes
           items:
@@ -5186,12 +6814,38 @@ var CRDsValidation map[string]string = map[string]string{
               name:
                 description: Name is the name of the volume
                 type: string
+              persistentVolumeClaimInfo:
+                description: PersistentVolumeClaimInfo is information about the PVC backing the volume
+                properties:
+                  accessModes:
+                    description: AccessModes contains the access modes of the PVC
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  capacity:
+                    additionalProperties:
+                      anyOf:
+                      - type: integer
+                      - type: string
+                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                      x-kubernetes-int-or-string: true
+                    description: Capacity is the capacity reported in the PVC status
+                    type: object
+                  volumeMode:
+                    description: VolumeMode is the volume mode of the PVC
+                    type: string
+                type: object
               phase:
                 description: Phase is the phase
                 type: string
               reason:
                 description: Reason is a brief description of why we are in the current hotplug volume phase
                 type: string
+              size:
+                description: Size is the size of the disk as seen by the guest, in bytes
+                format: int64
+                type: integer
               target:
                 description: 'Target is the target name used when adding the volume to the VM, eg: vda'
                 type: string
@@ -5382,9 +7036,20 @@ var CRDsValidation map[string]string = map[string]string{
                 isolateEmulatorThread:
                   description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
                   type: boolean
+                maxSockets:
+                  description: MaxSockets specifies the maximum number of sockets the vmi can be scaled up to while it is running. The domain is defined with the additional sockets offline. Requires the CPUHotplug feature gate.
+                  format: int32
+                  type: integer
                 model:
                   description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                   type: string
+                numa:
+                  description: NUMA allows specifying settings for the guest NUMA topology.
+                  properties:
+                    guestMappingPassthrough:
+                      description: GuestMappingPassthrough creates a guest NUMA topology which mirrors the host NUMA nodes of the dedicated pCPUs and hugepages of the VMI. Guest NUMA nodes never cross host NUMA nodes. Requires DedicatedCPUPlacement, hugepages and the NUMA feature gate.
+                      type: object
+                  type: object
                 sockets:
                   description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                   format: int32
@@ -5467,6 +7132,66 @@ var CRDsValidation map[string]string = map[string]string{
                       io:
                         description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: IOTune limits the throughput and the I/O operations of the disk. It can be changed on a running VMI through the setiotune subresource.
+                        properties:
+                          burst:
+                            description: Burst allows exceeding the limits for a short time.
+                            properties:
+                              lengthSeconds:
+                                description: LengthSeconds is how long the burst limits can be sustained. Defaults to 1.
+                                format: int64
+                                type: integer
+                              readBytesSec:
+                                description: Read throughput burst limit in bytes per second.
+                                format: int64
+                                type: integer
+                              readIopsSec:
+                                description: Read I/O operations per second burst limit.
+                                format: int64
+                                type: integer
+                              totalBytesSec:
+                                description: Total throughput burst limit in bytes per second.
+                                format: int64
+                                type: integer
+                              totalIopsSec:
+                                description: Total I/O operations per second burst limit.
+                                format: int64
+                                type: integer
+                              writeBytesSec:
+                                description: Write throughput burst limit in bytes per second.
+                                format: int64
+                                type: integer
+                              writeIopsSec:
+                                description: Write I/O operations per second burst limit.
+                                format: int64
+                                type: integer
+                            type: object
+                          readBytesSec:
+                            description: Read throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          readIopsSec:
+                            description: Read I/O operations per second.
+                            format: int64
+                            type: integer
+                          totalBytesSec:
+                            description: Total throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          totalIopsSec:
+                            description: Total I/O operations per second.
+                            format: int64
+                            type: integer
+                          writeBytesSec:
+                            description: Write throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          writeIopsSec:
+                            description: Write I/O operations per second.
+                            format: int64
+                            type: integer
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -5558,6 +7283,55 @@ var CRDsValidation map[string]string = map[string]string{
                   description: Interfaces describe network interfaces which are added to the vmi.
                   items:
                     properties:
+                      bandwidth:
+                        description: If specified, limits the inbound and outbound traffic of the interface.
+                        properties:
+                          inbound:
+                            description: BandwidthLimits of a single traffic direction.
+                            properties:
+                              average:
+                                description: Average bit rate in kilobytes per second.
+                                format: int32
+                                type: integer
+                              burst:
+                                description: Amount of kilobytes that can be burst at peak speed.
+                                format: int32
+                                type: integer
+                              peak:
+                                description: Maximum rate at which the interface can send data, in kilobytes per second.
+                                format: int32
+                                type: integer
+                            required:
+                            - average
+                            type: object
+                          outbound:
+                            description: BandwidthLimits of a single traffic direction.
+                            properties:
+                              average:
+                                description: Average bit rate in kilobytes per second.
+                                format: int32
+                                type: integer
+                              burst:
+                                description: Amount of kilobytes that can be burst at peak speed.
+                                format: int32
+                                type: integer
+                              peak:
+                                description: Maximum rate at which the interface can send data, in kilobytes per second.
+                                format: int32
+                                type: integer
+                            required:
+                            - average
+                            type: object
+                        type: object
+                      binding:
+                        description: Binding specifies a network binding plugin which connects the interface to the guest. It can not be combined with the other binding methods.
+                        properties:
+                          name:
+                            description: Name of the network binding plugin.
+                            type: string
+                        required:
+                        - name
+                        type: object
                       bootOrder:
                         description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                         type: integer
@@ -5577,23 +7351,135 @@ var CRDsValidation map[string]string = map[string]string{
                           privateOptions:
                             description: 'If specified will pass extra DHCP options for private use, range: 224-254'
                             items:
-                              description: DHCPExtraOptions defines Extra DHCP options for a VM.
+                              description: DHCPExtraOptions defines Extra DHCP options for a VM.
+                              properties:
+                                option:
+                                  description: Option is an Integer value from 224-254 Required.
+                                  type: integer
+                                value:
+                                  description: Value is a String value for the Option provided Required.
+                                  type: string
+                              required:
+                              - option
+                              - value
+                              type: object
+                            type: array
+                          tftpServerName:
+                            description: If specified will pass option 66 to interface's DHCP server
+                            type: string
+                        type: object
+                      firewall:
+                        description: If specified, filters the traffic of the interface in the virt-launcher pod. Only supported for bridge and masquerade interfaces.
+                        properties:
+                          egress:
+                            description: FirewallPolicy filters a single traffic direction.
+                            properties:
+                              defaultAction:
+                                description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                                type: string
+                              rules:
+                                description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                                items:
+                                  description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                                  properties:
+                                    action:
+                                      description: 'Action taken for the matching traffic. One of: allow, deny.'
+                                      type: string
+                                    cidr:
+                                      description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                                      type: string
+                                    port:
+                                      description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                                      format: int32
+                                      type: integer
+                                    protocol:
+                                      description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                                      type: string
+                                  required:
+                                  - action
+                                  type: object
+                                type: array
+                            type: object
+                          ingress:
+                            description: FirewallPolicy filters a single traffic direction.
+                            properties:
+                              defaultAction:
+                                description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                                type: string
+                              rules:
+                                description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                                items:
+                                  description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                                  properties:
+                                    action:
+                                      description: 'Action taken for the matching traffic. One of: allow, deny.'
+                                      type: string
+                                    cidr:
+                                      description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                                      type: string
+                                    port:
+                                      description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                                      format: int32
+                                      type: integer
+                                    protocol:
+                                      description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                                      type: string
+                                  required:
+                                  - action
+                                  type: object
+                                type: array
+                            type: object
+                        type: object
+                      ipam:
+                        description: If specified, the guest addresses of the interface are assigned statically instead of being discovered on the pod interface. Only supported for bridge interfaces on secondary Multus networks without an IPAM plugin.
+                        properties:
+                          addresses:
+                            description: Addresses of the interface in CIDR notation, e.g. 192.168.100.10/24 or fd10:100::10/64. At most one IPv4 and one IPv6 address can be specified.
+                            items:
+                              type: string
+                            type: array
+                          dns:
+                            description: DNS configuration of the interface. Defaults to the DNS configuration of the virt-launcher pod.
+                            properties:
+                              nameservers:
+                                description: Nameservers are the IP addresses of the DNS servers.
+                                items:
+                                  type: string
+                                type: array
+                              search:
+                                description: Search is the list of DNS search domains.
+                                items:
+                                  type: string
+                                type: array
+                            type: object
+                          gateway:
+                            description: Gateway is the IPv4 default gateway.
+                            type: string
+                          gatewayIPv6:
+                            description: GatewayIPv6 is the IPv6 default gateway. DHCPv6 can not announce gateways, it is only applied through cloud-init.
+                            type: string
+                          routes:
+                            description: Routes in addition to the default routes.
+                            items:
+                              description: InterfaceRoute is a static route of an interface.
                               properties:
-                                option:
-                                  description: Option is an Integer value from 224-254 Required.
-                                  type: integer
-                                value:
-                                  description: Value is a String value for the Option provided Required.
+                                destination:
+                                  description: Destination network in CIDR notation.
+                                  type: string
+                                gateway:
+                                  description: Gateway through which the destination is reached.
                                   type: string
                               required:
-                              - option
-                              - value
+                              - destination
+                              - gateway
                               type: object
                             type: array
-                          tftpServerName:
-                            description: If specified will pass option 66 to interface's DHCP server
-                            type: string
+                        required:
+                        - addresses
                         type: object
+                      linkState:
+                        description: 'LinkState of the virtual network interface as seen by the guest. One of: up, down. Defaults to up.'
+                        type: string
                       macAddress:
                         description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                         type: string
@@ -5636,6 +7522,9 @@ var CRDsValidation map[string]string = map[string]string{
                       tag:
                         description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
                         type: string
+                      vhostuser:
+                        description: InterfaceVhostUser connects to a vhost-user socket provided by a userspace switch, e.g. OVS-DPDK, through a Multus network.
+                        type: object
                     required:
                     - name
                     type: object
@@ -5646,6 +7535,13 @@ var CRDsValidation map[string]string = map[string]string{
                 rng:
                   description: Whether to have random number generator from host
                   type: object
+                tpm:
+                  description: Whether to emulate a TPM 2.0 device backed by a software TPM.
+                  properties:
+                    persistent:
+                      description: If set to true, the TPM state is stored in the persistent state volume of the VirtualMachine and survives restarts and migrations. Requires the VMPersistentState feature gate. Defaults to false
+                      type: boolean
+                  type: object
                 useVirtioTransitional:
                   description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
                   type: boolean
@@ -5839,6 +7735,9 @@ var CRDsValidation map[string]string = map[string]string{
                     efi:
                       description: If set, EFI will be used instead of BIOS.
                       properties:
+                        persistent:
+                          description: If set to true, the EFI NVRAM is stored in the persistent state volume of the VirtualMachine and survives restarts and migrations. Requires the VMPersistentState feature gate. Defaults to false
+                          type: boolean
                         secureBoot:
                           description: If set, SecureBoot will be enabled and the OVMF roms will be swapped for SecureBoot-enabled ones. Requires SMM to be enabled. Defaults to true
                           type: boolean
@@ -6559,9 +8458,20 @@ var CRDsValidation map[string]string = map[string]string{
                         isolateEmulatorThread:
                           description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
                           type: boolean
+                        maxSockets:
+                          description: MaxSockets specifies the maximum number of sockets the vmi can be scaled up to while it is running. The domain is defined with the additional sockets offline. Requires the CPUHotplug feature gate.
+                          format: int32
+                          type: integer
                         model:
                           description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                           type: string
+                        numa:
+                          description: NUMA allows specifying settings for the guest NUMA topology.
+                          properties:
+                            guestMappingPassthrough:
+                              description: GuestMappingPassthrough creates a guest NUMA topology which mirrors the host NUMA nodes of the dedicated pCPUs and hugepages of the VMI. Guest NUMA nodes never cross host NUMA nodes. Requires DedicatedCPUPlacement, hugepages and the NUMA feature gate.
+                              type: object
+                          type: object
                         sockets:
                           description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                           format: int32
@@ -6644,6 +8554,66 @@ var CRDsValidation map[string]string = map[string]string{
                               io:
                                 description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                                 type: string
+                              ioTune:
+                                description: IOTune limits the throughput and the I/O operations of the disk. It can be changed on a running VMI through the setiotune subresource.
+                                properties:
+                                  burst:
+                                    description: Burst allows exceeding the limits for a short time.
+                                    properties:
+                                      lengthSeconds:
+                                        description: LengthSeconds is how long the burst limits can be sustained. Defaults to 1.
+                                        format: int64
+                                        type: integer
+                                      readBytesSec:
+                                        description: Read throughput burst limit in bytes per second.
+                                        format: int64
+                                        type: integer
+                                      readIopsSec:
+                                        description: Read I/O operations per second burst limit.
+                                        format: int64
+                                        type: integer
+                                      totalBytesSec:
+                                        description: Total throughput burst limit in bytes per second.
+                                        format: int64
+                                        type: integer
+                                      totalIopsSec:
+                                        description: Total I/O operations per second burst limit.
+                                        format: int64
+                                        type: integer
+                                      writeBytesSec:
+                                        description: Write throughput burst limit in bytes per second.
+                                        format: int64
+                                        type: integer
+                                      writeIopsSec:
+                                        description: Write I/O operations per second burst limit.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                  readBytesSec:
+                                    description: Read throughput limit in bytes per second.
+                                    format: int64
+                                    type: integer
+                                  readIopsSec:
+                                    description: Read I/O operations per second.
+                                    format: int64
+                                    type: integer
+                                  totalBytesSec:
+                                    description: Total throughput limit in bytes per second.
+                                    format: int64
+                                    type: integer
+                                  totalIopsSec:
+                                    description: Total I/O operations per second.
+                                    format: int64
+                                    type: integer
+                                  writeBytesSec:
+                                    description: Write throughput limit in bytes per second.
+                                    format: int64
+                                    type: integer
+                                  writeIopsSec:
+                                    description: Write I/O operations per second.
+                                    format: int64
+                                    type: integer
+                                type: object
                               lun:
                                 description: Attach a volume as a LUN to the vmi.
                                 properties:
@@ -6735,6 +8705,55 @@ var CRDsValidation map[string]string = map[string]string{
                           description: Interfaces describe network interfaces which are added to the vmi.
                           items:
                             properties:
+                              bandwidth:
+                                description: If specified, limits the inbound and outbound traffic of the interface.
+                                properties:
+                                  inbound:
+                                    description: BandwidthLimits of a single traffic direction.
+                                    properties:
+                                      average:
+                                        description: Average bit rate in kilobytes per second.
+                                        format: int32
+                                        type: integer
+                                      burst:
+                                        description: Amount of kilobytes that can be burst at peak speed.
+                                        format: int32
+                                        type: integer
+                                      peak:
+                                        description: Maximum rate at which the interface can send data, in kilobytes per second.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - average
+                                    type: object
+                                  outbound:
+                                    description: BandwidthLimits of a single traffic direction.
+                                    properties:
+                                      average:
+                                        description: Average bit rate in kilobytes per second.
+                                        format: int32
+                                        type: integer
+                                      burst:
+                                        description: Amount of kilobytes that can be burst at peak speed.
+                                        format: int32
+                                        type: integer
+                                      peak:
+                                        description: Maximum rate at which the interface can send data, in kilobytes per second.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - average
+                                    type: object
+                                type: object
+                              binding:
+                                description: Binding specifies a network binding plugin which connects the interface to the guest. It can not be combined with the other binding methods.
+                                properties:
+                                  name:
+                                    description: Name of the network binding plugin.
+                                    type: string
+                                required:
+                                - name
+                                type: object
                               bootOrder:
                                 description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                                 type: integer
@@ -6771,6 +8790,118 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: If specified will pass option 66 to interface's DHCP server
                                     type: string
                                 type: object
+                              firewall:
+                                description: If specified, filters the traffic of the interface in the virt-launcher pod. Only supported for bridge and masquerade interfaces.
+                                properties:
+                                  egress:
+                                    description: FirewallPolicy filters a single traffic direction.
+                                    properties:
+                                      defaultAction:
+                                        description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                                        type: string
+                                      rules:
+                                        description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                                        items:
+                                          description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                                          properties:
+                                            action:
+                                              description: 'Action taken for the matching traffic. One of: allow, deny.'
+                                              type: string
+                                            cidr:
+                                              description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                                              type: string
+                                            port:
+                                              description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                                              format: int32
+                                              type: integer
+                                            protocol:
+                                              description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                                              type: string
+                                          required:
+                                          - action
+                                          type: object
+                                        type: array
+                                    type: object
+                                  ingress:
+                                    description: FirewallPolicy filters a single traffic direction.
+                                    properties:
+                                      defaultAction:
+                                        description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                                        type: string
+                                      rules:
+                                        description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                                        items:
+                                          description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                                          properties:
+                                            action:
+                                              description: 'Action taken for the matching traffic. One of: allow, deny.'
+                                              type: string
+                                            cidr:
+                                              description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                                              type: string
+                                            port:
+                                              description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                                              format: int32
+                                              type: integer
+                                            protocol:
+                                              description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                                              type: string
+                                          required:
+                                          - action
+                                          type: object
+                                        type: array
+                                    type: object
+                                type: object
+                              ipam:
+                                description: If specified, the guest addresses of the interface are assigned statically instead of being discovered on the pod interface. Only supported for bridge interfaces on secondary Multus networks without an IPAM plugin.
+                                properties:
+                                  addresses:
+                                    description: Addresses of the interface in CIDR notation, e.g. 192.168.100.10/24 or fd10:100::10/64. At most one IPv4 and one IPv6 address can be specified.
+                                    items:
+                                      type: string
+                                    type: array
+                                  dns:
+                                    description: DNS configuration of the interface. Defaults to the DNS configuration of the virt-launcher pod.
+                                    properties:
+                                      nameservers:
+                                        description: Nameservers are the IP addresses of the DNS servers.
+                                        items:
+                                          type: string
+                                        type: array
+                                      search:
+                                        description: Search is the list of DNS search domains.
+                                        items:
+                                          type: string
+                                        type: array
+                                    type: object
+                                  gateway:
+                                    description: Gateway is the IPv4 default gateway.
+                                    type: string
+                                  gatewayIPv6:
+                                    description: GatewayIPv6 is the IPv6 default gateway. DHCPv6 can not announce gateways, it is only applied through cloud-init.
+                                    type: string
+                                  routes:
+                                    description: Routes in addition to the default routes.
+                                    items:
+                                      description: InterfaceRoute is a static route of an interface.
+                                      properties:
+                                        destination:
+                                          description: Destination network in CIDR notation.
+                                          type: string
+                                        gateway:
+                                          description: Gateway through which the destination is reached.
+                                          type: string
+                                      required:
+                                      - destination
+                                      - gateway
+                                      type: object
+                                    type: array
+                                required:
+                                - addresses
+                                type: object
+                              linkState:
+                                description: 'LinkState of the virtual network interface as seen by the guest. One of: up, down. Defaults to up.'
+                                type: string
                               macAddress:
                                 description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                                 type: string
@@ -6813,6 +8944,9 @@ var CRDsValidation map[string]string = map[string]string{
                               tag:
                                 description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
                                 type: string
+                              vhostuser:
+                                description: InterfaceVhostUser connects to a vhost-user socket provided by a userspace switch, e.g. OVS-DPDK, through a Multus network.
+                                type: object
                             required:
                             - name
                             type: object
@@ -6823,6 +8957,13 @@ var CRDsValidation map[string]string = map[string]string{
                         rng:
                           description: Whether to have random number generator from host
                           type: object
+                        tpm:
+                          description: Whether to emulate a TPM 2.0 device backed by a software TPM.
+                          properties:
+                            persistent:
+                              description: If set to true, the TPM state is stored in the persistent state volume of the VirtualMachine and survives restarts and migrations. Requires the VMPersistentState feature gate. Defaults to false
+                              type: boolean
+                          type: object
                         useVirtioTransitional:
                           description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
                           type: boolean
@@ -7016,6 +9157,9 @@ var CRDsValidation map[string]string = map[string]string{
                             efi:
                               description: If set, EFI will be used instead of BIOS.
                               properties:
+                                persistent:
+                                  description: If set to true, the EFI NVRAM is stored in the persistent state volume of the VirtualMachine and survives restarts and migrations. Requires the VMPersistentState feature gate. Defaults to false
+                                  type: boolean
                                 secureBoot:
                                   description: If set, SecureBoot will be enabled and the OVMF roms will be swapped for SecureBoot-enabled ones. Requires SMM to be enabled. Defaults to true
                                   type: boolean
@@ -7588,49 +9732,385 @@ var CRDsValidation map[string]string = map[string]string{
               - domain
               type: object
           type: object
-      required:
-      - selector
-      - template
-      type: object
-    status:
-      description: Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.
-      nullable: true
-      properties:
-        conditions:
-          items:
-            properties:
-              lastProbeTime:
-                format: date-time
-                nullable: true
-                type: string
-              lastTransitionTime:
-                format: date-time
-                nullable: true
-                type: string
-              message:
-                type: string
-              reason:
-                type: string
-              status:
-                type: string
-              type:
-                type: string
-            required:
-            - status
-            - type
-            type: object
-          type: array
-        labelSelector:
-          description: Canonical form of the label selector for HPA which consumes it through the scale subresource.
-          type: string
-        readyReplicas:
-          description: The number of ready replicas for this replica set.
-          format: int32
-          type: integer
-        replicas:
-          description: Total number of non-terminated pods targeted by this deployment (their labels match the selector).
-          format: int32
-          type: integer
+      required:
+      - selector
+      - template
+      type: object
+    status:
+      description: Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.
+      nullable: true
+      properties:
+        conditions:
+          items:
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+        labelSelector:
+          description: Canonical form of the label selector for HPA which consumes it through the scale subresource.
+          type: string
+        readyReplicas:
+          description: The number of ready replicas for this replica set.
+          format: int32
+          type: integer
+        replicas:
+          description: Total number of non-terminated pods targeted by this deployment (their labels match the selector).
+          format: int32
+          type: integer
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineinstancetype": `openAPIV3Schema:
+  description: VirtualMachineInstancetype resource contains quantitative and resource related VirtualMachine configuration that can be used by multiple VirtualMachine resources.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: Required spec describing the instancetype
+      properties:
+        cpu:
+          description: Required CPU related attributes of the instancetype.
+          properties:
+            dedicatedCPUPlacement:
+              description: DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node with enough dedicated pCPUs and pin the vCPUs to it.
+              type: boolean
+            guest:
+              description: "Required number of vCPUs to expose to the guest. \n The resulting CPU topology being derived from the optional PreferredCPUTopology attribute of CPUPreferences that itself defaults to PreferSockets."
+              format: int32
+              type: integer
+            isolateEmulatorThread:
+              description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
+              type: boolean
+            model:
+              description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
+              type: string
+          required:
+          - guest
+          type: object
+        gpus:
+          description: Optionally defines any GPU devices associated with the instancetype.
+          items:
+            properties:
+              deviceName:
+                type: string
+              name:
+                description: Name of the GPU device as exposed by a device plugin
+                type: string
+            required:
+            - deviceName
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        hostDevices:
+          description: Optionally defines any HostDevices associated with the instancetype.
+          items:
+            properties:
+              deviceName:
+                description: DeviceName is the resource name of the host device exposed by a device plugin
+                type: string
+              name:
+                type: string
+            required:
+            - deviceName
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        ioThreadsPolicy:
+          description: Optionally defines the IOThreadsPolicy to be used by the instancetype.
+          type: string
+        memory:
+          description: Required Memory related attributes of the instancetype.
+          properties:
+            guest:
+              anyOf:
+              - type: integer
+              - type: string
+              description: Required amount of memory which is visible inside the guest OS.
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            hugepages:
+              description: Optionally enables the use of hugepages for the VirtualMachineInstance instead of regular memory.
+              properties:
+                pageSize:
+                  description: PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
+                  type: string
+              type: object
+          required:
+          - guest
+          type: object
+      required:
+      - cpu
+      - memory
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachinepreference": `openAPIV3Schema:
+  description: VirtualMachinePreference resource contains optional preferences related to the VirtualMachine.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: Required spec describing the preferences
+      properties:
+        cpu:
+          description: CPU optionally defines preferences associated with the CPU attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredCPUTopology:
+              description: PreferredCPUTopology optionally defines the preferred guest visible CPU topology, defaults to PreferSockets.
+              type: string
+          type: object
+        devices:
+          description: Devices optionally defines preferences associated with the Devices attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredAutoattachGraphicsDevice:
+              description: PreferredAutoattachGraphicsDevice optionally defines the preferred value of AutoattachGraphicsDevice
+              type: boolean
+            preferredAutoattachMemBalloon:
+              description: PreferredAutoattachMemBalloon optionally defines the preferred value of AutoattachMemBalloon
+              type: boolean
+            preferredAutoattachPodInterface:
+              description: PreferredAutoattachPodInterface optionally defines the preferred value of AutoattachPodInterface
+              type: boolean
+            preferredAutoattachSerialConsole:
+              description: PreferredAutoattachSerialConsole optionally defines the preferred value of AutoattachSerialConsole
+              type: boolean
+            preferredBlockMultiQueue:
+              description: PreferredBlockMultiQueue optionally enables the vhost multiqueue feature for virtio disks.
+              type: boolean
+            preferredCdromBus:
+              description: PreferredCdromBus optionally defines the preferred bus for Cdrom Disk devices.
+              type: string
+            preferredDiskBus:
+              description: PreferredDiskBus optionally defines the preferred bus for Disk Disk devices.
+              type: string
+            preferredInputBus:
+              description: PreferredInputBus optionally defines the preferred bus for Input devices.
+              type: string
+            preferredInputType:
+              description: PreferredInputType optionally defines the preferred type for Input devices.
+              type: string
+            preferredInterfaceModel:
+              description: PreferredInterfaceModel optionally defines the preferred model to be used by Interface devices.
+              type: string
+            preferredLunBus:
+              description: PreferredLunBus optionally defines the preferred bus for Lun Disk devices.
+              type: string
+            preferredNetworkInterfaceMultiQueue:
+              description: PreferredNetworkInterfaceMultiQueue optionally enables the vhost multiqueue feature for virtio interfaces.
+              type: boolean
+            preferredUseVirtioTransitional:
+              description: PreferredUseVirtioTransitional optionally defines the preferred value of UseVirtioTransitional
+              type: boolean
+          type: object
+        features:
+          description: Features optionally defines preferences associated with the Features attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredAcpi:
+              description: PreferredAcpi optionally enables the ACPI feature
+              properties:
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
+              type: object
+            preferredApic:
+              description: PreferredApic optionally enables and configures the APIC feature
+              properties:
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
+                endOfInterrupt:
+                  description: EndOfInterrupt enables the end of interrupt notification in the guest. Defaults to false.
+                  type: boolean
+              type: object
+            preferredHyperv:
+              description: PreferredHyperv optionally enables and configures HyperV features
+              properties:
+                evmcs:
+                  description: EVMCS Speeds up L2 vmexits, but disables other virtualization features. Requires vapic. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                frequencies:
+                  description: Frequencies improves the TSC clock source handling for Hyper-V on KVM. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                ipi:
+                  description: IPI improves performances in overcommited environments. Requires vpindex. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                reenlightenment:
+                  description: Reenlightenment enables the notifications on TSC frequency changes. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                relaxed:
+                  description: Relaxed instructs the guest OS to disable watchdog timeouts. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                reset:
+                  description: Reset enables Hyperv reboot/reset for the vmi. Requires synic. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                runtime:
+                  description: Runtime improves the time accounting to improve scheduling in the guest. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                spinlocks:
+                  description: Spinlocks allows to configure the spinlock retry attempts.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                    spinlocks:
+                      description: Retries indicates the number of retries. Must be a value greater or equal 4096. Defaults to 4096.
+                      format: int32
+                      type: integer
+                  type: object
+                synic:
+                  description: SyNIC enables the Synthetic Interrupt Controller. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                synictimer:
+                  description: SyNICTimer enables Synthetic Interrupt Controller Timers, reducing CPU load. Defaults to the machine type setting.
+                  properties:
+                    direct:
+                      description: Represents if a feature is enabled or disabled.
+                      properties:
+                        enabled:
+                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                          type: boolean
+                      type: object
+                    enabled:
+                      type: boolean
+                  type: object
+                tlbflush:
+                  description: TLBFlush improves performances in overcommited environments. Requires vpindex. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                vapic:
+                  description: VAPIC improves the paravirtualized handling of interrupts. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                vendorid:
+                  description: VendorID allows setting the hypervisor vendor id. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                    vendorid:
+                      description: VendorID sets the hypervisor vendor id, visible to the vmi. String up to twelve characters.
+                      type: string
+                  type: object
+                vpindex:
+                  description: VPIndex enables the Virtual Processor Index to help windows identifying virtual processors. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+              type: object
+            preferredKvm:
+              description: PreferredKvm optionally enables and configures KVM features
+              properties:
+                hidden:
+                  description: Hide the KVM hypervisor from standard MSR based discovery. Defaults to false
+                  type: boolean
+              type: object
+            preferredSmm:
+              description: PreferredSmm optionally enables the SMM feature
+              properties:
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
+              type: object
+          type: object
+        firmware:
+          description: Firmware optionally defines preferences associated with the Firmware attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredUseBios:
+              description: PreferredUseBios optionally enables BIOS
+              type: boolean
+            preferredUseBiosSerial:
+              description: "PreferredUseBiosSerial optionally transmits BIOS output over the serial. \n Requires PreferredUseBios to be enabled."
+              type: boolean
+            preferredUseEfi:
+              description: PreferredUseEfi optionally enables EFI
+              type: boolean
+            preferredUseSecureBoot:
+              description: "PreferredUseSecureBoot optionally enables SecureBoot and the OVMF roms will be swapped for SecureBoot-enabled ones. \n Requires PreferredUseEfi and PreferredSmm to be enabled."
+              type: boolean
+          type: object
+        machine:
+          description: Machine optionally defines preferences associated with the Machine attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredMachineType:
+              description: PreferredMachineType optionally defines the preferred machine type to use.
+              type: string
+          type: object
       type: object
   required:
   - spec
@@ -7650,6 +10130,11 @@ var CRDsValidation map[string]string = map[string]string{
     spec:
       description: VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource
       properties:
+        patches:
+          description: Patches are JSON patches applied to the restored VirtualMachine, only allowed for the restore of a VirtualMachineClone and limited to its labels, annotations and template
+          items:
+            type: string
+          type: array
         target:
           description: initially only VirtualMachine type supported
           properties:
@@ -8104,6 +10589,30 @@ var CRDsValidation map[string]string = map[string]string{
                         - spec
                         type: object
                       type: array
+                    instancetype:
+                      description: Instancetype references a resource that contains quantitative and resource related VirtualMachine configuration that is expanded into the VirtualMachineInstance.
+                      properties:
+                        kind:
+                          description: 'Kind specifies which instancetype resource is referenced. Allowed values are: "VirtualMachineInstancetype" and "VirtualMachineClusterInstancetype". If not specified, "VirtualMachineClusterInstancetype" is used by default.'
+                          type: string
+                        name:
+                          description: Name is the name of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype
+                          type: string
+                      required:
+                      - name
+                      type: object
+                    preference:
+                      description: Preference references a resource that contains a set of preferred values that are only applied when not already provided by the VirtualMachineInstance template.
+                      properties:
+                        kind:
+                          description: 'Kind specifies which preference resource is referenced. Allowed values are: "VirtualMachinePreference" and "VirtualMachineClusterPreference". If not specified, "VirtualMachineClusterPreference" is used by default.'
+                          type: string
+                        name:
+                          description: Name is the name of the VirtualMachinePreference or VirtualMachineClusterPreference
+                          type: string
+                      required:
+                      - name
+                      type: object
                     runStrategy:
                       description: Running state indicates the requested running state of the VirtualMachineInstance mutually exclusive with Running
                       type: string
@@ -8674,9 +11183,20 @@ var CRDsValidation map[string]string = map[string]string{
                                     isolateEmulatorThread:
                                       description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
                                       type: boolean
+                                    maxSockets:
+                                      description: MaxSockets specifies the maximum number of sockets the vmi can be scaled up to while it is running. The domain is defined with the additional sockets offline. Requires the CPUHotplug feature gate.
+                                      format: int32
+                                      type: integer
                                     model:
                                       description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                                       type: string
+                                    numa:
+                                      description: NUMA allows specifying settings for the guest NUMA topology.
+                                      properties:
+                                        guestMappingPassthrough:
+                                          description: GuestMappingPassthrough creates a guest NUMA topology which mirrors the host NUMA nodes of the dedicated pCPUs and hugepages of the VMI. Guest NUMA nodes never cross host NUMA nodes. Requires DedicatedCPUPlacement, hugepages and the NUMA feature gate.
+                                          type: object
+                                      type: object
                                     sockets:
                                       description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                                       format: int32
@@ -8759,6 +11279,66 @@ var CRDsValidation map[string]string = map[string]string{
                                           io:
                                             description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                                             type: string
+                                          ioTune:
+                                            description: IOTune limits the throughput and the I/O operations of the disk. It can be changed on a running VMI through the setiotune subresource.
+                                            properties:
+                                              burst:
+                                                description: Burst allows exceeding the limits for a short time.
+                                                properties:
+                                                  lengthSeconds:
+                                                    description: LengthSeconds is how long the burst limits can be sustained. Defaults to 1.
+                                                    format: int64
+                                                    type: integer
+                                                  readBytesSec:
+                                                    description: Read throughput burst limit in bytes per second.
+                                                    format: int64
+                                                    type: integer
+                                                  readIopsSec:
+                                                    description: Read I/O operations per second burst limit.
+                                                    format: int64
+                                                    type: integer
+                                                  totalBytesSec:
+                                                    description: Total throughput burst limit in bytes per second.
+                                                    format: int64
+                                                    type: integer
+                                                  totalIopsSec:
+                                                    description: Total I/O operations per second burst limit.
+                                                    format: int64
+                                                    type: integer
+                                                  writeBytesSec:
+                                                    description: Write throughput burst limit in bytes per second.
+                                                    format: int64
+                                                    type: integer
+                                                  writeIopsSec:
+                                                    description: Write I/O operations per second burst limit.
+                                                    format: int64
+                                                    type: integer
+                                                type: object
+                                              readBytesSec:
+                                                description: Read throughput limit in bytes per second.
+                                                format: int64
+                                                type: integer
+                                              readIopsSec:
+                                                description: Read I/O operations per second.
+                                                format: int64
+                                                type: integer
+                                              totalBytesSec:
+                                                description: Total throughput limit in bytes per second.
+                                                format: int64
+                                                type: integer
+                                              totalIopsSec:
+                                                description: Total I/O operations per second.
+                                                format: int64
+                                                type: integer
+                                              writeBytesSec:
+                                                description: Write throughput limit in bytes per second.
+                                                format: int64
+                                                type: integer
+                                              writeIopsSec:
+                                                description: Write I/O operations per second.
+                                                format: int64
+                                                type: integer
+                                            type: object
                                           lun:
                                             description: Attach a volume as a LUN to the vmi.
                                             properties:
@@ -8850,6 +11430,55 @@ var CRDsValidation map[string]string = map[string]string{
                                       description: Interfaces describe network interfaces which are added to the vmi.
                                       items:
                                         properties:
+                                          bandwidth:
+                                            description: If specified, limits the inbound and outbound traffic of the interface.
+                                            properties:
+                                              inbound:
+                                                description: BandwidthLimits of a single traffic direction.
+                                                properties:
+                                                  average:
+                                                    description: Average bit rate in kilobytes per second.
+                                                    format: int32
+                                                    type: integer
+                                                  burst:
+                                                    description: Amount of kilobytes that can be burst at peak speed.
+                                                    format: int32
+                                                    type: integer
+                                                  peak:
+                                                    description: Maximum rate at which the interface can send data, in kilobytes per second.
+                                                    format: int32
+                                                    type: integer
+                                                required:
+                                                - average
+                                                type: object
+                                              outbound:
+                                                description: BandwidthLimits of a single traffic direction.
+                                                properties:
+                                                  average:
+                                                    description: Average bit rate in kilobytes per second.
+                                                    format: int32
+                                                    type: integer
+                                                  burst:
+                                                    description: Amount of kilobytes that can be burst at peak speed.
+                                                    format: int32
+                                                    type: integer
+                                                  peak:
+                                                    description: Maximum rate at which the interface can send data, in kilobytes per second.
+                                                    format: int32
+                                                    type: integer
+                                                required:
+                                                - average
+                                                type: object
+                                            type: object
+                                          binding:
+                                            description: Binding specifies a network binding plugin which connects the interface to the guest. It can not be combined with the other binding methods.
+                                            properties:
+                                              name:
+                                                description: Name of the network binding plugin.
+                                                type: string
+                                            required:
+                                            - name
+                                            type: object
                                           bootOrder:
                                             description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                                             type: integer
@@ -8886,6 +11515,118 @@ var CRDsValidation map[string]string = map[string]string{
                                                 description: If specified will pass option 66 to interface's DHCP server
                                                 type: string
                                             type: object
+                                          firewall:
+                                            description: If specified, filters the traffic of the interface in the virt-launcher pod. Only supported for bridge and masquerade interfaces.
+                                            properties:
+                                              egress:
+                                                description: FirewallPolicy filters a single traffic direction.
+                                                properties:
+                                                  defaultAction:
+                                                    description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                                                    type: string
+                                                  rules:
+                                                    description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                                                    items:
+                                                      description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                                                      properties:
+                                                        action:
+                                                          description: 'Action taken for the matching traffic. One of: allow, deny.'
+                                                          type: string
+                                                        cidr:
+                                                          description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                                                          type: string
+                                                        port:
+                                                          description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                                                          format: int32
+                                                          type: integer
+                                                        protocol:
+                                                          description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                                                          type: string
+                                                      required:
+                                                      - action
+                                                      type: object
+                                                    type: array
+                                                type: object
+                                              ingress:
+                                                description: FirewallPolicy filters a single traffic direction.
+                                                properties:
+                                                  defaultAction:
+                                                    description: 'DefaultAction is taken for the traffic not matched by any rule. One of: allow, deny. Defaults to allow.'
+                                                    type: string
+                                                  rules:
+                                                    description: Rules are evaluated in order, the first rule matching a packet decides about it.
+                                                    items:
+                                                      description: FirewallRule matches traffic by the address of the remote peer, the protocol and the port. Empty fields match any traffic.
+                                                      properties:
+                                                        action:
+                                                          description: 'Action taken for the matching traffic. One of: allow, deny.'
+                                                          type: string
+                                                        cidr:
+                                                          description: 'CIDR of the remote peer, the source of ingress and the destination of egress traffic. For example: 10.10.0.0/16 or fd10::/64.'
+                                                          type: string
+                                                        port:
+                                                          description: Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress. Requires the TCP or UDP protocol.
+                                                          format: int32
+                                                          type: integer
+                                                        protocol:
+                                                          description: 'Protocol of the traffic. One of: TCP, UDP, ICMP.'
+                                                          type: string
+                                                      required:
+                                                      - action
+                                                      type: object
+                                                    type: array
+                                                type: object
+                                            type: object
+                                          ipam:
+                                            description: If specified, the guest addresses of the interface are assigned statically instead of being discovered on the pod interface. Only supported for bridge interfaces on secondary Multus networks without an IPAM plugin.
+                                            properties:
+                                              addresses:
+                                                description: Addresses of the interface in CIDR notation, e.g. 192.168.100.10/24 or fd10:100::10/64. At most one IPv4 and one IPv6 address can be specified.
+                                                items:
+                                                  type: string
+                                                type: array
+                                              dns:
+                                                description: DNS configuration of the interface. Defaults to the DNS configuration of the virt-launcher pod.
+                                                properties:
+                                                  nameservers:
+                                                    description: Nameservers are the IP addresses of the DNS servers.
+                                                    items:
+                                                      type: string
+                                                    type: array
+                                                  search:
+                                                    description: Search is the list of DNS search domains.
+                                                    items:
+                                                      type: string
+                                                    type: array
+                                                type: object
+                                              gateway:
+                                                description: Gateway is the IPv4 default gateway.
+                                                type: string
+                                              gatewayIPv6:
+                                                description: GatewayIPv6 is the IPv6 default gateway. DHCPv6 can not announce gateways, it is only applied through cloud-init.
+                                                type: string
+                                              routes:
+                                                description: Routes in addition to the default routes.
+                                                items:
+                                                  description: InterfaceRoute is a static route of an interface.
+                                                  properties:
+                                                    destination:
+                                                      description: Destination network in CIDR notation.
+                                                      type: string
+                                                    gateway:
+                                                      description: Gateway through which the destination is reached.
+                                                      type: string
+                                                  required:
+                                                  - destination
+                                                  - gateway
+                                                  type: object
+                                                type: array
+                                            required:
+                                            - addresses
+                                            type: object
+                                          linkState:
+                                            description: 'LinkState of the virtual network interface as seen by the guest. One of: up, down. Defaults to up.'
+                                            type: string
                                           macAddress:
                                             description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                                             type: string
@@ -8928,6 +11669,9 @@ var CRDsValidation map[string]string = map[string]string{
                                           tag:
                                             description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
                                             type: string
+                                          vhostuser:
+                                            description: InterfaceVhostUser connects to a vhost-user socket provided by a userspace switch, e.g. OVS-DPDK, through a Multus network.
+                                            type: object
                                         required:
                                         - name
                                         type: object
@@ -8938,6 +11682,13 @@ var CRDsValidation map[string]string = map[string]string{
                                     rng:
                                       description: Whether to have random number generator from host
                                       type: object
+                                    tpm:
+                                      description: Whether to emulate a TPM 2.0 device backed by a software TPM.
+                                      properties:
+                                        persistent:
+                                          description: If set to true, the TPM state is stored in the persistent state volume of the VirtualMachine and survives restarts and migrations. Requires the VMPersistentState feature gate. Defaults to false
+                                          type: boolean
+                                      type: object
                                     useVirtioTransitional:
                                       description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
                                       type: boolean
@@ -9131,6 +11882,9 @@ var CRDsValidation map[string]string = map[string]string{
                                         efi:
                                           description: If set, EFI will be used instead of BIOS.
                                           properties:
+                                            persistent:
+                                              description: If set to true, the EFI NVRAM is stored in the persistent state volume of the VirtualMachine and survives restarts and migrations. Requires the VMPersistentState feature gate. Defaults to false
+                                              type: boolean
                                             secureBoot:
                                               description: If set, SecureBoot will be enabled and the OVMF roms will be swapped for SecureBoot-enabled ones. Requires SMM to be enabled. Defaults to true
                                               type: boolean
@@ -9703,6 +12457,9 @@ var CRDsValidation map[string]string = map[string]string{
                           - domain
                           type: object
                       type: object
+                    updateVolumesStrategy:
+                      description: UpdateVolumesStrategy controls how a change of the claims of the volumes in the template applies to a running VirtualMachineInstance. Defaults to Replacement.
+                      type: string
                   required:
                   - template
                   type: object
@@ -9738,6 +12495,35 @@ var CRDsValidation map[string]string = map[string]string{
                     created:
                       description: Created indicates if the virtual machine is created in the cluster
                       type: boolean
+                    interfaceRequests:
+                      description: InterfaceRequests indicates a list of interfaces added to or removed from the VMI template and hotplugged on an active running VMI.
+                      items:
+                        properties:
+                          addInterfaceOptions:
+                            description: AddInterfaceOptions when set indicates a network interface should be added. The details within this field specify how to add the interface
+                            properties:
+                              name:
+                                description: Name indicates the logical name of the interface and of the network it is connected to.
+                                type: string
+                              networkAttachmentDefinitionName:
+                                description: 'NetworkAttachmentDefinitionName references a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
+                                type: string
+                            required:
+                            - name
+                            - networkAttachmentDefinitionName
+                            type: object
+                          removeInterfaceOptions:
+                            description: RemoveInterfaceOptions when set indicates a network interface should be removed. The details within this field specify which interface to remove
+                            properties:
+                              name:
+                                description: Name indicates the logical name of the interface and network to remove.
+                                type: string
+                            required:
+                            - name
+                            type: object
+                        type: object
+                      type: array
+                      x-kubernetes-list-type: atomic
                     ready:
                       description: Ready indicates if the virtual machine is running and ready
                       type: boolean
@@ -9821,6 +12607,66 @@ var CRDsValidation map[string]string = map[string]string{
                                   io:
                                     description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                                     type: string
+                                  ioTune:
+                                    description: IOTune limits the throughput and the I/O operations of the disk. It can be changed on a running VMI through the setiotune subresource.
+                                    properties:
+                                      burst:
+                                        description: Burst allows exceeding the limits for a short time.
+                                        properties:
+                                          lengthSeconds:
+                                            description: LengthSeconds is how long the burst limits can be sustained. Defaults to 1.
+                                            format: int64
+                                            type: integer
+                                          readBytesSec:
+                                            description: Read throughput burst limit in bytes per second.
+                                            format: int64
+                                            type: integer
+                                          readIopsSec:
+                                            description: Read I/O operations per second burst limit.
+                                            format: int64
+                                            type: integer
+                                          totalBytesSec:
+                                            description: Total throughput burst limit in bytes per second.
+                                            format: int64
+                                            type: integer
+                                          totalIopsSec:
+                                            description: Total I/O operations per second burst limit.
+                                            format: int64
+                                            type: integer
+                                          writeBytesSec:
+                                            description: Write throughput burst limit in bytes per second.
+                                            format: int64
+                                            type: integer
+                                          writeIopsSec:
+                                            description: Write I/O operations per second burst limit.
+                                            format: int64
+                                            type: integer
+                                        type: object
+                                      readBytesSec:
+                                        description: Read throughput limit in bytes per second.
+                                        format: int64
+                                        type: integer
+                                      readIopsSec:
+                                        description: Read I/O operations per second.
+                                        format: int64
+                                        type: integer
+                                      totalBytesSec:
+                                        description: Total throughput limit in bytes per second.
+                                        format: int64
+                                        type: integer
+                                      totalIopsSec:
+                                        description: Total I/O operations per second.
+                                        format: int64
+                                        type: integer
+                                      writeBytesSec:
+                                        description: Write throughput limit in bytes per second.
+                                        format: int64
+                                        type: integer
+                                      writeIopsSec:
+                                        description: Write I/O operations per second.
+                                        format: int64
+                                        type: integer
+                                    type: object
                                   lun:
                                     description: Attach a volume as a LUN to the vmi.
                                     properties:
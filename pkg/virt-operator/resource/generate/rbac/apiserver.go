@@ -184,6 +184,31 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"clone.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineclones",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
+			{
+				APIGroups: []string{
+					"instancetype.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstancetypes",
+					"virtualmachineclusterinstancetypes",
+					"virtualmachinepreferences",
+					"virtualmachineclusterpreferences",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 		},
 	}
 }
@@ -267,6 +292,17 @@ func newApiServerRole(namespace string) *rbacv1.Role {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"configmaps",
+				},
+				Verbs: []string{
+					"create", "update",
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,233 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package portforward
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_PORTFORWARD = "port-forward"
+
+	KIND_VMI = "vmi"
+	KIND_VM  = "vm"
+)
+
+var (
+	address        = "127.0.0.1"
+	forwardToStdio = false
+)
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "port-forward [kind/]name [local:]remote...",
+		Short: "Forward local ports to a virtual machine or virtual machine instance.",
+		Long: `Forward local ports to a virtual machine or virtual machine instance.
+The connections are tunneled through the Kubernetes API server and reach the guest from within the pod
+network namespace of the virtual machine instance, no Service is needed. Only TCP ports can be forwarded.
+
+The kind is either 'vmi' (the default) or 'vm'. The remote port is used as the local port if no local port is given.`,
+		Example: usage(),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				fmt.Printf("fatal: Number of input parameters is incorrect, %s accepts at least 2 args, received %d\n\n", COMMAND_PORTFORWARD, len(args))
+				cmd.Help()
+				return fmt.Errorf("argument validation failed")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := PortForward{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&address, "address", address, "--address=127.0.0.1: The address to listen on for local connections.")
+	cmd.Flags().BoolVar(&forwardToStdio, "stdio", forwardToStdio, "--stdio=false: Forward a single port to stdin and stdout instead of listening locally, e.g. to be used as an SSH ProxyCommand.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Forward the local port 8080 to the port 8080 of the VirtualMachineInstance 'testvmi':
+  {{ProgramName}} port-forward vmi/testvmi 8080
+
+  # Forward the local port 2222 to the port 22 of the VirtualMachine 'testvm':
+  {{ProgramName}} port-forward vm/testvm 2222:22
+
+  # Use port-forward as a ProxyCommand of ssh:
+  ssh -o 'ProxyCommand={{ProgramName}} port-forward --stdio vmi/testvmi 22' fedora@testvmi`
+}
+
+type PortForward struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+// ForwardedPort maps a local port to a port of the guest
+type ForwardedPort struct {
+	Local  int
+	Remote int
+}
+
+func (o *PortForward) Run(cmd *cobra.Command, args []string) error {
+	_, name, err := ParseTarget(args[0])
+	if err != nil {
+		return err
+	}
+
+	ports := make([]ForwardedPort, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		port, err := ParsePort(arg)
+		if err != nil {
+			return err
+		}
+		ports = append(ports, port)
+	}
+
+	namespace, _, err := o.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtCli, err := kubecli.GetKubevirtClientFromClientConfig(o.clientConfig)
+	if err != nil {
+		return err
+	}
+	vmiClient := virtCli.VirtualMachineInstance(namespace)
+
+	if forwardToStdio {
+		if len(ports) != 1 {
+			return fmt.Errorf("exactly one port can be forwarded to stdio")
+		}
+		return forwardStream(vmiClient, name, ports[0].Remote, cmd.InOrStdin(), cmd.OutOrStdout())
+	}
+
+	errChan := make(chan error, len(ports))
+	for _, port := range ports {
+		ln, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(port.Local)))
+		if err != nil {
+			return fmt.Errorf("Can't listen on port %d: %s", port.Local, err.Error())
+		}
+		defer ln.Close()
+		fmt.Fprintf(cmd.OutOrStdout(), "Forwarding from %s -> %d\n", ln.Addr(), port.Remote)
+
+		go func(ln net.Listener, remote int) {
+			errChan <- Forward(vmiClient, name, ln, remote, cmd.ErrOrStderr())
+		}(ln, port.Remote)
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	select {
+	case <-interrupt:
+		return nil
+	case err = <-errChan:
+		return err
+	}
+}
+
+// ParseTarget splits a [kind/]name target into its kind and name.
+// Without a kind the target is a VirtualMachineInstance.
+func ParseTarget(target string) (kind string, name string, err error) {
+	kind = KIND_VMI
+	name = target
+	if parts := strings.SplitN(target, "/", 2); len(parts) == 2 {
+		kind, name = parts[0], parts[1]
+	}
+
+	switch kind {
+	case KIND_VMI, "virtualmachineinstance":
+		kind = KIND_VMI
+	case KIND_VM, "virtualmachine":
+		kind = KIND_VM
+	default:
+		return "", "", fmt.Errorf("unsupported resource kind %s, use %s or %s", kind, KIND_VMI, KIND_VM)
+	}
+	if name == "" {
+		return "", "", fmt.Errorf("target name can not be empty")
+	}
+	return kind, name, nil
+}
+
+// ParsePort parses a [local:]remote port mapping
+func ParsePort(arg string) (ForwardedPort, error) {
+	parts := strings.SplitN(arg, ":", 2)
+	remote, err := parsePortNumber(parts[len(parts)-1])
+	if err != nil {
+		return ForwardedPort{}, err
+	}
+	port := ForwardedPort{Local: remote, Remote: remote}
+	if len(parts) == 2 {
+		if port.Local, err = parsePortNumber(parts[0]); err != nil {
+			return ForwardedPort{}, err
+		}
+	}
+	return port, nil
+}
+
+func parsePortNumber(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %s, a port must be a number between 1 and 65535", value)
+	}
+	return port, nil
+}
+
+// Forward tunnels every connection accepted on the listener to the port of the VirtualMachineInstance,
+// until the listener is closed. A connection which can not be forwarded is closed and the error is
+// printed to errOut.
+func Forward(vmiClient kubecli.VirtualMachineInstanceInterface, name string, ln net.Listener, port int, errOut io.Writer) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			glog.V(2).Infof("Handling connection for %d", port)
+			if err := forwardStream(vmiClient, name, port, conn, conn); err != nil {
+				fmt.Fprintf(errOut, "error forwarding port %d: %v\n", port, err)
+			}
+		}()
+	}
+}
+
+func forwardStream(vmiClient kubecli.VirtualMachineInstanceInterface, name string, port int, in io.Reader, out io.Writer) error {
+	stream, err := vmiClient.PortForward(name, port)
+	if err != nil {
+		return fmt.Errorf("Can't access VMI %s: %s", name, err.Error())
+	}
+	return stream.Stream(kubecli.StreamOptions{
+		In:  in,
+		Out: out,
+	})
+}
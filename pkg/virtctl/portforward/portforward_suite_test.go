@@ -0,0 +1,11 @@
+package portforward_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestPortForward(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t, "PortForward Suite")
+}
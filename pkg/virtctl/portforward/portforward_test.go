@@ -0,0 +1,158 @@
+package portforward_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/portforward"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Port forward", func() {
+
+	const vmiName = "testvmi"
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var ctrl *gomock.Controller
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	})
+
+	Context("With missing input parameters", func() {
+		It("should fail", func() {
+			cmd := tests.NewRepeatableVirtctlCommand(portforward.COMMAND_PORTFORWARD, "vmi/"+vmiName)
+			err := cmd()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	table.DescribeTable("should parse the target", func(target string, expectedKind string, expectedName string) {
+		kind, name, err := portforward.ParseTarget(target)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kind).To(Equal(expectedKind))
+		Expect(name).To(Equal(expectedName))
+	},
+		table.Entry("without kind", "testvmi", portforward.KIND_VMI, "testvmi"),
+		table.Entry("of a vmi", "vmi/testvmi", portforward.KIND_VMI, "testvmi"),
+		table.Entry("of a vm", "vm/testvm", portforward.KIND_VM, "testvm"),
+		table.Entry("with the full kind", "virtualmachine/testvm", portforward.KIND_VM, "testvm"),
+	)
+
+	table.DescribeTable("should reject the target", func(target string) {
+		_, _, err := portforward.ParseTarget(target)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("with an unknown kind", "pod/testpod"),
+		table.Entry("without name", "vmi/"),
+	)
+
+	table.DescribeTable("should parse the port", func(arg string, expectedPort portforward.ForwardedPort) {
+		port, err := portforward.ParsePort(arg)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(port).To(Equal(expectedPort))
+	},
+		table.Entry("with the remote port only", "22", portforward.ForwardedPort{Local: 22, Remote: 22}),
+		table.Entry("with local and remote port", "2222:22", portforward.ForwardedPort{Local: 2222, Remote: 22}),
+	)
+
+	table.DescribeTable("should reject the port", func(arg string) {
+		_, err := portforward.ParsePort(arg)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("which is not a number", "ssh"),
+		table.Entry("which is out of range", "65536"),
+		table.Entry("with an invalid local port", "0:22"),
+	)
+
+	It("should forward a port to stdio", func() {
+		stream := kubecli.NewMockStreamInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().PortForward(vmiName, 22).Return(stream, nil).Times(1)
+		stream.EXPECT().Stream(gomock.Any()).DoAndReturn(func(options kubecli.StreamOptions) error {
+			data, err := ioutil.ReadAll(options.In)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = options.Out.Write(data)
+			return err
+		}).Times(1)
+
+		out := &bytes.Buffer{}
+		cmd := tests.NewVirtctlCommand(portforward.COMMAND_PORTFORWARD, "--stdio", "vmi/"+vmiName, "22")
+		cmd.SetIn(bytes.NewBufferString("SSH-2.0-OpenSSH"))
+		cmd.SetOut(out)
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(out.String()).To(Equal("SSH-2.0-OpenSSH"))
+	})
+
+	It("should return the error of a failing port forward to stdio", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().PortForward(vmiName, 22).Return(nil, fmt.Errorf("VMI is paused")).Times(1)
+
+		cmd := tests.NewVirtctlCommand(portforward.COMMAND_PORTFORWARD, "--stdio", "vmi/"+vmiName, "22")
+		Expect(cmd.Execute()).To(MatchError("Can't access VMI testvmi: VMI is paused"))
+	})
+
+	It("should print the error of a connection which can not be forwarded", func() {
+		vmiInterface.EXPECT().PortForward(vmiName, 22).Return(nil, fmt.Errorf("VMI is paused")).Times(1)
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		errOut := &syncBuffer{}
+		forwardErr := make(chan error, 1)
+		go func() {
+			forwardErr <- portforward.Forward(vmiInterface, vmiName, ln, 22, errOut)
+		}()
+
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		defer conn.Close()
+		Expect(conn.SetReadDeadline(time.Now().Add(10 * time.Second))).To(Succeed())
+		// the connection is closed
+		_, err = conn.Read(make([]byte, 1))
+		Expect(err).To(Equal(io.EOF))
+		Eventually(errOut.String).Should(Equal("error forwarding port 22: Can't access VMI testvmi: VMI is paused\n"))
+
+		Expect(ln.Close()).To(Succeed())
+		Eventually(forwardErr).Should(Receive(HaveOccurred()))
+	})
+
+	It("should forward a single port to stdio only", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+
+		cmd := tests.NewVirtctlCommand(portforward.COMMAND_PORTFORWARD, "--stdio", "vmi/"+vmiName, "22", "80")
+		Expect(cmd.Execute()).To(HaveOccurred())
+	})
+})
+
+// syncBuffer is a buffer which is safe to be written by the forwarded connections while the test reads it
+type syncBuffer struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.String()
+}
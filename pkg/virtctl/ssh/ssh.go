@@ -0,0 +1,235 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package ssh
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/portforward"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const COMMAND_SSH = "ssh"
+
+var (
+	username     = ""
+	identityFile = ""
+	sshPort      = 22
+	sshOptions   []string
+)
+
+// RunLocalSSH executes the local ssh client with the given arguments.
+// The unit tests overwrite it.
+var RunLocalSSH = func(args []string) error {
+	cmd := exec.Command("ssh", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ssh [user@][kind/]name [command...]",
+		Short: "Open a SSH connection to a virtual machine or virtual machine instance.",
+		Long: `Open a SSH connection to a virtual machine or virtual machine instance.
+The local ssh client is connected to the guest through a port forward over the Kubernetes API server.
+
+The kind is either 'vmi' (the default) or 'vm'. Without a user, the user the SSH public keys of the
+accessCredentials are propagated to by the guest agent is used, if it is the only one.`,
+		Example: usage(),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				fmt.Printf("fatal: Number of input parameters is incorrect, %s accepts at least 1 arg, received %d\n\n", COMMAND_SSH, len(args))
+				cmd.Help()
+				return fmt.Errorf("argument validation failed")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := SSH{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	// flags after the target belong to the remote command
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().StringVarP(&username, "login-name", "l", username, "--login-name=: The user to log in as, overrides the user of the target.")
+	cmd.Flags().StringVarP(&identityFile, "identity-file", "i", identityFile, "--identity-file=: The private key to authenticate with.")
+	cmd.Flags().IntVarP(&sshPort, "port", "p", sshPort, "--port=22: The SSH port of the guest.")
+	cmd.Flags().StringArrayVarP(&sshOptions, "local-ssh-opts", "o", sshOptions, "--local-ssh-opts=: Additional options for the local ssh client, in the format used by ssh -o.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Connect to the VirtualMachineInstance 'testvmi' as user 'fedora':
+  {{ProgramName}} ssh fedora@testvmi
+
+  # Run a command as user 'fedora' on the VirtualMachine 'testvm' with a specific private key:
+  {{ProgramName}} ssh -i ~/.ssh/id_rsa fedora@vm/testvm uptime`
+}
+
+type SSH struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func (o *SSH) Run(cmd *cobra.Command, args []string) error {
+	user, target := "", args[0]
+	if idx := strings.LastIndex(target, "@"); idx >= 0 {
+		user, target = target[:idx], target[idx+1:]
+	}
+	if username != "" {
+		user = username
+	}
+
+	kind, name, err := portforward.ParseTarget(target)
+	if err != nil {
+		return err
+	}
+	if sshPort < 1 || sshPort > 65535 {
+		return fmt.Errorf("invalid port %d, a port must be a number between 1 and 65535", sshPort)
+	}
+
+	namespace, _, err := o.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtCli, err := kubecli.GetKubevirtClientFromClientConfig(o.clientConfig)
+	if err != nil {
+		return err
+	}
+	vmiClient := virtCli.VirtualMachineInstance(namespace)
+
+	vmi, err := vmiClient.Get(name, &k8smetav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Can't access VMI %s: %s", name, err.Error())
+	}
+	if user, err = resolveUser(vmi, user); err != nil {
+		return err
+	}
+	if user != "" && !keysPropagatedTo(vmi, user) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: the SSH public keys of the accessCredentials of %s are not propagated to user %s\n", name, user)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("Can't listen for the local ssh client: %s", err.Error())
+	}
+	defer ln.Close()
+	forwardErrs := make(chan error, 1)
+	go func() {
+		// errors of the tunneled connection are printed before ssh reports the closed connection
+		forwardErrs <- portforward.Forward(vmiClient, name, ln, sshPort, cmd.ErrOrStderr())
+	}()
+
+	localPort := ln.Addr().(*net.TCPAddr).Port
+	err = RunLocalSSH(buildSSHArgs(fmt.Sprintf("%s/%s.%s", kind, name, namespace), user, localPort, args[1:]))
+
+	// Forward only returns on its own if it failed, it is stopped by closing the listener
+	select {
+	case forwardErr := <-forwardErrs:
+		return fmt.Errorf("Can't forward the ssh port of VMI %s: %s", name, forwardErr.Error())
+	default:
+	}
+	return err
+}
+
+func buildSSHArgs(hostKeyAlias string, user string, localPort int, command []string) []string {
+	args := []string{
+		"-p", strconv.Itoa(localPort),
+		// the local port changes with every connection, the known host key is stored for the target instead
+		"-o", "HostKeyAlias=" + hostKeyAlias,
+	}
+	if identityFile != "" {
+		args = append(args, "-i", identityFile)
+	}
+	for _, option := range sshOptions {
+		args = append(args, "-o", option)
+	}
+	if user != "" {
+		args = append(args, "-l", user)
+	}
+	args = append(args, "127.0.0.1")
+	return append(args, command...)
+}
+
+// resolveUser defaults the user to the only user the SSH public keys of the
+// accessCredentials are propagated to by the guest agent
+func resolveUser(vmi *v1.VirtualMachineInstance, user string) (string, error) {
+	if user != "" {
+		return user, nil
+	}
+
+	users := map[string]struct{}{}
+	for _, credential := range vmi.Spec.AccessCredentials {
+		if credential.SSHPublicKey == nil || credential.SSHPublicKey.PropagationMethod.QemuGuestAgent == nil {
+			continue
+		}
+		for _, agentUser := range credential.SSHPublicKey.PropagationMethod.QemuGuestAgent.Users {
+			users[agentUser] = struct{}{}
+		}
+	}
+
+	switch len(users) {
+	case 0:
+		return "", nil
+	case 1:
+		for agentUser := range users {
+			return agentUser, nil
+		}
+	}
+	return "", fmt.Errorf("the accessCredentials of %s propagate SSH public keys to several users, a user is required", vmi.Name)
+}
+
+// keysPropagatedTo tells if the SSH public keys of the accessCredentials reach the user. Keys propagated
+// through the config drive are handed to cloud-init, which decides about the user.
+func keysPropagatedTo(vmi *v1.VirtualMachineInstance, user string) bool {
+	hasSSHPublicKeys := false
+	for _, credential := range vmi.Spec.AccessCredentials {
+		if credential.SSHPublicKey == nil {
+			continue
+		}
+		hasSSHPublicKeys = true
+		propagation := credential.SSHPublicKey.PropagationMethod
+		if propagation.ConfigDrive != nil {
+			return true
+		}
+		if propagation.QemuGuestAgent != nil {
+			for _, agentUser := range propagation.QemuGuestAgent.Users {
+				if agentUser == user {
+					return true
+				}
+			}
+		}
+	}
+	return !hasSSHPublicKeys
+}
@@ -0,0 +1,11 @@
+package ssh_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestSSH(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t, "SSH Suite")
+}
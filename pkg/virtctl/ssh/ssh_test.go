@@ -0,0 +1,103 @@
+package ssh_test
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/ssh"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("SSH", func() {
+
+	const vmiName = "testvmi"
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var ctrl *gomock.Controller
+	var sshArgs []string
+	var runLocalSSH func([]string) error
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+
+		sshArgs = nil
+		runLocalSSH = ssh.RunLocalSSH
+		ssh.RunLocalSSH = func(args []string) error {
+			sshArgs = args
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		ssh.RunLocalSSH = runLocalSSH
+	})
+
+	expectVMI := func(accessCredentials ...v1.AccessCredential) {
+		vmi := v1.NewMinimalVMI(vmiName)
+		vmi.Spec.AccessCredentials = accessCredentials
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().Get(vmiName, gomock.Any()).Return(vmi, nil).Times(1)
+	}
+
+	agentKeys := func(users ...string) v1.AccessCredential {
+		return v1.AccessCredential{
+			SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+				Source: v1.SSHPublicKeyAccessCredentialSource{
+					Secret: &v1.AccessCredentialSecretSource{SecretName: "keys"},
+				},
+				PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+					QemuGuestAgent: &v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation{Users: users},
+				},
+			},
+		}
+	}
+
+	Context("With missing input parameters", func() {
+		It("should fail", func() {
+			cmd := tests.NewRepeatableVirtctlCommand(ssh.COMMAND_SSH)
+			err := cmd()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("should connect the local ssh client as the given user", func() {
+		expectVMI()
+
+		cmd := tests.NewVirtctlCommand(ssh.COMMAND_SSH, "fedora@vmi/"+vmiName, "uptime", "-p")
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(sshArgs).To(ContainElement("HostKeyAlias=vmi/testvmi.default"))
+		Expect(sshArgs[len(sshArgs)-5:]).To(Equal([]string{"-l", "fedora", "127.0.0.1", "uptime", "-p"}))
+	})
+
+	It("should use the user the SSH keys are propagated to", func() {
+		expectVMI(agentKeys("fedora"))
+
+		cmd := tests.NewVirtctlCommand(ssh.COMMAND_SSH, vmiName)
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(sshArgs[len(sshArgs)-3:]).To(Equal([]string{"-l", "fedora", "127.0.0.1"}))
+	})
+
+	It("should require a user if the SSH keys are propagated to several users", func() {
+		expectVMI(agentKeys("fedora", "centos"))
+
+		cmd := tests.NewVirtctlCommand(ssh.COMMAND_SSH, vmiName)
+		Expect(cmd.Execute()).To(HaveOccurred())
+		Expect(sshArgs).To(BeNil())
+	})
+
+	It("should pass the identity file and ssh options", func() {
+		expectVMI()
+
+		cmd := tests.NewVirtctlCommand(ssh.COMMAND_SSH, "-i", "/tmp/id_rsa", "-o", "StrictHostKeyChecking=no", "fedora@"+vmiName)
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(sshArgs).To(ContainElement("/tmp/id_rsa"))
+		Expect(sshArgs).To(ContainElement("StrictHostKeyChecking=no"))
+	})
+})
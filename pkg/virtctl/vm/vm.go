@@ -27,6 +27,7 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 
 	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"kubevirt.io/client-go/kubecli"
@@ -42,11 +43,15 @@ const (
 	COMMAND_GUESTOSINFO = "guestosinfo"
 	COMMAND_USERLIST    = "userlist"
 	COMMAND_FSLIST      = "fslist"
+	COMMAND_INJECTMEDIA = "injectmedia"
+	COMMAND_EJECTMEDIA  = "ejectmedia"
 )
 
 var (
-	forceRestart bool
-	gracePeriod  int = -1
+	forceRestart   bool
+	gracePeriod    int = -1
+	claimName      string
+	dataVolumeName string
 )
 
 func NewStartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
@@ -171,6 +176,38 @@ func NewFSListCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	return cmd
 }
 
+func NewInjectMediaCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "injectmedia (VMI) (CD-ROM)",
+		Short:   "Insert a PersistentVolumeClaim or DataVolume into an empty CD-ROM of a running virtual machine instance.",
+		Example: usage(COMMAND_INJECTMEDIA),
+		Args:    templates.ExactArgs("injectmedia", 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_INJECTMEDIA, clientConfig: clientConfig}
+			return c.Run(args)
+		},
+	}
+	cmd.Flags().StringVar(&claimName, "claim-name", "", "Name of the PersistentVolumeClaim to insert.")
+	cmd.Flags().StringVar(&dataVolumeName, "data-volume", "", "Name of the DataVolume to insert.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewEjectMediaCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ejectmedia (VMI) (CD-ROM)",
+		Short:   "Eject the media of a CD-ROM of a running virtual machine instance.",
+		Example: usage(COMMAND_EJECTMEDIA),
+		Args:    templates.ExactArgs("ejectmedia", 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_EJECTMEDIA, clientConfig: clientConfig}
+			return c.Run(args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
 type Command struct {
 	clientConfig clientcmd.ClientConfig
 	command      string
@@ -183,6 +220,18 @@ func usage(cmd string) string {
 		return usage
 	}
 
+	if cmd == COMMAND_INJECTMEDIA {
+		usage := "  # Insert the PersistentVolumeClaim 'virtio-win' into the CD-ROM 'drivers' of a virtual machine instance called 'myvm':\n"
+		usage += fmt.Sprintf("  {{ProgramName}} %s myvm drivers --claim-name=virtio-win", cmd)
+		return usage
+	}
+
+	if cmd == COMMAND_EJECTMEDIA {
+		usage := "  # Eject the media of the CD-ROM 'drivers' of a virtual machine instance called 'myvm':\n"
+		usage += fmt.Sprintf("  {{ProgramName}} %s myvm drivers", cmd)
+		return usage
+	}
+
 	if cmd == COMMAND_USERLIST || cmd == COMMAND_FSLIST || cmd == COMMAND_GUESTOSINFO {
 		usage := fmt.Sprintf("  # %s a virtual machine instance called 'myvm':\n", strings.Title(cmd))
 		usage += fmt.Sprintf("  {{ProgramName}} %s myvm", cmd)
@@ -287,6 +336,29 @@ func (o *Command) Run(args []string) error {
 
 		fmt.Printf("%s\n", string(data))
 		return nil
+	case COMMAND_INJECTMEDIA:
+		if (claimName == "") == (dataVolumeName == "") {
+			return fmt.Errorf("Either --claim-name or --data-volume has to be set")
+		}
+		volumeSource := &v1.HotplugVolumeSource{}
+		if claimName != "" {
+			volumeSource.PersistentVolumeClaim = &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: claimName}
+		} else {
+			volumeSource.DataVolume = &v1.DataVolumeSource{Name: dataVolumeName}
+		}
+		err = virtClient.VirtualMachineInstance(namespace).InjectMedia(vmiName, &v1.InjectMediaOptions{Name: args[1], VolumeSource: volumeSource})
+		if err != nil {
+			return fmt.Errorf("Error injecting media into CD-ROM %s of VirtualMachineInstance %s, %v", args[1], vmiName, err)
+		}
+		fmt.Printf("Media was scheduled to be injected into CD-ROM %s of VMI %s\n", args[1], vmiName)
+		return nil
+	case COMMAND_EJECTMEDIA:
+		err = virtClient.VirtualMachineInstance(namespace).EjectMedia(vmiName, &v1.EjectMediaOptions{Name: args[1]})
+		if err != nil {
+			return fmt.Errorf("Error ejecting media from CD-ROM %s of VirtualMachineInstance %s, %v", args[1], vmiName, err)
+		}
+		fmt.Printf("Media was scheduled to be ejected from CD-ROM %s of VMI %s\n", args[1], vmiName)
+		return nil
 	}
 
 	fmt.Printf("VM %s was scheduled to %s\n", vmiName, o.command)
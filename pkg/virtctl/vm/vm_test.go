@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	k8sv1 "k8s.io/api/core/v1"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -273,6 +274,58 @@ var _ = Describe("VirtualMachine", func() {
 		})
 	})
 
+	Context("CD-ROM media", func() {
+
+		It("should inject a PersistentVolumeClaim", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+			vmiInterface.EXPECT().InjectMedia(vmName, &v1.InjectMediaOptions{
+				Name: "drivers",
+				VolumeSource: &v1.HotplugVolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "virtio-win"},
+				},
+			}).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("injectmedia", vmName, "drivers", "--claim-name", "virtio-win")
+			Expect(cmd.Execute()).To(Succeed())
+		})
+
+		It("should inject a DataVolume", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+			vmiInterface.EXPECT().InjectMedia(vmName, &v1.InjectMediaOptions{
+				Name: "drivers",
+				VolumeSource: &v1.HotplugVolumeSource{
+					DataVolume: &v1.DataVolumeSource{Name: "virtio-win"},
+				},
+			}).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("injectmedia", vmName, "drivers", "--data-volume", "virtio-win")
+			Expect(cmd.Execute()).To(Succeed())
+		})
+
+		It("should fail to inject without a volume", func() {
+			cmd := tests.NewRepeatableVirtctlCommand("injectmedia", vmName, "drivers")
+			Expect(cmd()).NotTo(Succeed())
+		})
+
+		It("should fail to inject both a claim and a DataVolume", func() {
+			cmd := tests.NewRepeatableVirtctlCommand("injectmedia", vmName, "drivers", "--claim-name", "a", "--data-volume", "b")
+			Expect(cmd()).NotTo(Succeed())
+		})
+
+		It("should eject the media", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+			vmiInterface.EXPECT().EjectMedia(vmName, &v1.EjectMediaOptions{Name: "drivers"}).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("ejectmedia", vmName, "drivers")
+			Expect(cmd.Execute()).To(Succeed())
+		})
+
+		It("should fail to eject without a CD-ROM", func() {
+			cmd := tests.NewRepeatableVirtctlCommand("ejectmedia", vmName)
+			Expect(cmd()).NotTo(Succeed())
+		})
+	})
+
 	AfterEach(func() {
 		ctrl.Finish()
 	})
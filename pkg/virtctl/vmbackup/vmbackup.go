@@ -0,0 +1,131 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package vmbackup
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const COMMAND_BACKUP_NBD = "backup-nbd"
+
+var socket string
+
+func NewBackupNBDCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup-nbd (VMI)",
+		Short: "Expose the NBD server of a backup in progress as a local unix socket.",
+		Long: `Expose the NBD server of a backup in progress as a local unix socket.
+The connections are tunneled through the Kubernetes API server to the NBD server exporting the disks
+of the VirtualMachineBackup. The export names and the dirty bitmaps of incremental backups are
+listed in the status of the VirtualMachineBackup.`,
+		Example: usage(),
+		Args:    templates.ExactArgs(COMMAND_BACKUP_NBD, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := BackupNBD{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&socket, "socket", "", "--socket=/tmp/backup.sock: The unix socket to listen on for local connections, defaults to <VMI>-backup-nbd.sock in the working directory.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Expose the NBD server of the backup of the VirtualMachineInstance 'testvmi':
+  {{ProgramName}} backup-nbd testvmi --socket /tmp/testvmi.sock
+
+  # Copy the disk exported as 'vda' with qemu-img:
+  qemu-img convert -O qcow2 'nbd+unix:///vda?socket=/tmp/testvmi.sock' vda.qcow2`
+}
+
+type BackupNBD struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func (o *BackupNBD) Run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	namespace, _, err := o.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtCli, err := kubecli.GetKubevirtClientFromClientConfig(o.clientConfig)
+	if err != nil {
+		return err
+	}
+
+	path := socket
+	if path == "" {
+		path = name + "-backup-nbd.sock"
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("Can't listen on unix socket %s: %s", path, err.Error())
+	}
+	defer ln.Close()
+	fmt.Fprintf(cmd.OutOrStdout(), "Forwarding from %s\n", path)
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- Forward(virtCli.VirtualMachineInstance(namespace), name, ln)
+	}()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	select {
+	case <-interrupt:
+		return nil
+	case err = <-errChan:
+		return err
+	}
+}
+
+// Forward tunnels every connection accepted on the listener to the NBD server of the backup of the
+// VirtualMachineInstance, until the listener is closed
+func Forward(vmiClient kubecli.VirtualMachineInstanceInterface, name string, ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			glog.V(2).Infof("Handling NBD connection for %s", name)
+			stream, err := vmiClient.BackupNBD(name)
+			if err != nil {
+				glog.Errorf("Can't access the backup of VMI %s: %v", name, err)
+				return
+			}
+			if err := stream.Stream(kubecli.StreamOptions{In: conn, Out: conn}); err != nil {
+				glog.Errorf("error forwarding the NBD connection of VMI %s: %v", name, err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,11 @@
+package vmbackup_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestVMBackup(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t, "VMBackup Suite")
+}
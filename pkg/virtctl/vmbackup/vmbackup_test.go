@@ -0,0 +1,72 @@
+package vmbackup_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/vmbackup"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Backup NBD", func() {
+
+	const vmiName = "testvmi"
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var ctrl *gomock.Controller
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	})
+
+	It("should fail without a VMI", func() {
+		cmd := tests.NewRepeatableVirtctlCommand(vmbackup.COMMAND_BACKUP_NBD)
+		Expect(cmd()).To(HaveOccurred())
+	})
+
+	It("should forward local connections to the NBD server of the backup", func() {
+		dir, err := ioutil.TempDir("", "backup-nbd")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		ln, err := net.Listen("unix", filepath.Join(dir, "nbd.sock"))
+		Expect(err).ToNot(HaveOccurred())
+
+		stream := kubecli.NewMockStreamInterface(ctrl)
+		vmiInterface.EXPECT().BackupNBD(vmiName).Return(stream, nil)
+		stream.EXPECT().Stream(gomock.Any()).DoAndReturn(func(options kubecli.StreamOptions) error {
+			buf := make([]byte, 8)
+			n, err := options.In.Read(buf)
+			if err != nil {
+				return err
+			}
+			_, err = options.Out.Write(buf[:n])
+			return err
+		})
+
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- vmbackup.Forward(vmiInterface, vmiName, ln)
+		}()
+
+		conn, err := net.Dial("unix", filepath.Join(dir, "nbd.sock"))
+		Expect(err).ToNot(HaveOccurred())
+		defer conn.Close()
+		_, err = conn.Write([]byte("NBDMAGIC"))
+		Expect(err).ToNot(HaveOccurred())
+		buf := make([]byte, 8)
+		_, err = conn.Read(buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(buf)).To(Equal("NBDMAGIC"))
+
+		ln.Close()
+		Eventually(errChan).Should(Receive(HaveOccurred()))
+	})
+})
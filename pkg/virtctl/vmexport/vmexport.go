@@ -0,0 +1,505 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vmexport
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	pb "gopkg.in/cheggaaa/pb.v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	exportserver "kubevirt.io/kubevirt/pkg/virt-exportserver"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_VMEXPORT = "vmexport"
+
+	CREATE   = "create"
+	DELETE   = "delete"
+	DOWNLOAD = "download"
+
+	tokenKey = "token"
+
+	exportReadyWaitInterval = 2 * time.Second
+)
+
+var (
+	vmName      string
+	pvcName     string
+	outputFile  string
+	volumeName  string
+	format      string
+	manifest    bool
+	keepVME     bool
+	portForward bool
+	insecure    bool
+	waitSecs    uint
+)
+
+// HTTPClientCreator is a function that creates http clients for the export server
+type HTTPClientCreator func(caCert string, serverName string, insecure bool) (*http.Client, error)
+
+var httpClientCreatorFunc HTTPClientCreator = getHTTPClient
+
+// SetHTTPClientCreator allows overriding the default http client
+// useful for unit tests
+func SetHTTPClientCreator(f HTTPClientCreator) {
+	httpClientCreatorFunc = f
+}
+
+// SetDefaultHTTPClientCreator sets the http client creator back to default
+func SetDefaultHTTPClientCreator() {
+	httpClientCreatorFunc = getHTTPClient
+}
+
+// NewVirtualMachineExportCommand returns a cobra.Command to handle VirtualMachineExports
+func NewVirtualMachineExportCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vmexport create|delete|download (VMEXPORT)",
+		Short: "Export the volumes and the definition of a VirtualMachine or the content of a PersistentVolumeClaim.",
+		Long: `Creates, deletes or downloads from a VirtualMachineExport.
+First argument is the action, possible actions are create, delete and download.
+Second argument is the name of the VirtualMachineExport.`,
+		Args:    templates.ExactArgs(COMMAND_VMEXPORT, 2),
+		Example: usage(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := command{clientConfig: clientConfig}
+			return c.run(args)
+		},
+	}
+	cmd.Flags().StringVar(&vmName, "vm", "", "The VirtualMachine to export, used with create and download.")
+	cmd.Flags().StringVar(&pvcName, "pvc", "", "The PersistentVolumeClaim to export, used with create and download.")
+	cmd.Flags().StringVar(&outputFile, "output", "", "The file to download to.")
+	cmd.Flags().StringVar(&volumeName, "volume", "", "The volume to download, required if more than one volume is exported.")
+	cmd.Flags().StringVar(&format, "format", string(exportv1alpha1.KubeVirtGz), "The format to download volumes in, raw or gzip.")
+	cmd.Flags().BoolVar(&manifest, "manifest", false, "Download the VirtualMachine manifest instead of a volume.")
+	cmd.Flags().BoolVar(&keepVME, "keep-vme", false, "Keep the VirtualMachineExport created by download.")
+	cmd.Flags().BoolVar(&portForward, "port-forward", false, "Reach the export server through a port forward instead of the cluster network.")
+	cmd.Flags().BoolVar(&insecure, "insecure", false, "Skip verifying the certificate of the export server.")
+	cmd.Flags().UintVar(&waitSecs, "wait-secs", 300, "Seconds to wait for the export to become ready.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Create a VirtualMachineExport of a stopped VirtualMachine:
+  {{ProgramName}} vmexport create my-export --vm=my-vm
+
+  # Create a VirtualMachineExport of a PersistentVolumeClaim:
+  {{ProgramName}} vmexport create my-export --pvc=my-pvc
+
+  # Download a volume of an existing VirtualMachineExport through a port forward:
+  {{ProgramName}} vmexport download my-export --volume=my-pvc --output=disk.img.gz --port-forward
+
+  # Download the VirtualMachine manifest of an existing VirtualMachineExport:
+  {{ProgramName}} vmexport download my-export --manifest --output=vm.yaml
+
+  # Create a VirtualMachineExport, download the raw volume and delete the export afterwards:
+  {{ProgramName}} vmexport download my-export --pvc=my-pvc --format=raw --output=disk.img
+
+  # Delete a VirtualMachineExport:
+  {{ProgramName}} vmexport delete my-export`
+}
+
+type command struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func (c *command) run(args []string) error {
+	action, name := args[0], args[1]
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("cannot obtain KubeVirt client: %v", err)
+	}
+
+	switch action {
+	case CREATE:
+		return createExport(virtClient, namespace, name)
+	case DELETE:
+		return deleteExport(virtClient, namespace, name)
+	case DOWNLOAD:
+		return c.download(virtClient, namespace, name)
+	default:
+		return fmt.Errorf("invalid action %s, expecting one of create, delete or download", action)
+	}
+}
+
+func exportSource() (*corev1.TypedLocalObjectReference, error) {
+	switch {
+	case vmName != "" && pvcName != "":
+		return nil, fmt.Errorf("only one of --vm and --pvc can be used")
+	case vmName != "":
+		apiGroup := v1.GroupName
+		return &corev1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: "VirtualMachine", Name: vmName}, nil
+	case pvcName != "":
+		return &corev1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: pvcName}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func createExport(virtClient kubecli.KubevirtClient, namespace, name string) error {
+	source, err := exportSource()
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		return fmt.Errorf("one of --vm or --pvc is required")
+	}
+
+	vmExport := &exportv1alpha1.VirtualMachineExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: exportv1alpha1.VirtualMachineExportSpec{
+			Source:         *source,
+			TokenSecretRef: fmt.Sprintf("export-token-%s", name),
+		},
+	}
+
+	vmExport, err = virtClient.VirtualMachineExport(namespace).Create(context.Background(), vmExport, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+
+	// the secret is owned by the export so it is cleaned up together with it
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vmExport.Spec.TokenSecretRef,
+			Namespace: namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(vmExport, exportv1alpha1.SchemeGroupVersion.WithKind("VirtualMachineExport")),
+			},
+		},
+		StringData: map[string]string{
+			tokenKey: token,
+		},
+	}
+
+	if _, err := virtClient.CoreV1().Secrets(namespace).Create(context.Background(), secret, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+
+	fmt.Printf("VirtualMachineExport %s/%s created\n", namespace, name)
+	return nil
+}
+
+func deleteExport(virtClient kubecli.KubevirtClient, namespace, name string) error {
+	if err := virtClient.VirtualMachineExport(namespace).Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil {
+		return err
+	}
+
+	fmt.Printf("VirtualMachineExport %s/%s deleted\n", namespace, name)
+	return nil
+}
+
+func newToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+func (c *command) download(virtClient kubecli.KubevirtClient, namespace, name string) error {
+	if outputFile == "" {
+		return fmt.Errorf("--output is required")
+	}
+
+	source, err := exportSource()
+	if err != nil {
+		return err
+	}
+	if source != nil {
+		if err := createExport(virtClient, namespace, name); err != nil {
+			return err
+		}
+		if !keepVME {
+			defer deleteExport(virtClient, namespace, name)
+		}
+	}
+
+	vmExport, err := waitForExportReady(virtClient, namespace, name, exportReadyWaitInterval, time.Duration(waitSecs)*time.Second)
+	if err != nil {
+		return err
+	}
+
+	downloadURL, err := getDownloadURL(vmExport)
+	if err != nil {
+		return err
+	}
+
+	secret, err := virtClient.CoreV1().Secrets(namespace).Get(context.Background(), vmExport.Spec.TokenSecretRef, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(downloadURL)
+	if err != nil {
+		return err
+	}
+	serverName := u.Hostname()
+
+	if portForward {
+		localPort, stop, err := c.forwardExportServer(virtClient, vmExport)
+		if err != nil {
+			return err
+		}
+		defer close(stop)
+		u.Host = fmt.Sprintf("127.0.0.1:%d", localPort)
+	}
+
+	client, err := httpClientCreatorFunc(vmExport.Status.Links.Internal.Cert, serverName, insecure)
+	if err != nil {
+		return err
+	}
+
+	return downloadToFile(client, u.String(), string(secret.Data[tokenKey]), outputFile)
+}
+
+func waitForExportReady(virtClient kubecli.KubevirtClient, namespace, name string, interval, timeout time.Duration) (*exportv1alpha1.VirtualMachineExport, error) {
+	var vmExport *exportv1alpha1.VirtualMachineExport
+
+	err := wait.PollImmediate(interval, timeout, func() (bool, error) {
+		var err error
+		vmExport, err = virtClient.VirtualMachineExport(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return vmExport.Status != nil && vmExport.Status.Phase == exportv1alpha1.Ready, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("VirtualMachineExport %s/%s is not ready: %v", namespace, name, err)
+	}
+
+	return vmExport, nil
+}
+
+func getDownloadURL(vmExport *exportv1alpha1.VirtualMachineExport) (string, error) {
+	if vmExport.Status.Links == nil || vmExport.Status.Links.Internal == nil {
+		return "", fmt.Errorf("VirtualMachineExport %s has no links", vmExport.Name)
+	}
+	link := vmExport.Status.Links.Internal
+
+	if manifest {
+		for _, m := range link.Manifests {
+			if m.Type == exportv1alpha1.VirtualMachineManifest {
+				return m.Url, nil
+			}
+		}
+		return "", fmt.Errorf("VirtualMachineExport %s does not export a manifest", vmExport.Name)
+	}
+
+	var volume *exportv1alpha1.VirtualMachineExportVolume
+	switch {
+	case volumeName != "":
+		for i := range link.Volumes {
+			if link.Volumes[i].Name == volumeName {
+				volume = &link.Volumes[i]
+			}
+		}
+		if volume == nil {
+			return "", fmt.Errorf("VirtualMachineExport %s does not export volume %s", vmExport.Name, volumeName)
+		}
+	case len(link.Volumes) == 1:
+		volume = &link.Volumes[0]
+	case len(link.Volumes) == 0:
+		return "", fmt.Errorf("VirtualMachineExport %s does not export any volume", vmExport.Name)
+	default:
+		return "", fmt.Errorf("VirtualMachineExport %s exports more than one volume, use --volume to pick one", vmExport.Name)
+	}
+
+	for _, f := range volume.Formats {
+		if string(f.Format) == format {
+			return f.Url, nil
+		}
+	}
+
+	return "", fmt.Errorf("volume %s is not available in format %s", volume.Name, format)
+}
+
+// forwardExportServer forwards a free local port to the export server pod and
+// returns the local port and the channel to close to stop forwarding
+func (c *command) forwardExportServer(virtClient kubecli.KubevirtClient, vmExport *exportv1alpha1.VirtualMachineExport) (uint16, chan struct{}, error) {
+	service, err := virtClient.CoreV1().Services(vmExport.Namespace).Get(context.Background(), vmExport.Status.ServiceName, metav1.GetOptions{})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	pods, err := virtClient.CoreV1().Pods(vmExport.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var pod *corev1.Pod
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning {
+			pod = &pods.Items[i]
+			break
+		}
+	}
+	if pod == nil || len(service.Spec.Ports) == 0 {
+		return 0, nil, fmt.Errorf("no running export server found for VirtualMachineExport %s", vmExport.Name)
+	}
+
+	config, err := c.clientConfig.ClientConfig()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req := virtClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	ports := []string{fmt.Sprintf("0:%d", service.Spec.Ports[0].TargetPort.IntValue())}
+	forwarder, err := portforward.New(dialer, ports, stop, ready, ioutil.Discard, os.Stderr)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case err := <-errChan:
+		return 0, nil, err
+	case <-ready:
+	}
+
+	forwardedPorts, err := forwarder.GetPorts()
+	if err != nil {
+		close(stop)
+		return 0, nil, err
+	}
+
+	return forwardedPorts[0].Local, stop, nil
+}
+
+func getHTTPClient(caCert string, serverName string, insecure bool) (*http.Client, error) {
+	// #nosec cause: InsecureSkipVerify is only set when explicitly requested
+	tlsConfig := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: insecure,
+	}
+
+	if !insecure {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, fmt.Errorf("failed to parse the certificate of the export server")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}, nil
+}
+
+func downloadToFile(client *http.Client, downloadURL, token, output string) error {
+	req, err := http.NewRequest(http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(exportserver.TokenHeader, strings.TrimSpace(token))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected return value %d, %s", resp.StatusCode, resp.Status)
+	}
+
+	// #nosec G304 No risk for path injection as this function executes with
+	// the same privileges as those of the virtctl user who supplies the output
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	bar := pb.New64(resp.ContentLength).SetUnits(pb.U_BYTES)
+	if resp.ContentLength <= 0 {
+		bar = pb.New(0).SetUnits(pb.U_BYTES)
+	}
+	bar.Start()
+	defer bar.Finish()
+
+	if _, err := io.Copy(file, bar.NewProxyReader(resp.Body)); err != nil {
+		return err
+	}
+
+	fmt.Printf("Downloaded %s to %s\n", downloadURL, output)
+	return nil
+}
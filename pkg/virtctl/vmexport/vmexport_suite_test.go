@@ -0,0 +1,11 @@
+package vmexport_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestVMExport(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t, "VMExport Suite")
+}
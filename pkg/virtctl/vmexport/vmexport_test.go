@@ -0,0 +1,227 @@
+package vmexport_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8sclient "k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	exportserver "kubevirt.io/kubevirt/pkg/virt-exportserver"
+	"kubevirt.io/kubevirt/pkg/virtctl/vmexport"
+	"kubevirt.io/kubevirt/tests"
+)
+
+const (
+	commandName = "vmexport"
+	exportName  = "test-export"
+	token       = "test-token"
+)
+
+var _ = Describe("VMExport", func() {
+	var (
+		ctrl         *gomock.Controller
+		kubeClient   *fakek8sclient.Clientset
+		exportClient *kubevirtfake.Clientset
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+
+		kubeClient = fakek8sclient.NewSimpleClientset()
+		exportClient = kubevirtfake.NewSimpleClientset()
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineExport(metav1.NamespaceDefault).
+			Return(exportClient.ExportV1alpha1().VirtualMachineExports(metav1.NamespaceDefault)).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	getExport := func() (*exportv1alpha1.VirtualMachineExport, error) {
+		return exportClient.ExportV1alpha1().VirtualMachineExports(metav1.NamespaceDefault).Get(context.Background(), exportName, metav1.GetOptions{})
+	}
+
+	It("should fail with an invalid action", func() {
+		cmd := tests.NewRepeatableVirtctlCommand(commandName, "export", exportName)
+		Expect(cmd()).To(MatchError(ContainSubstring("invalid action")))
+	})
+
+	Context("create", func() {
+		It("should fail without a source", func() {
+			cmd := tests.NewRepeatableVirtctlCommand(commandName, vmexport.CREATE, exportName)
+			Expect(cmd()).To(MatchError(ContainSubstring("one of --vm or --pvc is required")))
+		})
+
+		It("should fail with both sources", func() {
+			cmd := tests.NewRepeatableVirtctlCommand(commandName, vmexport.CREATE, exportName, "--vm", "vm", "--pvc", "pvc")
+			Expect(cmd()).To(MatchError(ContainSubstring("only one of --vm and --pvc")))
+		})
+
+		It("should create a VirtualMachine export with a token secret", func() {
+			cmd := tests.NewRepeatableVirtctlCommand(commandName, vmexport.CREATE, exportName, "--vm", "vm")
+			Expect(cmd()).To(Succeed())
+
+			vmExport, err := getExport()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*vmExport.Spec.Source.APIGroup).To(Equal(v1.GroupName))
+			Expect(vmExport.Spec.Source.Kind).To(Equal("VirtualMachine"))
+			Expect(vmExport.Spec.Source.Name).To(Equal("vm"))
+
+			secret, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Get(context.Background(), vmExport.Spec.TokenSecretRef, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.StringData["token"]).To(HaveLen(64))
+			Expect(secret.OwnerReferences).To(HaveLen(1))
+			Expect(secret.OwnerReferences[0].Name).To(Equal(exportName))
+		})
+
+		It("should create a PersistentVolumeClaim export", func() {
+			cmd := tests.NewRepeatableVirtctlCommand(commandName, vmexport.CREATE, exportName, "--pvc", "pvc")
+			Expect(cmd()).To(Succeed())
+
+			vmExport, err := getExport()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmExport.Spec.Source.APIGroup).To(BeNil())
+			Expect(vmExport.Spec.Source.Kind).To(Equal("PersistentVolumeClaim"))
+		})
+	})
+
+	It("should delete an export", func() {
+		_, err := exportClient.ExportV1alpha1().VirtualMachineExports(metav1.NamespaceDefault).Create(context.Background(),
+			&exportv1alpha1.VirtualMachineExport{ObjectMeta: metav1.ObjectMeta{Name: exportName}}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		cmd := tests.NewRepeatableVirtctlCommand(commandName, vmexport.DELETE, exportName)
+		Expect(cmd()).To(Succeed())
+
+		_, err = getExport()
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("download", func() {
+		var (
+			server  *httptest.Server
+			tempDir string
+			output  string
+		)
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get(exportserver.TokenHeader) != token {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Write([]byte(r.URL.Path))
+			}))
+			vmexport.SetHTTPClientCreator(func(_, _ string, _ bool) (*http.Client, error) {
+				return server.Client(), nil
+			})
+
+			var err error
+			tempDir, err = ioutil.TempDir("", "vmexport")
+			Expect(err).ToNot(HaveOccurred())
+			output = filepath.Join(tempDir, "output")
+
+			_, err = kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "token"},
+				Data:       map[string][]byte{"token": []byte(token)},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			vmexport.SetDefaultHTTPClientCreator()
+			server.Close()
+			os.RemoveAll(tempDir)
+		})
+
+		addReadyExport := func(volumes ...string) {
+			link := &exportv1alpha1.VirtualMachineExportLink{
+				Manifests: []exportv1alpha1.VirtualMachineExportManifest{
+					{Type: exportv1alpha1.VirtualMachineManifest, Url: server.URL + exportserver.ManifestPath},
+				},
+			}
+			for _, volume := range volumes {
+				link.Volumes = append(link.Volumes, exportv1alpha1.VirtualMachineExportVolume{
+					Name: volume,
+					Formats: []exportv1alpha1.VirtualMachineExportVolumeFormat{
+						{Format: exportv1alpha1.KubeVirtRaw, Url: server.URL + exportserver.VolumeRawPath(volume)},
+						{Format: exportv1alpha1.KubeVirtGz, Url: server.URL + exportserver.VolumeGzipPath(volume)},
+					},
+				})
+			}
+
+			_, err := exportClient.ExportV1alpha1().VirtualMachineExports(metav1.NamespaceDefault).Create(context.Background(), &exportv1alpha1.VirtualMachineExport{
+				ObjectMeta: metav1.ObjectMeta{Name: exportName},
+				Spec:       exportv1alpha1.VirtualMachineExportSpec{TokenSecretRef: "token"},
+				Status: &exportv1alpha1.VirtualMachineExportStatus{
+					Phase: exportv1alpha1.Ready,
+					Links: &exportv1alpha1.VirtualMachineExportLinks{Internal: link},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		expectOutput := func(content string) {
+			downloaded, err := ioutil.ReadFile(output)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(downloaded)).To(Equal(content))
+		}
+
+		It("should require an output file", func() {
+			cmd := tests.NewRepeatableVirtctlCommand(commandName, vmexport.DOWNLOAD, exportName)
+			Expect(cmd()).To(MatchError(ContainSubstring("--output is required")))
+		})
+
+		It("should download the only volume in gzip format by default", func() {
+			addReadyExport("disk")
+			cmd := tests.NewRepeatableVirtctlCommand(commandName, vmexport.DOWNLOAD, exportName, "--output", output)
+			Expect(cmd()).To(Succeed())
+			expectOutput(exportserver.VolumeGzipPath("disk"))
+		})
+
+		It("should download the selected volume in raw format", func() {
+			addReadyExport("disk0", "disk1")
+			cmd := tests.NewRepeatableVirtctlCommand(commandName, vmexport.DOWNLOAD, exportName, "--output", output, "--volume", "disk1", "--format", "raw")
+			Expect(cmd()).To(Succeed())
+			expectOutput(exportserver.VolumeRawPath("disk1"))
+		})
+
+		It("should require a volume if more than one is exported", func() {
+			addReadyExport("disk0", "disk1")
+			cmd := tests.NewRepeatableVirtctlCommand(commandName, vmexport.DOWNLOAD, exportName, "--output", output)
+			Expect(cmd()).To(MatchError(ContainSubstring("use --volume")))
+		})
+
+		It("should download the manifest", func() {
+			addReadyExport("disk")
+			cmd := tests.NewRepeatableVirtctlCommand(commandName, vmexport.DOWNLOAD, exportName, "--output", output, "--manifest")
+			Expect(cmd()).To(Succeed())
+			expectOutput(exportserver.ManifestPath)
+		})
+
+		It("should fail if the export does not become ready", func() {
+			_, err := exportClient.ExportV1alpha1().VirtualMachineExports(metav1.NamespaceDefault).Create(context.Background(),
+				&exportv1alpha1.VirtualMachineExport{ObjectMeta: metav1.ObjectMeta{Name: exportName}}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			cmd := tests.NewRepeatableVirtctlCommand(commandName, vmexport.DOWNLOAD, exportName, "--output", output, "--wait-secs", "1")
+			Expect(cmd()).To(MatchError(ContainSubstring("is not ready")))
+		})
+	})
+})
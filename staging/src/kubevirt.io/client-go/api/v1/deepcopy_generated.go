@@ -70,6 +70,22 @@ func (in *AccessCredentialSecretSource) DeepCopy() *AccessCredentialSecretSource
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddInterfaceOptions) DeepCopyInto(out *AddInterfaceOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddInterfaceOptions.
+func (in *AddInterfaceOptions) DeepCopy() *AddInterfaceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(AddInterfaceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddVolumeOptions) DeepCopyInto(out *AddVolumeOptions) {
 	*out = *in
@@ -133,6 +149,74 @@ func (in *BIOS) DeepCopy() *BIOS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupExport) DeepCopyInto(out *BackupExport) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupExport.
+func (in *BackupExport) DeepCopy() *BackupExport {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupOptions) DeepCopyInto(out *BackupOptions) {
+	*out = *in
+	if in.Incremental != nil {
+		in, out := &in.Incremental, &out.Incremental
+		*out = new(string)
+		**out = **in
+	}
+	if in.TargetPodUID != nil {
+		in, out := &in.TargetPodUID, &out.TargetPodUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupOptions.
+func (in *BackupOptions) DeepCopy() *BackupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BandwidthLimits) DeepCopyInto(out *BandwidthLimits) {
+	*out = *in
+	if in.Peak != nil {
+		in, out := &in.Peak, &out.Peak
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthLimits.
+func (in *BandwidthLimits) DeepCopy() *BandwidthLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(BandwidthLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bootloader) DeepCopyInto(out *Bootloader) {
 	*out = *in
@@ -188,6 +272,11 @@ func (in *CPU) DeepCopyInto(out *CPU) {
 		*out = make([]CPUFeature, len(*in))
 		copy(*out, *in)
 	}
+	if in.NUMA != nil {
+		in, out := &in.NUMA, &out.NUMA
+		*out = new(NUMA)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -217,6 +306,22 @@ func (in *CPUFeature) DeepCopy() *CPUFeature {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUTopology) DeepCopyInto(out *CPUTopology) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUTopology.
+func (in *CPUTopology) DeepCopy() *CPUTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Chassis) DeepCopyInto(out *Chassis) {
 	*out = *in
@@ -654,6 +759,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(Rng)
 		**out = **in
 	}
+	if in.TPM != nil {
+		in, out := &in.TPM, &out.TPM
+		*out = new(TPMDevice)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BlockMultiQueue != nil {
 		in, out := &in.BlockMultiQueue, &out.BlockMultiQueue
 		*out = new(bool)
@@ -708,6 +818,11 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IOTune != nil {
+		in, out := &in.IOTune, &out.IOTune
+		*out = new(DiskIOTune)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -757,6 +872,43 @@ func (in *DiskDevice) DeepCopy() *DiskDevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskIOTune) DeepCopyInto(out *DiskIOTune) {
+	*out = *in
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(DiskIOTuneBurst)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskIOTune.
+func (in *DiskIOTune) DeepCopy() *DiskIOTune {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskIOTune)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskIOTuneBurst) DeepCopyInto(out *DiskIOTuneBurst) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskIOTuneBurst.
+func (in *DiskIOTuneBurst) DeepCopy() *DiskIOTuneBurst {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskIOTuneBurst)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskTarget) DeepCopyInto(out *DiskTarget) {
 	*out = *in
@@ -858,6 +1010,11 @@ func (in *EFI) DeepCopyInto(out *EFI) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Persistent != nil {
+		in, out := &in.Persistent, &out.Persistent
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -871,6 +1028,22 @@ func (in *EFI) DeepCopy() *EFI {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EjectMediaOptions) DeepCopyInto(out *EjectMediaOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EjectMediaOptions.
+func (in *EjectMediaOptions) DeepCopy() *EjectMediaOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(EjectMediaOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmptyDiskSource) DeepCopyInto(out *EmptyDiskSource) {
 	*out = *in
@@ -1179,6 +1352,43 @@ func (in *FilesystemVirtiofs) DeepCopy() *FilesystemVirtiofs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallPolicy) DeepCopyInto(out *FirewallPolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]FirewallRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallPolicy.
+func (in *FirewallPolicy) DeepCopy() *FirewallPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRule) DeepCopyInto(out *FirewallRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRule.
+func (in *FirewallRule) DeepCopy() *FirewallRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Firmware) DeepCopyInto(out *Firmware) {
 	*out = *in
@@ -1216,6 +1426,27 @@ func (in *FloppyTarget) DeepCopy() *FloppyTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeUnfreezeTimeout) DeepCopyInto(out *FreezeUnfreezeTimeout) {
+	*out = *in
+	if in.UnfreezeTimeout != nil {
+		in, out := &in.UnfreezeTimeout, &out.UnfreezeTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeUnfreezeTimeout.
+func (in *FreezeUnfreezeTimeout) DeepCopy() *FreezeUnfreezeTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeUnfreezeTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPU) DeepCopyInto(out *GPU) {
 	*out = *in
@@ -1412,6 +1643,27 @@ func (in *I6300ESBWatchdog) DeepCopy() *I6300ESBWatchdog {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectMediaOptions) DeepCopyInto(out *InjectMediaOptions) {
+	*out = *in
+	if in.VolumeSource != nil {
+		in, out := &in.VolumeSource, &out.VolumeSource
+		*out = new(HotplugVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectMediaOptions.
+func (in *InjectMediaOptions) DeepCopy() *InjectMediaOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectMediaOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Input) DeepCopyInto(out *Input) {
 	*out = *in
@@ -1428,10 +1680,31 @@ func (in *Input) DeepCopy() *Input {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstancetypeMatcher) DeepCopyInto(out *InstancetypeMatcher) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancetypeMatcher.
+func (in *InstancetypeMatcher) DeepCopy() *InstancetypeMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(InstancetypeMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Interface) DeepCopyInto(out *Interface) {
 	*out = *in
 	in.InterfaceBindingMethod.DeepCopyInto(&out.InterfaceBindingMethod)
+	if in.Binding != nil {
+		in, out := &in.Binding, &out.Binding
+		*out = new(PluginBinding)
+		**out = **in
+	}
 	if in.Ports != nil {
 		in, out := &in.Ports, &out.Ports
 		*out = make([]Port, len(*in))
@@ -1447,6 +1720,21 @@ func (in *Interface) DeepCopyInto(out *Interface) {
 		*out = new(DHCPOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IPAM != nil {
+		in, out := &in.IPAM, &out.IPAM
+		*out = new(InterfaceIPAM)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(InterfaceBandwidth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Firewall != nil {
+		in, out := &in.Firewall, &out.Firewall
+		*out = new(InterfaceFirewall)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1460,6 +1748,32 @@ func (in *Interface) DeepCopy() *Interface {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceBandwidth) DeepCopyInto(out *InterfaceBandwidth) {
+	*out = *in
+	if in.Inbound != nil {
+		in, out := &in.Inbound, &out.Inbound
+		*out = new(BandwidthLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outbound != nil {
+		in, out := &in.Outbound, &out.Outbound
+		*out = new(BandwidthLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceBandwidth.
+func (in *InterfaceBandwidth) DeepCopy() *InterfaceBandwidth {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceBandwidth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 	*out = *in
@@ -1488,6 +1802,11 @@ func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 		*out = new(InterfaceMacvtap)
 		**out = **in
 	}
+	if in.VhostUser != nil {
+		in, out := &in.VhostUser, &out.VhostUser
+		*out = new(InterfaceVhostUser)
+		**out = **in
+	}
 	return
 }
 
@@ -1501,6 +1820,22 @@ func (in *InterfaceBindingMethod) DeepCopy() *InterfaceBindingMethod {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceBindingPlugin) DeepCopyInto(out *InterfaceBindingPlugin) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceBindingPlugin.
+func (in *InterfaceBindingPlugin) DeepCopy() *InterfaceBindingPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceBindingPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceBridge) DeepCopyInto(out *InterfaceBridge) {
 	*out = *in
@@ -1517,6 +1852,89 @@ func (in *InterfaceBridge) DeepCopy() *InterfaceBridge {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceDNS) DeepCopyInto(out *InterfaceDNS) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Search != nil {
+		in, out := &in.Search, &out.Search
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceDNS.
+func (in *InterfaceDNS) DeepCopy() *InterfaceDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceFirewall) DeepCopyInto(out *InterfaceFirewall) {
+	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(FirewallPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(FirewallPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceFirewall.
+func (in *InterfaceFirewall) DeepCopy() *InterfaceFirewall {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceFirewall)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceIPAM) DeepCopyInto(out *InterfaceIPAM) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]InterfaceRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(InterfaceDNS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceIPAM.
+func (in *InterfaceIPAM) DeepCopy() *InterfaceIPAM {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceIPAM)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceMacvtap) DeepCopyInto(out *InterfaceMacvtap) {
 	*out = *in
@@ -1549,6 +1967,22 @@ func (in *InterfaceMasquerade) DeepCopy() *InterfaceMasquerade {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceRoute) DeepCopyInto(out *InterfaceRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceRoute.
+func (in *InterfaceRoute) DeepCopy() *InterfaceRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceSRIOV) DeepCopyInto(out *InterfaceSRIOV) {
 	*out = *in
@@ -1581,6 +2015,22 @@ func (in *InterfaceSlirp) DeepCopy() *InterfaceSlirp {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceVhostUser) DeepCopyInto(out *InterfaceVhostUser) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceVhostUser.
+func (in *InterfaceVhostUser) DeepCopy() *InterfaceVhostUser {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceVhostUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KVMTimer) DeepCopyInto(out *KVMTimer) {
 	*out = *in
@@ -1934,6 +2384,43 @@ func (in *LunTarget) DeepCopy() *LunTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MacPoolConfiguration) DeepCopyInto(out *MacPoolConfiguration) {
+	*out = *in
+	if in.Ranges != nil {
+		in, out := &in.Ranges, &out.Ranges
+		*out = make([]MacPoolRange, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MacPoolConfiguration.
+func (in *MacPoolConfiguration) DeepCopy() *MacPoolConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MacPoolConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MacPoolRange) DeepCopyInto(out *MacPoolRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MacPoolRange.
+func (in *MacPoolRange) DeepCopy() *MacPoolRange {
+	if in == nil {
+		return nil
+	}
+	out := new(MacPoolRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Machine) DeepCopyInto(out *Machine) {
 	*out = *in
@@ -1992,6 +2479,22 @@ func (in *Memory) DeepCopy() *Memory {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigratedVolume) DeepCopyInto(out *MigratedVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigratedVolume.
+func (in *MigratedVolume) DeepCopy() *MigratedVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(MigratedVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
 	*out = *in
@@ -2043,28 +2546,81 @@ func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationConfiguration.
-func (in *MigrationConfiguration) DeepCopy() *MigrationConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationConfiguration.
+func (in *MigrationConfiguration) DeepCopy() *MigrationConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationDiskCopyProgress) DeepCopyInto(out *MigrationDiskCopyProgress) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationDiskCopyProgress.
+func (in *MigrationDiskCopyProgress) DeepCopy() *MigrationDiskCopyProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationDiskCopyProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultusNetwork) DeepCopyInto(out *MultusNetwork) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultusNetwork.
+func (in *MultusNetwork) DeepCopy() *MultusNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(MultusNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMA) DeepCopyInto(out *NUMA) {
+	*out = *in
+	if in.GuestMappingPassthrough != nil {
+		in, out := &in.GuestMappingPassthrough, &out.GuestMappingPassthrough
+		*out = new(NUMAGuestMappingPassthrough)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMA.
+func (in *NUMA) DeepCopy() *NUMA {
 	if in == nil {
 		return nil
 	}
-	out := new(MigrationConfiguration)
+	out := new(NUMA)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultusNetwork) DeepCopyInto(out *MultusNetwork) {
+func (in *NUMAGuestMappingPassthrough) DeepCopyInto(out *NUMAGuestMappingPassthrough) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultusNetwork.
-func (in *MultusNetwork) DeepCopy() *MultusNetwork {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMAGuestMappingPassthrough.
+func (in *NUMAGuestMappingPassthrough) DeepCopy() *NUMAGuestMappingPassthrough {
 	if in == nil {
 		return nil
 	}
-	out := new(MultusNetwork)
+	out := new(NUMAGuestMappingPassthrough)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2099,6 +2655,18 @@ func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Binding != nil {
+		in, out := &in.Binding, &out.Binding
+		*out = make(map[string]InterfaceBindingPlugin, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MacPool != nil {
+		in, out := &in.MacPool, &out.MacPool
+		*out = new(MacPoolConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2236,6 +2804,55 @@ func (in *PermittedHostDevices) DeepCopy() *PermittedHostDevices {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentVolumeClaimInfo) DeepCopyInto(out *PersistentVolumeClaimInfo) {
+	*out = *in
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(corev1.PersistentVolumeMode)
+		**out = **in
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimInfo.
+func (in *PersistentVolumeClaimInfo) DeepCopy() *PersistentVolumeClaimInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginBinding) DeepCopyInto(out *PluginBinding) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginBinding.
+func (in *PluginBinding) DeepCopy() *PluginBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodNetwork) DeepCopyInto(out *PodNetwork) {
 	*out = *in
@@ -2268,6 +2885,22 @@ func (in *Port) DeepCopy() *Port {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreferenceMatcher) DeepCopyInto(out *PreferenceMatcher) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreferenceMatcher.
+func (in *PreferenceMatcher) DeepCopy() *PreferenceMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(PreferenceMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Probe) DeepCopyInto(out *Probe) {
 	*out = *in
@@ -2343,6 +2976,22 @@ func (in *RTCTimer) DeepCopy() *RTCTimer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoveInterfaceOptions) DeepCopyInto(out *RemoveInterfaceOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoveInterfaceOptions.
+func (in *RemoveInterfaceOptions) DeepCopy() *RemoveInterfaceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoveInterfaceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoveVolumeOptions) DeepCopyInto(out *RemoveVolumeOptions) {
 	*out = *in
@@ -2567,6 +3216,43 @@ func (in *ServiceAccountVolumeSource) DeepCopy() *ServiceAccountVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SetIOTuneOptions) DeepCopyInto(out *SetIOTuneOptions) {
+	*out = *in
+	if in.IOTune != nil {
+		in, out := &in.IOTune, &out.IOTune
+		*out = new(DiskIOTune)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SetIOTuneOptions.
+func (in *SetIOTuneOptions) DeepCopy() *SetIOTuneOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SetIOTuneOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SetLinkStateOptions) DeepCopyInto(out *SetLinkStateOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SetLinkStateOptions.
+func (in *SetLinkStateOptions) DeepCopy() *SetLinkStateOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SetLinkStateOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyNICTimer) DeepCopyInto(out *SyNICTimer) {
 	*out = *in
@@ -2619,6 +3305,27 @@ func (in *SysprepSource) DeepCopy() *SysprepSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TPMDevice) DeepCopyInto(out *TPMDevice) {
+	*out = *in
+	if in.Persistent != nil {
+		in, out := &in.Persistent, &out.Persistent
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TPMDevice.
+func (in *TPMDevice) DeepCopy() *TPMDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(TPMDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Timer) DeepCopyInto(out *Timer) {
 	*out = *in
@@ -2810,6 +3517,57 @@ func (in *VirtualMachineInstance) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceBackupState) DeepCopyInto(out *VirtualMachineInstanceBackupState) {
+	*out = *in
+	if in.Exports != nil {
+		in, out := &in.Exports, &out.Exports
+		*out = make([]BackupExport, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceBackupState.
+func (in *VirtualMachineInstanceBackupState) DeepCopy() *VirtualMachineInstanceBackupState {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceBackupState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceCheckpoint) DeepCopyInto(out *VirtualMachineInstanceCheckpoint) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceCheckpoint.
+func (in *VirtualMachineInstanceCheckpoint) DeepCopy() *VirtualMachineInstanceCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceCondition) DeepCopyInto(out *VirtualMachineInstanceCondition) {
 	*out = *in
@@ -2928,6 +3686,32 @@ func (in *VirtualMachineInstanceGuestAgentInfo) DeepCopyObject() runtime.Object
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceGuestDNS) DeepCopyInto(out *VirtualMachineInstanceGuestDNS) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Search != nil {
+		in, out := &in.Search, &out.Search
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestDNS.
+func (in *VirtualMachineInstanceGuestDNS) DeepCopy() *VirtualMachineInstanceGuestDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceGuestDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceGuestOSInfo) DeepCopyInto(out *VirtualMachineInstanceGuestOSInfo) {
 	*out = *in
@@ -3137,6 +3921,11 @@ func (in *VirtualMachineInstanceMigrationState) DeepCopyInto(out *VirtualMachine
 			(*out)[key] = val
 		}
 	}
+	if in.DiskCopyProgress != nil {
+		in, out := &in.DiskCopyProgress, &out.DiskCopyProgress
+		*out = new(MigrationDiskCopyProgress)
+		**out = **in
+	}
 	return
 }
 
@@ -3181,6 +3970,21 @@ func (in *VirtualMachineInstanceNetworkInterface) DeepCopyInto(out *VirtualMachi
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPDetails != nil {
+		in, out := &in.IPDetails, &out.IPDetails
+		*out = make([]VirtualMachineInstanceNetworkInterfaceIP, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]VirtualMachineInstanceNetworkRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.Firewall != nil {
+		in, out := &in.Firewall, &out.Firewall
+		*out = new(InterfaceFirewall)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3194,6 +3998,38 @@ func (in *VirtualMachineInstanceNetworkInterface) DeepCopy() *VirtualMachineInst
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceNetworkInterfaceIP) DeepCopyInto(out *VirtualMachineInstanceNetworkInterfaceIP) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceNetworkInterfaceIP.
+func (in *VirtualMachineInstanceNetworkInterfaceIP) DeepCopy() *VirtualMachineInstanceNetworkInterfaceIP {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceNetworkInterfaceIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceNetworkRoute) DeepCopyInto(out *VirtualMachineInstanceNetworkRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceNetworkRoute.
+func (in *VirtualMachineInstanceNetworkRoute) DeepCopy() *VirtualMachineInstanceNetworkRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceNetworkRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstancePreset) DeepCopyInto(out *VirtualMachineInstancePreset) {
 	*out = *in
@@ -3509,6 +4345,11 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		}
 	}
 	out.GuestOSInfo = in.GuestOSInfo
+	if in.GuestDNS != nil {
+		in, out := &in.GuestDNS, &out.GuestDNS
+		*out = new(VirtualMachineInstanceGuestDNS)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MigrationState != nil {
 		in, out := &in.MigrationState, &out.MigrationState
 		*out = new(VirtualMachineInstanceMigrationState)
@@ -3533,6 +4374,33 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CurrentCPUTopology != nil {
+		in, out := &in.CurrentCPUTopology, &out.CurrentCPUTopology
+		*out = new(CPUTopology)
+		**out = **in
+	}
+	if in.MaxCPUTopology != nil {
+		in, out := &in.MaxCPUTopology, &out.MaxCPUTopology
+		*out = new(CPUTopology)
+		**out = **in
+	}
+	if in.VolumeMigration != nil {
+		in, out := &in.VolumeMigration, &out.VolumeMigration
+		*out = new(VolumeMigrationState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(VirtualMachineInstanceBackupState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checkpoints != nil {
+		in, out := &in.Checkpoints, &out.Checkpoints
+		*out = make([]VirtualMachineInstanceCheckpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -3564,6 +4432,32 @@ func (in *VirtualMachineInstanceTemplateSpec) DeepCopy() *VirtualMachineInstance
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInterfaceRequest) DeepCopyInto(out *VirtualMachineInterfaceRequest) {
+	*out = *in
+	if in.AddInterfaceOptions != nil {
+		in, out := &in.AddInterfaceOptions, &out.AddInterfaceOptions
+		*out = new(AddInterfaceOptions)
+		**out = **in
+	}
+	if in.RemoveInterfaceOptions != nil {
+		in, out := &in.RemoveInterfaceOptions, &out.RemoveInterfaceOptions
+		*out = new(RemoveInterfaceOptions)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInterfaceRequest.
+func (in *VirtualMachineInterfaceRequest) DeepCopy() *VirtualMachineInterfaceRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInterfaceRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineList) DeepCopyInto(out *VirtualMachineList) {
 	*out = *in
@@ -3610,6 +4504,16 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(VirtualMachineRunStrategy)
 		**out = **in
 	}
+	if in.Instancetype != nil {
+		in, out := &in.Instancetype, &out.Instancetype
+		*out = new(InstancetypeMatcher)
+		**out = **in
+	}
+	if in.Preference != nil {
+		in, out := &in.Preference, &out.Preference
+		*out = new(PreferenceMatcher)
+		**out = **in
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(VirtualMachineInstanceTemplateSpec)
@@ -3622,6 +4526,11 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UpdateVolumesStrategy != nil {
+		in, out := &in.UpdateVolumesStrategy, &out.UpdateVolumesStrategy
+		*out = new(UpdateVolumesStrategy)
+		**out = **in
+	}
 	return
 }
 
@@ -3692,6 +4601,13 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.InterfaceRequests != nil {
+		in, out := &in.InterfaceRequests, &out.InterfaceRequests
+		*out = make([]VirtualMachineInterfaceRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.VolumeSnapshotStatuses != nil {
 		in, out := &in.VolumeSnapshotStatuses, &out.VolumeSnapshotStatuses
 		*out = make([]VolumeSnapshotStatus, len(*in))
@@ -3753,6 +4669,27 @@ func (in *Volume) DeepCopy() *Volume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeMigrationState) DeepCopyInto(out *VolumeMigrationState) {
+	*out = *in
+	if in.MigratedVolumes != nil {
+		in, out := &in.MigratedVolumes, &out.MigratedVolumes
+		*out = make([]MigratedVolume, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeMigrationState.
+func (in *VolumeMigrationState) DeepCopy() *VolumeMigrationState {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeMigrationState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshotStatus) DeepCopyInto(out *VolumeSnapshotStatus) {
 	*out = *in
@@ -3858,6 +4795,11 @@ func (in *VolumeStatus) DeepCopyInto(out *VolumeStatus) {
 		*out = new(HotplugVolumeStatus)
 		**out = **in
 	}
+	if in.PersistentVolumeClaimInfo != nil {
+		in, out := &in.PersistentVolumeClaimInfo, &out.PersistentVolumeClaimInfo
+		*out = new(PersistentVolumeClaimInfo)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -254,6 +254,12 @@ type EFI struct {
 	// Defaults to true
 	// +optional
 	SecureBoot *bool `json:"secureBoot,omitempty"`
+	// If set to true, the EFI NVRAM is stored in the persistent state volume
+	// of the VirtualMachine and survives restarts and migrations.
+	// Requires the VMPersistentState feature gate.
+	// Defaults to false
+	// +optional
+	Persistent *bool `json:"persistent,omitempty"`
 }
 
 //
@@ -283,6 +289,11 @@ type CPU struct {
 	// Sockets specifies the number of sockets inside the vmi.
 	// Must be a value greater or equal 1.
 	Sockets uint32 `json:"sockets,omitempty"`
+	// MaxSockets specifies the maximum number of sockets the vmi can be scaled up to
+	// while it is running. The domain is defined with the additional sockets offline.
+	// Requires the CPUHotplug feature gate.
+	// +optional
+	MaxSockets uint32 `json:"maxSockets,omitempty"`
 	// Threads specifies the number of threads inside the vmi.
 	// Must be a value greater or equal 1.
 	Threads uint32 `json:"threads,omitempty"`
@@ -304,6 +315,27 @@ type CPU struct {
 	// the emulator thread on it.
 	// +optional
 	IsolateEmulatorThread bool `json:"isolateEmulatorThread,omitempty"`
+	// NUMA allows specifying settings for the guest NUMA topology.
+	// +optional
+	NUMA *NUMA `json:"numa,omitempty"`
+}
+
+// NUMA allows specifying settings for the guest NUMA topology.
+//
+// +k8s:openapi-gen=true
+type NUMA struct {
+	// GuestMappingPassthrough creates a guest NUMA topology which mirrors the host NUMA nodes
+	// of the dedicated pCPUs and hugepages of the VMI. Guest NUMA nodes never cross host NUMA nodes.
+	// Requires DedicatedCPUPlacement, hugepages and the NUMA feature gate.
+	// +optional
+	GuestMappingPassthrough *NUMAGuestMappingPassthrough `json:"guestMappingPassthrough,omitempty"`
+}
+
+// NUMAGuestMappingPassthrough instructs virt-launcher to derive the guest NUMA topology
+// from the host NUMA nodes of the pCPUs assigned to the pod.
+//
+// +k8s:openapi-gen=true
+type NUMAGuestMappingPassthrough struct {
 }
 
 // CPUFeature allows specifying a CPU feature.
@@ -398,6 +430,9 @@ type Devices struct {
 	// Whether to have random number generator from host
 	// +optional
 	Rng *Rng `json:"rng,omitempty"`
+	// Whether to emulate a TPM 2.0 device backed by a software TPM.
+	// +optional
+	TPM *TPMDevice `json:"tpm,omitempty"`
 	// Whether or not to enable virtio multi-queue for block devices.
 	// Defaults to false.
 	// +optional
@@ -494,6 +529,68 @@ type Disk struct {
 	// If specified, disk address and its tag will be provided to the guest via config drive metadata
 	// +optional
 	Tag string `json:"tag,omitempty"`
+	// IOTune limits the throughput and the I/O operations of the disk.
+	// It can be changed on a running VMI through the setiotune subresource.
+	// +optional
+	IOTune *DiskIOTune `json:"ioTune,omitempty"`
+}
+
+// DiskIOTune limits the I/O of a disk, rendered into the libvirt iotune element.
+// Limits which are not set or 0 are unlimited. A total limit can not be combined with
+// a read or write limit of the same kind.
+//
+// +k8s:openapi-gen=true
+type DiskIOTune struct {
+	// Total throughput limit in bytes per second.
+	// +optional
+	TotalBytesSec uint64 `json:"totalBytesSec,omitempty"`
+	// Read throughput limit in bytes per second.
+	// +optional
+	ReadBytesSec uint64 `json:"readBytesSec,omitempty"`
+	// Write throughput limit in bytes per second.
+	// +optional
+	WriteBytesSec uint64 `json:"writeBytesSec,omitempty"`
+	// Total I/O operations per second.
+	// +optional
+	TotalIOPSSec uint64 `json:"totalIopsSec,omitempty"`
+	// Read I/O operations per second.
+	// +optional
+	ReadIOPSSec uint64 `json:"readIopsSec,omitempty"`
+	// Write I/O operations per second.
+	// +optional
+	WriteIOPSSec uint64 `json:"writeIopsSec,omitempty"`
+	// Burst allows exceeding the limits for a short time.
+	// +optional
+	Burst *DiskIOTuneBurst `json:"burst,omitempty"`
+}
+
+// DiskIOTuneBurst are the limits a disk can burst to. Each burst limit requires the
+// matching limit to be set, and has to be greater than it.
+//
+// +k8s:openapi-gen=true
+type DiskIOTuneBurst struct {
+	// Total throughput burst limit in bytes per second.
+	// +optional
+	TotalBytesSec uint64 `json:"totalBytesSec,omitempty"`
+	// Read throughput burst limit in bytes per second.
+	// +optional
+	ReadBytesSec uint64 `json:"readBytesSec,omitempty"`
+	// Write throughput burst limit in bytes per second.
+	// +optional
+	WriteBytesSec uint64 `json:"writeBytesSec,omitempty"`
+	// Total I/O operations per second burst limit.
+	// +optional
+	TotalIOPSSec uint64 `json:"totalIopsSec,omitempty"`
+	// Read I/O operations per second burst limit.
+	// +optional
+	ReadIOPSSec uint64 `json:"readIopsSec,omitempty"`
+	// Write I/O operations per second burst limit.
+	// +optional
+	WriteIOPSSec uint64 `json:"writeIopsSec,omitempty"`
+	// LengthSeconds is how long the burst limits can be sustained.
+	// Defaults to 1.
+	// +optional
+	LengthSeconds uint64 `json:"lengthSeconds,omitempty"`
 }
 
 // Represents the target of a volume to mount.
@@ -561,6 +658,8 @@ const (
 	TrayStateClosed TrayState = "closed"
 )
 
+// CDRomTarget is a CD-ROM drive. A CD-ROM without a volume of the same name is an
+// empty drive, media can be injected into it and ejected from a running VMI.
 //
 // +k8s:openapi-gen=true
 type CDRomTarget struct {
@@ -1087,6 +1186,10 @@ type Interface struct {
 	// BindingMethod specifies the method which will be used to connect the interface to the guest.
 	// Defaults to Bridge.
 	InterfaceBindingMethod `json:",inline"`
+	// Binding specifies a network binding plugin which connects the interface to the guest.
+	// It can not be combined with the other binding methods.
+	// +optional
+	Binding *PluginBinding `json:"binding,omitempty"`
 	// List of ports to be forwarded to the virtual machine.
 	Ports []Port `json:"ports,omitempty"`
 	// Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.
@@ -1103,11 +1206,117 @@ type Interface struct {
 	// If specified the network interface will pass additional DHCP options to the VMI
 	// +optional
 	DHCPOptions *DHCPOptions `json:"dhcpOptions,omitempty"`
+	// If specified, the guest addresses of the interface are assigned statically instead of being
+	// discovered on the pod interface. Only supported for bridge interfaces on secondary Multus networks
+	// without an IPAM plugin.
+	// +optional
+	IPAM *InterfaceIPAM `json:"ipam,omitempty"`
 	// If specified, the virtual network interface address and its tag will be provided to the guest via config drive
 	// +optional
 	Tag string `json:"tag,omitempty"`
+	// If specified, limits the inbound and outbound traffic of the interface.
+	// +optional
+	Bandwidth *InterfaceBandwidth `json:"bandwidth,omitempty"`
+	// LinkState of the virtual network interface as seen by the guest.
+	// One of: up, down.
+	// Defaults to up.
+	// +optional
+	LinkState InterfaceLinkState `json:"linkState,omitempty"`
+	// If specified, filters the traffic of the interface in the virt-launcher pod.
+	// Only supported for bridge and masquerade interfaces.
+	// +optional
+	Firewall *InterfaceFirewall `json:"firewall,omitempty"`
+}
+
+// InterfaceLinkState represents the link state of a virtual network interface.
+type InterfaceLinkState string
+
+const (
+	InterfaceLinkStateUp   InterfaceLinkState = "up"
+	InterfaceLinkStateDown InterfaceLinkState = "down"
+)
+
+// InterfaceBandwidth limits the traffic of a network interface.
+// Inbound is the traffic received by the guest, outbound the traffic sent by the guest.
+//
+// +k8s:openapi-gen=true
+type InterfaceBandwidth struct {
+	// +optional
+	Inbound *BandwidthLimits `json:"inbound,omitempty"`
+	// +optional
+	Outbound *BandwidthLimits `json:"outbound,omitempty"`
+}
+
+// BandwidthLimits of a single traffic direction.
+//
+// +k8s:openapi-gen=true
+type BandwidthLimits struct {
+	// Average bit rate in kilobytes per second.
+	Average uint32 `json:"average"`
+	// Maximum rate at which the interface can send data, in kilobytes per second.
+	// +optional
+	Peak *uint32 `json:"peak,omitempty"`
+	// Amount of kilobytes that can be burst at peak speed.
+	// +optional
+	Burst *uint32 `json:"burst,omitempty"`
+}
+
+// InterfaceFirewall filters the traffic of a network interface.
+// Ingress is the traffic received by the guest, egress the traffic sent by the guest.
+// Connections which were allowed are tracked, so that their replies are always allowed.
+//
+// +k8s:openapi-gen=true
+type InterfaceFirewall struct {
+	// +optional
+	Ingress *FirewallPolicy `json:"ingress,omitempty"`
+	// +optional
+	Egress *FirewallPolicy `json:"egress,omitempty"`
 }
 
+// FirewallPolicy filters a single traffic direction.
+//
+// +k8s:openapi-gen=true
+type FirewallPolicy struct {
+	// Rules are evaluated in order, the first rule matching a packet decides about it.
+	// +optional
+	Rules []FirewallRule `json:"rules,omitempty"`
+	// DefaultAction is taken for the traffic not matched by any rule.
+	// One of: allow, deny.
+	// Defaults to allow.
+	// +optional
+	DefaultAction FirewallAction `json:"defaultAction,omitempty"`
+}
+
+// FirewallRule matches traffic by the address of the remote peer, the protocol and the port.
+// Empty fields match any traffic.
+//
+// +k8s:openapi-gen=true
+type FirewallRule struct {
+	// Action taken for the matching traffic.
+	// One of: allow, deny.
+	Action FirewallAction `json:"action"`
+	// CIDR of the remote peer, the source of ingress and the destination of egress traffic.
+	// For example: 10.10.0.0/16 or fd10::/64.
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+	// Protocol of the traffic.
+	// One of: TCP, UDP, ICMP.
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+	// Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress.
+	// Requires the TCP or UDP protocol.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+// FirewallAction is the action taken by a firewall for the matching traffic.
+type FirewallAction string
+
+const (
+	FirewallActionAllow FirewallAction = "allow"
+	FirewallActionDeny  FirewallAction = "deny"
+)
+
 // Extra DHCP options to use in the interface.
 //
 // +k8s:openapi-gen=true
@@ -1126,6 +1335,53 @@ type DHCPOptions struct {
 	PrivateOptions []DHCPPrivateOptions `json:"privateOptions,omitempty"`
 }
 
+// InterfaceIPAM statically configures the guest network of an interface.
+// The configuration is served by the DHCP servers of the interface and,
+// unless network data is provided, injected into the cloud-init NoCloud network-config.
+//
+// +k8s:openapi-gen=true
+type InterfaceIPAM struct {
+	// Addresses of the interface in CIDR notation, e.g. 192.168.100.10/24 or fd10:100::10/64.
+	// At most one IPv4 and one IPv6 address can be specified.
+	Addresses []string `json:"addresses"`
+	// Gateway is the IPv4 default gateway.
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+	// GatewayIPv6 is the IPv6 default gateway.
+	// DHCPv6 can not announce gateways, it is only applied through cloud-init.
+	// +optional
+	GatewayIPv6 string `json:"gatewayIPv6,omitempty"`
+	// Routes in addition to the default routes.
+	// +optional
+	Routes []InterfaceRoute `json:"routes,omitempty"`
+	// DNS configuration of the interface.
+	// Defaults to the DNS configuration of the virt-launcher pod.
+	// +optional
+	DNS *InterfaceDNS `json:"dns,omitempty"`
+}
+
+// InterfaceRoute is a static route of an interface.
+//
+// +k8s:openapi-gen=true
+type InterfaceRoute struct {
+	// Destination network in CIDR notation.
+	Destination string `json:"destination"`
+	// Gateway through which the destination is reached.
+	Gateway string `json:"gateway"`
+}
+
+// InterfaceDNS is the DNS configuration of an interface.
+//
+// +k8s:openapi-gen=true
+type InterfaceDNS struct {
+	// Nameservers are the IP addresses of the DNS servers.
+	// +optional
+	Nameservers []string `json:"nameservers,omitempty"`
+	// Search is the list of DNS search domains.
+	// +optional
+	Search []string `json:"search,omitempty"`
+}
+
 // DHCPExtraOptions defines Extra DHCP options for a VM.
 //
 // +k8s:openapi-gen=true
@@ -1148,6 +1404,15 @@ type InterfaceBindingMethod struct {
 	Masquerade *InterfaceMasquerade `json:"masquerade,omitempty"`
 	SRIOV      *InterfaceSRIOV      `json:"sriov,omitempty"`
 	Macvtap    *InterfaceMacvtap    `json:"macvtap,omitempty"`
+	VhostUser  *InterfaceVhostUser  `json:"vhostuser,omitempty"`
+}
+
+// PluginBinding refers to a network binding plugin registered in the KubeVirt configuration.
+//
+// +k8s:openapi-gen=true
+type PluginBinding struct {
+	// Name of the network binding plugin.
+	Name string `json:"name"`
 }
 
 //
@@ -1170,6 +1435,12 @@ type InterfaceSRIOV struct{}
 // +k8s:openapi-gen=true
 type InterfaceMacvtap struct{}
 
+// InterfaceVhostUser connects to a vhost-user socket provided by a userspace switch,
+// e.g. OVS-DPDK, through a Multus network.
+//
+// +k8s:openapi-gen=true
+type InterfaceVhostUser struct{}
+
 // Port repesents a port to expose from the virtual machine.
 // Default protocol TCP.
 // The port field is mandatory
@@ -1356,6 +1627,18 @@ type PodNetwork struct {
 type Rng struct {
 }
 
+// TPMDevice represents an emulated TPM 2.0 device.
+//
+// +k8s:openapi-gen=true
+type TPMDevice struct {
+	// If set to true, the TPM state is stored in the persistent state volume
+	// of the VirtualMachine and survives restarts and migrations.
+	// Requires the VMPersistentState feature gate.
+	// Defaults to false
+	// +optional
+	Persistent *bool `json:"persistent,omitempty"`
+}
+
 // Represents the multus cni network.
 //
 // +k8s:openapi-gen=true
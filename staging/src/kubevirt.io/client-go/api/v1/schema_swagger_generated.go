@@ -117,6 +117,7 @@ func (EFI) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":           "If set, EFI will be used instead of BIOS.\n\n+k8s:openapi-gen=true",
 		"secureBoot": "If set, SecureBoot will be enabled and the OVMF roms will be swapped for\nSecureBoot-enabled ones.\nRequires SMM to be enabled.\nDefaults to true\n+optional",
+		"persistent": "If set to true, the EFI NVRAM is stored in the persistent state volume\nof the VirtualMachine and survives restarts and migrations.\nRequires the VMPersistentState feature gate.\nDefaults to false\n+optional",
 	}
 }
 
@@ -134,11 +135,26 @@ func (CPU) SwaggerDoc() map[string]string {
 		"":                      "CPU allows specifying the CPU topology.\n\n+k8s:openapi-gen=true",
 		"cores":                 "Cores specifies the number of cores inside the vmi.\nMust be a value greater or equal 1.",
 		"sockets":               "Sockets specifies the number of sockets inside the vmi.\nMust be a value greater or equal 1.",
+		"maxSockets":            "MaxSockets specifies the maximum number of sockets the vmi can be scaled up to\nwhile it is running. The domain is defined with the additional sockets offline.\nRequires the CPUHotplug feature gate.\n+optional",
 		"threads":               "Threads specifies the number of threads inside the vmi.\nMust be a value greater or equal 1.",
 		"model":                 "Model specifies the CPU model inside the VMI.\nList of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.\nIt is possible to specify special cases like \"host-passthrough\" to get the same CPU as the node\nand \"host-model\" to get CPU closest to the node one.\nDefaults to host-model.\n+optional",
 		"features":              "Features specifies the CPU features list inside the VMI.\n+optional",
 		"dedicatedCpuPlacement": "DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node\nwith enough dedicated pCPUs and pin the vCPUs to it.\n+optional",
 		"isolateEmulatorThread": "IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place\nthe emulator thread on it.\n+optional",
+		"numa":                  "NUMA allows specifying settings for the guest NUMA topology.\n+optional",
+	}
+}
+
+func (NUMA) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "NUMA allows specifying settings for the guest NUMA topology.\n\n+k8s:openapi-gen=true",
+		"guestMappingPassthrough": "GuestMappingPassthrough creates a guest NUMA topology which mirrors the host NUMA nodes\nof the dedicated pCPUs and hugepages of the VMI. Guest NUMA nodes never cross host NUMA nodes.\nRequires DedicatedCPUPlacement, hugepages and the NUMA feature gate.\n+optional",
+	}
+}
+
+func (NUMAGuestMappingPassthrough) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "NUMAGuestMappingPassthrough instructs virt-launcher to derive the guest NUMA topology\nfrom the host NUMA nodes of the pCPUs assigned to the pod.\n\n+k8s:openapi-gen=true",
 	}
 }
 
@@ -195,6 +211,7 @@ func (Devices) SwaggerDoc() map[string]string {
 		"autoattachSerialConsole":    "Whether to attach the default serial console or not.\nSerial console access will not be available if set to false. Defaults to true.",
 		"autoattachMemBalloon":       "Whether to attach the Memory balloon device with default period.\nPeriod can be adjusted in virt-config.\nDefaults to true.\n+optional",
 		"rng":                        "Whether to have random number generator from host\n+optional",
+		"tpm":                        "Whether to emulate a TPM 2.0 device backed by a software TPM.\n+optional",
 		"blockMultiQueue":            "Whether or not to enable virtio multi-queue for block devices.\nDefaults to false.\n+optional",
 		"networkInterfaceMultiqueue": "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.\n+optional",
 		"gpus":                       "Whether to attach a GPU device to the vmi.\n+optional\n+listType=atomic",
@@ -250,6 +267,33 @@ func (Disk) SwaggerDoc() map[string]string {
 		"cache":             "Cache specifies which kvm disk cache mode should be used.\nSupported values are: CacheNone, CacheWriteThrough.\n+optional",
 		"io":                "IO specifies which QEMU disk IO mode should be used.\nSupported values are: native, default, threads.\n+optional",
 		"tag":               "If specified, disk address and its tag will be provided to the guest via config drive metadata\n+optional",
+		"ioTune":            "IOTune limits the throughput and the I/O operations of the disk.\nIt can be changed on a running VMI through the setiotune subresource.\n+optional",
+	}
+}
+
+func (DiskIOTune) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "DiskIOTune limits the I/O of a disk, rendered into the libvirt iotune element.\nLimits which are not set or 0 are unlimited. A total limit can not be combined with\na read or write limit of the same kind.\n\n+k8s:openapi-gen=true",
+		"totalBytesSec": "Total throughput limit in bytes per second.\n+optional",
+		"readBytesSec":  "Read throughput limit in bytes per second.\n+optional",
+		"writeBytesSec": "Write throughput limit in bytes per second.\n+optional",
+		"totalIopsSec":  "Total I/O operations per second.\n+optional",
+		"readIopsSec":   "Read I/O operations per second.\n+optional",
+		"writeIopsSec":  "Write I/O operations per second.\n+optional",
+		"burst":         "Burst allows exceeding the limits for a short time.\n+optional",
+	}
+}
+
+func (DiskIOTuneBurst) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "DiskIOTuneBurst are the limits a disk can burst to. Each burst limit requires the\nmatching limit to be set, and has to be greater than it.\n\n+k8s:openapi-gen=true",
+		"totalBytesSec": "Total throughput burst limit in bytes per second.\n+optional",
+		"readBytesSec":  "Read throughput burst limit in bytes per second.\n+optional",
+		"writeBytesSec": "Write throughput burst limit in bytes per second.\n+optional",
+		"totalIopsSec":  "Total I/O operations per second burst limit.\n+optional",
+		"readIopsSec":   "Read I/O operations per second burst limit.\n+optional",
+		"writeIopsSec":  "Write I/O operations per second burst limit.\n+optional",
+		"lengthSeconds": "LengthSeconds is how long the burst limits can be sustained.\nDefaults to 1.\n+optional",
 	}
 }
 
@@ -290,7 +334,7 @@ func (FloppyTarget) SwaggerDoc() map[string]string {
 
 func (CDRomTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "+k8s:openapi-gen=true",
+		"":         "CDRomTarget is a CD-ROM drive. A CD-ROM without a volume of the same name is an\nempty drive, media can be injected into it and ejected from a running VMI.\n\n+k8s:openapi-gen=true",
 		"bus":      "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
 		"readonly": "ReadOnly.\nDefaults to true.",
 		"tray":     "Tray indicates if the tray of the device is open or closed.\nAllowed values are \"open\" and \"closed\".\nDefaults to closed.\n+optional",
@@ -536,12 +580,60 @@ func (Interface) SwaggerDoc() map[string]string {
 		"":            "+k8s:openapi-gen=true",
 		"name":        "Logical name of the interface as well as a reference to the associated networks.\nMust match the Name of a Network.",
 		"model":       "Interface model.\nOne of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio.\nDefaults to virtio.",
+		"binding":     "Binding specifies a network binding plugin which connects the interface to the guest.\nIt can not be combined with the other binding methods.\n+optional",
 		"ports":       "List of ports to be forwarded to the virtual machine.",
 		"macAddress":  "Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.",
 		"bootOrder":   "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach interface or disk that has a boot order must have a unique value.\nInterfaces without a boot order are not tried.\n+optional",
 		"pciAddress":  "If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
 		"dhcpOptions": "If specified the network interface will pass additional DHCP options to the VMI\n+optional",
+		"ipam":        "If specified, the guest addresses of the interface are assigned statically instead of being\ndiscovered on the pod interface. Only supported for bridge interfaces on secondary Multus networks\nwithout an IPAM plugin.\n+optional",
 		"tag":         "If specified, the virtual network interface address and its tag will be provided to the guest via config drive\n+optional",
+		"bandwidth":   "If specified, limits the inbound and outbound traffic of the interface.\n+optional",
+		"linkState":   "LinkState of the virtual network interface as seen by the guest.\nOne of: up, down.\nDefaults to up.\n+optional",
+		"firewall":    "If specified, filters the traffic of the interface in the virt-launcher pod.\nOnly supported for bridge and masquerade interfaces.\n+optional",
+	}
+}
+
+func (InterfaceBandwidth) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "InterfaceBandwidth limits the traffic of a network interface.\nInbound is the traffic received by the guest, outbound the traffic sent by the guest.\n\n+k8s:openapi-gen=true",
+		"inbound":  "+optional",
+		"outbound": "+optional",
+	}
+}
+
+func (BandwidthLimits) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "BandwidthLimits of a single traffic direction.\n\n+k8s:openapi-gen=true",
+		"average": "Average bit rate in kilobytes per second.",
+		"peak":    "Maximum rate at which the interface can send data, in kilobytes per second.\n+optional",
+		"burst":   "Amount of kilobytes that can be burst at peak speed.\n+optional",
+	}
+}
+
+func (InterfaceFirewall) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "InterfaceFirewall filters the traffic of a network interface.\nIngress is the traffic received by the guest, egress the traffic sent by the guest.\nConnections which were allowed are tracked, so that their replies are always allowed.\n\n+k8s:openapi-gen=true",
+		"ingress": "+optional",
+		"egress":  "+optional",
+	}
+}
+
+func (FirewallPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "FirewallPolicy filters a single traffic direction.\n\n+k8s:openapi-gen=true",
+		"rules":         "Rules are evaluated in order, the first rule matching a packet decides about it.\n+optional",
+		"defaultAction": "DefaultAction is taken for the traffic not matched by any rule.\nOne of: allow, deny.\nDefaults to allow.\n+optional",
+	}
+}
+
+func (FirewallRule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "FirewallRule matches traffic by the address of the remote peer, the protocol and the port.\nEmpty fields match any traffic.\n\n+k8s:openapi-gen=true",
+		"action":   "Action taken for the matching traffic.\nOne of: allow, deny.",
+		"cidr":     "CIDR of the remote peer, the source of ingress and the destination of egress traffic.\nFor example: 10.10.0.0/16 or fd10::/64.\n+optional",
+		"protocol": "Protocol of the traffic.\nOne of: TCP, UDP, ICMP.\n+optional",
+		"port":     "Destination port of the traffic, the port of the guest for ingress and of the remote peer for egress.\nRequires the TCP or UDP protocol.\n+optional",
 	}
 }
 
@@ -555,6 +647,33 @@ func (DHCPOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (InterfaceIPAM) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "InterfaceIPAM statically configures the guest network of an interface.\nThe configuration is served by the DHCP servers of the interface and,\nunless network data is provided, injected into the cloud-init NoCloud network-config.\n\n+k8s:openapi-gen=true",
+		"addresses":   "Addresses of the interface in CIDR notation, e.g. 192.168.100.10/24 or fd10:100::10/64.\nAt most one IPv4 and one IPv6 address can be specified.",
+		"gateway":     "Gateway is the IPv4 default gateway.\n+optional",
+		"gatewayIPv6": "GatewayIPv6 is the IPv6 default gateway.\nDHCPv6 can not announce gateways, it is only applied through cloud-init.\n+optional",
+		"routes":      "Routes in addition to the default routes.\n+optional",
+		"dns":         "DNS configuration of the interface.\nDefaults to the DNS configuration of the virt-launcher pod.\n+optional",
+	}
+}
+
+func (InterfaceRoute) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "InterfaceRoute is a static route of an interface.\n\n+k8s:openapi-gen=true",
+		"destination": "Destination network in CIDR notation.",
+		"gateway":     "Gateway through which the destination is reached.",
+	}
+}
+
+func (InterfaceDNS) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "InterfaceDNS is the DNS configuration of an interface.\n\n+k8s:openapi-gen=true",
+		"nameservers": "Nameservers are the IP addresses of the DNS servers.\n+optional",
+		"search":      "Search is the list of DNS search domains.\n+optional",
+	}
+}
+
 func (DHCPPrivateOptions) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":       "DHCPExtraOptions defines Extra DHCP options for a VM.\n\n+k8s:openapi-gen=true",
@@ -569,6 +688,13 @@ func (InterfaceBindingMethod) SwaggerDoc() map[string]string {
 	}
 }
 
+func (PluginBinding) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "PluginBinding refers to a network binding plugin registered in the KubeVirt configuration.\n\n+k8s:openapi-gen=true",
+		"name": "Name of the network binding plugin.",
+	}
+}
+
 func (InterfaceBridge) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "+k8s:openapi-gen=true",
@@ -599,6 +725,12 @@ func (InterfaceMacvtap) SwaggerDoc() map[string]string {
 	}
 }
 
+func (InterfaceVhostUser) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "InterfaceVhostUser connects to a vhost-user socket provided by a userspace switch,\ne.g. OVS-DPDK, through a Multus network.\n\n+k8s:openapi-gen=true",
+	}
+}
+
 func (Port) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "Port repesents a port to expose from the virtual machine.\nDefault protocol TCP.\nThe port field is mandatory\n\n+k8s:openapi-gen=true",
@@ -720,6 +852,13 @@ func (Rng) SwaggerDoc() map[string]string {
 	}
 }
 
+func (TPMDevice) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "TPMDevice represents an emulated TPM 2.0 device.\n\n+k8s:openapi-gen=true",
+		"persistent": "If set to true, the TPM state is stored in the persistent state volume\nof the VirtualMachine and survives restarts and migrations.\nRequires the VMPersistentState feature gate.\nDefaults to false\n+optional",
+	}
+}
+
 func (MultusNetwork) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":            "Represents the multus cni network.\n\n+k8s:openapi-gen=true",
@@ -177,6 +177,9 @@ type VirtualMachineInstanceStatus struct {
 	Interfaces []VirtualMachineInstanceNetworkInterface `json:"interfaces,omitempty"`
 	// Guest OS Information
 	GuestOSInfo VirtualMachineInstanceGuestOSInfo `json:"guestOSInfo,omitempty"`
+	// DNS configuration of the guest as reported by the guest agent
+	// +optional
+	GuestDNS *VirtualMachineInstanceGuestDNS `json:"guestDNS,omitempty"`
 	// Represents the status of a live migration
 	MigrationState *VirtualMachineInstanceMigrationState `json:"migrationState,omitempty"`
 	// Represents the method using which the vmi can be migrated: live migration or block migration
@@ -203,6 +206,123 @@ type VirtualMachineInstanceStatus struct {
 	// +optional
 	// +listType=atomic
 	VolumeStatus []VolumeStatus `json:"volumeStatus,omitempty"`
+
+	// CurrentCPUTopology is the CPU topology currently plugged into the guest.
+	// +optional
+	CurrentCPUTopology *CPUTopology `json:"currentCPUTopology,omitempty"`
+
+	// MaxCPUTopology is the CPU topology the guest can be scaled up to without a restart.
+	// +optional
+	MaxCPUTopology *CPUTopology `json:"maxCPUTopology,omitempty"`
+
+	// VolumeMigration shows the volumes which are copied to new claims by a live migration.
+	// +optional
+	VolumeMigration *VolumeMigrationState `json:"volumeMigration,omitempty"`
+
+	// Backup shows the backup job exporting the disks of the VirtualMachineInstance.
+	// +optional
+	Backup *VirtualMachineInstanceBackupState `json:"backup,omitempty"`
+
+	// Checkpoints are the checkpoints created by backups, later backups can be incremental to them.
+	// +optional
+	// +listType=atomic
+	Checkpoints []VirtualMachineInstanceCheckpoint `json:"checkpoints,omitempty"`
+}
+
+// VolumeMigrationState represents the copy of volumes of a running VirtualMachineInstance to new claims.
+//
+// +k8s:openapi-gen=true
+type VolumeMigrationState struct {
+	// The name of the VirtualMachineInstanceMigration copying the volumes
+	MigrationName string `json:"migrationName"`
+	// The volumes copied to new claims
+	// +listType=atomic
+	MigratedVolumes []MigratedVolume `json:"migratedVolumes"`
+}
+
+// MigratedVolume is a volume copied from one claim to another.
+//
+// +k8s:openapi-gen=true
+type MigratedVolume struct {
+	// The name of the volume
+	VolumeName string `json:"volumeName"`
+	// The claim the volume is copied from
+	SourceClaim string `json:"sourceClaim"`
+	// The claim the volume is copied to
+	DestinationClaim string `json:"destinationClaim"`
+}
+
+// VirtualMachineInstanceBackupState represents the backup job of a running VirtualMachineInstance.
+// The disks are exported through an NBD server until the backup is ended.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceBackupState struct {
+	// The name of the VirtualMachineBackup
+	Name string `json:"name"`
+	// The checkpoint created by the backup, empty if no disk supports changed block tracking
+	// +optional
+	Checkpoint string `json:"checkpoint,omitempty"`
+	// The checkpoint the backup is incremental to, empty for full backups
+	// +optional
+	Incremental string `json:"incremental,omitempty"`
+	// The UID of the pod the NBD server is made available to
+	// +optional
+	TargetPodUID types.UID `json:"targetPodUID,omitempty"`
+	// The disks exported by the NBD server
+	// +optional
+	// +listType=atomic
+	Exports []BackupExport `json:"exports,omitempty"`
+	// The time the backup began
+	// +nullable
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+	// The time the backup ended
+	// +nullable
+	EndTimestamp *metav1.Time `json:"endTimestamp,omitempty"`
+	// Indicates the backup ended
+	Completed bool `json:"completed,omitempty"`
+	// Indicates the backup failed
+	Failed bool `json:"failed,omitempty"`
+	// Details about the failure of the backup
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BackupExport is a disk exported by the NBD server of a backup.
+//
+// +k8s:openapi-gen=true
+type BackupExport struct {
+	// The name of the volume
+	VolumeName string `json:"volumeName"`
+	// The NBD export name of the disk
+	ExportName string `json:"exportName"`
+	// The NBD metadata context listing the changed blocks of an incremental backup,
+	// e.g. qemu:dirty-bitmap:backup-vda
+	// +optional
+	DirtyBitmap string `json:"dirtyBitmap,omitempty"`
+}
+
+// VirtualMachineInstanceCheckpoint is a point in time the changed blocks of disks are tracked from.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceCheckpoint struct {
+	// The name of the checkpoint
+	Name string `json:"name"`
+	// The time the checkpoint was created
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+	// The volumes whose changed blocks are tracked
+	// +listType=atomic
+	Volumes []string `json:"volumes"`
+}
+
+// CPUTopology represents the number of sockets, cores and threads of a guest CPU.
+// +k8s:openapi-gen=true
+type CPUTopology struct {
+	// Sockets is the number of sockets.
+	Sockets uint32 `json:"sockets,omitempty"`
+	// Cores is the number of cores per socket.
+	Cores uint32 `json:"cores,omitempty"`
+	// Threads is the number of threads per core.
+	Threads uint32 `json:"threads,omitempty"`
 }
 
 // VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.
@@ -220,6 +340,25 @@ type VolumeStatus struct {
 	Message string `json:"message,omitempty"`
 	// If the volume is hotplug, this will contain the hotplug status.
 	HotplugVolume *HotplugVolumeStatus `json:"hotplugVolume,omitempty"`
+	// PersistentVolumeClaimInfo is information about the PVC backing the volume
+	PersistentVolumeClaimInfo *PersistentVolumeClaimInfo `json:"persistentVolumeClaimInfo,omitempty"`
+	// Size is the size of the disk as seen by the guest, in bytes
+	Size int64 `json:"size,omitempty"`
+}
+
+// PersistentVolumeClaimInfo contains the information about a PVC needed to expand the disk backed by it
+// +k8s:openapi-gen=true
+type PersistentVolumeClaimInfo struct {
+	// AccessModes contains the access modes of the PVC
+	// +listType=atomic
+	// +optional
+	AccessModes []k8sv1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// VolumeMode is the volume mode of the PVC
+	// +optional
+	VolumeMode *k8sv1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+	// Capacity is the capacity reported in the PVC status
+	// +optional
+	Capacity k8sv1.ResourceList `json:"capacity,omitempty"`
 }
 
 // HotplugVolumeStatus represents the hotplug status of the volume
@@ -344,6 +483,8 @@ const (
 	VirtualMachineInstanceReasonInterfaceNotMigratable = "InterfaceNotLiveMigratable"
 	// Reason means that VMI is not live migratioable because of it's network interfaces collection
 	VirtualMachineInstanceReasonHotplugNotMigratable = "HotplugNotLiveMigratable"
+	// Reason means that VMI is not live migratable because a backup of its disks is in progress
+	VirtualMachineInstanceReasonBackupNotMigratable = "BackupNotLiveMigratable"
 )
 
 const (
@@ -428,6 +569,66 @@ type VirtualMachineInstanceNetworkInterface struct {
 	IPs []string `json:"ipAddresses,omitempty"`
 	// The interface name inside the Virtual Machine
 	InterfaceName string `json:"interfaceName,omitempty"`
+	// The IP addresses of the interface together with their source
+	// +optional
+	IPDetails []VirtualMachineInstanceNetworkInterfaceIP `json:"ipDetails,omitempty"`
+	// Link state of the virtual network interface: up or down
+	// +optional
+	LinkState InterfaceLinkState `json:"linkState,omitempty"`
+	// MTU of the virtual network interface
+	// +optional
+	MTU int `json:"mtu,omitempty"`
+	// Routes of the guest through the interface as reported by the guest agent
+	// +optional
+	Routes []VirtualMachineInstanceNetworkRoute `json:"routes,omitempty"`
+	// Firewall rules enforced for the interface in the virt-launcher pod
+	// +optional
+	Firewall *InterfaceFirewall `json:"firewall,omitempty"`
+}
+
+// InterfaceIPSource tells where an IP address of an interface is known from
+type InterfaceIPSource string
+
+const (
+	// The IP address is the address of the pod, the interface is reached through the pod
+	InterfaceIPSourcePod InterfaceIPSource = "pod"
+	// The IP address was served to the guest by the DHCP server of the interface
+	InterfaceIPSourceDHCP InterfaceIPSource = "dhcp"
+	// The IP address was reported by the guest agent
+	InterfaceIPSourceGuestAgent InterfaceIPSource = "guest-agent"
+)
+
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceNetworkInterfaceIP struct {
+	// The IP address
+	IP string `json:"ip"`
+	// Prefix length of the network of the IP address, if known
+	// +optional
+	PrefixLength int `json:"prefixLength,omitempty"`
+	// Source of the IP address: pod, dhcp or guest-agent
+	Source InterfaceIPSource `json:"source"`
+}
+
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceNetworkRoute struct {
+	// Destination network in CIDR notation
+	Destination string `json:"destination"`
+	// Gateway of the route, empty for directly connected networks
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+	// Metric of the route
+	// +optional
+	Metric int `json:"metric,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceGuestDNS struct {
+	// IP addresses of the DNS servers of the guest
+	// +optional
+	Nameservers []string `json:"nameservers,omitempty"`
+	// DNS search domains of the guest
+	// +optional
+	Search []string `json:"search,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -482,6 +683,19 @@ type VirtualMachineInstanceMigrationState struct {
 	MigrationUID types.UID `json:"migrationUid,omitempty"`
 	// Lets us know if the vmi is currently running pre or post copy migration
 	Mode MigrationMode `json:"mode,omitempty"`
+	// The progress of the copy of the non-shared disks to the target
+	// +optional
+	DiskCopyProgress *MigrationDiskCopyProgress `json:"diskCopyProgress,omitempty"`
+}
+
+// MigrationDiskCopyProgress represents how much of the non-shared disks was copied to the migration target.
+//
+// +k8s:openapi-gen=true
+type MigrationDiskCopyProgress struct {
+	// The size of the disks to copy in bytes
+	TotalBytes int64 `json:"totalBytes"`
+	// The bytes copied to the target so far
+	ProcessedBytes int64 `json:"processedBytes"`
 }
 
 //
@@ -634,6 +848,9 @@ const (
 	// Used on VirtualMachineInstance.
 	IgnitionAnnotation           string = "kubevirt.io/ignitiondata"
 	PlacePCIDevicesOnRootComplex string = "kubevirt.io/placePCIDevicesOnRootComplex"
+	// This annotation enables reading the DNS configuration of the guest with the guest agent file commands
+	// Used on VirtualMachineInstance.
+	ReportGuestDNSAnnotation string = "kubevirt.io/reportGuestDNS"
 
 	// This label represents supported cpu features on the node
 	CPUFeatureLabel = "cpu-feature.node.kubevirt.io/"
@@ -1065,6 +1282,9 @@ const (
 	// VMI will initially be running--and restarted if a failure occurs.
 	// It will not be restarted upon successful completion.
 	RunStrategyRerunOnFailure VirtualMachineRunStrategy = "RerunOnFailure"
+	// VMI will run once and not be restarted upon completion regardless
+	// if the completion is of phase Failure or Success.
+	RunStrategyOnce VirtualMachineRunStrategy = "Once"
 )
 
 // VirtualMachineSpec describes how the proper VirtualMachine
@@ -1080,12 +1300,68 @@ type VirtualMachineSpec struct {
 	// mutually exclusive with Running
 	RunStrategy *VirtualMachineRunStrategy `json:"runStrategy,omitempty" optional:"true"`
 
+	// Instancetype references a resource that contains quantitative and resource related
+	// VirtualMachine configuration that is expanded into the VirtualMachineInstance.
+	// +optional
+	Instancetype *InstancetypeMatcher `json:"instancetype,omitempty"`
+
+	// Preference references a resource that contains a set of preferred values
+	// that are only applied when not already provided by the VirtualMachineInstance template.
+	// +optional
+	Preference *PreferenceMatcher `json:"preference,omitempty"`
+
 	// Template is the direct specification of VirtualMachineInstance
 	Template *VirtualMachineInstanceTemplateSpec `json:"template"`
 
 	// dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.
 	// DataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.
 	DataVolumeTemplates []DataVolumeTemplateSpec `json:"dataVolumeTemplates,omitempty"`
+
+	// UpdateVolumesStrategy controls how a change of the claims of the volumes in the template
+	// applies to a running VirtualMachineInstance. Defaults to Replacement.
+	// +optional
+	UpdateVolumesStrategy *UpdateVolumesStrategy `json:"updateVolumesStrategy,omitempty"`
+}
+
+// UpdateVolumesStrategy is the strategy to apply a change of the volumes to a running VirtualMachineInstance.
+//
+// +k8s:openapi-gen=true
+type UpdateVolumesStrategy string
+
+const (
+	// UpdateVolumesStrategyReplacement applies the new claims the next time the VirtualMachineInstance is started
+	UpdateVolumesStrategyReplacement UpdateVolumesStrategy = "Replacement"
+	// UpdateVolumesStrategyMigration copies the disks of the running VirtualMachineInstance to the
+	// new claims with a live migration
+	UpdateVolumesStrategyMigration UpdateVolumesStrategy = "Migration"
+)
+
+// InstancetypeMatcher references a instancetype that is used to fill fields in the VMI template.
+//
+// +k8s:openapi-gen=true
+type InstancetypeMatcher struct {
+	// Name is the name of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype
+	Name string `json:"name"`
+
+	// Kind specifies which instancetype resource is referenced.
+	// Allowed values are: "VirtualMachineInstancetype" and "VirtualMachineClusterInstancetype".
+	// If not specified, "VirtualMachineClusterInstancetype" is used by default.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// PreferenceMatcher references a set of preference that is used to fill fields in the VMI template.
+//
+// +k8s:openapi-gen=true
+type PreferenceMatcher struct {
+	// Name is the name of the VirtualMachinePreference or VirtualMachineClusterPreference
+	Name string `json:"name"`
+
+	// Kind specifies which preference resource is referenced.
+	// Allowed values are: "VirtualMachinePreference" and "VirtualMachineClusterPreference".
+	// If not specified, "VirtualMachineClusterPreference" is used by default.
+	// +optional
+	Kind string `json:"kind,omitempty"`
 }
 
 // StateChangeRequestType represents the existing state change requests that are possible
@@ -1120,6 +1396,10 @@ type VirtualMachineStatus struct {
 	// hotplug on an active running VMI.
 	// +listType=atomic
 	VolumeRequests []VirtualMachineVolumeRequest `json:"volumeRequests,omitempty" optional:"true"`
+	// InterfaceRequests indicates a list of interfaces added to or removed from
+	// the VMI template and hotplugged on an active running VMI.
+	// +listType=atomic
+	InterfaceRequests []VirtualMachineInterfaceRequest `json:"interfaceRequests,omitempty" optional:"true"`
 
 	// VolumeSnapshotStatuses indicates a list of statuses whether snapshotting is
 	// supported by each volume.
@@ -1146,6 +1426,16 @@ type VirtualMachineVolumeRequest struct {
 	RemoveVolumeOptions *RemoveVolumeOptions `json:"removeVolumeOptions,omitempty" optional:"true"`
 }
 
+// +k8s:openapi-gen=true
+type VirtualMachineInterfaceRequest struct {
+	// AddInterfaceOptions when set indicates a network interface should be added.
+	// The details within this field specify how to add the interface
+	AddInterfaceOptions *AddInterfaceOptions `json:"addInterfaceOptions,omitempty" optional:"true"`
+	// RemoveInterfaceOptions when set indicates a network interface should be removed.
+	// The details within this field specify which interface to remove
+	RemoveInterfaceOptions *RemoveInterfaceOptions `json:"removeInterfaceOptions,omitempty" optional:"true"`
+}
+
 // +k8s:openapi-gen=true
 type VirtualMachineStateChangeRequest struct {
 	// Indicates the type of action that is requested. e.g. Start or Stop
@@ -1641,6 +1931,88 @@ type RemoveVolumeOptions struct {
 	Name string `json:"name"`
 }
 
+// AddInterfaceOptions is provided when dynamically hot plugging a network interface
+// +k8s:openapi-gen=true
+type AddInterfaceOptions struct {
+	// NetworkAttachmentDefinitionName references a NetworkAttachmentDefinition CRD object.
+	// Format: <networkName>, <namespace>/<networkName>. If namespace is not
+	// specified, VMI namespace is assumed.
+	NetworkAttachmentDefinitionName string `json:"networkAttachmentDefinitionName"`
+	// Name indicates the logical name of the interface and of the network
+	// it is connected to.
+	Name string `json:"name"`
+}
+
+// RemoveInterfaceOptions is provided when dynamically hot unplugging a network interface
+// +k8s:openapi-gen=true
+type RemoveInterfaceOptions struct {
+	// Name indicates the logical name of the interface and network to remove.
+	Name string `json:"name"`
+}
+
+// SetLinkStateOptions is provided when changing the link state of a network interface
+// of a running VirtualMachineInstance
+// +k8s:openapi-gen=true
+type SetLinkStateOptions struct {
+	// Name indicates the logical name of the interface.
+	Name string `json:"name"`
+	// LinkState is the new link state of the interface, up or down.
+	LinkState InterfaceLinkState `json:"linkState"`
+}
+
+// BackupOptions are provided when beginning the backup of the disks of a running VirtualMachineInstance
+// +k8s:openapi-gen=true
+type BackupOptions struct {
+	// Name is the name of the backup.
+	Name string `json:"name"`
+	// Checkpoint is the name of the checkpoint created by the backup.
+	Checkpoint string `json:"checkpoint"`
+	// Incremental is the name of a checkpoint, only the blocks changed since then are exported as changed.
+	// +optional
+	Incremental *string `json:"incremental,omitempty"`
+	// TargetPodUID is the UID of a pod on the node of the VirtualMachineInstance, the NBD server is made
+	// available to it as the socket nbd.sock in its emptyDir volume named backup-nbd. The file exports next
+	// to it lists the volume name and the export name of each exported disk, one disk per line.
+	// +optional
+	TargetPodUID *types.UID `json:"targetPodUID,omitempty"`
+}
+
+// SetIOTuneOptions is provided when changing the I/O limits of a disk
+// of a running VirtualMachineInstance
+// +k8s:openapi-gen=true
+type SetIOTuneOptions struct {
+	// Name indicates the logical name of the disk.
+	Name string `json:"name"`
+	// IOTune are the new limits of the disk, the limits are removed if not set.
+	// +optional
+	IOTune *DiskIOTune `json:"ioTune,omitempty"`
+}
+
+// InjectMediaOptions is provided when inserting media into an empty CD-ROM
+// of a running VirtualMachineInstance
+// +k8s:openapi-gen=true
+type InjectMediaOptions struct {
+	// Name indicates the logical name of the CD-ROM.
+	Name string `json:"name"`
+	// VolumeSource is the media, it is attached to the VMI like a hotplugged volume
+	// named after the CD-ROM.
+	VolumeSource *HotplugVolumeSource `json:"volumeSource"`
+}
+
+// EjectMediaOptions is provided when removing the media from a CD-ROM
+// of a running VirtualMachineInstance
+// +k8s:openapi-gen=true
+type EjectMediaOptions struct {
+	// Name indicates the logical name of the CD-ROM.
+	Name string `json:"name"`
+}
+
+// FreezeUnfreezeTimeout represent the time unfreeze will be triggered if guest was not unfrozen by unfreeze command
+// +k8s:openapi-gen=true
+type FreezeUnfreezeTimeout struct {
+	UnfreezeTimeout *metav1.Duration `json:"unfreezeTimeout"`
+}
+
 // KubeVirtConfiguration holds all kubevirt configurations
 // +k8s:openapi-gen=true
 type KubeVirtConfiguration struct {
@@ -1660,6 +2032,7 @@ type KubeVirtConfiguration struct {
 	PermittedHostDevices        *PermittedHostDevices   `json:"permittedHostDevices,omitempty"`
 	MinCPUModel                 string                  `json:"minCPUModel,omitempty"`
 	ObsoleteCPUModels           map[string]bool         `json:"obsoleteCPUModels,omitempty"`
+	VMStateStorageClass         string                  `json:"vmStateStorageClass,omitempty"`
 }
 
 //
@@ -1741,4 +2114,53 @@ type NetworkConfiguration struct {
 	NetworkInterface                  string `json:"defaultNetworkInterface,omitempty"`
 	PermitSlirpInterface              *bool  `json:"permitSlirpInterface,omitempty"`
 	PermitBridgeInterfaceOnPodNetwork *bool  `json:"permitBridgeInterfaceOnPodNetwork,omitempty"`
+	// Binding registers network binding plugins by name, interfaces refer to them through their binding.
+	// +optional
+	Binding map[string]InterfaceBindingPlugin `json:"binding,omitempty"`
+	// VhostUserSocketDir is the host directory holding the vhost-user socket directories of the VMIs.
+	// The socket directory of a VMI is mounted into its virt-launcher pod, defaults to /var/lib/cni/usrspcni.
+	// +optional
+	VhostUserSocketDir string `json:"vhostUserSocketDir,omitempty"`
+	// MacPool assigns MAC addresses from its ranges to the interfaces on Multus networks which have none,
+	// and rejects MAC addresses which are already in use by another VirtualMachine or VirtualMachineInstance.
+	// +optional
+	MacPool *MacPoolConfiguration `json:"macPool,omitempty"`
+}
+
+// MacPoolConfiguration holds the MAC address ranges of the MAC pool
+// +k8s:openapi-gen=true
+type MacPoolConfiguration struct {
+	// Ranges are the MAC address ranges addresses are allocated from
+	Ranges []MacPoolRange `json:"ranges"`
+}
+
+// MacPoolRange is a range of MAC addresses, both ends are included
+// +k8s:openapi-gen=true
+type MacPoolRange struct {
+	// Start is the first MAC address of the range, e.g. 02:00:00:00:00:00
+	Start string `json:"start"`
+	// End is the last MAC address of the range, e.g. 02:00:00:ff:ff:ff
+	End string `json:"end"`
 }
+
+// InterfaceBindingPlugin describes a network binding plugin which connects the pod network to the guest
+// +k8s:openapi-gen=true
+type InterfaceBindingPlugin struct {
+	// SidecarImage references a container image which runs in the virt-launcher pod.
+	// The sidecar implements the hook points of the plugin, e.g. to adjust the domain.
+	// +optional
+	SidecarImage string `json:"sidecarImage,omitempty"`
+	// DomainAttachmentType is the way the domain interface is attached to the pod network.
+	// If not set, the sidecar is responsible for adding the domain interface.
+	// +optional
+	DomainAttachmentType DomainAttachmentType `json:"domainAttachmentType,omitempty"`
+}
+
+// DomainAttachmentType defines how the domain interface of a network binding plugin is attached
+// +k8s:openapi-gen=true
+type DomainAttachmentType string
+
+const (
+	// Tap attaches the domain interface to a tap device which the plugin connects to the pod network.
+	Tap DomainAttachmentType = "tap"
+)
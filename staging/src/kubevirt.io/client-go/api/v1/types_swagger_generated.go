@@ -48,6 +48,7 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"phase":                         "Phase is the status of the VirtualMachineInstance in kubernetes world. It is not the VirtualMachineInstance status, but partially correlates to it.",
 		"interfaces":                    "Interfaces represent the details of available network interfaces.",
 		"guestOSInfo":                   "Guest OS Information",
+		"guestDNS":                      "DNS configuration of the guest as reported by the guest agent\n+optional",
 		"migrationState":                "Represents the status of a live migration",
 		"migrationMethod":               "Represents the method using which the vmi can be migrated: live migration or block migration",
 		"qosClass":                      "The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements\nSee PodQOSClass type for available QOS classes\nMore info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md\n+optional",
@@ -55,18 +56,94 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"evacuationNodeName":            "EvacuationNodeName is used to track the eviction process of a VMI. It stores the name of the node that we want\nto evacuate. It is meant to be used by KubeVirt core components only and can't be set or modified by users.\n+optional",
 		"activePods":                    "ActivePods is a mapping of pod UID to node name.\nIt is possible for multiple pods to be running for a single VMI during migration.",
 		"volumeStatus":                  "VolumeStatus contains the statuses of all the volumes\n+optional\n+listType=atomic",
+		"currentCPUTopology":            "CurrentCPUTopology is the CPU topology currently plugged into the guest.\n+optional",
+		"maxCPUTopology":                "MaxCPUTopology is the CPU topology the guest can be scaled up to without a restart.\n+optional",
+		"volumeMigration":               "VolumeMigration shows the volumes which are copied to new claims by a live migration.\n+optional",
+		"backup":                        "Backup shows the backup job exporting the disks of the VirtualMachineInstance.\n+optional",
+		"checkpoints":                   "Checkpoints are the checkpoints created by backups, later backups can be incremental to them.\n+optional\n+listType=atomic",
+	}
+}
+
+func (VolumeMigrationState) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VolumeMigrationState represents the copy of volumes of a running VirtualMachineInstance to new claims.\n\n+k8s:openapi-gen=true",
+		"migrationName":   "The name of the VirtualMachineInstanceMigration copying the volumes",
+		"migratedVolumes": "The volumes copied to new claims\n+listType=atomic",
+	}
+}
+
+func (MigratedVolume) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "MigratedVolume is a volume copied from one claim to another.\n\n+k8s:openapi-gen=true",
+		"volumeName":       "The name of the volume",
+		"sourceClaim":      "The claim the volume is copied from",
+		"destinationClaim": "The claim the volume is copied to",
+	}
+}
+
+func (VirtualMachineInstanceBackupState) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineInstanceBackupState represents the backup job of a running VirtualMachineInstance.\nThe disks are exported through an NBD server until the backup is ended.\n\n+k8s:openapi-gen=true",
+		"name":           "The name of the VirtualMachineBackup",
+		"checkpoint":     "The checkpoint created by the backup, empty if no disk supports changed block tracking\n+optional",
+		"incremental":    "The checkpoint the backup is incremental to, empty for full backups\n+optional",
+		"targetPodUID":   "The UID of the pod the NBD server is made available to\n+optional",
+		"exports":        "The disks exported by the NBD server\n+optional\n+listType=atomic",
+		"startTimestamp": "The time the backup began\n+nullable",
+		"endTimestamp":   "The time the backup ended\n+nullable",
+		"completed":      "Indicates the backup ended",
+		"failed":         "Indicates the backup failed",
+		"message":        "Details about the failure of the backup\n+optional",
+	}
+}
+
+func (BackupExport) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "BackupExport is a disk exported by the NBD server of a backup.\n\n+k8s:openapi-gen=true",
+		"volumeName":  "The name of the volume",
+		"exportName":  "The NBD export name of the disk",
+		"dirtyBitmap": "The NBD metadata context listing the changed blocks of an incremental backup,\ne.g. qemu:dirty-bitmap:backup-vda\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceCheckpoint) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "VirtualMachineInstanceCheckpoint is a point in time the changed blocks of disks are tracked from.\n\n+k8s:openapi-gen=true",
+		"name":              "The name of the checkpoint",
+		"creationTimestamp": "The time the checkpoint was created",
+		"volumes":           "The volumes whose changed blocks are tracked\n+listType=atomic",
+	}
+}
+
+func (CPUTopology) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "CPUTopology represents the number of sockets, cores and threads of a guest CPU.\n+k8s:openapi-gen=true",
+		"sockets": "Sockets is the number of sockets.",
+		"cores":   "Cores is the number of cores per socket.",
+		"threads": "Threads is the number of threads per core.",
 	}
 }
 
 func (VolumeStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.\n+k8s:openapi-gen=true",
-		"name":          "Name is the name of the volume",
-		"target":        "Target is the target name used when adding the volume to the VM, eg: vda",
-		"phase":         "Phase is the phase",
-		"reason":        "Reason is a brief description of why we are in the current hotplug volume phase",
-		"message":       "Message is a detailed message about the current hotplug volume phase",
-		"hotplugVolume": "If the volume is hotplug, this will contain the hotplug status.",
+		"":                          "VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.\n+k8s:openapi-gen=true",
+		"name":                      "Name is the name of the volume",
+		"target":                    "Target is the target name used when adding the volume to the VM, eg: vda",
+		"phase":                     "Phase is the phase",
+		"reason":                    "Reason is a brief description of why we are in the current hotplug volume phase",
+		"message":                   "Message is a detailed message about the current hotplug volume phase",
+		"hotplugVolume":             "If the volume is hotplug, this will contain the hotplug status.",
+		"persistentVolumeClaimInfo": "PersistentVolumeClaimInfo is information about the PVC backing the volume",
+		"size":                      "Size is the size of the disk as seen by the guest, in bytes",
+	}
+}
+
+func (PersistentVolumeClaimInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "PersistentVolumeClaimInfo contains the information about a PVC needed to expand the disk backed by it\n+k8s:openapi-gen=true",
+		"accessModes": "AccessModes contains the access modes of the PVC\n+listType=atomic\n+optional",
+		"volumeMode":  "VolumeMode is the volume mode of the PVC\n+optional",
+		"capacity":    "Capacity is the capacity reported in the PVC status\n+optional",
 	}
 }
 
@@ -102,6 +179,37 @@ func (VirtualMachineInstanceNetworkInterface) SwaggerDoc() map[string]string {
 		"name":          "Name of the interface, corresponds to name of the network assigned to the interface",
 		"ipAddresses":   "List of all IP addresses of a Virtual Machine interface",
 		"interfaceName": "The interface name inside the Virtual Machine",
+		"ipDetails":     "The IP addresses of the interface together with their source\n+optional",
+		"linkState":     "Link state of the virtual network interface: up or down\n+optional",
+		"mtu":           "MTU of the virtual network interface\n+optional",
+		"routes":        "Routes of the guest through the interface as reported by the guest agent\n+optional",
+		"firewall":      "Firewall rules enforced for the interface in the virt-launcher pod\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceNetworkInterfaceIP) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "+k8s:openapi-gen=true",
+		"ip":           "The IP address",
+		"prefixLength": "Prefix length of the network of the IP address, if known\n+optional",
+		"source":       "Source of the IP address: pod, dhcp or guest-agent",
+	}
+}
+
+func (VirtualMachineInstanceNetworkRoute) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "+k8s:openapi-gen=true",
+		"destination": "Destination network in CIDR notation",
+		"gateway":     "Gateway of the route, empty for directly connected networks\n+optional",
+		"metric":      "Metric of the route\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceGuestDNS) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "+k8s:openapi-gen=true",
+		"nameservers": "IP addresses of the DNS servers of the guest\n+optional",
+		"search":      "DNS search domains of the guest\n+optional",
 	}
 }
 
@@ -136,6 +244,15 @@ func (VirtualMachineInstanceMigrationState) SwaggerDoc() map[string]string {
 		"abortStatus":                    "Indicates the final status of the live migration abortion",
 		"migrationUid":                   "The VirtualMachineInstanceMigration object associated with this migration",
 		"mode":                           "Lets us know if the vmi is currently running pre or post copy migration",
+		"diskCopyProgress":               "The progress of the copy of the non-shared disks to the target\n+optional",
+	}
+}
+
+func (MigrationDiskCopyProgress) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "MigrationDiskCopyProgress represents how much of the non-shared disks was copied to the migration target.\n\n+k8s:openapi-gen=true",
+		"totalBytes":     "The size of the disks to copy in bytes",
+		"processedBytes": "The bytes copied to the target so far",
 	}
 }
 
@@ -270,11 +387,30 @@ func (VirtualMachineList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                    "VirtualMachineSpec describes how the proper VirtualMachine\nshould look like\n\n+k8s:openapi-gen=true",
-		"running":             "Running controls whether the associatied VirtualMachineInstance is created or not\nMutually exclusive with RunStrategy",
-		"runStrategy":         "Running state indicates the requested running state of the VirtualMachineInstance\nmutually exclusive with Running",
-		"template":            "Template is the direct specification of VirtualMachineInstance",
-		"dataVolumeTemplates": "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.\nDataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
+		"":                      "VirtualMachineSpec describes how the proper VirtualMachine\nshould look like\n\n+k8s:openapi-gen=true",
+		"running":               "Running controls whether the associatied VirtualMachineInstance is created or not\nMutually exclusive with RunStrategy",
+		"runStrategy":           "Running state indicates the requested running state of the VirtualMachineInstance\nmutually exclusive with Running",
+		"instancetype":          "Instancetype references a resource that contains quantitative and resource related\nVirtualMachine configuration that is expanded into the VirtualMachineInstance.\n+optional",
+		"preference":            "Preference references a resource that contains a set of preferred values\nthat are only applied when not already provided by the VirtualMachineInstance template.\n+optional",
+		"template":              "Template is the direct specification of VirtualMachineInstance",
+		"dataVolumeTemplates":   "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.\nDataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
+		"updateVolumesStrategy": "UpdateVolumesStrategy controls how a change of the claims of the volumes in the template\napplies to a running VirtualMachineInstance. Defaults to Replacement.\n+optional",
+	}
+}
+
+func (InstancetypeMatcher) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "InstancetypeMatcher references a instancetype that is used to fill fields in the VMI template.\n\n+k8s:openapi-gen=true",
+		"name": "Name is the name of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype",
+		"kind": "Kind specifies which instancetype resource is referenced.\nAllowed values are: \"VirtualMachineInstancetype\" and \"VirtualMachineClusterInstancetype\".\nIf not specified, \"VirtualMachineClusterInstancetype\" is used by default.\n+optional",
+	}
+}
+
+func (PreferenceMatcher) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "PreferenceMatcher references a set of preference that is used to fill fields in the VMI template.\n\n+k8s:openapi-gen=true",
+		"name": "Name is the name of the VirtualMachinePreference or VirtualMachineClusterPreference",
+		"kind": "Kind specifies which preference resource is referenced.\nAllowed values are: \"VirtualMachinePreference\" and \"VirtualMachineClusterPreference\".\nIf not specified, \"VirtualMachineClusterPreference\" is used by default.\n+optional",
 	}
 }
 
@@ -287,6 +423,7 @@ func (VirtualMachineStatus) SwaggerDoc() map[string]string {
 		"conditions":             "Hold the state information of the VirtualMachine and its VirtualMachineInstance",
 		"stateChangeRequests":    "StateChangeRequests indicates a list of actions that should be taken on a VMI\ne.g. stop a specific VMI then start a new one.",
 		"volumeRequests":         "VolumeRequests indicates a list of volumes add or remove from the VMI template and\nhotplug on an active running VMI.\n+listType=atomic",
+		"interfaceRequests":      "InterfaceRequests indicates a list of interfaces added to or removed from\nthe VMI template and hotplugged on an active running VMI.\n+listType=atomic",
 		"volumeSnapshotStatuses": "VolumeSnapshotStatuses indicates a list of statuses whether snapshotting is\nsupported by each volume.",
 	}
 }
@@ -308,6 +445,14 @@ func (VirtualMachineVolumeRequest) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineInterfaceRequest) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                       "+k8s:openapi-gen=true",
+		"addInterfaceOptions":    "AddInterfaceOptions when set indicates a network interface should be added.\nThe details within this field specify how to add the interface",
+		"removeInterfaceOptions": "RemoveInterfaceOptions when set indicates a network interface should be removed.\nThe details within this field specify which interface to remove",
+	}
+}
+
 func (VirtualMachineStateChangeRequest) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":       "+k8s:openapi-gen=true",
@@ -496,6 +641,68 @@ func (RemoveVolumeOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (AddInterfaceOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                                "AddInterfaceOptions is provided when dynamically hot plugging a network interface\n+k8s:openapi-gen=true",
+		"networkAttachmentDefinitionName": "NetworkAttachmentDefinitionName references a NetworkAttachmentDefinition CRD object.\nFormat: <networkName>, <namespace>/<networkName>. If namespace is not\nspecified, VMI namespace is assumed.",
+		"name":                            "Name indicates the logical name of the interface and of the network\nit is connected to.",
+	}
+}
+
+func (RemoveInterfaceOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "RemoveInterfaceOptions is provided when dynamically hot unplugging a network interface\n+k8s:openapi-gen=true",
+		"name": "Name indicates the logical name of the interface and network to remove.",
+	}
+}
+
+func (SetLinkStateOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "SetLinkStateOptions is provided when changing the link state of a network interface\nof a running VirtualMachineInstance\n+k8s:openapi-gen=true",
+		"name":      "Name indicates the logical name of the interface.",
+		"linkState": "LinkState is the new link state of the interface, up or down.",
+	}
+}
+
+func (BackupOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "BackupOptions are provided when beginning the backup of the disks of a running VirtualMachineInstance\n+k8s:openapi-gen=true",
+		"name":         "Name is the name of the backup.",
+		"checkpoint":   "Checkpoint is the name of the checkpoint created by the backup.",
+		"incremental":  "Incremental is the name of a checkpoint, only the blocks changed since then are exported as changed.\n+optional",
+		"targetPodUID": "TargetPodUID is the UID of a pod on the node of the VirtualMachineInstance, the NBD server is made\navailable to it as the socket nbd.sock in its emptyDir volume named backup-nbd. The file exports next\nto it lists the volume name and the export name of each exported disk, one disk per line.\n+optional",
+	}
+}
+
+func (SetIOTuneOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "SetIOTuneOptions is provided when changing the I/O limits of a disk\nof a running VirtualMachineInstance\n+k8s:openapi-gen=true",
+		"name":   "Name indicates the logical name of the disk.",
+		"ioTune": "IOTune are the new limits of the disk, the limits are removed if not set.\n+optional",
+	}
+}
+
+func (InjectMediaOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "InjectMediaOptions is provided when inserting media into an empty CD-ROM\nof a running VirtualMachineInstance\n+k8s:openapi-gen=true",
+		"name":         "Name indicates the logical name of the CD-ROM.",
+		"volumeSource": "VolumeSource is the media, it is attached to the VMI like a hotplugged volume\nnamed after the CD-ROM.",
+	}
+}
+
+func (EjectMediaOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "EjectMediaOptions is provided when removing the media from a CD-ROM\nof a running VirtualMachineInstance\n+k8s:openapi-gen=true",
+		"name": "Name indicates the logical name of the CD-ROM.",
+	}
+}
+
+func (FreezeUnfreezeTimeout) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "FreezeUnfreezeTimeout represent the time unfreeze will be triggered if guest was not unfrozen by unfreeze command\n+k8s:openapi-gen=true",
+	}
+}
+
 func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "KubeVirtConfiguration holds all kubevirt configurations\n+k8s:openapi-gen=true",
@@ -549,6 +756,32 @@ func (MediatedHostDevice) SwaggerDoc() map[string]string {
 
 func (NetworkConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "NetworkConfiguration holds network options\n+k8s:openapi-gen=true",
+		"":                   "NetworkConfiguration holds network options\n+k8s:openapi-gen=true",
+		"binding":            "Binding registers network binding plugins by name, interfaces refer to them through their binding.\n+optional",
+		"vhostUserSocketDir": "VhostUserSocketDir is the host directory holding the vhost-user socket directories of the VMIs.\nThe socket directory of a VMI is mounted into its virt-launcher pod, defaults to /var/lib/cni/usrspcni.\n+optional",
+		"macPool":            "MacPool assigns MAC addresses from its ranges to the interfaces on Multus networks which have none,\nand rejects MAC addresses which are already in use by another VirtualMachine or VirtualMachineInstance.\n+optional",
+	}
+}
+
+func (MacPoolConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "MacPoolConfiguration holds the MAC address ranges of the MAC pool\n+k8s:openapi-gen=true",
+		"ranges": "Ranges are the MAC address ranges addresses are allocated from",
+	}
+}
+
+func (MacPoolRange) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "MacPoolRange is a range of MAC addresses, both ends are included\n+k8s:openapi-gen=true",
+		"start": "Start is the first MAC address of the range, e.g. 02:00:00:00:00:00",
+		"end":   "End is the last MAC address of the range, e.g. 02:00:00:ff:ff:ff",
+	}
+}
+
+func (InterfaceBindingPlugin) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "InterfaceBindingPlugin describes a network binding plugin which connects the pod network to the guest\n+k8s:openapi-gen=true",
+		"sidecarImage":         "SidecarImage references a container image which runs in the virt-launcher pod.\nThe sidecar implements the hook points of the plugin, e.g. to adjust the domain.\n+optional",
+		"domainAttachmentType": "DomainAttachmentType is the way the domain interface is attached to the pod network.\nIf not set, the sidecar is responsible for adding the domain interface.\n+optional",
 	}
 }
@@ -0,0 +1,175 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// VirtualMachineBackup defines the operation of backing up the disks of a running VirtualMachine
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineBackupSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineBackupStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineBackupList is a list of VirtualMachineBackup resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineBackup `json:"items"`
+}
+
+// BackupMode is the way the disks are backed up
+type BackupMode string
+
+const (
+	// BackupModePull exports the disks to a backup application through an NBD server
+	BackupModePull BackupMode = "Pull"
+	// BackupModePush copies the disks to a PersistentVolumeClaim
+	BackupModePush BackupMode = "Push"
+)
+
+// VirtualMachineBackupSpec is the spec for a VirtualMachineBackup resource
+type VirtualMachineBackupSpec struct {
+	// Source is the VirtualMachine to back up, it has to be running
+	Source corev1.TypedLocalObjectReference `json:"source"`
+
+	// Mode is Pull, the default, or Push
+	// +optional
+	Mode *BackupMode `json:"mode,omitempty"`
+
+	// Incremental is the checkpoint of a previous backup. Only the blocks
+	// changed since that checkpoint are reported as changed. Only supported
+	// by pull mode backups.
+	// +optional
+	Incremental *string `json:"incremental,omitempty"`
+
+	// Target is where push mode backups are written to
+	// +optional
+	Target *BackupTarget `json:"target,omitempty"`
+}
+
+// BackupTarget is the destination of a push mode backup
+type BackupTarget struct {
+	// PersistentVolumeClaimName is the name of a filesystem mode claim, each
+	// disk is written to <backup name>/<volume name>.qcow2 on it
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+}
+
+// BackupType is the type of a backup
+type BackupType string
+
+const (
+	// Full backups report all blocks of the disks
+	Full BackupType = "Full"
+	// Incremental backups report the blocks changed since a checkpoint
+	Incremental BackupType = "Incremental"
+)
+
+// VirtualMachineBackupPhase is the current phase of the VirtualMachineBackup
+type VirtualMachineBackupPhase string
+
+const (
+	// PhaseUnset means the backup has not been processed yet
+	PhaseUnset VirtualMachineBackupPhase = ""
+	// Pending means the backup waits for the VirtualMachine or another backup
+	Pending VirtualMachineBackupPhase = "Pending"
+	// InProgress means the disks are exported, they are read by the backup
+	// application or copied to the target
+	InProgress VirtualMachineBackupPhase = "InProgress"
+	// Succeeded means the disks were copied to the target
+	Succeeded VirtualMachineBackupPhase = "Succeeded"
+	// Failed means the backup cannot complete
+	Failed VirtualMachineBackupPhase = "Failed"
+)
+
+// VirtualMachineBackupStatus is the status for a VirtualMachineBackup resource
+type VirtualMachineBackupStatus struct {
+	// +optional
+	Phase VirtualMachineBackupPhase `json:"phase,omitempty"`
+
+	// +optional
+	Type BackupType `json:"type,omitempty"`
+
+	// CheckpointName is the checkpoint created by the backup, later backups
+	// can be incremental to it. It is not set if no disk supports changed
+	// block tracking.
+	// +optional
+	CheckpointName *string `json:"checkpointName,omitempty"`
+
+	// Exports are the disks exported by the NBD server, which is reachable
+	// through the backupnbd subresource of the VirtualMachineInstance
+	// +optional
+	// +listType=atomic
+	Exports []v1.BackupExport `json:"exports,omitempty"`
+
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// +optional
+	Conditions []VirtualMachineBackupCondition `json:"conditions,omitempty"`
+}
+
+// ConditionType is the const type for VirtualMachineBackup conditions
+type ConditionType string
+
+const (
+	// ConditionReady is the "ready" condition type
+	ConditionReady ConditionType = "Ready"
+
+	// ConditionProgressing is the "progressing" condition type
+	ConditionProgressing ConditionType = "Progressing"
+)
+
+// VirtualMachineBackupCondition defines conditions of a VirtualMachineBackup
+type VirtualMachineBackupCondition struct {
+	Type ConditionType `json:"type"`
+
+	Status corev1.ConditionStatus `json:"status"`
+
+	// +optional
+	// +nullable
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// +optional
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
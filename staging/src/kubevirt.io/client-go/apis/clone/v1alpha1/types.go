@@ -0,0 +1,150 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtualMachineClone defines the operation of cloning a VirtualMachine
+// or a VirtualMachineSnapshot into a new VirtualMachine
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineClone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineCloneSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineCloneStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineCloneList is a list of VirtualMachineClone resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineCloneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineClone `json:"items"`
+}
+
+// VirtualMachineCloneSpec is the spec for a VirtualMachineClone resource
+type VirtualMachineCloneSpec struct {
+	// Source is the VirtualMachine or VirtualMachineSnapshot to clone
+	Source *corev1.TypedLocalObjectReference `json:"source"`
+
+	// Target is the VirtualMachine to create. A name is generated if omitted.
+	// +optional
+	Target *corev1.TypedLocalObjectReference `json:"target,omitempty"`
+
+	// AnnotationFilters select the annotations copied from the source. Filters
+	// support the '*' wildcard and are negated with a leading '!'. The last
+	// matching filter wins. All annotations are copied if empty.
+	// +optional
+	AnnotationFilters []string `json:"annotationFilters,omitempty"`
+
+	// LabelFilters select the labels copied from the source, see AnnotationFilters
+	// +optional
+	LabelFilters []string `json:"labelFilters,omitempty"`
+
+	// NewMacAddresses maps interface names to the MAC address of the clone.
+	// Interfaces not listed get a new MAC address assigned.
+	// +optional
+	NewMacAddresses map[string]string `json:"newMacAddresses,omitempty"`
+
+	// NewSMBiosSerial is the SMBios serial of the clone. A new serial is
+	// assigned if omitted.
+	// +optional
+	NewSMBiosSerial *string `json:"newSMBiosSerial,omitempty"`
+}
+
+// VirtualMachineClonePhase is the current phase of the VirtualMachineClone
+type VirtualMachineClonePhase string
+
+const (
+	// PhaseUnset means the clone has not been processed yet
+	PhaseUnset VirtualMachineClonePhase = ""
+	// SnapshotInProgress means the source VirtualMachine is being snapshotted
+	SnapshotInProgress VirtualMachineClonePhase = "SnapshotInProgress"
+	// RestoreInProgress means the snapshot is being restored into the target
+	RestoreInProgress VirtualMachineClonePhase = "RestoreInProgress"
+	// Succeeded means the target VirtualMachine was created
+	Succeeded VirtualMachineClonePhase = "Succeeded"
+	// Failed means the clone cannot complete
+	Failed VirtualMachineClonePhase = "Failed"
+)
+
+// VirtualMachineCloneStatus is the status for a VirtualMachineClone resource
+type VirtualMachineCloneStatus struct {
+	// +optional
+	Phase VirtualMachineClonePhase `json:"phase,omitempty"`
+
+	// SnapshotName is the name of the VirtualMachineSnapshot being restored
+	// +optional
+	SnapshotName *string `json:"snapshotName,omitempty"`
+
+	// RestoreName is the name of the VirtualMachineRestore creating the target
+	// +optional
+	RestoreName *string `json:"restoreName,omitempty"`
+
+	// TargetName is the name of the created VirtualMachine
+	// +optional
+	TargetName *string `json:"targetName,omitempty"`
+
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// +optional
+	Conditions []VirtualMachineCloneCondition `json:"conditions,omitempty"`
+}
+
+// ConditionType is the const type for VirtualMachineClone conditions
+type ConditionType string
+
+const (
+	// ConditionReady is the "ready" condition type
+	ConditionReady ConditionType = "Ready"
+
+	// ConditionProgressing is the "progressing" condition type
+	ConditionProgressing ConditionType = "Progressing"
+)
+
+// VirtualMachineCloneCondition defines conditions of a VirtualMachineClone
+type VirtualMachineCloneCondition struct {
+	Type ConditionType `json:"type"`
+
+	Status corev1.ConditionStatus `json:"status"`
+
+	// +optional
+	// +nullable
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// +optional
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
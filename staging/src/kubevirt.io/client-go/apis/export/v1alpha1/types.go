@@ -0,0 +1,181 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtualMachineExport defines the operation of exporting the disks and
+// definition of a stopped VirtualMachine or a single PersistentVolumeClaim
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineExportSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineExportStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineExportList is a list of VirtualMachineExport resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineExport `json:"items"`
+}
+
+// VirtualMachineExportSpec is the spec for a VirtualMachineExport resource
+type VirtualMachineExportSpec struct {
+	// Source is the VirtualMachine or PersistentVolumeClaim to export
+	Source corev1.TypedLocalObjectReference `json:"source"`
+
+	// TokenSecretRef is the name of the secret holding the token clients have
+	// to present to the export server. The token is read from the "token" key.
+	TokenSecretRef string `json:"tokenSecretRef"`
+
+	// TTLDuration limits how long the export is served. Once it expired, the export
+	// server is stopped and the VirtualMachineExport is deleted. The token secret is left to the user.
+	// Defaults to 2 hours.
+	// +optional
+	TTLDuration *metav1.Duration `json:"ttlDuration,omitempty"`
+}
+
+// VirtualMachineExportPhase is the current phase of the VirtualMachineExport
+type VirtualMachineExportPhase string
+
+const (
+	// Pending means the export server is not serving the source yet
+	Pending VirtualMachineExportPhase = "Pending"
+	// Ready means the export server is serving the source
+	Ready VirtualMachineExportPhase = "Ready"
+)
+
+// ExportVolumeFormat defines the format a volume is served in
+type ExportVolumeFormat string
+
+const (
+	// KubeVirtRaw serves the raw disk image
+	KubeVirtRaw ExportVolumeFormat = "raw"
+	// KubeVirtGz serves the gzip compressed raw disk image
+	KubeVirtGz ExportVolumeFormat = "gzip"
+)
+
+// ExportManifestType defines the kind of manifest served
+type ExportManifestType string
+
+const (
+	// VirtualMachineManifest is the sanitized VirtualMachine definition
+	VirtualMachineManifest ExportManifestType = "VirtualMachine"
+)
+
+// VirtualMachineExportStatus is the status for a VirtualMachineExport resource
+type VirtualMachineExportStatus struct {
+	// +optional
+	Phase VirtualMachineExportPhase `json:"phase,omitempty"`
+
+	// ServiceName is the name of the service exposing the export server
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+
+	// TTLExpirationTime is the time the export expires at
+	// +optional
+	TTLExpirationTime *metav1.Time `json:"ttlExpirationTime,omitempty"`
+
+	// +optional
+	Links *VirtualMachineExportLinks `json:"links,omitempty"`
+
+	// +optional
+	Conditions []VirtualMachineExportCondition `json:"conditions,omitempty"`
+}
+
+// VirtualMachineExportLinks contains the links to the exported content
+type VirtualMachineExportLinks struct {
+	// Internal links are reachable from within the cluster
+	// +optional
+	Internal *VirtualMachineExportLink `json:"internal,omitempty"`
+}
+
+// VirtualMachineExportLink contains the certificate and the links of the export server
+type VirtualMachineExportLink struct {
+	// Cert is the PEM encoded CA certificate the export server certificate is signed with
+	Cert string `json:"cert"`
+
+	// +optional
+	Volumes []VirtualMachineExportVolume `json:"volumes,omitempty"`
+
+	// +optional
+	Manifests []VirtualMachineExportManifest `json:"manifests,omitempty"`
+}
+
+// VirtualMachineExportVolume contains the name and the available formats of an exported volume
+type VirtualMachineExportVolume struct {
+	// Name is the name of the exported PersistentVolumeClaim
+	Name string `json:"name"`
+
+	// +optional
+	Formats []VirtualMachineExportVolumeFormat `json:"formats,omitempty"`
+}
+
+// VirtualMachineExportVolumeFormat contains the format and the url of an exported volume
+type VirtualMachineExportVolumeFormat struct {
+	Format ExportVolumeFormat `json:"format"`
+	Url    string             `json:"url"`
+}
+
+// VirtualMachineExportManifest contains the type and the url of an exported manifest
+type VirtualMachineExportManifest struct {
+	Type ExportManifestType `json:"type"`
+	Url  string             `json:"url"`
+}
+
+// ConditionType is the const type for VirtualMachineExport conditions
+type ConditionType string
+
+const (
+	// ConditionReady is the "ready" condition type
+	ConditionReady ConditionType = "Ready"
+)
+
+// VirtualMachineExportCondition defines conditions of a VirtualMachineExport
+type VirtualMachineExportCondition struct {
+	Type ConditionType `json:"type"`
+
+	Status corev1.ConditionStatus `json:"status"`
+
+	// +optional
+	// +nullable
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// +optional
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -102,6 +102,11 @@ const (
 
 	// ConditionProgressing is the "progressing" condition type
 	ConditionProgressing ConditionType = "Progressing"
+
+	// ConditionFilesystemConsistent is the "filesystemConsistent" condition type,
+	// it is set on snapshots of running VMs and is true if the guest filesystems
+	// stayed frozen through the guest agent until every volume snapshot was taken
+	ConditionFilesystemConsistent ConditionType = "FilesystemConsistent"
 )
 
 // Condition defines conditions
@@ -245,6 +250,10 @@ type VirtualMachineRestoreSpec struct {
 	Target corev1.TypedLocalObjectReference `json:"target"`
 
 	VirtualMachineSnapshotName string `json:"virtualMachineSnapshotName"`
+
+	// Patches are JSON patches applied to the restored VirtualMachine, only allowed for the restore of a VirtualMachineClone and limited to its labels, annotations and template
+	// +optional
+	Patches []string `json:"patches,omitempty"`
 }
 
 // VirtualMachineRestoreStatus is the spec for a VirtualMachineRestoreresource
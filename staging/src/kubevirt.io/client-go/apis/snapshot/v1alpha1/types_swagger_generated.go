@@ -120,8 +120,9 @@ func (VirtualMachineRestore) SwaggerDoc() map[string]string {
 
 func (VirtualMachineRestoreSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":       "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource",
-		"target": "initially only VirtualMachine type supported",
+		"":        "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource",
+		"target":  "initially only VirtualMachine type supported",
+		"patches": "Patches are JSON patches applied to the restored VirtualMachine, only allowed for the restore of a VirtualMachineClone and limited to its labels, annotations and template\n+optional",
 	}
 }
 
@@ -27,6 +27,7 @@ package kubecli
 
 import (
 	"io"
+	"time"
 
 	secv1 "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1"
 	autov1 "k8s.io/api/autoscaling/v1"
@@ -42,6 +43,10 @@ import (
 	cdiclient "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned"
 	k8ssnapshotclient "kubevirt.io/client-go/generated/external-snapshotter/clientset/versioned"
 	generatedclient "kubevirt.io/client-go/generated/kubevirt/clientset/versioned"
+	backupv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/backup/v1alpha1"
+	clonev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/clone/v1alpha1"
+	exportv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/export/v1alpha1"
+	instancetypev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/instancetype/v1alpha1"
 	vmsnapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
 	networkclient "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned"
 	promclient "kubevirt.io/client-go/generated/prometheus-operator/clientset/versioned"
@@ -57,6 +62,13 @@ type KubevirtClient interface {
 	VirtualMachineSnapshot(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotInterface
 	VirtualMachineSnapshotContent(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotContentInterface
 	VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreInterface
+	VirtualMachineInstancetype(namespace string) instancetypev1alpha1.VirtualMachineInstancetypeInterface
+	VirtualMachineClusterInstancetype() instancetypev1alpha1.VirtualMachineClusterInstancetypeInterface
+	VirtualMachinePreference(namespace string) instancetypev1alpha1.VirtualMachinePreferenceInterface
+	VirtualMachineClusterPreference() instancetypev1alpha1.VirtualMachineClusterPreferenceInterface
+	VirtualMachineExport(namespace string) exportv1alpha1.VirtualMachineExportInterface
+	VirtualMachineClone(namespace string) clonev1alpha1.VirtualMachineCloneInterface
+	VirtualMachineBackup(namespace string) backupv1alpha1.VirtualMachineBackupInterface
 	ServerVersion() *ServerVersion
 	RestClient() *rest.RESTClient
 	GeneratedKubeVirtClient() generatedclient.Interface
@@ -137,6 +149,34 @@ func (k kubevirt) VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.Vir
 	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineRestores(namespace)
 }
 
+func (k kubevirt) VirtualMachineInstancetype(namespace string) instancetypev1alpha1.VirtualMachineInstancetypeInterface {
+	return k.generatedKubeVirtClient.InstancetypeV1alpha1().VirtualMachineInstancetypes(namespace)
+}
+
+func (k kubevirt) VirtualMachineClusterInstancetype() instancetypev1alpha1.VirtualMachineClusterInstancetypeInterface {
+	return k.generatedKubeVirtClient.InstancetypeV1alpha1().VirtualMachineClusterInstancetypes()
+}
+
+func (k kubevirt) VirtualMachinePreference(namespace string) instancetypev1alpha1.VirtualMachinePreferenceInterface {
+	return k.generatedKubeVirtClient.InstancetypeV1alpha1().VirtualMachinePreferences(namespace)
+}
+
+func (k kubevirt) VirtualMachineClusterPreference() instancetypev1alpha1.VirtualMachineClusterPreferenceInterface {
+	return k.generatedKubeVirtClient.InstancetypeV1alpha1().VirtualMachineClusterPreferences()
+}
+
+func (k kubevirt) VirtualMachineExport(namespace string) exportv1alpha1.VirtualMachineExportInterface {
+	return k.generatedKubeVirtClient.ExportV1alpha1().VirtualMachineExports(namespace)
+}
+
+func (k kubevirt) VirtualMachineClone(namespace string) clonev1alpha1.VirtualMachineCloneInterface {
+	return k.generatedKubeVirtClient.CloneV1alpha1().VirtualMachineClones(namespace)
+}
+
+func (k kubevirt) VirtualMachineBackup(namespace string) backupv1alpha1.VirtualMachineBackupInterface {
+	return k.generatedKubeVirtClient.BackupV1alpha1().VirtualMachineBackups(namespace)
+}
+
 func (k kubevirt) KubernetesSnapshotClient() k8ssnapshotclient.Interface {
 	return k.snapshotClient
 }
@@ -163,13 +203,25 @@ type VirtualMachineInstanceInterface interface {
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineInstance, err error)
 	SerialConsole(name string, options *SerialConsoleOptions) (StreamInterface, error)
 	VNC(name string) (StreamInterface, error)
+	PortForward(name string, port int) (StreamInterface, error)
 	Pause(name string) error
 	Unpause(name string) error
+	Freeze(name string, unfreezeTimeout time.Duration) error
+	Unfreeze(name string) error
+	Backup(name string, backupOptions *v1.BackupOptions) error
+	EndBackup(name string) error
+	BackupNBD(name string) (StreamInterface, error)
 	GuestOsInfo(name string) (v1.VirtualMachineInstanceGuestAgentInfo, error)
 	UserList(name string) (v1.VirtualMachineInstanceGuestOSUserList, error)
 	FilesystemList(name string) (v1.VirtualMachineInstanceFileSystemList, error)
 	AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
+	AddInterface(name string, addInterfaceOptions *v1.AddInterfaceOptions) error
+	RemoveInterface(name string, removeInterfaceOptions *v1.RemoveInterfaceOptions) error
+	SetLinkState(name string, setLinkStateOptions *v1.SetLinkStateOptions) error
+	SetIOTune(name string, setIOTuneOptions *v1.SetIOTuneOptions) error
+	InjectMedia(name string, injectMediaOptions *v1.InjectMediaOptions) error
+	EjectMedia(name string, ejectMediaOptions *v1.EjectMediaOptions) error
 }
 
 type ReplicaSetInterface interface {
@@ -213,6 +265,8 @@ type VirtualMachineInterface interface {
 	Rename(name string, options *v1.RenameOptions) error
 	AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
+	AddInterface(name string, addInterfaceOptions *v1.AddInterfaceOptions) error
+	RemoveInterface(name string, removeInterfaceOptions *v1.RemoveInterfaceOptions) error
 }
 
 type VirtualMachineInstanceMigrationInterface interface {
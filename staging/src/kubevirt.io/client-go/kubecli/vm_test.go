@@ -187,6 +187,33 @@ var _ = Describe("Kubevirt VirtualMachine Client", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should add an interface to a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/addinterface"),
+			ghttp.VerifyBody([]byte(`{"networkAttachmentDefinitionName":"blue-net","name":"blue"}`)),
+			ghttp.RespondWith(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).AddInterface("testvm", &virtv1.AddInterfaceOptions{
+			NetworkAttachmentDefinitionName: "blue-net",
+			Name:                            "blue",
+		})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should remove an interface from a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/removeinterface"),
+			ghttp.VerifyBody([]byte(`{"name":"blue"}`)),
+			ghttp.RespondWith(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).RemoveInterface("testvm", &virtv1.RemoveInterfaceOptions{Name: "blue"})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	It("should rename a VM", func() {
 		server.AppendHandlers(
 			ghttp.CombineHandlers(
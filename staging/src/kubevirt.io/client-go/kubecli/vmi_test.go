@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 	. "github.com/onsi/ginkgo"
@@ -157,6 +158,22 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("should connect a stream to a port of the VM", func() {
+		portForwardPath := subVMPath + "/portforward/22"
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", portForwardPath),
+			func(w http.ResponseWriter, r *http.Request) {
+				_, err := upgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+			},
+		))
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).PortForward("testvm", 22)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	It("should exchange data with the VM", func() {
 		vncPath := subVMPath + "/vnc"
 
@@ -241,6 +258,80 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should set the link state of a VirtualMachineInstance interface", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/setlinkstate"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).SetLinkState("testvm", &v1.SetLinkStateOptions{Name: "default", LinkState: v1.InterfaceLinkStateDown})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should set the I/O limits of a VirtualMachineInstance disk", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/setiotune"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).SetIOTune("testvm", &v1.SetIOTuneOptions{Name: "rootdisk", IOTune: &v1.DiskIOTune{TotalIOPSSec: 100}})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should inject media into a CD-ROM of a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/injectmedia"),
+			ghttp.VerifyBody([]byte(`{"name":"cdrom","volumeSource":{"persistentVolumeClaim":{"claimName":"virtio-win"}}}`)),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).InjectMedia("testvm", &v1.InjectMediaOptions{
+			Name: "cdrom",
+			VolumeSource: &v1.HotplugVolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "virtio-win"},
+			},
+		})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should eject the media of a CD-ROM of a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/ejectmedia"),
+			ghttp.VerifyBody([]byte(`{"name":"cdrom"}`)),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).EjectMedia("testvm", &v1.EjectMediaOptions{Name: "cdrom"})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should freeze a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/freeze"),
+			ghttp.VerifyBody([]byte(`{"unfreezeTimeout":"1m0s"}`)),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Freeze("testvm", time.Minute)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should unfreeze a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/unfreeze"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Unfreeze("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	It("should fetch GuestOSInfo from VirtualMachineInstance via subresource", func() {
 		osInfo := v1.VirtualMachineInstanceGuestAgentInfo{
 			GAVersion: "4.1.1",
@@ -306,6 +306,7 @@ var _ = Describe("[rfe_id:500][crit:high][vendor:cnv-qe@redhat.com][level:compon
 				table.Entry("[test_id:2921]given a vmi", "virtualmachineinstances/unpause", "update"),
 				table.Entry("[test_id:2921]given a vmi", "virtualmachineinstances/console", "get"),
 				table.Entry("[test_id:2921]given a vmi", "virtualmachineinstances/vnc", "get"),
+				table.Entry("given a vmi", "virtualmachineinstances/portforward", "get"),
 			)
 		})
 
@@ -349,6 +350,7 @@ var _ = Describe("[rfe_id:500][crit:high][vendor:cnv-qe@redhat.com][level:compon
 				table.Entry("[test_id:2921]given a vmi", "virtualmachineinstances/unpause", "update"),
 				table.Entry("[test_id:2921]given a vmi", "virtualmachineinstances/console", "get"),
 				table.Entry("[test_id:2921]given a vmi", "virtualmachineinstances/vnc", "get"),
+				table.Entry("given a vmi", "virtualmachineinstances/portforward", "get"),
 			)
 		})
 	})
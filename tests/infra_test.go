@@ -92,7 +92,9 @@ var _ = Describe("[Serial][owner:@sig-compute]Infrastructure", func() {
 		It("[test_id:5177]Should have structural schema", func() {
 			ourCRDs := []string{crds.VIRTUALMACHINE, crds.VIRTUALMACHINEINSTANCE, crds.VIRTUALMACHINEINSTANCEPRESET,
 				crds.VIRTUALMACHINEINSTANCEREPLICASET, crds.VIRTUALMACHINEINSTANCEMIGRATION, crds.KUBEVIRT,
-				crds.VIRTUALMACHINESNAPSHOT, crds.VIRTUALMACHINESNAPSHOTCONTENT,
+				crds.VIRTUALMACHINESNAPSHOT, crds.VIRTUALMACHINESNAPSHOTCONTENT, crds.VIRTUALMACHINEINSTANCETYPE,
+				crds.VIRTUALMACHINECLUSTERINSTANCETYPE, crds.VIRTUALMACHINEPREFERENCE, crds.VIRTUALMACHINECLUSTERPREFERENCE,
+				crds.VIRTUALMACHINEEXPORT, crds.VIRTUALMACHINECLONE, crds.VIRTUALMACHINEBACKUP,
 			}
 
 			for _, name := range ourCRDs {